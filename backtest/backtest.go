@@ -0,0 +1,103 @@
+package backtest
+
+import (
+	"time"
+
+	"github.com/svanas/nefertiti/model"
+)
+
+// a backtest replays the candles of one market through the strategy of the bot: a limit buy at --dip percent below
+// the close of the previous candle, then a limit sell at --mult times what we paid, or a stop-loss at --stop times
+// what we paid. we hold one position at a time.
+
+type (
+	Params struct {
+		Dip  float64 `json:"dip"`            // in percent, for example: 5
+		Mult float64 `json:"mult"`           // for example: 1.05
+		Stop float64 `json:"stop,omitempty"` // for example: 0.9 (0 = no stop-loss)
+	}
+	Trade struct {
+		Entry   time.Time `json:"entry"`
+		Exit    time.Time `json:"exit"`
+		Buy     float64   `json:"buy"`
+		Sell    float64   `json:"sell"`
+		Return  float64   `json:"return"` // after fees, for example: 0.04 is a 4% profit
+		Stopped bool      `json:"stopped,omitempty"`
+		Open    bool      `json:"open,omitempty"` // still open at the end of the candles, valued at the last close
+	}
+	Result struct {
+		Params      Params  `json:"params"`
+		Trades      []Trade `json:"-"`
+		Count       int     `json:"trades"`
+		Wins        int     `json:"wins"`
+		Return      float64 `json:"return"`       // compounded, for example: 0.25 is a 25% profit
+		MaxDrawdown float64 `json:"max_drawdown"` // of the compounded equity, for example: 0.1 is 10%
+	}
+)
+
+// Run replays the candles (oldest first) with these params. fee is per side, for example: 0.001 is 0.1%.
+func Run(candles model.Candles, params Params, fee float64) Result {
+	out := Result{Params: params}
+
+	var (
+		inside bool // do we hold a position?
+		trade  Trade
+	)
+	for i := 1; i < len(candles); i++ {
+		candle := candles[i]
+		if !inside {
+			limit := candles[i-1].Close * (1 - params.Dip/100)
+			if limit > 0 && candle.Low <= limit {
+				inside = true
+				trade = Trade{Entry: candle.Time, Buy: limit}
+			}
+			// we never sell during the candle that we bought in, because we cannot tell which came first
+			continue
+		}
+		// if a candle hits both our stop and our target, we assume the worst
+		if params.Stop > 0 && candle.Low <= trade.Buy*params.Stop {
+			trade.Sell = trade.Buy * params.Stop
+			trade.Stopped = true
+		} else if candle.High >= trade.Buy*params.Mult {
+			trade.Sell = trade.Buy * params.Mult
+		} else {
+			continue
+		}
+		trade.Exit = candle.Time
+		out.add(trade, fee)
+		inside = false
+	}
+
+	if inside && len(candles) > 0 {
+		last := candles[len(candles)-1]
+		trade.Exit = last.Time
+		trade.Sell = last.Close
+		trade.Open = true
+		out.add(trade, fee)
+	}
+
+	return out
+}
+
+func (result *Result) add(trade Trade, fee float64) {
+	trade.Return = (trade.Sell*(1-fee))/(trade.Buy*(1+fee)) - 1
+	result.Trades = append(result.Trades, trade)
+	result.Count++
+	if trade.Return > 0 {
+		result.Wins++
+	}
+
+	equity := 1.0
+	peak := 1.0
+	result.MaxDrawdown = 0
+	for _, t := range result.Trades {
+		equity *= 1 + t.Return
+		if equity > peak {
+			peak = equity
+		}
+		if drawdown := (peak - equity) / peak; drawdown > result.MaxDrawdown {
+			result.MaxDrawdown = drawdown
+		}
+	}
+	result.Return = equity - 1
+}
@@ -0,0 +1,129 @@
+package backtest
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/model"
+)
+
+type (
+	// Range is min:max:step, for example: 1.02:1.1:0.01
+	Range struct {
+		Min  float64
+		Max  float64
+		Step float64
+	}
+	// Fold is one step of a walk-forward: the best params on the training window, and how they did on the window
+	// right after it, which the optimizer never saw.
+	Fold struct {
+		Train  time.Time `json:"train"` // the first candle of the training window
+		Test   time.Time `json:"test"`  // the first candle of the test window
+		Best   Result    `json:"best"`
+		Tested Result    `json:"tested"`
+	}
+	Optimized struct {
+		Params Params  `json:"params"` // the best params on the most recent window
+		Folds  []Fold  `json:"folds"`
+		Return float64 `json:"out_of_sample_return"` // compounded over the test windows
+	}
+)
+
+func ParseRange(name, value string) (*Range, error) {
+	parts := strings.Split(value, ":")
+	out := &Range{}
+	var err error
+	if out.Min, err = strconv.ParseFloat(parts[0], 64); err != nil {
+		return nil, errors.Errorf("%s %s is invalid", name, value)
+	}
+	out.Max = out.Min
+	if len(parts) == 1 {
+		return out, nil
+	}
+	if len(parts) != 3 {
+		return nil, errors.Errorf("%s %s is invalid. expected min:max:step", name, value)
+	}
+	if out.Max, err = strconv.ParseFloat(parts[1], 64); err != nil {
+		return nil, errors.Errorf("%s %s is invalid", name, value)
+	}
+	if out.Step, err = strconv.ParseFloat(parts[2], 64); err != nil || out.Step <= 0 || out.Max < out.Min {
+		return nil, errors.Errorf("%s %s is invalid", name, value)
+	}
+	return out, nil
+}
+
+func (r *Range) Values() []float64 {
+	if r.Step <= 0 {
+		return []float64{r.Min}
+	}
+	var out []float64
+	for i := 0; ; i++ {
+		// multiply (rather than add) so that we don't accumulate rounding errors
+		value := math.Round((r.Min+float64(i)*r.Step)*1e8) / 1e8
+		if value > r.Max+1e-9 {
+			break
+		}
+		out = append(out, value)
+	}
+	return out
+}
+
+// Sweep runs a backtest for every combination of params, and returns the one with the highest return
+func Sweep(candles model.Candles, dips, mults, stops []float64, fee float64) Result {
+	var best *Result
+	for _, dip := range dips {
+		for _, mult := range mults {
+			for _, stop := range stops {
+				result := Run(candles, Params{Dip: dip, Mult: mult, Stop: stop}, fee)
+				if best == nil || result.Return > best.Return || (result.Return == best.Return && result.MaxDrawdown < best.MaxDrawdown) {
+					best = &result
+				}
+			}
+		}
+	}
+	if best == nil {
+		return Result{}
+	}
+	return *best
+}
+
+// WalkForward splits the candles into folds+1 windows. for every window but the last, we sweep the params on it,
+// then test the best of them on the next window. the params we recommend are the best on the last window.
+func WalkForward(candles model.Candles, dips, mults, stops []float64, folds int, fee float64) (*Optimized, error) {
+	if folds < 1 {
+		return nil, errors.Errorf("folds %d is invalid", folds)
+	}
+	size := len(candles) / (folds + 1)
+	if size < 2 {
+		return nil, errors.Errorf("%d candles are not enough for %d folds", len(candles), folds)
+	}
+
+	window := func(i int) model.Candles {
+		if i == folds {
+			return candles[i*size:]
+		}
+		return candles[i*size : (i+1)*size]
+	}
+
+	out := &Optimized{}
+	equity := 1.0
+	for i := 0; i < folds; i++ {
+		train, test := window(i), window(i+1)
+		best := Sweep(train, dips, mults, stops, fee)
+		tested := Run(test, best.Params, fee)
+		equity *= 1 + tested.Return
+		out.Folds = append(out.Folds, Fold{
+			Train:  train[0].Time,
+			Test:   test[0].Time,
+			Best:   best,
+			Tested: tested,
+		})
+	}
+	out.Return = equity - 1
+	out.Params = Sweep(window(folds), dips, mults, stops, fee).Params
+
+	return out, nil
+}
@@ -0,0 +1,114 @@
+package backtest
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// a Monte Carlo risk simulation resamples the days of our history (with replacement) into many possible futures,
+// and looks at how deep the drawdowns go, and how often we would have tripped the kill switch (--max-daily-loss).
+
+type (
+	// Day is the realized profit and loss of one day, in quote currency
+	Day struct {
+		Net    float64 // profits minus losses
+		Losses float64 // the sum of the losses, as a positive number
+	}
+	Percentiles struct {
+		P50 float64 `json:"p50"`
+		P90 float64 `json:"p90"`
+		P95 float64 `json:"p95"`
+		P99 float64 `json:"p99"`
+	}
+	Risk struct {
+		History      int         `json:"history"` // the number of days we resampled from
+		Days         int         `json:"days"`    // the length of every path
+		Paths        int         `json:"paths"`
+		Drawdown     Percentiles `json:"drawdown"` // the max drawdown of a path, in quote currency
+		Net          Percentiles `json:"net"`      // the realized PnL at the end of a path, in quote currency
+		MaxDailyLoss float64     `json:"max_daily_loss,omitempty"`
+		// the probability that a path hits the max daily loss at least once, and that one day does
+		HitLimit    float64 `json:"hit_limit"`
+		HitLimitDay float64 `json:"hit_limit_per_day"`
+	}
+)
+
+// Daily groups outcomes into days, from the first to the last, including the days without outcomes
+func Daily(times []time.Time, amounts []float64) []Day {
+	if len(times) == 0 {
+		return nil
+	}
+	first, last := times[0], times[0]
+	for _, t := range times {
+		if t.Before(first) {
+			first = t
+		}
+		if t.After(last) {
+			last = t
+		}
+	}
+	first = first.UTC().Truncate(24 * time.Hour)
+	out := make([]Day, int(last.UTC().Sub(first)/(24*time.Hour))+1)
+	for i, t := range times {
+		day := &out[int(t.UTC().Sub(first)/(24*time.Hour))]
+		day.Net += amounts[i]
+		if amounts[i] < 0 {
+			day.Losses -= amounts[i]
+		}
+	}
+	return out
+}
+
+func percentiles(values []float64) Percentiles {
+	if len(values) == 0 {
+		return Percentiles{}
+	}
+	sort.Float64s(values)
+	at := func(p float64) float64 {
+		return values[int(p*float64(len(values)-1))]
+	}
+	return Percentiles{P50: at(0.5), P90: at(0.9), P95: at(0.95), P99: at(0.99)}
+}
+
+// MonteCarlo resamples history into paths of so many days. limit is the max daily loss (0 = no limit).
+func MonteCarlo(history []Day, days, paths int, limit float64, rnd *rand.Rand) Risk {
+	out := Risk{History: len(history), Days: days, Paths: paths, MaxDailyLoss: limit}
+	if len(history) == 0 || days <= 0 || paths <= 0 {
+		return out
+	}
+
+	drawdowns := make([]float64, paths)
+	nets := make([]float64, paths)
+	var hits, hitDays int
+	for p := 0; p < paths; p++ {
+		var equity, peak, drawdown float64
+		hit := false
+		for d := 0; d < days; d++ {
+			day := history[rnd.Intn(len(history))]
+			equity += day.Net
+			if equity > peak {
+				peak = equity
+			}
+			if peak-equity > drawdown {
+				drawdown = peak - equity
+			}
+			if limit > 0 && day.Losses > limit {
+				hit = true
+				hitDays++
+			}
+		}
+		if hit {
+			hits++
+		}
+		drawdowns[p] = drawdown
+		nets[p] = equity
+	}
+
+	out.Drawdown = percentiles(drawdowns)
+	out.Net = percentiles(nets)
+	out.HitLimit = float64(hits) / float64(paths)
+	out.HitLimitDay = float64(hitDays) / float64(paths*days)
+
+	return out
+}
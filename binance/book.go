@@ -11,7 +11,7 @@ import (
 type BookEntry = common.PriceLevel
 
 func (self *Client) Depth(symbol string, limit int) (*exchange.DepthResponse, error) {
-	defer AfterRequest()
+	defer AfterRequest(self)
 	if limit < 500 {
 		BeforeRequest(self, 1)
 	} else if limit < 1000 {
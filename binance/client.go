@@ -4,9 +4,13 @@ package binance
 import (
 	"context"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	exchange "github.com/adshao/go-binance/v2"
+	"github.com/svanas/nefertiti/clock"
+	"github.com/svanas/nefertiti/ratelimit"
 )
 
 const (
@@ -28,7 +32,7 @@ func (self *Client) Orders(symbol string) ([]Order, error) {
 		orders []*exchange.Order
 		output []Order
 	)
-	defer AfterRequest()
+	defer AfterRequest(self)
 	BeforeRequest(self, WEIGHT_ALL_ORDERS)
 	if orders, err = self.inner.NewListOrdersService().Symbol(symbol).Do(context.Background()); err != nil {
 		self.handleError(err)
@@ -51,7 +55,7 @@ func (self *Client) OpenOrders() ([]Order, error) {
 		orders []*exchange.Order
 		output []Order
 	)
-	defer AfterRequest()
+	defer AfterRequest(self)
 	BeforeRequest(self, WEIGHT_OPEN_ORDERS_WITHOUT_SYMBOL)
 	if orders, err = self.inner.NewListOpenOrdersService().Do(context.Background()); err != nil {
 		self.handleError(err)
@@ -74,7 +78,7 @@ func (self *Client) OpenOrdersEx(symbol string) ([]Order, error) {
 		orders []*exchange.Order
 		output []Order
 	)
-	defer AfterRequest()
+	defer AfterRequest(self)
 	BeforeRequest(self, WEIGHT_OPEN_ORDERS_WITH_SYMBOL)
 	if orders, err = self.inner.NewListOpenOrdersService().Symbol(symbol).Do(context.Background()); err != nil {
 		self.handleError(err)
@@ -90,9 +94,21 @@ func (self *Client) OpenOrdersEx(symbol string) ([]Order, error) {
 	return output, nil
 }
 
+// Check an order's status.
+func (self *Client) GetOrder(symbol string, orderID int64) (*Order, error) {
+	defer AfterRequest(self)
+	BeforeRequest(self, WEIGHT_QUERY_ORDER)
+	order, err := self.inner.NewGetOrderService().Symbol(symbol).OrderID(orderID).Do(context.Background())
+	if err != nil {
+		self.handleError(err)
+		return nil, err
+	}
+	return wrap(order)
+}
+
 // Cancel an active order.
 func (self *Client) CancelOrder(symbol string, orderID int64) error {
-	defer AfterRequest()
+	defer AfterRequest(self)
 	BeforeRequest(self, WEIGHT_CANCEL_ORDER)
 	_, err := self.inner.NewCancelOrderService().Symbol(symbol).OrderID(orderID).Do(context.Background())
 	self.handleError(err)
@@ -107,6 +123,25 @@ func (self *Client) NewCreateOCOService() *CreateOCOService {
 	return &CreateOCOService{client: self, inner: self.inner.NewCreateOCOService()}
 }
 
+// Domain returns the registered domain of the endpoint, for example binance.com or binance.us. The clusters of an
+// exchange share the same domain (and the same rate limits and symbols).
+func (self *Client) Domain() string {
+	return Domain(self.inner.BaseURL)
+}
+
+// Domain returns the domain of a base URL, for example: binance.com or binance.us
+func Domain(baseURL string) string {
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Hostname() == "" {
+		return baseURL
+	}
+	labels := strings.Split(u.Hostname(), ".")
+	if len(labels) > 2 {
+		labels = labels[len(labels)-2:]
+	}
+	return strings.Join(labels, ".")
+}
+
 func (self *Client) handleError(err error) {
 	if err == nil {
 		return
@@ -120,6 +155,10 @@ func (self *Client) handleError(err error) {
 				SERVER_TIME_UPDATE = time.Now()
 			}
 		}
+		if binanceError.Code == -1003 && OnRateLimit != nil {
+			// Too many requests; current limit is %s requests per minute.
+			OnRateLimit(self)
+		}
 	}
 }
 
@@ -136,14 +175,21 @@ func New(baseURL, apiKey, apiSecret string) *Client {
 		Timeout: 30 * time.Second,
 	}
 
+	out := &Client{inner: client}
+	client.HTTPClient.Transport = &weightTransport{
+		domain: out.Domain(),
+		inner:  ratelimit.NewTransport(out.Domain(), nil),
+	}
+
 	if SERVER_TIME_OFFSET == 0 || time.Since(SERVER_TIME_UPDATE).Minutes() > 15 {
 		if offset, err := client.NewSetServerTimeService().Do(context.Background()); err == nil {
 			SERVER_TIME_OFFSET = offset
 			SERVER_TIME_UPDATE = time.Now()
+			clock.Warn("Binance", time.Duration(offset)*time.Millisecond)
 		}
 	} else {
 		client.TimeOffset = SERVER_TIME_OFFSET
 	}
 
-	return &Client{inner: client}
+	return out
 }
@@ -0,0 +1,27 @@
+//lint:file-ignore ST1006 receiver name should be a reflection of its identity; don't use generic names such as "this" or "self"
+package binance
+
+import (
+	"context"
+)
+
+type Fees struct {
+	Maker float64
+	Taker float64
+}
+
+// GetFees returns your (account-specific) commission rates. Binance returns them in basis points, for example: 10 is
+// 0.1%
+func (self *Client) GetFees() (*Fees, error) {
+	defer AfterRequest(self)
+	BeforeRequest(self, WEIGHT_ACCOUNT)
+	account, err := self.inner.NewGetAccountService().Do(context.Background())
+	if err != nil {
+		self.handleError(err)
+		return nil, err
+	}
+	return &Fees{
+		Maker: float64(account.MakerCommission) / 10000,
+		Taker: float64(account.TakerCommission) / 10000,
+	}, nil
+}
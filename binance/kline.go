@@ -0,0 +1,28 @@
+//lint:file-ignore ST1006 receiver name should be a reflection of its identity; don't use generic names such as "this" or "self"
+package binance
+
+import (
+	"context"
+
+	exchange "github.com/adshao/go-binance/v2"
+)
+
+const KLINES_LIMIT = 1000
+
+// Kline/candlestick bars for a symbol, opened in [start, end]. start and end are in milliseconds.
+func (self *Client) Klines(symbol, interval string, start, end int64) ([]*exchange.Kline, error) {
+	defer AfterRequest(self)
+	BeforeRequest(self, WEIGHT_KLINES)
+	out, err := self.inner.NewKlinesService().
+		Symbol(symbol).
+		Interval(interval).
+		StartTime(start).
+		EndTime(end).
+		Limit(KLINES_LIMIT).
+		Do(context.Background())
+	if err != nil {
+		self.handleError(err)
+		return nil, err
+	}
+	return out, nil
+}
@@ -4,6 +4,8 @@ package binance
 import (
 	"context"
 	"encoding/json"
+	"net/http"
+	"net/url"
 	"strconv"
 	"time"
 
@@ -58,7 +60,7 @@ func (self *CreateOrderService) StopPrice(stopPrice float64) *CreateOrderService
 }
 
 func (self *CreateOrderService) Do(ctx context.Context, opts ...exchange.RequestOption) (*exchange.CreateOrderResponse, error) {
-	defer AfterRequest()
+	defer AfterRequest(self.client)
 	BeforeRequest(self.client, WEIGHT_CREATE_ORDER)
 	res, err := self.inner.Do(ctx, opts...)
 	self.client.handleError(err)
@@ -118,7 +120,7 @@ func (self *CreateOCOService) StopLimitTimeInForce(stopLimitTimeInForce exchange
 }
 
 func (self *CreateOCOService) Do(ctx context.Context, opts ...exchange.RequestOption) (*exchange.CreateOCOResponse, error) {
-	defer AfterRequest()
+	defer AfterRequest(self.client)
 	BeforeRequest(self.client, WEIGHT_CREATE_OCO_ORDER)
 	res, err := self.inner.Do(ctx, opts...)
 	self.client.handleError(err)
@@ -177,6 +179,15 @@ func (self *Order) GetSize() float64 {
 	return 0
 }
 
+// GetExecutedSize returns the ExecutedQuantity as float64
+func (self *Order) GetExecutedSize() float64 {
+	out, err := strconv.ParseFloat(self.ExecutedQuantity, 64)
+	if err == nil {
+		return out
+	}
+	return 0
+}
+
 // GetStopPrice returns the StopPrice as float64
 func (self *Order) GetStopPrice() float64 {
 	out, err := strconv.ParseFloat(self.StopPrice, 64)
@@ -218,3 +229,37 @@ func wrap(input *exchange.Order) (*Order, error) {
 	}
 	return &out, nil
 }
+
+//------------------------------- TrailingStop --------------------------------
+
+// TrailingStop opens a sell order that trails the price by delta BIPs (basis points, 100 = 1%). a zero price opens a
+// STOP_LOSS, otherwise a STOP_LOSS_LIMIT at that price. our library is older than trailingDelta, so we sign the
+// request ourselves.
+func (self *Client) TrailingStop(symbol string, quantity float64, delta int64, price float64, clientOrderID string) (*exchange.CreateOrderResponse, error) {
+	query := url.Values{}
+	query.Set("symbol", symbol)
+	query.Set("side", string(exchange.SideTypeSell))
+	query.Set("quantity", strconv.FormatFloat(quantity, 'f', -1, 64))
+	query.Set("trailingDelta", strconv.FormatInt(delta, 10))
+	if price == 0 {
+		query.Set("type", string(exchange.OrderTypeStopLoss))
+	} else {
+		query.Set("type", string(exchange.OrderTypeStopLossLimit))
+		query.Set("timeInForce", string(exchange.TimeInForceTypeGTC))
+		query.Set("price", strconv.FormatFloat(price, 'f', -1, 64))
+	}
+	if clientOrderID != "" {
+		query.Set("newClientOrderId", clientOrderID)
+	}
+
+	body, err := self.signed(http.MethodPost, "/api/v3/order", query, WEIGHT_CREATE_ORDER)
+	if err != nil {
+		return nil, err
+	}
+
+	var out exchange.CreateOrderResponse
+	if err = json.Unmarshal(body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"sync"
 	"time"
 
 	exchange "github.com/adshao/go-binance/v2"
@@ -43,12 +44,16 @@ type Cache struct {
 	updatedAt time.Time
 }
 
-var cache *Cache
+// one cache per domain, because Binance and Binance.US list different symbols
+var (
+	cache      = make(map[string]*Cache)
+	cacheMutex sync.Mutex
+)
 
 func getPrecs(client *Client) (Precs, error) {
 	var out Precs
 
-	defer AfterRequest()
+	defer AfterRequest(client)
 	BeforeRequest(client, WEIGHT_EXCHANGE_INFO)
 
 	info, err := client.inner.NewExchangeInfoService().Do(context.Background())
@@ -92,17 +97,23 @@ func getPrecs(client *Client) (Precs, error) {
 }
 
 func GetPrecs(client *Client, cached bool) (Precs, error) {
-	if cache == nil || !cached || time.Since(cache.updatedAt).Minutes() > 15 {
+	cacheMutex.Lock()
+	entry, ok := cache[client.Domain()]
+	cacheMutex.Unlock()
+	if !ok || !cached || time.Since(entry.updatedAt).Minutes() > 15 {
 		latest, err := getPrecs(client)
 		if err != nil {
 			return nil, err
 		}
-		cache = &Cache{
+		entry = &Cache{
 			precs:     latest,
 			updatedAt: time.Now(),
 		}
+		cacheMutex.Lock()
+		cache[client.Domain()] = entry
+		cacheMutex.Unlock()
 	}
-	return cache.precs, nil
+	return entry.precs, nil
 }
 
 func GetSymbol(client *Client, name string) (*exchange.Symbol, error) {
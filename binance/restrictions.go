@@ -0,0 +1,37 @@
+//lint:file-ignore ST1006 receiver name should be a reflection of its identity; don't use generic names such as "this" or "self"
+package binance
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+// ApiRestrictions tells you what your API key is allowed to do
+type ApiRestrictions struct {
+	IpRestrict                     bool  `json:"ipRestrict"`
+	CreateTime                     int64 `json:"createTime"`
+	EnableReading                  bool  `json:"enableReading"`
+	EnableWithdrawals              bool  `json:"enableWithdrawals"`
+	EnableInternalTransfer         bool  `json:"enableInternalTransfer"`
+	EnableMargin                   bool  `json:"enableMargin"`
+	EnableFutures                  bool  `json:"enableFutures"`
+	PermitsUniversalTransfer       bool  `json:"permitsUniversalTransfer"`
+	EnableVanillaOptions           bool  `json:"enableVanillaOptions"`
+	EnableSpotAndMarginTrading     bool  `json:"enableSpotAndMarginTrading"`
+	TradingAuthorityExpirationTime int64 `json:"tradingAuthorityExpirationTime,omitempty"`
+}
+
+// GetApiRestrictions returns the permissions of your API key. the endpoint is newer than our library, so we sign the
+// request ourselves.
+func (self *Client) GetApiRestrictions() (*ApiRestrictions, error) {
+	body, err := self.signed(http.MethodGet, "/sapi/v1/account/apiRestrictions", url.Values{}, WEIGHT_API_RESTRICTIONS)
+	if err != nil {
+		return nil, err
+	}
+	var out ApiRestrictions
+	if err = json.Unmarshal(body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
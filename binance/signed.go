@@ -0,0 +1,58 @@
+//lint:file-ignore ST1006 receiver name should be a reflection of its identity; don't use generic names such as "this" or "self"
+package binance
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+// signed sends a signed request to an endpoint (or with a parameter) that is newer than our library
+func (self *Client) signed(method, path string, query url.Values, weight int) ([]byte, error) {
+	defer AfterRequest(self)
+	BeforeRequest(self, weight)
+
+	query.Set("timestamp", strconv.FormatInt(time.Now().UnixNano()/int64(time.Millisecond)-self.inner.TimeOffset, 10))
+	mac := hmac.New(sha256.New, []byte(self.inner.SecretKey))
+	if _, err := mac.Write([]byte(query.Encode())); err != nil {
+		return nil, err
+	}
+
+	// the signature goes last, after the parameters that it signs
+	req, err := http.NewRequest(method, fmt.Sprintf("%s%s?%s&signature=%s", self.inner.BaseURL, path, query.Encode(), hex.EncodeToString(mac.Sum(nil))), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-MBX-APIKEY", self.inner.APIKey)
+
+	resp, err := self.inner.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		apiError := new(common.APIError)
+		if json.Unmarshal(body, apiError) != nil || apiError.Code == 0 {
+			return nil, fmt.Errorf("%s %s", resp.Status, string(body))
+		}
+		self.handleError(apiError)
+		return nil, apiError
+	}
+
+	return body, nil
+}
@@ -2,6 +2,9 @@ package binance
 
 import (
 	"context"
+	"net/http"
+	"strconv"
+	"sync"
 	"time"
 
 	exchange "github.com/adshao/go-binance/v2"
@@ -10,9 +13,11 @@ import (
 var (
 	lastRequest       time.Time
 	lastWeight        int                                    = 1
-	requestsPerSecond float64                                = 0
+	requestsPerSecond map[string]float64                     = make(map[string]float64) // per domain
+	throttleMutex     sync.Mutex                                                        // guards the above
 	BeforeRequest     func(client *Client, weight int) error = nil
-	AfterRequest      func()                                 = nil
+	AfterRequest      func(client *Client)                   = nil
+	OnRateLimit       func(client *Client)                   = nil
 )
 
 func getIntervalNum(rl exchange.RateLimit) int64 {
@@ -39,22 +44,40 @@ func getRequestsPerSecond(info *exchange.ExchangeInfo) int64 {
 	return 20
 }
 
-func GetRequestsPerSecond(client *Client, weight int) (float64, error) {
+// GetWeightPerSecond returns the request weight we are allowed to spend per second
+func GetWeightPerSecond(client *Client) (float64, error) {
 	var out float64 = 20
 
-	if requestsPerSecond == 0 {
+	throttleMutex.Lock()
+	rps, ok := requestsPerSecond[client.Domain()]
+	throttleMutex.Unlock()
+	if !ok {
 		info, err := client.inner.NewExchangeInfoService().Do(context.Background())
 		if err != nil {
 			client.handleError(err)
 			return out, err
 		}
-		requestsPerSecond = float64(getRequestsPerSecond(info))
+		rps = float64(getRequestsPerSecond(info))
+		throttleMutex.Lock()
+		requestsPerSecond[client.Domain()] = rps
+		throttleMutex.Unlock()
 	}
 
-	if requestsPerSecond > 0 {
-		out = requestsPerSecond
+	if rps > 0 {
+		out = rps
 	}
 
+	return out, nil
+}
+
+func GetRequestsPerSecond(client *Client, weight int) (float64, error) {
+	out, err := GetWeightPerSecond(client)
+	if err != nil {
+		return out, err
+	}
+
+	throttleMutex.Lock()
+	defer throttleMutex.Unlock()
 	if lastWeight > 0 {
 		out = out / float64(lastWeight)
 	}
@@ -63,19 +86,97 @@ func GetRequestsPerSecond(client *Client, weight int) (float64, error) {
 	return out, nil
 }
 
-func init() {
-	BeforeRequest = func(client *Client, weight int) error {
+// usedWeight is the request weight that Binance says we have used during the current minute
+type usedWeight struct {
+	weight int
+	time   time.Time // when we received the X-MBX-USED-WEIGHT-1M header
+}
+
+var (
+	usedWeightMutex sync.Mutex
+	usedWeights     = make(map[string]usedWeight) // per domain
+)
+
+// weightTransport reads the X-MBX-USED-WEIGHT-1M header from every response
+type weightTransport struct {
+	domain string
+	inner  http.RoundTripper
+}
+
+func (self *weightTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := self.inner.RoundTrip(req)
+	if err == nil {
+		value := resp.Header.Get("X-MBX-USED-WEIGHT-1M")
+		if value == "" {
+			value = resp.Header.Get("X-MBX-USED-WEIGHT")
+		}
+		if weight, err := strconv.Atoi(value); err == nil {
+			usedWeightMutex.Lock()
+			usedWeights[self.domain] = usedWeight{weight: weight, time: time.Now()}
+			usedWeightMutex.Unlock()
+		}
+	}
+	return resp, err
+}
+
+// GetUsedWeight returns the request weight we have used during the current minute, or false if we don't know.
+func GetUsedWeight(client *Client) (int, bool) {
+	usedWeightMutex.Lock()
+	defer usedWeightMutex.Unlock()
+	used, ok := usedWeights[client.Domain()]
+	if !ok {
+		return 0, false
+	}
+	// Binance resets the weight at the start of every minute
+	if used.time.Truncate(time.Minute) != time.Now().Truncate(time.Minute) {
+		return 0, true
+	}
+	return used.weight, true
+}
+
+// GetWeightDelay returns how long we need to wait before we can send a request with this weight. Binance meters our
+// requests by weight per minute, so we only wait if this request would push us over our budget for the current minute.
+// If we don't know how much weight we have used yet, then we fall back on spacing our requests evenly.
+func GetWeightDelay(client *Client, weight int, lastRequest time.Time) (time.Duration, error) {
+	used, ok := GetUsedWeight(client)
+	if !ok {
 		rps, err := GetRequestsPerSecond(client, weight)
 		if err != nil {
-			return err
+			return 0, err
 		}
 		elapsed := time.Since(lastRequest)
 		if elapsed.Seconds() < (float64(1) / rps) {
-			time.Sleep(time.Duration((float64(time.Second) / rps)) - elapsed)
+			return time.Duration((float64(time.Second) / rps)) - elapsed, nil
+		}
+		return 0, nil
+	}
+	wps, err := GetWeightPerSecond(client)
+	if err != nil {
+		return 0, err
+	}
+	// keep 10% of our budget in reserve for the requests that we cannot postpone (for example: cancel an order)
+	budget := int(wps * 60 * 0.9)
+	if used+weight <= budget {
+		return 0, nil
+	}
+	return time.Until(time.Now().Truncate(time.Minute).Add(time.Minute)), nil
+}
+
+func init() {
+	BeforeRequest = func(client *Client, weight int) error {
+		throttleMutex.Lock()
+		last := lastRequest
+		throttleMutex.Unlock()
+		sleep, err := GetWeightDelay(client, weight, last)
+		if err != nil {
+			return err
 		}
+		time.Sleep(sleep)
 		return nil
 	}
-	AfterRequest = func() {
+	AfterRequest = func(client *Client) {
+		throttleMutex.Lock()
+		defer throttleMutex.Unlock()
 		lastRequest = time.Now()
 	}
 }
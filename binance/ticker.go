@@ -14,7 +14,7 @@ func (self *Client) Ticker(symbol string) (*exchange.PriceChangeStats, error) {
 		err   error
 		stats []*exchange.PriceChangeStats
 	)
-	defer AfterRequest()
+	defer AfterRequest(self)
 	BeforeRequest(self, WEIGHT_TICKER_24H_WITH_SYMBOL)
 	if stats, err = self.inner.NewListPriceChangeStatsService().Symbol(symbol).Do(context.Background()); err != nil {
 		self.handleError(err)
@@ -25,3 +25,27 @@ func (self *Client) Ticker(symbol string) (*exchange.PriceChangeStats, error) {
 	}
 	return stats[0], nil
 }
+
+// latest price for every symbol.
+func (self *Client) Prices() ([]*exchange.SymbolPrice, error) {
+	defer AfterRequest(self)
+	BeforeRequest(self, WEIGHT_TICKER_PRICE_ALL)
+	out, err := self.inner.NewListPricesService().Do(context.Background())
+	if err != nil {
+		self.handleError(err)
+		return nil, err
+	}
+	return out, nil
+}
+
+// 24 hour rolling window price change statistics for every symbol.
+func (self *Client) Tickers() ([]*exchange.PriceChangeStats, error) {
+	defer AfterRequest(self)
+	BeforeRequest(self, WEIGHT_TICKER_24H_WITHOUT_SYMBOL)
+	out, err := self.inner.NewListPriceChangeStatsService().Do(context.Background())
+	if err != nil {
+		self.handleError(err)
+		return nil, err
+	}
+	return out, nil
+}
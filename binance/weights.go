@@ -1,13 +1,18 @@
 package binance
 
 const (
+	WEIGHT_ACCOUNT                    = 10
 	WEIGHT_ALL_ORDERS                 = 10
+	WEIGHT_API_RESTRICTIONS           = 1
 	WEIGHT_CANCEL_ORDER               = 1
 	WEIGHT_CREATE_OCO_ORDER           = 1
 	WEIGHT_CREATE_ORDER               = 1
 	WEIGHT_EXCHANGE_INFO              = 10
+	WEIGHT_KLINES                     = 1
 	WEIGHT_OPEN_ORDERS_WITH_SYMBOL    = 3
 	WEIGHT_OPEN_ORDERS_WITHOUT_SYMBOL = 40
+	WEIGHT_QUERY_ORDER                = 2
 	WEIGHT_TICKER_24H_WITH_SYMBOL     = 1
 	WEIGHT_TICKER_24H_WITHOUT_SYMBOL  = 40
+	WEIGHT_TICKER_PRICE_ALL           = 2
 )
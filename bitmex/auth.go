@@ -0,0 +1,16 @@
+package bitmex
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// sign a request, returns api-signature
+func signature(apiSecret, verb, path string, expires int64, body []byte) string {
+	hash := hmac.New(sha256.New, []byte(apiSecret))
+	hash.Write([]byte(verb + path + strconv.FormatInt(expires, 10)))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,21 @@
+package bitmex
+
+import (
+	"testing"
+)
+
+// the example from https://www.bitmex.com/app/apiKeysUsage
+func TestSignature(t *testing.T) {
+	calculated := signature(
+		"chNOOS4KvNXR_Xq4k4c9qsfoKWvnDecLATCRlcBwyKDYnWgO",
+		"POST",
+		"/api/v1/order",
+		1518064238,
+		[]byte(`{"symbol":"XBTM15","price":219.0,"clOrdID":"mm_bitmex_1a/oemUeQ4CAJZgP3fjHsA","orderQty":98}`),
+	)
+	expected := "1749cd2ccae4aa49048ae09f0b95110cee706e0944e6a14ad0b3a8cb45bd336b"
+
+	if calculated != expected {
+		t.Errorf("TestSignature failed, got: %v, want: %v.", calculated, expected)
+	}
+}
@@ -0,0 +1,47 @@
+package bitmex
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+type BookEntry struct {
+	Symbol string  `json:"symbol"`
+	ID     int64   `json:"id"`
+	Side   Side    `json:"side"`
+	Size   float64 `json:"size"` // in contracts
+	Price  float64 `json:"price"`
+}
+
+type OrderBook []BookEntry
+
+func (book OrderBook) Side(side Side) []BookEntry {
+	var out []BookEntry
+	for _, entry := range book {
+		if entry.Side == side {
+			out = append(out, entry)
+		}
+	}
+	return out
+}
+
+func (client *Client) OrderBook(symbol string) (OrderBook, error) {
+	query := url.Values{}
+	query.Add("symbol", symbol)
+	query.Add("depth", "0") // full depth
+
+	var (
+		err  error
+		body []byte
+		out  OrderBook
+	)
+	if body, err = client.call(http.MethodGet, "/orderBook/L2", query, nil, false); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(body, &out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
@@ -0,0 +1,129 @@
+package bitmex
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/ratelimit"
+)
+
+const (
+	API_BASE = "https://www.bitmex.com"
+	API_TEST = "https://testnet.bitmex.com"
+	API_PATH = "/api/v1"
+)
+
+var (
+	lastRequest       time.Time
+	RequestsPerSecond float64                         = 2 // 120 requests per minute
+	BeforeRequest     func(method, path string) error = nil
+	AfterRequest      func()                          = nil
+)
+
+func init() {
+	BeforeRequest = func(method, path string) error {
+		elapsed := time.Since(lastRequest)
+		if elapsed.Seconds() < (float64(1) / RequestsPerSecond) {
+			time.Sleep(time.Duration((float64(time.Second) / RequestsPerSecond) - float64(elapsed)))
+		}
+		return nil
+	}
+	AfterRequest = func() {
+		lastRequest = time.Now()
+	}
+}
+
+type Client struct {
+	URL        string
+	apiKey     string
+	apiSecret  string
+	httpClient *http.Client
+}
+
+func New(URL, apiKey, apiSecret string) *Client {
+	return &Client{
+		URL,
+		apiKey,
+		apiSecret,
+		&http.Client{
+			Timeout:   30 * time.Second,
+			Transport: ratelimit.NewTransport("BitMEX", nil),
+		},
+	}
+}
+
+func (client *Client) do(req *http.Request) ([]byte, error) {
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+			return nil, errors.NewStatusError(resp.StatusCode, resp.Status)
+		}
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		if err, msg := IsError(body); err {
+			return body, errors.NewStatusError(resp.StatusCode, msg)
+		}
+		return body, errors.NewStatusError(resp.StatusCode, resp.Status)
+	}
+
+	return body, nil
+}
+
+func (client *Client) call(method, path string, query url.Values, payload interface{}, auth bool) ([]byte, error) {
+	// respect the rate limit
+	err := BeforeRequest(method, path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		AfterRequest()
+	}()
+
+	// set the endpoint for this request
+	endpoint, err := url.Parse(client.URL)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path += (API_PATH + path)
+	if query != nil {
+		endpoint.RawQuery = query.Encode()
+	}
+
+	// create the request
+	var body []byte
+	if payload != nil {
+		if body, err = json.Marshal(payload); err != nil {
+			return nil, err
+		}
+	}
+	req, err := http.NewRequest(method, endpoint.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", "application/json")
+
+	// add autentication headers
+	if auth {
+		expires := time.Now().Add(time.Minute).Unix()
+		req.Header.Add("api-expires", strconv.FormatInt(expires, 10))
+		req.Header.Add("api-key", client.apiKey)
+		req.Header.Add("api-signature", signature(client.apiSecret, method, endpoint.RequestURI(), expires, body))
+	}
+
+	// do the request
+	return client.do(req)
+}
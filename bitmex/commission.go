@@ -0,0 +1,31 @@
+package bitmex
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+type Commission struct {
+	MakerFee float64 `json:"makerFee"`
+	TakerFee float64 `json:"takerFee"`
+}
+
+// Commission returns your (account-specific) commission rates for a symbol
+func (client *Client) Commission(symbol string) (*Commission, error) {
+	var (
+		err  error
+		body []byte
+		out  map[string]Commission
+	)
+	if body, err = client.call(http.MethodGet, "/user/commission", nil, nil, true); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(body, &out); err != nil {
+		return nil, err
+	}
+	if commission, ok := out[symbol]; ok {
+		return &commission, nil
+	}
+	return nil, fmt.Errorf("symbol %s does not exist", symbol)
+}
@@ -0,0 +1,24 @@
+package bitmex
+
+import (
+	"encoding/json"
+)
+
+type Error struct {
+	Error struct {
+		Name    string `json:"name"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (err *Error) Failure() bool {
+	return err.Error.Message != ""
+}
+
+func IsError(response []byte) (bool, string) {
+	var err Error
+	if json.Unmarshal(response, &err) == nil {
+		return err.Failure(), err.Error.Message
+	}
+	return false, ""
+}
@@ -0,0 +1,115 @@
+package bitmex
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"strings"
+
+	"github.com/svanas/nefertiti/precision"
+)
+
+// FFWCSX is the type of a perpetual swap
+const TYPE_PERPETUAL = "FFWCSX"
+
+type Instrument struct {
+	Symbol        string  `json:"symbol"`
+	RootSymbol    string  `json:"rootSymbol"`
+	State         string  `json:"state"`
+	Typ           string  `json:"typ"`
+	Underlying    string  `json:"underlying"`
+	QuoteCurrency string  `json:"quoteCurrency"`
+	IsInverse     bool    `json:"isInverse"`
+	TickSize      float64 `json:"tickSize"`
+	LotSize       float64 `json:"lotSize"`
+	Multiplier    float64 `json:"multiplier"`
+	LastPrice     float64 `json:"lastPrice"`
+	PrevPrice24h  float64 `json:"prevPrice24h"`
+	MarkPrice     float64 `json:"markPrice"`
+	HighPrice     float64 `json:"highPrice"`
+	LowPrice      float64 `json:"lowPrice"`
+	Volume24h     float64 `json:"volume24h"`
+	Turnover24h   float64 `json:"turnover24h"`
+}
+
+func (instrument *Instrument) IsPerpetual() bool {
+	return instrument.Typ == TYPE_PERPETUAL && instrument.State == "Open"
+}
+
+// bitmex calls bitcoin XBT
+func (instrument *Instrument) Base() string {
+	return ParseCurrency(instrument.Underlying)
+}
+
+func (instrument *Instrument) Quote() string {
+	return ParseCurrency(instrument.QuoteCurrency)
+}
+
+// round the price to the nearest tick
+func (instrument *Instrument) RoundPrice(price float64) float64 {
+	return precision.RoundTick(price, instrument.TickSize)
+}
+
+// converts a size in the underlying currency into a number of contracts, rounded to the lot size.
+func (instrument *Instrument) ToContracts(size, price float64) float64 {
+	contracts := size
+	if instrument.IsInverse {
+		contracts = size * price
+	} else if instrument.Multiplier > 0 {
+		contracts = size / (instrument.Multiplier / 1e8)
+	}
+	if instrument.LotSize > 0 {
+		contracts = math.Round(contracts/instrument.LotSize) * instrument.LotSize
+		if contracts < instrument.LotSize {
+			contracts = instrument.LotSize
+		}
+	}
+	return contracts
+}
+
+// converts a number of contracts into a size in the underlying currency.
+func (instrument *Instrument) FromContracts(contracts, price float64) float64 {
+	if instrument.IsInverse {
+		if price > 0 {
+			return contracts / price
+		}
+		return 0
+	}
+	if instrument.Multiplier > 0 {
+		return contracts * (instrument.Multiplier / 1e8)
+	}
+	return contracts
+}
+
+func (client *Client) Instruments() ([]Instrument, error) {
+	var (
+		err  error
+		body []byte
+		out  []Instrument
+	)
+	if body, err = client.call(http.MethodGet, "/instrument/active", nil, nil, false); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(body, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func FormatCurrency(currency string) string {
+	if strings.EqualFold(currency, "BTC") {
+		return "XBT"
+	}
+	return strings.ToUpper(currency)
+}
+
+func ParseCurrency(currency string) string {
+	if strings.EqualFold(currency, "XBT") {
+		return "BTC"
+	}
+	return strings.ToUpper(currency)
+}
+
+func FormatSymbol(base, quote string) string {
+	return FormatCurrency(base) + FormatCurrency(quote)
+}
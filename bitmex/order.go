@@ -0,0 +1,153 @@
+package bitmex
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type (
+	Side      string
+	OrderType string
+	OrdStatus string
+)
+
+const (
+	SideBuy  Side = "Buy"
+	SideSell Side = "Sell"
+)
+
+const (
+	OrderTypeLimit  OrderType = "Limit"
+	OrderTypeMarket OrderType = "Market"
+	OrderTypeStop   OrderType = "Stop" // stop-market
+)
+
+const (
+	OrdStatusNew             OrdStatus = "New"
+	OrdStatusPartiallyFilled OrdStatus = "PartiallyFilled"
+	OrdStatusFilled          OrdStatus = "Filled"
+	OrdStatusCanceled        OrdStatus = "Canceled"
+)
+
+const (
+	EXEC_INST_REDUCE_ONLY = "ReduceOnly"
+	EXEC_INST_LAST_PRICE  = "LastPrice"
+)
+
+type Order struct {
+	OrderID      string    `json:"orderID"`
+	ClOrdID      string    `json:"clOrdID,omitempty"`
+	Symbol       string    `json:"symbol"`
+	Side         Side      `json:"side"`
+	OrderQty     float64   `json:"orderQty"`
+	Price        float64   `json:"price,omitempty"`
+	StopPx       float64   `json:"stopPx,omitempty"`
+	OrdType      OrderType `json:"ordType"`
+	OrdStatus    OrdStatus `json:"ordStatus"`
+	ExecInst     string    `json:"execInst,omitempty"`
+	CumQty       float64   `json:"cumQty"`
+	AvgPx        float64   `json:"avgPx"`
+	Text         string    `json:"text,omitempty"`
+	Timestamp    time.Time `json:"timestamp"`
+	TransactTime time.Time `json:"transactTime"`
+}
+
+func (order *Order) ReduceOnly() bool {
+	return strings.Contains(order.ExecInst, EXEC_INST_REDUCE_ONLY) || strings.Contains(order.ExecInst, "Close")
+}
+
+func (order *Order) IsStop() bool {
+	return order.OrdType == OrderTypeStop
+}
+
+func (order *Order) ExecutedAt() float64 {
+	if order.AvgPx > 0 {
+		return order.AvgPx
+	}
+	return order.Price
+}
+
+type NewOrder struct {
+	Symbol   string    `json:"symbol"`
+	Side     Side      `json:"side"`
+	OrderQty float64   `json:"orderQty"`
+	Price    float64   `json:"price,omitempty"`
+	StopPx   float64   `json:"stopPx,omitempty"`
+	OrdType  OrderType `json:"ordType"`
+	ExecInst string    `json:"execInst,omitempty"`
+	Text     string    `json:"text,omitempty"`
+}
+
+func (client *Client) Order(order *NewOrder) (*Order, error) {
+	var (
+		err  error
+		body []byte
+		out  Order
+	)
+	if body, err = client.call(http.MethodPost, "/order", nil, order, true); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (client *Client) CancelOrder(orderID string) error {
+	_, err := client.call(http.MethodDelete, "/order", nil, map[string]string{"orderID": orderID}, true)
+	return err
+}
+
+func (client *Client) orders(symbol string, filter map[string]interface{}) ([]Order, error) {
+	query := url.Values{}
+	query.Add("symbol", symbol)
+	query.Add("count", "500")
+	query.Add("reverse", "true")
+	if filter != nil {
+		data, err := json.Marshal(filter)
+		if err != nil {
+			return nil, err
+		}
+		query.Add("filter", string(data))
+	}
+
+	var (
+		err  error
+		body []byte
+		out  []Order
+	)
+	if body, err = client.call(http.MethodGet, "/order", query, nil, true); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(body, &out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// returns the open orders, including the untriggered stop orders
+func (client *Client) OpenOrders(symbol string) ([]Order, error) {
+	return client.orders(symbol, map[string]interface{}{"open": true})
+}
+
+// returns the order with this ID
+func (client *Client) GetOrder(symbol, orderID string) (*Order, error) {
+	orders, err := client.orders(symbol, map[string]interface{}{"orderID": orderID})
+	if err != nil {
+		return nil, err
+	}
+	if len(orders) == 0 {
+		return nil, fmt.Errorf("order %s does not exist", orderID)
+	}
+	return &orders[0], nil
+}
+
+// returns the (most recent) filled orders
+func (client *Client) FilledOrders(symbol string) ([]Order, error) {
+	return client.orders(symbol, map[string]interface{}{"ordStatus": OrdStatusFilled})
+}
@@ -0,0 +1,86 @@
+package bitmex
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+type Position struct {
+	Symbol           string  `json:"symbol"`
+	CurrentQty       float64 `json:"currentQty"` // in contracts, negative when short
+	AvgEntryPrice    float64 `json:"avgEntryPrice"`
+	Leverage         float64 `json:"leverage"`
+	CrossMargin      bool    `json:"crossMargin"`
+	LiquidationPrice float64 `json:"liquidationPrice"`
+	UnrealisedPnl    float64 `json:"unrealisedPnl"`
+	IsOpen           bool    `json:"isOpen"`
+}
+
+func (client *Client) Position(symbol string) (*Position, error) {
+	data, err := json.Marshal(map[string]string{"symbol": symbol})
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Add("filter", string(data))
+
+	var (
+		body []byte
+		out  []Position
+	)
+	if body, err = client.call(http.MethodGet, "/position", query, nil, true); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(body, &out); err != nil {
+		return nil, err
+	}
+
+	for _, position := range out {
+		if position.Symbol == symbol {
+			return &position, nil
+		}
+	}
+
+	// no position yet
+	return &Position{Symbol: symbol, CrossMargin: true}, nil
+}
+
+// toggles between isolated margin and cross margin
+func (client *Client) Isolate(symbol string, enabled bool) (*Position, error) {
+	var (
+		err  error
+		body []byte
+		out  Position
+	)
+	if body, err = client.call(http.MethodPost, "/position/isolate", nil, map[string]interface{}{
+		"symbol":  symbol,
+		"enabled": enabled,
+	}, true); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// sets the leverage for an isolated position. zero means cross margin.
+func (client *Client) Leverage(symbol string, leverage float64) (*Position, error) {
+	var (
+		err  error
+		body []byte
+		out  Position
+	)
+	if body, err = client.call(http.MethodPost, "/position/leverage", nil, map[string]interface{}{
+		"symbol":   symbol,
+		"leverage": leverage,
+	}, true); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
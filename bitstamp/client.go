@@ -6,7 +6,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/ratelimit"
 	"github.com/svanas/nefertiti/uuid"
 )
 
@@ -55,7 +56,8 @@ func New(apiKey, apiSecret string) *Client {
 		Key:    apiKey,
 		Secret: apiSecret,
 		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout:   30 * time.Second,
+			Transport: ratelimit.NewTransport("Bitstamp", nil),
 		},
 	}
 }
@@ -109,7 +111,7 @@ func (client *Client) get(path string) ([]byte, error) {
 	}
 
 	var body []byte
-	if body, err = ioutil.ReadAll(resp.Body); err != nil {
+	if body, err = io.ReadAll(resp.Body); err != nil {
 		return nil, errors.Wrap(err, 1)
 	}
 
@@ -207,7 +209,7 @@ func (client *Client) post(path string, values url.Values) ([]byte, error) {
 
 	// read the body of the http message into a byte array
 	var body []byte
-	if body, err = ioutil.ReadAll(resp.Body); err != nil {
+	if body, err = io.ReadAll(resp.Body); err != nil {
 		return nil, errors.Wrap(err, 1)
 	}
 
@@ -359,6 +361,25 @@ func (client *Client) GetOpenOrdersEx(pair string) ([]Order, error) {
 	return out, nil
 }
 
+func (client *Client) GetOrderStatus(id string) (*OrderStatus, error) {
+	var err error
+
+	v := url.Values{}
+	v.Add("id", id)
+
+	var body []byte
+	if body, err = client.post("/order_status/", v); err != nil {
+		return nil, err
+	}
+
+	var out OrderStatus
+	if err = json.Unmarshal(body, &out); err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	return &out, nil
+}
+
 func (client *Client) CancelOrder(id string) error {
 	v := url.Values{}
 	v.Add("id", id)
@@ -1,9 +1,12 @@
 package bitstamp
 
 import (
+	"math"
 	"strings"
 	"time"
 
+	"github.com/svanas/nefertiti/clock"
+	"github.com/svanas/nefertiti/empty"
 	"github.com/svanas/nefertiti/errors"
 )
 
@@ -61,7 +64,7 @@ func (order *Order) GetDateTime() (*time.Time, error) {
 		err error
 		out time.Time
 	)
-	if out, err = time.Parse(TimeFormat, order.DateTime); err != nil {
+	if out, err = clock.Parse(order.DateTime, TimeFormat); err != nil {
 		return nil, errors.Wrap(err, 1)
 	}
 	return &out, nil
@@ -95,3 +98,51 @@ func (orders Orders) OrderById(id string) *Order {
 	}
 	return nil
 }
+
+const (
+	ORDER_STATUS_OPEN     = "Open"
+	ORDER_STATUS_FINISHED = "Finished"
+	ORDER_STATUS_CANCELED = "Canceled"
+)
+
+// OrderStatus is what the order_status endpoint returns. unlike the open orders, it has the (partial) fills.
+type OrderStatus struct {
+	Id              string        `json:"id"`
+	DateTime        string        `json:"datetime"`
+	Type            int           `json:"type,string"`
+	Status          string        `json:"status"`
+	AmountRemaining float64       `json:"amount_remaining,string"`
+	Transactions    []Transaction `json:"transactions"`
+}
+
+func (order *OrderStatus) Side() string {
+	if order.Type == 0 {
+		return BUY
+	}
+	if order.Type == 1 {
+		return SELL
+	}
+	return ""
+}
+
+func (order *OrderStatus) GetDateTimeEx() time.Time {
+	out, err := clock.Parse(order.DateTime, TimeFormat)
+	if err == nil {
+		return out
+	}
+	return time.Time{}
+}
+
+// Filled returns the size and the average price of the fills, for the base currency of the market
+func (order *OrderStatus) Filled(base string) (size, price float64) {
+	var total float64
+	for _, transaction := range order.Transactions {
+		amount := math.Abs(empty.AsFloat64(transaction[base]))
+		size += amount
+		total += amount * empty.AsFloat64(transaction["price"])
+	}
+	if size > 0 {
+		price = total / size
+	}
+	return size, price
+}
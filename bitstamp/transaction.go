@@ -5,6 +5,7 @@ import (
 	"math"
 	"time"
 
+	"github.com/svanas/nefertiti/clock"
 	"github.com/svanas/nefertiti/empty"
 	"github.com/svanas/nefertiti/errors"
 )
@@ -13,6 +14,10 @@ type (
 	Transaction map[string]interface{}
 )
 
+func (transaction *Transaction) Id() string {
+	return empty.AsString((*transaction)["id"])
+}
+
 func (transaction *Transaction) OrderId() string {
 	return empty.AsString((*transaction)["order_id"])
 }
@@ -102,7 +107,7 @@ func (transaction *Transaction) DateTime() time.Time {
 	dt := (*transaction)["datetime"]
 	var out time.Time
 	if dt != nil {
-		out, _ = time.Parse(TimeFormat, empty.AsString(dt))
+		out, _ = clock.Parse(empty.AsString(dt), TimeFormat)
 	}
 	return out
 }
@@ -9,11 +9,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/svanas/nefertiti/clock"
+	"github.com/svanas/nefertiti/ratelimit"
 )
 
 const (
@@ -28,6 +32,7 @@ var (
 	BeforeRequest      func(path string) (bool, error)      = nil // -> (cooled, error)
 	AfterRequest       func()                               = nil
 	HandleRateLimitErr func(path string, cooled bool) error = nil
+	ServerTime         *clock.Offset                        = clock.New("Bittrex")
 )
 
 const (
@@ -40,9 +45,52 @@ func RequestsPerSecond(intensity int) float64 {
 	return float64(1) / float64(intensity)
 }
 
+// after this long without a rate limit error, we lower the intensity of an endpoint by one
+const INTENSITY_DECAY = time.Hour
+
 type Call struct {
-	Path      string `json:"path"`
-	Intensity int    `json:"intensity"`
+	Path          string    `json:"path"`
+	Intensity     int       `json:"intensity"`
+	LastRateLimit time.Time `json:"last_rate_limit"` // the last time this endpoint got rate limited
+	LastDecay     time.Time `json:"last_decay"`      // the last time we lowered the intensity of this endpoint
+}
+
+// decay gradually restores the intensity of this endpoint. returns true if the call has changed.
+func (call *Call) decay() bool {
+	if call.Intensity <= INTENSITY_LOW {
+		return false
+	}
+	last := call.LastRateLimit
+	if call.LastDecay.After(last) {
+		last = call.LastDecay
+	}
+	if last.IsZero() {
+		// learned before we kept timestamps? start the clock now.
+		call.LastDecay = time.Now()
+		return true
+	}
+	steps := int(time.Since(last) / INTENSITY_DECAY)
+	if steps <= 0 {
+		return false
+	}
+	call.Intensity = call.Intensity - steps
+	if call.Intensity < INTENSITY_LOW {
+		call.Intensity = INTENSITY_LOW
+	}
+	call.LastDecay = last.Add(time.Duration(steps) * INTENSITY_DECAY)
+	return true
+}
+
+// Decay gradually restores the intensity of the endpoints that haven't been rate limited for a while.
+// returns true if one (or more) calls have changed.
+func Decay(calls []Call) bool {
+	out := false
+	for idx := range calls {
+		if calls[idx].decay() {
+			out = true
+		}
+	}
+	return out
 }
 
 var Calls = []Call{}
@@ -52,6 +100,7 @@ func getRequestsPerSecond(path string) (float64, bool) { // -> (rps, cooldown)
 		cooldown = false
 		return RequestsPerSecond(INTENSITY_SUPER), true
 	}
+	Decay(Calls)
 	for i := range path {
 		if strings.Contains("?", string(path[i])) {
 			path = path[:i]
@@ -99,13 +148,15 @@ func init() {
 				} else {
 					Calls[idx].Intensity = Calls[idx].Intensity + 1
 				}
+				Calls[idx].LastRateLimit = time.Now()
 				exists = true
 			}
 		}
 		if !exists {
 			Calls = append(Calls, Call{
-				Path:      path,
-				Intensity: INTENSITY_TWO,
+				Path:          path,
+				Intensity:     INTENSITY_TWO,
+				LastRateLimit: time.Now(),
 			})
 		}
 		cooldown = true
@@ -114,6 +165,7 @@ func init() {
 }
 
 type Client struct {
+	URL        string
 	apiKey     string
 	apiSecret  string
 	appId      string
@@ -122,11 +174,13 @@ type Client struct {
 
 func New(apiKey, apiSecret, appId string) *Client {
 	return &Client{
+		fmt.Sprintf("%s/%s", API_BASE, API_VERSION),
 		apiKey,
 		apiSecret,
 		appId,
 		&http.Client{
-			Timeout: 30 * time.Second,
+			Timeout:   30 * time.Second,
+			Transport: ratelimit.NewTransport("Bittrex", nil),
 		},
 	}
 }
@@ -137,6 +191,9 @@ func (client *Client) do(method string, path string, payload []byte, auth bool)
 		out  []byte
 		err  error
 	)
+	if auth {
+		ServerTime.Sync(client.GetServerTime)
+	}
 	for {
 		code, out, err = client._do(method, path, payload, auth)
 		if code != http.StatusTooManyRequests {
@@ -164,7 +221,7 @@ func (client *Client) _do(method string, path string, payload []byte, auth bool)
 	if strings.HasPrefix(path, "http") {
 		url = path
 	} else {
-		url = fmt.Sprintf("%s/%s/%s", API_BASE, API_VERSION, path)
+		url = fmt.Sprintf("%s/%s", client.URL, path)
 	}
 
 	var req *http.Request
@@ -184,7 +241,7 @@ func (client *Client) _do(method string, path string, payload []byte, auth bool)
 		}
 
 		// Unix timestamp in millisecond format
-		nonce := strconv.FormatInt((time.Now().UnixNano() / int64(time.Millisecond/time.Nanosecond)), 10)
+		nonce := strconv.FormatInt((ServerTime.Now().UnixNano() / int64(time.Millisecond/time.Nanosecond)), 10)
 
 		req.Header.Add("Api-Key", client.apiKey)
 		req.Header.Add("Api-Timestamp", nonce)
@@ -209,7 +266,7 @@ func (client *Client) _do(method string, path string, payload []byte, auth bool)
 	}
 	defer resp.Body.Close()
 
-	if out, err = ioutil.ReadAll(resp.Body); err != nil {
+	if out, err = io.ReadAll(resp.Body); err != nil {
 		return resp.StatusCode, nil, err
 	}
 
@@ -232,6 +289,23 @@ func (client *Client) _do(method string, path string, payload []byte, auth bool)
 	return resp.StatusCode, out, nil
 }
 
+func (client *Client) GetServerTime() (time.Time, error) {
+	var (
+		err  error
+		data []byte
+		out  struct {
+			ServerTime int64 `json:"serverTime"`
+		}
+	)
+	if data, err = client.do("GET", "ping", nil, false); err != nil {
+		return time.Time{}, err
+	}
+	if err = json.Unmarshal(data, &out); err != nil {
+		return time.Time{}, err
+	}
+	return clock.FromMilli(out.ServerTime), nil
+}
+
 func (client *Client) GetMarkets() (markets []Market, err error) {
 	var data []byte
 	if data, err = client.do("GET", "markets", nil, false); err != nil {
@@ -258,6 +332,36 @@ func (client *Client) GetTicker(market string) (*Ticker, error) {
 	return &out, nil
 }
 
+func (client *Client) GetTickers() ([]Ticker, error) {
+	var (
+		err  error
+		data []byte
+	)
+	if data, err = client.do("GET", "markets/tickers", nil, false); err != nil {
+		return nil, err
+	}
+	var out []Ticker
+	if err = json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (client *Client) GetMarketSummaries() ([]MarketSummary, error) {
+	var (
+		err  error
+		data []byte
+	)
+	if data, err = client.do("GET", "markets/summaries", nil, false); err != nil {
+		return nil, err
+	}
+	var out []MarketSummary
+	if err = json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (client *Client) GetMarketSummary(market string) (*MarketSummary, error) {
 	var (
 		err  error
@@ -372,11 +476,19 @@ func (client *Client) GetOrder(orderId OrderId) (*Order, error) {
 }
 
 func (client *Client) GetOrderHistory(market string) (Orders, error) {
+	return client.GetOrderHistorySince(market, time.Time{})
+}
+
+// GetOrderHistorySince returns the orders that closed after the since cursor. A zero cursor returns the entire history.
+func (client *Client) GetOrderHistorySince(market string, since time.Time) (Orders, error) {
 	path := func(nextPageToken string) string {
 		result := "orders/closed?pageSize=200"
 		if market != "" && market != "all" {
 			result += "&marketSymbol=" + market
 		}
+		if !since.IsZero() {
+			result += "&startDate=" + url.QueryEscape(since.UTC().Format(TIME_FORMAT))
+		}
 		if nextPageToken != "" {
 			result += "&nextPageToken=" + nextPageToken
 		}
@@ -474,6 +586,65 @@ func (client *Client) CancelConditionalOrder(orderId OrderId) (err error) {
 	return err
 }
 
+// CreateTrailingStopOrder creates a conditional order that triggers when the price drops trailingStopPercent below
+// its highest price since the order was created.
+func (client *Client) CreateTrailingStopOrder(
+	marketSymbol string,
+	trailingStopPercent float64,
+	orderToCreate *NewOrder,
+) (*ConditionalOrder, error) {
+	var err error
+
+	type newTrailingStopOrder struct {
+		MarketSymbol        string    `json:"marketSymbol"`
+		Operand             string    `json:"operand"` // LTE
+		TrailingStopPercent string    `json:"trailingStopPercent"`
+		OrderToCreate       *newOrder `json:"orderToCreate"`
+	}
+
+	helper := orderToCreate.into()
+	order := &newTrailingStopOrder{
+		MarketSymbol:        marketSymbol,
+		Operand:             OperandString[LTE],
+		TrailingStopPercent: strconv.FormatFloat(trailingStopPercent, 'f', -1, 64),
+		OrderToCreate:       &helper,
+	}
+
+	var payload []byte
+	if payload, err = json.Marshal(order); err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	if data, err = client.do("POST", "conditional-orders", payload, true); err != nil {
+		return nil, err
+	}
+
+	var result ConditionalOrder
+	if err = json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (client *Client) GetClosedConditionalOrders(market string) (orders []ConditionalOrder, err error) {
+	var data []byte
+	if data, err = client.do("GET", func() string {
+		result := "conditional-orders/closed"
+		if market != "" && market != "all" {
+			result += "?marketSymbol=" + market
+		}
+		return result
+	}(), nil, true); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &orders); err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
 func (client *Client) GetOpenConditionalOrders(market string) (orders []ConditionalOrder, err error) {
 	var data []byte
 	if data, err = client.do("GET", func() string {
@@ -0,0 +1,33 @@
+package bittrex
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type TradingFee struct {
+	MarketSymbol string  `json:"marketSymbol"`
+	MakerRate    float64 `json:"makerRate,string"`
+	TakerRate    float64 `json:"takerRate,string"`
+}
+
+// GetTradingFee returns your (account-specific) commission rates for a market
+func (client *Client) GetTradingFee(market string) (*TradingFee, error) {
+	var (
+		err  error
+		data []byte
+	)
+	if data, err = client.do("GET", "account/fees/trading", nil, true); err != nil {
+		return nil, err
+	}
+	var fees []TradingFee
+	if err = json.Unmarshal(data, &fees); err != nil {
+		return nil, err
+	}
+	for i := range fees {
+		if fees[i].MarketSymbol == market {
+			return &fees[i], nil
+		}
+	}
+	return nil, fmt.Errorf("market %s does not exist", market)
+}
@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/svanas/nefertiti/clock"
 )
 
 type OrderId string
@@ -108,13 +111,17 @@ type NewOrder struct {
 }
 
 func (self *NewOrder) into() newOrder {
-	return newOrder{
+	out := newOrder{
 		MarketSymbol: self.MarketSymbol,
 		Direction:    self.Direction.String(),
 		OrderType:    self.OrderType.String(),
 		Quantity:     strconv.FormatFloat(self.Quantity, 'f', -1, 64),
 		TimeInForce:  self.TimeInForce.String(),
 	}
+	if self.Limit > 0 {
+		out.Limit = strconv.FormatFloat(self.Limit, 'f', -1, 64)
+	}
+	return out
 }
 
 //------------------------- Order -------------------------
@@ -185,6 +192,18 @@ func (orders Orders) IndexByOrderId(id OrderId) int {
 	return -1
 }
 
+// LastClosedAt returns the most recent closing time in the history, or a zero time if there is none.
+func (orders Orders) LastClosedAt() time.Time {
+	var out time.Time
+	for _, order := range orders {
+		closedAt, err := clock.Parse(order.ClosedAt, TIME_FORMAT)
+		if err == nil && closedAt.After(out) {
+			out = closedAt
+		}
+	}
+	return out
+}
+
 func (orders Orders) IndexByOrderIdEx(id OrderId, side OrderSide) int {
 	for i, order := range orders {
 		if order.Id == id && order.Direction == OrderSideString[side] {
@@ -217,3 +236,36 @@ type ConditionalOrder struct {
 	UpdatedAt                string                     `json:"updatedAt"`
 	ClosedAt                 string                     `json:"closedAt"`
 }
+
+// MarketName returns the old (v1) market name that was reversed.
+func (order *ConditionalOrder) MarketName() string {
+	symbols := strings.Split(order.MarketSymbol, "-")
+	return fmt.Sprintf("%s-%s", symbols[1], symbols[0])
+}
+
+func (order *ConditionalOrder) Direction() string {
+	if order.OrderToCreate != nil {
+		return order.OrderToCreate.Direction
+	}
+	return ""
+}
+
+func (order *ConditionalOrder) Quantity() float64 {
+	if order.OrderToCreate != nil {
+		if out, err := strconv.ParseFloat(order.OrderToCreate.Quantity, 64); err == nil {
+			return out
+		}
+	}
+	return 0
+}
+
+// Limit returns the limit price of the order that will be created when the conditional order triggers, or zero
+// if the order to create is a market order.
+func (order *ConditionalOrder) Limit() float64 {
+	if order.OrderToCreate != nil && order.OrderToCreate.Limit != "" {
+		if out, err := strconv.ParseFloat(order.OrderToCreate.Limit, 64); err == nil {
+			return out
+		}
+	}
+	return 0
+}
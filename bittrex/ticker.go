@@ -1,8 +1,22 @@
 package bittrex
 
+import (
+	"fmt"
+	"strings"
+)
+
 type Ticker struct {
 	Symbol        string  `json:"symbol"`
 	LastTradeRate float64 `json:"lastTradeRate,string"`
 	BidRate       float64 `json:"bidRate,string"`
 	AskRate       float64 `json:"askRate,string"`
 }
+
+// MarketName returns the old (v1) market name that was reversed.
+func (ticker *Ticker) MarketName() string {
+	symbols := strings.Split(ticker.Symbol, "-")
+	if len(symbols) < 2 {
+		return ticker.Symbol
+	}
+	return fmt.Sprintf("%s-%s", symbols[1], symbols[0])
+}
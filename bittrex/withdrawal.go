@@ -0,0 +1,44 @@
+package bittrex
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+)
+
+type Withdrawal struct {
+	Id                 string  `json:"id"`
+	CurrencySymbol     string  `json:"currencySymbol"`
+	Quantity           float64 `json:"quantity,string"`
+	CryptoAddress      string  `json:"cryptoAddress"`
+	CryptoAddressTag   string  `json:"cryptoAddressTag,omitempty"`
+	TxCost             float64 `json:"txCost,string,omitempty"`
+	TxId               string  `json:"txId,omitempty"`
+	Status             string  `json:"status"`
+	CreatedAt          string  `json:"createdAt"`
+	CompletedAt        string  `json:"completedAt,omitempty"`
+	ClientWithdrawalId string  `json:"clientWithdrawalId,omitempty"`
+}
+
+// GetWithdrawals returns the open withdrawals, plus the withdrawals that closed after the since cursor
+func (client *Client) GetWithdrawals(since time.Time) ([]Withdrawal, error) {
+	var (
+		err  error
+		data []byte
+		out  []Withdrawal
+	)
+	for _, path := range []string{
+		"withdrawals/open",
+		"withdrawals/closed?pageSize=200&startDate=" + url.QueryEscape(since.UTC().Format(TIME_FORMAT)),
+	} {
+		if data, err = client.do("GET", path, nil, true); err != nil {
+			return nil, err
+		}
+		var withdrawals []Withdrawal
+		if err = json.Unmarshal(data, &withdrawals); err != nil {
+			return nil, err
+		}
+		out = append(out, withdrawals...)
+	}
+	return out, nil
+}
@@ -7,12 +7,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/svanas/nefertiti/ratelimit"
 )
 
 const (
@@ -22,11 +25,23 @@ const (
 
 var (
 	lastRequest   time.Time
-	BeforeRequest func(path string) error = nil
-	AfterRequest  func()                  = nil
+	lastNonce     int64
+	nonceMutex    sync.Mutex
+	BeforeRequest func(path string) error                                                = nil
+	AfterRequest  func()                                                                 = nil
+	LockNonce     func(apiKey string, min int64) (nonce int64, unlock func(), err error) = nil
 )
 
 func init() {
+	// CEX.IO expects every nonce to be higher than the previous one. Hold the lock until the request has been sent.
+	LockNonce = func(apiKey string, min int64) (int64, func(), error) {
+		nonceMutex.Lock()
+		if min <= lastNonce {
+			min = lastNonce + 1
+		}
+		lastNonce = min
+		return min, nonceMutex.Unlock, nil
+	}
 	BeforeRequest = func(path string) error {
 		elapsed := time.Since(lastRequest)
 		if elapsed.Seconds() < (float64(1) / float64(RequestsPerSecond)) {
@@ -54,7 +69,8 @@ func New(apiKey, apiSecret, userName string) *Client {
 		Secret:   apiSecret,
 		UserName: userName,
 		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout:   30 * time.Second,
+			Transport: ratelimit.NewTransport("CEX.IO", nil),
 		},
 	}
 }
@@ -66,8 +82,8 @@ func (client *Client) hmac256(message string, secret string) string {
 	return strings.ToUpper(hex.EncodeToString(h.Sum(nil)))
 }
 
-func (client *Client) signature() (string, string) {
-	nonce := strconv.FormatInt(time.Now().UnixNano(), 10)
+func (client *Client) signature(n int64) (string, string) {
+	nonce := strconv.FormatInt(n, 10)
 	message := nonce + client.UserName + client.Key
 	signature := client.hmac256(message, client.Secret)
 	return signature, nonce
@@ -81,7 +97,7 @@ func (client *Client) get(url string) ([]byte, error) {
 	}
 	defer resp.Body.Close()
 	var data []byte
-	if data, err = ioutil.ReadAll(resp.Body); err != nil {
+	if data, err = io.ReadAll(resp.Body); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -95,7 +111,7 @@ func (client *Client) post(url string, v url.Values) ([]byte, error) {
 	}
 	defer resp.Body.Close()
 	var data []byte
-	if data, err = ioutil.ReadAll(resp.Body); err != nil {
+	if data, err = io.ReadAll(resp.Body); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -127,9 +143,14 @@ func (client *Client) query(path string, params map[string]string, private bool)
 			return nil, err
 		}
 	} else {
+		n, unlock, err := LockNonce(client.Key, time.Now().UnixNano())
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
 		v := url.Values{}
 		// add required key, signature & nonce to values
-		signature, nonce := client.signature()
+		signature, nonce := client.signature(n)
 		v.Set("key", client.Key)
 		v.Set("signature", signature)
 		v.Set("nonce", nonce)
@@ -284,6 +305,22 @@ func (client *Client) OpenOrders(symbol1, symbol2 string) ([]Order, error) {
 	return output, nil
 }
 
+func (client *Client) GetOrder(id string) (*Order, error) {
+	var err error
+
+	var body []byte
+	if body, err = client.query("get_order/", map[string]string{"id": id}, true); err != nil {
+		return nil, err
+	}
+
+	var output Order
+	if err = json.Unmarshal(body, &output); err != nil {
+		return nil, errors.New(err.Error() + ": " + string(body))
+	}
+
+	return &output, nil
+}
+
 func (client *Client) ArchivedOrders(symbol1, symbol2 string) ([]Order, error) {
 	var err error
 
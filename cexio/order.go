@@ -34,6 +34,13 @@ func NewSide(data string) Side {
 	return SIDE_UNKNOWN
 }
 
+const (
+	ORDER_STATUS_ACTIVE              = "a"
+	ORDER_STATUS_DONE                = "d"
+	ORDER_STATUS_CANCELLED           = "c"
+	ORDER_STATUS_CANCELLED_PARTIALLY = "cd" // cancelled, but partially executed
+)
+
 type Order struct {
 	Id      string      `json:"id"`
 	Time    interface{} `json:"time"`
@@ -41,6 +48,8 @@ type Order struct {
 	Price   float64     `json:"price,string"`
 	Amount  float64     `json:"amount,string"`
 	Pending float64     `json:"pending,string"`
+	Remains float64     `json:"remains,string,omitempty"` // get_order only
+	Status  string      `json:"status,omitempty"`         // get_order only
 	Symbol1 string      `json:"symbol1"`
 	Symbol2 string      `json:"symbol2"`
 }
@@ -7,6 +7,7 @@ type Ticker struct {
 	Last      float64 `json:"last,string"`
 	Volume    float64 `json:"volume,string"`
 	Volume30d float64 `json:"volume30d,string"`
+	Change    float64 `json:"priceChangePercentage,string"`
 	Bid       float64 `json:"bid"`
 	Ask       float64 `json:"ask"`
 }
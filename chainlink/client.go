@@ -0,0 +1,152 @@
+package chainlink
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// the default JSON RPC of Ethereum mainnet, where the feeds below live
+const DEFAULT_RPC = "https://eth.llamarpc.com"
+
+const (
+	selectorDecimals        = "0x313ce567" // decimals()
+	selectorLatestRoundData = "0xfeaf968c" // latestRoundData()
+)
+
+// we refuse answers that haven't been updated for this long
+const MaxAge = 24 * time.Hour
+
+// Feeds maps a pair (for example ETH-USD) onto the address of its Chainlink aggregator on Ethereum mainnet
+var Feeds = map[string]string{
+	"BTC-USD": "0xF4030086522a5bEEa4988F8cA5B36dbC97BeE88c",
+	"ETH-USD": "0x5f4eC3Df9cbd43714FE2740f5E3616155c5b8419",
+}
+
+type Client struct {
+	RPC        string
+	httpClient *http.Client
+}
+
+func New(RPC string) *Client {
+	if RPC == "" {
+		RPC = DEFAULT_RPC
+	}
+	return &Client{
+		RPC: RPC,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (err *rpcError) Error() string {
+	return fmt.Sprintf("%d %s", err.Code, err.Message)
+}
+
+// ethCall executes a read-only call against a contract, and returns the ABI-encoded result
+func (client *Client) ethCall(address, data string) ([]byte, error) {
+	payload, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "eth_call",
+		"params":  []interface{}{map[string]string{"to": address, "data": data}, "latest"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.httpClient.Post(client.RPC, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return nil, errors.New(resp.Status)
+	}
+
+	var out struct {
+		Result string    `json:"result"`
+		Error  *rpcError `json:"error"`
+	}
+	if err = json.Unmarshal(body, &out); err != nil {
+		return nil, err
+	}
+	if out.Error != nil {
+		return nil, out.Error
+	}
+
+	result, ok := new(big.Int).SetString(strings.TrimPrefix(out.Result, "0x"), 16)
+	if !ok {
+		return nil, fmt.Errorf("cannot parse result %s", out.Result)
+	}
+	// left-pad to a multiple of 32 bytes, so that the words line up
+	size := (len(strings.TrimPrefix(out.Result, "0x")) + 1) / 2
+	if size%32 != 0 {
+		size += 32 - size%32
+	}
+	return result.FillBytes(make([]byte, size)), nil
+}
+
+// word returns the n-th 32-byte word of an ABI-encoded result
+func word(data []byte, n int) (*big.Int, error) {
+	if len(data) < (n+1)*32 {
+		return nil, errors.New("result is too short")
+	}
+	return new(big.Int).SetBytes(data[n*32 : (n+1)*32]), nil
+}
+
+// Price returns the latest answer of the aggregator at this address
+func (client *Client) Price(address string) (float64, error) {
+	data, err := client.ethCall(address, selectorDecimals)
+	if err != nil {
+		return 0, err
+	}
+	decimals, err := word(data, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	if data, err = client.ethCall(address, selectorLatestRoundData); err != nil {
+		return 0, err
+	}
+	// (roundId, answer, startedAt, updatedAt, answeredInRound)
+	answer, err := word(data, 1)
+	if err != nil {
+		return 0, err
+	}
+	updatedAt, err := word(data, 3)
+	if err != nil {
+		return 0, err
+	}
+
+	if answer.Sign() <= 0 || answer.Bit(255) == 1 {
+		return 0, fmt.Errorf("feed %s has no answer", address)
+	}
+	if time.Since(time.Unix(updatedAt.Int64(), 0)) > MaxAge {
+		return 0, fmt.Errorf("feed %s is stale", address)
+	}
+
+	out, _ := new(big.Float).Quo(
+		new(big.Float).SetInt(answer),
+		new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), decimals, nil)),
+	).Float64()
+
+	return out, nil
+}
@@ -0,0 +1,124 @@
+package chart
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/model"
+)
+
+// a chart is a small candlestick image that we attach to a notification, so that you can see at a glance where an
+// order got filled, and where our target and our stop are.
+
+const (
+	WIDTH  = 480
+	HEIGHT = 240
+	margin = 8
+)
+
+var (
+	Background = color.RGBA{0x16, 0x1a, 0x25, 0xff}
+	Up         = color.RGBA{0x26, 0xa6, 0x9a, 0xff}
+	Down       = color.RGBA{0xef, 0x53, 0x50, 0xff}
+	Entry      = color.RGBA{0x42, 0xa5, 0xf5, 0xff}
+	Target     = color.RGBA{0x66, 0xbb, 0x6a, 0xff}
+	Stop       = color.RGBA{0xff, 0xa7, 0x26, 0xff}
+)
+
+// Line is a horizontal (dashed) line at a price, for example: our entry, our target, or our stop
+type Line struct {
+	Price float64
+	Color color.RGBA
+}
+
+// Render draws the candles (oldest first) plus the lines, and returns a PNG
+func Render(candles model.Candles, lines ...Line) ([]byte, error) {
+	if len(candles) == 0 {
+		return nil, errors.New("cannot render a chart without candles")
+	}
+
+	// the price range, including our lines
+	low, high := candles[0].Low, candles[0].High
+	for _, candle := range candles {
+		if candle.Low < low {
+			low = candle.Low
+		}
+		if candle.High > high {
+			high = candle.High
+		}
+	}
+	for _, line := range lines {
+		if line.Price > 0 && line.Price < low {
+			low = line.Price
+		}
+		if line.Price > high {
+			high = line.Price
+		}
+	}
+	if high <= low {
+		high = low * 1.01
+		low = low * 0.99
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, WIDTH, HEIGHT))
+	draw.Draw(img, img.Bounds(), &image.Uniform{Background}, image.Point{}, draw.Src)
+
+	y := func(price float64) int {
+		return margin + int((high-price)/(high-low)*float64(HEIGHT-2*margin))
+	}
+
+	step := float64(WIDTH-2*margin) / float64(len(candles))
+	body := int(step * 0.7)
+	if body < 1 {
+		body = 1
+	}
+	for i, candle := range candles {
+		clr := Up
+		if candle.Close < candle.Open {
+			clr = Down
+		}
+		x := margin + int(float64(i)*step)
+		// the wick
+		vline(img, x+body/2, y(candle.High), y(candle.Low), clr)
+		// the body
+		top, bottom := y(candle.Open), y(candle.Close)
+		if top > bottom {
+			top, bottom = bottom, top
+		}
+		if bottom == top {
+			bottom++
+		}
+		draw.Draw(img, image.Rect(x, top, x+body, bottom), &image.Uniform{clr}, image.Point{}, draw.Src)
+	}
+
+	for _, line := range lines {
+		if line.Price <= 0 {
+			continue
+		}
+		ly := y(line.Price)
+		for x := 0; x < WIDTH; x++ {
+			if (x/6)%2 == 0 {
+				img.Set(x, ly, line.Color)
+			}
+		}
+	}
+
+	var out bytes.Buffer
+	if err := png.Encode(&out, img); err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+	return out.Bytes(), nil
+}
+
+func vline(img *image.RGBA, x, y1, y2 int, clr color.RGBA) {
+	if y1 > y2 {
+		y1, y2 = y2, y1
+	}
+	for y := y1; y <= y2; y++ {
+		img.Set(x, y, clr)
+	}
+}
@@ -0,0 +1,72 @@
+// Package clock keeps track of the difference between our (local) clock and the clock of an exchange.
+package clock
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	MaxDrift     = time.Second      // warn when our clock is off by more than this
+	SyncInterval = 15 * time.Minute // ask the exchange for its time this often
+)
+
+type Offset struct {
+	name      string
+	mutex     sync.Mutex
+	value     time.Duration
+	attempted time.Time
+	syncing   bool
+}
+
+func New(name string) *Offset {
+	return &Offset{name: name}
+}
+
+// Now returns our clock, adjusted to the clock of the exchange.
+func (o *Offset) Now() time.Time {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	return time.Now().Add(o.value)
+}
+
+// Sync asks the exchange for its time (through fn) when we haven't done so during the last SyncInterval.
+func (o *Offset) Sync(fn func() (time.Time, error)) {
+	o.mutex.Lock()
+	if o.syncing || (!o.attempted.IsZero() && time.Since(o.attempted) < SyncInterval) {
+		o.mutex.Unlock()
+		return
+	}
+	o.syncing = true
+	o.attempted = time.Now()
+	o.mutex.Unlock()
+
+	sent := time.Now()
+	server, err := fn()
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	o.syncing = false
+
+	if err != nil {
+		log.Printf("[WARN] cannot get %s server time: %v", o.name, err)
+		return
+	}
+
+	// assume the server read its clock halfway the round trip
+	o.value = server.Sub(sent.Add(time.Since(sent) / 2))
+	Warn(o.name, o.value)
+}
+
+// Warn logs a warning when the offset between our clock and the clock of an exchange exceeds MaxDrift.
+func Warn(name string, offset time.Duration) {
+	if offset > MaxDrift || offset < -MaxDrift {
+		log.Printf("[WARN] your clock is off by %v compared to %s. Please synchronize your clock with a time server.", offset.Round(time.Millisecond), name)
+	}
+}
+
+// FromMilli converts a Unix timestamp in milliseconds to time.Time
+func FromMilli(msec int64) time.Time {
+	return time.Unix(0, msec*int64(time.Millisecond))
+}
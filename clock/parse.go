@@ -0,0 +1,41 @@
+package clock
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/svanas/nefertiti/errors"
+)
+
+// the layouts we try when the exchange doesn't use the layout it says it does. a layout without a time zone is UTC.
+var layouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02T15:04:05.999999999Z",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// Parse parses a timestamp that we got from an exchange. we try the layout(s) of the exchange first, then every
+// layout we know about, and last but not least a unix timestamp in seconds or milliseconds. the result is in UTC.
+func Parse(value string, layout ...string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, errors.New("cannot parse empty timestamp")
+	}
+	for _, l := range append(layout, layouts...) {
+		if out, err := time.Parse(l, value); err == nil {
+			return out.UTC(), nil
+		}
+	}
+	if unix, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if unix > 1e11 { // milliseconds
+			return time.Unix(0, unix*int64(time.Millisecond)).UTC(), nil
+		}
+		return time.Unix(unix, 0).UTC(), nil
+	}
+	return time.Time{}, errors.Errorf("cannot parse timestamp %s", value)
+}
@@ -0,0 +1,22 @@
+package coinex
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"net/url"
+	"strings"
+)
+
+// sign a request, returns the authorization header
+func signature(secretKey string, params url.Values) string {
+	// sort the params alphabetically, append the secret key, then MD5 the unescaped result
+	normalized, err := url.QueryUnescape(params.Encode())
+	if err != nil {
+		normalized = params.Encode()
+	}
+	if normalized != "" {
+		normalized = normalized + "&"
+	}
+	hash := md5.Sum([]byte(normalized + "secret_key=" + secretKey))
+	return strings.ToUpper(hex.EncodeToString(hash[:]))
+}
@@ -0,0 +1,23 @@
+package coinex
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestSignature(t *testing.T) {
+	params := url.Values{}
+	params.Add("access_id", "4DA36FFC61334695A66F8D29020EB589")
+	params.Add("market", "BTCUSDT")
+	params.Add("type", "sell")
+	params.Add("amount", "1.0")
+	params.Add("price", "680")
+	params.Add("tonce", "1513746038205")
+
+	calculated := signature("B51068CF10B34E7789C374AB932696A05E0A629BE7BFC62F", params)
+	expected := "65FE986CD8ADFAEC683CEB44FDA15E5A"
+
+	if calculated != expected {
+		t.Errorf("TestSignature failed, got: %v, want: %v.", calculated, expected)
+	}
+}
@@ -0,0 +1,56 @@
+package coinex
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+type BookEntry struct {
+	Price  float64
+	Amount float64
+}
+
+func (entry *BookEntry) UnmarshalJSON(data []byte) error {
+	var aux []string
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if len(aux) > 1 {
+		var err error
+		if entry.Price, err = strconv.ParseFloat(aux[0], 64); err != nil {
+			return err
+		}
+		if entry.Amount, err = strconv.ParseFloat(aux[1], 64); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type OrderBook struct {
+	Asks []BookEntry `json:"asks"`
+	Bids []BookEntry `json:"bids"`
+}
+
+func (client *Client) OrderBook(market string) (*OrderBook, error) {
+	params := url.Values{}
+	params.Add("market", market)
+	params.Add("merge", "0")
+	params.Add("limit", "50")
+
+	var (
+		err  error
+		data json.RawMessage
+		out  OrderBook
+	)
+	if data, err = client.call(http.MethodGet, "/market/depth", params, false); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
@@ -0,0 +1,149 @@
+package coinex
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/ratelimit"
+)
+
+const (
+	API_BASE = "https://api.coinex.com/v1"
+)
+
+var (
+	lastRequest       time.Time
+	RequestsPerSecond float64                         = 10
+	BeforeRequest     func(method, path string) error = nil
+	AfterRequest      func()                          = nil
+)
+
+func init() {
+	BeforeRequest = func(method, path string) error {
+		elapsed := time.Since(lastRequest)
+		if elapsed.Seconds() < (float64(1) / RequestsPerSecond) {
+			time.Sleep(time.Duration((float64(time.Second) / RequestsPerSecond) - float64(elapsed)))
+		}
+		return nil
+	}
+	AfterRequest = func() {
+		lastRequest = time.Now()
+	}
+}
+
+type Client struct {
+	URL        string
+	accessID   string
+	secretKey  string
+	httpClient *http.Client
+}
+
+func New(URL, accessID, secretKey string) *Client {
+	return &Client{
+		URL,
+		accessID,
+		secretKey,
+		&http.Client{
+			Timeout:   30 * time.Second,
+			Transport: ratelimit.NewTransport("CoinEx", nil),
+		},
+	}
+}
+
+type response struct {
+	Code    int             `json:"code"`
+	Data    json.RawMessage `json:"data"`
+	Message string          `json:"message"`
+}
+
+func (client *Client) do(req *http.Request) (json.RawMessage, error) {
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+			return nil, errors.NewStatusError(resp.StatusCode, resp.Status)
+		}
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return nil, errors.NewStatusError(resp.StatusCode, resp.Status)
+	}
+
+	// coinex returns HTTP 200 OK and a non-zero code in the body when something went wrong
+	var out response
+	if err = json.Unmarshal(body, &out); err != nil {
+		return nil, err
+	}
+	if out.Code != 0 {
+		return nil, &Error{Code: out.Code, Message: out.Message}
+	}
+
+	return out.Data, nil
+}
+
+func (client *Client) call(method, path string, params url.Values, auth bool) (json.RawMessage, error) {
+	// respect the rate limit
+	err := BeforeRequest(method, path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		AfterRequest()
+	}()
+
+	if params == nil {
+		params = url.Values{}
+	}
+	if auth {
+		params.Set("access_id", client.accessID)
+		params.Set("tonce", strconv.FormatInt(time.Now().UnixNano()/int64(time.Millisecond), 10))
+	}
+
+	// set the endpoint for this request
+	endpoint, err := url.Parse(client.URL)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path += path
+
+	// create the request. POST requests have a JSON body, the other methods have a query string.
+	var body io.Reader
+	if method == http.MethodPost {
+		payload := make(map[string]string)
+		for key := range params {
+			payload[key] = params.Get(key)
+		}
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(data)
+	} else {
+		endpoint.RawQuery = params.Encode()
+	}
+	req, err := http.NewRequest(method, endpoint.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", "application/json")
+
+	// add autentication header
+	if auth {
+		req.Header.Add("authorization", signature(client.secretKey, params))
+	}
+
+	// do the request
+	return client.do(req)
+}
@@ -0,0 +1,14 @@
+package coinex
+
+import (
+	"fmt"
+)
+
+type Error struct {
+	Code    int
+	Message string
+}
+
+func (err *Error) Error() string {
+	return fmt.Sprintf("%d %s", err.Code, err.Message)
+}
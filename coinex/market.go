@@ -0,0 +1,37 @@
+package coinex
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+type Market struct {
+	Name           string  `json:"name"`
+	MinAmount      float64 `json:"min_amount,string"`
+	MakerFeeRate   float64 `json:"maker_fee_rate,string"`
+	TakerFeeRate   float64 `json:"taker_fee_rate,string"`
+	PricingName    string  `json:"pricing_name"` // quote currency
+	PricingDecimal int     `json:"pricing_decimal"`
+	TradingName    string  `json:"trading_name"` // base currency
+	TradingDecimal int     `json:"trading_decimal"`
+}
+
+func (client *Client) Markets() (map[string]Market, error) {
+	var (
+		err  error
+		data json.RawMessage
+		out  map[string]Market
+	)
+	if data, err = client.call(http.MethodGet, "/market/info", nil, false); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func FormatMarket(base, quote string) string {
+	return strings.ToUpper(base) + strings.ToUpper(quote)
+}
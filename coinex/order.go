@@ -0,0 +1,194 @@
+package coinex
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	OrderSide   string
+	OrderStatus string
+)
+
+const (
+	OrderSideBuy  OrderSide = "buy"
+	OrderSideSell OrderSide = "sell"
+)
+
+const (
+	OrderStatusNotDeal  OrderStatus = "not_deal"
+	OrderStatusPartDeal OrderStatus = "part_deal"
+	OrderStatusDone     OrderStatus = "done"
+	OrderStatusCancel   OrderStatus = "cancel"
+)
+
+type Order struct {
+	ID         int64       `json:"id"`
+	Market     string      `json:"market"`
+	Type       OrderSide   `json:"type"`
+	OrderType  string      `json:"order_type"`
+	Status     OrderStatus `json:"status"`
+	Amount     float64     `json:"amount,string"`
+	Price      float64     `json:"price,string"`
+	AvgPrice   float64     `json:"avg_price,string"`
+	DealAmount float64     `json:"deal_amount,string"`
+	DealMoney  float64     `json:"deal_money,string"`
+	DealFee    float64     `json:"deal_fee,string"`
+	FeeAsset   string      `json:"fee_asset"`
+	CreateTime int64       `json:"create_time"`
+	FinishedAt int64       `json:"finished_time"`
+	ClientID   string      `json:"client_id"`
+}
+
+func (order *Order) CreatedAt() time.Time {
+	return time.Unix(order.CreateTime, 0)
+}
+
+func (order *Order) ClosedAt() time.Time {
+	return time.Unix(order.FinishedAt, 0)
+}
+
+// a finished order has been filled if (part of) the amount got traded, otherwise it has been cancelled.
+func (order *Order) Filled() bool {
+	return order.DealAmount > 0
+}
+
+func (order *Order) ExecutedAt() float64 {
+	if order.AvgPrice > 0 {
+		return order.AvgPrice
+	}
+	return order.Price
+}
+
+// the fee is deducted from the base currency, unless it got paid in another asset (for example CET)
+func (order *Order) AmountMinusFee(base string) float64 {
+	if order.FeeAsset == "" || strings.EqualFold(order.FeeAsset, base) {
+		return order.DealAmount - order.DealFee
+	}
+	return order.DealAmount
+}
+
+func (client *Client) LimitOrder(market string, side OrderSide, amount, price float64, clientID string) (*Order, error) {
+	params := url.Values{}
+	params.Add("market", market)
+	params.Add("type", string(side))
+	params.Add("amount", strconv.FormatFloat(amount, 'f', -1, 64))
+	params.Add("price", strconv.FormatFloat(price, 'f', -1, 64))
+	if clientID != "" {
+		params.Add("client_id", clientID)
+	}
+	return client.placeOrder("/order/limit", params)
+}
+
+// market buy orders are placed for an amount in quote currency, market sell orders for an amount in base currency.
+func (client *Client) MarketOrder(market string, side OrderSide, amount float64, clientID string) (*Order, error) {
+	params := url.Values{}
+	params.Add("market", market)
+	params.Add("type", string(side))
+	params.Add("amount", strconv.FormatFloat(amount, 'f', -1, 64))
+	if clientID != "" {
+		params.Add("client_id", clientID)
+	}
+	return client.placeOrder("/order/market", params)
+}
+
+func (client *Client) placeOrder(path string, params url.Values) (*Order, error) {
+	var (
+		err  error
+		data json.RawMessage
+		out  Order
+	)
+	if data, err = client.call(http.MethodPost, path, params, true); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (client *Client) CancelOrder(market string, id int64) error {
+	params := url.Values{}
+	params.Add("market", market)
+	params.Add("id", strconv.FormatInt(id, 10))
+
+	_, err := client.call(http.MethodDelete, "/order/pending", params, true)
+
+	return err
+}
+
+func (client *Client) GetOrder(market string, id int64) (*Order, error) {
+	params := url.Values{}
+	params.Add("market", market)
+	params.Add("id", strconv.FormatInt(id, 10))
+
+	var (
+		err  error
+		data json.RawMessage
+		out  Order
+	)
+	if data, err = client.call(http.MethodGet, "/order/status", params, true); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+type orderPage struct {
+	Count    int     `json:"count"`
+	CurrPage int     `json:"curr_page"`
+	Data     []Order `json:"data"`
+	HasNext  bool    `json:"has_next"`
+}
+
+func (client *Client) orders(path, market string) ([]Order, error) {
+	var (
+		page   int64 = 1
+		result []Order
+	)
+
+	for {
+		params := url.Values{}
+		params.Add("market", market)
+		params.Add("page", strconv.FormatInt(page, 10))
+		params.Add("limit", "100")
+
+		var (
+			err  error
+			data json.RawMessage
+			out  orderPage
+		)
+		if data, err = client.call(http.MethodGet, path, params, true); err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(data, &out); err != nil {
+			return nil, err
+		}
+
+		result = append(result, out.Data...)
+
+		if !out.HasNext || len(out.Data) == 0 {
+			break
+		}
+
+		page++
+	}
+
+	return result, nil
+}
+
+// returns the orders that have not (completely) been filled yet
+func (client *Client) OpenOrders(market string) ([]Order, error) {
+	return client.orders("/order/pending", market)
+}
+
+// returns the orders that have been filled or cancelled, aka the order history
+func (client *Client) FinishedOrders(market string) ([]Order, error) {
+	return client.orders("/order/finished", market)
+}
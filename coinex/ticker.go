@@ -0,0 +1,58 @@
+package coinex
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+type Ticker struct {
+	Buy  float64 `json:"buy,string"`
+	Sell float64 `json:"sell,string"`
+	Open float64 `json:"open,string"`
+	High float64 `json:"high,string"`
+	Low  float64 `json:"low,string"`
+	Last float64 `json:"last,string"`
+	Vol  float64 `json:"vol,string"` // 24h volume in base currency
+}
+
+func (client *Client) Ticker(market string) (*Ticker, error) {
+	params := url.Values{}
+	params.Add("market", market)
+
+	var (
+		err  error
+		data json.RawMessage
+		out  struct {
+			Date   int64  `json:"date"`
+			Ticker Ticker `json:"ticker"`
+		}
+	)
+	if data, err = client.call(http.MethodGet, "/market/ticker", params, false); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+
+	return &out.Ticker, nil
+}
+
+// Tickers returns the tickers of every market, indexed by market
+func (client *Client) Tickers() (map[string]Ticker, error) {
+	var (
+		err  error
+		data json.RawMessage
+		out  struct {
+			Date   int64             `json:"date"`
+			Ticker map[string]Ticker `json:"ticker"`
+		}
+	)
+	if data, err = client.call(http.MethodGet, "/market/ticker/all", nil, false); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out.Ticker, nil
+}
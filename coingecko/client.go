@@ -0,0 +1,120 @@
+package coingecko
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	API_BASE = "https://api.coingecko.com/api/v3"
+)
+
+var (
+	lastRequest   time.Time
+	BeforeRequest func(apiKey, path string) error = nil
+	AfterRequest  func()                          = nil
+)
+
+func init() {
+	BeforeRequest = func(apiKey, path string) error {
+		elapsed := time.Since(lastRequest)
+		if elapsed.Seconds() < (float64(1) / RequestsPerSecond(apiKey)) {
+			time.Sleep(time.Duration((float64(time.Second) / RequestsPerSecond(apiKey))) - elapsed)
+		}
+		return nil
+	}
+	AfterRequest = func() {
+		lastRequest = time.Now()
+	}
+}
+
+// the public API allows for (roughly) 10 calls per minute, a demo key allows for 30 calls per minute
+func RequestsPerSecond(apiKey string) float64 {
+	if apiKey != "" {
+		return 0.5
+	} else {
+		return 0.16
+	}
+}
+
+type Client struct {
+	Key        string // optional
+	httpClient *http.Client
+}
+
+func New(apiKey string) *Client {
+	return &Client{
+		Key: apiKey,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+func (client *Client) query(path string, params url.Values) ([]byte, error) {
+	var err error
+
+	// parse the coingecko address
+	var endpoint *url.URL
+	if endpoint, err = url.Parse(API_BASE); err != nil {
+		return nil, err
+	}
+
+	// set the endpoint for this request
+	endpoint.Path += path
+	if params != nil {
+		endpoint.RawQuery = params.Encode()
+	}
+
+	// satisfy the rate limits
+	if err = BeforeRequest(client.Key, path); err != nil {
+		return nil, err
+	}
+	defer func() {
+		AfterRequest()
+	}()
+
+	req, err := http.NewRequest(http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if client.Key != "" {
+		req.Header.Add("x-cg-demo-api-key", client.Key)
+	}
+
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		type Error struct {
+			Error  string `json:"error"`
+			Status struct {
+				ErrorMessage string `json:"error_message"`
+			} `json:"status"`
+		}
+		var out Error
+		if json.Unmarshal(body, &out) == nil {
+			if out.Error != "" {
+				return nil, errors.New(out.Error)
+			}
+			if out.Status.ErrorMessage != "" {
+				return nil, errors.New(out.Status.ErrorMessage)
+			}
+		}
+		return nil, errors.New(resp.Status)
+	}
+
+	return body, nil
+}
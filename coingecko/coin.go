@@ -0,0 +1,60 @@
+package coingecko
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Coin struct {
+	Id     string `json:"id"`
+	Symbol string `json:"symbol"`
+	Name   string `json:"name"`
+}
+
+// the coin list hardly ever changes, so we cache it for a day
+var (
+	coins      []Coin
+	coinsTime  time.Time
+	coinsMutex sync.Mutex
+)
+
+func (client *Client) Coins() ([]Coin, error) {
+	coinsMutex.Lock()
+	defer coinsMutex.Unlock()
+
+	if coins != nil && time.Since(coinsTime) < 24*time.Hour {
+		return coins, nil
+	}
+
+	body, err := client.query("/coins/list", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []Coin
+	if err = json.Unmarshal(body, &out); err != nil {
+		return nil, err
+	}
+
+	coins = out
+	coinsTime = time.Now()
+
+	return out, nil
+}
+
+// returns the ids of the coins that go by this symbol. more often than not, there is more than one.
+func (client *Client) CoinIds(symbol string) ([]string, error) {
+	coins, err := client.Coins()
+	if err != nil {
+		return nil, err
+	}
+	var out []string
+	for _, coin := range coins {
+		if strings.EqualFold(coin.Symbol, symbol) {
+			out = append(out, coin.Id)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,76 @@
+package coingecko
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+type Market struct {
+	Id                       string  `json:"id"`
+	Symbol                   string  `json:"symbol"`
+	Name                     string  `json:"name"`
+	CurrentPrice             float64 `json:"current_price"`
+	MarketCap                float64 `json:"market_cap"`
+	TotalVolume              float64 `json:"total_volume"` // 24h volume
+	High24h                  float64 `json:"high_24h"`
+	Low24h                   float64 `json:"low_24h"`
+	PriceChangePercentage24h float64 `json:"price_change_percentage_24h"`
+}
+
+// returns price, market cap and 24h volume for a list of coin ids, expressed in vsCurrency (for example: usd)
+func (client *Client) Markets(vsCurrency string, ids []string) ([]Market, error) {
+	body, err := client.query("/coins/markets", url.Values{
+		"vs_currency": {strings.ToLower(vsCurrency)},
+		"ids":         {strings.Join(ids, ",")},
+		"order":       {"market_cap_desc"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var out []Market
+	if err = json.Unmarshal(body, &out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// returns the market data for a symbol. when more than one coin goes by this symbol, we pick the one with the biggest market cap.
+func (client *Client) GetMarket(symbol, vsCurrency string) (*Market, error) {
+	ids, err := client.CoinIds(symbol)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("coin %s does not exist", symbol)
+	}
+
+	markets, err := client.Markets(vsCurrency, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var out *Market
+	for i, market := range markets {
+		if out == nil || market.MarketCap > out.MarketCap {
+			out = &markets[i]
+		}
+	}
+	if out == nil {
+		return nil, fmt.Errorf("no market data for %s", symbol)
+	}
+
+	return out, nil
+}
+
+// returns the price of a symbol, expressed in vsCurrency
+func (client *Client) Price(symbol, vsCurrency string) (float64, error) {
+	market, err := client.GetMarket(symbol, vsCurrency)
+	if err != nil {
+		return 0, err
+	}
+	return market.CurrentPrice, nil
+}
@@ -0,0 +1,68 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/svanas/nefertiti/exchanges"
+	"github.com/svanas/nefertiti/flag"
+)
+
+type (
+	ApproveCommand struct {
+		*CommandMeta
+	}
+)
+
+func (c *ApproveCommand) Run(args []string) int {
+	var (
+		err error
+		out []byte
+	)
+
+	// without an id, list the orders that are waiting for your approval
+	id := flag.Get("id").String()
+	if id == "" {
+		var approvals exchanges.Approvals
+		if approvals, err = exchanges.GetApprovals(); err != nil {
+			return c.ReturnError(err)
+		}
+		if out, err = json.Marshal(approvals); err != nil {
+			return c.ReturnError(err)
+		}
+		fmt.Println(string(out))
+		return 0
+	}
+
+	var approval *exchanges.Approval
+	if approval, err = exchanges.Approve(id, !flag.Exists("reject")); err != nil {
+		return c.ReturnError(err)
+	}
+
+	if out, err = json.Marshal(approval); err != nil {
+		return c.ReturnError(err)
+	}
+	fmt.Println(string(out))
+
+	return 0
+}
+
+func (c *ApproveCommand) Help() string {
+	text := `
+Usage: ./nefertiti approve [options]
+
+The approve command approves (or rejects) an order that is waiting for your
+approval because it is worth more than --approve-above. Without --id, the
+command lists the orders that are waiting for your approval.
+
+Options:
+  --id     = the id of the approval (optional)
+  --reject = if included, rejects the order (optional)
+`
+	return strings.TrimSpace(text)
+}
+
+func (c *ApproveCommand) Synopsis() string {
+	return "Approve (or reject) an order that is waiting for your approval."
+}
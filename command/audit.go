@@ -0,0 +1,147 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/i18n"
+	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/session"
+)
+
+type (
+	AuditCommand struct {
+		*CommandMeta
+	}
+)
+
+func (c *AuditCommand) Run(args []string) int {
+	output := "json"
+	if arg := flag.Get("output"); arg.Exists && arg.String() != "" {
+		output = strings.ToLower(arg.String())
+	}
+	if output != "json" && output != "table" {
+		return c.ReturnError(errors.Errorf("output %s is invalid", output))
+	}
+
+	hours := 24.0
+	if arg := flag.Get("hours"); arg.Exists {
+		var err error
+		if hours, err = arg.Float64(); err != nil || hours <= 0 {
+			return c.ReturnError(errors.Errorf("hours %v is invalid", arg))
+		}
+	}
+
+	changes, err := session.Changes(time.Now().Add(-time.Duration(hours * float64(time.Hour))))
+	if err != nil {
+		return c.ReturnError(err)
+	}
+
+	switch output {
+	case "table":
+		tbl := table.NewWriter()
+		tbl.SetOutputMirror(os.Stdout)
+		tbl.AppendHeader(table.Row{"Time", "Source", "Run", "Flag", "From", "To"})
+		for _, change := range changes {
+			tbl.AppendRow(table.Row{change.Time.Format("2006-01-02 15:04:05"), change.Source, change.Run, change.Name, change.From, change.To})
+		}
+		tbl.Render()
+	default:
+		if changes == nil {
+			changes = []session.Change{}
+		}
+		data, err := json.Marshal(changes)
+		if err != nil {
+			return c.ReturnError(err)
+		}
+		fmt.Println(string(data))
+	}
+
+	return 0
+}
+
+func (c *AuditCommand) Help() string {
+	text := `
+Usage: ./nefertiti audit [options]
+
+The audit command prints the settings that changed while the bot was running:
+a new multiplier, a market added to the hold list, a loop that got paused.
+Every change comes with a timestamp, its source and the loop that changed.
+The sell command sends you a daily summary of these changes (with --notify=2
+or higher, and only if something changed).
+
+Options:
+  --hours  = the changes during the last X hours (optional, defaults to 24)
+  --output = [json|table] (optional, defaults to json)
+  --user   = name of the user (optional)
+`
+	return strings.TrimSpace(text)
+}
+
+func (c *AuditCommand) Synopsis() string {
+	return "Prints the settings that changed at runtime."
+}
+
+const (
+	auditSummaryCursor   = "audit.summary" // the time of the last daily summary
+	auditSummaryInterval = 24 * time.Hour
+)
+
+// auditSummary sends the settings that changed since the last summary, if the last summary is a day old. the loops
+// that share the session dir share the cursor, so that you get one summary a day between them.
+func auditSummary(service model.Notify) error {
+	mutex, err := session.NewFileMutex(auditSummaryCursor + ".lock")
+	if err != nil {
+		return err
+	}
+	if err = mutex.Lock(); err != nil {
+		return err
+	}
+	defer mutex.Unlock()
+
+	last, err := session.GetCursor(auditSummaryCursor)
+	if err != nil {
+		return err
+	}
+	if last == nil {
+		// this is our first run. the first summary is a day from now.
+		return session.SetCursor(auditSummaryCursor, time.Now())
+	}
+	if time.Since(*last) < auditSummaryInterval {
+		return nil
+	}
+
+	changes, err := session.Changes(*last)
+	if err != nil {
+		return err
+	}
+	if err = session.SetCursor(auditSummaryCursor, time.Now()); err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	var lines []string
+	for _, change := range changes {
+		lines = append(lines, fmt.Sprintf("%s %s changed %s from %q to %q", change.Time.Format("15:04"), change.Source, change.Name, change.From, change.To))
+	}
+	return service.SendMessage(strings.Join(lines, "\n"), i18n.Sprintf("Settings changed (%d)", len(changes)), model.ALWAYS)
+}
+
+// watchAuditSummary checks every hour if the daily summary is due
+func watchAuditSummary(service model.Notify) {
+	for {
+		if err := auditSummary(service); err != nil {
+			log.Printf("[ERROR] %v", err)
+		}
+		time.Sleep(time.Hour)
+	}
+}
@@ -0,0 +1,246 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/svanas/nefertiti/ecb"
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/exchanges"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/model"
+)
+
+type (
+	BenchmarkCommand struct {
+		*CommandMeta
+	}
+	benchmark struct {
+		Market     string    `json:"market"`
+		Quote      string    `json:"quote"`
+		From       time.Time `json:"from"`
+		To         time.Time `json:"to"`
+		Trades     int       `json:"trades"`
+		BotPnL     float64   `json:"bot_pnl"`                 // realized, in quote currency
+		BotReturn  float64   `json:"bot_return,omitempty"`    // in percent, if you included --capital
+		HoldReturn float64   `json:"hold_return"`             // in percent
+		HoldPnL    float64   `json:"hold_pnl,omitempty"`      // in quote currency, if you included --capital
+		Excess     float64   `json:"excess_return,omitempty"` // bot minus hold, in percent, if you included --capital
+		Currency   string    `json:"currency,omitempty"`      // if you included --currency
+		Converted  *float64  `json:"converted_pnl,omitempty"` // bot PnL in --currency, if we have an exchange rate for the quote
+	}
+)
+
+func (c *BenchmarkCommand) Run(args []string) int {
+	var err error
+
+	output := "json"
+	if arg := flag.Get("output"); arg.Exists && arg.String() != "" {
+		output = strings.ToLower(arg.String())
+	}
+	if output != "json" && output != "table" {
+		return c.ReturnError(errors.Errorf("output %s is invalid", output))
+	}
+
+	var exchange model.Exchange
+	if exchange, err = exchanges.GetExchange(); err != nil {
+		return c.ReturnError(err)
+	}
+
+	var capital float64
+	if arg := flag.Get("capital"); arg.Exists {
+		if capital, err = arg.Float64(); err != nil || capital <= 0 {
+			return c.ReturnError(errors.Errorf("capital %v is invalid", arg))
+		}
+	}
+
+	start, err := parseDate("from")
+	if err != nil {
+		return c.ReturnError(err)
+	}
+	end, err := parseDate("to")
+	if err != nil {
+		return c.ReturnError(err)
+	}
+	if end == nil {
+		now := time.Now()
+		end = &now
+	}
+
+	// before since, the journal holds your profits without your losses
+	outcomes, since, err := exchanges.GetOutcomes(exchange)
+	if err != nil {
+		return c.ReturnError(err)
+	}
+	if start != nil && start.Before(since) {
+		start = &since
+	}
+
+	// the realized PnL per market, over the period
+	filter := flag.Get("market")
+	bench := make(map[string]*benchmark)
+	for _, outcome := range outcomes {
+		if filter.Exists && filter.String() != "" && !filter.Contains(outcome.Market) {
+			continue
+		}
+		if (start != nil && outcome.Time.Before(*start)) || !outcome.Time.Before(*end) {
+			continue
+		}
+		entry, ok := bench[outcome.Market]
+		if !ok {
+			entry = &benchmark{Market: outcome.Market, Quote: outcome.Quote, From: outcome.Time, To: *end}
+			if start != nil {
+				entry.From = *start
+			}
+			bench[outcome.Market] = entry
+		}
+		if start == nil && outcome.Time.Before(entry.From) {
+			entry.From = outcome.Time
+		}
+		if start == nil {
+			// the outcome is the exit, and we entered before that. buy-and-hold starts at the open of that day.
+			entry.From = entry.From.UTC().Truncate(24 * time.Hour)
+		}
+		entry.Trades++
+		entry.BotPnL += outcome.Amount
+	}
+	if len(bench) == 0 {
+		return c.ReturnError(errors.New("no realized profits (or losses) in this period"))
+	}
+
+	var client interface{}
+	if client, err = exchange.GetClient(model.PUBLIC, flag.Sandbox()); err != nil {
+		return c.ReturnError(err)
+	}
+
+	// buy-and-hold: buy at the open of the first candle, value at the close of the last one
+	var out []benchmark
+	for _, entry := range bench {
+		candles, err := exchanges.GetCandles(exchange, client, entry.Market, 24*time.Hour, entry.From, entry.To)
+		if err != nil {
+			return c.ReturnError(err)
+		}
+		// today has no daily candle yet? then we ask for the hourly ones
+		if len(candles) == 0 {
+			if candles, err = exchanges.GetCandles(exchange, client, entry.Market, time.Hour, entry.From, entry.To); err != nil {
+				return c.ReturnError(err)
+			}
+		}
+		if len(candles) == 0 || candles[0].Open == 0 {
+			return c.ReturnError(errors.Errorf("%s does not have candles between %v and %v", entry.Market, entry.From, entry.To))
+		}
+		entry.HoldReturn = (candles[len(candles)-1].Close/candles[0].Open - 1) * 100
+		if capital > 0 {
+			entry.BotReturn = entry.BotPnL / capital * 100
+			entry.HoldPnL = capital * entry.HoldReturn / 100
+			entry.Excess = entry.BotReturn - entry.HoldReturn
+		}
+		out = append(out, *entry)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Market < out[j].Market
+	})
+
+	// convert the fiat (and stablecoin) quotes into the reporting currency, at today's ECB reference rate
+	var total float64
+	currency := flag.Currency()
+	if currency != "" {
+		rates, err := ecb.New().Rates()
+		if err != nil {
+			return c.ReturnError(err)
+		}
+		if !rates.Has(currency) {
+			return c.ReturnError(errors.Errorf("currency %s is invalid", currency))
+		}
+		for i := range out {
+			out[i].Currency = currency
+			if rates.Has(out[i].Quote) {
+				converted, err := rates.Convert(out[i].BotPnL, out[i].Quote, currency)
+				if err != nil {
+					return c.ReturnError(err)
+				}
+				out[i].Converted = &converted
+				total += converted
+			}
+		}
+	}
+
+	switch output {
+	case "table":
+		tbl := table.NewWriter()
+		tbl.SetOutputMirror(os.Stdout)
+		header := table.Row{"Market", "From", "To", "Trades", "Bot PnL", "Bot %", "Hold %", "Hold PnL", "Excess %"}
+		if currency != "" {
+			header = append(header, "PnL "+currency)
+		}
+		tbl.AppendHeader(header)
+		for _, entry := range out {
+			row := table.Row{
+				entry.Market,
+				entry.From.Format("2006-01-02"),
+				entry.To.Format("2006-01-02"),
+				entry.Trades,
+				fmt.Sprintf("%.8f %s", entry.BotPnL, entry.Quote),
+				fmt.Sprintf("%.2f", entry.BotReturn),
+				fmt.Sprintf("%.2f", entry.HoldReturn),
+				fmt.Sprintf("%.8f %s", entry.HoldPnL, entry.Quote),
+				fmt.Sprintf("%.2f", entry.Excess),
+			}
+			if currency != "" {
+				converted := "n/a"
+				if entry.Converted != nil {
+					converted = fmt.Sprintf("%.2f", *entry.Converted)
+				}
+				row = append(row, converted)
+			}
+			tbl.AppendRow(row)
+		}
+		if currency != "" {
+			tbl.AppendFooter(table.Row{"Total", "", "", "", "", "", "", "", "", fmt.Sprintf("%.2f", total)})
+		}
+		tbl.Render()
+	default:
+		data, err := json.Marshal(out)
+		if err != nil {
+			return c.ReturnError(err)
+		}
+		fmt.Println(string(data))
+	}
+
+	return 0
+}
+
+func (c *BenchmarkCommand) Help() string {
+	text := `
+Usage: ./nefertiti benchmark [options]
+
+The benchmark command compares the realized PnL of the bot, per market, with
+simply buying and holding that market over the same period. The prices come
+from the candle store, and we download the daily candles that are missing.
+
+Options:
+  --exchange = name, for example: Binance
+  --market   = a valid market pair, or a comma-separated list of pairs
+               (optional, defaults to every market in your journal)
+  --capital  = what you put to work per market, in quote currency. if included,
+               we compare the returns in percent, and what buy-and-hold would
+               have made in quote currency (optional)
+  --from     = the first day, for example: 2024-01-01 (optional, defaults to
+               the first trade per market)
+  --to       = up to (not including) this day (optional, defaults to now)
+  --currency = convert the bot PnL of the fiat (and stablecoin) quoted markets
+               into this currency, at today's ECB reference rate. for example:
+               EUR, GBP or KRW (optional)
+  --output   = [json|table] (optional, defaults to json)
+`
+	return strings.TrimSpace(text)
+}
+
+func (c *BenchmarkCommand) Synopsis() string {
+	return "Compares the bot with buy-and-hold."
+}
@@ -0,0 +1,120 @@
+package command
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/exchanges"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/multiplier"
+	"github.com/svanas/nefertiti/precision"
+	"github.com/svanas/nefertiti/pricing"
+)
+
+// in bootstrap mode (--bootstrap), the sell command looks at the assets you are holding before it starts listening,
+// and opens a limit sell at --mult above your cost basis for every one of them. this way, the bags that you bought
+// before you installed the bot get managed right away.
+
+// askCost prompts for the price you paid for an asset. returns zero if you do not know (or in --listen mode).
+func askCost(asset, quote string) (float64, error) {
+	if flag.Listen() {
+		return 0, nil
+	}
+	fmt.Printf("Price you paid (in %s) for your %s, or leave empty to skip: ", quote, asset)
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return 0, errors.Wrap(err, 1)
+	}
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return 0, nil
+	}
+	out, err := strconv.ParseFloat(line, 64)
+	if err != nil || out < 0 {
+		return 0, errors.Errorf("price %s is invalid", line)
+	}
+	return out, nil
+}
+
+func bootstrap(exchange model.Exchange, all []model.Market, mult multiplier.Mult, hold model.Markets) error {
+	reader, ok := exchange.(model.BalanceReader)
+	if !ok {
+		return errors.Errorf("%s does not support --bootstrap", exchange.GetInfo().Name)
+	}
+
+	// --quote is required, we need to know what market to sell the asset on
+	quote := flag.Get("quote").String()
+	if quote == "" {
+		return errors.New("missing argument: quote")
+	}
+
+	client, err := exchange.GetClient(model.PRIVATE, flag.Sandbox())
+	if err != nil {
+		return err
+	}
+
+	balances, err := reader.GetBalances(client)
+	if err != nil {
+		return err
+	}
+
+	for asset, balance := range balances {
+		if balance == 0 || strings.EqualFold(asset, quote) {
+			continue
+		}
+
+		m := model.FindMarket(all, model.NewMarketSymbol(asset, quote))
+		if m == nil || hold.HasMarket(m.Name) {
+			continue
+		}
+		market := m.Name
+
+		// --cost=ASSET:X, or the cost basis from the import command, or we will ask
+		var cost float64
+		if cost, err = flag.Cost(asset); err != nil {
+			return err
+		}
+		if cost == 0 {
+			if cost, err = exchanges.CostBasis(exchange, market); err != nil {
+				return err
+			}
+		}
+		if cost == 0 {
+			if cost, err = askCost(asset, quote); err != nil {
+				return err
+			}
+		}
+		if cost == 0 {
+			log.Printf("[INFO] Ignoring %s because we do not know its cost basis.\n", market)
+			continue
+		}
+
+		var prec int
+		if prec, err = exchange.GetSizePrec(client, market); err != nil {
+			return err
+		}
+		size := precision.Floor(balance, prec)
+		if size == 0 {
+			continue
+		}
+
+		var tick float64
+		if tick, err = model.GetTickSize(exchange, client, market); err != nil {
+			return err
+		}
+		price := pricing.MultiplyTick(cost, mult, tick)
+
+		log.Printf("[INFO] Selling %v %s at %v\n", size, market, price)
+		if _, _, err = exchange.Order(client, model.SELL, market, size, price, model.LIMIT, ""); err != nil {
+			log.Printf("[ERROR] %v\n", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,194 @@
+package command
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/exchanges"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/precision"
+	"github.com/svanas/nefertiti/session"
+)
+
+// in breakout mode (--breakout=X), we do not open a ladder of limit buy orders. instead, we look up the high of the
+// last N hourly candles (aka resistance) every time we run, and we place a stop-buy X% above it. once the price
+// confirms the breakout, the stop-buy gets filled and the sell command picks it up like any other buy order.
+
+type (
+	Resistance struct {
+		Price   float64   `json:"price"`              // the N-period high
+		Trigger float64   `json:"trigger"`            // where our stop-buy triggers
+		OrderID string    `json:"order_id,omitempty"` // our stop-buy, if any
+		Time    time.Time `json:"time"`
+	}
+	Resistances map[string]Resistance // market -> resistance
+)
+
+func resistancesFile(exchange model.Exchange) string {
+	return session.GetSessionFile(strings.ToLower(exchange.GetInfo().Code) + ".breakout.json")
+}
+
+func readResistances(exchange model.Exchange) (Resistances, error) {
+	out := make(Resistances)
+	data, err := session.ReadFile(resistancesFile(exchange))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return out, nil
+		}
+		return nil, errors.Wrap(err, 1)
+	}
+	if err = json.Unmarshal(data, &out); err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+	return out, nil
+}
+
+func (resistances Resistances) write(exchange model.Exchange) error {
+	data, err := json.Marshal(resistances)
+	if err != nil {
+		return errors.Wrap(err, 1)
+	}
+	return session.WriteFile(resistancesFile(exchange), data)
+}
+
+// without returns a copy of the resistances minus the market. note that delete() has been taken by the web server.
+func (resistances Resistances) without(market string) Resistances {
+	out := make(Resistances)
+	for key, value := range resistances {
+		if key != market {
+			out[key] = value
+		}
+	}
+	return out
+}
+
+// getResistance returns the high of the last N hourly candles
+func getResistance(exchange model.Exchange, client interface{}, market string) (float64, error) {
+	periods, err := flag.BreakoutPeriods()
+	if err != nil {
+		return 0, err
+	}
+	end := time.Now()
+	candles, err := exchanges.GetCandles(exchange, client, market, time.Hour, end.Add(-time.Duration(periods)*time.Hour), end)
+	if err != nil {
+		return 0, err
+	}
+	var out float64
+	for _, candle := range candles {
+		if candle.High > out {
+			out = candle.High
+		}
+	}
+	if out == 0 {
+		return 0, errors.Errorf("%s has no candles", market)
+	}
+	return out, nil
+}
+
+// breakout places (or moves) a stop-buy X% above the resistance of the market
+func breakout(
+	client interface{},
+	exchange model.Exchange,
+	market string,
+	ticker float64,
+	pct float64,
+	size float64,
+	price float64,
+	service model.Notify,
+	sandbox bool,
+	test bool,
+) error {
+	stopper, ok := exchange.(model.StopBuy)
+	if !ok {
+		return errors.Errorf("%s does not support --breakout", exchange.GetInfo().Name)
+	}
+
+	high, err := getResistance(exchange, client, market)
+	if err != nil {
+		return err
+	}
+	tick, err := model.GetTickSize(exchange, client, market)
+	if err != nil {
+		return err
+	}
+	trigger := precision.CeilTick(high*(1+(pct/100)), tick)
+
+	resistances, err := readResistances(exchange)
+	if err != nil {
+		return err
+	}
+
+	resistance, ok := resistances[market]
+	if ok && resistance.OrderID != "" {
+		var order *model.Order
+		if order, err = exchange.GetOrder(client, market, resistance.OrderID); err != nil {
+			return err
+		}
+		// the stop-buy got (partially) filled? then the sell command takes it from here
+		if order.Status != model.OPEN || order.FilledSize > 0 {
+			log.Printf("[INFO] %s stop-buy %s is no longer waiting for a breakout\n", market, resistance.OrderID)
+			if order.Status == model.OPEN {
+				return nil
+			}
+			return resistances.without(market).write(exchange)
+		}
+		if resistance.Trigger == trigger {
+			log.Printf("[INFO] %s resistance is %v. Waiting for a breakout above %v\n", market, high, trigger)
+			return nil
+		}
+		// the resistance moved, and so does our stop-buy
+		if test {
+			log.Printf("[INFO] %s resistance moved to %v. Would move the stop-buy to %v\n", market, high, trigger)
+			return nil
+		}
+		if err = exchange.CancelOrder(client, market, resistance.OrderID); err != nil {
+			return err
+		}
+		if err = resistances.without(market).write(exchange); err != nil {
+			return err
+		}
+	}
+
+	// a stop-buy below the ticker would trigger immediately, and that is no breakout
+	if ticker >= trigger {
+		log.Printf("[INFO] %s is already above %v. Waiting for a new resistance\n", market, trigger)
+		return nil
+	}
+
+	log.Printf("[INFO] %s resistance is %v. Placing a stop-buy at %v\n", market, high, trigger)
+	if test {
+		return nil
+	}
+
+	qty := size
+	if price != 0 {
+		if qty, err = exchanges.QuoteToSize(exchange, client, market, price, trigger); err != nil {
+			return err
+		}
+	}
+
+	if err = exchanges.CheckExposure(exchange, client, market, qty*trigger, false, sandbox); err != nil {
+		return err
+	}
+	if err = exchanges.RequestApproval(exchange, client, market, model.BUY, qty, trigger, service); err != nil {
+		return err
+	}
+
+	var oid []byte
+	if oid, _, err = stopper.StopBuy(client, market, qty, trigger, ""); err != nil {
+		return err
+	}
+
+	resistances[market] = Resistance{
+		Price:   high,
+		Trigger: trigger,
+		OrderID: string(oid),
+		Time:    time.Now(),
+	}
+	return resistances.write(exchange)
+}
@@ -14,11 +14,14 @@ import (
 	"github.com/svanas/nefertiti/errors"
 	"github.com/svanas/nefertiti/exchanges"
 	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/i18n"
 	"github.com/svanas/nefertiti/model"
 	"github.com/svanas/nefertiti/multiplier"
 	"github.com/svanas/nefertiti/notify"
+	"github.com/svanas/nefertiti/override"
 	"github.com/svanas/nefertiti/precision"
 	"github.com/svanas/nefertiti/pricing"
+	"github.com/svanas/nefertiti/session"
 	"github.com/svanas/nefertiti/signals"
 )
 
@@ -54,7 +57,7 @@ func report(err error,
 	}
 
 	if service != nil {
-		err := service.SendMessage(msg, (exchange.GetInfo().Name + " - ERROR"), model.ONCE_PER_MINUTE)
+		err := notify.SendError(service, err, msg, (exchange.GetInfo().Name + " - ERROR"))
 		if err != nil {
 			log.Printf("[ERROR] %v", err)
 		}
@@ -130,7 +133,9 @@ func buy(
 	}
 
 	if !wildcard {
-		enumerable = markets
+		for _, market := range markets {
+			enumerable = append(enumerable, model.ResolveMarket(exchange, available, market))
+		}
 	} else {
 		quote := flag.Get("quote").String()
 		if quote == "" {
@@ -138,18 +143,126 @@ func buy(
 		}
 		for _, market := range available {
 			if strings.EqualFold(market.Quote, quote) {
+				if flag.Exists("exclude-leveraged") && exchange.IsLeveragedToken(market.Base) {
+					continue
+				}
 				enumerable = append(enumerable, market.Name)
 			}
 		}
 	}
 
+	// has the kill switch been tripped? then we will not buy anything, and we withdraw the buy orders on the book
+	var halted bool
+	if halted, err = exchanges.Halted(exchange); err != nil {
+		return "", err
+	}
+	if halted {
+		log.Println("[WARN] New buys are paused because your daily loss limit has been hit. Run the resume command to start buying again.")
+		withdraw(client, exchange, enumerable, "your daily loss limit has been hit", test)
+		return "", nil
+	}
+
+	// has somebody paused the buy side? then we will not buy anything either
+	var paused *session.Pause
+	if paused, err = session.Paused(); err != nil {
+		return "", err
+	}
+	if paused != nil {
+		log.Printf("[WARN] New buys are paused since %s. Run the resume command to start buying again.\n", paused.Since.Format(time.RFC3339))
+		withdraw(client, exchange, enumerable, "the buy side has been paused since "+paused.Since.Format(time.RFC3339), test)
+		return "", nil
+	}
+
+	// every market gets its own ladder. one market failing does not stop us from buying the other markets.
+	if len(enumerable) > 1 {
+		// filter on volume in one request, rather than one request per market. without a summaries endpoint, buy()
+		// checks the volume of every market it is about to buy, and not the volume of every market on the exchange.
+		if btcVolumeMin > 0 {
+			if all, err := exchange.Get24hAll(client); err != nil {
+				if !errors.Is(err, exchanges.ErrNoSummaries) {
+					log.Printf("[WARN] %v\n", err)
+				}
+			} else {
+				var liquid []string
+				for _, market := range enumerable {
+					if stats, ok := all[market]; ok && stats.BtcVolume > 0 && stats.BtcVolume < btcVolumeMin {
+						log.Printf("[INFO] Ignoring %s because volume %.2f is lower than %.2f BTC\n", market, stats.BtcVolume, btcVolumeMin)
+						continue
+					}
+					liquid = append(liquid, market)
+				}
+				enumerable = liquid
+			}
+		}
+		// is the benchmark dumping? then we do not put our capital into every (correlated) market at once
+		var regime int
+		if regime, err = exchanges.RegimeMax(exchange, client); err != nil {
+			return "", err
+		}
+		if regime > -1 {
+			var drop []string
+			if enumerable, drop, err = exchanges.RegimeMarkets(exchange, client, enumerable, regime); err != nil {
+				return "", err
+			}
+			for _, market := range drop {
+				log.Printf("[INFO] Ignoring %s because of the market regime.\n", market)
+			}
+			withdraw(client, exchange, drop, "of the market regime", test)
+		}
+		for _, market := range enumerable {
+			if _, err = buy(client, exchange, []string{market}, hold, agg, size, dip, pip, mult, dist, top, max, min, price, btcVolumeMin, deviation, service, strict, sandbox, test, debug); err != nil {
+				report(err, market, nil, service, exchange)
+			}
+		}
+		return "", nil
+	}
+
 	for _, market := range enumerable {
+		cancelled[market] = "" // we are buying this market again
+
 		// "algo" orders are stop-loss, take-profit, and OCO (aka one-cancels-the-other) orders
 		if hasAlgoOrder, _ := exchange.HasAlgoOrder(client, market); hasAlgoOrder {
 			log.Printf("[INFO] Ignoring %s because you have at least one \"algo\" order open on this market.\n", market)
 			continue
 		}
 
+		// --size=MARKET:X overrides the default size for this market
+		msize := size
+		if price == 0 {
+			if msize, err = flag.Size(market); err != nil {
+				return market, err
+			}
+			if msize == 0 {
+				return market, errors.Errorf("missing size for market %s", market)
+			}
+		}
+
+		// --compound=X grows --price by X% of our realized profits, spread over the top X orders
+		var mprice float64
+		if mprice, err = exchanges.Compound(exchange, market, price, top); err != nil {
+			return market, err
+		}
+
+		// do not buy right after a whale sold into the market
+		var gated bool
+		if gated, err = exchanges.WhaleGated(exchange, market); err != nil {
+			return market, err
+		}
+		if gated {
+			log.Printf("[INFO] Ignoring %s because a whale sold into this market recently.\n", market)
+			continue
+		}
+
+		// do not buy right after a stop-loss got filled on this market
+		var cooling bool
+		if cooling, err = exchanges.Cooling(exchange, market); err != nil {
+			return market, err
+		}
+		if cooling {
+			log.Printf("[INFO] Ignoring %s because it got stopped out recently.\n", market)
+			continue
+		}
+
 		var (
 			ticker float64
 			stats  *model.Stats // 24-hour statistics
@@ -173,6 +286,41 @@ func buy(
 			return market, err
 		}
 
+		// mean reversion: only buy when the price is in the lower X% of its 24h range
+		var rng float64
+		if rng, err = flag.Range(); err != nil {
+			return market, err
+		}
+		if rng > 0 && stats.High > stats.Low {
+			pos := ((ticker - stats.Low) / (stats.High - stats.Low)) * 100
+			if pos > rng {
+				// the price reclaimed the midpoint? then withdraw the ladder
+				if ticker >= avg && !test {
+					if err = exchange.Cancel(client, market, model.BUY); err != nil {
+						return market, err
+					}
+				}
+				log.Printf("[INFO] Ignoring %s because the price is at %.0f%% of its 24h range (higher than %.0f%%)\n", market, pos, rng)
+				continue
+			}
+		}
+
+		// momentum: place a stop-buy above the resistance, and let the breakout fill it
+		var brk float64
+		if brk, err = flag.Breakout(); err != nil {
+			return market, err
+		}
+		if brk > 0 {
+			if err = breakout(client, exchange, market, ticker, brk, msize, mprice, service, sandbox, test); err != nil {
+				if len(enumerable) > 1 || flag.Get("ignore").Contains("error") {
+					report(err, market, nil, service, exchange)
+				} else {
+					return market, err
+				}
+			}
+			continue
+		}
+
 		var (
 			magg float64
 			mdip float64
@@ -182,7 +330,7 @@ func buy(
 		)
 
 		magg = agg
-		mdip = dip
+		mdip = override.Dip(market, dip)
 		mpip = pip
 		mmax = max
 
@@ -206,11 +354,11 @@ func buy(
 			for _, fill := range closed {
 				if fill.Side == model.BUY {
 					// step 2: has this filled BUY order NOT been sold?
-					var prec int
-					if prec, err = exchange.GetPricePrec(client, market); err != nil {
+					var tick float64
+					if tick, err = model.GetTickSize(exchange, client, market); err != nil {
 						return market, err
 					}
-					if opened.IndexByPrice(model.SELL, market, pricing.Multiply(fill.Price, mult, prec)) > -1 {
+					if opened.IndexByPrice(model.SELL, market, pricing.MultiplyTick(fill.Price, override.Mult(market, mult), tick)) > -1 {
 						if mmax == 0 || mmax >= fill.Price {
 							mmax = fill.Price
 						}
@@ -220,7 +368,7 @@ func buy(
 		}
 
 		if magg == 0 {
-			if magg, mdip, mpip, err = aggregation.GetEx(exchange, client, market, ticker, avg, dip, pip, mmax, min, int(top), strict); err != nil {
+			if magg, mdip, mpip, err = aggregation.GetEx(exchange, client, market, ticker, avg, mdip, pip, mmax, min, int(top), strict); err != nil {
 				if errors.Is(err, aggregation.EOrderBookTooThin) && (len(enumerable) > 1 || flag.Get("ignore").Contains("error")) {
 					report(err, market, nil, service, exchange)
 					continue
@@ -349,11 +497,13 @@ func buy(
 		}
 
 		for i := 0; i < len(book2); i++ {
-			book2[i].Size = size
+			book2[i].Size = msize
 
 			// if we have an arg named --price, then we'll calculate the desired size here
 			if price != 0 {
-				book2[i].Size = precision.Round((price / book2[i].Price), prec)
+				if book2[i].Size, err = exchanges.QuoteToSize(exchange, client, market, mprice, book2[i].Price); err != nil {
+					return market, err
+				}
 			}
 
 			// the more non-sold sell orders we have, the bigger the new buy order size
@@ -364,16 +514,81 @@ func buy(
 			// for BTC and ETH, there is a minimum size (otherwise, we would never be hodl'ing)
 			units := model.GetSizeMin(hold.HasMarket(market), base)
 			if book2[i].Size < units {
-				return market, errors.Errorf("Cannot buy %s. Size is too low. You must buy at least %f units.", market, units)
+				return market, errors.Errorf("Cannot buy %s. Size is too low. You must buy at least %s units.", market, model.FormatSize(exchange, client, market, units))
+			}
+		}
+
+		// refuse prices that are wildly off-market
+		for i := 0; i < len(book2) && i < int(top); i++ {
+			if err = exchanges.CheckPrice(exchange, market, model.BUY, book2[i].Price, sandbox); err != nil {
+				break
+			}
+		}
+		if err != nil {
+			if len(enumerable) > 1 || flag.Get("ignore").Contains("error") {
+				report(err, market, nil, service, exchange)
+				continue
+			} else {
+				return market, err
+			}
+		}
+
+		// refuse to go beyond our max exposure
+		if !test {
+			var amount float64
+			for i := 0; i < len(book2) && i < int(top); i++ {
+				amount += book2[i].Size * book2[i].Price
+			}
+			if err = exchanges.CheckExposure(exchange, client, market, amount, true, sandbox); err != nil {
+				if len(enumerable) > 1 || flag.Get("ignore").Contains("error") {
+					report(err, market, nil, service, exchange)
+					continue
+				} else {
+					return market, err
+				}
+			}
+		}
+
+		// hold large orders until you approve them. you approve the ladder once, rather than every order in it.
+		if !test {
+			ladder := book2.Calls()
+			if len(ladder) > int(top) {
+				ladder = ladder[:top]
+			}
+			if err = exchanges.RequestLadderApproval(exchange, client, market, ladder, service); err != nil {
+				if len(enumerable) > 1 || flag.Get("ignore").Contains("error") {
+					report(err, market, nil, service, exchange)
+					continue
+				} else {
+					return market, err
+				}
 			}
 		}
 
 		// cancel your open buy order(s), then place the top X buy orders
 		if !test {
-			if len(book2) < int(top) {
-				err = exchange.Buy(client, true, market, book2.Calls(), deviation, model.LIMIT)
-			} else {
-				err = exchange.Buy(client, true, market, book2[:top].Calls(), deviation, model.LIMIT)
+			calls := book2.Calls()
+			if len(calls) > int(top) {
+				calls = calls[:top]
+			}
+			// anti-chop: leave the open buy orders alone that are no more than X ticks away from their new price
+			var ticks int64
+			if ticks, err = flag.Ticks(); err == nil && ticks > 0 {
+				var (
+					opened model.Orders
+					tick   float64
+				)
+				if opened, err = exchange.GetOpened(client, market); err == nil {
+					if tick, err = model.GetTickSize(exchange, client, market); err == nil {
+						calls.Snap(opened, ticks, tick)
+					}
+				}
+			}
+			if err == nil {
+				err = exchange.Buy(client, true, market, calls, deviation, model.LIMIT)
+			}
+			if err == nil {
+				exchanges.ExpireLater(market)
 			}
 			if err != nil {
 				if len(enumerable) > 1 || flag.Get("ignore").Contains("error") {
@@ -425,6 +640,33 @@ func buySignalsEvery(
 	}
 }
 
+// the buy side stays paused for a while, but we enumerate the markets every iteration. cancelled remembers the
+// markets whose buy orders we have withdrawn (and why), so that we withdraw them once rather than every iteration.
+var cancelled = make(map[string]string) // market -> reason
+
+// withdraw cancels the buy orders in these markets, because the orders that are on the book keep on buying while the
+// buy side is paused. --keep-buys leaves them alone.
+func withdraw(client interface{}, exchange model.Exchange, markets []string, reason string, test bool) {
+	if test || flag.KeepBuys() {
+		return
+	}
+	for _, market := range markets {
+		if cancelled[market] == reason {
+			continue
+		}
+		if err := exchange.Cancel(client, market, model.BUY); err != nil {
+			log.Printf("[ERROR] %v\n", err)
+			continue
+		}
+		log.Printf("[INFO] Cancelled the buy orders on %s because %s.\n", market, reason)
+		cancelled[market] = reason
+		// the profit that --compound put into this market is available to the other markets again
+		if err := exchanges.ReleaseCompound(exchange, []string{market}); err != nil {
+			log.Printf("[ERROR] %v\n", err)
+		}
+	}
+}
+
 func buySignals(
 	channel model.Channel,
 	client interface{},
@@ -446,6 +688,26 @@ func buySignals(
 		new model.Calls
 	)
 
+	// has the kill switch been tripped? then we will not buy anything
+	var halted bool
+	if halted, err = exchanges.Halted(exchange); err != nil {
+		return old, err
+	}
+	if halted {
+		log.Println("[WARN] New buys are paused because your daily loss limit has been hit. Run the resume command to start buying again.")
+		withdraw(client, exchange, old.Markets(), "your daily loss limit has been hit", test)
+		return old, nil
+	}
+	var paused *session.Pause
+	if paused, err = session.Paused(); err != nil {
+		return old, err
+	}
+	if paused != nil {
+		log.Printf("[WARN] New buys are paused since %s. Run the resume command to start buying again.\n", paused.Since.Format(time.RFC3339))
+		withdraw(client, exchange, old.Markets(), "the buy side has been paused since "+paused.Since.Format(time.RFC3339), test)
+		return old, nil
+	}
+
 	if quote.IsEmpty() {
 		return old, errors.New("missing argument: quote")
 	}
@@ -498,8 +760,16 @@ func buySignals(
 				return old, err
 			}
 
+			// --compound=X grows --price by X% of our realized profits
+			var mprice float64
+			if mprice, err = exchanges.Compound(exchange, market, price, 1); err != nil {
+				return old, err
+			}
+
 			for i := range calls {
-				calls[i].Size = precision.Round(price/ticker, prec)
+				if calls[i].Size, err = exchanges.QuoteToSize(exchange, client, market, mprice, ticker); err != nil {
+					return old, err
+				}
 
 				if flag.Dca() {
 					hasOpenSell := 0
@@ -531,13 +801,23 @@ func buySignals(
 							limit = ticker
 						}
 						if limit < min {
-							log.Printf("[INFO] Ignoring %s because price %.8f is lower than %.8f\n", calls[i].Market, calls[i].Price, min)
+							log.Printf("[INFO] Ignoring %s because price %s is lower than %s\n", calls[i].Market, precision.FormatFloat(calls[i].Price, -1), precision.FormatFloat(min, -1))
 							calls[i].Skip = true
 						}
 					}
 				}
 			}
 
+			// refuse signals that are wildly off-market
+			for i := range calls {
+				if !calls[i].Skip && calls[i].Price > 0 {
+					if err := exchanges.CheckPrice(exchange, calls[i].Market, model.BUY, calls[i].Price, sandbox); err != nil {
+						report(err, calls[i].Market, channel, service, exchange)
+						calls[i].Skip = true
+					}
+				}
+			}
+
 			if btcVolumeMin > 0 {
 				for i := range calls {
 					if !calls[i].Skip {
@@ -553,6 +833,29 @@ func buySignals(
 				}
 			}
 
+			// do not buy right after a whale sold into the market
+			for i := range calls {
+				if !calls[i].Skip {
+					gated, err := exchanges.WhaleGated(exchange, calls[i].Market)
+					if err != nil {
+						return old, err
+					}
+					if gated {
+						log.Printf("[INFO] Ignoring %s because a whale sold into this market recently\n", calls[i].Market)
+						calls[i].Skip = true
+						continue
+					}
+					cooling, err := exchanges.Cooling(exchange, calls[i].Market)
+					if err != nil {
+						return old, err
+					}
+					if cooling {
+						log.Printf("[INFO] Ignoring %s because it got stopped out recently\n", calls[i].Market)
+						calls[i].Skip = true
+					}
+				}
+			}
+
 			if len(calls) > 0 {
 				new = append(new, calls...)
 				if !test {
@@ -572,6 +875,36 @@ func buySignals(
 							}
 						}
 					}
+					// refuse to go beyond our max exposure
+					if calls.HasBuy() {
+						var amount float64
+						for i := range calls {
+							if !calls[i].Skip {
+								limit := calls[i].Price
+								if limit == 0 {
+									limit = ticker
+								}
+								amount += calls[i].Size * limit
+							}
+						}
+						if err := exchanges.CheckExposure(exchange, client, market, amount, false, sandbox); err != nil {
+							report(err, market, channel, service, exchange)
+							for i := range calls {
+								calls[i].Skip = true
+							}
+						}
+					}
+					// hold large orders until you approve them, one approval per market
+					for _, m := range calls.Markets() {
+						if err := exchanges.RequestLadderApproval(exchange, client, m, calls, service); err != nil {
+							report(err, m, channel, service, exchange)
+							for i := range calls {
+								if calls[i].Market == m {
+									calls[i].Skip = true
+								}
+							}
+						}
+					}
 					if calls.HasBuy() {
 						// cancel your open buy order(s), then place the new buy orders
 						err = exchange.Buy(client, false, market, calls, deviation, channel.GetOrderType())
@@ -631,7 +964,28 @@ func (c *BuyCommand) Run(args []string) int {
 		return c.ReturnError(err)
 	}
 
+	// --size-quote is another name for --price, eg. the amount of quote currency to spend per order
+	if arg := flag.Get("size-quote"); arg.Exists && !flag.Exists("price") {
+		flag.Set("price", arg.String())
+	}
+
 	test := flag.Exists("test")
+	dry := flag.DryRun()
+
+	if _, _, err = flag.Regime(); err != nil {
+		return c.ReturnError(err)
+	}
+
+	if err = exchanges.ValidateExposure(exchange); err != nil {
+		return c.ReturnError(err)
+	}
+	if err = exchanges.ValidateEquityShare(exchange); err != nil {
+		return c.ReturnError(err)
+	}
+
+	if err = exchanges.ValidateCompound(exchange); err != nil {
+		return c.ReturnError(err)
+	}
 
 	var client interface{}
 	if client, err = exchange.GetClient(model.PRIVATE, flag.Sandbox()); err != nil {
@@ -639,7 +993,7 @@ func (c *BuyCommand) Run(args []string) int {
 	}
 
 	var service model.Notify = nil
-	if !test {
+	if !test && !dry {
 		if service, err = notify.New().Init(flag.Interactive(), true); err != nil {
 			return c.ReturnError(err)
 		}
@@ -713,7 +1067,7 @@ func (c *BuyCommand) Run(args []string) int {
 			}
 		}
 		// iterations start here
-		if !test {
+		if !test && !dry {
 			flg = flag.Get("repeat")
 			if flg.Exists {
 				var repeat float64 = 1
@@ -732,7 +1086,7 @@ func (c *BuyCommand) Run(args []string) int {
 							}
 						}
 					}
-					msg := fmt.Sprintf("Listening to %s...", channel.GetName())
+					msg := i18n.Sprintf("Listening to %s...", channel.GetName())
 					log.Println("[INFO] " + msg)
 					if service != nil {
 						service.SendMessage(msg, (exchange.GetInfo().Name + " - INFO"), model.ALWAYS)
@@ -752,6 +1106,11 @@ func (c *BuyCommand) Run(args []string) int {
 		return c.ReturnError(err)
 	}
 
+	// --all is short for --market=all
+	if flag.Exists("all") && !flag.Exists("market") {
+		flag.Set("market", "all")
+	}
+
 	flg = flag.Get("market")
 	if !flg.Exists {
 		return c.ReturnError(errors.New("missing argument: market"))
@@ -789,8 +1148,8 @@ func (c *BuyCommand) Run(args []string) int {
 	// if we have an arg named --size, then that one will take precedence
 	flg = flag.Get("size")
 	if flg.Exists {
-		if size, err = flg.Float64(); err != nil {
-			return c.ReturnError(errors.Errorf("size %v is invalid", flg))
+		if size, err = flag.Size(""); err != nil {
+			return c.ReturnError(err)
 		}
 	} else {
 		// if we have an arg named --price, then we'll calculate the desired size later
@@ -848,7 +1207,20 @@ func (c *BuyCommand) Run(args []string) int {
 		}
 	}
 
-	if !test {
+	if !test && !dry {
+		// emulate good-til-date orders on the exchanges that do not support them
+		var expire time.Duration
+		if expire, err = flag.Expire(); err != nil {
+			return c.ReturnError(err)
+		}
+		if expire > 0 && !exchanges.ExpiresNatively(exchange) {
+			if flag.Exists("repeat") {
+				go exchanges.WatchExpiry(exchange, client, expire, service)
+			} else {
+				log.Printf("[WARN] %s does not expire orders natively. --expire requires --repeat.\n", exchange.GetInfo().Name)
+			}
+		}
+
 		var repeat float64 = 1
 		flg = flag.Get("repeat")
 		if flg.Exists {
@@ -873,10 +1245,23 @@ The buy command opens new limit buy orders on the specified exchange/market.
 
 Options:
   --exchange = name, for example: Bittrex
-  --market   = a valid market pair.
+  --rest     = URI of the exchange's REST API, for example a regional mirror or
+               a proxy (optional, applies to the sandbox if --sandbox=Y, on the
+               exchanges that have one)
+  --websocket = URI of the exchange's websocket. GDAX only. (optional)
+  --market   = a valid market pair, or a comma-separated list of market pairs,
+               or all (requires --quote). every market gets its own ladder.
+               you can spell a pair the way the exchange does, or as BASE/QUOTE
+               (for example: BTC/USD is XBTUSD on BitMEX).
+  --exclude-leveraged = if included, --market=all excludes leveraged tokens.
+               (optional)
   --size     = amount of cryptocurrency to buy per order. please note --size is
                mutually exclusive with --price, eg. the price in quote currency
-               you will want to pay for an order.
+               you will want to pay for an order. the size can differ per
+               market, for example: --size=BTC-USDT:0.001,default:0.1
+  --size-quote = amount of quote currency to spend per order, for example: 50.
+               converted to a size (in base currency) when the order is placed.
+               another name for --price.
   --agg      = aggregate public order book to nearest multiple of agg.
                (optional)
   --dip      = percentage that will kick the bot into action.
@@ -897,10 +1282,78 @@ Options:
   --dca      = if included, then slowly but surely, the bot will proportionally
                increase your stack while lowering your average buying price.
                (optional)
+  --ticks    = only move a buy order when its price changes by more than X
+               ticks. (optional, defaults to 0, aka always)
+  --expire   = cancel the buy orders that are older than X hours. natively
+               where the exchange supports it, otherwise requires --repeat.
+               (optional, defaults to 0, aka never)
+  --range    = only buy when the price is in the lower X% of its 24h range.
+               withdraws your buy orders when the price reclaims the midpoint.
+               (optional, defaults to disabled)
+  --breakout = instead of opening limit buy orders, place a stop-buy X% above
+               the high of the last N hourly candles, and move it when that
+               high moves. use with --repeat. Binance and GDAX only.
+               (optional, defaults to disabled)
+  --breakout-periods = N, the number of hourly candles that make up the high.
+               (optional, defaults to 24)
   --test     = if included, merely reports what it would do.
                (optional, defaults to false)
+  --sanity   = refuse orders that deviate more than X% from a reference price.
+               (optional, defaults to disabled)
+  --oracle   = where the reference price comes from, in order of priority. for
+               example: --oracle=chainlink,coingecko,binance. the next one is
+               asked when one fails. (optional, defaults to coingecko)
+  --max-exposure = maximum quote currency at stake across all markets, eg. your
+               open buy orders plus the value of what you hold, according to
+               your balances. GDAX and KuCoin only. (optional, defaults to no
+               limit)
+  --max-market-exposure = maximum quote currency at stake per market: your open
+               buy orders plus your balance (or your position, or else your
+               open sell orders). (optional, defaults to no limit)
+  --regime-drop = once the benchmark is down more than X% today, buy no more
+               than --regime-max markets (optional, defaults to disabled)
+  --regime-max = the number of markets to buy while the benchmark is down.
+               the markets you have a position in count towards it.
+               (optional, defaults to 1)
+  --regime-market = the benchmark, for example: BTC-USDT (optional, defaults
+               to BTC against your --quote)
+  --max-equity-share = refuse any buy order that would take a market beyond
+               this percentage of your equity. GDAX and KuCoin only.
+               (optional, defaults to no limit)
+  --approve-above = hold orders worth more than this (in quote currency) until
+               you approve them with the approve command. you approve the
+               ladder once. (optional, defaults to disabled)
+  --stop-cooldown = hours to stop buying a market after a stop-loss got filled
+               on that market. (optional, defaults to disabled)
+  --compound = percentage of your realized profits (minus your realized
+               losses, minus what --skim took off the table) to add to
+               --price. works with --price only, on the exchanges that journal their profits
+               (Binance, BitMEX, Bittrex, Deribit, Jupiter, KuCoin and 1inch).
+               (optional, defaults to 0)
+  --observe  = if included, never sends an order to the exchange. instead, the
+               bot journals and notifies what it would have done. works with
+               read-only API keys. on Bittrex, the journal refuses the orders
+               below the min trade size and expires the orders after 28 days.
+               (optional, defaults to false)
+  --dry-run  = if included, prints the orders that would be sent (after size
+               and price adjustments), then exits. (optional, defaults to false)
   --repeat   = if included, repeats this command every X hours.
                (optional, defaults to false)
+  --label    = a name for this loop. your log lines, notifications and journal
+               rows carry it (plus a run ID). (optional)
+  --keep-buys = if included, the buy orders stay on the book while the buy
+               side is paused (by the pause command, or because your daily
+               loss limit has been hit). by default, we cancel them.
+               (optional)
+  --allow-withdraw = Binance and KuCoin warn you at startup if your API key can
+               withdraw. include this if you really want the bot to have such
+               a key, to silence the warning (optional)
+  --user     = name of the user you are trading for. every user has their own
+               vault, session state, journals and notifications. (optional)
+  --overrides = a JSON file with the dip per market, for example the file that
+               the optimize command writes. (optional)
+  --control-token = role:token,role:token where role is viewer or operator.
+               if included, the control API requires a token. (optional)
 
 Alternative Strategy:
   The trading bot can listen to signals (for example: Telegram bots) as an
@@ -908,7 +1361,15 @@ Alternative Strategy:
 
 Alternative Strategy Options:
   --exchange = name, for example: Bittrex
+  --rest     = URI of the exchange's REST API, for example a regional mirror or
+               a proxy (optional, applies to the sandbox if --sandbox=Y, on the
+               exchanges that have one)
+  --websocket = URI of the exchange's websocket. GDAX only. (optional)
   --signals  = provider, for example: MiningHamster
+               or spike, the built-in volume-spike detector. spike emits a
+               signal when the 24h volume exceeds its rolling average (over
+               --window=24 samples) by --factor=3, while the price went up by
+               less than --max-up=5 percent.
   --price    = price (in quote currency) that you will want to pay for an order
   --quote    = currency that is used as the reference, for example: BTC or USDT
   --min      = minimum price for a unit of quote currency.
@@ -923,8 +1384,58 @@ Alternative Strategy Options:
                bot will cancel the (non-filled) limit buy order(s) associated
                with the signal.
                (optional, defaults to 1 hour)
+  --sanity   = refuse signals that deviate more than X% from a reference price.
+               the reference is CoinGecko, unless you include --oracle=X,Y,Z
+               with coingecko, chainlink and/or the names of other exchanges.
+               (optional, defaults to disabled)
+  --max-exposure = maximum quote currency at stake across all markets. GDAX and
+               KuCoin only. (optional, defaults to no limit)
+  --max-market-exposure = maximum quote currency at stake per market.
+               (optional, defaults to no limit)
+  --regime-drop = once the benchmark is down more than X% today, buy no more
+               than --regime-max markets (optional, defaults to disabled)
+  --regime-max = the number of markets to buy while the benchmark is down.
+               the markets you have a position in count towards it.
+               (optional, defaults to 1)
+  --regime-market = the benchmark, for example: BTC-USDT (optional, defaults
+               to BTC against your --quote)
+  --max-equity-share = refuse any buy order that would take a market beyond
+               this percentage of your equity. GDAX and KuCoin only.
+               (optional, defaults to no limit)
+  --approve-above = hold orders worth more than this (in quote currency) until
+               you approve them with the approve command. you approve the
+               ladder once. (optional, defaults to disabled)
+  --stop-cooldown = hours to stop buying a market after a stop-loss got filled
+               on that market. (optional, defaults to disabled)
+  --compound = percentage of your realized profits (minus your realized
+               losses, minus what --skim took off the table) to add to
+               --price. works with --price only, on the exchanges that journal their profits
+               (Binance, BitMEX, Bittrex, Deribit, Jupiter, KuCoin and 1inch).
+               (optional, defaults to 0)
+  --observe  = if included, never sends an order to the exchange. instead, the
+               bot journals and notifies what it would have done. works with
+               read-only API keys. on Bittrex, the journal refuses the orders
+               below the min trade size and expires the orders after 28 days.
+               (optional, defaults to false)
+  --dry-run  = if included, prints the orders that would be sent (after size
+               and price adjustments), then exits. (optional, defaults to false)
   --repeat   = if included, repeats this command every X hours.
                (optional, defaults to false)
+  --label    = a name for this loop. your log lines, notifications and journal
+               rows carry it (plus a run ID). (optional)
+  --keep-buys = if included, the buy orders stay on the book while the buy
+               side is paused (by the pause command, or because your daily
+               loss limit has been hit). by default, we cancel them.
+               (optional)
+  --allow-withdraw = Binance and KuCoin warn you at startup if your API key can
+               withdraw. include this if you really want the bot to have such
+               a key, to silence the warning (optional)
+  --user     = name of the user you are trading for. every user has their own
+               vault, session state, journals and notifications. (optional)
+  --overrides = a JSON file with the dip per market, for example the file that
+               the optimize command writes. (optional)
+  --control-token = role:token,role:token where role is viewer or operator.
+               if included, the control API requires a token. (optional)
 `
 	return strings.TrimSpace(text)
 }
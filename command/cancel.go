@@ -1,6 +1,8 @@
 package command
 
 import (
+	"encoding/json"
+	"fmt"
 	"strings"
 
 	"github.com/svanas/nefertiti/errors"
@@ -21,41 +23,72 @@ func (c *CancelCommand) Run(args []string) int {
 		return c.ReturnError(err)
 	}
 
-	market, err := model.GetMarket(exchange)
-	if err != nil {
+	market := "all"
+	if !flag.Exists("all-markets") {
+		if market, err = model.GetMarket(exchange); err != nil {
+			return c.ReturnError(err)
+		}
+	}
+
+	filter := &model.CancelFilter{}
+	if filter.OlderThan, err = flag.OlderThan(); err != nil {
 		return c.ReturnError(err)
 	}
+	if filter.PriceAbove, filter.PriceBelow, err = flag.PriceRange(); err != nil {
+		return c.ReturnError(err)
+	}
+	filtered := filter.OlderThan > 0 || filter.PriceAbove > 0 || filter.PriceBelow > 0
 
+	// --side is optional when you filter by age or price, otherwise it is mandatory
 	arg := flag.Get("side")
-	if !arg.Exists {
+	if arg.Exists {
+		filter.Side = model.NewOrderSide(arg.String())
+		if filter.Side == model.ORDER_SIDE_NONE {
+			return c.ReturnError(errors.Errorf("side %v is invalid", arg))
+		}
+	} else if !filtered {
 		return c.ReturnError(errors.New("missing argument: side"))
 	}
-	side := model.NewOrderSide(arg.String())
-	if side == model.ORDER_SIDE_NONE {
-		return c.ReturnError(errors.Errorf("side %v is invalid", arg))
-	}
 
 	client, err := exchange.GetClient(model.PRIVATE, flag.Sandbox())
 	if err != nil {
 		return c.ReturnError(err)
 	}
 
-	if market != "all" {
-		if err = exchange.Cancel(client, market, side); err != nil {
+	markets := []string{market}
+	if market == "all" {
+		all, err := exchange.GetMarkets(true, flag.Sandbox(), flag.Get("ignore").Split())
+		if err != nil {
 			return c.ReturnError(err)
 		}
-		return 0
+		markets = nil
+		for _, m := range all {
+			markets = append(markets, m.Name)
+		}
 	}
 
-	markets, err := exchange.GetMarkets(true, flag.Sandbox(), flag.Get("ignore").Split())
-	if err != nil {
-		return c.ReturnError(err)
+	var cancelled model.Orders
+	for _, market := range markets {
+		if !filtered {
+			if err = exchange.Cancel(client, market, filter.Side); err != nil {
+				return c.ReturnError(err)
+			}
+			continue
+		}
+		orders, err := model.CancelOrders(exchange, client, market, filter, flag.DryRun())
+		cancelled = append(cancelled, orders...)
+		if err != nil {
+			return c.ReturnError(err)
+		}
 	}
 
-	for _, market := range markets {
-		if err = exchange.Cancel(client, market.Name, side); err != nil {
+	// in dry-run mode, print the orders that we would have cancelled
+	if filtered && flag.DryRun() {
+		out, err := json.Marshal(cancelled)
+		if err != nil {
 			return c.ReturnError(err)
 		}
+		fmt.Println(string(out))
 	}
 
 	return 0
@@ -65,16 +98,25 @@ func (c *CancelCommand) Help() string {
 	text := `
 Usage: ./nefertiti cancel [options]
 
-The cancel command cancels all your buy or sell orders on a given market.
+The cancel command cancels your open orders on a given market, including the
+stop-loss (conditional) orders that have not been triggered yet.
 
 Options:
-  --exchange = name
-  --market   = a valid market pair
-  --side     = [buy|sell]
+  --exchange    = name
+  --market      = a valid market pair
+  --side        = [buy|sell] (optional when you include a filter, see below)
+  --all-markets = if included, cancels your orders on every market (optional)
+  --dry-run     = print the cancellations instead of sending them (optional)
+
+Filters:
+  --older-than  = cancel orders opened longer than X ago, for example: 48h
+                  (optional)
+  --price-above = cancel orders priced above X (optional)
+  --price-below = cancel orders priced below X (optional)
 `
 	return strings.TrimSpace(text)
 }
 
 func (c *CancelCommand) Synopsis() string {
-	return "Cancels all your buy or sell orders on a given market."
+	return "Cancels your open orders on a given market."
 }
@@ -8,6 +8,8 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/svanas/nefertiti/control"
+	"github.com/svanas/nefertiti/errors"
 	"github.com/svanas/nefertiti/flag"
 )
 
@@ -25,8 +27,11 @@ func (cm *CommandMeta) ReturnError(err error) int {
 	// step #1: execute the callback function (passing the error back to main)
 	cb := *cm.CallBack
 	cb(pc, file, line, err)
-	// step #2: return 1 as an error code
-	return 1
+	// step #2: return an exit code that tells your wrapper script what kind of error this is
+	if code := errors.ExitCode(err); code != errors.EXIT_OK {
+		return code
+	}
+	return errors.EXIT_ERROR
 }
 
 func (cm *CommandMeta) ReturnSuccess() error {
@@ -44,6 +49,7 @@ func (cm *CommandMeta) ReturnSuccess() error {
 				return err
 			}
 			req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+			control.Sign(req)
 			if _, err := http.DefaultClient.Do(req); err != nil {
 				return err
 			}
@@ -0,0 +1,246 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mitchellh/cli"
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/exchanges"
+	"github.com/svanas/nefertiti/flag"
+)
+
+type (
+	// CompletionCommand prints the completion script for your shell
+	CompletionCommand struct {
+		*CommandMeta
+	}
+	// CompleteCommand is the hidden command that the completion script calls, with the command line so far
+	CompleteCommand struct {
+		*CommandMeta
+		Commands map[string]cli.CommandFactory
+	}
+)
+
+const COMPLETE_COMMAND = "__complete"
+
+// the flags that every command understands
+var completeGlobalFlags = []string{"--debug", "--label", "--json-errors"}
+
+// the values of these flags are known up front
+var completeFlagValues = map[string][]string{
+	"side":  {"buy", "sell"},
+	"type":  {"limit", "market"},
+	"shell": {"bash", "zsh", "fish"},
+	"sort":  {"name", "volume"},
+}
+
+// we find the flags of a command in its help text, for example: `  --market   = a valid market pair`
+var completeHelpFlag = regexp.MustCompile(`(?m)^\s+(--[a-z0-9-]+)\s*=`)
+
+func (c *CompletionCommand) Run(args []string) int {
+	exe, err := os.Executable()
+	if err != nil {
+		exe = c.AppName
+	}
+
+	var script string
+	switch strings.ToLower(flag.Get("shell").String()) {
+	case "bash":
+		script = completionBash
+	case "zsh":
+		script = "autoload -U +X bashcompinit && bashcompinit\n" + completionBash
+	case "fish":
+		script = completionFish
+	case "":
+		return c.ReturnError(errors.New("missing argument: shell"))
+	default:
+		return c.ReturnError(errors.Errorf("shell %v is invalid", flag.Get("shell")))
+	}
+
+	script = strings.ReplaceAll(script, "{{name}}", c.AppName)
+	script = strings.ReplaceAll(script, "{{exe}}", exe)
+	script = strings.ReplaceAll(script, "{{complete}}", COMPLETE_COMMAND)
+
+	fmt.Print(script)
+
+	return 0
+}
+
+func (c *CompletionCommand) Help() string {
+	text := `
+Usage: ./nefertiti completion [options]
+
+The completion command prints a completion script for your shell. --exchange
+completes to the exchanges we support, and --market completes to the markets
+on the selected exchange.
+
+Options:
+  --shell = [bash|zsh|fish]
+
+Examples:
+  bash: source <(./nefertiti completion --shell=bash)
+  zsh : source <(./nefertiti completion --shell=zsh)
+  fish: ./nefertiti completion --shell=fish | source
+`
+	return strings.TrimSpace(text)
+}
+
+func (c *CompletionCommand) Synopsis() string {
+	return "Print a shell completion script."
+}
+
+// Run prints the candidates for the last word on the command line, one per line. we never return an error code,
+// because the shell has nowhere to show it.
+func (c *CompleteCommand) Run(args []string) int {
+	line := strings.Join(args, " ")
+	words := strings.Fields(line)
+	// the first word is our own name
+	if len(words) > 0 {
+		words = words[1:]
+	}
+	// the last word is the one we are completing, and it is empty if the line ends with a space
+	last := ""
+	if len(words) > 0 && !strings.HasSuffix(line, " ") {
+		last = words[len(words)-1]
+		words = words[:len(words)-1]
+	}
+
+	for _, candidate := range c.complete(words, last) {
+		if strings.HasPrefix(candidate, last) {
+			fmt.Println(candidate)
+		}
+	}
+
+	return 0
+}
+
+// command returns the name of the command on the command line, and how many words it takes
+func (c *CompleteCommand) command(words []string) (string, int) {
+	var (
+		name string
+		size int
+	)
+	for key := range c.Commands {
+		parts := strings.Fields(key)
+		if len(parts) <= len(words) && len(parts) > size && strings.Join(words[:len(parts)], " ") == key {
+			name, size = key, len(parts)
+		}
+	}
+	return name, size
+}
+
+func (c *CompleteCommand) complete(words []string, last string) []string {
+	name, size := c.command(words)
+
+	// the (sub)command itself
+	if !strings.HasPrefix(last, "-") && size == len(words) {
+		unique := make(map[string]bool)
+		for key := range c.Commands {
+			parts := strings.Fields(key)
+			if key == COMPLETE_COMMAND || len(parts) <= len(words) || strings.Join(parts[:len(words)], " ") != strings.Join(words, " ") {
+				continue
+			}
+			unique[parts[len(words)]] = true
+		}
+		var out []string
+		for key := range unique {
+			out = append(out, key)
+		}
+		sort.Strings(out)
+		return out
+	}
+
+	// the value of a flag
+	if strings.HasPrefix(last, "-") && strings.Contains(last, "=") {
+		key := strings.TrimLeft(last[:strings.Index(last, "=")], "-")
+		prefix := last[:strings.Index(last, "=")+1]
+		var values []string
+		switch key {
+		case "exchange":
+			values = exchanges.GetExchangeCodes()
+		case "market":
+			values = c.markets(words)
+		default:
+			values = completeFlagValues[key]
+		}
+		var out []string
+		for _, value := range values {
+			out = append(out, prefix+value)
+		}
+		return out
+	}
+
+	// the name of a flag
+	if strings.HasPrefix(last, "-") || last == "" {
+		out := append([]string{}, completeGlobalFlags...)
+		if factory, ok := c.Commands[name]; ok {
+			if cmd, err := factory(); err == nil {
+				for _, match := range completeHelpFlag.FindAllStringSubmatch(cmd.Help(), -1) {
+					out = append(out, match[1])
+				}
+			}
+		}
+		sort.Strings(out)
+		return out
+	}
+
+	return nil
+}
+
+// markets returns the names of the markets on the --exchange=X that is on the command line
+func (c *CompleteCommand) markets(words []string) []string {
+	var (
+		name    string
+		sandbox bool
+	)
+	for _, word := range words {
+		word = strings.TrimLeft(word, "-")
+		if strings.HasPrefix(word, "exchange=") {
+			name = strings.TrimPrefix(word, "exchange=")
+		}
+		if word == "sandbox" || strings.EqualFold(word, "sandbox=Y") {
+			sandbox = true
+		}
+	}
+	if name == "" {
+		return nil
+	}
+	for _, exchange := range *exchanges.New() {
+		if exchange.GetInfo().Equals(name) {
+			out, err := exchanges.GetMarketNames(exchange, sandbox)
+			if err != nil {
+				return nil
+			}
+			return out
+		}
+	}
+	return nil
+}
+
+func (c *CompleteCommand) Help() string {
+	return "Usage: ./nefertiti " + COMPLETE_COMMAND + " [command line]"
+}
+
+func (c *CompleteCommand) Synopsis() string {
+	return "Print the completion candidates for a command line."
+}
+
+const completionBash = `_{{name}}() {
+	local line="${COMP_LINE:0:COMP_POINT}"
+	local cur="${line##* }"
+	local IFS=$'\n'
+	COMPREPLY=($("{{exe}}" {{complete}} "$line" 2>/dev/null))
+	# bash splits the word on the = sign, so we only return what comes after it
+	if [[ "$cur" == *=* && "$COMP_WORDBREAKS" == *=* ]]; then
+		COMPREPLY=("${COMPREPLY[@]#"${cur%=*}="}")
+	fi
+}
+complete -o nospace -F _{{name}} {{name}}
+`
+
+const completionFish = `complete -c {{name}} -f -a '("{{exe}}" {{complete}} (commandline -cp) 2>/dev/null)'
+`
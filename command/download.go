@@ -0,0 +1,149 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/exchanges"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/model"
+)
+
+type (
+	DownloadCommand struct {
+		*CommandMeta
+	}
+	downloaded struct {
+		Market   string     `json:"market"`
+		Interval string     `json:"interval"`
+		Candles  int        `json:"candles"`
+		First    *time.Time `json:"first,omitempty"`
+		Last     *time.Time `json:"last,omitempty"`
+	}
+)
+
+// parseDate reads a date (2006-01-02) or a date and time (RFC 3339)
+func parseDate(name string) (*time.Time, error) {
+	arg := flag.Get(name)
+	if !arg.Exists || arg.String() == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{"2006-01-02", time.RFC3339} {
+		if out, err := time.Parse(layout, arg.String()); err == nil {
+			return &out, nil
+		}
+	}
+	return nil, errors.Errorf("%s %v is invalid", name, arg)
+}
+
+func (c *DownloadCommand) Run(args []string) int {
+	var err error
+
+	var exchange model.Exchange
+	if exchange, err = exchanges.GetExchange(); err != nil {
+		return c.ReturnError(err)
+	}
+
+	arg := flag.Get("market")
+	if !arg.Exists || arg.String() == "" {
+		return c.ReturnError(errors.New("missing argument: market"))
+	}
+	names := arg.Split()
+
+	markets, err := exchange.GetMarkets(true, flag.Sandbox(), nil)
+	if err != nil {
+		return c.ReturnError(err)
+	}
+	for _, name := range names {
+		if !model.HasMarket(markets, name) {
+			return c.ReturnError(errors.Errorf("market %s does not exist", name))
+		}
+	}
+
+	interval := time.Hour
+	if arg := flag.Get("interval"); arg.Exists && arg.String() != "" {
+		if interval, err = model.ParseInterval(arg.String()); err != nil {
+			return c.ReturnError(err)
+		}
+	}
+
+	start, err := parseDate("from")
+	if err != nil {
+		return c.ReturnError(err)
+	}
+	if start == nil {
+		return c.ReturnError(errors.New("missing argument: from"))
+	}
+	end, err := parseDate("to")
+	if err != nil {
+		return c.ReturnError(err)
+	}
+	if end == nil {
+		now := time.Now()
+		end = &now
+	}
+	if !end.After(*start) {
+		return c.ReturnError(errors.Errorf("to %v is before from %v", flag.Get("to"), flag.Get("from")))
+	}
+
+	var client interface{}
+	if client, err = exchange.GetClient(model.PUBLIC, flag.Sandbox()); err != nil {
+		return c.ReturnError(err)
+	}
+
+	var out []downloaded
+	for _, market := range names {
+		log.Printf("[INFO] Downloading %s %s candles from %s to %s...", market, model.FormatInterval(interval), start.Format(time.RFC3339), end.Format(time.RFC3339))
+		// the candle store asks the exchange for the candles we don't have yet, and stores them
+		candles, err := exchanges.GetCandles(exchange, client, market, interval, *start, *end)
+		if err != nil {
+			return c.ReturnError(err)
+		}
+		entry := downloaded{
+			Market:   market,
+			Interval: model.FormatInterval(interval),
+			Candles:  len(candles),
+		}
+		if len(candles) > 0 {
+			entry.First = &candles[0].Time
+			entry.Last = &candles[len(candles)-1].Time
+		}
+		out = append(out, entry)
+	}
+
+	data, err := json.Marshal(out)
+	if err != nil {
+		return c.ReturnError(err)
+	}
+	fmt.Println(string(data))
+
+	return 0
+}
+
+func (c *DownloadCommand) Help() string {
+	text := `
+Usage: ./nefertiti download [options]
+
+The download command pulls the historical candles of one or more markets into
+the local candle store, so that a backtest can read them without asking the
+exchange again. We only ask for the candles that aren't in the store yet.
+
+Options:
+  --exchange = name, for example: Binance
+  --market   = a valid market pair, or a comma-separated list of pairs
+  --interval = the size of a candle, for example: 1m, 15m, 1h, 1d (optional,
+               defaults to 1h)
+  --from     = the first day, for example: 2024-01-01 (or a RFC 3339 time)
+  --to       = up to (not including) this day, or this RFC 3339 time
+               (optional, defaults to now)
+`
+	return strings.TrimSpace(text)
+}
+
+func (c *DownloadCommand) Synopsis() string {
+	return "Downloads historical candles into the local candle store."
+}
@@ -3,14 +3,22 @@ package command
 import (
 	"encoding/json"
 	"fmt"
+	"os"
+	"strings"
 
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/svanas/nefertiti/errors"
 	"github.com/svanas/nefertiti/exchanges"
+	"github.com/svanas/nefertiti/flag"
 )
 
 type (
 	ExchangesCommand struct {
 		*CommandMeta
 	}
+	ExchangesListCommand struct {
+		*CommandMeta
+	}
 )
 
 func (c *ExchangesCommand) Run(args []string) int {
@@ -35,3 +43,78 @@ func (c *ExchangesCommand) Help() string {
 func (c *ExchangesCommand) Synopsis() string {
 	return "Get a list of supported exchanges."
 }
+
+func (c *ExchangesListCommand) Run(args []string) int {
+	output := "json"
+	if arg := flag.Get("output"); arg.Exists && arg.String() != "" {
+		output = strings.ToLower(arg.String())
+	}
+	if output != "json" && output != "table" {
+		return c.ReturnError(errors.Errorf("output %s is invalid", output))
+	}
+
+	var out []exchanges.Capabilities
+	for _, exchange := range *exchanges.New() {
+		capabilities, err := exchanges.GetCapabilities(exchange)
+		if err != nil {
+			return c.ReturnError(err)
+		}
+		out = append(out, *capabilities)
+	}
+
+	switch output {
+	case "table":
+		yes := func(value bool) string {
+			if value {
+				return "Y"
+			}
+			return "N"
+		}
+		tbl := table.NewWriter()
+		tbl.SetOutputMirror(os.Stdout)
+		tbl.AppendHeader(table.Row{"Code", "Name", "Country", "Strategies", "Stop", "OCO", "Trailing", "Futures", "Sandbox", "WebSocket"})
+		for _, capabilities := range out {
+			tbl.AppendRow(table.Row{
+				capabilities.Code,
+				capabilities.Name,
+				capabilities.Country,
+				strings.Join(capabilities.Strategies, ","),
+				yes(capabilities.Stop),
+				yes(capabilities.OCO),
+				yes(capabilities.TrailingStop),
+				yes(capabilities.Futures),
+				yes(capabilities.Sandbox),
+				yes(capabilities.WebSocket),
+			})
+		}
+		tbl.Render()
+	default:
+		data, err := json.Marshal(out)
+		if err != nil {
+			return c.ReturnError(err)
+		}
+		fmt.Println(string(data))
+	}
+
+	return 0
+}
+
+func (c *ExchangesListCommand) Help() string {
+	text := `
+Usage: ./nefertiti exchanges list [options]
+
+The exchanges list command prints what every supported exchange can do: the
+strategies that the sell command supports on it, whether it can open stop
+orders, OCO orders and trailing stops, whether it has futures, a sandbox and
+a websocket, and whether it tells us about your balances, historic candles,
+the permissions of your API key and the withdrawals from your account.
+
+Options:
+  --output = [json|table] (optional, defaults to json)
+`
+	return strings.TrimSpace(text)
+}
+
+func (c *ExchangesListCommand) Synopsis() string {
+	return "Get a list of supported exchanges, and what they can do."
+}
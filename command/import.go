@@ -0,0 +1,182 @@
+package command
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/exchanges"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/multiplier"
+)
+
+type (
+	ImportCommand struct {
+		*CommandMeta
+	}
+)
+
+// readOrdersCSV reads your order history from a CSV file with a header row. the market, side, size and price columns
+// are required, the time column (RFC 3339) is optional.
+func readOrdersCSV(path string) (model.Orders, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	index := make(map[string]int)
+	for i, name := range header {
+		index[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"market", "side", "size", "price"} {
+		if _, ok := index[name]; !ok {
+			return nil, errors.Errorf("%s is missing column: %s", path, name)
+		}
+	}
+
+	var out model.Orders
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, 1)
+		}
+		order := model.Order{
+			Market: record[index["market"]],
+			Side:   model.NewOrderSide(strings.ToLower(record[index["side"]])),
+		}
+		if order.Side == model.ORDER_SIDE_NONE {
+			return nil, errors.Errorf("%s line %d: side %s is invalid", path, line, record[index["side"]])
+		}
+		if order.Size, err = strconv.ParseFloat(record[index["size"]], 64); err != nil {
+			return nil, errors.Errorf("%s line %d: size %s is invalid", path, line, record[index["size"]])
+		}
+		if order.Price, err = strconv.ParseFloat(record[index["price"]], 64); err != nil {
+			return nil, errors.Errorf("%s line %d: price %s is invalid", path, line, record[index["price"]])
+		}
+		if i, ok := index["time"]; ok && record[i] != "" {
+			if order.CreatedAt, err = time.Parse(time.RFC3339, record[i]); err != nil {
+				return nil, errors.Errorf("%s line %d: time %s is invalid", path, line, record[i])
+			}
+		}
+		out = append(out, order)
+	}
+
+	return out, nil
+}
+
+func (c *ImportCommand) Run(args []string) int {
+	exchange, err := exchanges.GetExchange()
+	if err != nil {
+		return c.ReturnError(err)
+	}
+
+	var (
+		orders model.Orders
+		client interface{}
+	)
+	if path := flag.Get("csv").String(); path != "" {
+		if orders, err = readOrdersCSV(path); err != nil {
+			return c.ReturnError(err)
+		}
+	} else {
+		markets := flag.Get("market").Split()
+		if len(markets) == 0 || markets[0] == "" {
+			return c.ReturnError(errors.New("missing argument: market or csv"))
+		}
+		if client, err = exchange.GetClient(model.PRIVATE, flag.Sandbox()); err != nil {
+			return c.ReturnError(err)
+		}
+		for _, market := range markets {
+			var closed model.Orders
+			if closed, err = exchange.GetClosed(client, market); err != nil {
+				return c.ReturnError(err)
+			}
+			orders = append(orders, closed...)
+		}
+	}
+
+	positions, err := exchanges.ReadPositions(exchange)
+	if err != nil {
+		return c.ReturnError(err)
+	}
+
+	// the imported markets replace whatever we had, so you can import the same history more than once
+	imported := exchanges.NewPositions(orders)
+	for _, order := range orders {
+		if pos, ok := imported[order.Market]; ok {
+			positions[order.Market] = pos
+		} else {
+			positions = positions.Without(order.Market)
+		}
+	}
+
+	if !flag.DryRun() {
+		if err = positions.Write(exchange); err != nil {
+			return c.ReturnError(err)
+		}
+	}
+
+	mult, err := multiplier.Get(multiplier.FIVE_PERCENT)
+	if err != nil {
+		return c.ReturnError(err)
+	}
+
+	// we need a client for the tickers. if you imported a CSV file, then a public one will do.
+	if client == nil {
+		client, _ = exchange.GetClient(model.PUBLIC, flag.Sandbox())
+	}
+
+	out, err := json.Marshal(imported.Report(exchange, client, mult))
+	if err != nil {
+		return c.ReturnError(err)
+	}
+
+	fmt.Println(string(out))
+
+	return 0
+}
+
+func (c *ImportCommand) Help() string {
+	text := `
+Usage: ./nefertiti import [options]
+
+The import command backfills your positions (and their cost basis) from your
+order history, so that the bot knows what you paid for the assets you bought
+before you installed it. The sell command uses the cost basis in --bootstrap
+mode.
+
+Options:
+  --exchange = name
+  --market   = a valid market pair, or a comma-separated list of markets to
+               import from the exchange's order history
+  --csv      = path to a CSV file to import instead. the file has a header row
+               with the market, side, size, price and (optionally) time columns.
+               time is in RFC 3339 format, for example: 2021-06-01T12:00:00Z
+  --dry-run  = if included, prints the positions but does not save them
+               (optional, defaults to false)
+  --mult     = the target of your positions, as a multiple of their cost basis
+               (optional, defaults to 1.05)
+`
+	return strings.TrimSpace(text)
+}
+
+func (c *ImportCommand) Synopsis() string {
+	return "Import your order history to establish your cost basis."
+}
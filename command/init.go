@@ -0,0 +1,347 @@
+package command
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/exchanges"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/passphrase"
+	"github.com/svanas/nefertiti/vault"
+)
+
+type (
+	InitCommand struct {
+		*CommandMeta
+	}
+	// initSetting is one line in the config file that we emit
+	initSetting struct {
+		name  string
+		value string
+	}
+)
+
+// the exchanges that need a third secret, and what they call it
+var initPassphrase = map[string]string{
+	"GDAX": "API passphrase",
+	"KUCN": "API passphrase",
+	"CXIO": "user name",
+}
+
+type initPrompt struct {
+	reader *bufio.Reader
+}
+
+// ask prompts for a value. returns the default if you leave it empty.
+func (p *initPrompt) ask(question, def string) (string, error) {
+	if def != "" {
+		fmt.Printf("%s [%s]: ", question, def)
+	} else {
+		fmt.Printf("%s: ", question)
+	}
+	line, err := p.reader.ReadString('\n')
+	if err != nil {
+		return "", errors.Wrap(err, 1)
+	}
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return def, nil
+	}
+	return line, nil
+}
+
+// choose prompts for one of the options
+func (p *initPrompt) choose(question string, options []string, def string) (string, error) {
+	for {
+		out, err := p.ask(fmt.Sprintf("%s (%s)", question, strings.Join(options, "|")), def)
+		if err != nil {
+			return "", err
+		}
+		for _, option := range options {
+			if strings.EqualFold(option, out) {
+				return option, nil
+			}
+		}
+		fmt.Printf("%s is not one of the options.\n", out)
+	}
+}
+
+// number prompts for a positive number
+func (p *initPrompt) number(question, def string) (string, error) {
+	for {
+		out, err := p.ask(question, def)
+		if err != nil {
+			return "", err
+		}
+		if value, err := strconv.ParseFloat(out, 64); err == nil && value > 0 {
+			return out, nil
+		}
+		fmt.Printf("%s is not a valid number.\n", out)
+	}
+}
+
+// secret prompts for a value without echoing it
+func (p *initPrompt) secret(name string) (string, error) {
+	data, err := passphrase.Read(name)
+	if err != nil {
+		return "", errors.Wrap(err, 1)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+func (c *InitCommand) Run(args []string) int {
+	var err error
+
+	prompt := &initPrompt{reader: bufio.NewReader(os.Stdin)}
+
+	fmt.Println("This wizard writes a config file (plus a sample systemd unit) for one strategy on one exchange.")
+	fmt.Println("Your keys go into an encrypted vault, never into the config file.")
+	fmt.Println()
+
+	// step #1: the exchange
+	var options []string
+	for _, exchange := range *exchanges.New() {
+		options = append(options, strings.ToLower(exchange.GetInfo().Code))
+	}
+	code, err := prompt.choose("Exchange", options, "")
+	if err != nil {
+		return c.ReturnError(err)
+	}
+	var exchange model.Exchange
+	for _, other := range *exchanges.New() {
+		if other.GetInfo().Equals(code) {
+			exchange = other
+		}
+	}
+	info := exchange.GetInfo()
+
+	settings := []initSetting{{"exchange", code}}
+	if user, err := flag.User(); err == nil && user != "" {
+		settings = append(settings, initSetting{"user", user})
+	}
+	secrets := make(map[string]string) // flag -> value
+
+	// step #2: the keys
+	switch info.Code {
+	case "JUP":
+		keypair, err := prompt.ask("Path to your Solana keypair file", "")
+		if err != nil {
+			return c.ReturnError(err)
+		}
+		settings = append(settings, initSetting{"keypair", keypair})
+	case "1INCH":
+		chain, err := prompt.choose("Chain", []string{"ethereum", "polygon", "arbitrum"}, "ethereum")
+		if err != nil {
+			return c.ReturnError(err)
+		}
+		wallet, err := prompt.ask("Path to your wallet file", "")
+		if err != nil {
+			return c.ReturnError(err)
+		}
+		settings = append(settings, initSetting{"chain", chain}, initSetting{"wallet", wallet})
+		if secrets["api-key"], err = prompt.secret("1inch API key"); err != nil {
+			return c.ReturnError(err)
+		}
+	default:
+		if secrets["api-key"], err = prompt.secret(info.Name + " API key"); err != nil {
+			return c.ReturnError(err)
+		}
+		if secrets["api-secret"], err = prompt.secret(info.Name + " API secret"); err != nil {
+			return c.ReturnError(err)
+		}
+		if name, ok := initPassphrase[info.Code]; ok {
+			if secrets["api-passphrase"], err = prompt.secret(info.Name + " " + name); err != nil {
+				return c.ReturnError(err)
+			}
+		}
+	}
+
+	// step #3: the strategy
+	strategy, err := prompt.choose("Strategy", []string{"sell", "buy"}, "sell")
+	if err != nil {
+		return c.ReturnError(err)
+	}
+
+	// step #4: the multipliers and the markets
+	if strategy == "sell" {
+		mult, err := prompt.number("Multiplier for your sell orders, for example 1.05 is 5% above what you paid", "1.05")
+		if err != nil {
+			return c.ReturnError(err)
+		}
+		stoploss, err := prompt.choose("Stop-loss", []string{"Y", "N"}, "N")
+		if err != nil {
+			return c.ReturnError(err)
+		}
+		settings = append(settings, initSetting{"mult", mult}, initSetting{"stoploss", stoploss})
+	} else {
+		market, err := prompt.ask("Markets to buy, comma-separated, or all", "all")
+		if err != nil {
+			return c.ReturnError(err)
+		}
+		settings = append(settings, initSetting{"market", market})
+		if strings.EqualFold(market, "all") {
+			quote, err := prompt.ask("Quote currency, for example BTC or USDT", "USDT")
+			if err != nil {
+				return c.ReturnError(err)
+			}
+			settings = append(settings, initSetting{"quote", quote})
+		}
+		price, err := prompt.number("What you spend per order, in quote currency", "")
+		if err != nil {
+			return c.ReturnError(err)
+		}
+		dip, err := prompt.number("Percentage below the ticker to buy at", "5")
+		if err != nil {
+			return c.ReturnError(err)
+		}
+		repeat, err := prompt.number("Repeat every X hours", "1")
+		if err != nil {
+			return c.ReturnError(err)
+		}
+		settings = append(settings, initSetting{"price", price}, initSetting{"dip", dip}, initSetting{"repeat", repeat})
+	}
+
+	// step #5: the notifications
+	channel, err := prompt.choose("Notifications", []string{"pushover", "telegram", "none"}, "none")
+	if err != nil {
+		return c.ReturnError(err)
+	}
+	notify := make(map[string]string)
+	switch channel {
+	case "pushover":
+		if notify["pushover-app-key"], err = prompt.secret("Pushover application key"); err != nil {
+			return c.ReturnError(err)
+		}
+		if notify["pushover-user-key"], err = prompt.secret("Pushover user key"); err != nil {
+			return c.ReturnError(err)
+		}
+	case "telegram":
+		if notify["telegram-app-key"], err = prompt.secret("Telegram app key"); err != nil {
+			return c.ReturnError(err)
+		}
+		if notify["telegram-chat-id"], err = prompt.ask("Telegram chat ID", ""); err != nil {
+			return c.ReturnError(err)
+		}
+	}
+	if channel != "none" {
+		level, err := prompt.choose("Notify level: 0 = nothing, 1 = errors, 2 = errors + filled orders, 3 = everything", []string{"0", "1", "2", "3"}, "2")
+		if err != nil {
+			return c.ReturnError(err)
+		}
+		settings = append(settings, initSetting{"notify", level})
+	}
+
+	// step #6: the vault
+	fmt.Println()
+	var password []byte
+	if vault.Exists() {
+		fmt.Println("Please unlock your vault, so we can add your keys to it.")
+		password, err = vault.Password(false)
+	} else {
+		fmt.Println("Please choose a password for your vault.")
+		password, err = vault.Password(true)
+	}
+	if err != nil {
+		return c.ReturnError(err)
+	}
+	keys, err := vault.Open(password)
+	if err != nil {
+		return c.ReturnError(err)
+	}
+	for name, value := range secrets {
+		keys.Set(vault.Section(info.Code), name, value)
+	}
+	for name, value := range notify {
+		keys.Set(vault.SECTION_NOTIFY, name, value)
+	}
+	if err = keys.Save(password); err != nil {
+		return c.ReturnError(err)
+	}
+
+	// step #7: the config file
+	label := strings.ToLower(code + "-" + strategy)
+	settings = append(settings, initSetting{"vault", ""}, initSetting{"label", label})
+
+	var config strings.Builder
+	fmt.Fprintf(&config, "# %s %s, written by the init command\n", c.AppName, strategy)
+	for _, setting := range settings {
+		if setting.value == "" {
+			fmt.Fprintln(&config, setting.name)
+		} else {
+			fmt.Fprintf(&config, "%s=%s\n", setting.name, setting.value)
+		}
+	}
+	configFile := filepath.Join(vault.Dir(), label+".conf")
+	if err = os.WriteFile(configFile, []byte(config.String()), 0600); err != nil {
+		return c.ReturnError(err)
+	}
+
+	// step #8: the systemd unit
+	exe, err := os.Executable()
+	if err != nil {
+		exe = c.AppName
+	}
+	// the vault lives in the config dir of whoever ran this wizard, so the service needs to run as that user
+	owner := "root"
+	if current, err := user.Current(); err == nil {
+		owner = current.Username
+	}
+	envFile := filepath.Join(vault.Dir(), "env")
+	unit := fmt.Sprintf(`[Unit]
+Description=%s %s on %s
+After=network-online.target
+Wants=network-online.target
+
+[Service]
+User=%s
+# put NEFERTITI_VAULT_PASSWORD=... in this file, and chmod 600 it. this is your
+# vault password in plain text: anyone who can read this file can unlock your keys.
+EnvironmentFile=%s
+ExecStart=%s %s --config=%s
+Restart=on-failure
+RestartSec=60
+
+[Install]
+WantedBy=multi-user.target
+`, c.AppName, strategy, info.Name, owner, envFile, exe, strategy, configFile)
+	unitFile := filepath.Join(vault.Dir(), c.AppName+"-"+label+".service")
+	if err = os.WriteFile(unitFile, []byte(unit), 0600); err != nil {
+		return c.ReturnError(err)
+	}
+
+	fmt.Println()
+	fmt.Printf("Your config file: %s\n", configFile)
+	fmt.Printf("Your systemd unit: %s\n", unitFile)
+	fmt.Printf("The unit reads your vault password from %s. That file holds your password in plain text, so chmod 600 it.\n", envFile)
+	fmt.Printf("Run it with: %s %s --config=%s\n", exe, strategy, configFile)
+
+	return 0
+}
+
+func (c *InitCommand) Help() string {
+	text := `
+Usage: ./nefertiti init
+
+The init command walks you through selecting an exchange, entering your keys,
+choosing a strategy, multipliers, markets and notifications. Your keys go into
+an encrypted vault. Then it writes a ready-to-run config file and a sample
+systemd unit.
+
+Run the config file with: ./nefertiti [sell|buy] --config=FILE
+Set NEFERTITI_VAULT_PASSWORD if you don't want to be asked for the password of
+your vault. The systemd unit runs as the user that ran the wizard (that's where
+the vault is) and reads this variable from an env file, in plain text.
+`
+	return strings.TrimSpace(text)
+}
+
+func (c *InitCommand) Synopsis() string {
+	return "Interactive setup wizard."
+}
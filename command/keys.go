@@ -0,0 +1,272 @@
+package command
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/mitchellh/cli"
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/exchanges"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/vault"
+)
+
+type (
+	KeysCommand struct {
+		*CommandMeta
+	}
+	KeysCheckCommand struct {
+		*CommandMeta
+	}
+	// KeysReport is what the API key of an exchange is allowed to do, versus what your strategies need
+	KeysReport struct {
+		Exchange    string                `json:"exchange"`
+		Strategies  []string              `json:"strategies,omitempty"`
+		Needs       model.KeyPermissions  `json:"needs"`                 // what your strategies need
+		Permissions *model.KeyPermissions `json:"permissions,omitempty"` // nil if the exchange doesn't tell us
+		Problems    []string              `json:"problems,omitempty"`
+		Error       string                `json:"error,omitempty"`
+	}
+)
+
+func (c *KeysCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+func (c *KeysCommand) Help() string {
+	text := `
+Usage: ./nefertiti keys check [options]
+
+The keys commands look at the API keys in your vault.
+`
+	return strings.TrimSpace(text)
+}
+
+func (c *KeysCommand) Synopsis() string {
+	return "Look at the API keys in your vault."
+}
+
+// keysStrategies reads the config files that the init command wrote, and returns the strategies per exchange code
+func keysStrategies() (map[string][]string, error) {
+	out := make(map[string][]string)
+	files, err := filepath.Glob(filepath.Join(vault.Dir(), "*.conf"))
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+	for _, name := range files {
+		if err := func() error {
+			file, err := os.Open(name)
+			if err != nil {
+				return errors.Wrap(err, 1)
+			}
+			defer file.Close()
+			var code, strategy string
+			scanner := bufio.NewScanner(file)
+			for scanner.Scan() {
+				line := strings.TrimSpace(scanner.Text())
+				if strings.HasPrefix(line, "#") {
+					// # nefertiti [strategy], written by the init command
+					if fields := strings.Fields(strings.Split(line, ",")[0]); len(fields) == 3 && strategy == "" {
+						strategy = fields[2]
+					}
+					continue
+				}
+				if strings.HasPrefix(line, "exchange=") {
+					code = strings.TrimPrefix(line, "exchange=")
+				}
+			}
+			if code != "" && strategy != "" {
+				code = strings.ToUpper(code)
+				out[code] = append(out[code], strategy)
+			}
+			return scanner.Err()
+		}(); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// keysNeeds returns the permissions that your strategies need. on a futures exchange, you trade futures.
+func keysNeeds(strategies []string, futures bool) model.KeyPermissions {
+	var out model.KeyPermissions
+	for _, strategy := range strategies {
+		switch strategy {
+		case "sell", "buy": // both read your orders, and place new ones
+			out.Read = true
+			out.Trade = !futures
+			out.Futures = futures
+		}
+	}
+	return out
+}
+
+// check compares the permissions of a key with what your strategies need
+func (report *KeysReport) check() {
+	if report.Permissions == nil {
+		return
+	}
+	if report.Needs.Read && !report.Permissions.Read {
+		report.Problems = append(report.Problems, "cannot read your orders")
+	}
+	if report.Needs.Trade && !report.Permissions.Trade {
+		report.Problems = append(report.Problems, "cannot trade")
+	}
+	if report.Needs.Futures && !report.Permissions.Futures {
+		report.Problems = append(report.Problems, "cannot trade futures")
+	}
+	if report.Permissions.Withdraw && !flag.AllowWithdraw() {
+		report.Problems = append(report.Problems, "can withdraw, but the bot never does")
+	}
+}
+
+func (c *KeysCheckCommand) Run(args []string) int {
+	output := "json"
+	if arg := flag.Get("output"); arg.Exists && arg.String() != "" {
+		output = strings.ToLower(arg.String())
+	}
+	if output != "json" && output != "table" {
+		return c.ReturnError(errors.Errorf("output %s is invalid", output))
+	}
+
+	if !vault.Exists() {
+		return c.ReturnError(errors.New("vault does not exist. please run the init command"))
+	}
+	password, err := vault.Password(false)
+	if err != nil {
+		return c.ReturnError(err)
+	}
+	keys, err := vault.Open(password)
+	if err != nil {
+		return c.ReturnError(err)
+	}
+
+	strategies, err := keysStrategies()
+	if err != nil {
+		return c.ReturnError(err)
+	}
+
+	var only model.Exchange
+	if flag.Exists("exchange") {
+		if only, err = exchanges.GetExchange(); err != nil {
+			return c.ReturnError(err)
+		}
+	}
+
+	// we report on the permissions ourselves, so GetClient should not warn you about them halfway through the report
+	exchanges.SetVerifyKeys(false)
+
+	var out []KeysReport
+	for _, exchange := range *exchanges.New() {
+		info := exchange.GetInfo()
+		if only != nil && only.GetInfo().Code != info.Code {
+			continue
+		}
+		secrets := keys[vault.Section(info.Code)]
+		if secrets["api-key"] == "" {
+			continue
+		}
+
+		// the secrets of this exchange replace the secrets of the previous one
+		for _, name := range []string{"api-key", "api-secret", "api-passphrase"} {
+			flag.Set(name, secrets[name])
+		}
+
+		_, futures := exchange.(model.Futures)
+		report := KeysReport{
+			Exchange:   info.Name,
+			Strategies: strategies[strings.ToUpper(info.Code)],
+		}
+		report.Needs = keysNeeds(report.Strategies, futures)
+		if reader, ok := exchange.(model.KeyReader); ok {
+			client, err := exchange.GetClient(model.PRIVATE, false)
+			if err == nil {
+				report.Permissions, err = reader.GetKeyPermissions(client)
+			}
+			if err != nil {
+				report.Error = err.Error()
+			}
+		}
+		report.check()
+		out = append(out, report)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Exchange < out[j].Exchange
+	})
+
+	switch output {
+	case "table":
+		yes := func(permissions *model.KeyPermissions, value func(*model.KeyPermissions) bool) string {
+			if permissions == nil {
+				return "?"
+			}
+			if value(permissions) {
+				return "Y"
+			}
+			return "N"
+		}
+		tbl := table.NewWriter()
+		tbl.SetOutputMirror(os.Stdout)
+		tbl.AppendHeader(table.Row{"Exchange", "Strategies", "Read", "Trade", "Withdraw", "Futures", "Problems"})
+		for _, report := range out {
+			problems := strings.Join(report.Problems, ", ")
+			if report.Error != "" {
+				problems = report.Error
+			} else if report.Permissions == nil {
+				problems = "the exchange does not tell us"
+			}
+			tbl.AppendRow(table.Row{
+				report.Exchange,
+				strings.Join(report.Strategies, ","),
+				yes(report.Permissions, func(p *model.KeyPermissions) bool { return p.Read }),
+				yes(report.Permissions, func(p *model.KeyPermissions) bool { return p.Trade }),
+				yes(report.Permissions, func(p *model.KeyPermissions) bool { return p.Withdraw }),
+				yes(report.Permissions, func(p *model.KeyPermissions) bool { return p.Futures }),
+				problems,
+			})
+		}
+		tbl.Render()
+	default:
+		data, err := json.Marshal(out)
+		if err != nil {
+			return c.ReturnError(err)
+		}
+		fmt.Println(string(data))
+	}
+
+	return 0
+}
+
+func (c *KeysCheckCommand) Help() string {
+	text := `
+Usage: ./nefertiti keys check [options]
+
+The keys check command reports what the API keys in your vault are allowed
+to do (read, trade, withdraw, futures), and whether that matches what your
+strategies need. Your strategies are the config files that the init command
+wrote. Both the sell and the buy strategy need to read and trade (or trade
+futures, on a futures exchange). A key without a strategy needs nothing, but
+it should not be able to withdraw. Binance and KuCoin tell us about the permissions of a key; the other
+exchanges do not.
+
+Options:
+  --exchange = name of the exchange to check (optional, defaults to every
+               exchange in your vault)
+  --allow-withdraw = if included, a key that can withdraw isn't a problem
+               (optional)
+  --output   = [json|table] (optional, defaults to json)
+`
+	return strings.TrimSpace(text)
+}
+
+func (c *KeysCheckCommand) Synopsis() string {
+	return "Check the permissions of the API keys in your vault."
+}
@@ -0,0 +1,106 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/exchanges"
+	"github.com/svanas/nefertiti/flag"
+)
+
+type (
+	LimitsCommand struct {
+		*CommandMeta
+	}
+)
+
+func (c *LimitsCommand) Run(args []string) int {
+	output := "json"
+	if arg := flag.Get("output"); arg.Exists && arg.String() != "" {
+		output = strings.ToLower(arg.String())
+	}
+	if output != "json" && output != "table" {
+		return c.ReturnError(errors.Errorf("output %s is invalid", output))
+	}
+
+	var names []string
+	if arg := flag.Get("exchange"); arg.Exists && arg.String() != "" {
+		names = arg.Split()
+	} else {
+		for _, exchange := range *exchanges.New() {
+			names = append(names, exchange.GetInfo().Code)
+		}
+	}
+
+	var out []exchanges.Limits
+	for _, name := range names {
+		flag.Set("exchange", name)
+		exchange, err := exchanges.GetExchange()
+		if err != nil {
+			return c.ReturnError(err)
+		}
+		limits, err := exchanges.GetLimits(exchange)
+		if err != nil {
+			return c.ReturnError(err)
+		}
+		out = append(out, *limits)
+	}
+
+	switch output {
+	case "table":
+		tbl := table.NewWriter()
+		tbl.SetOutputMirror(os.Stdout)
+		tbl.AppendHeader(table.Row{"Exchange", "Endpoint", "Intensity", "Req/sec", "Cooldown", "429s (24h)", "Last 429"})
+		for _, limits := range out {
+			last := ""
+			if limits.LastRateLimited != nil {
+				last = limits.LastRateLimited.Format("2006-01-02 15:04:05")
+			}
+			tbl.AppendRow(table.Row{limits.Exchange, "*", "", limits.RequestsPerSecond, limits.Cooldown, limits.RateLimited, last})
+			for _, endpoint := range limits.Endpoints {
+				intensity := ""
+				if endpoint.Intensity > 0 {
+					intensity = fmt.Sprintf("%d", endpoint.Intensity)
+				}
+				tbl.AppendRow(table.Row{"", endpoint.Path, intensity, endpoint.RequestsPerSecond, "", "", ""})
+			}
+		}
+		tbl.Render()
+	default:
+		data, err := json.Marshal(out)
+		if err != nil {
+			return c.ReturnError(err)
+		}
+		fmt.Println(string(data))
+	}
+
+	return 0
+}
+
+func (c *LimitsCommand) Help() string {
+	text := `
+Usage: ./nefertiti limits [options]
+
+The limits command prints the current state of our throttle, per exchange:
+the effective requests per second, the learned intensity per endpoint (if the
+exchange has one), whether we are cooling down, and how many times we ran into
+a rate limit error during the last 24 hours.
+
+Options:
+  --exchange = name, or a comma-separated list of names (optional, defaults to
+               all exchanges)
+  --output   = [json|table] (optional, defaults to json)
+  --api-key  = (optional) on Bittrex, Crypto.com and KuCoin, every API key has
+               its own throttle. if you omit this, then you get the throttle
+               for the public endpoints. other exchanges meter per IP address.
+`
+	return strings.TrimSpace(text)
+}
+
+func (c *LimitsCommand) Synopsis() string {
+	return "Prints the rate limits we are throttling our requests to."
+}
@@ -4,7 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"log"
 	"net/http"
 	"os"
@@ -14,8 +14,10 @@ import (
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/svanas/nefertiti/control"
 	"github.com/svanas/nefertiti/errors"
 	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/session"
 )
 
 type (
@@ -32,10 +34,10 @@ func (c *ListenCommand) Run(args []string) int {
 	var err error
 
 	router := mux.NewRouter()
-	router.HandleFunc("/ping", ping).Host("127.0.0.1").Methods(http.MethodGet)
-	router.HandleFunc("/post", post).Host("127.0.0.1").Methods(http.MethodPost)
-	router.HandleFunc("/", delete).Host("127.0.0.1").Methods(http.MethodDelete)
-	router.HandleFunc("/callback", callback).Host("127.0.0.1").Methods(http.MethodPost)
+	router.HandleFunc("/ping", control.Require(control.ROLE_VIEWER, ping)).Host("127.0.0.1").Methods(http.MethodGet)
+	router.HandleFunc("/post", control.Require(control.ROLE_OPERATOR, post)).Host("127.0.0.1").Methods(http.MethodPost)
+	router.HandleFunc("/", control.Require(control.ROLE_OPERATOR, delete)).Host("127.0.0.1").Methods(http.MethodDelete)
+	router.HandleFunc("/callback", control.Require(control.ROLE_OPERATOR, callback)).Host("127.0.0.1").Methods(http.MethodPost)
 
 	port := flag.Get("port")
 	if port.Exists {
@@ -67,7 +69,17 @@ func (c *ListenCommand) Run(args []string) int {
 }
 
 func (c *ListenCommand) Help() string {
-	return "Usage: ./nefertiti listen [options]"
+	text := `
+Usage: ./nefertiti listen [options]
+
+Options:
+  --port          = the port to listen to (optional, defaults to 38700)
+  --control-token = role:token,role:token where role is viewer or operator.
+                    if included, every request requires a token. a viewer can
+                    ping, an operator can also start and stop the bots. the
+                    bots that we start share these tokens (optional)
+`
+	return strings.TrimSpace(text)
 }
 
 func (c *ListenCommand) Synopsis() string {
@@ -80,6 +92,10 @@ type Pong struct {
 	Port    int64    `json:"port"`
 	Command string   `json:"command"`
 	Args    []string `json:"args"`
+	Run     string   `json:"run,omitempty"`
+	Label   string   `json:"label,omitempty"`
+	// the pause switch (if any) of the session dir
+	Paused *session.Pause `json:"paused,omitempty"`
 }
 
 type Pongs []Pong
@@ -118,7 +134,7 @@ func getFirstAvailablePort(req *http.Request) int64 {
 	host, port, _ := getHostPort(req)
 	for { // enumerate over ports, starting with "our" port + 1
 		port++
-		_, err := http.Get("http://" + host + ":" + strconv.FormatInt(port, 10) + "/ping")
+		_, err := control.Get("http://" + host + ":" + strconv.FormatInt(port, 10) + "/ping")
 		if err != nil {
 			break
 		}
@@ -139,12 +155,12 @@ func getPong(req *http.Request) Pongs {
 
 	for { // enumerate over ports, starting with "our" port + 1
 		port++
-		resp, err := http.Get("http://" + host + ":" + strconv.FormatInt(port, 10) + "/ping")
+		resp, err := control.Get("http://" + host + ":" + strconv.FormatInt(port, 10) + "/ping")
 		if err != nil {
 			break
 		}
 		// add the response to this func result
-		if raw, err := ioutil.ReadAll(resp.Body); err != nil {
+		if raw, err := io.ReadAll(resp.Body); err != nil {
 			log.Printf("[ERROR] %v", err)
 		} else {
 			var pong Pong
@@ -206,6 +222,7 @@ func post(resp http.ResponseWriter, req *http.Request) {
 				return
 			}
 			post.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+			control.Sign(post)
 			// submit the POST request
 			if _, err := http.DefaultClient.Do(post); err != nil {
 				returnError(resp, err)
@@ -230,6 +247,10 @@ func post(resp http.ResponseWriter, req *http.Request) {
 		fmt.Sprintf("--hub=%d", self),
 		fmt.Sprintf("--port=%d", port),
 	)
+	// the new instance shares our tokens, so that we can keep on talking to it
+	if token := flag.Get("control-token"); token.Exists && req.Form.Get("control-token") == "" {
+		args = append(args, "--control-token="+token.String())
+	}
 	for key, value := range req.Form {
 		if key != "" && key != "port" && key != "command" {
 			arg := "--" + key
@@ -329,6 +350,7 @@ func delete(resp http.ResponseWriter, req *http.Request) {
 			returnError(resp, err)
 			return
 		}
+		control.Sign(delete)
 		http.DefaultClient.Do(delete)
 		json.NewEncoder(resp).Encode(getPong(req))
 		return
@@ -341,6 +363,7 @@ func delete(resp http.ResponseWriter, req *http.Request) {
 			returnError(resp, err)
 			return
 		}
+		control.Sign(delete)
 		http.DefaultClient.Do(delete)
 	}
 
@@ -3,16 +3,30 @@ package command
 import (
 	"encoding/json"
 	"fmt"
+	"log"
+	"sort"
 	"strings"
 
+	"github.com/svanas/nefertiti/errors"
 	"github.com/svanas/nefertiti/exchanges"
 	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/model"
 )
 
 type (
 	MarketsCommand struct {
 		*CommandMeta
 	}
+	MarketStats struct {
+		Name      string  `json:"name"`
+		Base      string  `json:"base,omitempty"`
+		Quote     string  `json:"quote,omitempty"`
+		High      float64 `json:"high,omitempty"`
+		Low       float64 `json:"low,omitempty"`
+		BtcVolume float64 `json:"btcVolume,omitempty"`
+		Last      float64 `json:"last,omitempty"`
+		Change    float64 `json:"change,omitempty"` // in %
+	}
 )
 
 func (c *MarketsCommand) Run(args []string) int {
@@ -26,12 +40,115 @@ func (c *MarketsCommand) Run(args []string) int {
 		return c.ReturnError(err)
 	}
 
-	out, err := json.Marshal(markets)
+	// --min-btc-volume=X
+	var minVolume float64
+	if arg := flag.Get("min-btc-volume"); arg.Exists && arg.String() != "" {
+		if minVolume, err = arg.Float64(); err != nil || minVolume < 0 {
+			return c.ReturnError(errors.Errorf("min-btc-volume %v is invalid", arg))
+		}
+	}
+
+	// --sort=[name|volume]
+	order := flag.Get("sort").String()
+	if order != "" && order != "name" && order != "volume" {
+		return c.ReturnError(errors.Errorf("sort %v is invalid", order))
+	}
+
+	// without any of the below flags, we return the list of markets as-is
+	if minVolume == 0 && order == "" && !flag.Exists("quote") && !flag.Exists("exclude-leveraged") && !flag.Exists("stats") && !flag.Exists("names") {
+		out, err := json.Marshal(markets)
+		if err != nil {
+			return c.ReturnError(err)
+		}
+		fmt.Println(string(out))
+		return 0
+	}
+
+	// the 24h stats are expensive, so we only get them if we need them
+	stats := minVolume > 0 || order == "volume" || flag.Exists("stats")
+
+	// filter on --quote and --exclude-leveraged first, so we do not ask for the stats of the markets we leave out
+	quotes := flag.Get("quote")
+	var filtered []model.Market
+	for _, market := range markets {
+		if quotes.Exists && quotes.String() != "" && !quotes.Contains(market.Quote) {
+			continue
+		}
+		if flag.Exists("exclude-leveraged") && exchange.IsLeveragedToken(market.Base) {
+			continue
+		}
+		filtered = append(filtered, market)
+	}
+
+	var (
+		client interface{}
+		all    map[string]model.Stats // the stats of every market, in one request where the exchange supports that
+	)
+	if stats && len(filtered) > 0 {
+		if client, err = exchange.GetClient(model.PUBLIC, flag.Sandbox()); err != nil {
+			return c.ReturnError(err)
+		}
+		if all, err = exchange.Get24hAll(client); err != nil && !errors.Is(err, exchanges.ErrNoSummaries) {
+			log.Printf("[WARN] %v\n", err)
+		}
+	}
+
+	var out []MarketStats
+	for _, market := range filtered {
+		entry := MarketStats{
+			Name:  market.Name,
+			Base:  market.Base,
+			Quote: market.Quote,
+		}
+		if stats {
+			var s *model.Stats
+			if entry, ok := all[market.Name]; ok {
+				s = &entry
+			} else if s, err = exchange.Get24h(client, market.Name); err != nil {
+				if flag.Get("ignore").Contains("error") {
+					log.Printf("[ERROR] %v\n", err)
+					continue
+				}
+				return c.ReturnError(err)
+			}
+			if s.BtcVolume < minVolume {
+				continue
+			}
+			entry.High = s.High
+			entry.Low = s.Low
+			entry.BtcVolume = s.BtcVolume
+			entry.Last = s.Last
+			entry.Change = s.Change
+		}
+		out = append(out, entry)
+	}
+
+	switch order {
+	case "name":
+		sort.Slice(out, func(i, j int) bool {
+			return out[i].Name < out[j].Name
+		})
+	case "volume":
+		sort.Slice(out, func(i, j int) bool {
+			return out[i].BtcVolume > out[j].BtcVolume
+		})
+	}
+
+	if flag.Exists("names") {
+		var names []string
+		for _, market := range out {
+			names = append(names, market.Name)
+		}
+		fmt.Println(strings.Join(names, ","))
+		return 0
+	}
+
+	data, err := json.Marshal(out)
 	if err != nil {
 		return c.ReturnError(err)
 	}
 
-	fmt.Println(string(out))
+	fmt.Println(string(data))
 
 	return 0
 }
@@ -43,7 +160,16 @@ Usage: ./nefertiti markets [options]
 The markets command returns a list of available currency pairs for trading.
 
 Options:
-  --exchange=[name]
+  --exchange          = [name]
+  --quote             = currency that is used as the reference, for example:
+                        BTC or USDT (optional)
+  --min-btc-volume    = minimum BTC volume over the last 24 hours (optional)
+  --exclude-leveraged = if included, excludes leveraged tokens (optional)
+  --stats             = if included, includes the 24h high, low and volume
+                        (optional)
+  --sort              = [name|volume] (optional)
+  --names             = if included, returns a comma-separated list of market
+                        names that you can feed into the buy command (optional)
 `
 	return strings.TrimSpace(text)
 }
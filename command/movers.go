@@ -0,0 +1,260 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/exchanges"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/notify"
+)
+
+type (
+	MoversCommand struct {
+		*CommandMeta
+	}
+	Mover struct {
+		Market    string  `json:"market"`
+		Ticker    float64 `json:"ticker"`
+		Change    float64 `json:"change"` // in %
+		BtcVolume float64 `json:"btcVolume"`
+	}
+)
+
+// moversWorkers is the number of markets we query at the same time. the exchange's rate limiter has the final say.
+const moversWorkers = 4
+
+func getMover(exchange model.Exchange, client interface{}, market string, tickers func() map[string]float64) (*Mover, error) {
+	stats, err := exchange.Get24h(client, market)
+	if err != nil {
+		return nil, err
+	}
+	// most exchanges tell us the last price with the stats. if they don't, then we look it up.
+	ticker := stats.Last
+	if ticker == 0 {
+		ticker = tickers()[market]
+	}
+	if ticker == 0 {
+		if ticker, err = exchange.GetTicker(client, market); err != nil {
+			return nil, err
+		}
+	}
+	out := &Mover{
+		Market:    market,
+		Ticker:    ticker,
+		BtcVolume: stats.BtcVolume,
+	}
+	// the change is relative to the average price over the last 24 hours
+	avg := (stats.High + stats.Low) / 2
+	if avg > 0 {
+		out.Change = ((ticker - avg) / avg) * 100
+	}
+	return out, nil
+}
+
+func (c *MoversCommand) Run(args []string) int {
+	exchange, err := exchanges.GetExchange()
+	if err != nil {
+		return c.ReturnError(err)
+	}
+
+	// --top=N
+	var top int64 = 10
+	if arg := flag.Get("top"); arg.Exists && arg.String() != "" {
+		if top, err = arg.Int64(); err != nil || top < 1 {
+			return c.ReturnError(errors.Errorf("top %v is invalid", arg))
+		}
+	}
+
+	// --min-btc-volume=X
+	var minVolume float64
+	if arg := flag.Get("min-btc-volume"); arg.Exists && arg.String() != "" {
+		if minVolume, err = arg.Float64(); err != nil || minVolume < 0 {
+			return c.ReturnError(errors.Errorf("min-btc-volume %v is invalid", arg))
+		}
+	}
+
+	// --sort=[change|volume]
+	order := "change"
+	if arg := flag.Get("sort"); arg.Exists && arg.String() != "" {
+		order = arg.String()
+	}
+	if order != "change" && order != "volume" {
+		return c.ReturnError(errors.Errorf("sort %v is invalid", order))
+	}
+
+	all, err := exchange.GetMarkets(true, flag.Sandbox(), flag.Get("ignore").Split())
+	if err != nil {
+		return c.ReturnError(err)
+	}
+
+	quotes := flag.Get("quote")
+
+	var markets []string
+	for _, market := range all {
+		if quotes.Exists && quotes.String() != "" && !quotes.Contains(market.Quote) {
+			continue
+		}
+		if exchange.IsLeveragedToken(market.Base) {
+			continue
+		}
+		markets = append(markets, market.Name)
+	}
+	if len(markets) == 0 {
+		return c.ReturnError(errors.New("no markets found"))
+	}
+
+	client, err := exchange.GetClient(model.PUBLIC, flag.Sandbox())
+	if err != nil {
+		return c.ReturnError(err)
+	}
+
+	// if we need the tickers, then we ask for all of them at once instead of one market at a time
+	var (
+		once    sync.Once
+		tickers map[string]float64
+	)
+	getTickers := func() map[string]float64 {
+		once.Do(func() {
+			var err error
+			if tickers, err = exchange.GetTickers(client); err != nil && !errors.Is(err, exchanges.ErrNoTickers) {
+				log.Printf("[WARN] %v\n", err)
+			}
+		})
+		return tickers
+	}
+
+	var (
+		mutex  sync.Mutex
+		movers []Mover
+	)
+	add := func(market string) {
+		mover, err := getMover(exchange, client, market, getTickers)
+		if err != nil {
+			log.Printf("[ERROR] %v\n", err)
+			return
+		}
+		if mover.BtcVolume < minVolume {
+			return
+		}
+		mutex.Lock()
+		defer mutex.Unlock()
+		movers = append(movers, *mover)
+	}
+
+	// the first request goes on its own, so that the exchange can initialize its rate limiter
+	add(markets[0])
+
+	// a shared session locks a file, and a file lock does not protect us from our own goroutines
+	workers := moversWorkers
+	if flag.SharedSession() {
+		workers = 1
+	}
+
+	queue := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for market := range queue {
+				add(market)
+			}
+		}()
+	}
+	for _, market := range markets[1:] {
+		queue <- market
+	}
+	close(queue)
+	wg.Wait()
+
+	// --losers ranks the markets from worst to best
+	losers := flag.Exists("losers")
+	sort.Slice(movers, func(i, j int) bool {
+		if order == "volume" {
+			return movers[i].BtcVolume > movers[j].BtcVolume
+		}
+		if losers {
+			return movers[i].Change < movers[j].Change
+		}
+		return movers[i].Change > movers[j].Change
+	})
+	if len(movers) > int(top) {
+		movers = movers[:top]
+	}
+
+	// --alert sends the result to your notification service
+	if flag.Exists("alert") && len(movers) > 0 {
+		var service model.Notify
+		if service, err = notify.New().Init(false, true); err != nil {
+			return c.ReturnError(err)
+		}
+		if service != nil {
+			var lines []string
+			for _, mover := range movers {
+				lines = append(lines, fmt.Sprintf("%s %+.2f%% (%.2f BTC)", mover.Market, mover.Change, mover.BtcVolume))
+			}
+			title := exchange.GetInfo().Name + " - Gainers"
+			if losers {
+				title = exchange.GetInfo().Name + " - Losers"
+			}
+			if err = service.SendMessage(strings.Join(lines, "\n"), title, model.ALWAYS); err != nil {
+				return c.ReturnError(err)
+			}
+		}
+	}
+
+	// --names returns a comma-separated list that you can feed into the buy command
+	if flag.Exists("names") {
+		var names []string
+		for _, mover := range movers {
+			names = append(names, mover.Market)
+		}
+		fmt.Println(strings.Join(names, ","))
+		return 0
+	}
+
+	out, err := json.Marshal(movers)
+	if err != nil {
+		return c.ReturnError(err)
+	}
+
+	fmt.Println(string(out))
+
+	return 0
+}
+
+func (c *MoversCommand) Help() string {
+	text := `
+Usage: ./nefertiti movers [options]
+
+The movers command ranks the markets by their 24h change (relative to the 24h
+average price) or by their 24h volume.
+
+Options:
+  --exchange       = name
+  --quote          = currency that is used as the reference, for example: BTC
+                     or USDT (optional)
+  --min-btc-volume = minimum BTC volume over the last 24 hours (optional)
+  --sort           = [change|volume] (optional, defaults to change)
+  --losers         = if included, returns the top losers instead of the top
+                     gainers (optional)
+  --top            = number of markets to return (optional, defaults to 10)
+  --alert          = if included, sends the result to your notification
+                     service (optional)
+  --names          = if included, returns a comma-separated list of market
+                     names that you can feed into the buy command, for
+                     example: --market=$(./nefertiti movers --names ...)
+`
+	return strings.TrimSpace(text)
+}
+
+func (c *MoversCommand) Synopsis() string {
+	return "Ranks the markets by their 24h change and volume."
+}
@@ -1,8 +1,11 @@
 package command
 
 import (
+	"fmt"
+	"os"
 	"strings"
 
+	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/svanas/nefertiti/errors"
 	"github.com/svanas/nefertiti/flag"
 	"github.com/svanas/nefertiti/model"
@@ -57,3 +60,91 @@ and:
 func (c *NotifyCommand) Synopsis() string {
 	return "Send a notification."
 }
+
+//-------------------------------- notify test --------------------------------
+
+type (
+	NotifyTestCommand struct {
+		*CommandMeta
+	}
+)
+
+// notifyName returns the name of a notification service, for example: Pushover
+func notifyName(service model.Notify) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", service), "*notify.")
+}
+
+func (c *NotifyTestCommand) Run(args []string) int {
+	level, err := notify.Level()
+	if err != nil {
+		return c.ReturnError(err)
+	}
+
+	var (
+		configured int
+		failed     int
+	)
+	tbl := table.NewWriter()
+	tbl.SetOutputMirror(os.Stdout)
+	tbl.AppendHeader(table.Row{"Service", "Notification", "Result"})
+	for _, service := range *notify.New() {
+		ok, err := service.PromptForKeys(false, true)
+		if err != nil {
+			tbl.AppendRow(table.Row{notifyName(service), "", err.Error()})
+			failed++
+			continue
+		}
+		if !ok {
+			tbl.AppendRow(table.Row{notifyName(service), "", "not configured"})
+			continue
+		}
+		configured++
+		for _, notification := range []notify.Notification{notify.ERROR, notify.INFO, notify.FILLED, notify.OPENED, notify.CANCELLED} {
+			name := notify.NotificationString[notification]
+			if !notify.CanSend(level, notification) {
+				tbl.AppendRow(table.Row{notifyName(service), name, fmt.Sprintf("skipped (--notify=%d)", level)})
+				continue
+			}
+			result := "delivered"
+			if err := service.SendMessage(
+				fmt.Sprintf("This is a test. You will receive %s notifications like this one.", name),
+				fmt.Sprintf("nefertiti - %s", name),
+				model.ALWAYS,
+			); err != nil {
+				result = err.Error()
+				failed++
+			}
+			tbl.AppendRow(table.Row{notifyName(service), name, result})
+		}
+	}
+	tbl.Render()
+
+	if configured == 0 {
+		return c.ReturnError(errors.New("Service not found or not initialized. Quitting."))
+	}
+	if failed > 0 {
+		return c.ReturnError(errors.Errorf("%d notification(s) failed", failed))
+	}
+
+	return 0
+}
+
+func (c *NotifyTestCommand) Help() string {
+	text := `
+Usage: ./nefertiti notify test [options]
+
+The notify test command sends a sample message through every notification
+service you have configured, one for every kind of notification you will
+receive at your --notify level, and reports whether they were delivered.
+
+Options:
+  --pushover-app-key=X and --pushover-user-key=Y
+  --telegram-app-key=X and --telegram-chat-id=Y
+  --notify = [0|1|2|3] (optional, defaults to 2)
+`
+	return strings.TrimSpace(text)
+}
+
+func (c *NotifyTestCommand) Synopsis() string {
+	return "Sends a test notification through every configured service."
+}
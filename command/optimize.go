@@ -0,0 +1,182 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/svanas/nefertiti/backtest"
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/exchanges"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/override"
+)
+
+type (
+	OptimizeCommand struct {
+		*CommandMeta
+	}
+	optimized struct {
+		Market string `json:"market"`
+		*backtest.Optimized
+	}
+)
+
+// optimizeRange reads --name=min:max:step, or returns the default
+func optimizeRange(name, def string) ([]float64, error) {
+	value := def
+	if arg := flag.Get(name); arg.Exists && arg.String() != "" {
+		value = arg.String()
+	}
+	r, err := backtest.ParseRange(name, value)
+	if err != nil {
+		return nil, err
+	}
+	return r.Values(), nil
+}
+
+func (c *OptimizeCommand) Run(args []string) int {
+	var err error
+
+	var exchange model.Exchange
+	if exchange, err = exchanges.GetExchange(); err != nil {
+		return c.ReturnError(err)
+	}
+
+	arg := flag.Get("market")
+	if !arg.Exists || arg.String() == "" {
+		return c.ReturnError(errors.New("missing argument: market"))
+	}
+	names := arg.Split()
+
+	interval := time.Hour
+	if arg := flag.Get("interval"); arg.Exists && arg.String() != "" {
+		if interval, err = model.ParseInterval(arg.String()); err != nil {
+			return c.ReturnError(err)
+		}
+	}
+
+	start, err := parseDate("from")
+	if err != nil {
+		return c.ReturnError(err)
+	}
+	if start == nil {
+		return c.ReturnError(errors.New("missing argument: from"))
+	}
+	end, err := parseDate("to")
+	if err != nil {
+		return c.ReturnError(err)
+	}
+	if end == nil {
+		now := time.Now()
+		end = &now
+	}
+
+	var dips, mults, stops []float64
+	if dips, err = optimizeRange("dip", "1:10:1"); err != nil {
+		return c.ReturnError(err)
+	}
+	if mults, err = optimizeRange("mult", "1.02:1.1:0.01"); err != nil {
+		return c.ReturnError(err)
+	}
+	if stops, err = optimizeRange("stop", "0"); err != nil {
+		return c.ReturnError(err)
+	}
+
+	folds := 3
+	if arg := flag.Get("folds"); arg.Exists {
+		var n int64
+		if n, err = arg.Int64(); err != nil || n < 1 {
+			return c.ReturnError(errors.Errorf("folds %v is invalid", arg))
+		}
+		folds = int(n)
+	}
+
+	fee := 0.1
+	if arg := flag.Get("fee"); arg.Exists {
+		if fee, err = arg.Float64(); err != nil || fee < 0 || fee >= 100 {
+			return c.ReturnError(errors.Errorf("fee %v is invalid", arg))
+		}
+	}
+
+	var client interface{}
+	if client, err = exchange.GetClient(model.PUBLIC, flag.Sandbox()); err != nil {
+		return c.ReturnError(err)
+	}
+
+	var out []optimized
+	for _, market := range names {
+		candles, err := exchanges.GetCandles(exchange, client, market, interval, *start, *end)
+		if err != nil {
+			return c.ReturnError(err)
+		}
+		log.Printf("[INFO] Optimizing %s over %d candles...", market, len(candles))
+		result, err := backtest.WalkForward(candles, dips, mults, stops, folds, fee/100)
+		if err != nil {
+			return c.ReturnError(errors.Errorf("%s: %v", market, err))
+		}
+		out = append(out, optimized{Market: market, Optimized: result})
+	}
+
+	// --overrides=FILE writes (or updates) the per-market file that the live bot reads
+	if name := override.File(); name != "" {
+		overrides, err := override.Read(name)
+		if err != nil {
+			return c.ReturnError(err)
+		}
+		for _, result := range out {
+			overrides[result.Market] = override.Params{
+				Mult: result.Params.Mult,
+				Stop: result.Params.Stop,
+				Dip:  result.Params.Dip,
+			}
+		}
+		if err = overrides.Write(name); err != nil {
+			return c.ReturnError(err)
+		}
+		log.Printf("[INFO] Wrote %d market(s) to %s", len(out), name)
+	}
+
+	data, err := json.Marshal(out)
+	if err != nil {
+		return c.ReturnError(err)
+	}
+	fmt.Println(string(data))
+
+	return 0
+}
+
+func (c *OptimizeCommand) Help() string {
+	text := `
+Usage: ./nefertiti optimize [options]
+
+The optimize command backtests every combination of dip, mult and stop on the
+historical candles of a market, walk-forward: it splits the candles into
+folds+1 windows, finds the best params on one window, then tests them on the
+next window, which it never saw. The params it recommends are the best on the
+most recent window. Please download the candles first.
+
+Options:
+  --exchange  = name, for example: Binance
+  --market    = a valid market pair, or a comma-separated list of pairs
+  --interval  = the size of a candle, for example: 15m, 1h (optional, defaults
+                to 1h)
+  --from      = the first day, for example: 2024-01-01 (or a RFC 3339 time)
+  --to        = up to (not including) this day (optional, defaults to now)
+  --dip       = min:max:step in percent (optional, defaults to 1:10:1)
+  --mult      = min:max:step (optional, defaults to 1.02:1.1:0.01)
+  --stop      = min:max:step, 0 is no stop-loss (optional, defaults to 0)
+  --folds     = the number of out-of-sample windows (optional, defaults to 3)
+  --fee       = the fee per side in percent (optional, defaults to 0.1)
+  --overrides = if included, writes the params per market into this file.
+                sell and buy read it with the same --overrides=FILE
+`
+	return strings.TrimSpace(text)
+}
+
+func (c *OptimizeCommand) Synopsis() string {
+	return "Finds the best mult, stop and dip per market."
+}
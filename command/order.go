@@ -9,6 +9,7 @@ import (
 	"github.com/svanas/nefertiti/flag"
 	"github.com/svanas/nefertiti/model"
 	"github.com/svanas/nefertiti/multiplier"
+	"github.com/svanas/nefertiti/notify"
 	"github.com/svanas/nefertiti/pricing"
 )
 
@@ -80,14 +81,30 @@ func (c *OrderCommand) Run(args []string) int {
 	if mult, err = multiplier.Get(1.0); err != nil {
 		return c.ReturnError(err)
 	} else if mult != 1.0 {
-		var prec int
-		if prec, err = exchange.GetPricePrec(client, market); err != nil {
+		var tick float64
+		if tick, err = model.GetTickSize(exchange, client, market); err != nil {
 			return c.ReturnError(err)
 		} else {
-			price = pricing.Multiply(price, mult, prec)
+			price = pricing.MultiplyTick(price, mult, tick)
 		}
 	}
 
+	// refuse a price that is wildly off-market
+	if kind == model.LIMIT {
+		if err = exchanges.CheckPrice(exchange, market, side, price, flag.Sandbox()); err != nil {
+			return c.ReturnError(err)
+		}
+	}
+
+	// hold large orders until you approve them
+	var service model.Notify
+	if service, err = notify.New().Init(false, true); err != nil {
+		return c.ReturnError(err)
+	}
+	if err = exchanges.RequestApproval(exchange, client, market, side, size, price, service); err != nil {
+		return c.ReturnError(err)
+	}
+
 	var out []byte
 	if _, out, err = exchange.Order(
 		client,
@@ -100,7 +117,10 @@ func (c *OrderCommand) Run(args []string) int {
 		return c.ReturnError(err)
 	}
 
-	fmt.Println(string(out))
+	// in dry-run mode, the exchange already printed the order
+	if !flag.DryRun() {
+		fmt.Println(string(out))
+	}
 
 	return 0
 }
@@ -119,6 +139,14 @@ Options:
   --size     = amount of cryptocurrency to buy or sell
   --price    = price per unit (optional, not needed for market orders)
   --mult     = vector to multiply price with (optional, defaults to 1.0)
+  --sanity   = max deviation (in %) from a reference price (optional, defaults to disabled)
+  --oracle   = where the reference price comes from, for example: --oracle=chainlink,coingecko
+               (optional, defaults to coingecko)
+  --approve-above = hold orders worth more than this (in quote currency) until
+                    you approve them (optional, defaults to disabled)
+  --observe  = journal the order instead of sending it (optional)
+  --dry-run  = print the order (after size and price adjustments) instead of
+               sending it (optional)
 `
 	return strings.TrimSpace(text)
 }
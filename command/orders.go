@@ -0,0 +1,184 @@
+package command
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/exchanges"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/model"
+)
+
+type (
+	OrdersCommand struct {
+		*CommandMeta
+	}
+	OpenOrder struct {
+		Exchange string  `json:"exchange"`
+		Market   string  `json:"market"`
+		Side     string  `json:"side"`
+		Size     float64 `json:"size"`
+		Price    float64 `json:"price"`
+		Stop     float64 `json:"stop,omitempty"` // trigger price of a conditional order that is not in the book (yet)
+		Age      int64   `json:"age"`            // in seconds, or zero if the exchange does not tell us
+	}
+)
+
+func (c *OrdersCommand) Run(args []string) int {
+	output := "json"
+	if arg := flag.Get("output"); arg.Exists && arg.String() != "" {
+		output = strings.ToLower(arg.String())
+	}
+	if output != "json" && output != "csv" && output != "table" {
+		return c.ReturnError(errors.Errorf("output %s is invalid", output))
+	}
+
+	arg := flag.Get("exchange")
+	if !arg.Exists {
+		return c.ReturnError(errors.New("missing argument: exchange"))
+	}
+	names := arg.Split()
+
+	var out []OpenOrder
+	for _, name := range names {
+		flag.Set("exchange", name)
+		// every exchange has its own API keys
+		if len(names) > 1 {
+			flag.Set("api-key", "")
+			flag.Set("api-secret", "")
+			flag.Set("api-passphrase", "")
+		}
+		orders, err := c.getOpened()
+		if err != nil {
+			return c.ReturnError(err)
+		}
+		out = append(out, orders...)
+	}
+
+	switch output {
+	case "csv":
+		writer := csv.NewWriter(os.Stdout)
+		if err := writer.Write([]string{"exchange", "market", "side", "size", "price", "stop", "age"}); err != nil {
+			return c.ReturnError(err)
+		}
+		for _, order := range out {
+			if err := writer.Write([]string{
+				order.Exchange,
+				order.Market,
+				order.Side,
+				strconv.FormatFloat(order.Size, 'f', -1, 64),
+				strconv.FormatFloat(order.Price, 'f', -1, 64),
+				strconv.FormatFloat(order.Stop, 'f', -1, 64),
+				strconv.FormatInt(order.Age, 10),
+			}); err != nil {
+				return c.ReturnError(err)
+			}
+		}
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return c.ReturnError(err)
+		}
+	case "table":
+		tbl := table.NewWriter()
+		tbl.SetOutputMirror(os.Stdout)
+		tbl.AppendHeader(table.Row{"Exchange", "Market", "Side", "Size", "Price", "Stop", "Age"})
+		for _, order := range out {
+			tbl.AppendRow(table.Row{order.Exchange, order.Market, order.Side, order.Size, order.Price, order.Stop, time.Duration(order.Age) * time.Second})
+		}
+		tbl.Render()
+	default:
+		data, err := json.Marshal(out)
+		if err != nil {
+			return c.ReturnError(err)
+		}
+		fmt.Println(string(data))
+	}
+
+	return 0
+}
+
+// getOpened returns the open orders on the --exchange=X and --market=Y (defaults to all markets)
+func (c *OrdersCommand) getOpened() ([]OpenOrder, error) {
+	exchange, err := exchanges.GetExchange()
+	if err != nil {
+		return nil, err
+	}
+
+	var markets []string
+	if flag.Get("market").Exists {
+		var market string
+		if market, err = model.GetMarket(exchange); err != nil {
+			return nil, err
+		}
+		markets = append(markets, market)
+	}
+	if len(markets) == 0 || markets[0] == "all" {
+		all, err := exchange.GetMarkets(true, flag.Sandbox(), flag.Get("ignore").Split())
+		if err != nil {
+			return nil, err
+		}
+		markets = nil
+		for _, market := range all {
+			markets = append(markets, market.Name)
+		}
+	}
+
+	client, err := exchange.GetClient(model.PRIVATE, flag.Sandbox())
+	if err != nil {
+		return nil, err
+	}
+
+	var out []OpenOrder
+	for _, market := range markets {
+		opened, err := model.GetOpenedEx(exchange, client, market)
+		if err != nil {
+			return nil, err
+		}
+		for _, order := range opened {
+			var age int64
+			if !order.CreatedAt.IsZero() {
+				age = int64(time.Since(order.CreatedAt).Seconds())
+			}
+			out = append(out, OpenOrder{
+				Exchange: exchange.GetInfo().Name,
+				Market:   order.Market,
+				Side:     order.Side.String(),
+				Size:     order.Size,
+				Price:    order.Price,
+				Stop:     order.Stop,
+				Age:      age,
+			})
+		}
+	}
+
+	return out, nil
+}
+
+func (c *OrdersCommand) Help() string {
+	text := `
+Usage: ./nefertiti orders [options]
+
+The orders command lists your open orders, with the same columns for every
+exchange: exchange, market, side, size, price, stop and age (in seconds).
+Conditional orders that have not been triggered yet are included, with their
+trigger price in the stop column.
+
+Options:
+  --exchange = name, or a comma-separated list of names. with more than one
+               exchange, you will be prompted for the API keys of each one.
+  --market   = a valid market pair (optional, defaults to all markets)
+  --output   = [json|csv|table] (optional, defaults to json)
+`
+	return strings.TrimSpace(text)
+}
+
+func (c *OrdersCommand) Synopsis() string {
+	return "Lists your open orders across one or more exchanges."
+}
@@ -0,0 +1,55 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/session"
+)
+
+type (
+	PauseCommand struct {
+		*CommandMeta
+	}
+)
+
+func (c *PauseCommand) Run(args []string) int {
+	paused, err := session.Paused()
+	if err != nil {
+		return c.ReturnError(err)
+	}
+
+	if paused != nil {
+		fmt.Printf("Buying has been paused since %s.\n", paused.Since.Format("2006-01-02 15:04:05"))
+		return 0
+	}
+
+	if err = session.SetPaused(true, flag.Get("reason").String(), "cli"); err != nil {
+		return c.ReturnError(err)
+	}
+
+	fmt.Println("Paused buying. Your exits will continue to be managed. Run the resume command to start buying again.")
+
+	return 0
+}
+
+func (c *PauseCommand) Help() string {
+	text := `
+Usage: ./nefertiti pause [options]
+
+The pause command stops new buys in every loop that shares your session dir,
+for example during an exchange incident or a news event. Your exits continue
+to be managed. You can also pause by creating a file named pause in your
+session dir, or by sending POST /pause to a running bot.
+
+Options:
+  --reason = why you paused (optional)
+  --user   = name of the user (optional)
+`
+	return strings.TrimSpace(text)
+}
+
+func (c *PauseCommand) Synopsis() string {
+	return "Pause new buys in every loop."
+}
@@ -0,0 +1,244 @@
+package command
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/cli"
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/session"
+	"github.com/svanas/nefertiti/vault"
+)
+
+// a profile is everything the bot knows about you: the vault (with your encrypted keys), your config files, and the
+// session state (rate limits, cost basis, journals). the profile commands move it from one machine to another.
+
+type (
+	ProfileCommand struct {
+		*CommandMeta
+	}
+	ProfileExportCommand struct {
+		*CommandMeta
+	}
+	ProfileImportCommand struct {
+		*CommandMeta
+	}
+)
+
+// the directories in the archive
+const (
+	PROFILE_CONFIG  = "config"
+	PROFILE_SESSION = "session"
+)
+
+// profileSkip returns true for the files that belong to this machine only: locks, half-written files, and the
+// environment file that holds the password of your vault.
+func profileSkip(dir, name string) bool {
+	if dir == PROFILE_CONFIG && name == "env" {
+		return true
+	}
+	return strings.HasSuffix(name, ".lock") || strings.HasSuffix(name, ".tmp")
+}
+
+func profileDirs() map[string]string {
+	return map[string]string{
+		PROFILE_CONFIG:  vault.Dir(),
+		PROFILE_SESSION: session.GetSessionDir(),
+	}
+}
+
+func (c *ProfileCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+func (c *ProfileCommand) Help() string {
+	text := `
+Usage: ./nefertiti profile [export|import] [options]
+
+The profile commands move the bot from one machine to another. The archive
+holds your vault (your keys remain encrypted), your config files, and your
+session state: rate limits, cost basis, positions and journals.
+`
+	return strings.TrimSpace(text)
+}
+
+func (c *ProfileCommand) Synopsis() string {
+	return "Export or import your profile."
+}
+
+func (c *ProfileExportCommand) Run(args []string) int {
+	session.Flush()
+
+	name := flag.Get("file").String()
+	if name == "" {
+		name = fmt.Sprintf("%s-%s.tar.gz", c.AppName, time.Now().Format("20060102-150405"))
+	}
+
+	file, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return c.ReturnError(err)
+	}
+	defer file.Close()
+
+	zw := gzip.NewWriter(file)
+	tw := tar.NewWriter(zw)
+
+	count := 0
+	for dir, path := range profileDirs() {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return c.ReturnError(err)
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || profileSkip(dir, entry.Name()) {
+				continue
+			}
+			data, err := os.ReadFile(filepath.Join(path, entry.Name()))
+			if err != nil {
+				return c.ReturnError(err)
+			}
+			info, err := entry.Info()
+			if err != nil {
+				return c.ReturnError(err)
+			}
+			if err = tw.WriteHeader(&tar.Header{
+				Name:    dir + "/" + entry.Name(),
+				Mode:    0600,
+				Size:    int64(len(data)),
+				ModTime: info.ModTime(),
+			}); err != nil {
+				return c.ReturnError(err)
+			}
+			if _, err = tw.Write(data); err != nil {
+				return c.ReturnError(err)
+			}
+			count++
+		}
+	}
+
+	if err = tw.Close(); err != nil {
+		return c.ReturnError(err)
+	}
+	if err = zw.Close(); err != nil {
+		return c.ReturnError(err)
+	}
+
+	fmt.Printf("Exported %d files to %s\n", count, name)
+
+	return 0
+}
+
+func (c *ProfileExportCommand) Help() string {
+	text := `
+Usage: ./nefertiti profile export [options]
+
+The profile export command writes your vault, your config files and your
+session state into one archive. Please keep it somewhere safe: your keys are
+encrypted, but your positions and journals are not.
+
+Options:
+  --file = name of the archive (optional, defaults to nefertiti-[time].tar.gz)
+`
+	return strings.TrimSpace(text)
+}
+
+func (c *ProfileExportCommand) Synopsis() string {
+	return "Export your profile into one archive."
+}
+
+func (c *ProfileImportCommand) Run(args []string) int {
+	name := flag.Get("file").String()
+	if name == "" {
+		return c.ReturnError(errors.New("missing argument: file"))
+	}
+
+	file, err := os.Open(name)
+	if err != nil {
+		return c.ReturnError(err)
+	}
+	defer file.Close()
+
+	zr, err := gzip.NewReader(file)
+	if err != nil {
+		return c.ReturnError(err)
+	}
+	defer zr.Close()
+
+	dirs := profileDirs()
+	overwrite := flag.Exists("overwrite")
+
+	var imported, skipped int
+	tr := tar.NewReader(zr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return c.ReturnError(err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		// we only ever write into our own directories
+		parts := strings.Split(header.Name, "/")
+		if len(parts) != 2 || parts[1] == "" || parts[1] == "." || parts[1] == ".." {
+			return c.ReturnError(errors.Errorf("archive %s is invalid. unexpected file %s", name, header.Name))
+		}
+		path, ok := dirs[parts[0]]
+		if !ok || profileSkip(parts[0], parts[1]) {
+			continue
+		}
+		target := filepath.Join(path, parts[1])
+		if _, err := os.Stat(target); err == nil && !overwrite {
+			skipped++
+			continue
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return c.ReturnError(err)
+		}
+		// the files are copied as-is, including the checksums that session.WriteFile appends
+		if err = os.WriteFile(target+".tmp", data, 0600); err != nil {
+			return c.ReturnError(err)
+		}
+		if err = os.Rename(target+".tmp", target); err != nil {
+			return c.ReturnError(err)
+		}
+		os.Chtimes(target, header.ModTime, header.ModTime)
+		imported++
+	}
+
+	fmt.Printf("Imported %d files from %s\n", imported, name)
+	if skipped > 0 {
+		fmt.Printf("Skipped %d files that already exist. Include --overwrite to replace them.\n", skipped)
+	}
+
+	return 0
+}
+
+func (c *ProfileImportCommand) Help() string {
+	text := `
+Usage: ./nefertiti profile import [options]
+
+The profile import command restores the archive that you exported on another
+machine. Please stop the bot before you import.
+
+Options:
+  --file      = name of the archive
+  --overwrite = if included, replaces the files that already exist on this
+                machine (optional, defaults to keeping them)
+`
+	return strings.TrimSpace(text)
+}
+
+func (c *ProfileImportCommand) Synopsis() string {
+	return "Import your profile from an archive."
+}
@@ -0,0 +1,77 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/svanas/nefertiti/exchanges"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/session"
+)
+
+type (
+	ResumeCommand struct {
+		*CommandMeta
+	}
+)
+
+func (c *ResumeCommand) Run(args []string) int {
+	// the pause switch is session-wide
+	paused, err := session.Paused()
+	if err != nil {
+		return c.ReturnError(err)
+	}
+	if paused != nil {
+		if err = session.SetPaused(false, "", "cli"); err != nil {
+			return c.ReturnError(err)
+		}
+		fmt.Println("Resumed buying in every loop.")
+	}
+
+	// the kill switch is per exchange
+	if !flag.Exists("exchange") {
+		if paused == nil {
+			fmt.Println("Buying was not paused.")
+		}
+		return 0
+	}
+
+	exchange, err := exchanges.GetExchange()
+	if err != nil {
+		return c.ReturnError(err)
+	}
+
+	halted, err := exchanges.Halted(exchange)
+	if err != nil {
+		return c.ReturnError(err)
+	}
+
+	if err = exchanges.Resume(exchange); err != nil {
+		return c.ReturnError(err)
+	}
+
+	if halted {
+		fmt.Printf("Resumed buying on %s.\n", exchange.GetInfo().Name)
+	} else if paused == nil {
+		fmt.Printf("Buying on %s was not paused.\n", exchange.GetInfo().Name)
+	}
+
+	return 0
+}
+
+func (c *ResumeCommand) Help() string {
+	text := `
+Usage: ./nefertiti resume [options]
+
+The resume command lifts the pause command, and resets the kill switch that
+pauses new buys after your realized losses exceeded --max-daily-loss.
+
+Options:
+  --exchange = name (optional, the kill switch is per exchange)
+`
+	return strings.TrimSpace(text)
+}
+
+func (c *ResumeCommand) Synopsis() string {
+	return "Resume buying after a pause, or after the daily loss limit has been hit."
+}
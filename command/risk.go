@@ -0,0 +1,216 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/svanas/nefertiti/backtest"
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/exchanges"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/multiplier"
+	"github.com/svanas/nefertiti/override"
+)
+
+type (
+	RiskCommand struct {
+		*CommandMeta
+	}
+)
+
+// riskBacktest replays the stored candles with the current --dip, --mult and --stop (plus the --overrides per market)
+// and returns the outcome of every trade, in quote currency, per quote currency
+func riskBacktest(exchange model.Exchange, client interface{}) (map[string][]exchanges.Outcome, error) {
+	arg := flag.Get("market")
+	if !arg.Exists || arg.String() == "" {
+		return nil, errors.New("missing argument: market")
+	}
+
+	price, err := flag.Get("price").Float64()
+	if err != nil || price <= 0 {
+		return nil, errors.Errorf("price %v is invalid", flag.Get("price"))
+	}
+
+	interval := time.Hour
+	if arg := flag.Get("interval"); arg.Exists && arg.String() != "" {
+		if interval, err = model.ParseInterval(arg.String()); err != nil {
+			return nil, err
+		}
+	}
+	start, err := parseDate("from")
+	if err != nil {
+		return nil, err
+	}
+	if start == nil {
+		return nil, errors.New("missing argument: from")
+	}
+	end, err := parseDate("to")
+	if err != nil {
+		return nil, err
+	}
+	if end == nil {
+		now := time.Now()
+		end = &now
+	}
+
+	dip, err := flag.Dip()
+	if err != nil {
+		return nil, err
+	}
+	mult, err := multiplier.Get(multiplier.FIVE_PERCENT)
+	if err != nil {
+		return nil, err
+	}
+	var stop multiplier.Mult
+	if flag.Exists("stop") {
+		if stop, err = multiplier.Stop(); err != nil {
+			return nil, err
+		}
+	}
+
+	markets, err := exchange.GetMarkets(true, flag.Sandbox(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string][]exchanges.Outcome)
+	for _, market := range arg.Split() {
+		quote, err := model.GetQuoteCurr(markets, market)
+		if err != nil {
+			return nil, err
+		}
+		candles, err := exchanges.GetCandles(exchange, client, market, interval, *start, *end)
+		if err != nil {
+			return nil, err
+		}
+		result := backtest.Run(candles, backtest.Params{
+			Dip:  override.Dip(market, dip),
+			Mult: float64(override.Mult(market, mult)),
+			Stop: float64(override.Stop(market, stop)),
+		}, 0.001)
+		for _, trade := range result.Trades {
+			out[strings.ToUpper(quote)] = append(out[strings.ToUpper(quote)], exchanges.Outcome{
+				Market: market,
+				Quote:  strings.ToUpper(quote),
+				Amount: price * trade.Return,
+				Time:   trade.Exit,
+			})
+		}
+	}
+
+	return out, nil
+}
+
+func (c *RiskCommand) Run(args []string) int {
+	var err error
+
+	var exchange model.Exchange
+	if exchange, err = exchanges.GetExchange(); err != nil {
+		return c.ReturnError(err)
+	}
+
+	days := int64(30)
+	if arg := flag.Get("days"); arg.Exists {
+		if days, err = arg.Int64(); err != nil || days <= 0 {
+			return c.ReturnError(errors.Errorf("days %v is invalid", arg))
+		}
+	}
+	paths := int64(10000)
+	if arg := flag.Get("paths"); arg.Exists {
+		if paths, err = arg.Int64(); err != nil || paths <= 0 {
+			return c.ReturnError(errors.Errorf("paths %v is invalid", arg))
+		}
+	}
+
+	limit, err := flag.MaxDailyLoss()
+	if err != nil {
+		return c.ReturnError(err)
+	}
+
+	outcomes := make(map[string][]exchanges.Outcome) // quote -> outcomes
+	source := strings.ToLower(flag.Get("source").String())
+	switch source {
+	case "", "journal":
+		all, since, err := exchanges.GetOutcomes(exchange)
+		if err != nil {
+			return c.ReturnError(err)
+		}
+		source = "journal since " + since.Format(time.RFC3339)
+		for _, outcome := range all {
+			outcomes[outcome.Quote] = append(outcomes[outcome.Quote], outcome)
+		}
+	case "backtest":
+		var client interface{}
+		if client, err = exchange.GetClient(model.PUBLIC, flag.Sandbox()); err != nil {
+			return c.ReturnError(err)
+		}
+		if outcomes, err = riskBacktest(exchange, client); err != nil {
+			return c.ReturnError(err)
+		}
+	default:
+		return c.ReturnError(errors.Errorf("source %s is invalid", source))
+	}
+
+	if len(outcomes) == 0 {
+		return c.ReturnError(errors.Errorf("no trade outcomes in the %s", source))
+	}
+
+	rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	out := make(map[string]backtest.Risk)
+	for quote, entries := range outcomes {
+		var (
+			times   []time.Time
+			amounts []float64
+		)
+		for _, entry := range entries {
+			times = append(times, entry.Time)
+			amounts = append(amounts, entry.Amount)
+		}
+		out[quote] = backtest.MonteCarlo(backtest.Daily(times, amounts), int(days), int(paths), limit, rnd)
+	}
+
+	data, err := json.Marshal(out)
+	if err != nil {
+		return c.ReturnError(err)
+	}
+	fmt.Println(string(data))
+
+	return 0
+}
+
+func (c *RiskCommand) Help() string {
+	text := `
+Usage: ./nefertiti risk [options]
+
+The risk command resamples the days of your trade history into thousands of
+possible futures, then reports (per quote currency) the distribution of the
+drawdowns and of your PnL, and the probability of hitting your max daily loss.
+
+Options:
+  --exchange = name, for example: Binance
+  --source   = [journal|backtest] (optional, defaults to journal). the journal
+               holds your realized profits and losses, from the moment the
+               bot started keeping both. backtest replays the stored candles
+               with your current --dip, --mult, --stop and --overrides
+  --days     = the length of every future, in days (optional, defaults to 30)
+  --paths    = the number of futures (optional, defaults to 10000)
+  --max-daily-loss = in quote currency (optional, defaults to no limit)
+
+Backtest options:
+  --market   = a valid market pair, or a comma-separated list of pairs
+  --price    = what you spend per trade, in quote currency
+  --interval = the size of a candle (optional, defaults to 1h)
+  --from     = the first day, for example: 2024-01-01
+  --to       = up to (not including) this day (optional, defaults to now)
+`
+	return strings.TrimSpace(text)
+}
+
+func (c *RiskCommand) Synopsis() string {
+	return "Estimates your drawdowns and the odds of hitting your max daily loss."
+}
@@ -0,0 +1,176 @@
+package command
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/exchanges"
+	"github.com/svanas/nefertiti/flag"
+)
+
+// the exchanges new command is for the contributors who add a new exchange. it stubs out the client package (with
+// the throttle hooks, the signature and its test) plus the model.Exchange implementation (with the session mutex and
+// the observe hooks), so that every exchange starts from the same structure.
+
+//go:embed scaffold/*.tmpl
+var scaffold embed.FS
+
+const SCAFFOLD_COMMAND = "exchanges new"
+
+type (
+	ScaffoldCommand struct {
+		*CommandMeta
+	}
+	scaffoldData struct {
+		Package string // for example: foo
+		Type    string // for example: Foo
+		Code    string // for example: FOO
+		Name    string // for example: Foo Exchange
+		URL     string
+		REST    string
+		Country string
+	}
+)
+
+var scaffoldPackage = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+
+// the template -> the file that we generate from it
+func (data *scaffoldData) files() map[string]string {
+	return map[string]string{
+		"client.go.tmpl":    filepath.Join(data.Package, "client.go"),
+		"auth.go.tmpl":      filepath.Join(data.Package, "auth.go"),
+		"auth_test.go.tmpl": filepath.Join(data.Package, "auth_test.go"),
+		"exchange.go.tmpl":  filepath.Join("exchanges", data.Package+".go"),
+	}
+}
+
+func (c *ScaffoldCommand) Run(args []string) int {
+	// we write into the repository, so we'd better be in its root
+	if _, err := os.Stat(filepath.Join("exchanges", "main.go")); err != nil {
+		return c.ReturnError(errors.New("please run this command from the root of the repository"))
+	}
+
+	data := scaffoldData{
+		Code:    strings.ToUpper(flag.Get("code").String()),
+		Name:    flag.Get("name").String(),
+		URL:     flag.Get("url").String(),
+		REST:    flag.Get("rest").String(),
+		Country: flag.Get("country").String(),
+	}
+	if data.Code == "" {
+		return c.ReturnError(errors.New("missing argument: code"))
+	}
+	if data.Name == "" {
+		return c.ReturnError(errors.New("missing argument: name"))
+	}
+	// the conformance tests require a URL
+	if data.URL == "" {
+		return c.ReturnError(errors.New("missing argument: url"))
+	}
+	for _, exchange := range *exchanges.New() {
+		if exchange.GetInfo().Equals(data.Code) || exchange.GetInfo().Equals(data.Name) {
+			return c.ReturnError(errors.Errorf("exchange %s exists already", exchange.GetInfo().Name))
+		}
+	}
+
+	data.Package = flag.Get("package").String()
+	if data.Package == "" {
+		data.Package = strings.ToLower(strings.Join(strings.Fields(data.Name), ""))
+	}
+	if !scaffoldPackage.MatchString(data.Package) {
+		return c.ReturnError(errors.Errorf("package %s is invalid", data.Package))
+	}
+	data.Type = strings.ToUpper(data.Package[:1]) + data.Package[1:]
+
+	files := data.files()
+	for _, name := range files {
+		if _, err := os.Stat(name); err == nil {
+			return c.ReturnError(errors.Errorf("file %s exists already", name))
+		}
+	}
+
+	if err := os.MkdirAll(data.Package, 0755); err != nil {
+		return c.ReturnError(err)
+	}
+	for tmpl, name := range files {
+		if err := scaffoldFile(tmpl, name, &data); err != nil {
+			return c.ReturnError(err)
+		}
+		fmt.Println(name)
+	}
+
+	// add the new exchange to the registry of supported exchanges
+	registry := filepath.Join("exchanges", "registry.go")
+	src, err := os.ReadFile(registry)
+	if err != nil {
+		return c.ReturnError(err)
+	}
+	start := bytes.Index(src, []byte("var registry = []func() model.Exchange{\n"))
+	if start < 0 {
+		return c.ReturnError(errors.Errorf("cannot find the registry in %s", registry))
+	}
+	end := bytes.Index(src[start:], []byte("\n}\n"))
+	if end < 0 {
+		return c.ReturnError(errors.Errorf("cannot find the end of the registry in %s", registry))
+	}
+	end += start + 1
+	src = append(src[:end:end], append([]byte(fmt.Sprintf("\tnew%s,\n", data.Type)), src[end:]...)...)
+	if err = os.WriteFile(registry, src, 0644); err != nil {
+		return c.ReturnError(err)
+	}
+	fmt.Println(registry)
+
+	return 0
+}
+
+// scaffoldFile executes a template, formats the outcome, and writes it to a file
+func scaffoldFile(tmpl, name string, data *scaffoldData) error {
+	t, err := template.ParseFS(scaffold, "scaffold/"+tmpl)
+	if err != nil {
+		return errors.Wrap(err, 1)
+	}
+	var buf bytes.Buffer
+	if err = t.Execute(&buf, data); err != nil {
+		return errors.Wrap(err, 1)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return errors.Wrap(err, 1)
+	}
+	return os.WriteFile(name, src, 0644)
+}
+
+func (c *ScaffoldCommand) Help() string {
+	text := `
+Usage: ./nefertiti exchanges new [options]
+
+The exchanges new command is for the contributors who add a new exchange. Run
+it from the root of the repository. It stubs out the client package (with the
+throttle hooks, the signature and its test) plus the model.Exchange
+implementation (with the session mutex and the observe hooks), and adds the
+new exchange to the registry of supported exchanges. Then look for the TODOs,
+and implement GetStops if the new exchange can do stop orders.
+
+Options:
+  --code    = code of the exchange, for example: FOO
+  --name    = name of the exchange, for example: Foo
+  --package = name of the client package (optional, defaults to the name in
+              lower case)
+  --url     = website of the exchange
+  --rest    = base URL of the REST API (optional)
+  --country = where the exchange is registered (optional)
+`
+	return strings.TrimSpace(text)
+}
+
+func (c *ScaffoldCommand) Synopsis() string {
+	return "Stub out a new exchange."
+}
@@ -1,12 +1,16 @@
 package command
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
+	"runtime/debug"
 	"strings"
+	"time"
 
 	"github.com/svanas/nefertiti/exchanges"
 	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/i18n"
 	"github.com/svanas/nefertiti/model"
 	"github.com/svanas/nefertiti/multiplier"
 	"github.com/svanas/nefertiti/notify"
@@ -31,7 +35,8 @@ func (c *SellCommand) Run(args []string) int {
 		return c.ReturnError(err)
 	}
 
-	if _, err = multiplier.Get(multiplier.FIVE_PERCENT); err != nil {
+	var mult multiplier.Mult
+	if mult, err = multiplier.Get(multiplier.FIVE_PERCENT); err != nil {
 		return c.ReturnError(err)
 	}
 
@@ -58,17 +63,77 @@ func (c *SellCommand) Run(args []string) int {
 		}
 	}
 
+	// --skim buys --skim-to at market. on a futures exchange, that would open a leveraged position.
+	var skim float64
+	if skim, err = flag.Skim(); err != nil {
+		return c.ReturnError(err)
+	}
+	if _, ok := exchange.(model.Futures); ok && skim > 0 {
+		return c.ReturnError(fmt.Errorf("%s does not support --skim", exchange.GetInfo().Name))
+	}
+
+	// --max-equity-share needs to know what is in your wallet
+	if err = exchanges.ValidateEquityShare(exchange); err != nil {
+		return c.ReturnError(err)
+	}
+
+	// --bootstrap opens sell orders for the assets you were holding before you installed the bot
+	if flag.Exists("bootstrap") {
+		if err = bootstrap(exchange, all, mult, hold); err != nil {
+			return c.ReturnError(err)
+		}
+	}
+
+	// sell orders are a response to buy orders getting filled, so there is nothing to send just yet.
+	// print the settings that we would apply to those fills, then exit.
+	if flag.DryRun() {
+		var stop multiplier.Mult
+		if stop, err = multiplier.Stop(); err != nil {
+			return c.ReturnError(err)
+		}
+		var out []byte
+		if out, err = json.Marshal(struct {
+			Exchange string   `json:"exchange"`
+			Strategy int      `json:"strategy"`
+			Mult     float64  `json:"mult"`
+			Stop     float64  `json:"stop"`
+			Hold     []string `json:"hold,omitempty"`
+			Earn     []string `json:"earn,omitempty"`
+		}{
+			Exchange: exchange.GetInfo().Name,
+			Strategy: int(strategy),
+			Mult:     float64(mult),
+			Stop:     float64(stop),
+			Hold:     hold,
+			Earn:     earn,
+		}); err != nil {
+			return c.ReturnError(err)
+		}
+		fmt.Println(string(out))
+		return 0
+	}
+
 	var level int64 = notify.LEVEL_DEFAULT
 	if level, err = notify.Level(); err != nil {
 		return c.ReturnError(err)
 	}
 
-	success := func(service model.Notify) error {
+	var (
+		started bool
+		service model.Notify
+	)
+	success := func(svc model.Notify) error {
+		service = svc
+		if started {
+			// we have been restarted after a panic. don't tell the world we have started (again).
+			return nil
+		}
+		started = true
 		err := c.ReturnSuccess()
 		if err != nil {
 			return err
 		}
-		msg := fmt.Sprintf("Listening to %s...", exchange.GetInfo().Name)
+		msg := i18n.Sprintf("Listening to %s...", exchange.GetInfo().Name)
 		log.Println("[INFO] " + msg)
 		if service != nil {
 			if notify.CanSend(level, notify.INFO) {
@@ -76,16 +141,69 @@ func (c *SellCommand) Run(args []string) int {
 				if err != nil {
 					return err
 				}
+				go watchAuditSummary(service)
 			}
 		}
 		return nil
 	}
 
-	if err = exchange.Sell(strategy, hold, earn, flag.Sandbox(), flag.Exists("tweet"), flag.Debug(), success); err != nil {
-		return c.ReturnError(err)
+	var panics []time.Time
+	for {
+		stack, err := sell(exchange, strategy, hold, earn, success)
+		if stack == nil {
+			if err != nil {
+				return c.ReturnError(err)
+			}
+			return 0
+		}
+
+		// a panic should not leave our positions unmanaged. log the stack trace, notify, and start over.
+		log.Printf("[ERROR] %v\n%s", err, stack)
+
+		// remember the panics that happened during the last hour
+		panics = append(panics, time.Now())
+		for len(panics) > 0 && time.Since(panics[0]) > panicsWindow {
+			panics = panics[1:]
+		}
+
+		if service != nil {
+			title := fmt.Sprintf("%s - ERROR", exchange.GetInfo().Name)
+			if len(panics) >= panicsMax {
+				// we keep on crashing. this is something a human needs to look at.
+				if err := notify.SendEvent(service, notify.EVENT_ERROR, i18n.Sprintf("Crashed %d times during the last hour. Last crash: %v", len(panics), err), title, model.ALWAYS); err != nil {
+					log.Printf("[ERROR] %v", err)
+				}
+			} else if len(panics) == 1 && notify.CanSend(level, notify.ERROR) {
+				if err := notify.SendEvent(service, notify.EVENT_ERROR, i18n.Sprintf("Recovered from a crash: %v", err), title, model.ONCE_PER_MINUTE); err != nil {
+					log.Printf("[ERROR] %v", err)
+				}
+			}
+		}
+
+		time.Sleep(panicsBackoff)
 	}
+}
+
+const (
+	panicsMax     = 3                // if we panic this many times...
+	panicsWindow  = time.Hour        // ...during this window, then we send a full alert
+	panicsBackoff = 10 * time.Second // wait this long before we restart after a panic
+)
 
-	return 0
+// sell runs the sell loop of an exchange. if the loop panics, then we recover and return the stack trace.
+func sell(
+	exchange model.Exchange,
+	strategy model.Strategy,
+	hold, earn model.Markets,
+	success model.OnSuccess,
+) (stack []byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			stack = debug.Stack()
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return nil, exchange.Sell(strategy, hold, earn, flag.Sandbox(), flag.Exists("tweet"), flag.Debug(), success)
 }
 
 func (c *SellCommand) Help() string {
@@ -97,17 +215,115 @@ The sell command listens for buy orders getting filled, and then opens new sell
 Options:
   --exchange = [name]
   --sandbox  = [Y|N] (optional)
+  --rest     = URI of the exchange's REST API, for example a regional mirror or
+               a proxy (optional, applies to the sandbox if --sandbox=Y, on the
+               exchanges that have one)
+  --websocket = URI of the exchange's websocket. GDAX only. (optional)
   --stoploss = [Y|N] (optional)
+  --stop-limit = exit the stop-loss with a limit order that is X percent below
+               the trigger price, instead of at market (optional, defaults to
+               market. on Bittrex, this limit order is immediate-or-cancel)
   --notify   = [0|1|2|3] (see below)
   --mult     = multiplier, for example: 1.05 (aka 5 percent, optional)
   --hold     = name of the market not to sell, for example: BTC-EUR (optional)
   --earn     = name of the market where you want to sell only enough of the
                base asset at "mult" to break even; hold the rest (optional)
+  --dca      = if included, buys back more than you sold after a stop-loss got
+               filled (optional)
+  --dca-factor = what we buy back after a stop-loss, as a multiple of what we
+               sold (optional, defaults to 2.2)
+  --dca-rounds = max number of consecutive DCA buys per market, until a take
+               profit gets filled (optional, defaults to 0, aka no limit)
+  --dca-max  = max that we spend on DCA buys per market (in quote currency)
+               until a take profit gets filled (optional, defaults to 0, aka
+               no limit)
+  --max-equity-share = refuse any buy order (including the DCA buys) that would
+               take a market beyond this percentage of your equity. GDAX and
+               KuCoin only. (optional, defaults to no limit)
+  --max-daily-loss = max realized loss (in quote currency) from stop-loss fills
+               over the last 24 hours. once exceeded, the buy command pauses
+               until you run the resume command (optional)
+  --skim     = percentage of every realized profit to convert into --skim-to,
+               right after a sell order got filled. not on futures exchanges
+               (optional, defaults to 0)
+  --skim-to  = asset to take your profits into, for example: BTC or USDT
+               (optional, defaults to BTC)
+  --bootstrap = if included, opens a limit sell at --mult above your cost basis
+               for every asset in your wallet before the bot starts listening.
+               requires --quote (optional)
+  --quote    = currency that is used as the reference for --bootstrap, for
+               example: BTC or USDT
+  --cost     = your cost basis per asset for --bootstrap, for example:
+               --cost=ETH:2000,SOL:30 (optional, defaults to the cost basis
+               from the import command, the bot will ask otherwise)
+  --observe  = if included, never sends an order to the exchange. instead, the
+               bot journals and notifies what it would have done. on Bittrex,
+               the journal refuses the orders below the min trade size and
+               expires the orders after 28 days, the way Bittrex would have
+               (optional)
+  --watch-account = Bittrex and Kucoin only. if included, polls your account
+               for withdrawals every 15 minutes, and alerts you about every
+               new one. the bot never withdraws, so this is an early warning
+               that somebody else is using your keys (optional)
+  --dry-run  = if included, prints the settings that would be applied to your
+               filled buy orders, then exits (optional)
+  --reopen-after = Bittrex only. cancel and re-open the orders that are older
+               than X days, so Bittrex doesn't remove them after 28 days. 0
+               disables this (optional, defaults to 21)
+  --reopen-reprice = Bittrex only. if included, re-opens a stale sell order at
+               your current --mult target instead of its old price. if we
+               cannot find the price you paid, then we stick with the old
+               price (optional)
+  --pushover-emergency = comma-separated list of events that Pushover sends
+               with emergency priority, so they keep on alerting you until you
+               acknowledge them: stop-loss, kill-switch, auth, account
+               (optional, defaults to kill-switch,auth,account)
+  --pushover-quiet-hours = daily window during which notifications that
+               aren't errors (or stop-losses, or kill switches) are held back,
+               for example: 22:00-07:00. you get a digest when the window is
+               over, even if the bot restarted in the meantime. --telegram-quiet-hours is the
+               same for Telegram (optional)
+  --pushover-quiet-mode = [digest|lower] lower sends the notifications right
+               away, but without sound (optional, defaults to digest)
+  --chart    = if included, attaches a candlestick chart with your entry, target
+               and stop to the notifications of filled orders. Binance and
+               Kucoin, with Telegram only (optional)
+  --lang     = [en|nl|de|fr|es] the language of your notifications (optional,
+               defaults to en)
+  --label    = a name for this loop. your log lines, notifications and journal
+               rows carry it (plus a run ID), so you can tell apart the loops
+               that run simultaneously (optional)
+  --allow-withdraw = Binance and KuCoin warn you at startup if your API key can
+               withdraw. include this if you really want the bot to have such
+               a key, to silence the warning (optional)
+  --user     = name of the user you are trading for. every user has their own
+               vault, session state, journals and notifications (optional)
+  --overrides = a JSON file with the mult and stop per market, for example the
+               file that the optimize command writes. edits apply while the
+               bot is running (optional)
+  --control-token = role:token,role:token where role is viewer or operator.
+               if included, the control API requires a token. a viewer can
+               ping and read the metrics, an operator can also change flags,
+               approve orders and stop the bot (optional)
+
+Exits:
+  on Bittrex, the bot remembers which sell order belongs to which filled buy
+  order. if somebody (or Bittrex) cancels one of those sell orders, the bot
+  re-opens it at the same price.
+
+Webhook:
+  POST 127.0.0.1:[port]/fill?market=X&side=[buy|sell]&size=X&price=X&id=X
+  tells the bot about a fill that happened outside of the bot, for example an
+  order that you placed with another tool. a buy adds to your position and
+  gets a limit sell at --mult, a sell takes from your position and books the
+  profit (or loss) against your cost basis. the id is optional; if included,
+  the bot handles the same fill only once. requires the operator role.
 
 Notify:
   0 = nothing, ever
   1 = errors only
-  2 = errors + filled orders (default)
+  2 = errors + filled orders + a daily summary of the settings that changed
+      (default)
   3 = everything (including opened and cancelled orders)
 `
 	return strings.TrimSpace(text)
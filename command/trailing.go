@@ -0,0 +1,96 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/exchanges"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/notify"
+)
+
+type (
+	TrailingCommand struct {
+		*CommandMeta
+	}
+)
+
+func (c *TrailingCommand) Run(args []string) int {
+	var (
+		err error
+		flg *flag.Flag
+	)
+
+	var exchange model.Exchange
+	if exchange, err = exchanges.GetExchange(); err != nil {
+		return c.ReturnError(err)
+	}
+
+	var market string
+	if market, err = model.GetMarket(exchange); err != nil {
+		return c.ReturnError(err)
+	}
+
+	var size float64
+	flg = flag.Get("size")
+	if !flg.Exists {
+		return c.ReturnError(errors.New("missing argument: size"))
+	}
+	if size, err = flg.Float64(); err != nil {
+		return c.ReturnError(errors.Errorf("size %v is invalid", flg))
+	}
+
+	var percent float64
+	flg = flag.Get("percent")
+	if !flg.Exists {
+		return c.ReturnError(errors.New("missing argument: percent"))
+	}
+	if percent, err = flg.Float64(); err != nil || percent <= 0 || percent >= 100 {
+		return c.ReturnError(errors.Errorf("percent %v is invalid", flg))
+	}
+
+	var client interface{}
+	if client, err = exchange.GetClient(model.PRIVATE, flag.Sandbox()); err != nil {
+		return c.ReturnError(err)
+	}
+
+	var service model.Notify
+	if service, err = notify.New().Init(flag.Interactive(), true); err != nil {
+		return c.ReturnError(err)
+	}
+
+	var out []byte
+	if out, err = exchanges.Trail(exchange, client, market, size, percent, service); err != nil {
+		return c.ReturnError(err)
+	}
+
+	fmt.Println(string(out))
+
+	return 0
+}
+
+func (c *TrailingCommand) Help() string {
+	text := `
+Usage: ./nefertiti trailing [options]
+
+The trailing command places a trailing stop-loss with the specified exchange.
+On the exchanges that support trailing stops (Binance and Bittrex), the exchange
+trails the stop for you. Binance trails a stop between 0.1 and 20 percent.
+On the other exchanges, this command watches the ticker and sells at market
+once the price drops X percent below its high. It keeps on running until it
+has sold.
+
+Options:
+  --exchange = name
+  --market   = a valid market pair
+  --size     = amount of cryptocurrency to sell
+  --percent  = how far (in percent) the price may drop below its high
+`
+	return strings.TrimSpace(text)
+}
+
+func (c *TrailingCommand) Synopsis() string {
+	return "Place a trailing stop-loss with the specified exchange."
+}
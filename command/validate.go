@@ -0,0 +1,220 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/exchanges"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/multiplier"
+	"github.com/svanas/nefertiti/notify"
+	"github.com/svanas/nefertiti/vault"
+)
+
+const VALIDATE_COMMAND = "validate"
+
+type (
+	ValidateCommand struct {
+		*CommandMeta
+	}
+	check struct {
+		Name   string
+		Err    error
+		Detail string
+	}
+)
+
+func (c *ValidateCommand) Run(args []string) int {
+	var checks []check
+
+	// step #1: the settings
+	checks = append(checks, func() check {
+		out := check{Name: "settings"}
+		if _, err := model.GetStrategy(); err != nil {
+			out.Err = err
+		} else if _, err := notify.Level(); err != nil {
+			out.Err = err
+		} else if _, err := multiplier.Get(multiplier.FIVE_PERCENT); err != nil {
+			out.Err = err
+		} else if _, err := multiplier.Stop(); err != nil {
+			out.Err = err
+		}
+		return out
+	}())
+
+	// step #2: the API keys
+	exchangeChecks, err := validateExchanges()
+	if err != nil {
+		return c.ReturnError(err)
+	}
+	checks = append(checks, exchangeChecks...)
+
+	// step #3: the notification services
+	for _, service := range *notify.New() {
+		checks = append(checks, validateNotify(service))
+	}
+
+	failed := 0
+	tbl := table.NewWriter()
+	tbl.SetOutputMirror(os.Stdout)
+	tbl.AppendHeader(table.Row{"Check", "Result", "Detail"})
+	for _, check := range checks {
+		result := "OK"
+		detail := check.Detail
+		if check.Err != nil {
+			result = "FAILED"
+			detail = check.Err.Error()
+			failed++
+		}
+		tbl.AppendRow(table.Row{check.Name, result, detail})
+	}
+	tbl.Render()
+
+	if failed > 0 {
+		return c.ReturnError(errors.Errorf("%d of %d checks failed", failed, len(checks)))
+	}
+
+	return 0
+}
+
+// validateExchanges verifies the API keys of the --exchange=X, or (if you didn't include --exchange=X) of every
+// exchange in your --vault
+func validateExchanges() ([]check, error) {
+	if flag.Exists("exchange") {
+		exchange, err := exchanges.GetExchange()
+		if err != nil {
+			return []check{{Name: flag.Get("exchange").String(), Err: err}}, nil
+		}
+		return []check{validateExchange(exchange)}, nil
+	}
+
+	if !flag.Exists("vault") {
+		return nil, errors.New("missing argument: exchange")
+	}
+
+	if !vault.Exists() {
+		return nil, errors.New("vault does not exist. please run the init command")
+	}
+	password, err := vault.Password(false)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := vault.Open(password)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []check
+	for _, exchange := range *exchanges.New() {
+		secrets := keys[vault.Section(exchange.GetInfo().Code)]
+		if secrets["api-key"] == "" {
+			continue
+		}
+		// the secrets of this exchange replace the secrets of the previous one
+		for _, name := range []string{"api-key", "api-secret", "api-passphrase"} {
+			flag.Set(name, secrets[name])
+		}
+		out = append(out, validateExchange(exchange))
+	}
+
+	if len(out) == 0 {
+		return nil, errors.New("your vault does not hold the API keys of any exchange")
+	}
+
+	return out, nil
+}
+
+// validateExchange verifies the API keys of an exchange with a cheap authenticated call
+func validateExchange(exchange model.Exchange) check {
+	out := check{Name: exchange.GetInfo().Name}
+
+	markets, err := exchange.GetMarkets(true, flag.Sandbox(), nil)
+	if err != nil {
+		out.Err = err
+		return out
+	}
+	if len(markets) == 0 {
+		out.Err = errors.New("no markets found")
+		return out
+	}
+
+	client, err := exchange.GetClient(model.PRIVATE, flag.Sandbox())
+	if err != nil {
+		out.Err = err
+		return out
+	}
+
+	if reader, ok := exchange.(model.BalanceReader); ok {
+		if _, err = reader.GetBalances(client); err != nil {
+			out.Err = err
+		} else {
+			out.Detail = "balances"
+		}
+		return out
+	}
+
+	if _, err = exchange.GetOpened(client, markets[0].Name); err != nil {
+		out.Err = err
+	} else {
+		out.Detail = fmt.Sprintf("open orders on %s", markets[0].Name)
+	}
+
+	return out
+}
+
+// validateNotify verifies a notification service with a test message, if this service has been configured
+func validateNotify(service model.Notify) check {
+	out := check{Name: notifyName(service)}
+	ok, err := service.PromptForKeys(false, true)
+	if err != nil {
+		out.Err = err
+		return out
+	}
+	if !ok {
+		out.Detail = "not configured"
+		return out
+	}
+	if err = service.SendMessage("Your settings are valid.", "nefertiti - validate", model.ALWAYS); err != nil {
+		out.Err = err
+	} else {
+		out.Detail = "test message sent"
+	}
+	return out
+}
+
+func (c *ValidateCommand) Help() string {
+	text := `
+Usage: ./nefertiti validate [options]
+
+The validate command checks your settings without ever prompting for anything,
+so that a container fails fast instead of hanging on a hidden prompt. It
+verifies your API keys with a cheap authenticated call, sends a test message
+through every notification service you have configured, and exits with a
+non-zero exit code if one (or more) of these checks failed.
+
+Options:
+  --exchange = name (optional if you include --vault, defaults to every
+               exchange in your vault)
+  --api-key  = API key
+  --api-secret = API secret
+  --api-passphrase = API passphrase (if your exchange requires one)
+  --vault    = read your secrets from your vault (optional). because we never
+               prompt, the password of your vault must be in the
+               NEFERTITI_VAULT_PASSWORD environment variable.
+  --pushover-app-key=X and --pushover-user-key=Y (optional)
+  --telegram-app-key=X and --telegram-chat-id=Y (optional)
+
+You can add any other option you would pass to the sell command (for example:
+--strategy, --notify, --mult, --stop) and the validate command checks those
+too.
+`
+	return strings.TrimSpace(text)
+}
+
+func (c *ValidateCommand) Synopsis() string {
+	return "Validates your settings, API keys and notification services."
+}
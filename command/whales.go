@@ -0,0 +1,94 @@
+package command
+
+import (
+	"strings"
+	"time"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/exchanges"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/notify"
+)
+
+type (
+	WhalesCommand struct {
+		*CommandMeta
+	}
+)
+
+func (c *WhalesCommand) Run(args []string) int {
+	exchange, err := exchanges.GetExchange()
+	if err != nil {
+		return c.ReturnError(err)
+	}
+
+	arg := flag.Get("market")
+	if !arg.Exists || arg.String() == "" {
+		return c.ReturnError(errors.New("missing argument: market"))
+	}
+	markets := arg.Split()
+
+	all, err := exchange.GetMarkets(true, flag.Sandbox(), flag.Get("ignore").Split())
+	if err != nil {
+		return c.ReturnError(err)
+	}
+	for _, market := range markets {
+		if !model.HasMarket(all, market) {
+			return c.ReturnError(errors.Errorf("market %s does not exist", market))
+		}
+	}
+
+	arg = flag.Get("notional")
+	if !arg.Exists {
+		return c.ReturnError(errors.New("missing argument: notional"))
+	}
+	notional, err := arg.Float64()
+	if err != nil || notional <= 0 {
+		return c.ReturnError(errors.Errorf("notional %v is invalid", arg))
+	}
+
+	var cooldown int64 = 60
+	if arg = flag.Get("cooldown"); arg.Exists {
+		if cooldown, err = arg.Int64(); err != nil || cooldown < 0 {
+			return c.ReturnError(errors.Errorf("cooldown %v is invalid", arg))
+		}
+	}
+
+	var service model.Notify
+	if service, err = notify.New().Init(flag.Interactive(), true); err != nil {
+		return c.ReturnError(err)
+	}
+
+	if err = c.ReturnSuccess(); err != nil {
+		return c.ReturnError(err)
+	}
+
+	if err = exchanges.WatchWhales(exchange, markets, notional, time.Duration(cooldown)*time.Minute, service, flag.Sandbox()); err != nil {
+		return c.ReturnError(err)
+	}
+
+	return 0
+}
+
+func (c *WhalesCommand) Help() string {
+	text := `
+Usage: ./nefertiti whales [options]
+
+The whales command watches the public trade feed for single trades that are
+worth more than --notional. You will be notified about every whale, and the buy
+command will stop buying a market after a whale sold into it.
+
+Options:
+  --exchange = name (currently: Coinbase Pro)
+  --market   = a valid market pair, or a comma-separated list of pairs
+  --notional = minimum trade value (in quote currency) to qualify as a whale
+  --cooldown = minutes to stop buying after a whale sold (optional, defaults
+               to 60)
+`
+	return strings.TrimSpace(text)
+}
+
+func (c *WhalesCommand) Synopsis() string {
+	return "Watches the public trade feed for whales."
+}
@@ -0,0 +1,129 @@
+package control
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/flag"
+)
+
+// the control API (the bot's own HTTP server, and the listen command's hub) is open to 127.0.0.1 only. once you
+// include --control-token, every request needs a token too, and the role of the token decides what it may do: a
+// viewer can ping and read the metrics, an operator can also change flags, approve orders and stop the bot.
+
+type Role int
+
+const (
+	ROLE_NONE Role = iota
+	ROLE_VIEWER
+	ROLE_OPERATOR
+)
+
+var roles = map[string]Role{
+	"viewer":   ROLE_VIEWER,
+	"operator": ROLE_OPERATOR,
+}
+
+func (role Role) String() string {
+	for name, value := range roles {
+		if value == role {
+			return name
+		}
+	}
+	return "none"
+}
+
+// --control-token=ROLE:TOKEN,ROLE:TOKEN where ROLE is viewer or operator
+func Tokens() (map[string]Role, error) {
+	out := make(map[string]Role)
+	arg := flag.Get("control-token")
+	if !arg.Exists || arg.String() == "" {
+		return out, nil
+	}
+	for _, part := range arg.Split() {
+		i := strings.Index(part, ":")
+		if i == -1 || part[i+1:] == "" {
+			return nil, errors.Errorf("control-token %v is invalid", arg)
+		}
+		role, ok := roles[strings.ToLower(part[:i])]
+		if !ok {
+			return nil, errors.Errorf("control-token %v is invalid. role %s does not exist", arg, part[:i])
+		}
+		out[part[i+1:]] = role
+	}
+	return out, nil
+}
+
+// token returns the token of the request, from the Authorization: Bearer header or the X-Control-Token header
+func token(req *http.Request) string {
+	if auth := req.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		return strings.TrimPrefix(auth, "Bearer ")
+	}
+	return req.Header.Get("X-Control-Token")
+}
+
+// roleOf returns the role of the token. we compare every token in constant time, so that timing doesn't leak them.
+func roleOf(tokens map[string]Role, value string) Role {
+	out := ROLE_NONE
+	for token, role := range tokens {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(value)) == 1 {
+			out = role
+		}
+	}
+	return out
+}
+
+// Require wraps a handler, refusing the requests without a token of (at least) this role. without --control-token,
+// every request is let through, the way it was before we had tokens.
+func Require(role Role, handler http.HandlerFunc) http.HandlerFunc {
+	return func(resp http.ResponseWriter, req *http.Request) {
+		tokens, err := Tokens()
+		if err != nil {
+			http.Error(resp, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if len(tokens) > 0 {
+			value := token(req)
+			if value == "" {
+				http.Error(resp, "missing token", http.StatusUnauthorized)
+				return
+			}
+			actual := roleOf(tokens, value)
+			if actual == ROLE_NONE {
+				http.Error(resp, "invalid token", http.StatusUnauthorized)
+				return
+			}
+			if actual < role {
+				http.Error(resp, "this token is a "+actual.String()+", and this request requires an "+role.String(), http.StatusForbidden)
+				return
+			}
+		}
+		handler(resp, req)
+	}
+}
+
+// Sign adds our own operator token (if any) to a request that we send to the hub or to one of its bots
+func Sign(req *http.Request) {
+	tokens, err := Tokens()
+	if err != nil {
+		return
+	}
+	for token, role := range tokens {
+		if role == ROLE_OPERATOR {
+			req.Header.Set("Authorization", "Bearer "+token)
+			return
+		}
+	}
+}
+
+// Get is http.Get, plus our operator token
+func Get(url string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	Sign(req)
+	return http.DefaultClient.Do(req)
+}
@@ -0,0 +1,59 @@
+package deribit
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+type Position struct {
+	InstrumentName string  `json:"instrument_name"`
+	Direction      string  `json:"direction"`
+	Size           float64 `json:"size"` // in USD for the inverse perpetuals, negative when short
+	AveragePrice   float64 `json:"average_price"`
+	Leverage       float64 `json:"leverage"`
+}
+
+func (client *Client) Position(instrument string) (*Position, error) {
+	params := url.Values{}
+	params.Add("instrument_name", instrument)
+
+	var (
+		err  error
+		data json.RawMessage
+		out  Position
+	)
+	if data, err = client.get("/private/get_position", params, true, RPS_NON_MATCHING); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+type AccountSummary struct {
+	Currency string  `json:"currency"`
+	Equity   float64 `json:"equity"` // in the underlying currency
+	Balance  float64 `json:"balance"`
+}
+
+func (client *Client) AccountSummary(currency string) (*AccountSummary, error) {
+	params := url.Values{}
+	params.Add("currency", strings.ToUpper(currency))
+
+	var (
+		err  error
+		data json.RawMessage
+		out  AccountSummary
+	)
+	if data, err = client.get("/private/get_account_summary", params, true, RPS_NON_MATCHING); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
@@ -0,0 +1,49 @@
+package deribit
+
+import (
+	"encoding/json"
+	"net/url"
+)
+
+type BookEntry struct {
+	Price  float64
+	Amount float64
+}
+
+func (entry *BookEntry) UnmarshalJSON(data []byte) error {
+	var aux []float64
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if len(aux) > 1 {
+		entry.Price = aux[0]
+		entry.Amount = aux[1]
+	}
+	return nil
+}
+
+type OrderBook struct {
+	InstrumentName string      `json:"instrument_name"`
+	Bids           []BookEntry `json:"bids"`
+	Asks           []BookEntry `json:"asks"`
+}
+
+func (client *Client) OrderBook(instrument string) (*OrderBook, error) {
+	params := url.Values{}
+	params.Add("instrument_name", instrument)
+	params.Add("depth", "1000")
+
+	var (
+		err  error
+		data json.RawMessage
+		out  OrderBook
+	)
+	if data, err = client.get("/public/get_order_book", params, false, RPS_NON_MATCHING); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
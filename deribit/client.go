@@ -0,0 +1,180 @@
+package deribit
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/ratelimit"
+)
+
+const (
+	API_BASE = "https://www.deribit.com"
+	API_TEST = "https://test.deribit.com"
+	API_PATH = "/api/v2"
+)
+
+// deribit has a credit-based rate limit. the matching engine (buy, sell, cancel) refills at 5 requests per second, everything else at 20 requests per second.
+const (
+	RPS_MATCHING     float64 = 5
+	RPS_NON_MATCHING float64 = 20
+)
+
+var (
+	lastRequest   time.Time
+	BeforeRequest func(method, path string, rps float64) error = nil
+	AfterRequest  func()                                       = nil
+)
+
+func init() {
+	BeforeRequest = func(method, path string, rps float64) error {
+		elapsed := time.Since(lastRequest)
+		if elapsed.Seconds() < (float64(1) / rps) {
+			time.Sleep(time.Duration((float64(time.Second) / rps) - float64(elapsed)))
+		}
+		return nil
+	}
+	AfterRequest = func() {
+		lastRequest = time.Now()
+	}
+}
+
+type Client struct {
+	URL          string
+	clientID     string
+	clientSecret string
+	httpClient   *http.Client
+	mutex        sync.Mutex
+	accessToken  string
+	expiresAt    time.Time
+}
+
+func New(URL, clientID, clientSecret string) *Client {
+	return &Client{
+		URL:          URL,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		httpClient: &http.Client{
+			Timeout:   30 * time.Second,
+			Transport: ratelimit.NewTransport("Deribit", nil),
+		},
+	}
+}
+
+type response struct {
+	Result json.RawMessage `json:"result"`
+	Error  *Error          `json:"error"`
+}
+
+func (client *Client) do(req *http.Request) (json.RawMessage, error) {
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+			return nil, errors.NewStatusError(resp.StatusCode, resp.Status)
+		}
+		return nil, err
+	}
+
+	var out response
+	if err = json.Unmarshal(body, &out); err != nil {
+		if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+			return nil, errors.NewStatusError(resp.StatusCode, resp.Status)
+		}
+		return nil, err
+	}
+	if out.Error != nil {
+		return nil, out.Error
+	}
+
+	return out.Result, nil
+}
+
+func (client *Client) get(path string, params url.Values, auth bool, rps float64) (json.RawMessage, error) {
+	var (
+		err   error
+		token string
+	)
+	if auth {
+		if token, err = client.token(); err != nil {
+			return nil, err
+		}
+	}
+
+	// respect the rate limit
+	if err = BeforeRequest(http.MethodGet, path, rps); err != nil {
+		return nil, err
+	}
+	defer func() {
+		AfterRequest()
+	}()
+
+	// set the endpoint for this request
+	endpoint, err := url.Parse(client.URL)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path += (API_PATH + path)
+	if params != nil {
+		endpoint.RawQuery = params.Encode()
+	}
+
+	// create the request
+	req, err := http.NewRequest(http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// add autentication header
+	if auth {
+		req.Header.Add("Authorization", "Bearer "+token)
+	}
+
+	// do the request
+	return client.do(req)
+}
+
+// returns an access token, refreshed (via client credentials) when it is about to expire
+func (client *Client) token() (string, error) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	if client.accessToken != "" && time.Now().Before(client.expiresAt) {
+		return client.accessToken, nil
+	}
+
+	params := url.Values{}
+	params.Add("grant_type", "client_credentials")
+	params.Add("client_id", client.clientID)
+	params.Add("client_secret", client.clientSecret)
+
+	var (
+		err  error
+		data json.RawMessage
+		out  struct {
+			AccessToken string `json:"access_token"`
+			ExpiresIn   int64  `json:"expires_in"`
+		}
+	)
+	if data, err = client.get("/public/auth", params, false, RPS_NON_MATCHING); err != nil {
+		return "", err
+	}
+	if err = json.Unmarshal(data, &out); err != nil {
+		return "", err
+	}
+
+	client.accessToken = out.AccessToken
+	// refresh one minute before the token expires
+	client.expiresAt = time.Now().Add(time.Duration(out.ExpiresIn)*time.Second - time.Minute)
+
+	return client.accessToken, nil
+}
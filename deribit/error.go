@@ -0,0 +1,15 @@
+package deribit
+
+import (
+	"fmt"
+)
+
+type Error struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+func (err *Error) Error() string {
+	return fmt.Sprintf("%d %s", err.Code, err.Message)
+}
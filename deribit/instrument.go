@@ -0,0 +1,107 @@
+package deribit
+
+import (
+	"encoding/json"
+	"math"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/svanas/nefertiti/precision"
+)
+
+type Kind string
+
+const (
+	KIND_FUTURE Kind = "future"
+	KIND_OPTION Kind = "option"
+)
+
+// we support the BTC and the ETH perpetual swaps, and read-only options data.
+var Currencies = []string{"BTC", "ETH"}
+
+type Instrument struct {
+	InstrumentName      string  `json:"instrument_name"`
+	Kind                Kind    `json:"kind"`
+	IsActive            bool    `json:"is_active"`
+	BaseCurrency        string  `json:"base_currency"`
+	QuoteCurrency       string  `json:"quote_currency"`
+	SettlementPeriod    string  `json:"settlement_period"`
+	TickSize            float64 `json:"tick_size"`
+	MinTradeAmount      float64 `json:"min_trade_amount"`
+	ContractSize        float64 `json:"contract_size"`
+	ExpirationTimestamp int64   `json:"expiration_timestamp"`
+	Strike              float64 `json:"strike,omitempty"`
+	OptionType          string  `json:"option_type,omitempty"`
+}
+
+func (instrument *Instrument) IsPerpetual() bool {
+	return instrument.Kind == KIND_FUTURE && instrument.SettlementPeriod == "perpetual"
+}
+
+func (instrument *Instrument) IsOption() bool {
+	return instrument.Kind == KIND_OPTION
+}
+
+func (instrument *Instrument) ExpiresAt() time.Time {
+	return time.Unix(0, instrument.ExpirationTimestamp*int64(time.Millisecond))
+}
+
+func (client *Client) Instruments(currency string, kind Kind) ([]Instrument, error) {
+	params := url.Values{}
+	params.Add("currency", strings.ToUpper(currency))
+	params.Add("kind", string(kind))
+	params.Add("expired", "false")
+
+	var (
+		err  error
+		data json.RawMessage
+		out  []Instrument
+	)
+	if data, err = client.get("/public/get_instruments", params, false, RPS_NON_MATCHING); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// the perpetual swaps are named BTC-PERPETUAL and ETH-PERPETUAL
+func FormatPerpetual(currency string) string {
+	return strings.ToUpper(currency) + "-PERPETUAL"
+}
+
+// returns the underlying currency, for example BTC for BTC-PERPETUAL or BTC-27DEC24-60000-C
+func ParseCurrency(instrument string) string {
+	return strings.Split(instrument, "-")[0]
+}
+
+// round the price to the nearest tick
+func (instrument *Instrument) RoundPrice(price float64) float64 {
+	return precision.RoundTick(price, instrument.TickSize)
+}
+
+// converts a size in the underlying currency into a number of contracts, rounded to the contract size.
+func (instrument *Instrument) ToAmount(size, price float64) float64 {
+	amount := size
+	if instrument.IsPerpetual() && instrument.QuoteCurrency == "USD" {
+		amount = size * price
+	}
+	if instrument.ContractSize > 0 {
+		amount = math.Round(amount/instrument.ContractSize) * instrument.ContractSize
+	}
+	if amount < instrument.MinTradeAmount {
+		amount = instrument.MinTradeAmount
+	}
+	return amount
+}
+
+// converts a number of contracts into a size in the underlying currency.
+func (instrument *Instrument) FromAmount(amount, price float64) float64 {
+	if instrument.IsPerpetual() && instrument.QuoteCurrency == "USD" && price > 0 {
+		return amount / price
+	}
+	return amount
+}
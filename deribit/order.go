@@ -0,0 +1,201 @@
+package deribit
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type (
+	OrderDirection string
+	OrderType      string
+	OrderState     string
+)
+
+const (
+	OrderDirectionBuy  OrderDirection = "buy"
+	OrderDirectionSell OrderDirection = "sell"
+)
+
+const (
+	OrderTypeLimit      OrderType = "limit"
+	OrderTypeMarket     OrderType = "market"
+	OrderTypeStopMarket OrderType = "stop_market"
+	OrderTypeStopLimit  OrderType = "stop_limit"
+)
+
+const (
+	OrderStateOpen        OrderState = "open"
+	OrderStateFilled      OrderState = "filled"
+	OrderStateRejected    OrderState = "rejected"
+	OrderStateCancelled   OrderState = "cancelled"
+	OrderStateUntriggered OrderState = "untriggered"
+	OrderStateTriggered   OrderState = "triggered"
+)
+
+type Order struct {
+	OrderID           string         `json:"order_id"`
+	InstrumentName    string         `json:"instrument_name"`
+	Direction         OrderDirection `json:"direction"`
+	OrderType         OrderType      `json:"order_type"`
+	OrderState        OrderState     `json:"order_state"`
+	Price             interface{}    `json:"price"` // "market_price" for market orders
+	TriggerPrice      float64        `json:"trigger_price,omitempty"`
+	Amount            float64        `json:"amount"` // in USD for the inverse perpetuals
+	FilledAmount      float64        `json:"filled_amount"`
+	AveragePrice      float64        `json:"average_price"`
+	ReduceOnly        bool           `json:"reduce_only"`
+	Label             string         `json:"label"`
+	CreationTimestamp int64          `json:"creation_timestamp"`
+	UpdateTimestamp   int64          `json:"last_update_timestamp"`
+}
+
+func (order *Order) CreatedAt() time.Time {
+	return time.Unix(0, order.CreationTimestamp*int64(time.Millisecond))
+}
+
+func (order *Order) UpdatedAt() time.Time {
+	return time.Unix(0, order.UpdateTimestamp*int64(time.Millisecond))
+}
+
+func (order *Order) GetPrice() float64 {
+	if price, ok := order.Price.(float64); ok {
+		return price
+	}
+	return 0
+}
+
+func (order *Order) ExecutedAt() float64 {
+	if order.AveragePrice > 0 {
+		return order.AveragePrice
+	}
+	return order.GetPrice()
+}
+
+func (order *Order) IsStop() bool {
+	return order.OrderType == OrderTypeStopMarket || order.OrderType == OrderTypeStopLimit
+}
+
+type NewOrder struct {
+	Type         OrderType
+	Amount       float64
+	Price        float64
+	TriggerPrice float64
+	ReduceOnly   bool
+	Label        string
+}
+
+// places a buy or sell order. the amount is in USD for the inverse perpetuals.
+func (client *Client) Order(instrument string, direction OrderDirection, order *NewOrder) (*Order, error) {
+	params := url.Values{}
+	params.Add("instrument_name", instrument)
+	params.Add("amount", strconv.FormatFloat(order.Amount, 'f', -1, 64))
+	params.Add("type", string(order.Type))
+	if order.Type == OrderTypeLimit || order.Type == OrderTypeStopLimit {
+		params.Add("price", strconv.FormatFloat(order.Price, 'f', -1, 64))
+	}
+	if order.Type == OrderTypeStopMarket || order.Type == OrderTypeStopLimit {
+		params.Add("trigger_price", strconv.FormatFloat(order.TriggerPrice, 'f', -1, 64))
+		params.Add("trigger", "last_price")
+	}
+	if order.ReduceOnly {
+		params.Add("reduce_only", "true")
+	}
+	if order.Label != "" {
+		params.Add("label", order.Label)
+	}
+
+	var (
+		err  error
+		data json.RawMessage
+		out  struct {
+			Order Order `json:"order"`
+		}
+	)
+	if data, err = client.get(("/private/" + string(direction)), params, true, RPS_MATCHING); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+
+	return &out.Order, nil
+}
+
+func (client *Client) CancelOrder(orderID string) error {
+	params := url.Values{}
+	params.Add("order_id", orderID)
+
+	_, err := client.get("/private/cancel", params, true, RPS_MATCHING)
+
+	return err
+}
+
+func (client *Client) GetOrderState(orderID string) (*Order, error) {
+	params := url.Values{}
+	params.Add("order_id", orderID)
+
+	var (
+		err  error
+		data json.RawMessage
+		out  Order
+	)
+	if data, err = client.get("/private/get_order_state", params, true, RPS_NON_MATCHING); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// returns the open orders, including the untriggered stop orders
+func (client *Client) OpenOrders(instrument string) ([]Order, error) {
+	params := url.Values{}
+	params.Add("instrument_name", instrument)
+	params.Add("type", "all")
+
+	var (
+		err  error
+		data json.RawMessage
+		out  []Order
+	)
+	if data, err = client.get("/private/get_open_orders_by_instrument", params, true, RPS_NON_MATCHING); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// returns the (most recent) filled orders
+func (client *Client) FilledOrders(instrument string) ([]Order, error) {
+	params := url.Values{}
+	params.Add("instrument_name", instrument)
+	params.Add("count", "100")
+
+	var (
+		err  error
+		data json.RawMessage
+		out  []Order
+	)
+	if data, err = client.get("/private/get_order_history_by_instrument", params, true, RPS_NON_MATCHING); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+
+	var filled []Order
+	for _, order := range out {
+		if order.FilledAmount > 0 {
+			filled = append(filled, order)
+		}
+	}
+
+	return filled, nil
+}
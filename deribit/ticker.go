@@ -0,0 +1,54 @@
+package deribit
+
+import (
+	"encoding/json"
+	"net/url"
+)
+
+type Greeks struct {
+	Delta float64 `json:"delta"`
+	Gamma float64 `json:"gamma"`
+	Vega  float64 `json:"vega"`
+	Theta float64 `json:"theta"`
+	Rho   float64 `json:"rho"`
+}
+
+type Ticker struct {
+	InstrumentName  string  `json:"instrument_name"`
+	LastPrice       float64 `json:"last_price"`
+	MarkPrice       float64 `json:"mark_price"`
+	IndexPrice      float64 `json:"index_price"`
+	UnderlyingPrice float64 `json:"underlying_price,omitempty"`
+	BestBidPrice    float64 `json:"best_bid_price"`
+	BestAskPrice    float64 `json:"best_ask_price"`
+	OpenInterest    float64 `json:"open_interest"`
+	FundingRate     float64 `json:"current_funding,omitempty"`
+	MarkIV          float64 `json:"mark_iv,omitempty"` // options only
+	Greeks          *Greeks `json:"greeks,omitempty"`  // options only
+	Stats           struct {
+		High      float64 `json:"high"`
+		Low       float64 `json:"low"`
+		Volume    float64 `json:"volume"`
+		VolumeUSD float64 `json:"volume_usd"`
+		Change    float64 `json:"price_change"` // in %
+	} `json:"stats"`
+}
+
+func (client *Client) Ticker(instrument string) (*Ticker, error) {
+	params := url.Values{}
+	params.Add("instrument_name", instrument)
+
+	var (
+		err  error
+		data json.RawMessage
+		out  Ticker
+	)
+	if data, err = client.get("/public/ticker", params, false, RPS_NON_MATCHING); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
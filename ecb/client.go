@@ -0,0 +1,125 @@
+package ecb
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	API_BASE = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml"
+)
+
+var (
+	lastRequest   time.Time
+	BeforeRequest func(path string) error = nil
+	AfterRequest  func()                  = nil
+)
+
+func init() {
+	BeforeRequest = func(path string) error {
+		elapsed := time.Since(lastRequest)
+		if elapsed.Seconds() < 1 {
+			time.Sleep(time.Second - elapsed)
+		}
+		return nil
+	}
+	AfterRequest = func() {
+		lastRequest = time.Now()
+	}
+}
+
+// the ECB publishes its reference rates once a day, around 16:00 CET
+const cacheDuration = time.Hour
+
+var (
+	cache      Rates
+	cacheTime  time.Time
+	cacheMutex sync.Mutex
+)
+
+// euro foreign exchange reference rates, for example: 1 EUR = 1.0856 USD
+type Rates map[string]float64
+
+type Client struct {
+	URL        string
+	httpClient *http.Client
+}
+
+func New() *Client {
+	return &Client{
+		URL: API_BASE,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+func (client *Client) get() ([]byte, error) {
+	// satisfy the rate limit
+	if err := BeforeRequest(client.URL); err != nil {
+		return nil, err
+	}
+	defer func() {
+		AfterRequest()
+	}()
+
+	resp, err := client.httpClient.Get(client.URL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return nil, errors.New(resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// returns the latest reference rates (including EUR itself). the rates are cached for an hour.
+func (client *Client) Rates() (Rates, error) {
+	cacheMutex.Lock()
+	defer cacheMutex.Unlock()
+
+	if cache != nil && time.Since(cacheTime) < cacheDuration {
+		return cache, nil
+	}
+
+	body, err := client.get()
+	if err != nil {
+		return nil, err
+	}
+
+	var envelope struct {
+		Cube struct {
+			Cube struct {
+				Time string `xml:"time,attr"`
+				Cube []struct {
+					Currency string  `xml:"currency,attr"`
+					Rate     float64 `xml:"rate,attr"`
+				} `xml:"Cube"`
+			} `xml:"Cube"`
+		} `xml:"Cube"`
+	}
+	if err = xml.Unmarshal(body, &envelope); err != nil {
+		return nil, err
+	}
+
+	out := Rates{"EUR": 1}
+	for _, rate := range envelope.Cube.Cube.Cube {
+		out[strings.ToUpper(rate.Currency)] = rate.Rate
+	}
+	if len(out) == 1 {
+		return nil, errors.New("no exchange rates found")
+	}
+
+	cache = out
+	cacheTime = time.Now()
+
+	return out, nil
+}
@@ -0,0 +1,52 @@
+package ecb
+
+import (
+	"fmt"
+	"strings"
+)
+
+// stablecoins that we value at (roughly) one unit of fiat currency
+var stablecoins = map[string]string{
+	"USDT":  "USD",
+	"USDC":  "USD",
+	"BUSD":  "USD",
+	"TUSD":  "USD",
+	"USDP":  "USD",
+	"DAI":   "USD",
+	"EURT":  "EUR",
+	"EUROC": "EUR",
+}
+
+// returns the fiat currency behind an asset, for example USDT -> USD
+func Underlying(asset string) string {
+	asset = strings.ToUpper(asset)
+	if fiat, ok := stablecoins[asset]; ok {
+		return fiat
+	}
+	return asset
+}
+
+// returns true if we have a reference rate for this asset (or the fiat currency behind it)
+func (rates Rates) Has(asset string) bool {
+	_, ok := rates[Underlying(asset)]
+	return ok
+}
+
+// converts an amount from one (fiat) currency into another, for example from KRW into USD
+func (rates Rates) Convert(amount float64, from, to string) (float64, error) {
+	from = Underlying(from)
+	to = Underlying(to)
+	if from == to {
+		return amount, nil
+	}
+	rateFrom, ok := rates[from]
+	if !ok || rateFrom == 0 {
+		return 0, fmt.Errorf("no exchange rate for %s", from)
+	}
+	rateTo, ok := rates[to]
+	if !ok {
+		return 0, fmt.Errorf("no exchange rate for %s", to)
+	}
+	// both rates are quoted against the euro
+	return amount / rateFrom * rateTo, nil
+}
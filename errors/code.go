@@ -0,0 +1,154 @@
+package errors
+
+import (
+	stderrors "errors"
+	"io"
+	"net"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// the exit codes of the one-shot commands, so that your wrapper scripts can react appropriately
+const (
+	EXIT_OK                 = 0
+	EXIT_ERROR              = 1 // anything we don't know how to classify
+	EXIT_VALIDATION         = 2 // a missing or invalid argument
+	EXIT_AUTH               = 3 // the exchange rejected your API key or signature
+	EXIT_RATE_LIMIT         = 4 // the exchange (or one of our oracles) throttled us
+	EXIT_INSUFFICIENT_FUNDS = 5 // not enough balance to place the order
+	EXIT_NETWORK            = 6 // timeout, connection refused, etc
+	EXIT_REFUSED            = 7 // one of our own risk checks (sanity, exposure, equity share, approval) refused the order
+)
+
+var kinds = map[int]string{
+	EXIT_OK:                 "ok",
+	EXIT_ERROR:              "error",
+	EXIT_VALIDATION:         "validation",
+	EXIT_AUTH:               "auth",
+	EXIT_RATE_LIMIT:         "rate_limit",
+	EXIT_INSUFFICIENT_FUNDS: "insufficient_funds",
+	EXIT_NETWORK:            "network",
+	EXIT_REFUSED:            "refused",
+}
+
+// Kind returns the name of an exit code, for example: rate_limit
+func Kind(code int) string {
+	if out, ok := kinds[code]; ok {
+		return out
+	}
+	return kinds[EXIT_ERROR]
+}
+
+func contains(msg string, subs ...string) bool {
+	for _, sub := range subs {
+		if strings.Contains(msg, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// eof is io.EOF after it got turned into a string, for example: Get "https://...": EOF
+var eof = regexp.MustCompile(`\beof\b`)
+
+// exitCodeOf classifies an error by its type (or by the HTTP status code it came with). returns false if the error
+// doesn't tell us, in which case we fall back on the message.
+func exitCodeOf(err error) (int, bool) {
+	var status *StatusError
+	if stderrors.As(err, &status) {
+		switch status.Code {
+		case 401, 403:
+			return EXIT_AUTH, true
+		case 418, 429:
+			return EXIT_RATE_LIMIT, true
+		case 502, 503, 504:
+			return EXIT_NETWORK, true
+		}
+	}
+	// a file in the session dir (or your vault) that we cannot read, not an API key that the exchange rejected
+	if stderrors.Is(err, os.ErrPermission) {
+		return EXIT_ERROR, true
+	}
+	if stderrors.Is(err, io.EOF) || stderrors.Is(err, io.ErrUnexpectedEOF) {
+		return EXIT_NETWORK, true
+	}
+	var netErr net.Error
+	if stderrors.As(err, &netErr) {
+		return EXIT_NETWORK, true
+	}
+	return EXIT_ERROR, false
+}
+
+// ExitCode classifies an error into one of the above exit codes. we look at the type of the error first. the
+// exchanges don't agree on their error codes, so otherwise we look at the message.
+func ExitCode(err error) int {
+	if err == nil {
+		return EXIT_OK
+	}
+
+	if code, ok := exitCodeOf(err); ok {
+		return code
+	}
+
+	msg := strings.ToLower(err.Error())
+
+	switch {
+	case contains(msg,
+		"unauthorized",
+		"403 forbidden",
+		"invalid api",
+		"api-key format invalid",
+		"apikey_invalid",
+		"invalid_signature",
+		"invalid signature",
+		"signature for this request is not valid",
+	):
+		return EXIT_AUTH
+	case contains(msg,
+		"429 too many requests",
+		"too many requests",
+		"rate limit",
+		"ratelimit",
+		"throttled",
+	):
+		return EXIT_RATE_LIMIT
+	case contains(msg,
+		"insufficient",
+		"not enough balance",
+		"balance not enough",
+		"balance too low",
+		"account has insufficient balance",
+	):
+		return EXIT_INSUFFICIENT_FUNDS
+	case contains(msg,
+		"timeout",
+		"timed out",
+		"connection refused",
+		"connection reset",
+		"no such host",
+		"network is unreachable",
+		"502 bad gateway",
+		"503 service unavailable",
+		"504 gateway timeout",
+	) || eof.MatchString(msg):
+		return EXIT_NETWORK
+	case strings.HasPrefix(msg, "cannot ") && contains(msg,
+		"deviates",
+		"exceeds your max",
+		"approval",
+	):
+		return EXIT_REFUSED
+	case contains(msg,
+		"missing argument",
+		"invalid argument",
+		" is invalid",
+		"does not exist",
+		"not supported",
+		"does not support",
+	):
+		return EXIT_VALIDATION
+	}
+
+	return EXIT_ERROR
+}
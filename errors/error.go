@@ -97,6 +97,11 @@ func (err *Error) Error() string {
 	return err.Err.Error()
 }
 
+// Unwrap returns the underlying error, so that the standard library's errors.As can look inside an Error.
+func (err *Error) Unwrap() error {
+	return err.Err
+}
+
 // Stack returns the callstack formatted the same way that go does in runtime/debug.Stack()
 func (err *Error) Stack() []byte {
 	buf := bytes.Buffer{}
@@ -0,0 +1,17 @@
+package errors
+
+// StatusError is an error that knows the HTTP status code of the response it came from, so that we can classify it
+// without looking for "401" (or "429") in the message.
+type StatusError struct {
+	Code int
+	Msg  string
+}
+
+func (err *StatusError) Error() string {
+	return err.Msg
+}
+
+// NewStatusError returns an error with the HTTP status code of the response it came from
+func NewStatusError(code int, msg string) *StatusError {
+	return &StatusError{Code: code, Msg: msg}
+}
@@ -3,7 +3,7 @@ package ethplorer
 import (
 	"encoding/json"
 	"errors"
-	"io/ioutil"
+	"io"
 	"net/http"
 	"net/url"
 	"time"
@@ -59,7 +59,7 @@ func (client *Client) get(url string) ([]byte, error) {
 	}
 	defer resp.Body.Close()
 	var data []byte
-	if data, err = ioutil.ReadAll(resp.Body); err != nil {
+	if data, err = io.ReadAll(resp.Body); err != nil {
 		return nil, err
 	}
 	return data, nil
@@ -0,0 +1,136 @@
+package exchanges
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/i18n"
+	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/notify"
+	"github.com/svanas/nefertiti/session"
+)
+
+// with --watch-account, the sell loop polls your account for the events that the exchange tells us about (for
+// example: withdrawals). the bot never withdraws, so every new event is somebody else using your keys (or you, using
+// the same keys outside of the bot). either way, you want to know about it right away.
+
+type AccountEvents struct {
+	PolledAt time.Time            `json:"polled_at"`
+	Seen     map[string]time.Time `json:"seen"` // event ID -> when it happened
+}
+
+const (
+	accountInterval  = 15 * time.Minute   // we poll this often
+	accountOverlap   = time.Hour          // we look back this far before the last poll, in case the exchange is late
+	accountRetention = 7 * 24 * time.Hour // we forget about the events after this long
+)
+
+var (
+	accountMutex    sync.Mutex
+	accountPolledAt = make(map[string]time.Time) // exchange code -> last poll
+)
+
+func accountFile(exchange model.Exchange) string {
+	return session.GetSessionFile(strings.ToLower(exchange.GetInfo().Code) + ".account.json")
+}
+
+func readAccountEvents(exchange model.Exchange) (*AccountEvents, error) {
+	var out AccountEvents
+	data, err := session.ReadFile(accountFile(exchange))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, errors.Wrap(err, 1)
+		}
+	} else {
+		if err = json.Unmarshal(data, &out); err != nil {
+			return nil, errors.Wrap(err, 1)
+		}
+	}
+	if out.Seen == nil {
+		out.Seen = make(map[string]time.Time)
+	}
+	return &out, nil
+}
+
+func (events *AccountEvents) write(exchange model.Exchange) error {
+	for id, seen := range events.Seen {
+		if time.Since(seen) > accountRetention {
+			delete(events.Seen, id)
+		}
+	}
+	data, err := json.Marshal(events)
+	if err != nil {
+		return errors.Wrap(err, 1)
+	}
+	return session.WriteFile(accountFile(exchange), data)
+}
+
+// watchAccount alerts you about the account events that we haven't seen before. the first poll only takes note of
+// what is there, so that you don't get an alert for every withdrawal you've ever made.
+func watchAccount(exchange model.Exchange, client interface{}, service model.Notify) {
+	if !flag.WatchAccount() {
+		return
+	}
+	monitor, ok := exchange.(model.AccountMonitor)
+	if !ok {
+		return
+	}
+
+	accountMutex.Lock()
+	defer accountMutex.Unlock()
+
+	code := exchange.GetInfo().Code
+	if time.Since(accountPolledAt[code]) < accountInterval {
+		return
+	}
+	accountPolledAt[code] = time.Now()
+
+	if err := func() error {
+		events, err := readAccountEvents(exchange)
+		if err != nil {
+			return err
+		}
+
+		since := events.PolledAt
+		if since.IsZero() {
+			since = time.Now().Add(-accountRetention)
+		} else {
+			since = since.Add(-accountOverlap)
+		}
+
+		var polled []model.AccountEvent
+		if polled, err = monitor.GetAccountEvents(client, since); err != nil {
+			return err
+		}
+
+		first := events.PolledAt.IsZero()
+		for _, event := range polled {
+			if _, ok := events.Seen[event.Id]; ok {
+				continue
+			}
+			events.Seen[event.Id] = event.Time
+			if first {
+				continue
+			}
+			msg := i18n.Sprintf("Unexpected %s on your account: %v %s to %s at %s. The bot did not do this.", event.Kind, event.Amount, event.Asset, event.Detail, event.Time.Format(time.RFC3339))
+			log.Printf("[WARN] %s\n", msg)
+			if service != nil {
+				if err := notify.Critical(service, notify.EVENT_ACCOUNT, msg, fmt.Sprintf("%s - Account", exchange.GetInfo().Name)); err != nil {
+					log.Printf("[ERROR] %v", err)
+				}
+			}
+		}
+
+		events.PolledAt = time.Now()
+		return events.write(exchange)
+	}(); err != nil {
+		log.Printf("[ERROR] %v", err)
+	}
+}
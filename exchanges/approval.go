@@ -0,0 +1,284 @@
+package exchanges
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/session"
+	"github.com/svanas/nefertiti/uuid"
+)
+
+const (
+	approvalsFile = "approvals.json"
+	approvalsLock = "approvals.lock"
+)
+
+type ApprovalStatus string
+
+const (
+	APPROVAL_PENDING  ApprovalStatus = "pending"
+	APPROVAL_APPROVED ApprovalStatus = "approved"
+	APPROVAL_REJECTED ApprovalStatus = "rejected"
+	APPROVAL_EXPIRED  ApprovalStatus = "expired"
+)
+
+type Approval struct {
+	Id        string          `json:"id"`
+	Exchange  string          `json:"exchange"`
+	Market    string          `json:"market"`
+	Side      model.OrderSide `json:"side"`
+	Size      float64         `json:"size"`
+	Price     float64         `json:"price"`            // the average price if this approval is for a ladder
+	Orders    int             `json:"orders,omitempty"` // the number of orders in the ladder (if any)
+	Status    ApprovalStatus  `json:"status"`
+	CreatedAt time.Time       `json:"created_at"`
+	ExpiresAt time.Time       `json:"expires_at"`
+	Run       string          `json:"run,omitempty"` // the run ID (and label) of the loop that held this order
+}
+
+type Approvals []Approval
+
+func (approvals Approvals) indexById(id string) int {
+	for i, approval := range approvals {
+		if approval.Id == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// updateApprovals reads the approvals, hands them to callback, then writes them back. the approvals are shared
+// between the bot and the approve command, so we lock the file while we are at it.
+func updateApprovals(callback func(approvals Approvals) (Approvals, error)) error {
+	mutex, err := session.NewFileMutex(approvalsLock)
+	if err != nil {
+		return errors.Wrap(err, 1)
+	}
+	if err = mutex.Lock(); err != nil {
+		return errors.Wrap(err, 1)
+	}
+	defer mutex.Unlock()
+
+	var approvals Approvals
+	data, err := session.ReadFile(session.GetSessionFile(approvalsFile))
+	if err == nil {
+		if err = json.Unmarshal(data, &approvals); err != nil {
+			return errors.Wrap(err, 1)
+		}
+	} else if !os.IsNotExist(err) {
+		return errors.Wrap(err, 1)
+	}
+
+	if approvals, err = callback(approvals); err != nil {
+		return err
+	}
+
+	// forget about the approvals we no longer need
+	var out Approvals
+	for _, approval := range approvals {
+		if approval.Status == APPROVAL_PENDING || time.Since(approval.ExpiresAt) < 24*time.Hour {
+			out = append(out, approval)
+		}
+	}
+
+	if data, err = json.Marshal(out); err != nil {
+		return errors.Wrap(err, 1)
+	}
+	return session.WriteFile(session.GetSessionFile(approvalsFile), data)
+}
+
+// GetApprovals returns the approvals that are waiting for you
+func GetApprovals() (Approvals, error) {
+	var out Approvals
+	err := updateApprovals(func(approvals Approvals) (Approvals, error) {
+		for _, approval := range approvals {
+			if approval.Status == APPROVAL_PENDING && time.Now().Before(approval.ExpiresAt) {
+				out = append(out, approval)
+			}
+		}
+		return approvals, nil
+	})
+	return out, err
+}
+
+// Approve approves (or rejects) a pending order
+func Approve(id string, approve bool) (*Approval, error) {
+	var out *Approval
+	err := updateApprovals(func(approvals Approvals) (Approvals, error) {
+		i := approvals.indexById(id)
+		if i == -1 {
+			return nil, errors.Errorf("approval %s does not exist", id)
+		}
+		if approvals[i].Status != APPROVAL_PENDING || time.Now().After(approvals[i].ExpiresAt) {
+			return nil, errors.Errorf("approval %s is no longer pending", id)
+		}
+		if approve {
+			approvals[i].Status = APPROVAL_APPROVED
+		} else {
+			approvals[i].Status = APPROVAL_REJECTED
+		}
+		out = &approvals[i]
+		return approvals, nil
+	})
+	return out, err
+}
+
+// RequestApproval holds an order worth more than --approve-above=X (in quote currency) until you approve it, either
+// with the approve command or with POST 127.0.0.1:[port]/approve?id=X. The request expires after --approve-timeout=N
+// minutes. Returns nil if you approved the order, otherwise an error.
+func RequestApproval(exchange model.Exchange, client interface{}, market string, side model.OrderSide, size, price float64, service model.Notify) error {
+	threshold, timeout, err := flag.ApproveAbove()
+	if err != nil {
+		return err
+	}
+
+	// nothing to approve if we are not going to send the order anyway
+	if threshold == 0 || observing() {
+		return nil
+	}
+
+	// market orders have no price, so we use the ticker to calculate the notional value
+	notional := size * price
+	if price == 0 {
+		ticker, err := exchange.GetTicker(client, market)
+		if err != nil {
+			return err
+		}
+		notional = size * ticker
+	}
+	if notional <= threshold {
+		return nil
+	}
+
+	approval := Approval{
+		Id:        uuid.New().Short(),
+		Exchange:  exchange.GetInfo().Name,
+		Market:    market,
+		Side:      side,
+		Size:      size,
+		Price:     price,
+		Status:    APPROVAL_PENDING,
+		CreatedAt: time.Now(),
+		ExpiresAt: time.Now().Add(timeout),
+		Run:       session.RunTag(),
+	}
+
+	msg := fmt.Sprintf("%s %s %s at %s is waiting for your approval. Please run: ./nefertiti approve --id=%s (or --reject). This request expires in %v.",
+		model.FormatOrderSide(side), model.FormatSize(exchange, client, market, size), market, model.FormatPrice(exchange, client, market, price), approval.Id, timeout)
+
+	return waitForApproval(exchange, approval, msg, service)
+}
+
+// RequestLadderApproval holds a ladder of buy orders until you approve it, if one (or more) of the orders in that
+// ladder is worth more than --approve-above=X (in quote currency). You approve the ladder once, rather than every
+// order in it, so we do not place the bottom of the ladder at prices that are --approve-timeout minutes old.
+func RequestLadderApproval(exchange model.Exchange, client interface{}, market string, calls model.Calls, service model.Notify) error {
+	threshold, timeout, err := flag.ApproveAbove()
+	if err != nil {
+		return err
+	}
+
+	if threshold == 0 || observing() {
+		return nil
+	}
+
+	var (
+		ticker   float64
+		size     float64
+		notional float64
+		orders   int
+		above    bool
+	)
+	for _, call := range calls {
+		if call.Skip || (call.Market != "" && call.Market != market) {
+			continue
+		}
+		price := call.Price
+		if price == 0 {
+			if ticker == 0 {
+				if ticker, err = exchange.GetTicker(client, market); err != nil {
+					return err
+				}
+			}
+			price = ticker
+		}
+		if call.Size*price > threshold {
+			above = true
+		}
+		size += call.Size
+		notional += call.Size * price
+		orders++
+	}
+	if !above || size == 0 {
+		return nil
+	}
+
+	approval := Approval{
+		Id:        uuid.New().Short(),
+		Exchange:  exchange.GetInfo().Name,
+		Market:    market,
+		Side:      model.BUY,
+		Size:      size,
+		Price:     notional / size,
+		Orders:    orders,
+		Status:    APPROVAL_PENDING,
+		CreatedAt: time.Now(),
+		ExpiresAt: time.Now().Add(timeout),
+		Run:       session.RunTag(),
+	}
+
+	msg := fmt.Sprintf("%s %s %s in %d orders at an average price of %s is waiting for your approval. Please run: ./nefertiti approve --id=%s (or --reject). This request expires in %v.",
+		model.FormatOrderSide(model.BUY), model.FormatSize(exchange, client, market, size), market, orders, model.FormatPrice(exchange, client, market, approval.Price), approval.Id, timeout)
+
+	return waitForApproval(exchange, approval, msg, service)
+}
+
+// waitForApproval records the approval, lets you know about it, then waits for you to make up your mind
+func waitForApproval(exchange model.Exchange, approval Approval, msg string, service model.Notify) error {
+	if err := updateApprovals(func(approvals Approvals) (Approvals, error) {
+		return append(approvals, approval), nil
+	}); err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] %s\n", msg)
+	if service != nil {
+		if err := service.SendMessage(msg, (exchange.GetInfo().Name + " - Approval"), model.ALWAYS); err != nil {
+			log.Printf("[ERROR] %v", err)
+		}
+	}
+
+	// wait for you to make up your mind
+	for {
+		time.Sleep(5 * time.Second)
+
+		var status ApprovalStatus
+		if err := updateApprovals(func(approvals Approvals) (Approvals, error) {
+			i := approvals.indexById(approval.Id)
+			if i == -1 {
+				return nil, errors.Errorf("approval %s does not exist", approval.Id)
+			}
+			if approvals[i].Status == APPROVAL_PENDING && time.Now().After(approvals[i].ExpiresAt) {
+				approvals[i].Status = APPROVAL_EXPIRED
+			}
+			status = approvals[i].Status
+			return approvals, nil
+		}); err != nil {
+			return err
+		}
+
+		switch status {
+		case APPROVAL_APPROVED:
+			return nil
+		case APPROVAL_REJECTED, APPROVAL_EXPIRED:
+			return errors.Errorf("Cannot %s %s. Approval %s has been %s.", strings.ToLower(model.FormatOrderSide(approval.Side)), approval.Market, approval.Id, status)
+		}
+	}
+}
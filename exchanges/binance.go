@@ -6,35 +6,61 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"math"
 	"net/http"
 	"os"
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	exchange "github.com/adshao/go-binance/v2"
-	filemutex "github.com/alexflint/go-filemutex"
 	"github.com/svanas/nefertiti/aggregation"
 	"github.com/svanas/nefertiti/binance"
 	"github.com/svanas/nefertiti/errors"
 	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/i18n"
 	"github.com/svanas/nefertiti/model"
 	"github.com/svanas/nefertiti/multiplier"
 	"github.com/svanas/nefertiti/notify"
+	"github.com/svanas/nefertiti/override"
 	"github.com/svanas/nefertiti/precision"
 	"github.com/svanas/nefertiti/pricing"
 	"github.com/svanas/nefertiti/session"
 )
 
 var (
-	binanceMutex *filemutex.FileMutex
+	binanceMutex      = make(map[string]*session.Mutex)
+	binanceMutexGuard sync.Mutex // BeforeRequest runs on more than one goroutine, so guard the above map
 )
 
-const (
-	binanceSessionFile = "binance.time"
-	binanceSessionLock = "binance.lock"
-)
+func getBinanceMutex(lock string) (*session.Mutex, error) {
+	binanceMutexGuard.Lock()
+	defer binanceMutexGuard.Unlock()
+	mutex, ok := binanceMutex[lock]
+	if !ok {
+		var err error
+		if mutex, err = session.NewMutex(lock); err != nil {
+			return nil, err
+		}
+		binanceMutex[lock] = mutex
+	}
+	return mutex, nil
+}
+
+// Binance and Binance.US have their own rate limits, hence their own session files.
+func binanceSession(client *binance.Client) (file, lock string) {
+	name := binanceSessionName(client)
+	return name + ".time", name + ".lock"
+}
+
+func binanceSessionName(client *binance.Client) string {
+	if domain := client.Domain(); domain != "binance.com" {
+		return domain
+	}
+	return "binance"
+}
 
 //-------------------- globals -------------------
 
@@ -42,29 +68,28 @@ func init() {
 	binance.BeforeRequest = func(client *binance.Client, weight int) error {
 		var err error
 
-		if binanceMutex == nil {
-			if binanceMutex, err = filemutex.New(session.GetSessionFile(binanceSessionLock)); err != nil {
-				return err
-			}
+		sessionFile, sessionLock := binanceSession(client)
+
+		var mutex *session.Mutex
+		if mutex, err = getBinanceMutex(sessionLock); err != nil {
+			return err
 		}
 
-		if err = binanceMutex.Lock(); err != nil {
+		if err = mutex.Lock(); err != nil {
 			return err
 		}
 
 		var lastRequest *time.Time
-		if lastRequest, err = session.GetLastRequest(binanceSessionFile); err != nil {
+		if lastRequest, err = session.GetLastRequest(sessionFile); err != nil {
 			return err
 		}
 
 		if lastRequest != nil {
-			var rps float64
-			if rps, err = binance.GetRequestsPerSecond(client, weight); err != nil {
+			var sleep time.Duration
+			if sleep, err = binance.GetWeightDelay(client, weight, *lastRequest); err != nil {
 				return err
 			}
-			elapsed := time.Since(*lastRequest)
-			if elapsed.Seconds() < (float64(1) / rps) {
-				sleep := time.Duration((float64(time.Second) / rps)) - elapsed
+			if sleep > 0 {
 				if flag.Debug() {
 					log.Printf("[DEBUG] sleeping %f seconds", sleep.Seconds())
 				}
@@ -74,11 +99,17 @@ func init() {
 
 		return nil
 	}
-	binance.AfterRequest = func() {
+	binance.OnRateLimit = func(client *binance.Client) {
+		recordRateLimit(binanceSessionName(client), "")
+	}
+	binance.AfterRequest = func(client *binance.Client) {
+		sessionFile, sessionLock := binanceSession(client)
 		defer func() {
-			binanceMutex.Unlock()
+			if mutex, err := getBinanceMutex(sessionLock); err == nil {
+				mutex.Unlock()
+			}
 		}()
-		session.SetLastRequest(binanceSessionFile, time.Now())
+		session.SetLastRequest(sessionFile, time.Now())
 	}
 }
 
@@ -165,7 +196,7 @@ func (self *Binance) baseURL(sandbox bool) string {
 
 	output := self.ExchangeInfo.REST.URI
 
-	if output != binance.BASE_URL_US {
+	if output == binance.BASE_URL {
 		arg := flag.Get("cluster")
 		if arg.Exists {
 			if cluster, err := arg.Int64(); err == nil {
@@ -250,7 +281,7 @@ func (self *Binance) notify(err error, level int64, service model.Notify) {
 			//				}
 			//			}
 			// ---- END ---- svanas 2020-09-12 -----------------------------------------
-			err := service.SendMessage(msg, "Binance - ERROR", model.ONCE_PER_MINUTE)
+			err := notify.SendError(service, err, msg, (self.Name + " - ERROR"))
 			if err != nil {
 				self.error(err)
 			}
@@ -288,21 +319,51 @@ func (self *Binance) GetInfo() *model.ExchangeInfo {
 }
 
 func (self *Binance) GetClient(permission model.Permission, sandbox bool) (interface{}, error) {
+	if sandbox && self.ExchangeInfo.REST.Sandbox == "" {
+		return nil, errors.Errorf("%s does not have a sandbox", self.Name)
+	}
+
 	if permission != model.PRIVATE {
 		return binance.New(self.baseURL(sandbox), "", ""), nil
 	}
 
-	apiKey, apiSecret, err := promptForApiKeys("Binance")
+	apiKey, apiSecret, err := promptForApiKeys(self.Name)
 	if err != nil {
 		return nil, err
 	}
 
-	return binance.New(self.baseURL(sandbox), apiKey, apiSecret), nil
+	client := binance.New(self.baseURL(sandbox), apiKey, apiSecret)
+	if !sandbox {
+		verifyKey(self, client)
+	}
+
+	return client, nil
+}
+
+func (self *Binance) GetKeyPermissions(client interface{}) (*model.KeyPermissions, error) {
+	binanceClient, ok := client.(*binance.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+	restrictions, err := binanceClient.GetApiRestrictions()
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+	return &model.KeyPermissions{
+		Read:     restrictions.EnableReading,
+		Trade:    restrictions.EnableSpotAndMarginTrading,
+		Withdraw: restrictions.EnableWithdrawals,
+		Futures:  restrictions.EnableFutures,
+	}, nil
 }
 
 func (self *Binance) GetMarkets(cached, sandbox bool, blacklist []string) ([]model.Market, error) {
 	var out []model.Market
 
+	if sandbox && self.ExchangeInfo.REST.Sandbox == "" {
+		return nil, errors.Errorf("%s does not have a sandbox", self.Name)
+	}
+
 	precs, err := binance.GetPrecs(binance.New(self.baseURL(sandbox), "", ""), cached)
 
 	if err != nil {
@@ -352,8 +413,11 @@ func (self *Binance) getMarketsEx(cached, sandbox bool, ignore, quotes []string)
 	return out, nil
 }
 
+// how Binance spells its markets
+var binanceSymbol = model.SymbolFormat{}
+
 func (self *Binance) FormatMarket(base, quote string) string {
-	return strings.ToUpper(base + quote)
+	return model.NewMarketSymbol(base, quote).Format(binanceSymbol)
 }
 
 // listens to the open orders, send a notification on newly opened orders.
@@ -380,7 +444,7 @@ func (self *Binance) listen(client *binance.Client, service model.Notify, level
 				side := binanceOrderSide(&order)
 				if side != model.ORDER_SIDE_NONE {
 					if notify.CanSend(level, notify.OPENED) || (level == notify.LEVEL_DEFAULT && side == model.SELL) {
-						if err = service.SendMessage(order, ("Binance - Open " + model.FormatOrderSide(side)), model.ALWAYS); err != nil {
+						if err = service.SendMessage(order, (self.Name + " - " + i18n.Sprintf("Open %s", model.FormatOrderSide(side))), model.ALWAYS); err != nil {
 							self.error(err)
 						}
 					}
@@ -431,7 +495,7 @@ func (self *Binance) sell(
 
 	// look for newly filled orders
 	for _, order := range new {
-		if binanceOrderIndex(old, order.OrderID) == -1 {
+		if binanceOrderIndex(old, order.OrderID) == -1 && processOnce(self, strconv.FormatInt(order.OrderID, 10)) {
 			var data []byte
 			if data, err = binanceOrderToString(&order); err != nil {
 				return new, err
@@ -444,7 +508,7 @@ func (self *Binance) sell(
 				// send notification(s)
 				if notify.CanSend(level, notify.FILLED) {
 					if service != nil {
-						title := fmt.Sprintf("Binance - Done %s", model.FormatOrderSide(side))
+						title := fmt.Sprintf("%s - %s", self.Name, i18n.Sprintf("Done %s", model.FormatOrderSide(side)))
 						if side == model.SELL {
 							if strategy == model.STRATEGY_STOP_LOSS && (order.Type == exchange.OrderTypeStopLoss || order.Type == exchange.OrderTypeStopLossLimit) {
 								title = fmt.Sprintf("%s %s", title, multiplier.Format(stop))
@@ -455,26 +519,39 @@ func (self *Binance) sell(
 						if err = service.SendMessage(order, title, model.ALWAYS); err != nil {
 							self.error(err)
 						}
+						sendChart(self, client, service, strategy, order.Symbol, side, order.GetPrice(), mult, stop, title)
 					}
 					if twitter != nil {
-						notify.Tweet(twitter, fmt.Sprintf("Done %s. %s priced at %s #Binance", model.FormatOrderSide(side), model.TweetMarket(markets, order.Symbol), order.Price))
+						notify.Tweet(twitter, fmt.Sprintf("Done %s. %s priced at %s #%s", model.FormatOrderSide(side), model.TweetMarket(markets, order.Symbol), order.Price, self.Name))
 					}
 				}
 
+				// has a take profit been filled? then add the profit to our journal
+				if side == model.SELL && order.Type != exchange.OrderTypeStopLoss && order.Type != exchange.OrderTypeStopLossLimit {
+					recordTakeProfit(self, client, order.Symbol, order.GetSize()*order.GetPrice(), mult)
+				}
+
 				// has a stop loss been filled? then place a buy order double the order size *** if --dca is included ***
 				if side == model.SELL {
 					if strategy == model.STRATEGY_STOP_LOSS {
 						if order.Type == exchange.OrderTypeStopLoss || order.Type == exchange.OrderTypeStopLossLimit {
+							recordLoss(self, order.Symbol, order.GetSize()*order.GetPrice(), stop, service)
 							if flag.Dca() {
-								var prec int
-								if prec, err = self.GetSizePrec(client, order.Symbol); err == nil {
-									size := 2.2 * order.GetSize()
-									_, _, err = self.Order(client,
-										model.BUY,
-										order.Symbol,
-										precision.Round(size, prec),
-										0, model.MARKET, "",
-									)
+								var (
+									prec int
+									size float64
+								)
+								if size, err = dcaSize(self, client, order.Symbol, order.GetSize()); err == nil && size > 0 {
+									if prec, err = self.GetSizePrec(client, order.Symbol); err == nil {
+										if _, _, err = self.Order(client,
+											model.BUY,
+											order.Symbol,
+											precision.Round(size, prec),
+											0, model.MARKET, "",
+										); err == nil {
+											recordDca(self, client, order.Symbol, size)
+										}
+									}
 								}
 								if err != nil {
 									return new, errors.Append(err, "\t", string(data))
@@ -514,17 +591,17 @@ func (self *Binance) sell(
 						quote string
 					)
 					if base, quote, err = model.ParseMarket(markets, order.Symbol); err == nil {
-						qty := self.GetMaxSize(client, base, quote, hold.HasMarket(order.Symbol), earn.HasMarket(order.Symbol), order.GetSize(), mult)
+						qty := self.GetMaxSize(client, base, quote, hold.HasMarket(order.Symbol), earn.HasMarket(order.Symbol), order.GetSize(), override.Mult(order.Symbol, mult))
 						if qty > 0 {
-							var prec int
-							if prec, err = self.GetPricePrec(client, order.Symbol); err == nil {
+							var tick float64
+							if tick, err = model.GetTickSize(self, client, order.Symbol); err == nil {
 								var ticker float64
 								if ticker, err = self.GetTicker(client, order.Symbol); err == nil {
 									target := func() float64 {
 										if call != nil && call.HasTarget() {
-											return precision.Round(call.ParseTarget(), prec)
+											return precision.RoundTick(call.ParseTarget(), tick)
 										}
-										return pricing.Multiply(bought, mult, prec)
+										return pricing.MultiplyTick(bought, override.Mult(order.Symbol, mult), tick)
 									}()
 									if ticker >= target {
 										_, _, err = self.Order(client,
@@ -557,9 +634,9 @@ func (self *Binance) sell(
 														target,
 														func() float64 {
 															if call != nil && call.HasStop() {
-																return precision.Round(call.ParseStop(), prec)
+																return precision.RoundTick(call.ParseStop(), tick)
 															}
-															return pricing.Multiply(bought, stop, prec)
+															return pricing.MultiplyTick(bought, override.Stop(order.Symbol, stop), tick)
 														}(),
 														strconv.FormatFloat(bought, 'f', -1, 64),
 													); err != nil {
@@ -606,7 +683,7 @@ func (self *Binance) Sell(
 		apiKey    string
 		apiSecret string
 	)
-	if apiKey, apiSecret, err = promptForApiKeys("Binance"); err != nil {
+	if apiKey, apiSecret, err = promptForApiKeys(self.Name); err != nil {
 		return err
 	}
 
@@ -685,6 +762,7 @@ func (self *Binance) Sell(
 		if open, err = self.listen(client, service, level, open); err != nil {
 			self.notify(err, level, service)
 		}
+		beat(self.GetInfo().Name, err)
 	}
 }
 
@@ -697,6 +775,14 @@ func (self *Binance) Order(
 	kind model.OrderType,
 	metadata string,
 ) (oid []byte, raw []byte, err error) {
+	if raw, ok := observeOrder(self, client, side, market, size, price, kind); ok {
+		return nil, raw, nil
+	}
+
+	if err := CheckEquityShare(self, client, side, market, size, price); err != nil {
+		return nil, nil, err
+	}
+
 	binanceClient, ok := client.(*binance.Client)
 	if !ok {
 		return nil, nil, errors.New("invalid argument: client")
@@ -732,7 +818,15 @@ func (self *Binance) Order(
 	return []byte(order.ClientOrderID), out, nil
 }
 
+func (self *Binance) GetStops() model.Stops {
+	return model.Stops{Strategy: true, Stop: true, OCO: true}
+}
+
 func (self *Binance) StopLoss(client interface{}, market string, size float64, price float64, kind model.OrderType, metadata string) ([]byte, error) {
+	if raw, ok := observeStopLoss(self, client, market, size, price); ok {
+		return raw, nil
+	}
+
 	var err error
 
 	binanceClient, ok := client.(*binance.Client)
@@ -750,18 +844,17 @@ func (self *Binance) StopLoss(client interface{}, market string, size float64, p
 	if kind == model.MARKET {
 		service.Type(exchange.OrderTypeStopLoss)
 	} else {
-		var prec int
-		if prec, err = self.GetPricePrec(client, market); err != nil {
+		var (
+			tick float64
+			exit *model.StopExit
+		)
+		if tick, err = model.GetTickSize(self, client, market); err != nil {
 			return nil, err
 		}
-		limit := price
-		for {
-			limit = limit * 0.99
-			if precision.Round(limit, prec) < price {
-				break
-			}
+		if exit, err = model.GetStopExit(); err != nil {
+			return nil, err
 		}
-		service.Type(exchange.OrderTypeStopLossLimit).TimeInForce(exchange.TimeInForceTypeGTC).Price(precision.Round(limit, prec))
+		service.Type(exchange.OrderTypeStopLossLimit).TimeInForce(exchange.TimeInForceTypeGTC).Price(exit.Limit(price, tick))
 	}
 
 	var order *exchange.CreateOrderResponse
@@ -801,7 +894,105 @@ func (self *Binance) StopLoss(client interface{}, market string, size float64, p
 	return out, nil
 }
 
+// the range of a trailing stop, in BIPs (basis points, 100 = 1%)
+const (
+	binanceTrailingDeltaMin = 10
+	binanceTrailingDeltaMax = 2000
+)
+
+func (self *Binance) TrailingStop(client interface{}, market string, size, percent float64, metadata string) ([]byte, error) {
+	if raw, ok := observeStopLoss(self, client, market, size, 0); ok {
+		return raw, nil
+	}
+
+	binanceClient, ok := client.(*binance.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	delta := int64(math.Round(percent * 100))
+	if delta < binanceTrailingDeltaMin || delta > binanceTrailingDeltaMax {
+		return nil, errors.Errorf("Binance trails a stop between %.1f%% and %.0f%%", float64(binanceTrailingDeltaMin)/100, float64(binanceTrailingDeltaMax)/100)
+	}
+
+	order, err := binanceClient.TrailingStop(market, size, delta, 0, self.newClientOrderID(metadata))
+	if err != nil {
+		// -1013 stop loss orders are not supported for this symbol, so we try a stop-limit. the limit price does not
+		// trail, but a sell limit below the trigger fills all the same.
+		if _, ok := isBinanceError(err); !ok {
+			return nil, errors.Wrap(err, 1)
+		}
+		self.warn(err)
+		var (
+			ticker float64
+			tick   float64
+			exit   *model.StopExit
+		)
+		if ticker, err = self.GetTicker(client, market); err != nil {
+			return nil, err
+		}
+		if tick, err = model.GetTickSize(self, client, market); err != nil {
+			return nil, err
+		}
+		if exit, err = model.GetStopExit(); err != nil {
+			return nil, err
+		}
+		if order, err = binanceClient.TrailingStop(market, size, delta, exit.Limit(ticker*(1-percent/100), tick), self.newClientOrderID(metadata)); err != nil {
+			return nil, errors.Wrap(err, 1)
+		}
+	}
+
+	out, err := json.Marshal(order)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	return out, nil
+}
+
+func (self *Binance) StopBuy(client interface{}, market string, size, trigger float64, metadata string) ([]byte, []byte, error) {
+	if raw, ok := observeStopBuy(self, client, market, size, trigger); ok {
+		return nil, raw, nil
+	}
+
+	binanceClient, ok := client.(*binance.Client)
+	if !ok {
+		return nil, nil, errors.New("invalid argument: client")
+	}
+
+	tick, err := model.GetTickSize(self, client, market)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// most symbols do not support stop-market orders, so this is a stop-limit
+	order, err := binanceClient.NewCreateOrderService().
+		Symbol(market).
+		Side(exchange.SideTypeBuy).
+		Type(exchange.OrderTypeStopLossLimit).
+		TimeInForce(exchange.TimeInForceTypeGTC).
+		Quantity(size).
+		StopPrice(trigger).
+		Price(model.StopBuyLimit(trigger, tick)).
+		NewClientOrderID(self.newClientOrderID(metadata)).
+		Do(context.Background())
+	if err != nil {
+		return nil, nil, errors.Wrap(err, 1)
+	}
+
+	var out []byte
+	if out, err = json.Marshal(order); err != nil {
+		return nil, nil, errors.Wrap(err, 1)
+	}
+
+	return []byte(strconv.FormatInt(order.OrderID, 10)), out, nil
+}
+
 func (self *Binance) OCO(client interface{}, market string, size float64, price, stop float64, metadata string) ([]byte, error) {
+	if raw, ok := observeOCO(self, client, market, size, price, stop); ok {
+		return raw, nil
+	}
+
 	binanceClient, ok := client.(*binance.Client)
 	if !ok {
 		return nil, errors.New("invalid argument: client")
@@ -825,25 +1016,29 @@ func (self *Binance) OCO(client interface{}, market string, size float64, price,
 
 	var (
 		err  error
+		tick float64
+		exit *model.StopExit
 		resp *exchange.CreateOCOResponse
 	)
+	if exit, err = model.GetStopExit(); err != nil {
+		return nil, err
+	}
+	if exit.Kind == model.LIMIT {
+		if tick, err = model.GetTickSize(self, client, market); err != nil {
+			return nil, err
+		}
+		svc.StopLimitPrice(exit.Limit(stop, tick)).StopLimitTimeInForce(exchange.TimeInForceTypeGTC)
+	}
+
 	if resp, err = svc.Do(context.Background()); err != nil {
 		_, ok := isBinanceError(err)
 		if ok {
 			// -1013 Stop loss orders are not supported for this symbol
-			if strings.Contains(err.Error(), "loss orders are not supported") {
-				var prec int
-				if prec, err = self.GetPricePrec(client, market); err != nil {
+			if exit.Kind != model.LIMIT && strings.Contains(err.Error(), "loss orders are not supported") {
+				if tick, err = model.GetTickSize(self, client, market); err != nil {
 					return nil, err
 				}
-				lower := stop
-				for {
-					lower = lower * 0.99
-					if precision.Round(lower, prec) < stop {
-						break
-					}
-				}
-				svc.StopLimitPrice(precision.Round(lower, prec)).StopLimitTimeInForce(exchange.TimeInForceTypeGTC)
+				svc.StopLimitPrice(exit.Limit(stop, tick)).StopLimitTimeInForce(exchange.TimeInForceTypeGTC)
 				resp, err = svc.Do(context.Background())
 			}
 		}
@@ -878,11 +1073,14 @@ func (self *Binance) GetClosed(client interface{}, market string) (model.Orders,
 		// get the orders that got filled during the last 24 hours
 		if order.Status == exchange.OrderStatusTypeFilled && time.Since(order.UpdatedAt()).Hours() < 24 {
 			out = append(out, model.Order{
-				Side:      binanceOrderSide(&order),
-				Market:    order.Symbol,
-				Size:      order.GetSize(),
-				Price:     order.GetPrice(),
-				CreatedAt: time.Unix(order.Time/1000, 0),
+				Side:       binanceOrderSide(&order),
+				Status:     model.FILLED,
+				Market:     order.Symbol,
+				Size:       order.GetSize(),
+				Price:      order.GetPrice(),
+				CreatedAt:  time.Unix(order.Time/1000, 0),
+				ClosedAt:   model.TimePtr(order.UpdatedAt()),
+				FilledSize: order.GetSize(),
 			})
 		}
 	}
@@ -906,7 +1104,9 @@ func (self *Binance) GetOpened(client interface{}, market string) (model.Orders,
 	var out model.Orders
 	for _, order := range orders {
 		out = append(out, model.Order{
+			Status:    model.OPEN,
 			Side:      binanceOrderSide(&order),
+			ID:        strconv.FormatInt(order.OrderID, 10),
 			Market:    order.Symbol,
 			Size:      order.GetSize(),
 			Price:     order.GetPrice(),
@@ -998,6 +1198,27 @@ func (self *Binance) GetTicker(client interface{}, market string) (float64, erro
 	return out, nil
 }
 
+func (self *Binance) GetTickers(client interface{}) (map[string]float64, error) {
+	binanceClient, ok := client.(*binance.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	prices, err := binanceClient.Prices()
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	out := make(map[string]float64)
+	for _, price := range prices {
+		if value, err := strconv.ParseFloat(price.Price, 64); err == nil {
+			out[price.Symbol] = value
+		}
+	}
+
+	return out, nil
+}
+
 func (self *Binance) Get24h(client interface{}, market string) (*model.Stats, error) {
 	binanceClient, ok := client.(*binance.Client)
 	if !ok {
@@ -1019,10 +1240,18 @@ func (self *Binance) Get24h(client interface{}, market string) (*model.Stats, er
 		return nil, errors.Wrap(err, 1)
 	}
 
+	open, _ := strconv.ParseFloat(stats.OpenPrice, 64)
+	last, _ := strconv.ParseFloat(stats.LastPrice, 64)
+	change, _ := strconv.ParseFloat(stats.PriceChangePercent, 64)
+
 	return &model.Stats{
 		Market: market,
 		High:   high,
 		Low:    low,
+		Open:   open,
+		Last:   last,
+		Change: change,
+		Trades: stats.Count,
 		BtcVolume: func() float64 {
 			symbol, err := binance.GetSymbol(binanceClient, market)
 			if err == nil {
@@ -1047,6 +1276,95 @@ func (self *Binance) Get24h(client interface{}, market string) (*model.Stats, er
 	}, nil
 }
 
+func (self *Binance) Get24hAll(client interface{}) (map[string]model.Stats, error) {
+	binanceClient, ok := client.(*binance.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	tickers, err := binanceClient.Tickers()
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	out := make(map[string]model.Stats)
+	volume := make(map[string]float64) // in quote currency
+	for _, ticker := range tickers {
+		high, _ := strconv.ParseFloat(ticker.HighPrice, 64)
+		low, _ := strconv.ParseFloat(ticker.LowPrice, 64)
+		open, _ := strconv.ParseFloat(ticker.OpenPrice, 64)
+		last, _ := strconv.ParseFloat(ticker.LastPrice, 64)
+		change, _ := strconv.ParseFloat(ticker.PriceChangePercent, 64)
+		out[ticker.Symbol] = model.Stats{
+			Market: ticker.Symbol,
+			High:   high,
+			Low:    low,
+			Open:   open,
+			Last:   last,
+			Change: change,
+			Trades: ticker.Count,
+		}
+		volume[ticker.Symbol], _ = strconv.ParseFloat(ticker.QuoteVolume, 64)
+	}
+
+	if err = setBtcVolume(self, out, volume); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+var binanceIntervals = map[time.Duration]string{
+	time.Minute:        "1m",
+	3 * time.Minute:    "3m",
+	5 * time.Minute:    "5m",
+	15 * time.Minute:   "15m",
+	30 * time.Minute:   "30m",
+	time.Hour:          "1h",
+	2 * time.Hour:      "2h",
+	4 * time.Hour:      "4h",
+	6 * time.Hour:      "6h",
+	8 * time.Hour:      "8h",
+	12 * time.Hour:     "12h",
+	24 * time.Hour:     "1d",
+	3 * 24 * time.Hour: "3d",
+	7 * 24 * time.Hour: "1w",
+}
+
+func (self *Binance) GetCandles(client interface{}, market string, interval time.Duration, start, end time.Time) (model.Candles, error) {
+	binanceClient, ok := client.(*binance.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	kind, ok := binanceIntervals[interval]
+	if !ok {
+		return nil, errors.Errorf("interval %s is not supported", model.FormatInterval(interval))
+	}
+
+	// Binance's end time is inclusive
+	klines, err := binanceClient.Klines(market, kind, start.UnixNano()/int64(time.Millisecond), end.UnixNano()/int64(time.Millisecond)-1)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	var out model.Candles
+	for _, kline := range klines {
+		candle := model.Candle{Time: time.Unix(0, kline.OpenTime*int64(time.Millisecond)).UTC()}
+		if candle.Time.Before(start) || !candle.Time.Before(end) {
+			continue
+		}
+		candle.Open, _ = strconv.ParseFloat(kline.Open, 64)
+		candle.High, _ = strconv.ParseFloat(kline.High, 64)
+		candle.Low, _ = strconv.ParseFloat(kline.Low, 64)
+		candle.Close, _ = strconv.ParseFloat(kline.Close, 64)
+		candle.Volume, _ = strconv.ParseFloat(kline.Volume, 64)
+		out = append(out, candle)
+	}
+
+	return out, nil
+}
+
 func (self *Binance) GetPricePrec(client interface{}, market string) (int, error) {
 	binanceClient, ok := client.(*binance.Client)
 	if !ok {
@@ -1095,6 +1413,10 @@ func (self *Binance) GetMaxSize(client interface{}, base, quote string, hold, ea
 }
 
 func (self *Binance) Cancel(client interface{}, market string, side model.OrderSide) error {
+	if observeCancel(self, market, side) {
+		return nil
+	}
+
 	var err error
 
 	binanceClient, ok := client.(*binance.Client)
@@ -1122,7 +1444,118 @@ func (self *Binance) Cancel(client interface{}, market string, side model.OrderS
 	return nil
 }
 
+func (self *Binance) CancelOrder(client interface{}, market, id string) error {
+	if observeCancelOrder(self, market, id) {
+		return nil
+	}
+
+	binanceClient, ok := client.(*binance.Client)
+	if !ok {
+		return errors.New("invalid argument: client")
+	}
+
+	orderID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return errors.Wrap(err, 1)
+	}
+
+	if err = binanceClient.CancelOrder(market, orderID); err != nil {
+		return errors.Wrap(err, 1)
+	}
+
+	return nil
+}
+
+func (self *Binance) GetOrder(client interface{}, market, id string) (*model.Order, error) {
+	binanceClient, ok := client.(*binance.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	orderID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	order, err := binanceClient.GetOrder(market, orderID)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	out := &model.Order{
+		ID:         id,
+		Side:       binanceOrderSide(order),
+		Market:     order.Symbol,
+		Size:       order.GetSize(),
+		Price:      order.GetPrice(),
+		CreatedAt:  order.CreatedAt(),
+		FilledSize: order.GetExecutedSize(),
+	}
+	switch order.Status {
+	case exchange.OrderStatusTypeNew, exchange.OrderStatusTypePartiallyFilled:
+		out.Status = model.OPEN
+	case exchange.OrderStatusTypeFilled:
+		out.Status = model.FILLED
+		out.ClosedAt = model.TimePtr(order.UpdatedAt())
+	default:
+		out.Status = model.CANCELLED
+		out.ClosedAt = model.TimePtr(order.UpdatedAt())
+	}
+
+	return out, nil
+}
+
+func (self *Binance) GetFees(client interface{}, market string) (*model.Fees, error) {
+	binanceClient, ok := client.(*binance.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	fees, err := binanceClient.GetFees()
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	return &model.Fees{
+		Maker: fees.Maker,
+		Taker: fees.Taker,
+	}, nil
+}
+
+// minSize returns the size, bumped to respect the MIN_NOTIONAL filter
+func (self *Binance) minSize(client interface{}, market string, size, price float64) (float64, error) {
+	binanceClient, ok := client.(*binance.Client)
+	if !ok {
+		return size, errors.New("invalid argument: client")
+	}
+	// --- BEGIN --- svanas 2018-11-30 --- <APIError> code=-1013, msg=Filter failure: MIN_NOTIONAL.
+	min, err := self.getMinTrade(binanceClient, market, true)
+	if err != nil {
+		return size, err
+	}
+	if min > 0 {
+		if price == 0 {
+			if price, err = self.GetTicker(client, market); err != nil {
+				return size, err
+			}
+		}
+		if (size * price) < min {
+			prec, err := self.GetSizePrec(client, market)
+			if err != nil {
+				return size, err
+			}
+			size = precision.Ceil((min / price), prec)
+		}
+	}
+	// ---- END ---- svanas 2018-11-30 ------------------------------------------------------------
+	return size, nil
+}
+
 func (self *Binance) Buy(client interface{}, cancel bool, market string, calls model.Calls, deviation float64, kind model.OrderType) error {
+	if observeBuy(self, client, cancel, market, calls, kind) {
+		return nil
+	}
+
 	var err error
 
 	binanceClient, ok := client.(*binance.Client)
@@ -1157,32 +1590,15 @@ func (self *Binance) Buy(client interface{}, cancel bool, market string, calls m
 		if !call.Skip {
 			var (
 				oid   []byte
-				min   float64
 				qty   float64 = call.Size
 				limit float64 = call.Price
 			)
 			if deviation != 1.0 {
 				kind, limit = call.Deviate(self, client, kind, deviation)
 			}
-			// --- BEGIN --- svanas 2018-11-30 --- <APIError> code=-1013, msg=Filter failure: MIN_NOTIONAL.
-			if min, err = self.getMinTrade(binanceClient, market, true); err != nil {
+			if qty, err = self.minSize(client, market, qty, limit); err != nil {
 				return err
 			}
-			if min > 0 {
-				if limit == 0 {
-					if limit, err = self.GetTicker(client, market); err != nil {
-						return err
-					}
-				}
-				if (qty * limit) < min {
-					var prec int
-					if prec, err = self.GetSizePrec(client, market); err != nil {
-						return err
-					}
-					qty = precision.Ceil((min / limit), prec)
-				}
-			}
-			// ---- END ---- svanas 2018-11-30 ------------------------------------------------------------
 			oid, _, err = self.Order(client,
 				model.BUY,
 				market,
@@ -1220,6 +1636,10 @@ func (self *Binance) Buy(client interface{}, cancel bool, market string, calls m
 }
 
 func (self *Binance) IsLeveragedToken(name string) bool {
+	// Binance.US does not list leveraged tokens
+	if binance.Domain(self.baseURL(flag.Sandbox())) == binance.Domain(binance.BASE_URL_US) {
+		return false
+	}
 	return (len(name) > 2 && strings.HasSuffix(strings.ToUpper(name), "UP")) ||
 		(len(name) > 4 && strings.HasSuffix(strings.ToUpper(name), "DOWN")) ||
 		(len(name) > 4 && strings.HasSuffix(strings.ToUpper(name), "BEAR")) ||
@@ -1256,12 +1676,10 @@ func newBinanceUS() model.Exchange {
 			Name: "BinanceUS",
 			URL:  "https://www.binance.us/",
 			REST: model.Endpoint{
-				URI:     binance.BASE_URL_US,
-				Sandbox: "https://testnet.binance.vision",
+				URI: binance.BASE_URL_US,
 			},
 			WebSocket: model.Endpoint{
-				URI:     "wss://stream.binance.us:9443",
-				Sandbox: "wss://testnet.binance.vision",
+				URI: "wss://stream.binance.us:9443",
 			},
 			Country: "United States",
 		},
@@ -0,0 +1,1185 @@
+//lint:file-ignore ST1006 receiver name should be a reflection of its identity; don't use generic names such as "this" or "self"
+package exchanges
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/svanas/nefertiti/aggregation"
+	exchange "github.com/svanas/nefertiti/bitmex"
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/i18n"
+	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/multiplier"
+	"github.com/svanas/nefertiti/notify"
+	"github.com/svanas/nefertiti/override"
+	"github.com/svanas/nefertiti/precision"
+	"github.com/svanas/nefertiti/pricing"
+	"github.com/svanas/nefertiti/session"
+)
+
+var (
+	bitmexMutex *session.Mutex
+)
+
+const (
+	bitmexSessionFile = "bitmex.time"
+	bitmexSessionLock = "bitmex.lock"
+)
+
+func init() {
+	exchange.BeforeRequest = func(method, path string) error {
+		var err error
+
+		if bitmexMutex == nil {
+			if bitmexMutex, err = session.NewMutex(bitmexSessionLock); err != nil {
+				return err
+			}
+		}
+
+		if err = bitmexMutex.Lock(); err != nil {
+			return err
+		}
+
+		var lastRequest *time.Time
+		if lastRequest, err = session.GetLastRequest(bitmexSessionFile); err != nil {
+			return err
+		}
+
+		if lastRequest != nil {
+			elapsed := time.Since(*lastRequest)
+			if elapsed.Seconds() < (float64(1) / exchange.RequestsPerSecond) {
+				sleep := time.Duration((float64(time.Second) / exchange.RequestsPerSecond) - float64(elapsed))
+				if flag.Debug() {
+					log.Printf("[DEBUG] sleeping %f seconds\n", sleep.Seconds())
+				}
+				time.Sleep(sleep)
+			}
+		}
+
+		if flag.Debug() {
+			log.Printf("[DEBUG] %s %s\n", method, path)
+		}
+
+		return nil
+	}
+	exchange.AfterRequest = func() {
+		defer func() {
+			bitmexMutex.Unlock()
+		}()
+		session.SetLastRequest(bitmexSessionFile, time.Now())
+	}
+}
+
+type BitMEX struct {
+	*model.ExchangeInfo
+	instruments []exchange.Instrument
+}
+
+func (self *BitMEX) error(err error, level int64, service model.Notify) {
+	pc, file, line, _ := runtime.Caller(1)
+	prefix := errors.FormatCaller(pc, file, line)
+
+	msg := fmt.Sprintf("%s %v", prefix, err)
+	_, ok := err.(*errors.Error)
+	if ok && flag.Debug() {
+		log.Printf("[ERROR] %s", err.(*errors.Error).ErrorStack(prefix, ""))
+	} else {
+		log.Printf("[ERROR] %s", msg)
+	}
+
+	if service != nil {
+		if notify.CanSend(level, notify.ERROR) {
+			err := notify.SendError(service, err, msg, "BitMEX - ERROR")
+			if err != nil {
+				log.Printf("[ERROR] %v", err)
+			}
+		}
+	}
+}
+
+func (self *BitMEX) indexByOrderID(orders []exchange.Order, id string) int {
+	for i, o := range orders {
+		if o.OrderID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (self *BitMEX) getBaseURL(sandbox bool) string {
+	if sandbox {
+		return self.ExchangeInfo.REST.Sandbox
+	}
+	return self.ExchangeInfo.REST.URI
+}
+
+// returns the perpetual swaps, because we don't trade the futures that expire
+func (self *BitMEX) getInstruments(client *exchange.Client, cached bool) ([]exchange.Instrument, error) {
+	if self.instruments == nil || !cached {
+		instruments, err := client.Instruments()
+		if err != nil {
+			return nil, errors.Wrap(err, 1)
+		}
+		self.instruments = nil
+		for _, instrument := range instruments {
+			if instrument.IsPerpetual() {
+				self.instruments = append(self.instruments, instrument)
+			}
+		}
+	}
+	return self.instruments, nil
+}
+
+func (self *BitMEX) getInstrument(client *exchange.Client, symbol string, cached bool) (*exchange.Instrument, error) {
+	instruments, err := self.getInstruments(client, cached)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, instrument := range instruments {
+		if instrument.Symbol == symbol {
+			return &instrument, nil
+		}
+	}
+
+	return nil, errors.Errorf("instrument %v does not exist", symbol)
+}
+
+// applies --isolated=[Y|N] and (when isolated) --leverage=X to the position
+func (self *BitMEX) applyMargin(client *exchange.Client, symbol string) error {
+	isolated, ok := flag.Isolated()
+	if !ok {
+		return nil
+	}
+
+	if err := self.SetIsolated(client, symbol, isolated); err != nil {
+		return err
+	}
+
+	if isolated {
+		leverage, err := flag.Leverage()
+		if err != nil {
+			return err
+		}
+		if _, err = client.Leverage(symbol, leverage); err != nil {
+			return errors.Wrap(err, 1)
+		}
+	}
+
+	return nil
+}
+
+func (self *BitMEX) GetInfo() *model.ExchangeInfo {
+	return self.ExchangeInfo
+}
+
+func (self *BitMEX) GetClient(permission model.Permission, sandbox bool) (interface{}, error) {
+	if permission == model.PUBLIC {
+		return exchange.New(self.getBaseURL(sandbox), "", ""), nil
+	}
+
+	apiKey, apiSecret, err := promptForApiKeys("BitMEX")
+	if err != nil {
+		return nil, err
+	}
+
+	return exchange.New(self.getBaseURL(sandbox), apiKey, apiSecret), nil
+}
+
+func (self *BitMEX) GetMarkets(cached, sandbox bool, blacklist []string) ([]model.Market, error) {
+	var out []model.Market
+
+	instruments, err := self.getInstruments(exchange.New(self.getBaseURL(sandbox), "", ""), cached)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, instrument := range instruments {
+		if func() bool {
+			for _, ignore := range blacklist {
+				if strings.EqualFold(instrument.Symbol, ignore) {
+					return false
+				}
+			}
+			return true
+		}() {
+			out = append(out, model.Market{
+				Name:  instrument.Symbol,
+				Base:  instrument.Base(),
+				Quote: instrument.Quote(),
+			})
+		}
+	}
+
+	return out, nil
+}
+
+func (self *BitMEX) FormatMarket(base, quote string) string {
+	return exchange.FormatSymbol(base, quote)
+}
+
+// listen to the opened orders, look for cancelled orders, send a notification.
+func (self *BitMEX) listen(
+	client *exchange.Client,
+	service model.Notify,
+	level int64,
+	old []exchange.Order,
+	filled []exchange.Order,
+) ([]exchange.Order, error) {
+	instruments, err := self.getInstruments(client, true)
+	if err != nil {
+		return old, err
+	}
+
+	// get my opened orders
+	var new []exchange.Order
+	for _, instrument := range instruments {
+		orders, err := client.OpenOrders(instrument.Symbol)
+		if err != nil {
+			return old, errors.Wrap(err, 1)
+		}
+		new = append(new, orders...)
+	}
+
+	// look for cancelled orders
+	for _, order := range old {
+		if self.indexByOrderID(new, order.OrderID) == -1 {
+			// if this order has NOT been FILLED, then it has been cancelled.
+			if self.indexByOrderID(filled, order.OrderID) == -1 {
+				data, err := json.Marshal(order)
+				if err != nil {
+					return new, errors.Wrap(err, 1)
+				}
+
+				log.Println("[CANCELLED] " + string(data))
+
+				if service != nil {
+					if notify.CanSend(level, notify.CANCELLED) {
+						err := service.SendMessage(order, fmt.Sprintf("BitMEX - %s", i18n.Sprintf("Done %s (Reason: Cancelled)", order.Side)), model.ALWAYS)
+						if err != nil {
+							log.Printf("[ERROR] %v", err)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	// look for newly opened orders
+	for _, order := range new {
+		if self.indexByOrderID(old, order.OrderID) == -1 {
+			data, err := json.Marshal(order)
+			if err != nil {
+				return new, errors.Wrap(err, 1)
+			}
+
+			log.Println("[OPEN] " + string(data))
+
+			if service != nil {
+				if notify.CanSend(level, notify.OPENED) || (level == notify.LEVEL_DEFAULT && order.Side == exchange.SideSell) {
+					err := service.SendMessage(order, fmt.Sprintf("BitMEX - %s", i18n.Sprintf("Open %s", order.Side)), model.ALWAYS)
+					if err != nil {
+						log.Printf("[ERROR] %v", err)
+					}
+				}
+			}
+		}
+	}
+
+	return new, nil
+}
+
+// listen to the filled orders, look for newly filled orders, automatically place new reduce-only exits.
+func (self *BitMEX) sell(
+	client *exchange.Client,
+	strategy model.Strategy,
+	mult multiplier.Mult,
+	stop multiplier.Mult,
+	hold model.Markets,
+	service model.Notify,
+	level int64,
+	old []exchange.Order,
+) ([]exchange.Order, error) {
+	instruments, err := self.getInstruments(client, true)
+	if err != nil {
+		return old, err
+	}
+
+	// get my filled orders
+	var filled []exchange.Order
+	for _, instrument := range instruments {
+		orders, err := client.FilledOrders(instrument.Symbol)
+		if err != nil {
+			return old, errors.Wrap(err, 1)
+		}
+		filled = append(filled, orders...)
+	}
+
+	// make a list of newly filled orders
+	var new []exchange.Order
+	for _, order := range filled {
+		if self.indexByOrderID(old, order.OrderID) == -1 && processOnce(self, order.OrderID) {
+			new = append(new, order)
+		}
+	}
+
+	// send notification(s)
+	for _, order := range new {
+		data, err := json.Marshal(order)
+		if err != nil {
+			self.error(err, level, service)
+		} else {
+			log.Println("[FILLED] " + string(data))
+			if notify.CanSend(level, notify.FILLED) && service != nil {
+				err := service.SendMessage(order, fmt.Sprintf("BitMEX - %s", i18n.Sprintf("Done %s (Reason: Filled)", order.Side)), model.ALWAYS)
+				if err != nil {
+					log.Printf("[ERROR] %v", err)
+				}
+			}
+		}
+		// has a stop loss been filled? then add the notional value to our daily losses
+		if strategy == model.STRATEGY_STOP_LOSS && order.Side == exchange.SideSell && order.IsStop() {
+			if instrument, err := self.getInstrument(client, order.Symbol, true); err == nil {
+				recordLoss(self, order.Symbol, instrument.FromContracts(order.CumQty, order.AvgPx)*order.AvgPx, stop, service)
+			}
+		}
+		// has a take profit been filled? then add the profit to our journal
+		if order.Side == exchange.SideSell && !order.IsStop() {
+			if instrument, err := self.getInstrument(client, order.Symbol, true); err == nil {
+				recordTakeProfit(self, client, order.Symbol, instrument.FromContracts(order.CumQty, order.AvgPx)*order.AvgPx, mult)
+			}
+		}
+	}
+
+	// has a buy order been filled? then place a reduce-only exit
+	for _, order := range new {
+		if order.Side != exchange.SideBuy || order.ReduceOnly() || hold.HasMarket(order.Symbol) {
+			continue
+		}
+
+		instrument, err := self.getInstrument(client, order.Symbol, true)
+		if err == nil {
+			bought := order.ExecutedAt()
+			if strategy == model.STRATEGY_STOP_LOSS {
+				trigger := pricing.MultiplyTick(bought, override.Stop(order.Symbol, stop), instrument.TickSize)
+				if _, ok := observeStopLoss(self, client, order.Symbol, order.CumQty, trigger); !ok {
+					_, err = client.Order(&exchange.NewOrder{
+						Symbol:   order.Symbol,
+						Side:     exchange.SideSell,
+						OrderQty: order.CumQty,
+						StopPx:   trigger,
+						OrdType:  exchange.OrderTypeStop,
+						ExecInst: exchange.EXEC_INST_REDUCE_ONLY + "," + exchange.EXEC_INST_LAST_PRICE,
+					})
+				}
+			} else {
+				price := pricing.MultiplyTick(bought, override.Mult(order.Symbol, mult), instrument.TickSize)
+				if _, ok := observeOrder(self, client, model.SELL, order.Symbol, order.CumQty, price, model.LIMIT); !ok {
+					_, err = client.Order(&exchange.NewOrder{
+						Symbol:   order.Symbol,
+						Side:     exchange.SideSell,
+						OrderQty: order.CumQty,
+						Price:    price,
+						OrdType:  exchange.OrderTypeLimit,
+						ExecInst: exchange.EXEC_INST_REDUCE_ONLY,
+					})
+				}
+			}
+		}
+
+		if err != nil {
+			data, _ := json.Marshal(order)
+			if data == nil {
+				self.error(err, level, service)
+			} else {
+				self.error(errors.Append(err, "\t", string(data)), level, service)
+			}
+		}
+	}
+
+	return filled, nil
+}
+
+// position management: once a position has been closed, cancel the reduce-only orders that are left behind.
+func (self *BitMEX) cleanup(client *exchange.Client, opened []exchange.Order) error {
+	positions := make(map[string]*exchange.Position)
+	for _, order := range opened {
+		if !order.ReduceOnly() {
+			continue
+		}
+		position, ok := positions[order.Symbol]
+		if !ok {
+			var err error
+			if position, err = client.Position(order.Symbol); err != nil {
+				return errors.Wrap(err, 1)
+			}
+			positions[order.Symbol] = position
+		}
+		if position.CurrentQty == 0 {
+			if observeCancel(self, order.Symbol, model.SELL) {
+				continue
+			}
+			if err := client.CancelOrder(order.OrderID); err != nil {
+				return errors.Wrap(err, 1)
+			}
+		}
+	}
+	return nil
+}
+
+func (self *BitMEX) Sell(
+	strategy model.Strategy,
+	hold, earn model.Markets,
+	sandbox, tweet, debug bool,
+	success model.OnSuccess,
+) error {
+	if strategy == model.STRATEGY_STANDARD || strategy == model.STRATEGY_STOP_LOSS {
+		// we are OK
+	} else {
+		return errors.New("strategy not implemented")
+	}
+
+	apiKey, apiSecret, err := promptForApiKeys("BitMEX")
+	if err != nil {
+		return err
+	}
+
+	service, err := notify.New().Init(flag.Interactive(), true)
+	if err != nil {
+		return err
+	}
+
+	client := exchange.New(self.getBaseURL(sandbox), apiKey, apiSecret)
+
+	instruments, err := self.getInstruments(client, true)
+	if err != nil {
+		return err
+	}
+
+	var (
+		filled []exchange.Order
+		opened []exchange.Order
+	)
+
+	// get my filled orders
+	for _, instrument := range instruments {
+		orders, err := client.FilledOrders(instrument.Symbol)
+		if err != nil {
+			return errors.Wrap(err, 1)
+		}
+		filled = append(filled, orders...)
+	}
+
+	// get my opened orders
+	for _, instrument := range instruments {
+		orders, err := client.OpenOrders(instrument.Symbol)
+		if err != nil {
+			return errors.Wrap(err, 1)
+		}
+		opened = append(opened, orders...)
+	}
+
+	if err = success(service); err != nil {
+		return err
+	}
+
+	for {
+		// read the dynamic settings
+		var (
+			level int64 = notify.LEVEL_DEFAULT
+			mult  multiplier.Mult
+			stop  multiplier.Mult
+		)
+		if level, err = notify.Level(); err != nil {
+			self.error(err, level, service)
+		} else if mult, err = multiplier.Get(multiplier.FIVE_PERCENT); err != nil {
+			self.error(err, level, service)
+		} else if stop, err = multiplier.Stop(); err != nil {
+			self.error(err, level, service)
+		} else
+		// listen to the filled orders, look for newly filled orders, automatically place new reduce-only exits.
+		if filled, err = self.sell(client, strategy, mult, stop, hold, service, level, filled); err != nil {
+			self.error(err, level, service)
+		} else
+		// listen to the opened orders, look for cancelled orders, send a notification.
+		if opened, err = self.listen(client, service, level, opened, filled); err != nil {
+			self.error(err, level, service)
+		} else
+		// cancel the reduce-only orders of the positions that have been closed.
+		if err = self.cleanup(client, opened); err != nil {
+			self.error(err, level, service)
+		} else
+		// listen to the opened orders, follow up on the stop loss strategy
+		if strategy == model.STRATEGY_STOP_LOSS {
+			for _, order := range opened {
+				// enumerate over the stop-market exits
+				if order.IsStop() && order.ReduceOnly() {
+					var ticker float64
+					if ticker, err = self.GetTicker(client, order.Symbol); err == nil {
+						var tick float64
+						if tick, err = self.GetTickSize(client, order.Symbol); err == nil {
+							bought := order.StopPx / float64(override.Stop(order.Symbol, stop))
+							if ticker >= pricing.MultiplyTick(bought, override.Mult(order.Symbol, mult), tick) {
+								if _, ok := observeOrder(self, client, model.SELL, order.Symbol, order.OrderQty, 0, model.MARKET); !ok {
+									if err = client.CancelOrder(order.OrderID); err == nil {
+										_, err = client.Order(&exchange.NewOrder{
+											Symbol:   order.Symbol,
+											Side:     exchange.SideSell,
+											OrderQty: order.OrderQty,
+											OrdType:  exchange.OrderTypeMarket,
+											ExecInst: exchange.EXEC_INST_REDUCE_ONLY,
+										})
+									}
+								}
+							}
+						}
+					}
+					if err != nil {
+						data, _ := json.Marshal(order)
+						if data == nil {
+							self.error(err, level, service)
+						} else {
+							self.error(errors.Append(err, "\t", string(data)), level, service)
+						}
+					}
+				}
+			}
+		}
+		beat(self.GetInfo().Name, err)
+	}
+}
+
+func (self *BitMEX) Order(
+	client interface{},
+	side model.OrderSide,
+	market string,
+	size float64,
+	price float64,
+	kind model.OrderType,
+	metadata string,
+) (oid []byte, raw []byte, err error) {
+	if raw, ok := observeOrder(self, client, side, market, size, price, kind); ok {
+		return nil, raw, nil
+	}
+
+	if err := CheckEquityShare(self, client, side, market, size, price); err != nil {
+		return nil, nil, err
+	}
+
+	bitmexClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, nil, errors.New("invalid argument: client")
+	}
+
+	instrument, err := self.getInstrument(bitmexClient, market, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = self.applyMargin(bitmexClient, market); err != nil {
+		return nil, nil, err
+	}
+
+	if price == 0 {
+		if price, err = self.GetTicker(client, market); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	order := &exchange.NewOrder{
+		Symbol: market,
+		Side: func() exchange.Side {
+			if side == model.BUY {
+				return exchange.SideBuy
+			}
+			return exchange.SideSell
+		}(),
+		OrderQty: instrument.ToContracts(size, price),
+		OrdType:  exchange.OrderTypeMarket,
+		Text:     metadata,
+	}
+	if kind == model.LIMIT {
+		order.OrdType = exchange.OrderTypeLimit
+		order.Price = instrument.RoundPrice(price)
+	}
+
+	var placed *exchange.Order
+	if placed, err = bitmexClient.Order(order); err != nil {
+		return nil, nil, errors.Wrap(err, 1)
+	}
+
+	if raw, err = json.Marshal(placed); err != nil {
+		return nil, nil, errors.Wrap(err, 1)
+	}
+
+	return []byte(placed.OrderID), raw, nil
+}
+
+// places a reduce-only stop-market exit that closes (part of) a long position.
+func (self *BitMEX) GetStops() model.Stops {
+	return model.Stops{Strategy: true, Stop: true}
+}
+
+func (self *BitMEX) StopLoss(client interface{}, market string, size float64, price float64, kind model.OrderType, metadata string) ([]byte, error) {
+	if raw, ok := observeStopLoss(self, client, market, size, price); ok {
+		return raw, nil
+	}
+
+	bitmexClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	instrument, err := self.getInstrument(bitmexClient, market, true)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := bitmexClient.Order(&exchange.NewOrder{
+		Symbol:   market,
+		Side:     exchange.SideSell,
+		OrderQty: instrument.ToContracts(size, price),
+		StopPx:   instrument.RoundPrice(price),
+		OrdType:  exchange.OrderTypeStop,
+		ExecInst: exchange.EXEC_INST_REDUCE_ONLY + "," + exchange.EXEC_INST_LAST_PRICE,
+		Text:     metadata,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	out, err := json.Marshal(order)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	return out, nil
+}
+
+func (self *BitMEX) OCO(client interface{}, market string, size float64, price, stop float64, metadata string) ([]byte, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (self *BitMEX) GetClosed(client interface{}, market string) (model.Orders, error) {
+	bitmexClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	instrument, err := self.getInstrument(bitmexClient, market, true)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := bitmexClient.FilledOrders(market)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	var output model.Orders
+	for _, order := range orders {
+		output = append(output, model.Order{
+			Side: func() model.OrderSide {
+				if order.Side == exchange.SideSell {
+					return model.SELL
+				}
+				return model.BUY
+			}(),
+			Status:     model.FILLED,
+			Market:     market,
+			Size:       instrument.FromContracts(order.OrderQty, order.ExecutedAt()),
+			Price:      order.ExecutedAt(),
+			CreatedAt:  order.Timestamp,
+			ClosedAt:   model.TimePtr(order.TransactTime),
+			FilledSize: instrument.FromContracts(order.CumQty, order.ExecutedAt()),
+		})
+	}
+
+	return output, nil
+}
+
+func (self *BitMEX) GetOpened(client interface{}, market string) (model.Orders, error) {
+	bitmexClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	instrument, err := self.getInstrument(bitmexClient, market, true)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := bitmexClient.OpenOrders(market)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	var output model.Orders
+	for _, order := range orders {
+		price := order.Price
+		if order.IsStop() {
+			price = order.StopPx
+		}
+		output = append(output, model.Order{
+			Status: model.OPEN,
+			Side: func() model.OrderSide {
+				if order.Side == exchange.SideSell {
+					return model.SELL
+				}
+				return model.BUY
+			}(),
+			ID:        order.OrderID,
+			Market:    market,
+			Size:      instrument.FromContracts(order.OrderQty, price),
+			Price:     price,
+			CreatedAt: order.Timestamp,
+		})
+	}
+
+	return output, nil
+}
+
+func (self *BitMEX) GetBook(client interface{}, market string, side model.BookSide) (interface{}, error) {
+	bitmexClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	book, err := bitmexClient.OrderBook(market)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	if side == model.BOOK_SIDE_ASKS {
+		return book.Side(exchange.SideSell), nil
+	}
+	return book.Side(exchange.SideBuy), nil
+}
+
+func (self *BitMEX) Aggregate(client, book interface{}, market string, agg float64) (model.Book, error) {
+	bitmexClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	bids, ok := book.([]exchange.BookEntry)
+	if !ok {
+		return nil, errors.New("invalid argument: book")
+	}
+
+	instrument, err := self.getInstrument(bitmexClient, market, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var out model.Book
+	for _, e := range bids {
+		price := instrument.RoundPrice(aggregation.Round(e.Price, agg))
+		size := instrument.FromContracts(e.Size, e.Price)
+		entry := out.EntryByPrice(price)
+		if entry != nil {
+			entry.Size = entry.Size + size
+		} else {
+			entry = &model.Buy{
+				Market: market,
+				Price:  price,
+				Size:   size,
+			}
+			out = append(out, *entry)
+		}
+	}
+
+	return out, nil
+}
+
+func (self *BitMEX) GetTicker(client interface{}, market string) (float64, error) {
+	bitmexClient, ok := client.(*exchange.Client)
+	if !ok {
+		return 0, errors.New("invalid argument: client")
+	}
+
+	instrument, err := self.getInstrument(bitmexClient, market, false)
+	if err != nil {
+		return 0, err
+	}
+
+	return instrument.LastPrice, nil
+}
+
+func (self *BitMEX) GetTickers(client interface{}) (map[string]float64, error) {
+	return getTickers(self, client)
+}
+
+func (self *BitMEX) Get24h(client interface{}, market string) (*model.Stats, error) {
+	bitmexClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	instrument, err := self.getInstrument(bitmexClient, market, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Stats{
+		Market: market,
+		High:   instrument.HighPrice,
+		Low:    instrument.LowPrice,
+		Open:   instrument.PrevPrice24h,
+		Last:   instrument.LastPrice,
+		Change: model.PercentChange(instrument.PrevPrice24h, instrument.LastPrice),
+		// the turnover is in satoshis
+		BtcVolume: instrument.Turnover24h / 1e8,
+	}, nil
+}
+
+func (self *BitMEX) Get24hAll(client interface{}) (map[string]model.Stats, error) {
+	return get24hAll(self, client)
+}
+
+func (self *BitMEX) GetPricePrec(client interface{}, market string) (int, error) {
+	bitmexClient, ok := client.(*exchange.Client)
+	if !ok {
+		return 8, errors.New("invalid argument: client")
+	}
+
+	instrument, err := self.getInstrument(bitmexClient, market, true)
+	if err != nil {
+		return 8, err
+	}
+
+	return precision.Parse(strconv.FormatFloat(instrument.TickSize, 'f', -1, 64), 8), nil
+}
+
+func (self *BitMEX) GetTickSize(client interface{}, market string) (float64, error) {
+	bitmexClient, ok := client.(*exchange.Client)
+	if !ok {
+		return 0, errors.New("invalid argument: client")
+	}
+
+	instrument, err := self.getInstrument(bitmexClient, market, true)
+	if err != nil {
+		return 0, err
+	}
+
+	return instrument.TickSize, nil
+}
+
+// sizes are in the underlying currency. they get converted into contracts when we place an order.
+func (self *BitMEX) GetSizePrec(client interface{}, market string) (int, error) {
+	return 8, nil
+}
+
+func (self *BitMEX) GetMaxSize(client interface{}, base, quote string, hold, earn bool, def float64, mult multiplier.Mult) float64 {
+	return model.GetSizeMax(hold, earn, def, mult, func() int {
+		prec, err := self.GetSizePrec(client, self.FormatMarket(base, quote))
+		if err != nil {
+			return 0
+		}
+		return prec
+	})
+}
+
+func (self *BitMEX) Cancel(client interface{}, market string, side model.OrderSide) error {
+	if observeCancel(self, market, side) {
+		return nil
+	}
+
+	bitmexClient, ok := client.(*exchange.Client)
+	if !ok {
+		return errors.New("invalid argument: client")
+	}
+
+	orders, err := bitmexClient.OpenOrders(market)
+	if err != nil {
+		return errors.Wrap(err, 1)
+	}
+
+	for _, order := range orders {
+		if ((side == model.BUY) && (order.Side == exchange.SideBuy)) || ((side == model.SELL) && (order.Side == exchange.SideSell)) {
+			if err := bitmexClient.CancelOrder(order.OrderID); err != nil {
+				return errors.Wrap(err, 1)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (self *BitMEX) CancelOrder(client interface{}, market, id string) error {
+	if observeCancelOrder(self, market, id) {
+		return nil
+	}
+
+	bitmexClient, ok := client.(*exchange.Client)
+	if !ok {
+		return errors.New("invalid argument: client")
+	}
+
+	if err := bitmexClient.CancelOrder(id); err != nil {
+		return errors.Wrap(err, 1)
+	}
+
+	return nil
+}
+
+func (self *BitMEX) GetOrder(client interface{}, market, id string) (*model.Order, error) {
+	bitmexClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	instrument, err := self.getInstrument(bitmexClient, market, true)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := bitmexClient.GetOrder(market, id)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	price := order.Price
+	if order.IsStop() {
+		price = order.StopPx
+	}
+	out := &model.Order{
+		ID:     id,
+		Status: model.OPEN,
+		Side: func() model.OrderSide {
+			if order.Side == exchange.SideSell {
+				return model.SELL
+			}
+			return model.BUY
+		}(),
+		Market:     market,
+		Size:       instrument.FromContracts(order.OrderQty, price),
+		Price:      price,
+		CreatedAt:  order.Timestamp,
+		FilledSize: instrument.FromContracts(order.CumQty, order.ExecutedAt()),
+		Stop:       order.StopPx,
+	}
+	switch order.OrdStatus {
+	case exchange.OrdStatusFilled:
+		out.Status = model.FILLED
+		out.ClosedAt = model.TimePtr(order.TransactTime)
+	case exchange.OrdStatusCanceled:
+		out.Status = model.CANCELLED
+		out.ClosedAt = model.TimePtr(order.TransactTime)
+	}
+
+	return out, nil
+}
+
+func (self *BitMEX) GetFees(client interface{}, market string) (*model.Fees, error) {
+	bitmexClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	commission, err := bitmexClient.Commission(market)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	return &model.Fees{
+		Maker: commission.MakerFee,
+		Taker: commission.TakerFee,
+	}, nil
+}
+
+func (self *BitMEX) Buy(client interface{}, cancel bool, market string, calls model.Calls, deviation float64, kind model.OrderType) error {
+	if observeBuy(self, client, cancel, market, calls, kind) {
+		return nil
+	}
+
+	bitmexClient, ok := client.(*exchange.Client)
+	if !ok {
+		return errors.New("invalid argument: client")
+	}
+
+	// step #1: delete the buy order(s) that are open in your book
+	if cancel {
+		orders, err := bitmexClient.OpenOrders(market)
+		if err != nil {
+			return errors.Wrap(err, 1)
+		}
+		for _, order := range orders {
+			if order.Side == exchange.SideBuy && !order.IsStop() {
+				// do not cancel orders that we're about to re-place
+				index := calls.IndexByPrice(order.Price)
+				if index > -1 {
+					calls[index].Skip = true
+				} else {
+					if err := bitmexClient.CancelOrder(order.OrderID); err != nil {
+						return errors.Wrap(err, 1)
+					}
+				}
+			}
+		}
+	}
+
+	// step 2: open the top X buy orders
+	for _, call := range calls {
+		if !call.Skip {
+			limit := call.Price
+			if deviation != 1.0 {
+				kind, limit = call.Deviate(self, client, kind, deviation)
+			}
+			if _, _, err := self.Order(client, model.BUY, market, call.Size, limit, kind, ""); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (self *BitMEX) IsLeveragedToken(name string) bool {
+	return false
+}
+
+func (self *BitMEX) HasAlgoOrder(client interface{}, market string) (bool, error) {
+	bitmexClient, ok := client.(*exchange.Client)
+	if !ok {
+		return false, errors.New("invalid argument: client")
+	}
+
+	orders, err := bitmexClient.OpenOrders(market)
+	if err != nil {
+		return false, errors.Wrap(err, 1)
+	}
+
+	for _, order := range orders {
+		if order.IsStop() {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (self *BitMEX) GetPosition(client interface{}, market string) (*model.Position, error) {
+	bitmexClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	instrument, err := self.getInstrument(bitmexClient, market, true)
+	if err != nil {
+		return nil, err
+	}
+
+	position, err := bitmexClient.Position(market)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	size := instrument.FromContracts(math.Abs(position.CurrentQty), position.AvgEntryPrice)
+	if position.CurrentQty < 0 {
+		size = -size
+	}
+
+	return &model.Position{
+		Market:           market,
+		Size:             size,
+		EntryPrice:       position.AvgEntryPrice,
+		Leverage:         position.Leverage,
+		Isolated:         !position.CrossMargin,
+		LiquidationPrice: position.LiquidationPrice,
+	}, nil
+}
+
+// closes the position with a reduce-only market order, then cancels the orders that are left behind.
+func (self *BitMEX) ClosePosition(client interface{}, market string) error {
+	bitmexClient, ok := client.(*exchange.Client)
+	if !ok {
+		return errors.New("invalid argument: client")
+	}
+
+	position, err := bitmexClient.Position(market)
+	if err != nil {
+		return errors.Wrap(err, 1)
+	}
+
+	if position.CurrentQty != 0 {
+		if _, err = bitmexClient.Order(&exchange.NewOrder{
+			Symbol: market,
+			Side: func() exchange.Side {
+				if position.CurrentQty > 0 {
+					return exchange.SideSell
+				}
+				return exchange.SideBuy
+			}(),
+			OrderQty: math.Abs(position.CurrentQty),
+			OrdType:  exchange.OrderTypeMarket,
+			ExecInst: exchange.EXEC_INST_REDUCE_ONLY,
+		}); err != nil {
+			return errors.Wrap(err, 1)
+		}
+	}
+
+	orders, err := bitmexClient.OpenOrders(market)
+	if err != nil {
+		return errors.Wrap(err, 1)
+	}
+	for _, order := range orders {
+		if order.ReduceOnly() {
+			if err := bitmexClient.CancelOrder(order.OrderID); err != nil {
+				return errors.Wrap(err, 1)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (self *BitMEX) SetIsolated(client interface{}, market string, isolated bool) error {
+	bitmexClient, ok := client.(*exchange.Client)
+	if !ok {
+		return errors.New("invalid argument: client")
+	}
+
+	position, err := bitmexClient.Position(market)
+	if err != nil {
+		return errors.Wrap(err, 1)
+	}
+
+	// nothing to toggle
+	if position.CrossMargin != isolated {
+		return nil
+	}
+
+	if _, err = bitmexClient.Isolate(market, isolated); err != nil {
+		return errors.Wrap(err, 1)
+	}
+
+	return nil
+}
+
+func newBitMEX() model.Exchange {
+	return &BitMEX{
+		ExchangeInfo: &model.ExchangeInfo{
+			Code: "BMEX",
+			Name: "BitMEX",
+			URL:  "https://www.bitmex.com",
+			REST: model.Endpoint{
+				URI:     exchange.API_BASE,
+				Sandbox: exchange.API_TEST,
+			},
+			WebSocket: model.Endpoint{
+				URI:     "wss://ws.bitmex.com/realtime",
+				Sandbox: "wss://ws.testnet.bitmex.com/realtime",
+			},
+			Country: "Seychelles",
+		},
+	}
+}
@@ -10,21 +10,23 @@ import (
 	"strings"
 	"time"
 
-	filemutex "github.com/alexflint/go-filemutex"
 	"github.com/svanas/nefertiti/aggregation"
 	exchange "github.com/svanas/nefertiti/bitstamp"
 	"github.com/svanas/nefertiti/errors"
 	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/humanize"
+	"github.com/svanas/nefertiti/i18n"
 	"github.com/svanas/nefertiti/model"
 	"github.com/svanas/nefertiti/multiplier"
 	"github.com/svanas/nefertiti/notify"
+	"github.com/svanas/nefertiti/override"
 	"github.com/svanas/nefertiti/precision"
 	"github.com/svanas/nefertiti/pricing"
 	"github.com/svanas/nefertiti/session"
 )
 
 var (
-	bitstampMutex *filemutex.FileMutex
+	bitstampMutex *session.Mutex
 )
 
 const (
@@ -37,7 +39,7 @@ func init() {
 		var err error
 
 		if bitstampMutex == nil {
-			if bitstampMutex, err = filemutex.New(session.GetSessionFile(bitstampSessionLock)); err != nil {
+			if bitstampMutex, err = session.NewMutex(bitstampSessionLock); err != nil {
 				return err
 			}
 		}
@@ -107,7 +109,7 @@ func (self *Bitstamp) error(err error, level int64, service model.Notify) {
 
 	if service != nil {
 		if notify.CanSend(level, notify.ERROR) {
-			err := service.SendMessage(msg, "Bitstamp - ERROR", model.ONCE_PER_MINUTE)
+			err := notify.SendError(service, err, msg, "Bitstamp - ERROR")
 			if err != nil {
 				log.Printf("[ERROR] %v", err)
 			}
@@ -115,13 +117,19 @@ func (self *Bitstamp) error(err error, level int64, service model.Notify) {
 	}
 }
 
+func (self *Bitstamp) newClient(apiKey, apiSecret string) *exchange.Client {
+	out := exchange.New(apiKey, apiSecret)
+	out.URL = self.ExchangeInfo.REST.URI
+	return out
+}
+
 func (self *Bitstamp) GetInfo() *model.ExchangeInfo {
 	return self.ExchangeInfo
 }
 
 func (self *Bitstamp) GetClient(permission model.Permission, sandbox bool) (interface{}, error) {
 	if permission != model.PRIVATE {
-		return exchange.New("", ""), nil
+		return self.newClient("", ""), nil
 	}
 
 	var (
@@ -133,7 +141,7 @@ func (self *Bitstamp) GetClient(permission model.Permission, sandbox bool) (inte
 		return nil, err
 	}
 
-	return exchange.New(apiKey, apiSecret), nil
+	return self.newClient(apiKey, apiSecret), nil
 }
 
 func (self *Bitstamp) getMarket(client *exchange.Client, name string) (*exchange.Market, error) {
@@ -158,7 +166,7 @@ func (self *Bitstamp) getMarket(client *exchange.Client, name string) (*exchange
 func (self *Bitstamp) GetMarkets(cached, sandbox bool, blacklist []string) ([]model.Market, error) {
 	var out []model.Market
 
-	markets, err := exchange.GetMarkets(exchange.New("", ""), cached)
+	markets, err := exchange.GetMarkets(self.newClient("", ""), cached)
 
 	if err != nil {
 		return nil, err
@@ -184,8 +192,11 @@ func (self *Bitstamp) GetMarkets(cached, sandbox bool, blacklist []string) ([]mo
 	return out, nil
 }
 
+// how Bitstamp spells its markets
+var bitstampSymbol = model.SymbolFormat{Lower: true}
+
 func (self *Bitstamp) FormatMarket(base, quote string) string {
-	return strings.ToLower(base + quote)
+	return model.NewMarketSymbol(base, quote).Format(bitstampSymbol)
 }
 
 // listens to the open orders, look for cancelled orders, send a notification.
@@ -219,7 +230,7 @@ func (self *Bitstamp) listen(
 				side := order.Side()
 				if side != "" && service != nil {
 					if notify.CanSend(level, notify.CANCELLED) {
-						if err = service.SendMessage(order, fmt.Sprintf("Bitstamp - Done %s (Reason: Cancelled)", strings.Title(side)), model.ALWAYS); err != nil {
+						if err = service.SendMessage(order, fmt.Sprintf("Bitstamp - %s", i18n.Sprintf("Done %s (Reason: Cancelled)", strings.Title(side))), model.ALWAYS); err != nil {
 							log.Printf("[ERROR] %v", err)
 						}
 					}
@@ -241,7 +252,7 @@ func (self *Bitstamp) listen(
 			side := order.Side()
 			if side != "" && service != nil {
 				if notify.CanSend(level, notify.OPENED) || (level == notify.LEVEL_DEFAULT && side == exchange.SELL) {
-					if err = service.SendMessage(order, ("Bitstamp - Open " + strings.Title(side)), model.ALWAYS); err != nil {
+					if err = service.SendMessage(order, ("Bitstamp - " + i18n.Sprintf("Open %s", strings.Title(side))), model.ALWAYS); err != nil {
 						log.Printf("[ERROR] %v", err)
 					}
 				}
@@ -330,7 +341,7 @@ func (self *Bitstamp) sell(
 	var orders []exchange.Transaction
 	for _, transaction := range new {
 		if transaction.OrderId() != "" {
-			if old.IndexByOrderId(transaction.OrderId()) == -1 {
+			if old.IndexByOrderId(transaction.OrderId()) == -1 && processOnce(self, transaction.Id()) {
 				orders = append(orders, transaction)
 			}
 		}
@@ -349,12 +360,12 @@ func (self *Bitstamp) sell(
 					self.error(err, level, service)
 				} else {
 					if service != nil {
-						if err = service.SendMessage(order, fmt.Sprintf("Bitstamp - Done %s (Reason: Filled %f qty)", strings.Title(side), order.Amount(client)), model.ALWAYS); err != nil {
+						if err = service.SendMessage(order, fmt.Sprintf("Bitstamp - %s", i18n.Sprintf("Done %s (Reason: Filled %s qty)", strings.Title(side), model.FormatSize(self, client, order.Market(client), order.Amount(client)))), model.ALWAYS); err != nil {
 							log.Printf("[ERROR] %v", err)
 						}
 					}
 					if twitter != nil {
-						notify.Tweet(twitter, fmt.Sprintf("Done %s. %s priced at %f #Bitstamp", strings.Title(side), model.TweetMarket(markets, order.Market(client)), order.Price(client)))
+						notify.Tweet(twitter, fmt.Sprintf("Done %s. %s priced at %s #Bitstamp", strings.Title(side), model.TweetMarket(markets, order.Market(client)), model.FormatPrice(self, client, order.Market(client), order.Price(client))))
 					}
 				}
 			}
@@ -393,24 +404,27 @@ func (self *Bitstamp) sell(
 			)
 			base, quote, err = model.ParseMarket(markets, orders[i].Market(client))
 			if err == nil {
-				qty = self.GetMaxSize(client, base, quote, hold.HasMarket(orders[i].Market(client)), earn.HasMarket(orders[i].Market(client)), qty, mult)
+				qty = self.GetMaxSize(client, base, quote, hold.HasMarket(orders[i].Market(client)), earn.HasMarket(orders[i].Market(client)), qty, override.Mult(orders[i].Market(client), mult))
 				if qty > 0 {
-					var pp int
-					if pp, err = self.GetPricePrec(client, orders[i].Market(client)); err == nil {
-						attempts := 0
-						for {
-							_, err = client.SellLimitOrder(
-								orders[i].Market(client),
-								qty,
-								pricing.Multiply(orders[i].Price(client), mult, pp),
-							)
-							if err != nil && strings.Contains(err.Error(), "Order could not be placed") {
-								attempts++
-								if attempts >= 10 {
+					var tick float64
+					if tick, err = model.GetTickSize(self, client, orders[i].Market(client)); err == nil {
+						limit := pricing.MultiplyTick(orders[i].Price(client), override.Mult(orders[i].Market(client), mult), tick)
+						if _, ok := observeOrder(self, client, model.SELL, orders[i].Market(client), qty, limit, model.LIMIT); !ok {
+							attempts := 0
+							for {
+								_, err = client.SellLimitOrder(
+									orders[i].Market(client),
+									qty,
+									limit,
+								)
+								if err != nil && strings.Contains(err.Error(), "Order could not be placed") {
+									attempts++
+									if attempts >= 10 {
+										break
+									}
+								} else {
 									break
 								}
-							} else {
-								break
 							}
 						}
 					}
@@ -464,7 +478,7 @@ func (self *Bitstamp) Sell(
 		}
 	}
 
-	client := exchange.New(apiKey, apiSecret)
+	client := self.newClient(apiKey, apiSecret)
 
 	// get my open orders
 	var open []exchange.Order
@@ -546,8 +560,8 @@ func (self *Bitstamp) Sell(
 								// continue
 							} else {
 								self.info(fmt.Sprintf(
-									"Re-buying %s because your latest activity on this market (at %s) is older than %d days.",
-									market.Name, youngest.Format(time.RFC1123), rebuyAfterDays,
+									"Re-buying %s because your latest activity on this market (at %s, %s) is older than %d days.",
+									market.Name, youngest.Format(time.RFC1123), humanize.Age(youngest), rebuyAfterDays,
 								), level, service)
 								var ticker float64
 								if ticker, err = self.GetTicker(client, market.Name); err != nil {
@@ -587,6 +601,7 @@ func (self *Bitstamp) Sell(
 			}
 			reboughtAt = time.Now()
 		}
+		beat(self.GetInfo().Name, err)
 	}
 }
 
@@ -599,6 +614,14 @@ func (self *Bitstamp) Order(
 	kind model.OrderType,
 	metadata string,
 ) (oid []byte, raw []byte, err error) {
+	if raw, ok := observeOrder(self, client, side, market, size, price, kind); ok {
+		return nil, raw, nil
+	}
+
+	if err := CheckEquityShare(self, client, side, market, size, price); err != nil {
+		return nil, nil, err
+	}
+
 	bitstamp, ok := client.(*exchange.Client)
 	if !ok {
 		return nil, nil, errors.New("invalid argument: client")
@@ -649,11 +672,14 @@ func (self *Bitstamp) GetClosed(client interface{}, market string) (model.Orders
 		if transaction.OrderId() != "" {
 			side, _ := transaction.Side(bitstamp)
 			out = append(out, model.Order{
-				Side:      model.NewOrderSide(side),
-				Market:    transaction.Market(bitstamp),
-				Size:      transaction.Amount(bitstamp),
-				Price:     transaction.Price(bitstamp),
-				CreatedAt: transaction.DateTime(),
+				Side:       model.NewOrderSide(side),
+				Status:     model.FILLED,
+				Market:     transaction.Market(bitstamp),
+				Size:       transaction.Amount(bitstamp),
+				Price:      transaction.Price(bitstamp),
+				CreatedAt:  transaction.DateTime(),
+				ClosedAt:   model.TimePtr(transaction.DateTime()),
+				FilledSize: transaction.Amount(bitstamp),
 			})
 		}
 	}
@@ -677,7 +703,9 @@ func (self *Bitstamp) GetOpened(client interface{}, market string) (model.Orders
 	var out model.Orders
 	for _, order := range orders {
 		out = append(out, model.Order{
+			Status: model.OPEN,
 			Side:   model.NewOrderSide(order.Side()),
+			ID:     order.Id,
 			Market: market,
 			Size:   order.Amount,
 			Price:  order.Price,
@@ -755,6 +783,10 @@ func (self *Bitstamp) GetTicker(client interface{}, market string) (float64, err
 	return ticker.Last, nil
 }
 
+func (self *Bitstamp) GetTickers(client interface{}) (map[string]float64, error) {
+	return getTickers(self, client)
+}
+
 func (self *Bitstamp) Get24h(client interface{}, market string) (*model.Stats, error) {
 	bitstamp, ok := client.(*exchange.Client)
 	if !ok {
@@ -771,9 +803,16 @@ func (self *Bitstamp) Get24h(client interface{}, market string) (*model.Stats, e
 		High:      ticker.High,
 		Low:       ticker.Low,
 		BtcVolume: 0,
+		Open:      ticker.Open,
+		Last:      ticker.Last,
+		Change:    model.PercentChange(ticker.Open, ticker.Last),
 	}, nil
 }
 
+func (self *Bitstamp) Get24hAll(client interface{}) (map[string]model.Stats, error) {
+	return get24hAll(self, client)
+}
+
 func (self *Bitstamp) GetPricePrec(client interface{}, marketName string) (int, error) {
 	bitstamp, ok := client.(*exchange.Client)
 	if !ok {
@@ -838,6 +877,10 @@ func (self *Bitstamp) GetMaxSize(client interface{}, base, quote string, hold, e
 }
 
 func (self *Bitstamp) Cancel(client interface{}, market string, side model.OrderSide) error {
+	if observeCancel(self, market, side) {
+		return nil
+	}
+
 	var err error
 
 	bitstamp, ok := client.(*exchange.Client)
@@ -861,7 +904,71 @@ func (self *Bitstamp) Cancel(client interface{}, market string, side model.Order
 	return nil
 }
 
+func (self *Bitstamp) CancelOrder(client interface{}, market, id string) error {
+	if observeCancelOrder(self, market, id) {
+		return nil
+	}
+
+	bitstamp, ok := client.(*exchange.Client)
+	if !ok {
+		return errors.New("invalid argument: client")
+	}
+
+	return bitstamp.CancelOrder(id)
+}
+
+func (self *Bitstamp) GetOrder(client interface{}, market, id string) (*model.Order, error) {
+	var err error
+
+	bitstamp, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	var markets []model.Market
+	if markets, err = self.GetMarkets(true, false, nil); err != nil {
+		return nil, err
+	}
+	var base string
+	if base, _, err = model.ParseMarket(markets, market); err != nil {
+		return nil, err
+	}
+
+	var order *exchange.OrderStatus
+	if order, err = bitstamp.GetOrderStatus(id); err != nil {
+		return nil, err
+	}
+
+	filled, price := order.Filled(base)
+	out := &model.Order{
+		ID:         id,
+		Status:     model.OPEN,
+		Side:       model.NewOrderSide(order.Side()),
+		Market:     market,
+		Size:       filled + order.AmountRemaining,
+		Price:      price,
+		CreatedAt:  order.GetDateTimeEx(),
+		FilledSize: filled,
+	}
+	switch order.Status {
+	case exchange.ORDER_STATUS_FINISHED:
+		out.Status = model.FILLED
+	case exchange.ORDER_STATUS_CANCELED:
+		out.Status = model.CANCELLED
+	}
+
+	return out, nil
+}
+
+func (self *Bitstamp) GetFees(client interface{}, market string) (*model.Fees, error) {
+	return getFees(self)
+}
+
 func (self *Bitstamp) Buy(client interface{}, cancel bool, market string, calls model.Calls, deviation float64, kind model.OrderType) error {
+	if observeBuy(self, client, cancel, market, calls, kind) {
+		return nil
+	}
+
 	var err error
 
 	bitstamp, ok := client.(*exchange.Client)
@@ -879,8 +986,8 @@ func (self *Bitstamp) Buy(client interface{}, cancel bool, market string, calls
 			side := order.Side()
 			if side == exchange.BUY {
 				// do not cancel orders that we're about to re-place
-				index := calls.IndexByPrice(order.Price)
-				if index > -1 && order.Amount == calls[index].Size {
+				index := calls.IndexByPriceSize(order.Price, order.Amount)
+				if index > -1 {
 					calls[index].Skip = true
 				} else {
 					if err = bitstamp.CancelOrder(order.Id); err != nil {
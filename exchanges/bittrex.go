@@ -4,21 +4,23 @@ package exchanges
 import (
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"log"
 	"runtime"
 	"strconv"
 	"strings"
 	"time"
 
-	"github.com/alexflint/go-filemutex"
 	"github.com/svanas/nefertiti/aggregation"
 	exchange "github.com/svanas/nefertiti/bittrex"
+	"github.com/svanas/nefertiti/clock"
 	"github.com/svanas/nefertiti/errors"
 	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/humanize"
+	"github.com/svanas/nefertiti/i18n"
 	"github.com/svanas/nefertiti/model"
 	"github.com/svanas/nefertiti/multiplier"
 	"github.com/svanas/nefertiti/notify"
+	"github.com/svanas/nefertiti/override"
 	"github.com/svanas/nefertiti/precision"
 	"github.com/svanas/nefertiti/pricing"
 	"github.com/svanas/nefertiti/session"
@@ -29,15 +31,19 @@ const (
 )
 
 var (
-	bittrexMutex *filemutex.FileMutex
+	bittrexMutex *session.Mutex
 )
 
 const (
 	bittrexSessionFile = "bittrex.time"
 	bittrexSessionLock = "bittrex.lock"
 	bittrexSessionInfo = "bittrex.json"
+	bittrexSessionHist = "bittrex.cursor"
 )
 
+// re-fetch the orders that closed during this window before the cursor, so we never miss an order that closed out of sequence.
+const bittrexHistoryOverlap = 24 * time.Hour
+
 type BittrexSessionInfo struct {
 	Cooldown bool            `json:"cooldown"`
 	Calls    []exchange.Call `json:"calls"`
@@ -49,7 +55,7 @@ func bittrexRequestsPerSecond(path string) (float64, bool) { // -> (rps, cooldow
 		data []byte
 		info BittrexSessionInfo
 	)
-	data, err = ioutil.ReadFile(session.GetSessionFile(bittrexSessionInfo))
+	data, err = session.ReadFile(session.GetSessionFile(session.Keyed(bittrexSessionInfo)))
 	if err != nil {
 		info.Calls = exchange.Calls
 	} else {
@@ -60,10 +66,15 @@ func bittrexRequestsPerSecond(path string) (float64, bool) { // -> (rps, cooldow
 			if info.Cooldown {
 				info.Cooldown = false
 				if data, err = json.Marshal(info); err == nil {
-					ioutil.WriteFile(session.GetSessionFile(bittrexSessionInfo), data, 0600)
+					session.WriteFile(session.GetSessionFile(session.Keyed(bittrexSessionInfo)), data)
 				}
 				return exchange.RequestsPerSecond(exchange.INTENSITY_SUPER), true
 			}
+			if exchange.Decay(info.Calls) {
+				if data, err = json.Marshal(info); err == nil {
+					session.WriteFile(session.GetSessionFile(session.Keyed(bittrexSessionInfo)), data)
+				}
+			}
 		}
 	}
 	for i := range path {
@@ -90,7 +101,7 @@ func init() {
 		)
 
 		if bittrexMutex == nil {
-			if bittrexMutex, err = filemutex.New(session.GetSessionFile(bittrexSessionLock)); err != nil {
+			if bittrexMutex, err = session.NewMutex(session.Keyed(bittrexSessionLock)); err != nil {
 				return cooled, err
 			}
 		}
@@ -100,7 +111,7 @@ func init() {
 		}
 
 		var lastRequest *time.Time
-		if lastRequest, err = session.GetLastRequest(bittrexSessionFile); err != nil {
+		if lastRequest, err = session.GetLastRequest(session.Keyed(bittrexSessionFile)); err != nil {
 			return cooled, err
 		}
 
@@ -127,7 +138,7 @@ func init() {
 		defer func() {
 			bittrexMutex.Unlock()
 		}()
-		session.SetLastRequest(bittrexSessionFile, time.Now())
+		session.SetLastRequest(session.Keyed(bittrexSessionFile), time.Now())
 	}
 	// HandleRateLimitErr
 	exchange.HandleRateLimitErr = func(path string, cooled bool) error {
@@ -137,7 +148,7 @@ func init() {
 			info   BittrexSessionInfo
 			exists bool
 		)
-		data, err = ioutil.ReadFile(session.GetSessionFile(bittrexSessionInfo))
+		data, err = session.ReadFile(session.GetSessionFile(session.Keyed(bittrexSessionInfo)))
 		if err != nil {
 			info.Calls = exchange.Calls
 		} else {
@@ -161,19 +172,22 @@ func init() {
 				} else {
 					info.Calls[idx].Intensity = info.Calls[idx].Intensity + 1
 				}
+				info.Calls[idx].LastRateLimit = time.Now()
 				exists = true
 			}
 		}
 		if !exists {
 			info.Calls = append(info.Calls, exchange.Call{
-				Path:      path,
-				Intensity: exchange.INTENSITY_TWO,
+				Path:          path,
+				Intensity:     exchange.INTENSITY_TWO,
+				LastRateLimit: time.Now(),
 			})
 		}
 		info.Cooldown = true
 		if data, err = json.Marshal(info); err == nil {
-			err = ioutil.WriteFile(session.GetSessionFile(bittrexSessionInfo), data, 0600)
+			err = session.WriteFile(session.GetSessionFile(session.Keyed(bittrexSessionInfo)), data)
 		}
+		recordRateLimit(session.Keyed("bittrex"), path)
 		return err
 	}
 }
@@ -189,15 +203,43 @@ func bittrexOrderSide(order *exchange.Order) model.OrderSide {
 	return model.ORDER_SIDE_NONE
 }
 
-func bittrexParseMarket(market string, version int) (base, quote string, err error) {
-	symbols := strings.Split(market, "-")
-	if len(symbols) > 1 {
-		if version >= 3 {
-			return symbols[0], symbols[1], nil
+// bittrexStopOf returns the conditional order that has created this order: the stop of an OCO (at market, or with
+// --stop-limit=X at a limit) or a trailing stop. returns nil if the order hasn't been created by a conditional order.
+func bittrexStopOf(client *exchange.Client, order *exchange.Order) *exchange.ConditionalOrder {
+	conditionals, err := client.GetClosedConditionalOrders(order.MarketSymbol)
+	if err != nil {
+		log.Printf("[ERROR] %v", err)
+		return nil
+	}
+	for i := range conditionals {
+		if conditionals[i].CreatedOrderId == order.Id {
+			return &conditionals[i]
 		}
-		return symbols[1], symbols[0], nil
 	}
-	return "", "", errors.Errorf("cannot parse market %s", market)
+	return nil
+}
+
+// bittrexStoppedOut returns true if the stop of an OCO has cancelled this (limit sell) order
+func bittrexStoppedOut(client *exchange.Client, order *exchange.Order) (bool, error) {
+	conditionals, err := client.GetClosedConditionalOrders(order.MarketSymbol)
+	if err != nil {
+		return false, errors.Wrap(err, 1)
+	}
+	for _, conditional := range conditionals {
+		if conditional.OrderToCancel != nil && conditional.OrderToCancel.Id == order.Id && conditional.CreatedOrderId != "" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// bittrexSellId returns the ID of the limit sell that an OCO has opened, or an empty string if we cannot tell
+func bittrexSellId(raw []byte) string {
+	var conditional exchange.ConditionalOrder
+	if json.Unmarshal(raw, &conditional) == nil && conditional.OrderToCancel != nil {
+		return string(conditional.OrderToCancel.Id)
+	}
+	return ""
 }
 
 func bittrexLogInfo(msg string, level int64, service model.Notify) {
@@ -226,7 +268,7 @@ func bittrexLogError(err error, level int64, service model.Notify) {
 
 	if service != nil {
 		if notify.CanSend(level, notify.ERROR) {
-			err := service.SendMessage(msg, "Bittrex - ERROR", model.ONCE_PER_MINUTE)
+			err := notify.SendError(service, err, msg, "Bittrex - ERROR")
 			if err != nil {
 				log.Printf("[ERROR] %v", err)
 			}
@@ -241,7 +283,7 @@ func bittrexLogErrorEx(err error, order *exchange.Order, level int64, service mo
 
 	if service != nil {
 		if notify.CanSend(level, notify.ERROR) {
-			err := service.SendMessage(msg, "Bittrex - ERROR", model.ONCE_PER_MINUTE)
+			err := notify.SendError(service, err, msg, "Bittrex - ERROR")
 			if err != nil {
 				log.Printf("[ERROR] %v", err)
 			}
@@ -297,13 +339,19 @@ type Bittrex struct {
 	markets []exchange.Market
 }
 
+func (self *Bittrex) newClient(apiKey, apiSecret string) *exchange.Client {
+	out := exchange.New(apiKey, apiSecret, bittrexAppID)
+	out.URL = self.ExchangeInfo.REST.URI
+	return out
+}
+
 func (self *Bittrex) GetInfo() *model.ExchangeInfo {
 	return self.ExchangeInfo
 }
 
 func (self *Bittrex) GetClient(permission model.Permission, sandbox bool) (interface{}, error) {
 	if permission != model.PRIVATE {
-		return exchange.New("", "", bittrexAppID), nil
+		return self.newClient("", ""), nil
 	}
 
 	apiKey, apiSecret, err := promptForApiKeys("Bittrex")
@@ -311,7 +359,7 @@ func (self *Bittrex) GetClient(permission model.Permission, sandbox bool) (inter
 		return nil, err
 	}
 
-	return exchange.New(apiKey, apiSecret, bittrexAppID), nil
+	return self.newClient(apiKey, apiSecret), nil
 }
 
 func (self *Bittrex) getMarket(client *exchange.Client, market1 string) (*exchange.Market, error) {
@@ -358,14 +406,22 @@ func (self *Bittrex) GetMarkets(cached, sandbox bool, ignore []string) ([]model.
 	)
 
 	if self.markets == nil || !cached {
-		client := exchange.New("", "", bittrexAppID)
+		client := self.newClient("", "")
 		if self.markets, err = client.GetMarkets(); err != nil {
 			return nil, errors.Wrap(err, 1)
 		}
 	}
 
 	for _, market := range self.markets {
-		if market.Active() && !market.IsProhibited(ignore) {
+		// ignore holds the markets to ignore, and the regions where we aren't allowed to trade
+		if market.Active() && !market.IsProhibited(ignore) && func() bool {
+			for _, name := range ignore {
+				if strings.EqualFold(market.MarketName(), name) {
+					return false
+				}
+			}
+			return true
+		}() {
 			out = append(out, model.Market{
 				Name:  market.MarketName(),
 				Base:  market.BaseCurrencySymbol,
@@ -377,28 +433,64 @@ func (self *Bittrex) GetMarkets(cached, sandbox bool, ignore []string) ([]model.
 	return out, nil
 }
 
+// how Bittrex spells its markets. we use the old (v1) names that have the quote first, the v3 API has the base first.
+var (
+	bittrexSymbol   = model.SymbolFormat{Separator: "-", Inverted: true}
+	bittrexSymbolV3 = model.SymbolFormat{Separator: "-"}
+)
+
 func (self *Bittrex) FormatMarket(base, quote string) string {
-	return strings.ToUpper(fmt.Sprintf("%s-%s", quote, base))
+	return model.NewMarketSymbol(base, quote).Format(bittrexSymbol)
 }
 
-func (self *Bittrex) formatMarketEx(base, quote string, version int) string {
-	if version >= 3 {
-		return strings.ToUpper(fmt.Sprintf("%s-%s", base, quote))
+// ConvertMarket converts a market from the old version to version 3.
+func (self *Bittrex) convertMarket(old string) (string, error) {
+	symbol, err := model.ParseSymbol(old, bittrexSymbol)
+	if err != nil {
+		return "", err
 	}
-	return strings.ToUpper(fmt.Sprintf("%s-%s", quote, base))
+	return symbol.Format(bittrexSymbolV3), nil
 }
 
-// ConvertMarket converts a market from the old version to version 3.
-func (self *Bittrex) convertMarket(old string) (string, error) {
+// getOrderHistory returns the orders that closed since the last cursor, merged with the (recent) orders we already know about.
+func (self *Bittrex) getOrderHistory(client *exchange.Client, old exchange.Orders) (exchange.Orders, error) {
 	var (
 		err   error
-		base  string
-		quote string
+		since time.Time = old.LastClosedAt()
 	)
-	if base, quote, err = bittrexParseMarket(old, 1); err != nil {
-		return "", err
+
+	if since.IsZero() {
+		var cursor *time.Time
+		if cursor, err = session.GetCursor(bittrexSessionHist); err == nil && cursor != nil {
+			since = *cursor
+		}
+	}
+	if !since.IsZero() {
+		since = since.Add(-bittrexHistoryOverlap)
+	}
+
+	var out exchange.Orders
+	if out, err = client.GetOrderHistorySince("all", since); err != nil {
+		return old, err
+	}
+
+	// keep the orders we have seen before (and that are still inside the window), so they do not look "new" to us
+	for _, order := range old {
+		if out.IndexByOrderId(order.Id) == -1 {
+			closedAt, err := clock.Parse(order.ClosedAt, exchange.TIME_FORMAT)
+			if err == nil && !closedAt.Before(since) {
+				out = append(out, order)
+			}
+		}
 	}
-	return self.formatMarketEx(base, quote, 3), nil
+
+	if cursor := out.LastClosedAt(); !cursor.IsZero() {
+		if err = session.SetCursor(bittrexSessionHist, cursor); err != nil {
+			log.Printf("[WARN] %v", err)
+		}
+	}
+
+	return out, nil
 }
 
 // listens to the open orders, look for cancelled orders, send a notification.
@@ -432,15 +524,28 @@ func (self *Bittrex) listen(
 				side := bittrexOrderSide(&order)
 				if side != model.ORDER_SIDE_NONE {
 					if service != nil && notify.CanSend(level, notify.CANCELLED) {
-						if err = service.SendMessage(order, fmt.Sprintf("Bittrex - Done %s (Reason: Cancelled)", model.FormatOrderSide(side)), model.ALWAYS); err != nil {
+						if err = service.SendMessage(order, fmt.Sprintf("Bittrex - %s", i18n.Sprintf("Done %s (Reason: Cancelled)", model.FormatOrderSide(side))), model.ALWAYS); err != nil {
 							log.Printf("[ERROR] %v", err)
 						}
 					}
 				}
+
+				// has a take profit been cancelled? then we re-open its exit
+				if side == model.SELL {
+					if err := self.rebuildExit(client, &order, level, service); err != nil {
+						bittrexLogErrorEx(err, &order, level, service)
+					}
+				}
 			}
 		}
 	}
 
+	// the exits we have opened (and closed) before
+	var exits Exits
+	if exits, err = ReadExits(self); err != nil {
+		return new, err
+	}
+
 	// look for new orders
 	for _, order := range new {
 		if old.IndexByOrderId(order.Id) == -1 {
@@ -454,9 +559,9 @@ func (self *Bittrex) listen(
 			side := bittrexOrderSide(&order)
 			if side != model.ORDER_SIDE_NONE {
 				// [BUG] every now and then, Bittrex is sending out Open Sell notification(s) for previously sold order(s). Here we single those out.
-				if side != model.SELL || history.IndexByOrderIdEx(order.Id, exchange.SELL) == -1 {
+				if side != model.SELL || (history.IndexByOrderIdEx(order.Id, exchange.SELL) == -1 && !exits.IsClosed(string(order.Id))) {
 					if service != nil && (notify.CanSend(level, notify.OPENED) || (level == notify.LEVEL_DEFAULT && side == model.SELL)) {
-						if err = service.SendMessage(order, ("Bittrex - Open " + model.FormatOrderSide(side)), model.ALWAYS); err != nil {
+						if err = service.SendMessage(order, ("Bittrex - " + i18n.Sprintf("Open %s", model.FormatOrderSide(side))), model.ALWAYS); err != nil {
 							log.Printf("[ERROR] %v", err)
 						}
 					}
@@ -468,6 +573,45 @@ func (self *Bittrex) listen(
 	return new, nil
 }
 
+// rebuildExit re-opens the exit that has been opened with this (cancelled) sell order, unless the stop of its OCO has
+// done the cancelling. sell() closes the exit in that case.
+func (self *Bittrex) rebuildExit(client *exchange.Client, order *exchange.Order, level int64, service model.Notify) error {
+	exits, err := ReadExits(self)
+	if err != nil {
+		return err
+	}
+
+	exit := exits.BySellId(string(order.Id))
+	if exit == nil || exit.ClosedAt != nil {
+		return nil
+	}
+
+	var stopped bool
+	if stopped, err = bittrexStoppedOut(client, order); err != nil || stopped {
+		return err
+	}
+
+	var sellId string
+	if exit.Stop > 0 {
+		var raw []byte
+		if raw, err = self.OCO(client, exit.Market, exit.Size, exit.Price, exit.Stop, strconv.FormatFloat(exit.Bought, 'f', -1, 64)); err != nil {
+			return err
+		}
+		sellId = bittrexSellId(raw)
+	} else {
+		var oid []byte
+		if oid, _, err = self.Order(client, model.SELL, exit.Market, exit.Size, exit.Price, model.LIMIT, strconv.FormatFloat(exit.Bought, 'f', -1, 64)); err != nil {
+			return err
+		}
+		sellId = string(oid)
+	}
+	moveExit(self, string(order.Id), sellId, 0)
+
+	bittrexLogInfo(fmt.Sprintf("Re-opened the exit for buy order %s on %s because its sell order %s has been cancelled.", exit.BuyId, exit.Market, order.Id), level, service)
+
+	return nil
+}
+
 // listens to the order history, look for newly filled orders, automatically place new LIMIT SELL orders.
 func (self *Bittrex) sell(
 	client *exchange.Client,
@@ -491,13 +635,13 @@ func (self *Bittrex) sell(
 
 	// get my new order history
 	var new exchange.Orders
-	if new, err = client.GetOrderHistory("all"); err != nil {
+	if new, err = self.getOrderHistory(client, old); err != nil {
 		return old, errors.Wrap(err, 1)
 	}
 
 	// look for filled orders
 	for _, order := range new {
-		if old.IndexByOrderId(order.Id) == -1 {
+		if old.IndexByOrderId(order.Id) == -1 && processOnce(self, string(order.Id)) {
 			var data []byte
 			if data, err = json.Marshal(order); err != nil {
 				return new, errors.Wrap(err, 1)
@@ -507,12 +651,18 @@ func (self *Bittrex) sell(
 
 			side := bittrexOrderSide(&order)
 			if side != model.ORDER_SIDE_NONE {
+				var conditional *exchange.ConditionalOrder
+				if side == model.SELL {
+					conditional = bittrexStopOf(client, &order)
+				}
+				stopped := conditional != nil
+
 				// send notification(s)
 				if notify.CanSend(level, notify.FILLED) {
 					if service != nil {
-						title := fmt.Sprintf("Bittrex - Done %s", model.FormatOrderSide(side))
+						title := fmt.Sprintf("Bittrex - %s", i18n.Sprintf("Done %s", model.FormatOrderSide(side)))
 						if side == model.SELL {
-							if strategy == model.STRATEGY_STOP_LOSS && order.Type() == exchange.MARKET {
+							if strategy == model.STRATEGY_STOP_LOSS && stopped {
 								title = fmt.Sprintf("%s %s", title, multiplier.Format(stop))
 							} else {
 								title = fmt.Sprintf("%s %s", title, multiplier.Format(mult))
@@ -523,13 +673,30 @@ func (self *Bittrex) sell(
 						}
 					}
 					if twitter != nil {
-						notify.Tweet(twitter, fmt.Sprintf("Done %s. %s priced at %f #Bittrex", model.FormatOrderSide(side), model.TweetMarket(markets, order.MarketName()), order.Price()))
+						notify.Tweet(twitter, fmt.Sprintf("Done %s. %s priced at %s #Bittrex", model.FormatOrderSide(side), model.TweetMarket(markets, order.MarketName()), model.FormatPrice(self, client, order.MarketName(), order.Price())))
+					}
+				}
+
+				// has a take profit been filled? then add the profit to our journal
+				if side == model.SELL {
+					// the stop of an OCO creates a new order, and cancels the limit sell that we have opened the exit with
+					if stopped && conditional.OrderToCancel != nil {
+						moveExit(self, string(conditional.OrderToCancel.Id), string(order.Id), 0)
+					}
+					exit := closeExit(self, string(order.Id), order.Price())
+					if !stopped {
+						if exit != nil {
+							recordExitProfit(self, client, exit)
+						} else {
+							recordTakeProfit(self, client, order.MarketName(), order.QuantityFilled()*order.Price(), mult)
+						}
 					}
 				}
 
 				// has a stop loss been filled? then place a buy order double the order size *** if --dca is included ***
 				if side == model.SELL {
-					if strategy == model.STRATEGY_STOP_LOSS && order.Type() == exchange.MARKET {
+					if strategy == model.STRATEGY_STOP_LOSS && stopped {
+						recordLoss(self, order.MarketName(), order.QuantityFilled()*order.Price(), stop, service)
 						if flag.Dca() {
 							// do not re-buy the same thing. you don't want to be a victim of stop-loss hunting.
 							if func() bool {
@@ -548,12 +715,15 @@ func (self *Bittrex) sell(
 							}() {
 								var (
 									prec int
-									size float64 = 2.2 * order.QuantityFilled()
+									size float64
 								)
+								if size, err = dcaSize(self, client, order.MarketName(), order.QuantityFilled()); err != nil {
+									return new, err
+								}
 								if prec, err = self.GetSizePrec(client, order.MarketName()); err != nil {
 									return new, err
 								}
-								for {
+								for size > 0 {
 									_, _, err = self.Order(client,
 										model.BUY,
 										order.MarketName(),
@@ -561,6 +731,7 @@ func (self *Bittrex) sell(
 										0, model.MARKET, "",
 									)
 									if err == nil {
+										recordDca(self, client, order.MarketName(), size)
 										break
 									} else if !strings.Contains(err.Error(), "ORDERBOOK_DEPTH") {
 										return new, err
@@ -589,8 +760,10 @@ func (self *Bittrex) sell(
 					}
 				}
 
-				// has a buy order been filled? then place a sell order
-				if side == model.BUY {
+				// has a buy order been filled? then place a sell order *** unless we have done so before ***
+				if side == model.BUY && hasExit(self, string(order.Id)) {
+					log.Printf("[INFO] Not selling buy order %s on %s because we have opened an exit for it before\n", order.Id, order.MarketName())
+				} else if side == model.BUY {
 					bought := order.Price()
 					if bought == 0 {
 						if bought, err = self.GetTicker(client, order.MarketName()); err != nil {
@@ -610,29 +783,44 @@ func (self *Bittrex) sell(
 					}
 					// ---- END ---- svanas 2021-05-28 ------------------------------------
 					if err == nil {
-						var prec int
-						if prec, err = self.GetPricePrec(client, order.MarketName()); err == nil {
-							qty := self.GetMaxSize(client, base, quote, hold.HasMarket(order.MarketName()), earn.HasMarket(order.MarketName()), order.QuantityFilled(), mult)
+						var tick float64
+						if tick, err = model.GetTickSize(self, client, order.MarketName()); err == nil {
+							qty := self.GetMaxSize(client, base, quote, hold.HasMarket(order.MarketName()), earn.HasMarket(order.MarketName()), order.QuantityFilled(), override.Mult(order.MarketName(), mult))
 							if qty > 0 {
-								tgt := pricing.Multiply(bought, mult, prec)
+								exit := Exit{
+									Market: order.MarketName(),
+									BuyId:  string(order.Id),
+									Bought: bought,
+									Size:   qty,
+									Price:  pricing.MultiplyTick(bought, override.Mult(order.MarketName(), mult), tick),
+								}
+								var raw []byte
 								if strategy == model.STRATEGY_STOP_LOSS {
-									_, err = self.OCO(
+									exit.Stop = pricing.MultiplyTick(bought, override.Stop(order.MarketName(), stop), tick)
+									if raw, err = self.OCO(
 										client,
 										order.MarketName(),
 										qty,
-										tgt,
-										pricing.Multiply(bought, stop, prec),
+										exit.Price,
+										exit.Stop,
 										strconv.FormatFloat(bought, 'f', -1, 64),
-									)
+									); err == nil && raw != nil {
+										exit.SellId = bittrexSellId(raw)
+									}
 								} else {
-									_, _, err = self.Order(
+									if raw, _, err = self.Order(
 										client, model.SELL,
 										order.MarketName(),
 										qty,
-										tgt,
+										exit.Price,
 										model.LIMIT,
 										strconv.FormatFloat(bought, 'f', -1, 64),
-									)
+									); err == nil {
+										exit.SellId = string(raw)
+									}
+								}
+								if err == nil {
+									recordExit(self, exit)
 								}
 							}
 						}
@@ -681,11 +869,11 @@ func (self *Bittrex) Sell(
 		}
 	}
 
-	client := exchange.New(apiKey, apiSecret, bittrexAppID)
+	client := self.newClient(apiKey, apiSecret)
 
 	// get my order history
 	var history exchange.Orders
-	if history, err = client.GetOrderHistory("all"); err != nil {
+	if history, err = self.getOrderHistory(client, nil); err != nil {
 		return errors.Wrap(err, 1)
 	}
 
@@ -700,14 +888,15 @@ func (self *Bittrex) Sell(
 	}
 
 	reopenedAt := time.Now()
-	const reopenAfterDays = 21
+	collectedAt := time.Now()
 
 	for {
 		// read the dynamic settings
 		var (
-			level int64 = notify.LEVEL_DEFAULT
-			mult  multiplier.Mult
-			stop  multiplier.Mult
+			level       int64 = notify.LEVEL_DEFAULT
+			mult        multiplier.Mult
+			stop        multiplier.Mult
+			reopenAfter time.Duration
 		)
 		if level, err = notify.Level(); err != nil {
 			bittrexLogError(err, level, service)
@@ -715,6 +904,8 @@ func (self *Bittrex) Sell(
 			bittrexLogError(err, level, service)
 		} else if stop, err = multiplier.Stop(); err != nil {
 			bittrexLogError(err, level, service)
+		} else if reopenAfter, err = flag.ReopenAfter(); err != nil {
+			bittrexLogError(err, level, service)
 		} else
 		// listens to the order history, look for newly filled orders, automatically place new LIMIT SELL orders.
 		if history, err = self.sell(client, strategy, mult, stop, hold, earn, service, twitter, level, history, sandbox); err != nil {
@@ -726,8 +917,8 @@ func (self *Bittrex) Sell(
 		} else
 		// Effective 25-nov-2017, Bittrex will be removing orders that are older than 28 days. Here we will...
 		// 1. check for those every hour, and then
-		// 2. re-open those that are older than 21 days.
-		if time.Since(reopenedAt).Minutes() > 60 {
+		// 2. re-open those that are older than --reopen-after=X days (defaults to 21)
+		if reopenAfter > 0 && time.Since(reopenedAt).Minutes() > 60 {
 			for _, order := range open {
 				side := bittrexOrderSide(&order)
 				if side != model.ORDER_SIDE_NONE {
@@ -736,20 +927,36 @@ func (self *Bittrex) Sell(
 						bittrexLogErrorEx(err, &order, level, service)
 					} else if online {
 						var openedAt time.Time
-						if openedAt, err = time.Parse(exchange.TIME_FORMAT, order.CreatedAt); err != nil {
+						if openedAt, err = clock.Parse(order.CreatedAt, exchange.TIME_FORMAT); err != nil {
 							bittrexLogErrorEx(errors.Wrap(err, 1), &order, level, service)
-						} else if time.Since(openedAt).Hours() >= float64(reopenAfterDays*24) {
+						} else if time.Since(openedAt) >= reopenAfter {
+							price := order.Price()
+							if side == model.SELL && flag.ReopenReprice() {
+								price = self.reopenPrice(client, &order, history, mult)
+							}
+
+							distance := "n/a"
+							if ticker, err := self.GetTicker(client, order.MarketName()); err == nil {
+								distance = humanize.Distance(ticker, price)
+							}
 							bittrexLogInfo(fmt.Sprintf(
-								"Cancelling (and reopening) limit %s %s (market: %s, price: %g, qty: %f, opened at %s) because it is older than %d days.",
-								model.OrderSideString[side], order.Id, order.MarketName(), order.Price(), order.Quantity, order.CreatedAt, reopenAfterDays,
+								"Cancelling (and reopening) limit %s %s (market: %s, price: %s, qty: %s, opened %s, distance to target: %s) because it is older than %s.",
+								model.OrderSideString[side], order.Id, order.MarketName(), model.FormatPrice(self, client, order.MarketName(), price), model.FormatSize(self, client, order.MarketName(), order.Quantity), humanize.Age(openedAt), distance, humanize.Duration(reopenAfter),
 							), level, service)
 
-							var ocoTriggerPrice float64
+							var (
+								raw             []byte
+								ocoTriggerPrice float64
+							)
 							if ocoTriggerPrice, err = bittrexCancelOrder(client, &order); err == nil {
 								if ocoTriggerPrice > 0 {
-									_, err = self.OCO(client, order.MarketName(), order.Quantity, order.Price(), ocoTriggerPrice, "")
+									if raw, err = self.OCO(client, order.MarketName(), order.Quantity, price, ocoTriggerPrice, ""); err == nil {
+										moveExit(self, string(order.Id), bittrexSellId(raw), price)
+									}
 								} else {
-									_, _, err = self.Order(client, side, order.MarketName(), order.Quantity, order.Price(), model.LIMIT, "")
+									if raw, _, err = self.Order(client, side, order.MarketName(), order.Quantity, price, model.LIMIT, ""); err == nil && side == model.SELL {
+										moveExit(self, string(order.Id), string(raw), price)
+									}
 								}
 							}
 
@@ -762,9 +969,114 @@ func (self *Bittrex) Sell(
 			}
 			reopenedAt = time.Now()
 		}
+		// every hour, cancel the conditional orders that are referencing an order that is no longer open.
+		if err == nil && time.Since(collectedAt) > time.Hour {
+			if err = self.collectOrphans(client, level, service); err != nil {
+				bittrexLogError(err, level, service)
+			}
+			collectedAt = time.Now()
+		}
+		// every so often, look for withdrawals that we didn't make
+		watchAccount(self, client, service)
+		beat(self.GetInfo().Name, err)
 	}
 }
 
+// collectOrphans cancels the conditional orders whose OrderToCancel has been filled or cancelled. these accumulate
+// when both legs of an OCO fire at (almost) the same time, and they will fire again later if we leave them alone.
+// we also re-alert the limit sells that OCO failed to attach a stop-loss to.
+func (self *Bittrex) collectOrphans(client *exchange.Client, level int64, service model.Notify) error {
+	// get the conditional orders first. the orders they reference are older than that, so if those aren't open by the
+	// time we ask for the open orders, then they have been filled or cancelled.
+	conditionals, err := client.GetOpenConditionalOrders("all")
+	if err != nil {
+		return errors.Wrap(err, 1)
+	}
+
+	var open exchange.Orders
+	if open, err = client.GetOpenOrders("all"); err != nil {
+		return errors.Wrap(err, 1)
+	}
+
+	// keep on telling you about the limit sells that we failed to attach a stop-loss to
+	var unprotected []Unprotected
+	if unprotected, err = pruneUnprotected(self, func(id string) bool {
+		return open.IndexByOrderId(exchange.OrderId(id)) > -1
+	}); err != nil {
+		return err
+	}
+	for _, entry := range unprotected {
+		bittrexLogError(errors.Errorf(
+			"Unprotected position. Limit sell %s on %s (qty: %s) does not have a stop-loss at %s since %s. %s",
+			entry.OrderId, entry.Market, model.FormatSize(self, client, entry.Market, entry.Size), model.FormatPrice(self, client, entry.Market, entry.Stop), humanize.Age(entry.Time), entry.Error,
+		), level, service)
+	}
+
+	for _, conditional := range conditionals {
+		if conditional.OrderToCancel == nil || conditional.OrderToCancel.OrderType != "ORDER" {
+			continue
+		}
+		if open.IndexByOrderId(conditional.OrderToCancel.Id) > -1 {
+			continue
+		}
+		if err = client.CancelConditionalOrder(conditional.Id); err != nil {
+			return errors.Wrap(err, 1)
+		}
+		market := conditional.MarketName()
+		bittrexLogInfo(fmt.Sprintf(
+			"Cancelled orphaned conditional order %s (market: %s, trigger: %s, qty: %s) because order %s is no longer open.",
+			conditional.Id, market, model.FormatPrice(self, client, market, conditional.TriggerPrice), model.FormatSize(self, client, market, conditional.Quantity()), conditional.OrderToCancel.Id,
+		), level, service)
+	}
+
+	return nil
+}
+
+// reopenPrice returns the current --mult target of a stale sell order. we look for the price you paid in the exit
+// that opened this sell order first, then in the buy order of that exit, then in your positions, and then in your
+// order history. if we cannot find it, then we stick with the old price.
+func (self *Bittrex) reopenPrice(client *exchange.Client, order *exchange.Order, history exchange.Orders, mult multiplier.Mult) float64 {
+	market := order.MarketName()
+
+	var bought float64
+	if exits, err := ReadExits(self); err == nil {
+		if exit := exits.BySellId(string(order.Id)); exit != nil {
+			bought = exit.Bought
+			if bought == 0 && exit.BuyId != "" {
+				if buy, err := client.GetOrder(exchange.OrderId(exit.BuyId)); err == nil {
+					bought = buy.Price()
+				}
+			}
+		}
+	}
+	if bought == 0 {
+		bought, _ = CostBasis(self, market)
+	}
+	if bought == 0 {
+		var youngest time.Time
+		for _, closed := range history {
+			if closed.MarketName() == market && bittrexOrderSide(&closed) == model.BUY && closed.QuantityFilled() > 0 {
+				closedAt, err := clock.Parse(closed.ClosedAt, exchange.TIME_FORMAT)
+				if err == nil && closedAt.After(youngest) {
+					youngest = closedAt
+					bought = closed.Price()
+				}
+			}
+		}
+	}
+	if bought == 0 {
+		log.Printf("[WARN] Cannot find the price you paid for sell order %s on %s. Reopening at %s.\n", order.Id, market, model.FormatPrice(self, client, market, order.Price()))
+		return order.Price()
+	}
+
+	tick, err := model.GetTickSize(self, client, market)
+	if err != nil {
+		return order.Price()
+	}
+
+	return pricing.MultiplyTick(bought, override.Mult(market, mult), tick)
+}
+
 func (self *Bittrex) Order(
 	client interface{},
 	side model.OrderSide,
@@ -774,6 +1086,14 @@ func (self *Bittrex) Order(
 	kind model.OrderType,
 	metadata string,
 ) (oid []byte, raw []byte, err error) {
+	if raw, ok := observeOrder(self, client, side, market1, size, price, kind); ok {
+		return nil, raw, nil
+	}
+
+	if err := CheckEquityShare(self, client, side, market1, size, price); err != nil {
+		return nil, nil, err
+	}
+
 	bittrex, ok := client.(*exchange.Client)
 	if !ok {
 		return nil, nil, errors.New("arg is not a valid v3 client")
@@ -811,11 +1131,72 @@ func (self *Bittrex) Order(
 	return []byte(order.Id), out, nil
 }
 
+func (self *Bittrex) GetStops() model.Stops {
+	return model.Stops{Strategy: true, OCO: true}
+}
+
 func (self *Bittrex) StopLoss(client interface{}, market string, size float64, price float64, kind model.OrderType, metadata string) ([]byte, error) {
 	return nil, errors.New("not implemented")
 }
 
+func (self *Bittrex) TrailingStop(client interface{}, market1 string, size, percent float64, metadata string) ([]byte, error) {
+	if raw, ok := observeStopLoss(self, client, market1, size, 0); ok {
+		return raw, nil
+	}
+
+	bittrex, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("arg is not a valid v3 client")
+	}
+
+	market3, err := self.convertMarket(market1)
+	if err != nil {
+		return nil, err
+	}
+
+	var conditionalOrder *exchange.ConditionalOrder
+	if conditionalOrder, err = bittrex.CreateTrailingStopOrder(market3, percent, &exchange.NewOrder{
+		MarketSymbol: market3,
+		Direction:    exchange.SELL,
+		OrderType:    exchange.MARKET,
+		Quantity:     size,
+		TimeInForce:  exchange.IOC,
+	}); err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	var out []byte
+	if out, err = json.Marshal(conditionalOrder); err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	return out, nil
+}
+
+const (
+	bittrexOCOAttempts = 4               // how many times we try to create the conditional order of an OCO...
+	bittrexOCOBackoff  = 2 * time.Second // ...waiting 2, 4, 8 seconds in between
+)
+
+// bittrexFindConditional returns the open conditional order that cancels the order, or nil if there is none.
+func bittrexFindConditional(client *exchange.Client, market3 string, id exchange.OrderId) *exchange.ConditionalOrder {
+	conditionals, err := client.GetOpenConditionalOrders(market3)
+	if err != nil {
+		return nil
+	}
+	for _, conditional := range conditionals {
+		if conditional.OrderToCancel != nil && conditional.OrderToCancel.Id == id {
+			return &conditional
+		}
+	}
+	return nil
+}
+
 func (self *Bittrex) OCO(client interface{}, market1 string, size float64, price, stop float64, metadata string) ([]byte, error) {
+	if raw, ok := observeOCO(self, client, market1, size, price, stop); ok {
+		return raw, nil
+	}
+
 	var (
 		err error
 		id  []byte
@@ -830,23 +1211,67 @@ func (self *Bittrex) OCO(client interface{}, market1 string, size float64, price
 		return nil, err
 	}
 
-	var conditionalOrder *exchange.ConditionalOrder
-	if conditionalOrder, err = client.(*exchange.Client).CreateConditionalOrder(market3, exchange.LTE, stop, &exchange.NewOrder{
+	// by default, the stop sells at market. with --stop-limit=X, the stop sells down to X percent below the trigger
+	// price. by then, the take profit has been cancelled, so this limit order is good-til-cancelled: on a thin book,
+	// it has to wait for a fill rather than leave the position without an exit.
+	newOrder := &exchange.NewOrder{
 		MarketSymbol: market3,
 		Direction:    exchange.SELL,
 		OrderType:    exchange.MARKET,
 		Quantity:     size,
 		Limit:        0,
 		TimeInForce:  exchange.IOC,
-	}, exchange.OrderId(id)); err != nil {
+	}
+	var exit *model.StopExit
+	if exit, err = model.GetStopExit(); err != nil {
+		return nil, err
+	}
+	if exit.Kind == model.LIMIT {
+		var tick float64
+		if tick, err = model.GetTickSize(self, client, market1); err != nil {
+			return nil, err
+		}
+		newOrder.OrderType = exchange.LIMIT
+		newOrder.Limit = exit.Limit(stop, tick)
+		newOrder.TimeInForce = exchange.GTC
+	}
+
+	var conditionalOrder *exchange.ConditionalOrder
+	for attempt := 0; attempt < bittrexOCOAttempts; attempt++ {
+		if attempt > 0 {
+			time.Sleep(bittrexOCOBackoff << (attempt - 1))
+			// the previous attempt might have made it to the exchange, even though we got an error. don't create
+			// the conditional order twice.
+			if conditionalOrder = bittrexFindConditional(client.(*exchange.Client), market3, exchange.OrderId(id)); conditionalOrder != nil {
+				err = nil
+				break
+			}
+		}
+		if conditionalOrder, err = client.(*exchange.Client).CreateConditionalOrder(market3, exchange.LTE, stop, newOrder, exchange.OrderId(id)); err == nil {
+			break
+		}
 		if strings.Contains(err.Error(), "INVALID_CANCEL_ORDER") {
 			// the above limit sell order probably got filled before we had the
 			// opportunity to create this conditional order. ignore this error.
 			log.Printf("[ERROR] %v", err)
 			return nil, nil
-		} else {
-			return nil, errors.Wrap(err, 1)
 		}
+		log.Printf("[WARN] attempt #%d to create a conditional order on %s failed: %v", attempt+1, market1, err)
+	}
+	if err != nil {
+		// the limit sell is open, but it doesn't have a stop-loss. remember that, so we can keep on telling you.
+		if err := markUnprotected(self, Unprotected{
+			Market:  market1,
+			OrderId: string(id),
+			Size:    size,
+			Price:   price,
+			Stop:    stop,
+			Error:   err.Error(),
+			Time:    time.Now(),
+		}); err != nil {
+			log.Printf("[ERROR] %v", err)
+		}
+		return nil, errors.Errorf("limit sell %s on %s does not have a stop-loss at %s. %v", id, market1, model.FormatPrice(self, client, market1, stop), err)
 	}
 
 	var out []byte
@@ -870,6 +1295,16 @@ func (self *Bittrex) GetClosed(client interface{}, market1 string) (model.Orders
 		return nil, err
 	}
 
+	// the commission is in quote currency
+	var markets []model.Market
+	if markets, err = self.GetMarkets(true, flag.Sandbox(), nil); err != nil {
+		return nil, err
+	}
+	var quote string
+	if quote, err = model.GetQuoteCurr(markets, market1); err != nil {
+		return nil, err
+	}
+
 	var history exchange.Orders
 	if history, err = bittrex.GetOrderHistory(market3); err != nil {
 		return nil, errors.Wrap(err, 1)
@@ -877,16 +1312,24 @@ func (self *Bittrex) GetClosed(client interface{}, market1 string) (model.Orders
 
 	var out model.Orders
 	for _, order := range history {
-		var closedAt time.Time
-		if closedAt, err = time.Parse(exchange.TIME_FORMAT, order.ClosedAt); err != nil {
+		var createdAt, closedAt time.Time
+		if createdAt, err = clock.Parse(order.CreatedAt, exchange.TIME_FORMAT); err != nil {
+			return nil, errors.Wrap(err, 1)
+		}
+		if closedAt, err = clock.Parse(order.ClosedAt, exchange.TIME_FORMAT); err != nil {
 			return nil, errors.Wrap(err, 1)
 		}
 		out = append(out, model.Order{
-			Side:      bittrexOrderSide(&order),
-			Market:    market1,
-			Size:      order.Quantity,
-			Price:     order.Price(),
-			CreatedAt: closedAt,
+			Side:       bittrexOrderSide(&order),
+			Status:     model.FILLED,
+			Market:     market1,
+			Size:       order.Quantity,
+			Price:      order.Price(),
+			CreatedAt:  createdAt,
+			ClosedAt:   model.TimePtr(closedAt),
+			FilledSize: order.FillQuantity,
+			Fee:        order.Commission,
+			FeeAsset:   quote,
 		})
 	}
 
@@ -914,11 +1357,13 @@ func (self *Bittrex) GetOpened(client interface{}, market1 string) (model.Orders
 	var out model.Orders
 	for _, order := range orders {
 		var openedAt time.Time
-		if openedAt, err = time.Parse(exchange.TIME_FORMAT, order.CreatedAt); err != nil {
+		if openedAt, err = clock.Parse(order.CreatedAt, exchange.TIME_FORMAT); err != nil {
 			return nil, errors.Wrap(err, 1)
 		}
 		out = append(out, model.Order{
+			Status:    model.OPEN,
 			Side:      bittrexOrderSide(&order),
+			ID:        string(order.Id),
 			Market:    market1,
 			Size:      order.Quantity,
 			Price:     order.Price(),
@@ -929,6 +1374,69 @@ func (self *Bittrex) GetOpened(client interface{}, market1 string) (model.Orders
 	return out, nil
 }
 
+func (self *Bittrex) GetOpenConditionals(client interface{}, market1 string) (model.Orders, error) {
+	var err error
+
+	bittrex, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("arg is not a valid v3 client")
+	}
+
+	var market3 string
+	if market3, err = self.convertMarket(market1); err != nil {
+		return nil, err
+	}
+
+	var conditionals []exchange.ConditionalOrder
+	if conditionals, err = bittrex.GetOpenConditionalOrders(market3); err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	var out model.Orders
+	for _, conditional := range conditionals {
+		var openedAt time.Time
+		if openedAt, err = clock.Parse(conditional.CreatedAt, exchange.TIME_FORMAT); err != nil {
+			return nil, errors.Wrap(err, 1)
+		}
+		side := model.ORDER_SIDE_NONE
+		if conditional.Direction() == exchange.OrderSideString[exchange.BUY] {
+			side = model.BUY
+		} else if conditional.Direction() == exchange.OrderSideString[exchange.SELL] {
+			side = model.SELL
+		}
+		out = append(out, model.Order{
+			Status:      model.OPEN,
+			Side:        side,
+			ID:          string(conditional.Id),
+			Market:      market1,
+			Size:        conditional.Quantity(),
+			Price:       conditional.Limit(),
+			CreatedAt:   openedAt,
+			Stop:        conditional.TriggerPrice,
+			Conditional: true,
+		})
+	}
+
+	return out, nil
+}
+
+func (self *Bittrex) CancelConditional(client interface{}, market1 string, id string) error {
+	if observeCancelOrder(self, market1, id) {
+		return nil
+	}
+
+	bittrex, ok := client.(*exchange.Client)
+	if !ok {
+		return errors.New("invalid argument: client")
+	}
+
+	if err := bittrex.CancelConditionalOrder(exchange.OrderId(id)); err != nil {
+		return errors.Wrap(err, 1)
+	}
+
+	return nil
+}
+
 func (self *Bittrex) GetBook(client interface{}, market1 string, side model.BookSide) (interface{}, error) {
 	var err error
 
@@ -1009,6 +1517,25 @@ func (self *Bittrex) GetTicker(client interface{}, market1 string) (float64, err
 	return ticker.LastTradeRate, nil
 }
 
+func (self *Bittrex) GetTickers(client interface{}) (map[string]float64, error) {
+	bittrex, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("arg is not a valid v3 client")
+	}
+
+	tickers, err := bittrex.GetTickers()
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	out := make(map[string]float64)
+	for _, ticker := range tickers {
+		out[ticker.MarketName()] = ticker.LastTradeRate
+	}
+
+	return out, nil
+}
+
 func (self *Bittrex) Get24h(client interface{}, market1 string) (*model.Stats, error) {
 	bittrex, ok := client.(*exchange.Client)
 	if !ok {
@@ -1029,10 +1556,11 @@ func (self *Bittrex) Get24h(client interface{}, market1 string) (*model.Stats, e
 		Market: market1,
 		High:   sum.High,
 		Low:    sum.Low,
+		Change: sum.PercentChange,
 		BtcVolume: func() float64 {
-			_, quote, err := bittrexParseMarket(market1, 1)
+			symbol, err := model.ParseSymbol(market1, bittrexSymbol)
 			if err == nil {
-				if strings.EqualFold(quote, model.BTC) {
+				if strings.EqualFold(symbol.Quote, model.BTC) {
 					return sum.QuoteVolume
 				}
 			}
@@ -1041,6 +1569,44 @@ func (self *Bittrex) Get24h(client interface{}, market1 string) (*model.Stats, e
 	}, nil
 }
 
+func (self *Bittrex) Get24hAll(client interface{}) (map[string]model.Stats, error) {
+	bittrex, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("arg is not a valid v3 client")
+	}
+
+	summaries, err := bittrex.GetMarketSummaries()
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	// the summaries do not include the last price
+	tickers, err := self.GetTickers(client)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]model.Stats)
+	volume := make(map[string]float64) // in quote currency
+	for _, sum := range summaries {
+		market1 := (&exchange.Ticker{Symbol: sum.Symbol}).MarketName()
+		out[market1] = model.Stats{
+			Market: market1,
+			High:   sum.High,
+			Low:    sum.Low,
+			Last:   tickers[market1],
+			Change: sum.PercentChange,
+		}
+		volume[market1] = sum.QuoteVolume
+	}
+
+	if err = setBtcVolume(self, out, volume); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
 func (self *Bittrex) GetPricePrec(client interface{}, market1 string) (int, error) {
 	bittrex, ok := client.(*exchange.Client)
 	if !ok {
@@ -1070,6 +1636,10 @@ func (self *Bittrex) GetMaxSize(client interface{}, base, quote string, hold, ea
 }
 
 func (self *Bittrex) Cancel(client interface{}, market1 string, side model.OrderSide) error {
+	if observeCancel(self, market1, side) {
+		return nil
+	}
+
 	var err error
 
 	bittrex, ok := client.(*exchange.Client)
@@ -1087,6 +1657,28 @@ func (self *Bittrex) Cancel(client interface{}, market1 string, side model.Order
 		return errors.Wrap(err, 1)
 	}
 
+	var conditionals []exchange.ConditionalOrder
+	if conditionals, err = bittrex.GetOpenConditionalOrders(market3); err != nil {
+		return errors.Wrap(err, 1)
+	}
+
+	// cancel the conditional orders first, otherwise they might fire while we are cancelling the orders they reference
+	for _, conditional := range conditionals {
+		cancel := conditional.Direction() == exchange.OrderSideString[exchange.BUY] && side == model.BUY ||
+			conditional.Direction() == exchange.OrderSideString[exchange.SELL] && side == model.SELL
+		if !cancel && conditional.OrderToCancel != nil {
+			// is this conditional order referencing an order we are about to cancel?
+			if i := orders.IndexByOrderId(conditional.OrderToCancel.Id); i > -1 {
+				cancel = bittrexOrderSide(&orders[i]) == side
+			}
+		}
+		if cancel {
+			if err = bittrex.CancelConditionalOrder(conditional.Id); err != nil {
+				return errors.Wrap(err, 1)
+			}
+		}
+	}
+
 	for _, order := range orders {
 		if bittrexOrderSide(&order) == side {
 			if err = bittrex.CancelOrder(order.Id); err != nil {
@@ -1098,7 +1690,90 @@ func (self *Bittrex) Cancel(client interface{}, market1 string, side model.Order
 	return nil
 }
 
+func (self *Bittrex) CancelOrder(client interface{}, market1 string, id string) error {
+	if observeCancelOrder(self, market1, id) {
+		return nil
+	}
+
+	bittrex, ok := client.(*exchange.Client)
+	if !ok {
+		return errors.New("invalid argument: client")
+	}
+
+	if err := bittrex.CancelOrder(exchange.OrderId(id)); err != nil {
+		return errors.Wrap(err, 1)
+	}
+
+	return nil
+}
+
+func (self *Bittrex) GetOrder(client interface{}, market1 string, id string) (*model.Order, error) {
+	bittrex, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	order, err := bittrex.GetOrder(exchange.OrderId(id))
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	out := &model.Order{
+		ID:         id,
+		Side:       bittrexOrderSide(order),
+		Status:     model.OPEN,
+		Market:     market1,
+		Size:       order.Quantity,
+		Price:      order.Price(),
+		FilledSize: order.FillQuantity,
+		Fee:        order.Commission,
+	}
+	if out.CreatedAt, err = clock.Parse(order.CreatedAt, exchange.TIME_FORMAT); err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+	if order.ClosedAt != "" {
+		var closedAt time.Time
+		if closedAt, err = clock.Parse(order.ClosedAt, exchange.TIME_FORMAT); err != nil {
+			return nil, errors.Wrap(err, 1)
+		}
+		out.ClosedAt = &closedAt
+		if order.FillQuantity > 0 {
+			out.Status = model.FILLED
+		} else {
+			out.Status = model.CANCELLED
+		}
+	}
+
+	return out, nil
+}
+
+func (self *Bittrex) GetFees(client interface{}, market1 string) (*model.Fees, error) {
+	bittrex, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	market3, err := self.convertMarket(market1)
+	if err != nil {
+		return nil, err
+	}
+
+	fee, err := bittrex.GetTradingFee(market3)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	return &model.Fees{
+		Maker: fee.MakerRate,
+		Taker: fee.TakerRate,
+	}, nil
+}
+
 func (self *Bittrex) Buy(client interface{}, cancel bool, market1 string, calls model.Calls, deviation float64, kind model.OrderType) error {
+	if observeBuy(self, client, cancel, market1, calls, kind) {
+		return nil
+	}
+
 	var err error
 
 	bittrex, ok := client.(*exchange.Client)
@@ -1121,8 +1796,8 @@ func (self *Bittrex) Buy(client interface{}, cancel bool, market1 string, calls
 			side := bittrexOrderSide(&order)
 			if side == model.BUY {
 				// do not cancel orders that we're about to re-place
-				index := calls.IndexByPrice(order.Price())
-				if index > -1 && order.Quantity == calls[index].Size {
+				index := calls.IndexByPriceSize(order.Price(), order.Quantity)
+				if index > -1 {
 					calls[index].Skip = true
 				} else {
 					if err = bittrex.CancelOrder(order.Id); err != nil {
@@ -1193,6 +1868,57 @@ func (self *Bittrex) HasAlgoOrder(client interface{}, market1 string) (bool, err
 	return len(conditionals) > 0, nil
 }
 
+// GetSimulation returns the rules that a paper trade on Bittrex must obey: Bittrex cancels the orders that are older
+// than 28 days, and refuses the orders that are smaller than the min trade size of the market.
+func (self *Bittrex) GetSimulation(client interface{}, market1 string) (*model.Simulation, error) {
+	out := &model.Simulation{
+		MaxOrderAge: 28 * 24 * time.Hour,
+	}
+
+	bittrex, ok := client.(*exchange.Client)
+	if !ok {
+		return out, nil
+	}
+
+	var err error
+	if out.MinSize, err = self.minTradeSize(bittrex, market1); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// GetAccountEvents returns the open withdrawals, plus the withdrawals that closed since a moment in time
+func (self *Bittrex) GetAccountEvents(client interface{}, since time.Time) ([]model.AccountEvent, error) {
+	bittrex, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	withdrawals, err := bittrex.GetWithdrawals(since)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	var out []model.AccountEvent
+	for _, withdrawal := range withdrawals {
+		createdAt, err := clock.Parse(withdrawal.CreatedAt, exchange.TIME_FORMAT)
+		if err != nil {
+			return nil, errors.Wrap(err, 1)
+		}
+		out = append(out, model.AccountEvent{
+			Id:     withdrawal.Id,
+			Time:   createdAt,
+			Kind:   "withdrawal",
+			Asset:  withdrawal.CurrencySymbol,
+			Amount: withdrawal.Quantity,
+			Detail: withdrawal.CryptoAddress,
+		})
+	}
+
+	return out, nil
+}
+
 func newBittrex() model.Exchange {
 	return &Bittrex{
 		ExchangeInfo: &model.ExchangeInfo{
@@ -0,0 +1,187 @@
+package exchanges
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/session"
+)
+
+type (
+	// candleRange is a period [Start, End) that we have asked the exchange for. a market without trades doesn't
+	// have candles, so we need to remember what we have asked for, not just what we got.
+	candleRange struct {
+		Start time.Time `json:"start"`
+		End   time.Time `json:"end"`
+	}
+	candleStore struct {
+		Candles model.Candles `json:"candles"`
+		Covered []candleRange `json:"covered"`
+	}
+)
+
+var candlesMutex sync.Mutex
+
+func candlesFile(exchange model.Exchange, market string, interval time.Duration) string {
+	name := strings.NewReplacer("/", "-", "\\", "-", ":", "-").Replace(market)
+	return session.GetSessionFile(fmt.Sprintf("%s.candles.%s.%s.json", strings.ToLower(exchange.GetInfo().Code), name, model.FormatInterval(interval)))
+}
+
+func readCandles(exchange model.Exchange, market string, interval time.Duration) (*candleStore, error) {
+	var out candleStore
+	data, err := session.ReadFile(candlesFile(exchange, market, interval))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &out, nil
+		}
+		return nil, errors.Wrap(err, 1)
+	}
+	if err = json.Unmarshal(data, &out); err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+	return &out, nil
+}
+
+func (store *candleStore) write(exchange model.Exchange, market string, interval time.Duration) error {
+	data, err := json.Marshal(store)
+	if err != nil {
+		return errors.Wrap(err, 1)
+	}
+	return session.WriteFile(candlesFile(exchange, market, interval), data)
+}
+
+// gaps returns the parts of [start, end) that we haven't asked the exchange for yet
+func (store *candleStore) gaps(start, end time.Time) []candleRange {
+	var out []candleRange
+	cursor := start
+	for _, covered := range store.Covered {
+		if !covered.End.After(cursor) {
+			continue
+		}
+		if !covered.Start.Before(end) {
+			break
+		}
+		if covered.Start.After(cursor) {
+			out = append(out, candleRange{Start: cursor, End: covered.Start})
+		}
+		cursor = covered.End
+	}
+	if cursor.Before(end) {
+		out = append(out, candleRange{Start: cursor, End: end})
+	}
+	return out
+}
+
+// cover adds the candles and the period we've asked for to the store, merging overlapping periods
+func (store *candleStore) cover(period candleRange, candles model.Candles) {
+	index := make(map[int64]int)
+	for i, candle := range store.Candles {
+		index[candle.Time.Unix()] = i
+	}
+	for _, candle := range candles {
+		if i, ok := index[candle.Time.Unix()]; ok {
+			store.Candles[i] = candle
+		} else {
+			store.Candles = append(store.Candles, candle)
+		}
+	}
+	sort.Slice(store.Candles, func(i, j int) bool {
+		return store.Candles[i].Time.Before(store.Candles[j].Time)
+	})
+
+	ranges := append(store.Covered, period)
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].Start.Before(ranges[j].Start)
+	})
+	var merged []candleRange
+	for _, r := range ranges {
+		if len(merged) > 0 && !r.Start.After(merged[len(merged)-1].End) {
+			if r.End.After(merged[len(merged)-1].End) {
+				merged[len(merged)-1].End = r.End
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+	store.Covered = merged
+}
+
+// GetCandles returns the candles of a market that opened in [start, end), oldest first. we read them from disk,
+// and ask the exchange for the candles we don't have yet. the candle that is still open (and every candle after
+// that) is never stored, so that we ask for it again next time.
+func GetCandles(exchange model.Exchange, client interface{}, market string, interval time.Duration, start, end time.Time) (model.Candles, error) {
+	reader, ok := exchange.(model.CandleReader)
+	if !ok {
+		return nil, errors.Errorf("%s does not support candles", exchange.GetInfo().Name)
+	}
+	if interval <= 0 {
+		return nil, errors.Errorf("interval %v is invalid", interval)
+	}
+
+	start = start.UTC().Truncate(interval)
+	end = end.UTC()
+	// the candles that closed before now are final
+	final := time.Now().UTC().Truncate(interval)
+	if end.After(final) {
+		end = final
+	}
+
+	candlesMutex.Lock()
+	defer candlesMutex.Unlock()
+
+	store, err := readCandles(exchange, market, interval)
+	if err != nil {
+		return nil, err
+	}
+
+	var dirty bool
+	for _, gap := range store.gaps(start, end) {
+		cursor := gap.Start
+		for cursor.Before(gap.End) {
+			candles, err := reader.GetCandles(client, market, interval, cursor, gap.End)
+			if err != nil {
+				if dirty {
+					store.write(exchange, market, interval)
+				}
+				return nil, err
+			}
+			if len(candles) == 0 {
+				// no trades during the rest of this gap
+				store.cover(candleRange{Start: cursor, End: gap.End}, nil)
+				dirty = true
+				break
+			}
+			next := candles[len(candles)-1].Time.Add(interval)
+			if !next.After(cursor) {
+				return nil, errors.Errorf("%s returned candles before %v", exchange.GetInfo().Name, cursor)
+			}
+			if next.After(gap.End) {
+				next = gap.End
+			}
+			store.cover(candleRange{Start: cursor, End: next}, candles)
+			dirty = true
+			cursor = next
+		}
+	}
+
+	if dirty {
+		if err = store.write(exchange, market, interval); err != nil {
+			return nil, err
+		}
+	}
+
+	var out model.Candles
+	for _, candle := range store.Candles {
+		if !candle.Time.Before(start) && candle.Time.Before(end) {
+			out = append(out, candle)
+		}
+	}
+	return out, nil
+}
@@ -10,26 +10,28 @@ import (
 	"strings"
 	"time"
 
-	filemutex "github.com/alexflint/go-filemutex"
 	"github.com/svanas/nefertiti/aggregation"
 	exchange "github.com/svanas/nefertiti/cexio"
 	"github.com/svanas/nefertiti/errors"
 	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/i18n"
 	"github.com/svanas/nefertiti/model"
 	"github.com/svanas/nefertiti/multiplier"
 	"github.com/svanas/nefertiti/notify"
+	"github.com/svanas/nefertiti/override"
 	"github.com/svanas/nefertiti/precision"
 	"github.com/svanas/nefertiti/pricing"
 	"github.com/svanas/nefertiti/session"
 )
 
 var (
-	cexioMutex *filemutex.FileMutex
+	cexioMutex *session.Mutex
 )
 
 const (
-	cexioSessionFile = "cexio.time"
-	cexioSessionLock = "cexio.lock"
+	cexioSessionFile  = "cexio.time"
+	cexioSessionLock  = "cexio.lock"
+	cexioSessionNonce = "cexio"
 )
 
 func init() {
@@ -37,7 +39,7 @@ func init() {
 		var err error
 
 		if cexioMutex == nil {
-			if cexioMutex, err = filemutex.New(session.GetSessionFile(cexioSessionLock)); err != nil {
+			if cexioMutex, err = session.NewMutex(cexioSessionLock); err != nil {
 				return err
 			}
 		}
@@ -74,6 +76,18 @@ func init() {
 		}()
 		session.SetLastRequest(cexioSessionFile, time.Now())
 	}
+	exchange.LockNonce = func(apiKey string, min int64) (int64, func(), error) {
+		lock, err := session.LockNonce(cexioSessionNonce, apiKey)
+		if err != nil {
+			return 0, nil, err
+		}
+		nonce, err := lock.Next(min)
+		if err != nil {
+			lock.Unlock()
+			return 0, nil, err
+		}
+		return nonce, lock.Unlock, nil
+	}
 }
 
 type CexIo struct {
@@ -97,7 +111,7 @@ func (self *CexIo) error(err error, level int64, service model.Notify) {
 	log.Printf("[ERROR] %s:%d %s", filepath.Base(file), line, strings.Replace(str, "\n\n", " ", -1))
 	if service != nil {
 		if notify.CanSend(level, notify.ERROR) {
-			service.SendMessage(str, "CEX.IO - ERROR", model.ONCE_PER_MINUTE)
+			notify.SendError(service, err, str, "CEX.IO - ERROR")
 		}
 	}
 }
@@ -113,11 +127,18 @@ func (self *CexIo) encodePair(symbol1, symbol2 string) (string, error) {
 }
 
 func (self *CexIo) decodePair(pair string) (symbol1, symbol2 string, err error) {
-	symbols := strings.Split(pair, "-")
-	if len(symbols) > 1 {
-		return symbols[0], symbols[1], nil
+	symbol, err := model.ParseSymbol(pair, cexioSymbol)
+	if err != nil {
+		return "", "", err
 	}
-	return "", "", errors.New("Invalid market pair: " + pair)
+	return symbol.Base, symbol.Quote, nil
+}
+
+func (self *CexIo) newClient(apiKey, apiSecret, userName string) *exchange.Client {
+	out := exchange.New(apiKey, apiSecret, userName)
+	// CEX.IO appends the path to its endpoint, so the endpoint needs its trailing slash
+	out.URL = strings.TrimSuffix(self.ExchangeInfo.REST.URI, "/") + "/"
+	return out
 }
 
 func (self *CexIo) GetInfo() *model.ExchangeInfo {
@@ -126,7 +147,7 @@ func (self *CexIo) GetInfo() *model.ExchangeInfo {
 
 func (self *CexIo) GetClient(permission model.Permission, sandbox bool) (interface{}, error) {
 	if permission != model.PRIVATE {
-		return exchange.New("", "", ""), nil
+		return self.newClient("", "", ""), nil
 	}
 
 	var (
@@ -139,7 +160,7 @@ func (self *CexIo) GetClient(permission model.Permission, sandbox bool) (interfa
 		return nil, err
 	}
 
-	return exchange.New(apiKey, apiSecret, userName), nil
+	return self.newClient(apiKey, apiSecret, userName), nil
 }
 
 func (self *CexIo) GetMarkets(cached, sandbox bool, ignore []string) ([]model.Market, error) {
@@ -148,7 +169,7 @@ func (self *CexIo) GetMarkets(cached, sandbox bool, ignore []string) ([]model.Ma
 		out []model.Market
 	)
 
-	client := exchange.New("", "", "")
+	client := self.newClient("", "", "")
 
 	var pairs []exchange.Pair
 	if pairs, err = client.CurrencyLimits(); err != nil {
@@ -169,8 +190,11 @@ func (self *CexIo) GetMarkets(cached, sandbox bool, ignore []string) ([]model.Ma
 	return out, nil
 }
 
+// how CexIo spells its markets
+var cexioSymbol = model.SymbolFormat{Separator: "-"}
+
 func (self *CexIo) FormatMarket(base, quote string) string {
-	return fmt.Sprintf("%s-%s", base, quote)
+	return model.NewMarketSymbol(base, quote).Format(cexioSymbol)
 }
 
 // listens to the open orders, look for cancelled orders, send a notification.
@@ -208,7 +232,7 @@ func (self *CexIo) listen(
 				side := order.Side()
 				if side != exchange.SIDE_UNKNOWN {
 					if service != nil && notify.CanSend(level, notify.CANCELLED) {
-						if err = service.SendMessage(order, fmt.Sprintf("CEX.IO - Done %s (Reason: Cancelled)", strings.Title(order.Type)), model.ALWAYS); err != nil {
+						if err = service.SendMessage(order, fmt.Sprintf("CEX.IO - %s", i18n.Sprintf("Done %s (Reason: Cancelled)", strings.Title(order.Type))), model.ALWAYS); err != nil {
 							log.Printf("[ERROR] %v", err)
 						}
 					}
@@ -231,7 +255,7 @@ func (self *CexIo) listen(
 				side := order.Side()
 				if side != exchange.SIDE_UNKNOWN {
 					if notify.CanSend(level, notify.OPENED) || (level == notify.LEVEL_DEFAULT && side == exchange.SELL) {
-						if err = service.SendMessage(order, ("CEX.IO - Open " + strings.Title(order.Type)), model.ALWAYS); err != nil {
+						if err = service.SendMessage(order, ("CEX.IO - " + i18n.Sprintf("Open %s", strings.Title(order.Type))), model.ALWAYS); err != nil {
 							log.Printf("[ERROR] %v", err)
 						}
 					}
@@ -269,7 +293,7 @@ func (self *CexIo) sell(
 
 	// look for filled orders
 	for _, order := range new {
-		if old.IndexById(order.Id) == -1 {
+		if old.IndexById(order.Id) == -1 && processOnce(self, order.Id) {
 			var data []byte
 			if data, err = json.Marshal(order); err != nil {
 				return new, err
@@ -281,12 +305,12 @@ func (self *CexIo) sell(
 			if side != exchange.SIDE_UNKNOWN {
 				if notify.CanSend(level, notify.FILLED) {
 					if service != nil {
-						if err = service.SendMessage(order, fmt.Sprintf("CEX.IO - Done %s (Reason: Filled %f qty)", strings.Title(order.Type), order.Amount), model.ALWAYS); err != nil {
+						if err = service.SendMessage(order, fmt.Sprintf("CEX.IO - %s", i18n.Sprintf("Done %s (Reason: Filled %s qty)", strings.Title(order.Type), model.FormatSize(self, client, self.FormatMarket(order.Symbol1, order.Symbol2), order.Amount))), model.ALWAYS); err != nil {
 							log.Printf("[ERROR] %v", err)
 						}
 					}
 					if twitter != nil {
-						notify.Tweet(twitter, fmt.Sprintf("Done %s. $%s-%s priced at %f #CEXIO", strings.Title(order.Type), order.Symbol1, order.Symbol2, order.Price))
+						notify.Tweet(twitter, fmt.Sprintf("Done %s. $%s-%s priced at %s #CEXIO", strings.Title(order.Type), order.Symbol1, order.Symbol2, model.FormatPrice(self, client, self.FormatMarket(order.Symbol1, order.Symbol2), order.Price)))
 					}
 				}
 				// has a buy order been filled? then place a sell order
@@ -299,15 +323,18 @@ func (self *CexIo) sell(
 						)
 						base, quote, err = model.ParseMarket(markets, market)
 						if err == nil {
-							qty := self.GetMaxSize(client, base, quote, hold.HasMarket(market), earn.HasMarket(market), order.Amount, mult)
+							qty := self.GetMaxSize(client, base, quote, hold.HasMarket(market), earn.HasMarket(market), order.Amount, override.Mult(market, mult))
 							if qty > 0 {
-								var prec int
-								if prec, err = self.GetPricePrec(client, market); err == nil {
-									_, err = client.PlaceOrder(
-										order.Symbol1, order.Symbol2, exchange.SELL,
-										qty,
-										pricing.Multiply(order.Price, mult, prec),
-									)
+								var tick float64
+								if tick, err = model.GetTickSize(self, client, market); err == nil {
+									limit := pricing.MultiplyTick(order.Price, override.Mult(market, mult), tick)
+									if _, ok := observeOrder(self, client, model.SELL, market, qty, limit, model.LIMIT); !ok {
+										_, err = client.PlaceOrder(
+											order.Symbol1, order.Symbol2, exchange.SELL,
+											qty,
+											limit,
+										)
+									}
 								}
 							}
 						}
@@ -357,7 +384,7 @@ func (self *CexIo) Sell(
 		}
 	}
 
-	client := exchange.New(apiKey, apiSecret, userName)
+	client := self.newClient(apiKey, apiSecret, userName)
 
 	// get my open orders
 	var open []exchange.Order
@@ -394,6 +421,7 @@ func (self *CexIo) Sell(
 		if open, err = self.listen(client, service, level, open); err != nil {
 			self.error(err, level, service)
 		}
+		beat(self.GetInfo().Name, err)
 	}
 }
 
@@ -406,6 +434,14 @@ func (self *CexIo) Order(
 	kind model.OrderType,
 	metadata string,
 ) (oid []byte, raw []byte, err error) {
+	if raw, ok := observeOrder(self, client, side, market, size, price, kind); ok {
+		return nil, raw, nil
+	}
+
+	if err := CheckEquityShare(self, client, side, market, size, price); err != nil {
+		return nil, nil, err
+	}
+
 	cexio, ok := client.(*exchange.Client)
 	if !ok {
 		return nil, nil, errors.New("invalid argument: client")
@@ -467,6 +503,7 @@ func (self *CexIo) GetClosed(client interface{}, market string) (model.Orders, e
 	for _, order := range orders {
 		out = append(out, model.Order{
 			Side:   model.NewOrderSide(order.Type),
+			Status: model.FILLED,
 			Market: market,
 			Size:   order.Amount,
 			Price:  order.Price,
@@ -498,7 +535,9 @@ func (self *CexIo) GetOpened(client interface{}, market string) (model.Orders, e
 	var out model.Orders
 	for _, order := range orders {
 		out = append(out, model.Order{
+			Status: model.OPEN,
 			Side:   model.NewOrderSide(order.Type),
+			ID:     order.Id,
 			Market: market,
 			Size:   order.Amount,
 			Price:  order.Price,
@@ -587,6 +626,10 @@ func (self *CexIo) GetTicker(client interface{}, market string) (float64, error)
 	return ticker.Last, nil
 }
 
+func (self *CexIo) GetTickers(client interface{}) (map[string]float64, error) {
+	return getTickers(self, client)
+}
+
 func (self *CexIo) Get24h(client interface{}, market string) (*model.Stats, error) {
 	cexio, ok := client.(*exchange.Client)
 	if !ok {
@@ -608,9 +651,15 @@ func (self *CexIo) Get24h(client interface{}, market string) (*model.Stats, erro
 		High:      ticker.High,
 		Low:       ticker.Low,
 		BtcVolume: 0,
+		Last:      ticker.Last,
+		Change:    ticker.Change,
 	}, nil
 }
 
+func (self *CexIo) Get24hAll(client interface{}) (map[string]model.Stats, error) {
+	return get24hAll(self, client)
+}
+
 // see: https://blog.cex.io/news/precision-and-minimum-order-size-change-for-certain-trading-pairs-20957
 func (self *CexIo) GetPricePrec(client interface{}, market string) (int, error) {
 	if out, ok := func() map[string]int {
@@ -713,6 +762,10 @@ func (self *CexIo) GetMaxSize(client interface{}, base, quote string, hold, earn
 }
 
 func (self *CexIo) Cancel(client interface{}, market string, side model.OrderSide) error {
+	if observeCancel(self, market, side) {
+		return nil
+	}
+
 	var err error
 
 	cexio, ok := client.(*exchange.Client)
@@ -742,7 +795,60 @@ func (self *CexIo) Cancel(client interface{}, market string, side model.OrderSid
 	return nil
 }
 
+func (self *CexIo) CancelOrder(client interface{}, market, id string) error {
+	if observeCancelOrder(self, market, id) {
+		return nil
+	}
+
+	cexio, ok := client.(*exchange.Client)
+	if !ok {
+		return errors.New("invalid argument: client")
+	}
+
+	return cexio.CancelOrder(id)
+}
+
+func (self *CexIo) GetOrder(client interface{}, market, id string) (*model.Order, error) {
+	cexio, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	order, err := cexio.GetOrder(id)
+	if err != nil {
+		return nil, err
+	}
+
+	createdAt, _ := order.GetTime()
+	out := &model.Order{
+		ID:         id,
+		Status:     model.OPEN,
+		Side:       model.NewOrderSide(order.Type),
+		Market:     market,
+		Size:       order.Amount,
+		Price:      order.Price,
+		CreatedAt:  createdAt,
+		FilledSize: order.Amount - order.Remains,
+	}
+	switch order.Status {
+	case exchange.ORDER_STATUS_DONE:
+		out.Status = model.FILLED
+	case exchange.ORDER_STATUS_CANCELLED, exchange.ORDER_STATUS_CANCELLED_PARTIALLY:
+		out.Status = model.CANCELLED
+	}
+
+	return out, nil
+}
+
+func (self *CexIo) GetFees(client interface{}, market string) (*model.Fees, error) {
+	return getFees(self)
+}
+
 func (self *CexIo) Buy(client interface{}, cancel bool, market string, calls model.Calls, deviation float64, kind model.OrderType) error {
+	if observeBuy(self, client, cancel, market, calls, kind) {
+		return nil
+	}
+
 	var err error
 
 	cexio, ok := client.(*exchange.Client)
@@ -0,0 +1,72 @@
+package exchanges
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/svanas/nefertiti/chart"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/multiplier"
+	"github.com/svanas/nefertiti/notify"
+	"github.com/svanas/nefertiti/override"
+)
+
+// the last so many hours go into the chart of a filled order
+const chartHours = 48
+
+// sendChart sends a candlestick chart of a filled order (with --chart), if the exchange has candles and the
+// notification service can send images. a filled buy shows our entry, our target and (with the stop-loss strategy)
+// our stop. a filled sell shows where we sold.
+func sendChart(
+	exchange model.Exchange,
+	client interface{},
+	service model.Notify,
+	strategy model.Strategy,
+	market string,
+	side model.OrderSide,
+	price float64,
+	mult, stop multiplier.Mult,
+	title string,
+) {
+	if !flag.Chart() || service == nil || price == 0 {
+		return
+	}
+	image, ok := service.(notify.Image)
+	if !ok {
+		return
+	}
+	if _, ok := exchange.(model.CandleReader); !ok {
+		return
+	}
+
+	if err := func() error {
+		end := time.Now()
+		candles, err := GetCandles(exchange, client, market, time.Hour, end.Add(-chartHours*time.Hour), end)
+		if err != nil {
+			return err
+		}
+
+		lines := []chart.Line{{Price: price, Color: chart.Entry}}
+		caption := fmt.Sprintf("%s %s @ %v", model.FormatOrderSide(side), market, price)
+		if side == model.BUY {
+			target := price * float64(override.Mult(market, mult))
+			lines = append(lines, chart.Line{Price: target, Color: chart.Target})
+			caption = fmt.Sprintf("%s, target %v", caption, target)
+			if strategy == model.STRATEGY_STOP_LOSS {
+				stopped := price * float64(override.Stop(market, stop))
+				lines = append(lines, chart.Line{Price: stopped, Color: chart.Stop})
+				caption = fmt.Sprintf("%s, stop %v", caption, stopped)
+			}
+		}
+
+		data, err := chart.Render(candles, lines...)
+		if err != nil {
+			return err
+		}
+		return image.SendImage(data, caption, title)
+	}(); err != nil {
+		log.Printf("[ERROR] %v", err)
+	}
+}
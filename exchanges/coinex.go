@@ -0,0 +1,993 @@
+//lint:file-ignore ST1006 receiver name should be a reflection of its identity; don't use generic names such as "this" or "self"
+package exchanges
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/svanas/nefertiti/aggregation"
+	exchange "github.com/svanas/nefertiti/coinex"
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/i18n"
+	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/multiplier"
+	"github.com/svanas/nefertiti/notify"
+	"github.com/svanas/nefertiti/override"
+	"github.com/svanas/nefertiti/precision"
+	"github.com/svanas/nefertiti/pricing"
+	"github.com/svanas/nefertiti/session"
+)
+
+var (
+	coinexMutex *session.Mutex
+)
+
+const (
+	coinexSessionFile = "coinex.time"
+	coinexSessionLock = "coinex.lock"
+)
+
+func init() {
+	exchange.BeforeRequest = func(method, path string) error {
+		var err error
+
+		if coinexMutex == nil {
+			if coinexMutex, err = session.NewMutex(coinexSessionLock); err != nil {
+				return err
+			}
+		}
+
+		if err = coinexMutex.Lock(); err != nil {
+			return err
+		}
+
+		var lastRequest *time.Time
+		if lastRequest, err = session.GetLastRequest(coinexSessionFile); err != nil {
+			return err
+		}
+
+		if lastRequest != nil {
+			elapsed := time.Since(*lastRequest)
+			if elapsed.Seconds() < (float64(1) / exchange.RequestsPerSecond) {
+				sleep := time.Duration((float64(time.Second) / exchange.RequestsPerSecond) - float64(elapsed))
+				if flag.Debug() {
+					log.Printf("[DEBUG] sleeping %f seconds\n", sleep.Seconds())
+				}
+				time.Sleep(sleep)
+			}
+		}
+
+		if flag.Debug() {
+			log.Printf("[DEBUG] %s %s\n", method, path)
+		}
+
+		return nil
+	}
+	exchange.AfterRequest = func() {
+		defer func() {
+			coinexMutex.Unlock()
+		}()
+		session.SetLastRequest(coinexSessionFile, time.Now())
+	}
+}
+
+type CoinEx struct {
+	*model.ExchangeInfo
+	markets []exchange.Market
+}
+
+func (self *CoinEx) error(err error, level int64, service model.Notify) {
+	pc, file, line, _ := runtime.Caller(1)
+	prefix := errors.FormatCaller(pc, file, line)
+
+	msg := fmt.Sprintf("%s %v", prefix, err)
+	_, ok := err.(*errors.Error)
+	if ok && flag.Debug() {
+		log.Printf("[ERROR] %s", err.(*errors.Error).ErrorStack(prefix, ""))
+	} else {
+		log.Printf("[ERROR] %s", msg)
+	}
+
+	if service != nil {
+		if notify.CanSend(level, notify.ERROR) {
+			err := notify.SendError(service, err, msg, "CoinEx - ERROR")
+			if err != nil {
+				log.Printf("[ERROR] %v", err)
+			}
+		}
+	}
+}
+
+func (self *CoinEx) indexByOrderID(orders []exchange.Order, id int64) int {
+	for i, o := range orders {
+		if o.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (self *CoinEx) formatSide(order *exchange.Order) string {
+	if order.Type == exchange.OrderSideBuy {
+		return model.FormatOrderSide(model.BUY)
+	}
+	return model.FormatOrderSide(model.SELL)
+}
+
+func (self *CoinEx) getBaseURL(sandbox bool) string {
+	return self.ExchangeInfo.REST.URI
+}
+
+func (self *CoinEx) getMarkets(client *exchange.Client, cached bool) ([]exchange.Market, error) {
+	if self.markets == nil || !cached {
+		markets, err := client.Markets()
+		if err != nil {
+			return nil, errors.Wrap(err, 1)
+		}
+		self.markets = nil
+		for _, market := range markets {
+			self.markets = append(self.markets, market)
+		}
+	}
+	return self.markets, nil
+}
+
+func (self *CoinEx) getMarket(client *exchange.Client, pair string, cached bool) (*exchange.Market, error) {
+	markets, err := self.getMarkets(client, cached)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, market := range markets {
+		if market.Name == pair {
+			return &market, nil
+		}
+	}
+
+	return nil, errors.Errorf("market %v does not exist", pair)
+}
+
+func (self *CoinEx) parseMarket(client *exchange.Client, pair string) (string, string, error) { // -> (base, quote, error)
+	market, err := self.getMarket(client, pair, true)
+	if err != nil {
+		return "", "", err
+	}
+	return market.TradingName, market.PricingName, nil
+}
+
+func (self *CoinEx) GetInfo() *model.ExchangeInfo {
+	return self.ExchangeInfo
+}
+
+func (self *CoinEx) GetClient(permission model.Permission, sandbox bool) (interface{}, error) {
+	if sandbox {
+		return nil, errors.New("sandbox not supported")
+	}
+
+	if permission == model.PUBLIC {
+		return exchange.New(self.getBaseURL(sandbox), "", ""), nil
+	}
+
+	apiKey, apiSecret, err := promptForApiKeys("CoinEx")
+	if err != nil {
+		return nil, err
+	}
+
+	return exchange.New(self.getBaseURL(sandbox), apiKey, apiSecret), nil
+}
+
+func (self *CoinEx) GetMarkets(cached, sandbox bool, blacklist []string) ([]model.Market, error) {
+	var out []model.Market
+
+	markets, err := self.getMarkets(exchange.New(self.getBaseURL(sandbox), "", ""), cached)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, market := range markets {
+		if func() bool {
+			for _, ignore := range blacklist {
+				if strings.EqualFold(market.Name, ignore) {
+					return false
+				}
+			}
+			return true
+		}() {
+			out = append(out, model.Market{
+				Name:  market.Name,
+				Base:  market.TradingName,
+				Quote: market.PricingName,
+			})
+		}
+	}
+
+	return out, nil
+}
+
+func (self *CoinEx) FormatMarket(base, quote string) string {
+	return exchange.FormatMarket(base, quote)
+}
+
+// listen to the opened orders, look for cancelled orders, send a notification.
+func (self *CoinEx) listen(
+	client *exchange.Client,
+	service model.Notify,
+	level int64,
+	old []exchange.Order,
+	filled []exchange.Order,
+) ([]exchange.Order, error) {
+	markets, err := self.getMarkets(client, true)
+	if err != nil {
+		return old, err
+	}
+
+	// get my opened orders
+	var new []exchange.Order
+	for _, market := range markets {
+		orders, err := client.OpenOrders(market.Name)
+		if err != nil {
+			return old, errors.Wrap(err, 1)
+		}
+		new = append(new, orders...)
+	}
+
+	// look for cancelled orders
+	for _, order := range old {
+		if self.indexByOrderID(new, order.ID) == -1 {
+			// if this order has NOT been FILLED, then it has been cancelled.
+			if self.indexByOrderID(filled, order.ID) == -1 {
+				data, err := json.Marshal(order)
+				if err != nil {
+					return new, errors.Wrap(err, 1)
+				}
+
+				log.Println("[CANCELLED] " + string(data))
+
+				if service != nil {
+					if notify.CanSend(level, notify.CANCELLED) {
+						err := service.SendMessage(order, fmt.Sprintf("CoinEx - %s", i18n.Sprintf("Done %s (Reason: Cancelled)", self.formatSide(&order))), model.ALWAYS)
+						if err != nil {
+							log.Printf("[ERROR] %v", err)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	// look for newly opened orders
+	for _, order := range new {
+		if self.indexByOrderID(old, order.ID) == -1 {
+			data, err := json.Marshal(order)
+			if err != nil {
+				return new, errors.Wrap(err, 1)
+			}
+
+			log.Println("[OPEN] " + string(data))
+
+			if service != nil {
+				if notify.CanSend(level, notify.OPENED) || (level == notify.LEVEL_DEFAULT && order.Type == exchange.OrderSideSell) {
+					err := service.SendMessage(order, fmt.Sprintf("CoinEx - %s", i18n.Sprintf("Open %s", self.formatSide(&order))), model.ALWAYS)
+					if err != nil {
+						log.Printf("[ERROR] %v", err)
+					}
+				}
+			}
+		}
+	}
+
+	return new, nil
+}
+
+// get my filled orders. the order history has the cancelled orders too, so we filter those out.
+func (self *CoinEx) getFilled(client *exchange.Client, markets []exchange.Market) ([]exchange.Order, error) {
+	var out []exchange.Order
+	for _, market := range markets {
+		orders, err := client.FinishedOrders(market.Name)
+		if err != nil {
+			return nil, errors.Wrap(err, 1)
+		}
+		for _, order := range orders {
+			if order.Filled() {
+				out = append(out, order)
+			}
+		}
+	}
+	return out, nil
+}
+
+// listen to the filled orders, look for newly filled orders, automatically place new LIMIT SELL orders.
+func (self *CoinEx) sell(
+	client *exchange.Client,
+	mult multiplier.Mult,
+	hold, earn model.Markets,
+	service model.Notify,
+	level int64,
+	old []exchange.Order,
+) ([]exchange.Order, error) {
+	markets, err := self.getMarkets(client, true)
+	if err != nil {
+		return old, err
+	}
+
+	filled, err := self.getFilled(client, markets)
+	if err != nil {
+		return old, err
+	}
+
+	// make a list of newly filled orders
+	var new []exchange.Order
+	for _, order := range filled {
+		if self.indexByOrderID(old, order.ID) == -1 && processOnce(self, strconv.FormatInt(order.ID, 10)) {
+			new = append(new, order)
+		}
+	}
+
+	// send notification(s)
+	for _, order := range new {
+		data, err := json.Marshal(order)
+		if err != nil {
+			self.error(err, level, service)
+		} else {
+			log.Println("[FILLED] " + string(data))
+			if notify.CanSend(level, notify.FILLED) && service != nil {
+				err := service.SendMessage(order, fmt.Sprintf("CoinEx - %s", i18n.Sprintf("Done %s (Reason: Filled)", self.formatSide(&order))), model.ALWAYS)
+				if err != nil {
+					log.Printf("[ERROR] %v", err)
+				}
+			}
+		}
+	}
+
+	// has a buy order been filled? then place a sell order
+	for i := 0; i < len(new); i++ {
+		if new[i].Type == exchange.OrderSideBuy {
+			base, quote, err := self.parseMarket(client, new[i].Market)
+			if err != nil {
+				self.error(err, level, service)
+				continue
+			}
+
+			qty := new[i].AmountMinusFee(base)
+
+			// add up amount(s), hereby preventing a problem with partial matches
+			n := i + 1
+			for n < len(new) {
+				if new[n].Market == new[i].Market && new[n].Type == new[i].Type && new[n].Price == new[i].Price {
+					qty = qty + new[n].AmountMinusFee(base)
+					new = append(new[:n], new[n+1:]...)
+				} else {
+					n++
+				}
+			}
+
+			// round to precision if (a) fees got deducted, or (b) we added up partial matches
+			if qty != new[i].DealAmount {
+				prec, err := self.GetSizePrec(client, new[i].Market)
+				if err != nil {
+					self.error(err, level, service)
+				} else {
+					qty = precision.Floor(qty, prec)
+				}
+			}
+
+			// get desired size, calculate price, place sell order
+			qty = self.GetMaxSize(client, base, quote, hold.HasMarket(new[i].Market), earn.HasMarket(new[i].Market), qty, override.Mult(new[i].Market, mult))
+			if qty > 0 {
+				var tick float64
+				tick, err = model.GetTickSize(self, client, new[i].Market)
+				if err == nil {
+					_, err = client.LimitOrder(
+						new[i].Market,
+						exchange.OrderSideSell,
+						qty,
+						pricing.MultiplyTick(new[i].ExecutedAt(), override.Mult(new[i].Market, mult), tick),
+						"",
+					)
+				}
+			}
+
+			if err != nil {
+				data, _ := json.Marshal(new[i])
+				if data == nil {
+					self.error(err, level, service)
+				} else {
+					self.error(errors.Append(err, "\t", string(data)), level, service)
+				}
+			}
+		}
+	}
+
+	return filled, nil
+}
+
+func (self *CoinEx) Sell(
+	strategy model.Strategy,
+	hold, earn model.Markets,
+	sandbox, tweet, debug bool,
+	success model.OnSuccess,
+) error {
+	if strategy != model.STRATEGY_STANDARD {
+		return errors.New("strategy not implemented")
+	}
+
+	apiKey, apiSecret, err := promptForApiKeys("CoinEx")
+	if err != nil {
+		return err
+	}
+
+	service, err := notify.New().Init(flag.Interactive(), true)
+	if err != nil {
+		return err
+	}
+
+	client := exchange.New(self.getBaseURL(sandbox), apiKey, apiSecret)
+
+	markets, err := self.getMarkets(client, true)
+	if err != nil {
+		return err
+	}
+
+	// get my filled orders
+	filled, err := self.getFilled(client, markets)
+	if err != nil {
+		return err
+	}
+
+	// get my opened orders
+	var opened []exchange.Order
+	for _, market := range markets {
+		orders, err := client.OpenOrders(market.Name)
+		if err != nil {
+			return errors.Wrap(err, 1)
+		}
+		opened = append(opened, orders...)
+	}
+
+	if err = success(service); err != nil {
+		return err
+	}
+
+	for {
+		// read the dynamic settings
+		var (
+			level int64 = notify.LEVEL_DEFAULT
+			mult  multiplier.Mult
+		)
+		if level, err = notify.Level(); err != nil {
+			self.error(err, level, service)
+		} else if mult, err = multiplier.Get(multiplier.FIVE_PERCENT); err != nil {
+			self.error(err, level, service)
+		} else
+		// listen to the filled orders, look for newly filled orders, automatically place new LIMIT SELL orders.
+		if filled, err = self.sell(client, mult, hold, earn, service, level, filled); err != nil {
+			self.error(err, level, service)
+		} else
+		// listen to the opened orders, look for cancelled orders, send a notification.
+		if opened, err = self.listen(client, service, level, opened, filled); err != nil {
+			self.error(err, level, service)
+		}
+		beat(self.GetInfo().Name, err)
+	}
+}
+
+func (self *CoinEx) Order(
+	client interface{},
+	side model.OrderSide,
+	market string,
+	size float64,
+	price float64,
+	kind model.OrderType,
+	metadata string,
+) (oid []byte, raw []byte, err error) {
+	if raw, ok := observeOrder(self, client, side, market, size, price, kind); ok {
+		return nil, raw, nil
+	}
+
+	if err := CheckEquityShare(self, client, side, market, size, price); err != nil {
+		return nil, nil, err
+	}
+
+	coinexClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, nil, errors.New("invalid argument: client")
+	}
+
+	orderSide := exchange.OrderSideSell
+	if side == model.BUY {
+		orderSide = exchange.OrderSideBuy
+	}
+
+	var order *exchange.Order
+	if kind == model.MARKET {
+		amount := size
+		if side == model.BUY {
+			// market buy orders are placed for an amount in quote currency
+			var ticker float64
+			if ticker, err = self.GetTicker(client, market); err != nil {
+				return nil, nil, err
+			}
+			amount = size * ticker
+		}
+		order, err = coinexClient.MarketOrder(market, orderSide, amount, metadata)
+	} else {
+		order, err = coinexClient.LimitOrder(market, orderSide, size, price, metadata)
+	}
+	if err != nil {
+		return nil, nil, errors.Wrap(err, 1)
+	}
+
+	if raw, err = json.Marshal(order); err != nil {
+		return nil, nil, errors.Wrap(err, 1)
+	}
+
+	return []byte(strconv.FormatInt(order.ID, 10)), raw, nil
+}
+
+func (self *CoinEx) StopLoss(client interface{}, market string, size float64, price float64, kind model.OrderType, metadata string) ([]byte, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (self *CoinEx) OCO(client interface{}, market string, size float64, price, stop float64, metadata string) ([]byte, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (self *CoinEx) GetClosed(client interface{}, market string) (model.Orders, error) {
+	coinexClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	base, _, err := self.parseMarket(coinexClient, market)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := coinexClient.FinishedOrders(market)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	var output model.Orders
+	for _, order := range orders {
+		if order.Filled() {
+			output = append(output, model.Order{
+				Side: func() model.OrderSide {
+					if order.Type == exchange.OrderSideBuy {
+						return model.BUY
+					}
+					return model.SELL
+				}(),
+				Status:     model.FILLED,
+				Market:     market,
+				Size:       order.AmountMinusFee(base),
+				Price:      order.ExecutedAt(),
+				CreatedAt:  order.CreatedAt(),
+				ClosedAt:   model.TimePtr(order.ClosedAt()),
+				FilledSize: order.DealAmount,
+				Fee:        order.DealFee,
+				FeeAsset:   order.FeeAsset,
+			})
+		}
+	}
+
+	return output, nil
+}
+
+func (self *CoinEx) GetOpened(client interface{}, market string) (model.Orders, error) {
+	coinexClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	orders, err := coinexClient.OpenOrders(market)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	var output model.Orders
+	for _, order := range orders {
+		output = append(output, model.Order{
+			Status: model.OPEN,
+			Side: func() model.OrderSide {
+				if order.Type == exchange.OrderSideBuy {
+					return model.BUY
+				}
+				return model.SELL
+			}(),
+			ID:        strconv.FormatInt(order.ID, 10),
+			Market:    market,
+			Size:      order.Amount,
+			Price:     order.Price,
+			CreatedAt: order.CreatedAt(),
+		})
+	}
+
+	return output, nil
+}
+
+func (self *CoinEx) GetBook(client interface{}, market string, side model.BookSide) (interface{}, error) {
+	coinexClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	book, err := coinexClient.OrderBook(market)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	if side == model.BOOK_SIDE_ASKS {
+		return book.Asks, nil
+	}
+	return book.Bids, nil
+}
+
+func (self *CoinEx) Aggregate(client, book interface{}, market string, agg float64) (model.Book, error) {
+	bids, ok := book.([]exchange.BookEntry)
+	if !ok {
+		return nil, errors.New("invalid argument: book")
+	}
+
+	prec, err := self.GetPricePrec(client, market)
+	if err != nil {
+		return nil, err
+	}
+
+	var out model.Book
+	for _, e := range bids {
+		price := precision.Round(aggregation.Round(e.Price, agg), prec)
+		entry := out.EntryByPrice(price)
+		if entry != nil {
+			entry.Size = entry.Size + e.Amount
+		} else {
+			entry = &model.Buy{
+				Market: market,
+				Price:  price,
+				Size:   e.Amount,
+			}
+			out = append(out, *entry)
+		}
+	}
+
+	return out, nil
+}
+
+func (self *CoinEx) GetTicker(client interface{}, market string) (float64, error) {
+	coinexClient, ok := client.(*exchange.Client)
+	if !ok {
+		return 0, errors.New("invalid argument: client")
+	}
+
+	ticker, err := coinexClient.Ticker(market)
+	if err != nil {
+		return 0, errors.Wrap(err, 1)
+	}
+
+	return ticker.Last, nil
+}
+
+func (self *CoinEx) GetTickers(client interface{}) (map[string]float64, error) {
+	coinexClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	tickers, err := coinexClient.Tickers()
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	out := make(map[string]float64)
+	for market, ticker := range tickers {
+		out[market] = ticker.Last
+	}
+
+	return out, nil
+}
+
+func (self *CoinEx) Get24h(client interface{}, market string) (*model.Stats, error) {
+	coinexClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	ticker, err := coinexClient.Ticker(market)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	return &model.Stats{
+		Market: market,
+		High:   ticker.High,
+		Low:    ticker.Low,
+		Open:   ticker.Open,
+		Last:   ticker.Last,
+		Change: model.PercentChange(ticker.Open, ticker.Last),
+		BtcVolume: func(ticker1 *exchange.Ticker) float64 {
+			base, quote, err := self.parseMarket(coinexClient, market)
+			if err == nil {
+				if strings.EqualFold(base, model.BTC) {
+					return ticker1.Vol
+				}
+				if strings.EqualFold(quote, model.BTC) {
+					return ticker1.Vol * ticker1.Last
+				}
+				ticker2, err := coinexClient.Ticker(self.FormatMarket(model.BTC, quote))
+				if err == nil && ticker2.Last > 0 {
+					return (ticker1.Vol * ticker1.Last) / ticker2.Last
+				}
+			}
+			return 0
+		}(ticker),
+	}, nil
+}
+
+func (self *CoinEx) Get24hAll(client interface{}) (map[string]model.Stats, error) {
+	coinexClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	tickers, err := coinexClient.Tickers()
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	out := make(map[string]model.Stats)
+	volume := make(map[string]float64) // in quote currency
+	for market, ticker := range tickers {
+		out[market] = model.Stats{
+			Market: market,
+			High:   ticker.High,
+			Low:    ticker.Low,
+			Open:   ticker.Open,
+			Last:   ticker.Last,
+			Change: model.PercentChange(ticker.Open, ticker.Last),
+		}
+		volume[market] = ticker.Vol * ticker.Last
+	}
+
+	if err = setBtcVolume(self, out, volume); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (self *CoinEx) GetPricePrec(client interface{}, market string) (int, error) {
+	coinexClient, ok := client.(*exchange.Client)
+	if !ok {
+		return 0, errors.New("invalid argument: client")
+	}
+
+	info, err := self.getMarket(coinexClient, market, true)
+	if err != nil {
+		return 0, err
+	}
+
+	return info.PricingDecimal, nil
+}
+
+func (self *CoinEx) GetSizePrec(client interface{}, market string) (int, error) {
+	coinexClient, ok := client.(*exchange.Client)
+	if !ok {
+		return 0, errors.New("invalid argument: client")
+	}
+
+	info, err := self.getMarket(coinexClient, market, true)
+	if err != nil {
+		return 0, err
+	}
+
+	return info.TradingDecimal, nil
+}
+
+func (self *CoinEx) GetMaxSize(client interface{}, base, quote string, hold, earn bool, def float64, mult multiplier.Mult) float64 {
+	return model.GetSizeMax(hold, earn, def, mult, func() int {
+		prec, err := self.GetSizePrec(client, self.FormatMarket(base, quote))
+		if err != nil {
+			return 0
+		}
+		return prec
+	})
+}
+
+func (self *CoinEx) Cancel(client interface{}, market string, side model.OrderSide) error {
+	if observeCancel(self, market, side) {
+		return nil
+	}
+
+	coinexClient, ok := client.(*exchange.Client)
+	if !ok {
+		return errors.New("invalid argument: client")
+	}
+
+	orders, err := coinexClient.OpenOrders(market)
+	if err != nil {
+		return errors.Wrap(err, 1)
+	}
+
+	for _, order := range orders {
+		if ((side == model.BUY) && (order.Type == exchange.OrderSideBuy)) || ((side == model.SELL) && (order.Type == exchange.OrderSideSell)) {
+			if err := coinexClient.CancelOrder(market, order.ID); err != nil {
+				return errors.Wrap(err, 1)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (self *CoinEx) CancelOrder(client interface{}, market, id string) error {
+	if observeCancelOrder(self, market, id) {
+		return nil
+	}
+
+	coinexClient, ok := client.(*exchange.Client)
+	if !ok {
+		return errors.New("invalid argument: client")
+	}
+
+	orderID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return errors.Wrap(err, 1)
+	}
+
+	if err = coinexClient.CancelOrder(market, orderID); err != nil {
+		return errors.Wrap(err, 1)
+	}
+
+	return nil
+}
+
+func (self *CoinEx) GetOrder(client interface{}, market, id string) (*model.Order, error) {
+	coinexClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	orderID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	order, err := coinexClient.GetOrder(market, orderID)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	out := &model.Order{
+		ID:     id,
+		Status: model.OPEN,
+		Side: func() model.OrderSide {
+			if order.Type == exchange.OrderSideBuy {
+				return model.BUY
+			}
+			return model.SELL
+		}(),
+		Market:     market,
+		Size:       order.Amount,
+		Price:      order.ExecutedAt(),
+		CreatedAt:  order.CreatedAt(),
+		FilledSize: order.DealAmount,
+		Fee:        order.DealFee,
+		FeeAsset:   order.FeeAsset,
+	}
+	switch order.Status {
+	case exchange.OrderStatusDone:
+		out.Status = model.FILLED
+	case exchange.OrderStatusCancel:
+		out.Status = model.CANCELLED
+	}
+	if out.Status != model.OPEN && order.FinishedAt > 0 {
+		out.ClosedAt = model.TimePtr(order.ClosedAt())
+	}
+
+	return out, nil
+}
+
+func (self *CoinEx) GetFees(client interface{}, market string) (*model.Fees, error) {
+	coinexClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	m, err := self.getMarket(coinexClient, market, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Fees{
+		Maker: m.MakerFeeRate,
+		Taker: m.TakerFeeRate,
+	}, nil
+}
+
+func (self *CoinEx) Buy(client interface{}, cancel bool, market string, calls model.Calls, deviation float64, kind model.OrderType) error {
+	if observeBuy(self, client, cancel, market, calls, kind) {
+		return nil
+	}
+
+	coinexClient, ok := client.(*exchange.Client)
+	if !ok {
+		return errors.New("invalid argument: client")
+	}
+
+	// step #1: delete the buy order(s) that are open in your book
+	if cancel {
+		orders, err := coinexClient.OpenOrders(market)
+		if err != nil {
+			return errors.Wrap(err, 1)
+		}
+		for _, order := range orders {
+			if order.Type == exchange.OrderSideBuy {
+				// do not cancel orders that we're about to re-place
+				index := calls.IndexByPriceSize(order.Price, order.Amount)
+				if index > -1 {
+					calls[index].Skip = true
+				} else {
+					if err := coinexClient.CancelOrder(market, order.ID); err != nil {
+						return errors.Wrap(err, 1)
+					}
+				}
+			}
+		}
+	}
+
+	// step 2: open the top X buy orders
+	for _, call := range calls {
+		if !call.Skip {
+			var (
+				qty   float64 = call.Size
+				limit float64 = call.Price
+			)
+			if deviation != 1.0 {
+				kind, limit = call.Deviate(self, client, kind, deviation)
+			}
+			info, err := self.getMarket(coinexClient, market, true)
+			if err != nil {
+				return err
+			}
+			if info.MinAmount > 0 && qty < info.MinAmount {
+				qty = info.MinAmount
+			}
+			if _, _, err := self.Order(client, model.BUY, market, qty, limit, kind, ""); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (self *CoinEx) IsLeveragedToken(name string) bool {
+	return false
+}
+
+func (self *CoinEx) HasAlgoOrder(client interface{}, market string) (bool, error) {
+	return false, nil
+}
+
+func newCoinEx() model.Exchange {
+	return &CoinEx{
+		ExchangeInfo: &model.ExchangeInfo{
+			Code: "CNEX",
+			Name: "CoinEx",
+			URL:  "https://www.coinex.com",
+			REST: model.Endpoint{
+				URI: exchange.API_BASE,
+			},
+			WebSocket: model.Endpoint{
+				URI: "wss://socket.coinex.com/",
+			},
+			Country: "Hong Kong",
+		},
+	}
+}
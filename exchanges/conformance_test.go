@@ -0,0 +1,268 @@
+package exchanges
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/model"
+)
+
+// the conformance tests hold every model.Exchange to the same rules. the offline rules apply to every exchange. the
+// rules that need the API run against the responses in testdata/[code], for the exchanges that have them: we serve
+// testdata/[code]/[path].json on the path of the request, and point the REST endpoint of the exchange at us. a path
+// that is paginated has its second page in [path].2.json, its third page in [path].3.json, and so on.
+// testdata/[code]/expect.json holds what we expect of the private endpoints, for example: how many closed orders.
+
+type conformanceExpect struct {
+	Closed *struct {
+		Market string `json:"market"`
+		Orders int    `json:"orders"` // spread across more than one page
+	} `json:"closed,omitempty"`
+}
+
+func conformanceDir(exchange model.Exchange) string {
+	return filepath.Join("testdata", strings.ToLower(exchange.GetInfo().Code))
+}
+
+// conformanceServer serves the responses of an exchange. the query string is ignored: the Nth request to a paginated
+// path gets the Nth page. a path without a response is 404 Not Found, with testdata/[code]/error.json as its body.
+func conformanceServer(t *testing.T, dir string) *httptest.Server {
+	var (
+		mutex    sync.Mutex
+		requests = make(map[string]int)
+	)
+	return httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		path := filepath.Join(dir, filepath.FromSlash(strings.Trim(req.URL.Path, "/")))
+		mutex.Lock()
+		requests[path]++
+		page := requests[path]
+		mutex.Unlock()
+		name := path + ".json"
+		if page > 1 {
+			if _, err := os.Stat(path + ".2.json"); err == nil {
+				name = path + "." + strconv.Itoa(page) + ".json"
+			}
+		}
+		data, err := os.ReadFile(name)
+		if err != nil {
+			t.Logf("%s %s: no response", req.Method, req.URL.Path)
+			data, _ = os.ReadFile(filepath.Join(dir, "error.json"))
+			resp.WriteHeader(http.StatusNotFound)
+		}
+		resp.Header().Set("Content-Type", "application/json")
+		resp.Write(data)
+	}))
+}
+
+func TestConformanceInfo(t *testing.T) {
+	codes := make(map[string]bool)
+	for _, exchange := range *New() {
+		info := exchange.GetInfo()
+		if info == nil {
+			t.Fatalf("%T: GetInfo returned nil", exchange)
+		}
+		if info.Code == "" || info.Name == "" || info.URL == "" {
+			t.Errorf("%T: code, name and URL are required, got: %v, %v, %v.", exchange, info.Code, info.Name, info.URL)
+		}
+		if codes[strings.ToUpper(info.Code)] {
+			t.Errorf("%s: code %s is not unique.", info.Name, info.Code)
+		}
+		codes[strings.ToUpper(info.Code)] = true
+		if _, err := GetCapabilities(exchange); err != nil {
+			t.Errorf("%s: %v", info.Name, err)
+		}
+		for _, name := range []string{info.Code, info.Name, strings.ToLower(info.Code)} {
+			if found := New().findByName(name); found == nil || found.GetInfo().Code != info.Code {
+				t.Errorf("%s: cannot find the exchange by %s.", info.Name, name)
+			}
+		}
+	}
+}
+
+func TestConformanceFormatMarket(t *testing.T) {
+	for _, exchange := range *New() {
+		name := exchange.GetInfo().Name
+		btc := exchange.FormatMarket("BTC", "USDT")
+		eth := exchange.FormatMarket("ETH", "USDT")
+		if btc == "" || eth == "" {
+			t.Errorf("%s: FormatMarket returned an empty market.", name)
+		}
+		if btc == eth {
+			t.Errorf("%s: FormatMarket returned %s for two different markets.", name, btc)
+		}
+		if again := exchange.FormatMarket("BTC", "USDT"); again != btc {
+			t.Errorf("%s: FormatMarket is not deterministic, got: %v, want: %v.", name, again, btc)
+		}
+	}
+}
+
+// conformanceSession points the session dir at a temp dir, so that the fixtures never share their throttle (or their
+// cursors) with a bot that runs on this machine
+func conformanceSession(t *testing.T) {
+	old, ok := os.LookupEnv("TMPDIR")
+	os.Setenv("TMPDIR", t.TempDir())
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv("TMPDIR", old)
+		} else {
+			os.Unsetenv("TMPDIR")
+		}
+	})
+}
+
+func TestConformanceFixtures(t *testing.T) {
+	conformanceSession(t)
+
+	// we never prompt for anything, the private endpoints get fake API keys
+	flag.SetInteractive(false)
+	defer flag.SetInteractive(true)
+	args := os.Args
+	defer func() {
+		os.Args = args
+	}()
+	for _, name := range []string{"api-key", "api-secret", "api-passphrase"} {
+		flag.Set(name, "conformance")
+	}
+
+	for _, exchange := range *New() {
+		dir := conformanceDir(exchange)
+		if _, err := os.Stat(dir); err != nil {
+			continue
+		}
+		t.Run(exchange.GetInfo().Code, func(t *testing.T) {
+			server := conformanceServer(t, dir)
+			defer server.Close()
+			// the private endpoints run against the "sandbox", so that we don't verify the permissions of our fake keys
+			exchange.GetInfo().REST.URI = server.URL
+			exchange.GetInfo().REST.Sandbox = server.URL
+			conform(t, exchange)
+			conformPrivate(t, exchange, dir)
+		})
+	}
+}
+
+// conform runs the rules that need the API against an exchange
+func conform(t *testing.T, exchange model.Exchange) {
+	// market parsing: every market needs to round-trip through FormatMarket and ParseMarket
+	markets, err := exchange.GetMarkets(false, false, nil)
+	if err != nil {
+		t.Fatalf("GetMarkets failed: %v", err)
+	}
+	if len(markets) == 0 {
+		t.Fatal("GetMarkets returned no markets")
+	}
+	for _, market := range markets {
+		if market.Name == "" || market.Base == "" || market.Quote == "" {
+			t.Errorf("GetMarkets returned an incomplete market: %+v.", market)
+			continue
+		}
+		if name := exchange.FormatMarket(market.Base, market.Quote); name != market.Name {
+			t.Errorf("FormatMarket(%s, %s) failed, got: %v, want: %v.", market.Base, market.Quote, name, market.Name)
+		}
+		base, quote, err := model.ParseMarket(markets, market.Name)
+		if err != nil || base != market.Base || quote != market.Quote {
+			t.Errorf("ParseMarket(%s) failed, got: %v, %v, %v.", market.Name, base, quote, err)
+		}
+	}
+
+	// the blacklist
+	ignored := markets[0].Name
+	others, err := exchange.GetMarkets(false, false, []string{strings.ToUpper(ignored)})
+	if err != nil {
+		t.Fatalf("GetMarkets failed: %v", err)
+	}
+	if model.HasMarket(others, ignored) {
+		t.Errorf("GetMarkets returned %s, even though we asked to ignore it.", ignored)
+	}
+
+	client, err := exchange.GetClient(model.PUBLIC, false)
+	if err != nil {
+		t.Fatalf("GetClient failed: %v", err)
+	}
+
+	// precision: where the exchange knows it, the precision is a number of decimals
+	for _, market := range markets {
+		if prec, err := exchange.GetPricePrec(client, market.Name); err == nil && (prec < 0 || prec > 18) {
+			t.Errorf("GetPricePrec(%s) returned %d.", market.Name, prec)
+		}
+		if prec, err := exchange.GetSizePrec(client, market.Name); err == nil && (prec < 0 || prec > 18) {
+			t.Errorf("GetSizePrec(%s) returned %d.", market.Name, prec)
+		}
+	}
+
+	// the tickers are keyed by the same market names as GetMarkets
+	tickers, err := exchange.GetTickers(client)
+	if err != nil && !errors.Is(err, ErrNoTickers) {
+		t.Fatalf("GetTickers failed: %v", err)
+	}
+	for market, ticker := range tickers {
+		if model.HasMarket(markets, market) && ticker <= 0 {
+			t.Errorf("GetTickers returned %v for %s.", ticker, market)
+		}
+	}
+	if ticker, err := exchange.GetTicker(client, markets[0].Name); err != nil || ticker <= 0 {
+		t.Errorf("GetTicker(%s) failed, got: %v, %v.", markets[0].Name, ticker, err)
+	}
+
+	stats, err := exchange.Get24h(client, markets[0].Name)
+	if err != nil {
+		t.Fatalf("Get24h failed: %v", err)
+	}
+	if stats.Market != markets[0].Name || stats.Low > stats.High {
+		t.Errorf("Get24h(%s) returned %+v.", markets[0].Name, stats)
+	}
+
+	// error mapping: a client of the wrong type is an error, not a panic
+	invalid := struct{}{}
+	if _, err := exchange.GetTicker(invalid, markets[0].Name); err == nil {
+		t.Error("GetTicker accepted an invalid client.")
+	}
+	if _, err := exchange.GetTickers(invalid); err == nil {
+		t.Error("GetTickers accepted an invalid client.")
+	}
+	if _, err := exchange.Get24h(invalid, markets[0].Name); err == nil {
+		t.Error("Get24h accepted an invalid client.")
+	}
+}
+
+// conformPrivate runs the rules in testdata/[code]/expect.json against the private endpoints of an exchange
+func conformPrivate(t *testing.T, exchange model.Exchange, dir string) {
+	data, err := os.ReadFile(filepath.Join(dir, "expect.json"))
+	if err != nil {
+		return
+	}
+	var expect conformanceExpect
+	if err = json.Unmarshal(data, &expect); err != nil {
+		t.Fatalf("expect.json is invalid: %v", err)
+	}
+
+	client, err := exchange.GetClient(model.PRIVATE, true)
+	if err != nil {
+		t.Fatalf("GetClient failed: %v", err)
+	}
+
+	// pagination: we get every page, and every order on it
+	if expect.Closed != nil {
+		closed, err := exchange.GetClosed(client, expect.Closed.Market)
+		if err != nil {
+			t.Fatalf("GetClosed failed: %v", err)
+		}
+		if len(closed) != expect.Closed.Orders {
+			t.Errorf("GetClosed(%s) returned %d orders, want: %d.", expect.Closed.Market, len(closed), expect.Closed.Orders)
+		}
+		for _, order := range closed {
+			if order.Side == model.ORDER_SIDE_NONE || order.Size <= 0 || order.Price <= 0 || order.ClosedAt == nil {
+				t.Errorf("GetClosed(%s) returned an incomplete order: %+v.", expect.Closed.Market, order)
+			}
+		}
+	}
+}
@@ -4,7 +4,6 @@ package exchanges
 import (
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"log"
 	"net/url"
 	"runtime"
@@ -12,21 +11,22 @@ import (
 	"strings"
 	"time"
 
-	filemutex "github.com/alexflint/go-filemutex"
 	exchange "github.com/svanas/go-crypto-dot-com"
 	"github.com/svanas/nefertiti/aggregation"
 	"github.com/svanas/nefertiti/errors"
 	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/i18n"
 	"github.com/svanas/nefertiti/model"
 	"github.com/svanas/nefertiti/multiplier"
 	"github.com/svanas/nefertiti/notify"
+	"github.com/svanas/nefertiti/override"
 	"github.com/svanas/nefertiti/precision"
 	"github.com/svanas/nefertiti/pricing"
 	"github.com/svanas/nefertiti/session"
 )
 
 var (
-	cryptoDotComMutex *filemutex.FileMutex
+	cryptoDotComMutex *session.Mutex
 )
 
 const (
@@ -45,12 +45,12 @@ func cryptoDotComRequestsPerSecond() (float64, error) {
 		data []byte
 		info CryptoDotComSessionInfo
 	)
-	if data, err = ioutil.ReadFile(session.GetSessionFile(cryptoDotComSessionInfo)); err == nil {
+	if data, err = session.ReadFile(session.GetSessionFile(session.Keyed(cryptoDotComSessionInfo))); err == nil {
 		if err = json.Unmarshal(data, &info); err == nil {
 			if info.Cooldown {
 				info.Cooldown = false
 				if data, err = json.Marshal(info); err == nil {
-					err = ioutil.WriteFile(session.GetSessionFile(cryptoDotComSessionInfo), data, 0600)
+					err = session.WriteFile(session.GetSessionFile(session.Keyed(cryptoDotComSessionInfo)), data)
 				}
 				return exchange.RequestsPerSecond[exchange.RATE_LIMIT_COOL_DOWN], err
 			}
@@ -67,7 +67,7 @@ func init() {
 		)
 
 		if cryptoDotComMutex == nil {
-			if cryptoDotComMutex, err = filemutex.New(session.GetSessionFile(cryptoDotComSessionLock)); err != nil {
+			if cryptoDotComMutex, err = session.NewMutex(session.Keyed(cryptoDotComSessionLock)); err != nil {
 				return err
 			}
 		}
@@ -77,7 +77,7 @@ func init() {
 		}
 
 		var lastRequest *time.Time
-		if lastRequest, err = session.GetLastRequest(cryptoDotComSessionFile); err != nil {
+		if lastRequest, err = session.GetLastRequest(session.Keyed(cryptoDotComSessionFile)); err != nil {
 			return err
 		}
 
@@ -109,7 +109,7 @@ func init() {
 		defer func() {
 			cryptoDotComMutex.Unlock()
 		}()
-		session.SetLastRequest(cryptoDotComSessionFile, time.Now())
+		session.SetLastRequest(session.Keyed(cryptoDotComSessionFile), time.Now())
 	}
 	exchange.OnRateLimitError = func(method, path string) error {
 		var (
@@ -119,8 +119,9 @@ func init() {
 		)
 		info.Cooldown = true
 		if data, err = json.Marshal(info); err == nil {
-			err = ioutil.WriteFile(session.GetSessionFile(cryptoDotComSessionInfo), data, 0600)
+			err = session.WriteFile(session.GetSessionFile(session.Keyed(cryptoDotComSessionInfo)), data)
 		}
+		recordRateLimit(session.Keyed("crypto.com"), path)
 		return err
 	}
 }
@@ -146,7 +147,7 @@ func (self *CryptoDotCom) error(err error, level int64, service model.Notify) {
 
 	if service != nil {
 		if notify.CanSend(level, notify.ERROR) {
-			err := service.SendMessage(msg, "crypto.com - ERROR", model.ONCE_PER_MINUTE)
+			err := notify.SendError(service, err, msg, "crypto.com - ERROR")
 			if err != nil {
 				log.Printf("[ERROR] %v", err)
 			}
@@ -218,13 +219,19 @@ func (self *CryptoDotCom) indexByTradeId(trades []exchange.Trade, id int64) int
 
 //-------------------- public --------------------
 
+func (self *CryptoDotCom) newClient(apiKey, apiSecret string) *exchange.Client {
+	out := exchange.New(apiKey, apiSecret)
+	out.URL = self.ExchangeInfo.REST.URI
+	return out
+}
+
 func (self *CryptoDotCom) GetInfo() *model.ExchangeInfo {
 	return self.ExchangeInfo
 }
 
 func (self *CryptoDotCom) GetClient(permission model.Permission, sandbox bool) (interface{}, error) {
 	if permission != model.PRIVATE {
-		return exchange.New("", ""), nil
+		return self.newClient("", ""), nil
 	}
 
 	var (
@@ -236,13 +243,13 @@ func (self *CryptoDotCom) GetClient(permission model.Permission, sandbox bool) (
 		return nil, err
 	}
 
-	return exchange.New(apiKey, apiSecret), nil
+	return self.newClient(apiKey, apiSecret), nil
 }
 
 func (self *CryptoDotCom) GetMarkets(cached, sandbox bool, blacklist []string) ([]model.Market, error) {
 	var out []model.Market
 
-	symbols, err := self.getSymbols(exchange.New("", ""), nil, cached)
+	symbols, err := self.getSymbols(self.newClient("", ""), nil, cached)
 	if err != nil {
 		return nil, err
 	}
@@ -267,8 +274,11 @@ func (self *CryptoDotCom) GetMarkets(cached, sandbox bool, blacklist []string) (
 	return out, nil
 }
 
+// how Crypto.com spells its markets
+var cryptoSymbol = model.SymbolFormat{Lower: true}
+
 func (self *CryptoDotCom) FormatMarket(base, quote string) string {
-	return strings.ToLower(base + quote)
+	return model.NewMarketSymbol(base, quote).Format(cryptoSymbol)
 }
 
 // listen to the opened orders, look for cancelled orders, send a notification.
@@ -306,7 +316,7 @@ func (self *CryptoDotCom) listen(
 
 				if service != nil {
 					if notify.CanSend(level, notify.CANCELLED) {
-						if err = service.SendMessage(order, fmt.Sprintf("crypto.com - Done %s (Reason: Cancelled)", order.SideMsg), model.ALWAYS); err != nil {
+						if err = service.SendMessage(order, fmt.Sprintf("crypto.com - %s", i18n.Sprintf("Done %s (Reason: Cancelled)", order.SideMsg)), model.ALWAYS); err != nil {
 							log.Printf("[ERROR] %v", err)
 						}
 					}
@@ -327,7 +337,7 @@ func (self *CryptoDotCom) listen(
 
 			if service != nil {
 				if notify.CanSend(level, notify.OPENED) || (level == notify.LEVEL_DEFAULT && order.GetSide() == exchange.SELL) {
-					if err = service.SendMessage(order, ("crypto.com - Open " + order.SideMsg), model.ALWAYS); err != nil {
+					if err = service.SendMessage(order, ("crypto.com - " + i18n.Sprintf("Open %s", order.SideMsg)), model.ALWAYS); err != nil {
 						log.Printf("[ERROR] %v", err)
 					}
 				}
@@ -363,7 +373,7 @@ func (self *CryptoDotCom) sell(
 	// make a list of newly filled orders
 	var new []exchange.Trade
 	for _, trade := range filled {
-		if self.indexByTradeId(old, trade.Id) == -1 {
+		if self.indexByTradeId(old, trade.Id) == -1 && processOnce(self, strconv.FormatInt(trade.Id, 10)) {
 			new = append(new, trade)
 		}
 	}
@@ -376,7 +386,7 @@ func (self *CryptoDotCom) sell(
 		} else {
 			log.Println("[FILLED] " + string(data))
 			if notify.CanSend(level, notify.FILLED) && service != nil {
-				if err = service.SendMessage(trade, fmt.Sprintf("crypto.com - Done %s (Reason: Filled)", trade.Type), model.ALWAYS); err != nil {
+				if err = service.SendMessage(trade, fmt.Sprintf("crypto.com - %s", i18n.Sprintf("Done %s (Reason: Filled)", trade.Type)), model.ALWAYS); err != nil {
 					log.Printf("[ERROR] %v", err)
 				}
 			}
@@ -413,17 +423,20 @@ func (self *CryptoDotCom) sell(
 			)
 			base, quote, err = self.parseSymbol(symbols, new[i].Symbol)
 			if err == nil {
-				qty = self.GetMaxSize(client, base, quote, hold.HasMarket(new[i].Symbol), earn.HasMarket(new[i].Symbol), qty, mult)
+				qty = self.GetMaxSize(client, base, quote, hold.HasMarket(new[i].Symbol), earn.HasMarket(new[i].Symbol), qty, override.Mult(new[i].Symbol, mult))
 				if qty > 0 {
-					var prec int
-					if prec, err = self.GetPricePrec(client, new[i].Symbol); err == nil {
-						_, err = client.CreateOrder(
-							new[i].Symbol,
-							exchange.SELL,
-							exchange.LIMIT,
-							qty,
-							pricing.Multiply(new[i].Price, mult, prec),
-						)
+					var tick float64
+					if tick, err = model.GetTickSize(self, client, new[i].Symbol); err == nil {
+						limit := pricing.MultiplyTick(new[i].Price, override.Mult(new[i].Symbol, mult), tick)
+						if _, ok := observeOrder(self, client, model.SELL, new[i].Symbol, qty, limit, model.LIMIT); !ok {
+							_, err = client.CreateOrder(
+								new[i].Symbol,
+								exchange.SELL,
+								exchange.LIMIT,
+								qty,
+								limit,
+							)
+						}
 					}
 				}
 			}
@@ -466,7 +479,7 @@ func (self *CryptoDotCom) Sell(
 		return err
 	}
 
-	client := exchange.New(apiKey, apiSecret)
+	client := self.newClient(apiKey, apiSecret)
 
 	var (
 		quotes  []string = []string{model.BTC}
@@ -529,6 +542,7 @@ func (self *CryptoDotCom) Sell(
 		if opened, err = self.listen(client, symbols, service, level, opened, filled); err != nil {
 			self.error(err, level, service)
 		}
+		beat(self.GetInfo().Name, err)
 	}
 }
 
@@ -541,6 +555,14 @@ func (self *CryptoDotCom) Order(
 	kind model.OrderType,
 	metadata string,
 ) (oid []byte, raw []byte, err error) {
+	if raw, ok := observeOrder(self, client, side, market, size, price, kind); ok {
+		return nil, raw, nil
+	}
+
+	if err := CheckEquityShare(self, client, side, market, size, price); err != nil {
+		return nil, nil, err
+	}
+
 	var out int64
 
 	crypto, ok := client.(*exchange.Client)
@@ -593,11 +615,14 @@ func (self *CryptoDotCom) GetClosed(client interface{}, market string) (model.Or
 	var out model.Orders
 	for _, trade := range trades {
 		out = append(out, model.Order{
-			Side:      self.getOrderSide(trade.GetSide()),
-			Market:    market,
-			Size:      trade.Volume,
-			Price:     trade.Price,
-			CreatedAt: trade.GetCreatedAt(),
+			Side:       self.getOrderSide(trade.GetSide()),
+			Status:     model.FILLED,
+			Market:     market,
+			Size:       trade.Volume,
+			Price:      trade.Price,
+			CreatedAt:  trade.GetCreatedAt(),
+			ClosedAt:   model.TimePtr(trade.GetCreatedAt()),
+			FilledSize: trade.Volume,
 		})
 	}
 
@@ -620,7 +645,9 @@ func (self *CryptoDotCom) GetOpened(client interface{}, market string) (model.Or
 	var out model.Orders
 	for _, order := range orders {
 		out = append(out, model.Order{
+			Status:    model.OPEN,
 			Side:      self.getOrderSide(order.GetSide()),
+			ID:        strconv.FormatInt(order.Id, 10),
 			Market:    market,
 			Size:      order.Volume,
 			Price:     order.Price,
@@ -699,6 +726,10 @@ func (self *CryptoDotCom) GetTicker(client interface{}, market string) (float64,
 	return ticker.Last, nil
 }
 
+func (self *CryptoDotCom) GetTickers(client interface{}) (map[string]float64, error) {
+	return getTickers(self, client)
+}
+
 func (self *CryptoDotCom) Get24h(client interface{}, market string) (*model.Stats, error) {
 	crypto, ok := client.(*exchange.Client)
 	if !ok {
@@ -718,6 +749,10 @@ func (self *CryptoDotCom) Get24h(client interface{}, market string) (*model.Stat
 	}, nil
 }
 
+func (self *CryptoDotCom) Get24hAll(client interface{}) (map[string]model.Stats, error) {
+	return get24hAll(self, client)
+}
+
 func (self *CryptoDotCom) GetPricePrec(client interface{}, market string) (int, error) {
 	crypto, ok := client.(*exchange.Client)
 	if !ok {
@@ -768,6 +803,10 @@ func (self *CryptoDotCom) GetMaxSize(client interface{}, base, quote string, hol
 }
 
 func (self *CryptoDotCom) Cancel(client interface{}, market string, side model.OrderSide) error {
+	if observeCancel(self, market, side) {
+		return nil
+	}
+
 	var err error
 
 	crypto, ok := client.(*exchange.Client)
@@ -791,7 +830,77 @@ func (self *CryptoDotCom) Cancel(client interface{}, market string, side model.O
 	return nil
 }
 
+func (self *CryptoDotCom) CancelOrder(client interface{}, market, id string) error {
+	if observeCancelOrder(self, market, id) {
+		return nil
+	}
+
+	crypto, ok := client.(*exchange.Client)
+	if !ok {
+		return errors.New("invalid argument: client")
+	}
+
+	orderID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return errors.Wrap(err, 1)
+	}
+
+	if err = crypto.CancelOrder(market, orderID); err != nil {
+		return errors.Wrap(err, 1)
+	}
+
+	return nil
+}
+
+func (self *CryptoDotCom) GetOrder(client interface{}, market, id string) (*model.Order, error) {
+	crypto, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	orderID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	var order *exchange.Order
+	if order, err = crypto.GetOrder(market, orderID); err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	out := &model.Order{
+		ID:         id,
+		Status:     model.OPEN,
+		Side:       self.getOrderSide(order.GetSide()),
+		Market:     market,
+		Size:       order.Volume,
+		Price:      order.Price,
+		CreatedAt:  order.GetCreatedAt(),
+		FilledSize: order.DealVolume,
+		Fee:        order.Fee,
+		FeeAsset:   order.FeeCoin,
+	}
+	switch order.Status {
+	case exchange.ORDER_STATUS_FILLED:
+		out.Status = model.FILLED
+		out.ClosedAt = model.TimePtr(order.GetUpdatedAt())
+	case exchange.ORDER_STATUS_CANCELED, exchange.ORDER_STATUS_EXPIRED:
+		out.Status = model.CANCELLED
+		out.ClosedAt = model.TimePtr(order.GetUpdatedAt())
+	}
+
+	return out, nil
+}
+
+func (self *CryptoDotCom) GetFees(client interface{}, market string) (*model.Fees, error) {
+	return getFees(self)
+}
+
 func (self *CryptoDotCom) Buy(client interface{}, cancel bool, market string, calls model.Calls, deviation float64, kind model.OrderType) error {
+	if observeBuy(self, client, cancel, market, calls, kind) {
+		return nil
+	}
+
 	var err error
 
 	crypto, ok := client.(*exchange.Client)
@@ -809,8 +918,8 @@ func (self *CryptoDotCom) Buy(client interface{}, cancel bool, market string, ca
 			side := order.GetSide()
 			if side == exchange.BUY {
 				// do not cancel orders that we're about to re-place
-				index := calls.IndexByPrice(order.Price)
-				if index > -1 && order.Volume == calls[index].Size {
+				index := calls.IndexByPriceSize(order.Price, order.Volume)
+				if index > -1 {
 					calls[index].Skip = true
 				} else {
 					if err = crypto.CancelOrder(market, order.Id); err != nil {
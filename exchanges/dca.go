@@ -0,0 +1,143 @@
+package exchanges
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/session"
+)
+
+// when a stop-loss gets filled and --dca is included, we buy back --dca-factor times what we sold. to prevent this
+// from running away on a market that keeps going down, we keep track of the DCA rounds per position. the position
+// starts over when a take-profit gets filled.
+
+type (
+	DcaPosition struct {
+		Rounds    int       `json:"rounds"`
+		Notional  float64   `json:"notional"` // what we have spent on DCA buys, in quote currency
+		UpdatedAt time.Time `json:"updated_at"`
+	}
+	DcaPositions map[string]DcaPosition // market -> DCA position
+)
+
+func dcaFile(exchange model.Exchange) string {
+	return session.GetSessionFile(strings.ToLower(exchange.GetInfo().Code) + ".dca.json")
+}
+
+func readDca(exchange model.Exchange) (DcaPositions, error) {
+	out := make(DcaPositions)
+	data, err := session.ReadFile(dcaFile(exchange))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return out, nil
+		}
+		return nil, errors.Wrap(err, 1)
+	}
+	if err = json.Unmarshal(data, &out); err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+	return out, nil
+}
+
+func (positions DcaPositions) write(exchange model.Exchange) error {
+	data, err := json.Marshal(positions)
+	if err != nil {
+		return errors.Wrap(err, 1)
+	}
+	return session.WriteFile(dcaFile(exchange), data)
+}
+
+// dcaSize returns the size of the buy order that follows a stop-loss fill of this size, or zero if we have reached
+// --dca-rounds=X or --dca-max=Y for this market.
+func dcaSize(exchange model.Exchange, client interface{}, market string, stopped float64) (float64, error) {
+	factor, err := flag.DcaFactor()
+	if err != nil {
+		return 0, err
+	}
+	rounds, err := flag.DcaRounds()
+	if err != nil {
+		return 0, err
+	}
+	max, err := flag.DcaMax()
+	if err != nil {
+		return 0, err
+	}
+
+	positions, err := readDca(exchange)
+	if err != nil {
+		return 0, err
+	}
+	pos := positions[market]
+
+	if rounds > 0 && pos.Rounds >= int(rounds) {
+		log.Printf("[INFO] Not re-buying %s because you have reached %d DCA rounds.\n", market, pos.Rounds)
+		return 0, nil
+	}
+
+	size := factor * stopped
+	if max > 0 {
+		ticker, err := exchange.GetTicker(client, market)
+		if err != nil {
+			return 0, err
+		}
+		if ticker > 0 && pos.Notional+(size*ticker) > max {
+			size = (max - pos.Notional) / ticker
+			if size <= 0 {
+				log.Printf("[INFO] Not re-buying %s because you have reached your max DCA notional of %.8f.\n", market, max)
+				return 0, nil
+			}
+		}
+	}
+
+	return size, nil
+}
+
+// recordDca adds a DCA buy to the position in this market
+func recordDca(exchange model.Exchange, client interface{}, market string, size float64) {
+	if err := func() error {
+		ticker, err := exchange.GetTicker(client, market)
+		if err != nil {
+			return err
+		}
+		positions, err := readDca(exchange)
+		if err != nil {
+			return err
+		}
+		pos := positions[market]
+		pos.Rounds++
+		pos.Notional += size * ticker
+		pos.UpdatedAt = time.Now()
+		positions[market] = pos
+		return positions.write(exchange)
+	}(); err != nil {
+		log.Printf("[ERROR] %v", err)
+	}
+}
+
+// resetDca starts the DCA rounds in this market over
+func resetDca(exchange model.Exchange, market string) {
+	if err := func() error {
+		positions, err := readDca(exchange)
+		if err != nil {
+			return err
+		}
+		if _, ok := positions[market]; !ok {
+			return nil
+		}
+		out := make(DcaPositions)
+		for key, value := range positions {
+			if key != market {
+				out[key] = value
+			}
+		}
+		return out.write(exchange)
+	}(); err != nil {
+		log.Printf("[ERROR] %v", err)
+	}
+}
@@ -0,0 +1,1088 @@
+//lint:file-ignore ST1006 receiver name should be a reflection of its identity; don't use generic names such as "this" or "self"
+package exchanges
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/svanas/nefertiti/aggregation"
+	exchange "github.com/svanas/nefertiti/deribit"
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/i18n"
+	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/multiplier"
+	"github.com/svanas/nefertiti/notify"
+	"github.com/svanas/nefertiti/override"
+	"github.com/svanas/nefertiti/precision"
+	"github.com/svanas/nefertiti/pricing"
+	"github.com/svanas/nefertiti/session"
+)
+
+var (
+	deribitMutex *session.Mutex
+)
+
+const (
+	deribitSessionFile = "deribit.time"
+	deribitSessionLock = "deribit.lock"
+)
+
+func init() {
+	exchange.BeforeRequest = func(method, path string, rps float64) error {
+		var err error
+
+		if deribitMutex == nil {
+			if deribitMutex, err = session.NewMutex(deribitSessionLock); err != nil {
+				return err
+			}
+		}
+
+		if err = deribitMutex.Lock(); err != nil {
+			return err
+		}
+
+		var lastRequest *time.Time
+		if lastRequest, err = session.GetLastRequest(deribitSessionFile); err != nil {
+			return err
+		}
+
+		if lastRequest != nil {
+			elapsed := time.Since(*lastRequest)
+			if elapsed.Seconds() < (float64(1) / rps) {
+				sleep := time.Duration((float64(time.Second) / rps) - float64(elapsed))
+				if flag.Debug() {
+					log.Printf("[DEBUG] sleeping %f seconds\n", sleep.Seconds())
+				}
+				time.Sleep(sleep)
+			}
+		}
+
+		if flag.Debug() {
+			log.Printf("[DEBUG] %s %s\n", method, path)
+		}
+
+		return nil
+	}
+	exchange.AfterRequest = func() {
+		defer func() {
+			deribitMutex.Unlock()
+		}()
+		session.SetLastRequest(deribitSessionFile, time.Now())
+	}
+}
+
+type Deribit struct {
+	*model.ExchangeInfo
+	instruments []exchange.Instrument
+}
+
+func (self *Deribit) error(err error, level int64, service model.Notify) {
+	pc, file, line, _ := runtime.Caller(1)
+	prefix := errors.FormatCaller(pc, file, line)
+
+	msg := fmt.Sprintf("%s %v", prefix, err)
+	_, ok := err.(*errors.Error)
+	if ok && flag.Debug() {
+		log.Printf("[ERROR] %s", err.(*errors.Error).ErrorStack(prefix, ""))
+	} else {
+		log.Printf("[ERROR] %s", msg)
+	}
+
+	if service != nil {
+		if notify.CanSend(level, notify.ERROR) {
+			err := notify.SendError(service, err, msg, "Deribit - ERROR")
+			if err != nil {
+				log.Printf("[ERROR] %v", err)
+			}
+		}
+	}
+}
+
+func (self *Deribit) indexByOrderID(orders []exchange.Order, id string) int {
+	for i, o := range orders {
+		if o.OrderID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (self *Deribit) getBaseURL(sandbox bool) string {
+	if sandbox {
+		return self.ExchangeInfo.REST.Sandbox
+	}
+	return self.ExchangeInfo.REST.URI
+}
+
+// returns the perpetual swaps and the options (read-only) for BTC and ETH
+func (self *Deribit) getInstruments(client *exchange.Client, cached bool) ([]exchange.Instrument, error) {
+	if self.instruments == nil || !cached {
+		var out []exchange.Instrument
+		for _, currency := range exchange.Currencies {
+			for _, kind := range []exchange.Kind{exchange.KIND_FUTURE, exchange.KIND_OPTION} {
+				instruments, err := client.Instruments(currency, kind)
+				if err != nil {
+					return nil, errors.Wrap(err, 1)
+				}
+				for _, instrument := range instruments {
+					if instrument.IsActive && (instrument.IsPerpetual() || instrument.IsOption()) {
+						out = append(out, instrument)
+					}
+				}
+			}
+		}
+		self.instruments = out
+	}
+	return self.instruments, nil
+}
+
+func (self *Deribit) getInstrument(client *exchange.Client, name string) (*exchange.Instrument, error) {
+	instruments, err := self.getInstruments(client, true)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, instrument := range instruments {
+		if instrument.InstrumentName == name {
+			return &instrument, nil
+		}
+	}
+
+	return nil, errors.Errorf("instrument %v does not exist", name)
+}
+
+// returns the perpetual swaps only, because we don't trade options
+func (self *Deribit) getPerpetuals(client *exchange.Client) ([]exchange.Instrument, error) {
+	instruments, err := self.getInstruments(client, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []exchange.Instrument
+	for _, instrument := range instruments {
+		if instrument.IsPerpetual() {
+			out = append(out, instrument)
+		}
+	}
+
+	return out, nil
+}
+
+// returns an error if the order would take the position beyond --leverage=X
+func (self *Deribit) checkLeverage(client *exchange.Client, instrument *exchange.Instrument, direction exchange.OrderDirection, amount, price float64) error {
+	max, err := flag.Leverage()
+	if err != nil {
+		return err
+	}
+
+	position, err := client.Position(instrument.InstrumentName)
+	if err != nil {
+		return errors.Wrap(err, 1)
+	}
+
+	account, err := client.AccountSummary(instrument.BaseCurrency)
+	if err != nil {
+		return errors.Wrap(err, 1)
+	}
+
+	size := position.Size
+	if direction == exchange.OrderDirectionBuy {
+		size = size + amount
+	} else {
+		size = size - amount
+	}
+
+	if account.Equity <= 0 {
+		return errors.Errorf("cannot open a position in %s without equity", instrument.InstrumentName)
+	}
+
+	leverage := math.Abs(instrument.FromAmount(size, price)) / account.Equity
+	if leverage > max {
+		return errors.Errorf("order would take %s to %.2fx leverage, exceeding the cap of %vx", instrument.InstrumentName, leverage, max)
+	}
+
+	return nil
+}
+
+func (self *Deribit) GetInfo() *model.ExchangeInfo {
+	return self.ExchangeInfo
+}
+
+func (self *Deribit) GetClient(permission model.Permission, sandbox bool) (interface{}, error) {
+	if permission == model.PUBLIC {
+		return exchange.New(self.getBaseURL(sandbox), "", ""), nil
+	}
+
+	clientID, clientSecret, err := promptForApiKeys("Deribit")
+	if err != nil {
+		return nil, err
+	}
+
+	return exchange.New(self.getBaseURL(sandbox), clientID, clientSecret), nil
+}
+
+func (self *Deribit) GetMarkets(cached, sandbox bool, blacklist []string) ([]model.Market, error) {
+	var out []model.Market
+
+	client := exchange.New(self.getBaseURL(sandbox), "", "")
+	if _, err := self.getInstruments(client, cached); err != nil {
+		return nil, err
+	}
+
+	perpetuals, err := self.getPerpetuals(client)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, perpetual := range perpetuals {
+		if func() bool {
+			for _, ignore := range blacklist {
+				if strings.EqualFold(perpetual.InstrumentName, ignore) {
+					return false
+				}
+			}
+			return true
+		}() {
+			out = append(out, model.Market{
+				Name:  perpetual.InstrumentName,
+				Base:  perpetual.BaseCurrency,
+				Quote: perpetual.QuoteCurrency,
+			})
+		}
+	}
+
+	return out, nil
+}
+
+func (self *Deribit) FormatMarket(base, quote string) string {
+	return exchange.FormatPerpetual(base)
+}
+
+// listen to the opened orders, look for cancelled orders, send a notification.
+func (self *Deribit) listen(
+	client *exchange.Client,
+	service model.Notify,
+	level int64,
+	old []exchange.Order,
+	filled []exchange.Order,
+) ([]exchange.Order, error) {
+	perpetuals, err := self.getPerpetuals(client)
+	if err != nil {
+		return old, err
+	}
+
+	// get my opened orders
+	var new []exchange.Order
+	for _, perpetual := range perpetuals {
+		orders, err := client.OpenOrders(perpetual.InstrumentName)
+		if err != nil {
+			return old, errors.Wrap(err, 1)
+		}
+		new = append(new, orders...)
+	}
+
+	// look for cancelled orders
+	for _, order := range old {
+		if self.indexByOrderID(new, order.OrderID) == -1 {
+			// if this order has NOT been FILLED, then it has been cancelled.
+			if self.indexByOrderID(filled, order.OrderID) == -1 {
+				data, err := json.Marshal(order)
+				if err != nil {
+					return new, errors.Wrap(err, 1)
+				}
+
+				log.Println("[CANCELLED] " + string(data))
+
+				if service != nil {
+					if notify.CanSend(level, notify.CANCELLED) {
+						err := service.SendMessage(order, fmt.Sprintf("Deribit - %s", i18n.Sprintf("Done %s (Reason: Cancelled)", model.FormatOrderSide(model.NewOrderSide(string(order.Direction))))), model.ALWAYS)
+						if err != nil {
+							log.Printf("[ERROR] %v", err)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	// look for newly opened orders
+	for _, order := range new {
+		if self.indexByOrderID(old, order.OrderID) == -1 {
+			data, err := json.Marshal(order)
+			if err != nil {
+				return new, errors.Wrap(err, 1)
+			}
+
+			log.Println("[OPEN] " + string(data))
+
+			if service != nil {
+				if notify.CanSend(level, notify.OPENED) || (level == notify.LEVEL_DEFAULT && order.Direction == exchange.OrderDirectionSell) {
+					err := service.SendMessage(order, fmt.Sprintf("Deribit - %s", i18n.Sprintf("Open %s", model.FormatOrderSide(model.NewOrderSide(string(order.Direction))))), model.ALWAYS)
+					if err != nil {
+						log.Printf("[ERROR] %v", err)
+					}
+				}
+			}
+		}
+	}
+
+	return new, nil
+}
+
+// listen to the filled orders, look for newly filled orders, automatically place new reduce-only sell orders.
+func (self *Deribit) sell(
+	client *exchange.Client,
+	strategy model.Strategy,
+	mult multiplier.Mult,
+	stop multiplier.Mult,
+	hold model.Markets,
+	service model.Notify,
+	level int64,
+	old []exchange.Order,
+) ([]exchange.Order, error) {
+	perpetuals, err := self.getPerpetuals(client)
+	if err != nil {
+		return old, err
+	}
+
+	// get my filled orders
+	var filled []exchange.Order
+	for _, perpetual := range perpetuals {
+		orders, err := client.FilledOrders(perpetual.InstrumentName)
+		if err != nil {
+			return old, errors.Wrap(err, 1)
+		}
+		filled = append(filled, orders...)
+	}
+
+	// make a list of newly filled orders
+	var new []exchange.Order
+	for _, order := range filled {
+		if self.indexByOrderID(old, order.OrderID) == -1 && processOnce(self, order.OrderID) {
+			new = append(new, order)
+		}
+	}
+
+	// send notification(s)
+	for _, order := range new {
+		data, err := json.Marshal(order)
+		if err != nil {
+			self.error(err, level, service)
+		} else {
+			log.Println("[FILLED] " + string(data))
+			if notify.CanSend(level, notify.FILLED) && service != nil {
+				err := service.SendMessage(order, fmt.Sprintf("Deribit - %s", i18n.Sprintf("Done %s (Reason: Filled)", model.FormatOrderSide(model.NewOrderSide(string(order.Direction))))), model.ALWAYS)
+				if err != nil {
+					log.Printf("[ERROR] %v", err)
+				}
+			}
+		}
+		// has a stop loss been filled? the amount of a perpetual is in USD already
+		if strategy == model.STRATEGY_STOP_LOSS && order.Direction == exchange.OrderDirectionSell && order.OrderType == exchange.OrderTypeStopMarket {
+			recordLoss(self, order.InstrumentName, order.FilledAmount, stop, service)
+		}
+		// has a take profit been filled? then add the profit to our journal
+		if order.Direction == exchange.OrderDirectionSell && order.OrderType == exchange.OrderTypeLimit {
+			recordTakeProfit(self, client, order.InstrumentName, order.FilledAmount, mult)
+		}
+	}
+
+	// has a buy order been filled? then place a reduce-only sell order
+	for _, order := range new {
+		if order.Direction != exchange.OrderDirectionBuy || order.ReduceOnly || hold.HasMarket(order.InstrumentName) {
+			continue
+		}
+
+		instrument, err := self.getInstrument(client, order.InstrumentName)
+		if err == nil {
+			bought := order.ExecutedAt()
+			if strategy == model.STRATEGY_STOP_LOSS {
+				trigger := pricing.MultiplyTick(bought, override.Stop(order.InstrumentName, stop), instrument.TickSize)
+				if _, ok := observeStopLoss(self, client, order.InstrumentName, order.FilledAmount, trigger); !ok {
+					_, err = client.Order(order.InstrumentName, exchange.OrderDirectionSell, &exchange.NewOrder{
+						Type:         exchange.OrderTypeStopMarket,
+						Amount:       order.FilledAmount,
+						TriggerPrice: trigger,
+						ReduceOnly:   true,
+					})
+				}
+			} else {
+				price := pricing.MultiplyTick(bought, override.Mult(order.InstrumentName, mult), instrument.TickSize)
+				if _, ok := observeOrder(self, client, model.SELL, order.InstrumentName, order.FilledAmount, price, model.LIMIT); !ok {
+					_, err = client.Order(order.InstrumentName, exchange.OrderDirectionSell, &exchange.NewOrder{
+						Type:       exchange.OrderTypeLimit,
+						Amount:     order.FilledAmount,
+						Price:      price,
+						ReduceOnly: true,
+					})
+				}
+			}
+		}
+
+		if err != nil {
+			data, _ := json.Marshal(order)
+			if data == nil {
+				self.error(err, level, service)
+			} else {
+				self.error(errors.Append(err, "\t", string(data)), level, service)
+			}
+		}
+	}
+
+	return filled, nil
+}
+
+func (self *Deribit) Sell(
+	strategy model.Strategy,
+	hold, earn model.Markets,
+	sandbox, tweet, debug bool,
+	success model.OnSuccess,
+) error {
+	if strategy == model.STRATEGY_STANDARD || strategy == model.STRATEGY_STOP_LOSS {
+		// we are OK
+	} else {
+		return errors.New("strategy not implemented")
+	}
+
+	clientID, clientSecret, err := promptForApiKeys("Deribit")
+	if err != nil {
+		return err
+	}
+
+	service, err := notify.New().Init(flag.Interactive(), true)
+	if err != nil {
+		return err
+	}
+
+	client := exchange.New(self.getBaseURL(sandbox), clientID, clientSecret)
+
+	perpetuals, err := self.getPerpetuals(client)
+	if err != nil {
+		return err
+	}
+
+	var (
+		filled []exchange.Order
+		opened []exchange.Order
+	)
+
+	// get my filled orders
+	for _, perpetual := range perpetuals {
+		orders, err := client.FilledOrders(perpetual.InstrumentName)
+		if err != nil {
+			return errors.Wrap(err, 1)
+		}
+		filled = append(filled, orders...)
+	}
+
+	// get my opened orders
+	for _, perpetual := range perpetuals {
+		orders, err := client.OpenOrders(perpetual.InstrumentName)
+		if err != nil {
+			return errors.Wrap(err, 1)
+		}
+		opened = append(opened, orders...)
+	}
+
+	if err = success(service); err != nil {
+		return err
+	}
+
+	for {
+		// read the dynamic settings
+		var (
+			level int64 = notify.LEVEL_DEFAULT
+			mult  multiplier.Mult
+			stop  multiplier.Mult
+		)
+		if level, err = notify.Level(); err != nil {
+			self.error(err, level, service)
+		} else if mult, err = multiplier.Get(multiplier.FIVE_PERCENT); err != nil {
+			self.error(err, level, service)
+		} else if stop, err = multiplier.Stop(); err != nil {
+			self.error(err, level, service)
+		} else
+		// listen to the filled orders, look for newly filled orders, automatically place new reduce-only sell orders.
+		if filled, err = self.sell(client, strategy, mult, stop, hold, service, level, filled); err != nil {
+			self.error(err, level, service)
+		} else
+		// listen to the opened orders, look for cancelled orders, send a notification.
+		if opened, err = self.listen(client, service, level, opened, filled); err != nil {
+			self.error(err, level, service)
+		} else
+		// listen to the opened orders, follow up on the stop loss strategy
+		if strategy == model.STRATEGY_STOP_LOSS {
+			for _, order := range opened {
+				// enumerate over the untriggered stop orders
+				if order.IsStop() && order.ReduceOnly && order.OrderState == exchange.OrderStateUntriggered {
+					var ticker float64
+					if ticker, err = self.GetTicker(client, order.InstrumentName); err == nil {
+						var tick float64
+						if tick, err = self.GetTickSize(client, order.InstrumentName); err == nil {
+							bought := order.TriggerPrice / float64(override.Stop(order.InstrumentName, stop))
+							if ticker >= pricing.MultiplyTick(bought, override.Mult(order.InstrumentName, mult), tick) {
+								if _, ok := observeOrder(self, client, model.SELL, order.InstrumentName, order.Amount, 0, model.MARKET); !ok {
+									if err = client.CancelOrder(order.OrderID); err == nil {
+										_, err = client.Order(order.InstrumentName, exchange.OrderDirectionSell, &exchange.NewOrder{
+											Type:       exchange.OrderTypeMarket,
+											Amount:     order.Amount,
+											ReduceOnly: true,
+										})
+									}
+								}
+							}
+						}
+					}
+					if err != nil {
+						data, _ := json.Marshal(order)
+						if data == nil {
+							self.error(err, level, service)
+						} else {
+							self.error(errors.Append(err, "\t", string(data)), level, service)
+						}
+					}
+				}
+			}
+		}
+		beat(self.GetInfo().Name, err)
+	}
+}
+
+func (self *Deribit) Order(
+	client interface{},
+	side model.OrderSide,
+	market string,
+	size float64,
+	price float64,
+	kind model.OrderType,
+	metadata string,
+) (oid []byte, raw []byte, err error) {
+	if raw, ok := observeOrder(self, client, side, market, size, price, kind); ok {
+		return nil, raw, nil
+	}
+
+	if err := CheckEquityShare(self, client, side, market, size, price); err != nil {
+		return nil, nil, err
+	}
+
+	deribitClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, nil, errors.New("invalid argument: client")
+	}
+
+	instrument, err := self.getInstrument(deribitClient, market)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !instrument.IsPerpetual() {
+		return nil, nil, errors.Errorf("cannot trade %s. options are read-only", market)
+	}
+
+	if price == 0 {
+		if price, err = self.GetTicker(client, market); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	direction := exchange.OrderDirectionSell
+	if side == model.BUY {
+		direction = exchange.OrderDirectionBuy
+	}
+
+	amount := instrument.ToAmount(size, price)
+	if err = self.checkLeverage(deribitClient, instrument, direction, amount, price); err != nil {
+		return nil, nil, err
+	}
+
+	order, err := deribitClient.Order(market, direction, &exchange.NewOrder{
+		Type: func() exchange.OrderType {
+			if kind == model.MARKET {
+				return exchange.OrderTypeMarket
+			}
+			return exchange.OrderTypeLimit
+		}(),
+		Amount: amount,
+		Price:  instrument.RoundPrice(price),
+		Label:  metadata,
+	})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, 1)
+	}
+
+	if raw, err = json.Marshal(order); err != nil {
+		return nil, nil, errors.Wrap(err, 1)
+	}
+
+	return []byte(order.OrderID), raw, nil
+}
+
+// places a reduce-only stop order that closes (part of) a long position.
+func (self *Deribit) GetStops() model.Stops {
+	return model.Stops{Strategy: true, Stop: true}
+}
+
+func (self *Deribit) StopLoss(client interface{}, market string, size float64, price float64, kind model.OrderType, metadata string) ([]byte, error) {
+	if raw, ok := observeStopLoss(self, client, market, size, price); ok {
+		return raw, nil
+	}
+
+	deribitClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	instrument, err := self.getInstrument(deribitClient, market)
+	if err != nil {
+		return nil, err
+	}
+	if !instrument.IsPerpetual() {
+		return nil, errors.Errorf("cannot trade %s. options are read-only", market)
+	}
+
+	order, err := deribitClient.Order(market, exchange.OrderDirectionSell, &exchange.NewOrder{
+		Type: func() exchange.OrderType {
+			if kind == model.LIMIT {
+				return exchange.OrderTypeStopLimit
+			}
+			return exchange.OrderTypeStopMarket
+		}(),
+		Amount:       instrument.ToAmount(size, price),
+		Price:        instrument.RoundPrice(price),
+		TriggerPrice: instrument.RoundPrice(price),
+		ReduceOnly:   true,
+		Label:        metadata,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	out, err := json.Marshal(order)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	return out, nil
+}
+
+func (self *Deribit) OCO(client interface{}, market string, size float64, price, stop float64, metadata string) ([]byte, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (self *Deribit) GetClosed(client interface{}, market string) (model.Orders, error) {
+	deribitClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	instrument, err := self.getInstrument(deribitClient, market)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := deribitClient.FilledOrders(market)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	var output model.Orders
+	for _, order := range orders {
+		output = append(output, model.Order{
+			Side: func() model.OrderSide {
+				if order.Direction == exchange.OrderDirectionSell {
+					return model.SELL
+				}
+				return model.BUY
+			}(),
+			Status:     model.FILLED,
+			Market:     market,
+			Size:       instrument.FromAmount(order.Amount, order.ExecutedAt()),
+			Price:      order.ExecutedAt(),
+			CreatedAt:  order.CreatedAt(),
+			ClosedAt:   model.TimePtr(order.UpdatedAt()),
+			FilledSize: instrument.FromAmount(order.FilledAmount, order.ExecutedAt()),
+		})
+	}
+
+	return output, nil
+}
+
+func (self *Deribit) GetOpened(client interface{}, market string) (model.Orders, error) {
+	deribitClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	instrument, err := self.getInstrument(deribitClient, market)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := deribitClient.OpenOrders(market)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	var output model.Orders
+	for _, order := range orders {
+		price := order.GetPrice()
+		if order.IsStop() {
+			price = order.TriggerPrice
+		}
+		output = append(output, model.Order{
+			Status: model.OPEN,
+			Side: func() model.OrderSide {
+				if order.Direction == exchange.OrderDirectionSell {
+					return model.SELL
+				}
+				return model.BUY
+			}(),
+			ID:        order.OrderID,
+			Market:    market,
+			Size:      instrument.FromAmount(order.Amount, price),
+			Price:     price,
+			CreatedAt: order.CreatedAt(),
+		})
+	}
+
+	return output, nil
+}
+
+func (self *Deribit) GetBook(client interface{}, market string, side model.BookSide) (interface{}, error) {
+	deribitClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	book, err := deribitClient.OrderBook(market)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	if side == model.BOOK_SIDE_ASKS {
+		return book.Asks, nil
+	}
+	return book.Bids, nil
+}
+
+func (self *Deribit) Aggregate(client, book interface{}, market string, agg float64) (model.Book, error) {
+	deribitClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	bids, ok := book.([]exchange.BookEntry)
+	if !ok {
+		return nil, errors.New("invalid argument: book")
+	}
+
+	instrument, err := self.getInstrument(deribitClient, market)
+	if err != nil {
+		return nil, err
+	}
+
+	var out model.Book
+	for _, e := range bids {
+		price := instrument.RoundPrice(aggregation.Round(e.Price, agg))
+		size := instrument.FromAmount(e.Amount, e.Price)
+		entry := out.EntryByPrice(price)
+		if entry != nil {
+			entry.Size = entry.Size + size
+		} else {
+			entry = &model.Buy{
+				Market: market,
+				Price:  price,
+				Size:   size,
+			}
+			out = append(out, *entry)
+		}
+	}
+
+	return out, nil
+}
+
+func (self *Deribit) GetTicker(client interface{}, market string) (float64, error) {
+	deribitClient, ok := client.(*exchange.Client)
+	if !ok {
+		return 0, errors.New("invalid argument: client")
+	}
+
+	ticker, err := deribitClient.Ticker(market)
+	if err != nil {
+		return 0, errors.Wrap(err, 1)
+	}
+
+	return ticker.LastPrice, nil
+}
+
+func (self *Deribit) GetTickers(client interface{}) (map[string]float64, error) {
+	return getTickers(self, client)
+}
+
+func (self *Deribit) Get24h(client interface{}, market string) (*model.Stats, error) {
+	deribitClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	ticker, err := deribitClient.Ticker(market)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	return &model.Stats{
+		Market: market,
+		High:   ticker.Stats.High,
+		Low:    ticker.Stats.Low,
+		Last:   ticker.LastPrice,
+		Change: ticker.Stats.Change,
+		BtcVolume: func(ticker1 *exchange.Ticker) float64 {
+			if strings.EqualFold(exchange.ParseCurrency(market), model.BTC) {
+				return ticker1.Stats.Volume
+			}
+			ticker2, err := deribitClient.Ticker(exchange.FormatPerpetual(model.BTC))
+			if err == nil && ticker2.LastPrice > 0 {
+				return ticker1.Stats.VolumeUSD / ticker2.LastPrice
+			}
+			return 0
+		}(ticker),
+	}, nil
+}
+
+func (self *Deribit) Get24hAll(client interface{}) (map[string]model.Stats, error) {
+	return get24hAll(self, client)
+}
+
+func (self *Deribit) GetPricePrec(client interface{}, market string) (int, error) {
+	deribitClient, ok := client.(*exchange.Client)
+	if !ok {
+		return 8, errors.New("invalid argument: client")
+	}
+
+	instrument, err := self.getInstrument(deribitClient, market)
+	if err != nil {
+		return 8, err
+	}
+
+	return precision.Parse(strconv.FormatFloat(instrument.TickSize, 'f', -1, 64), 8), nil
+}
+
+func (self *Deribit) GetTickSize(client interface{}, market string) (float64, error) {
+	deribitClient, ok := client.(*exchange.Client)
+	if !ok {
+		return 0, errors.New("invalid argument: client")
+	}
+
+	instrument, err := self.getInstrument(deribitClient, market)
+	if err != nil {
+		return 0, err
+	}
+
+	return instrument.TickSize, nil
+}
+
+// sizes are in the underlying currency. they get converted into contracts when we place an order.
+func (self *Deribit) GetSizePrec(client interface{}, market string) (int, error) {
+	return 8, nil
+}
+
+func (self *Deribit) GetMaxSize(client interface{}, base, quote string, hold, earn bool, def float64, mult multiplier.Mult) float64 {
+	return model.GetSizeMax(hold, earn, def, mult, func() int {
+		prec, err := self.GetSizePrec(client, self.FormatMarket(base, quote))
+		if err != nil {
+			return 0
+		}
+		return prec
+	})
+}
+
+func (self *Deribit) Cancel(client interface{}, market string, side model.OrderSide) error {
+	if observeCancel(self, market, side) {
+		return nil
+	}
+
+	deribitClient, ok := client.(*exchange.Client)
+	if !ok {
+		return errors.New("invalid argument: client")
+	}
+
+	orders, err := deribitClient.OpenOrders(market)
+	if err != nil {
+		return errors.Wrap(err, 1)
+	}
+
+	for _, order := range orders {
+		if ((side == model.BUY) && (order.Direction == exchange.OrderDirectionBuy)) || ((side == model.SELL) && (order.Direction == exchange.OrderDirectionSell)) {
+			if err := deribitClient.CancelOrder(order.OrderID); err != nil {
+				return errors.Wrap(err, 1)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (self *Deribit) CancelOrder(client interface{}, market, id string) error {
+	if observeCancelOrder(self, market, id) {
+		return nil
+	}
+
+	deribitClient, ok := client.(*exchange.Client)
+	if !ok {
+		return errors.New("invalid argument: client")
+	}
+
+	if err := deribitClient.CancelOrder(id); err != nil {
+		return errors.Wrap(err, 1)
+	}
+
+	return nil
+}
+
+func (self *Deribit) GetOrder(client interface{}, market, id string) (*model.Order, error) {
+	deribitClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	instrument, err := self.getInstrument(deribitClient, market)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := deribitClient.GetOrderState(id)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	price := order.GetPrice()
+	if order.IsStop() {
+		price = order.TriggerPrice
+	}
+	out := &model.Order{
+		ID:     id,
+		Status: model.OPEN,
+		Side: func() model.OrderSide {
+			if order.Direction == exchange.OrderDirectionSell {
+				return model.SELL
+			}
+			return model.BUY
+		}(),
+		Market:     market,
+		Size:       instrument.FromAmount(order.Amount, price),
+		Price:      price,
+		CreatedAt:  order.CreatedAt(),
+		FilledSize: instrument.FromAmount(order.FilledAmount, order.ExecutedAt()),
+		Stop:       order.TriggerPrice,
+	}
+	switch order.OrderState {
+	case exchange.OrderStateFilled:
+		out.Status = model.FILLED
+		out.ClosedAt = model.TimePtr(order.UpdatedAt())
+	case exchange.OrderStateCancelled, exchange.OrderStateRejected:
+		out.Status = model.CANCELLED
+		out.ClosedAt = model.TimePtr(order.UpdatedAt())
+	}
+
+	return out, nil
+}
+
+func (self *Deribit) GetFees(client interface{}, market string) (*model.Fees, error) {
+	return getFees(self)
+}
+
+func (self *Deribit) Buy(client interface{}, cancel bool, market string, calls model.Calls, deviation float64, kind model.OrderType) error {
+	if observeBuy(self, client, cancel, market, calls, kind) {
+		return nil
+	}
+
+	deribitClient, ok := client.(*exchange.Client)
+	if !ok {
+		return errors.New("invalid argument: client")
+	}
+
+	// step #1: delete the buy order(s) that are open in your book
+	if cancel {
+		orders, err := deribitClient.OpenOrders(market)
+		if err != nil {
+			return errors.Wrap(err, 1)
+		}
+		for _, order := range orders {
+			if order.Direction == exchange.OrderDirectionBuy && !order.IsStop() {
+				// do not cancel orders that we're about to re-place
+				index := calls.IndexByPrice(order.GetPrice())
+				if index > -1 {
+					calls[index].Skip = true
+				} else {
+					if err := deribitClient.CancelOrder(order.OrderID); err != nil {
+						return errors.Wrap(err, 1)
+					}
+				}
+			}
+		}
+	}
+
+	// step 2: open the top X buy orders
+	for _, call := range calls {
+		if !call.Skip {
+			limit := call.Price
+			if deviation != 1.0 {
+				kind, limit = call.Deviate(self, client, kind, deviation)
+			}
+			if _, _, err := self.Order(client, model.BUY, market, call.Size, limit, kind, ""); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (self *Deribit) IsLeveragedToken(name string) bool {
+	return false
+}
+
+func (self *Deribit) HasAlgoOrder(client interface{}, market string) (bool, error) {
+	deribitClient, ok := client.(*exchange.Client)
+	if !ok {
+		return false, errors.New("invalid argument: client")
+	}
+
+	orders, err := deribitClient.OpenOrders(market)
+	if err != nil {
+		return false, errors.Wrap(err, 1)
+	}
+
+	for _, order := range orders {
+		if order.IsStop() {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func newDeribit() model.Exchange {
+	return &Deribit{
+		ExchangeInfo: &model.ExchangeInfo{
+			Code: "DRBT",
+			Name: "Deribit",
+			URL:  "https://www.deribit.com",
+			REST: model.Endpoint{
+				URI:     exchange.API_BASE,
+				Sandbox: exchange.API_TEST,
+			},
+			WebSocket: model.Endpoint{
+				URI:     "wss://www.deribit.com/ws/api/v2",
+				Sandbox: "wss://test.deribit.com/ws/api/v2",
+			},
+			Country: "Panama",
+		},
+	}
+}
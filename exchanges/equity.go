@@ -0,0 +1,146 @@
+package exchanges
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/model"
+)
+
+// getEquity returns what your wallet is worth in quote currency: the quote currency and the assets that trade against
+// it, including what is on hold in our open orders.
+func getEquity(exchange model.Exchange, client interface{}, reader model.HoldingReader, markets []model.Market, quote string) (float64, error) {
+	holdings, err := reader.GetHoldings(client)
+	if err != nil {
+		return 0, err
+	}
+
+	var out float64
+	for asset, holding := range holdings {
+		if holding.Total() <= 0 {
+			continue
+		}
+		if strings.EqualFold(asset, quote) {
+			out += holding.Total()
+			continue
+		}
+		if market := model.FindMarket(markets, model.NewMarketSymbol(asset, quote)); market != nil {
+			ticker, err := exchange.GetTicker(client, market.Name)
+			if err != nil {
+				return 0, err
+			}
+			out += holding.Total() * ticker
+		}
+	}
+
+	return out, nil
+}
+
+// your equity asks for every balance and every ticker, so we compute it once for every ladder (or DCA buy) rather
+// than once for every order in that ladder.
+const equityTTL = time.Minute
+
+type equityEntry struct {
+	value float64
+	at    time.Time
+}
+
+var (
+	equityCache = make(map[string]equityEntry) // exchange code + quote -> equity
+	equityMutex sync.Mutex
+)
+
+func getEquityCached(exchange model.Exchange, client interface{}, reader model.HoldingReader, markets []model.Market, quote string) (float64, error) {
+	key := exchange.GetInfo().Code + "." + strings.ToUpper(quote)
+
+	equityMutex.Lock()
+	entry, ok := equityCache[key]
+	equityMutex.Unlock()
+	if ok && time.Since(entry.at) < equityTTL {
+		return entry.value, nil
+	}
+
+	value, err := getEquity(exchange, client, reader, markets, quote)
+	if err != nil {
+		return 0, err
+	}
+
+	equityMutex.Lock()
+	equityCache[key] = equityEntry{value: value, at: time.Now()}
+	equityMutex.Unlock()
+
+	return value, nil
+}
+
+// ValidateEquityShare returns an error if you asked for --max-equity-share on an exchange that cannot tell us what is
+// in your wallet (at the time of this writing, only GDAX and KuCoin can).
+func ValidateEquityShare(exchange model.Exchange) error {
+	max, err := flag.MaxEquityShare()
+	if err != nil || max == 0 {
+		return err
+	}
+	if _, ok := exchange.(model.HoldingReader); !ok {
+		return errors.Errorf("%s does not support --max-equity-share", exchange.GetInfo().Name)
+	}
+	return nil
+}
+
+// CheckEquityShare refuses a buy order that would take the market beyond --max-equity-share=X percent of your
+// equity. we call this before we send an order to the exchange, so it applies to the DCA buys too.
+func CheckEquityShare(exchange model.Exchange, client interface{}, side model.OrderSide, market string, size, price float64) error {
+	if side != model.BUY {
+		return nil
+	}
+
+	max, err := flag.MaxEquityShare()
+	if err != nil || max == 0 {
+		return err
+	}
+
+	reader, ok := exchange.(model.HoldingReader)
+	if !ok {
+		return errors.Errorf("%s does not support --max-equity-share", exchange.GetInfo().Name)
+	}
+
+	markets, err := exchange.GetMarkets(true, flag.Sandbox(), nil)
+	if err != nil {
+		return err
+	}
+	quote, err := model.GetQuoteCurr(markets, market)
+	if err != nil {
+		return err
+	}
+
+	ticker, err := exchange.GetTicker(client, market)
+	if err != nil {
+		return err
+	}
+	if price == 0 {
+		price = ticker // market orders have no price
+	}
+
+	equity, err := getEquityCached(exchange, client, reader, markets, quote)
+	if err != nil {
+		return err
+	}
+	if equity <= 0 {
+		return nil
+	}
+
+	// what we hold in this market (available + on hold in our open orders) plus this order
+	exposure, err := getExposure(exchange, client, markets, market, false)
+	if err != nil {
+		return err
+	}
+	exposure += size * price
+
+	if share := exposure / equity * 100; share > max {
+		return errors.Errorf("Cannot buy %s. Your exposure would be %.2f%% of your equity, and that exceeds your max equity share of %.2f%%.",
+			market, share, max)
+	}
+
+	return nil
+}
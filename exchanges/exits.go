@@ -0,0 +1,187 @@
+package exchanges
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/session"
+)
+
+// Exit is the sell (or OCO) order that we have opened for a filled buy order
+type Exit struct {
+	Market   string     `json:"market"`
+	BuyId    string     `json:"buy_id"`
+	Bought   float64    `json:"bought"` // the price we paid
+	Size     float64    `json:"size"`
+	SellId   string     `json:"sell_id,omitempty"` // empty if the exchange didn't tell us
+	Price    float64    `json:"price"`             // the take profit
+	Stop     float64    `json:"stop,omitempty"`    // the stop-loss, if this is an OCO
+	OpenedAt time.Time  `json:"opened_at"`
+	ClosedAt *time.Time `json:"closed_at,omitempty"` // nil while the exit is open
+	Sold     float64    `json:"sold,omitempty"`      // the price we sold at
+	Profit   float64    `json:"profit,omitempty"`    // (sold - bought) * size, in quote currency and before fees
+	Run      string     `json:"run,omitempty"`       // the run ID (and label) of the loop that opened this exit
+}
+
+// Exits maps the buy order ID onto its exit
+type Exits map[string]Exit
+
+// we forget about the exits after this long
+const exitsRetention = 90 * 24 * time.Hour
+
+func exitsFile(exchange model.Exchange) string {
+	return session.GetSessionFile(strings.ToLower(exchange.GetInfo().Code) + ".exits.json")
+}
+
+func ReadExits(exchange model.Exchange) (Exits, error) {
+	out := make(Exits)
+	data, err := session.ReadFile(exitsFile(exchange))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return out, nil
+		}
+		return nil, errors.Wrap(err, 1)
+	}
+	if err = json.Unmarshal(data, &out); err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+	return out, nil
+}
+
+// lockExits locks the exits of an exchange. the sell loop and the HTTP handlers (on their own goroutines) both
+// read-modify-write them, so we lock a file in the session dir while we are at it.
+func lockExits(exchange model.Exchange) (*session.Mutex, error) {
+	mutex, err := session.NewFileMutex(strings.ToLower(exchange.GetInfo().Code) + ".exits.lock")
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+	if err = mutex.Lock(); err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+	return mutex, nil
+}
+
+// updateExits reads the exits, hands them to callback, then writes them back
+func updateExits(exchange model.Exchange, callback func(exits Exits) error) error {
+	mutex, err := lockExits(exchange)
+	if err != nil {
+		return err
+	}
+	defer mutex.Unlock()
+
+	exits, err := ReadExits(exchange)
+	if err != nil {
+		return err
+	}
+	if err = callback(exits); err != nil {
+		return err
+	}
+	return exits.write(exchange)
+}
+
+func (exits Exits) write(exchange model.Exchange) error {
+	for id, exit := range exits {
+		if time.Since(exit.OpenedAt) > exitsRetention {
+			delete(exits, id)
+		}
+	}
+	data, err := json.Marshal(exits)
+	if err != nil {
+		return errors.Wrap(err, 1)
+	}
+	return session.WriteFile(exitsFile(exchange), data)
+}
+
+// BySellId returns the exit that has been opened with this sell order, or nil if we don't know about it.
+func (exits Exits) BySellId(id string) *Exit {
+	if id == "" {
+		return nil
+	}
+	for _, exit := range exits {
+		if exit.SellId == id {
+			return &exit
+		}
+	}
+	return nil
+}
+
+// IsClosed returns true if the exit that has been opened with this sell order has been filled
+func (exits Exits) IsClosed(sellId string) bool {
+	exit := exits.BySellId(sellId)
+	return exit != nil && exit.ClosedAt != nil
+}
+
+// hasExit returns true if we have opened an exit for this buy order before
+func hasExit(exchange model.Exchange, buyId string) bool {
+	exits, err := ReadExits(exchange)
+	if err != nil {
+		log.Printf("[ERROR] %v", err)
+		return false
+	}
+	_, ok := exits[buyId]
+	return ok
+}
+
+// recordExit remembers the sell (or OCO) order that we have opened for a filled buy order
+func recordExit(exchange model.Exchange, exit Exit) {
+	if err := updateExits(exchange, func(exits Exits) error {
+		exits.record(exit)
+		return nil
+	}); err != nil {
+		log.Printf("[ERROR] %v", err)
+	}
+}
+
+func (exits Exits) record(exit Exit) {
+	if exit.OpenedAt.IsZero() {
+		exit.OpenedAt = time.Now()
+	}
+	if exit.Run == "" {
+		exit.Run = session.RunTag()
+	}
+	exits[exit.BuyId] = exit
+}
+
+// moveExit points the exit that has been opened with this sell order at another order, for example because we have
+// re-opened it, or because its stop-loss has created a new order. a price of zero keeps the take profit.
+func moveExit(exchange model.Exchange, sellId, newId string, price float64) {
+	if err := updateExits(exchange, func(exits Exits) error {
+		exit := exits.BySellId(sellId)
+		if exit == nil {
+			return nil
+		}
+		exit.SellId = newId
+		if price > 0 {
+			exit.Price = price
+		}
+		exits[exit.BuyId] = *exit
+		return nil
+	}); err != nil {
+		log.Printf("[ERROR] %v", err)
+	}
+}
+
+// closeExit marks the exit that has been opened with this sell order as closed, and returns it. returns nil if
+// we don't know about this sell order.
+func closeExit(exchange model.Exchange, sellId string, sold float64) *Exit {
+	var out *Exit
+	if err := updateExits(exchange, func(exits Exits) error {
+		if out = exits.BySellId(sellId); out == nil || out.ClosedAt != nil {
+			return nil
+		}
+		closedAt := time.Now()
+		out.ClosedAt = &closedAt
+		out.Sold = sold
+		out.Profit = (sold - out.Bought) * out.Size
+		exits[out.BuyId] = *out
+		return nil
+	}); err != nil {
+		log.Printf("[ERROR] %v", err)
+	}
+	return out
+}
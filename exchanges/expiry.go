@@ -0,0 +1,71 @@
+package exchanges
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/svanas/nefertiti/humanize"
+	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/notify"
+)
+
+// the markets we have opened buy orders on, and that we watch for --expire=X
+var (
+	expiringMutex sync.Mutex
+	expiring      = make(map[string]bool)
+)
+
+// ExpireLater adds the market to the markets that WatchExpiry watches
+func ExpireLater(market string) {
+	expiringMutex.Lock()
+	defer expiringMutex.Unlock()
+	expiring[market] = true
+}
+
+func expiringMarkets() []string {
+	expiringMutex.Lock()
+	defer expiringMutex.Unlock()
+	var out []string
+	for market := range expiring {
+		out = append(out, market)
+	}
+	return out
+}
+
+// ExpiresNatively returns true if the exchange cancels our buy orders after --expire=X hours by itself
+func ExpiresNatively(exchange model.Exchange) bool {
+	switch exchange.(type) {
+	case *Kucoin:
+		return true
+	}
+	return false
+}
+
+// WatchExpiry emulates good-til-date orders on the exchanges that do not support them: every minute, we cancel the
+// buy orders that are older than expire. blocks forever.
+func WatchExpiry(exchange model.Exchange, client interface{}, expire time.Duration, service model.Notify) {
+	filter := &model.CancelFilter{
+		Side:      model.BUY,
+		OlderThan: expire,
+	}
+	for range time.Tick(time.Minute) {
+		for _, market := range expiringMarkets() {
+			// in observer mode, we tell you what we would have cancelled
+			cancelled, err := model.CancelOrders(exchange, client, market, filter, observing())
+			if err != nil {
+				log.Printf("[ERROR] %v", err)
+				if service != nil {
+					notify.SendError(service, err, err.Error(), (exchange.GetInfo().Name + " - ERROR"))
+				}
+				continue
+			}
+			for _, order := range cancelled {
+				if observeCancelOrder(exchange, market, order.ID) {
+					continue
+				}
+				log.Printf("[INFO] Cancelled buy order %s on %s because it is older than %s\n", order.ID, market, humanize.Duration(expire))
+			}
+		}
+	}
+}
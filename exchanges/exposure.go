@@ -0,0 +1,193 @@
+package exchanges
+
+import (
+	"math"
+	"strings"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/model"
+)
+
+// getOpenBuys returns the amount of quote currency in our open buy orders on a market
+func getOpenBuys(exchange model.Exchange, client interface{}, market string) (float64, error) {
+	opened, err := exchange.GetOpened(client, market)
+	if err != nil {
+		return 0, err
+	}
+	var out float64
+	for _, order := range opened {
+		if order.Side == model.BUY {
+			out += order.Size * order.Price
+		}
+	}
+	return out, nil
+}
+
+// getPosition returns how much of the base asset we hold: the size of our position on a futures exchange, or else
+// our balance (including what is on hold in our open orders), or else the size of our open sell orders.
+func getPosition(exchange model.Exchange, client interface{}, markets []model.Market, market string) (float64, error) {
+	if futures, ok := exchange.(model.Futures); ok {
+		position, err := futures.GetPosition(client, market)
+		if err != nil || position == nil {
+			return 0, err
+		}
+		return math.Abs(position.Size), nil
+	}
+
+	if reader, ok := exchange.(model.HoldingReader); ok {
+		base, err := model.GetBaseCurr(markets, market)
+		if err != nil {
+			return 0, err
+		}
+		holdings, err := reader.GetHoldings(client)
+		if err != nil {
+			return 0, err
+		}
+		return model.GetHolding(holdings, base).Total(), nil
+	}
+
+	opened, err := exchange.GetOpened(client, market)
+	if err != nil {
+		return 0, err
+	}
+	var out float64
+	for _, order := range opened {
+		if order.Side == model.SELL {
+			out += order.Size
+		}
+	}
+	return out, nil
+}
+
+// getExposure returns the amount of quote currency we have at stake in a market: our open buy orders, plus our
+// position valued at the current price. if excludeBuys is true, then we omit our open buy orders, because we are
+// about to cancel and replace them.
+func getExposure(exchange model.Exchange, client interface{}, markets []model.Market, market string, excludeBuys bool) (float64, error) {
+	var out float64
+
+	if !excludeBuys {
+		buys, err := getOpenBuys(exchange, client, market)
+		if err != nil {
+			return 0, err
+		}
+		out += buys
+	}
+
+	position, err := getPosition(exchange, client, markets, market)
+	if err != nil {
+		return 0, err
+	}
+	if position > 0 {
+		ticker, err := exchange.GetTicker(client, market)
+		if err != nil {
+			return 0, err
+		}
+		out += position * ticker
+	}
+
+	return out, nil
+}
+
+// getTotalExposure returns the amount of quote currency we have at stake across all the markets that trade against
+// quote: the quote currency on hold in our open buy orders, plus every asset we hold that trades against quote, valued
+// at the current price.
+func getTotalExposure(exchange model.Exchange, client interface{}, reader model.HoldingReader, markets []model.Market, quote string) (float64, error) {
+	holdings, err := reader.GetHoldings(client)
+	if err != nil {
+		return 0, err
+	}
+
+	var out float64
+	for asset, holding := range holdings {
+		if strings.EqualFold(asset, quote) {
+			out += holding.Hold
+			continue
+		}
+		if holding.Total() <= 0 {
+			continue
+		}
+		if market := model.FindMarket(markets, model.NewMarketSymbol(asset, quote)); market != nil {
+			ticker, err := exchange.GetTicker(client, market.Name)
+			if err != nil {
+				return 0, err
+			}
+			out += holding.Total() * ticker
+		}
+	}
+
+	return out, nil
+}
+
+// ValidateExposure returns an error if you asked for --max-exposure on an exchange that cannot tell us what is on hold
+// in your wallet (at the time of this writing, only GDAX and KuCoin can).
+func ValidateExposure(exchange model.Exchange) error {
+	maxTotal, _, err := flag.MaxExposure()
+	if err != nil || maxTotal == 0 {
+		return err
+	}
+	if _, ok := exchange.(model.HoldingReader); !ok {
+		return errors.Errorf("%s does not support --max-exposure", exchange.GetInfo().Name)
+	}
+	return nil
+}
+
+// CheckExposure refuses new buy orders (worth amount in quote currency) that would take us beyond
+// --max-exposure (across all markets with the same quote currency) or --max-market-exposure (per market)
+func CheckExposure(exchange model.Exchange, client interface{}, market string, amount float64, cancel, sandbox bool) error {
+	maxTotal, maxMarket, err := flag.MaxExposure()
+	if err != nil {
+		return err
+	}
+
+	if maxTotal == 0 && maxMarket == 0 {
+		return nil
+	}
+
+	markets, err := exchange.GetMarkets(true, sandbox, nil)
+	if err != nil {
+		return err
+	}
+
+	quote, err := model.GetQuoteCurr(markets, market)
+	if err != nil {
+		return err
+	}
+
+	if maxMarket > 0 {
+		current, err := getExposure(exchange, client, markets, market, cancel)
+		if err != nil {
+			return err
+		}
+		if (current + amount) > maxMarket {
+			return errors.Errorf("Cannot buy %s. Your exposure would be %.8f %s, and that exceeds your max market exposure of %.8f %s.",
+				market, (current + amount), quote, maxMarket, quote)
+		}
+	}
+
+	if maxTotal > 0 {
+		reader, ok := exchange.(model.HoldingReader)
+		if !ok {
+			return errors.Errorf("%s does not support --max-exposure", exchange.GetInfo().Name)
+		}
+		total, err := getTotalExposure(exchange, client, reader, markets, quote)
+		if err != nil {
+			return err
+		}
+		// the buy orders we are about to cancel (and replace) are on hold, too
+		if cancel {
+			buys, err := getOpenBuys(exchange, client, market)
+			if err != nil {
+				return err
+			}
+			total -= buys
+		}
+		total += amount
+		if total > maxTotal {
+			return errors.Errorf("Cannot buy %s. Your exposure would be %.8f %s, and that exceeds your max exposure of %.8f %s.",
+				market, total, quote, maxTotal, quote)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,274 @@
+package exchanges
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/multiplier"
+	"github.com/svanas/nefertiti/override"
+	"github.com/svanas/nefertiti/precision"
+	"github.com/svanas/nefertiti/pricing"
+	"github.com/svanas/nefertiti/session"
+)
+
+// an external fill is a fill that happened outside of the bot, for example an order that you've placed with another
+// tool. the POST /fill webhook tells us about it, and from then on we manage the position as if we had bought it:
+// a buy adds to the position and gets a take profit at --mult, a sell takes from the position and books the PnL.
+
+type ExternalFill struct {
+	Id     string // optional, so that we handle the same fill only once
+	Market string
+	Side   model.OrderSide
+	Size   float64
+	Price  float64
+}
+
+// externalId prefixes the ID of the external fill, so that it cannot clash with the order IDs of the exchange
+func externalId(id string) string {
+	if id == "" {
+		return ""
+	}
+	return "external:" + id
+}
+
+// Manage takes over the external fill. returns the exit that we've opened for a buy, or nil for a sell.
+func Manage(fill ExternalFill) (*Exit, error) {
+	if fill.Market == "" {
+		return nil, errors.New("missing argument: market")
+	}
+	if fill.Side != model.BUY && fill.Side != model.SELL {
+		return nil, errors.New("side is invalid. valid values are buy or sell")
+	}
+	if fill.Size <= 0 || fill.Price <= 0 {
+		return nil, errors.New("size and price must be greater than zero")
+	}
+
+	exchange, err := GetExchange()
+	if err != nil {
+		return nil, err
+	}
+
+	markets, err := exchange.GetMarkets(true, flag.Sandbox(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if !model.HasMarket(markets, fill.Market) {
+		return nil, errors.Errorf("market %s does not exist", fill.Market)
+	}
+
+	client, err := exchange.GetClient(model.PRIVATE, flag.Sandbox())
+	if err != nil {
+		return nil, err
+	}
+
+	// a webhook retry runs alongside the original request (and alongside the sell loop), so we hold on to the exits
+	// (and the positions) until we are done with them
+	mutex, err := lockExits(exchange)
+	if err != nil {
+		return nil, err
+	}
+	defer mutex.Unlock()
+
+	exits, err := ReadExits(exchange)
+	if err != nil {
+		return nil, err
+	}
+
+	// record the fill first, so that we handle it only once
+	id := externalId(fill.Id)
+	if id != "" {
+		if _, ok := exits[id]; ok {
+			return nil, errors.Errorf("fill %s has been handled before", fill.Id)
+		}
+		exits.record(Exit{
+			Market: fill.Market,
+			BuyId:  id,
+			Bought: fill.Price,
+			Size:   fill.Size,
+		})
+		if err = exits.write(exchange); err != nil {
+			return nil, err
+		}
+	}
+
+	positions, err := ReadPositions(exchange)
+	if err != nil {
+		return nil, err
+	}
+	pos := positions[fill.Market]
+
+	session.Audit("webhook", "fill", "", fmt.Sprintf("%s %v %s at %v", model.OrderSideString[fill.Side], fill.Size, fill.Market, fill.Price))
+
+	if fill.Side == model.SELL {
+		var profit float64
+		if pos.Size > 0 && pos.Cost > 0 {
+			size := fill.Size
+			if size > pos.Size {
+				size = pos.Size
+			}
+			profit = (fill.Price - pos.Cost) * size
+			recordProfit(exchange, client, fill.Market, profit)
+		}
+		pos.Size -= fill.Size
+		if pos.Size <= 0 {
+			positions = positions.Without(fill.Market)
+		} else {
+			positions[fill.Market] = pos
+		}
+		// the sell took (some of) the size that our take profits are selling
+		if err = exits.reduce(exchange, client, fill.Market, fill.Size); err != nil {
+			log.Printf("[ERROR] %v", err)
+		}
+		if id != "" {
+			// remember the sell, so that we book its PnL only once
+			closedAt := time.Now()
+			exits.record(Exit{
+				Market:   fill.Market,
+				BuyId:    id,
+				Bought:   pos.Cost,
+				Size:     fill.Size,
+				ClosedAt: &closedAt,
+				Sold:     fill.Price,
+				Profit:   profit,
+			})
+		}
+		if err = exits.write(exchange); err != nil {
+			return nil, err
+		}
+		return nil, positions.Write(exchange)
+	}
+
+	// forget about the fill if we fail to open its take profit, so that a retry can try again
+	exit, err := openExternal(exchange, client, fill)
+	if err != nil {
+		if id != "" {
+			delete(exits, id)
+			if err := exits.write(exchange); err != nil {
+				log.Printf("[ERROR] %v", err)
+			}
+		}
+		return nil, err
+	}
+	if id != "" {
+		exit.BuyId = id
+	}
+	if exit.BuyId != "" {
+		exits.record(*exit)
+		if err = exits.write(exchange); err != nil {
+			return nil, err
+		}
+	}
+
+	// a buy adds to the position at a weighted average cost
+	if pos.Size == 0 {
+		pos.OpenedAt = time.Now()
+	}
+	pos.Cost = ((pos.Size * pos.Cost) + (fill.Size * fill.Price)) / (pos.Size + fill.Size)
+	pos.Size += fill.Size
+	positions[fill.Market] = pos
+	if err = positions.Write(exchange); err != nil {
+		return nil, err
+	}
+
+	return exit, nil
+}
+
+// openExternal opens the take profit for an external buy, the same way we would have done for our own buy order
+func openExternal(exchange model.Exchange, client interface{}, fill ExternalFill) (*Exit, error) {
+	mult, err := multiplier.Get(multiplier.FIVE_PERCENT)
+	if err != nil {
+		return nil, err
+	}
+	mult = override.Mult(fill.Market, mult)
+
+	prec, err := exchange.GetSizePrec(client, fill.Market)
+	if err != nil {
+		return nil, err
+	}
+	size := precision.Floor(fill.Size, prec)
+	if size == 0 {
+		return nil, errors.Errorf("size %v is below the precision of %s", fill.Size, fill.Market)
+	}
+
+	tick, err := model.GetTickSize(exchange, client, fill.Market)
+	if err != nil {
+		return nil, err
+	}
+	price := pricing.MultiplyTick(fill.Price, mult, tick)
+
+	log.Printf("[INFO] Selling %v %s at %v (external fill)\n", size, fill.Market, price)
+	oid, _, err := exchange.Order(client, model.SELL, fill.Market, size, price, model.LIMIT, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Exit{
+		Market: fill.Market,
+		BuyId:  externalId(string(oid)),
+		Bought: fill.Price,
+		Size:   size,
+		SellId: string(oid),
+		Price:  price,
+	}, nil
+}
+
+// reduce takes size off the open exits in a market (the youngest first), because somebody sold it outside of our
+// take profits. we cancel the take profit of every exit that we reduce, and re-open it with what is left.
+func (exits Exits) reduce(exchange model.Exchange, client interface{}, market string, size float64) error {
+	var open []Exit
+	for _, exit := range exits {
+		if exit.Market == market && exit.ClosedAt == nil && exit.SellId != "" {
+			open = append(open, exit)
+		}
+	}
+	sort.Slice(open, func(i, j int) bool {
+		return open[i].OpenedAt.After(open[j].OpenedAt)
+	})
+
+	prec, err := exchange.GetSizePrec(client, market)
+	if err != nil {
+		return err
+	}
+
+	for _, exit := range open {
+		if size <= 0 {
+			break
+		}
+		if !observeCancelOrder(exchange, market, exit.SellId) {
+			if err = exchange.CancelOrder(client, market, exit.SellId); err != nil {
+				return err
+			}
+		}
+		taken := exit.Size
+		if taken > size {
+			taken = size
+		}
+		size -= taken
+		exit.Size = precision.Floor(exit.Size-taken, prec)
+		if exit.Size <= 0 {
+			// the external sell has booked the PnL of this exit already
+			closedAt := time.Now()
+			exit.ClosedAt = &closedAt
+			exits[exit.BuyId] = exit
+			continue
+		}
+		var oid []byte
+		if exit.Stop > 0 {
+			oid, err = exchange.OCO(client, market, exit.Size, exit.Price, exit.Stop, "")
+		} else {
+			oid, _, err = exchange.Order(client, model.SELL, market, exit.Size, exit.Price, model.LIMIT, "")
+		}
+		if err != nil {
+			return err
+		}
+		exit.SellId = string(oid)
+		exits[exit.BuyId] = exit
+	}
+
+	return nil
+}
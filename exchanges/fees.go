@@ -0,0 +1,12 @@
+package exchanges
+
+import (
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/model"
+)
+
+// getFees is GetFees for the exchanges that do not tell us about your fee tier. we would rather not net our profits
+// of a fee we made up.
+func getFees(exchange model.Exchange) (*model.Fees, error) {
+	return nil, errors.Errorf("%s does not tell us about your fee tier", exchange.GetInfo().Name)
+}
@@ -13,23 +13,26 @@ import (
 	"strings"
 	"time"
 
-	filemutex "github.com/alexflint/go-filemutex"
 	ws "github.com/gorilla/websocket"
 	exchange "github.com/svanas/go-coinbasepro"
 	"github.com/svanas/nefertiti/aggregation"
+	"github.com/svanas/nefertiti/clock"
 	"github.com/svanas/nefertiti/errors"
 	"github.com/svanas/nefertiti/flag"
 	"github.com/svanas/nefertiti/gdax"
+	"github.com/svanas/nefertiti/humanize"
+	"github.com/svanas/nefertiti/i18n"
 	"github.com/svanas/nefertiti/model"
 	"github.com/svanas/nefertiti/multiplier"
 	"github.com/svanas/nefertiti/notify"
+	"github.com/svanas/nefertiti/override"
 	"github.com/svanas/nefertiti/precision"
 	"github.com/svanas/nefertiti/pricing"
 	"github.com/svanas/nefertiti/session"
 )
 
 var (
-	gdaxMutex *filemutex.FileMutex
+	gdaxMutex *session.Mutex
 )
 
 const (
@@ -42,7 +45,7 @@ func init() {
 		var err error
 
 		if gdaxMutex == nil {
-			if gdaxMutex, err = filemutex.New(session.GetSessionFile(gdaxSessionLock)); err != nil {
+			if gdaxMutex, err = session.NewMutex(gdaxSessionLock); err != nil {
 				return err
 			}
 		}
@@ -108,7 +111,7 @@ func (self *Gdax) error(err error, level int64, service model.Notify) {
 	log.Printf("[ERROR] %s %s", errors.FormatCaller(pc, file, line), strings.Replace(str, "\n\n", " ", -1))
 	if service != nil {
 		if notify.CanSend(level, notify.ERROR) {
-			service.SendMessage(str, "Coinbase Pro - ERROR", model.ONCE_PER_MINUTE)
+			notify.SendError(service, err, str, "Coinbase Pro - ERROR")
 		}
 	}
 }
@@ -141,12 +144,19 @@ func (self *Gdax) getMinOrderSize(client *gdax.Client, market string) (float64,
 	}
 }
 
+func (self *Gdax) baseURL(sandbox bool) string {
+	if sandbox {
+		return self.ExchangeInfo.REST.Sandbox
+	}
+	return self.ExchangeInfo.REST.URI
+}
+
 func (self *Gdax) GetInfo() *model.ExchangeInfo {
 	return self.ExchangeInfo
 }
 
 func (self *Gdax) getClient(apiKey, apiSecret, apiPassphrase string, sandbox bool) *gdax.Client {
-	client := gdax.New(sandbox)
+	client := gdax.New(self.baseURL(sandbox))
 
 	client.UpdateConfig(&exchange.ClientConfig{
 		Key:        apiKey,
@@ -159,7 +169,7 @@ func (self *Gdax) getClient(apiKey, apiSecret, apiPassphrase string, sandbox boo
 
 func (self *Gdax) GetClient(permission model.Permission, sandbox bool) (interface{}, error) {
 	if permission != model.PRIVATE {
-		return gdax.New(sandbox), nil
+		return gdax.New(self.baseURL(sandbox)), nil
 	}
 
 	var (
@@ -189,10 +199,56 @@ func (self *Gdax) getProducts(client interface{}, cached bool) ([]exchange.Produ
 	return self.products, nil
 }
 
+func (self *Gdax) GetBalances(client interface{}) (map[string]float64, error) {
+	gdaxClient, ok := client.(*gdax.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+	accounts, err := gdaxClient.GetAccounts()
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+	out := make(map[string]float64)
+	for _, account := range accounts {
+		var available float64
+		if available, err = strconv.ParseFloat(account.Available, 64); err != nil {
+			return nil, errors.Wrap(err, 1)
+		}
+		out[account.Currency] += available
+	}
+	return out, nil
+}
+
+func (self *Gdax) GetHoldings(client interface{}) (map[string]model.Holding, error) {
+	gdaxClient, ok := client.(*gdax.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+	accounts, err := gdaxClient.GetAccounts()
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+	out := make(map[string]model.Holding)
+	for _, account := range accounts {
+		var holding model.Holding
+		if holding.Available, err = strconv.ParseFloat(account.Available, 64); err != nil {
+			return nil, errors.Wrap(err, 1)
+		}
+		if holding.Hold, err = strconv.ParseFloat(account.Hold, 64); err != nil {
+			return nil, errors.Wrap(err, 1)
+		}
+		entry := out[account.Currency]
+		entry.Available += holding.Available
+		entry.Hold += holding.Hold
+		out[account.Currency] = entry
+	}
+	return out, nil
+}
+
 func (self *Gdax) GetMarkets(cached, sandbox bool, blacklist []string) ([]model.Market, error) {
 	var out []model.Market
 
-	products, err := self.getProducts(gdax.New(sandbox), cached)
+	products, err := self.getProducts(gdax.New(self.baseURL(sandbox)), cached)
 
 	if err != nil {
 		return nil, err
@@ -218,8 +274,11 @@ func (self *Gdax) GetMarkets(cached, sandbox bool, blacklist []string) ([]model.
 	return out, nil
 }
 
+// how Gdax spells its markets
+var gdaxSymbol = model.SymbolFormat{Separator: "-"}
+
 func (self *Gdax) FormatMarket(base, quote string) string {
-	return fmt.Sprintf("%s-%s", base, quote)
+	return model.NewMarketSymbol(base, quote).Format(gdaxSymbol)
 }
 
 func (self *Gdax) sell(
@@ -320,6 +379,7 @@ func (self *Gdax) sell(
 				}
 			}
 		} else {
+			beat(self.GetInfo().Name, nil)
 			msg := gdax.Message{}
 			if err = json.Unmarshal(data, &msg); err != nil {
 				self.error(errors.Errorf("%s. Message: %s", err.Error(), string(data)), level, service)
@@ -387,8 +447,8 @@ func (self *Gdax) sell(
 									}
 								}
 
-								var prec int
-								if prec, err = self.GetPricePrec(client, msg.ProductID); err != nil {
+								var tick float64
+								if tick, err = model.GetTickSize(self, client, msg.ProductID); err != nil {
 									self.error(err, level, service)
 								}
 
@@ -398,6 +458,8 @@ func (self *Gdax) sell(
 								}
 
 								// by default, we will sell at a 5% profit
+								size := self.GetMaxSize(client, base, quote, hold.HasMarket(msg.ProductID), earn.HasMarket(msg.ProductID), qty, override.Mult(msg.ProductID, mult))
+								limit := pricing.MultiplyTick(price, override.Mult(msg.ProductID, mult), tick)
 
 								order := (&gdax.Order{
 									Order: &exchange.Order{
@@ -406,8 +468,8 @@ func (self *Gdax) sell(
 										ProductID: msg.ProductID,
 									},
 								}).
-									SetSize(self.GetMaxSize(client, base, quote, hold.HasMarket(msg.ProductID), earn.HasMarket(msg.ProductID), qty, mult)).
-									SetPrice(pricing.Multiply(price, mult, prec))
+									SetSize(size).
+									SetPrice(limit)
 
 								// log the newly created SELL order
 								var raw []byte
@@ -415,13 +477,15 @@ func (self *Gdax) sell(
 									log.Println("[INFO] " + string(raw))
 									if service != nil {
 										if notify.CanSend(level, notify.INFO) {
-											service.SendMessage(order, "Coinbase Pro - New Sell", model.ALWAYS)
+											service.SendMessage(order, "Coinbase Pro - "+i18n.T("New Sell"), model.ALWAYS)
 										}
 									}
 								}
 
-								if _, err = client.CreateOrder(order); err != nil {
-									self.error(errors.Wrap(err, 1), level, service)
+								if _, ok := observeOrder(self, client, model.SELL, msg.ProductID, size, limit, model.LIMIT); !ok {
+									if _, err = client.CreateOrder(order); err != nil {
+										self.error(errors.Wrap(err, 1), level, service)
+									}
 								}
 							}
 						}
@@ -487,8 +551,8 @@ func (self *Gdax) sell(
 											// continue
 										} else {
 											msg := fmt.Sprintf(
-												"Re-buying %s because your latest activity on this market (at %s) is older than %d days.",
-												product.ID, youngest.Format(time.RFC1123), rebuyAfterDays,
+												"Re-buying %s because your latest activity on this market (at %s, %s) is older than %d days.",
+												product.ID, youngest.Format(time.RFC1123), humanize.Age(youngest), rebuyAfterDays,
 											)
 
 											log.Println("[INFO] " + msg)
@@ -520,13 +584,15 @@ func (self *Gdax) sell(
 													log.Println("[INFO] " + string(raw))
 													if service != nil {
 														if notify.CanSend(level, notify.INFO) {
-															service.SendMessage(order, "Coinbase Pro - New Buy", model.ALWAYS)
+															service.SendMessage(order, "Coinbase Pro - "+i18n.T("New Buy"), model.ALWAYS)
 														}
 													}
 												}
 
-												if _, err = client.CreateOrder(order); err != nil {
-													self.error(errors.Wrap(err, 1), level, service)
+												if _, ok := observeOrder(self, client, model.BUY, product.ID, qty, 0, model.MARKET); !ok {
+													if _, err = client.CreateOrder(order); err != nil {
+														self.error(errors.Wrap(err, 1), level, service)
+													}
 												}
 											}
 										}
@@ -600,6 +666,14 @@ func (self *Gdax) Order(
 	kind model.OrderType,
 	metadata string,
 ) (oid []byte, raw []byte, err error) {
+	if raw, ok := observeOrder(self, client, side, market, size, price, kind); ok {
+		return nil, raw, nil
+	}
+
+	if err := CheckEquityShare(self, client, side, market, size, price); err != nil {
+		return nil, nil, err
+	}
+
 	gdaxClient, ok := client.(*gdax.Client)
 	if !ok {
 		return nil, nil, errors.New("invalid argument: client")
@@ -626,7 +700,15 @@ func (self *Gdax) Order(
 	return []byte(saved.ID), out, nil
 }
 
+func (self *Gdax) GetStops() model.Stops {
+	return model.Stops{Stop: true}
+}
+
 func (self *Gdax) StopLoss(client interface{}, market string, size float64, price float64, kind model.OrderType, metadata string) ([]byte, error) {
+	if raw, ok := observeStopLoss(self, client, market, size, price); ok {
+		return raw, nil
+	}
+
 	var err error
 
 	gdaxClient, ok := client.(*gdax.Client)
@@ -644,18 +726,17 @@ func (self *Gdax) StopLoss(client interface{}, market string, size float64, pric
 	}).SetSize(size).SetStopPrice(price)
 
 	if kind == model.LIMIT {
-		var prec int
-		if prec, err = self.GetPricePrec(client, order.ProductID); err != nil {
+		var (
+			tick float64
+			exit *model.StopExit
+		)
+		if tick, err = model.GetTickSize(self, client, order.ProductID); err != nil {
 			return nil, err
 		}
-		limit := price
-		for {
-			limit = limit * 0.99
-			if precision.Round(limit, prec) < price {
-				break
-			}
+		if exit, err = model.GetStopExit(); err != nil {
+			return nil, err
 		}
-		order.SetPrice(precision.Round(limit, prec))
+		order.SetPrice(exit.Limit(price, tick))
 	}
 
 	var saved *gdax.Order
@@ -671,6 +752,43 @@ func (self *Gdax) StopLoss(client interface{}, market string, size float64, pric
 	return out, nil
 }
 
+func (self *Gdax) StopBuy(client interface{}, market string, size, trigger float64, metadata string) ([]byte, []byte, error) {
+	if raw, ok := observeStopBuy(self, client, market, size, trigger); ok {
+		return nil, raw, nil
+	}
+
+	gdaxClient, ok := client.(*gdax.Client)
+	if !ok {
+		return nil, nil, errors.New("invalid argument: client")
+	}
+
+	tick, err := model.GetTickSize(self, client, market)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	order := (&gdax.Order{
+		Order: &exchange.Order{
+			Type:      model.OrderTypeString[model.LIMIT],
+			Side:      model.OrderSideString[model.BUY],
+			ProductID: market,
+			Stop:      "entry",
+		},
+	}).SetSize(size).SetStopPrice(trigger).SetPrice(model.StopBuyLimit(trigger, tick))
+
+	var saved *gdax.Order
+	if saved, err = gdaxClient.CreateOrder(order); err != nil {
+		return nil, nil, errors.Wrap(err, 1)
+	}
+
+	var out []byte
+	if out, err = json.Marshal(saved); err != nil {
+		return nil, nil, errors.Wrap(err, 1)
+	}
+
+	return []byte(saved.ID), out, nil
+}
+
 func (self *Gdax) OCO(client interface{}, market string, size float64, price, stop float64, metadata string) ([]byte, error) {
 	return nil, errors.New("not implemented")
 }
@@ -681,12 +799,21 @@ func (self *Gdax) GetClosed(client interface{}, market string) (model.Orders, er
 		return nil, errors.New("invalid argument: client")
 	}
 
+	// the fee is in quote currency
+	markets, err := self.GetMarkets(true, flag.Sandbox(), nil)
+	if err != nil {
+		return nil, err
+	}
+	quote, err := model.GetQuoteCurr(markets, market)
+	if err != nil {
+		return nil, err
+	}
+
 	cursor := gdaxClient.ListFills(exchange.ListFillsParams{
 		ProductID: market,
 	})
 
 	var (
-		err   error
 		out   model.Orders
 		fills []exchange.Fill
 	)
@@ -696,11 +823,16 @@ func (self *Gdax) GetClosed(client interface{}, market string) (model.Orders, er
 		}
 		for _, fill := range fills {
 			out = append(out, model.Order{
-				Side:      model.NewOrderSide(fill.Side),
-				Market:    fill.ProductID,
-				Size:      gdax.ParseFloat(fill.Size),
-				Price:     gdax.ParseFloat(fill.Price),
-				CreatedAt: fill.CreatedAt.Time(),
+				Side:       model.NewOrderSide(fill.Side),
+				Status:     model.FILLED,
+				Market:     fill.ProductID,
+				Size:       gdax.ParseFloat(fill.Size),
+				Price:      gdax.ParseFloat(fill.Price),
+				CreatedAt:  fill.CreatedAt.Time(),
+				ClosedAt:   model.TimePtr(fill.CreatedAt.Time()),
+				FilledSize: gdax.ParseFloat(fill.Size),
+				Fee:        gdax.ParseFloat(fill.Fee),
+				FeeAsset:   quote,
 			})
 		}
 	}
@@ -730,7 +862,9 @@ func (self *Gdax) GetOpened(client interface{}, market string) (model.Orders, er
 		for _, order := range orders {
 			if order.ProductID == market {
 				out = append(out, model.Order{
+					Status:    model.OPEN,
 					Side:      model.NewOrderSide(order.Side),
+					ID:        order.ID,
 					Market:    order.ProductID,
 					Size:      order.GetSize(),
 					Price:     order.GetPrice(),
@@ -815,6 +949,10 @@ func (self *Gdax) GetTicker(client interface{}, market string) (float64, error)
 	return gdax.ParseFloat(ticker.Price), nil
 }
 
+func (self *Gdax) GetTickers(client interface{}) (map[string]float64, error) {
+	return getTickers(self, client)
+}
+
 func (self *Gdax) Get24h(client interface{}, market string) (*model.Stats, error) {
 	gdaxClient, ok := client.(*gdax.Client)
 	if !ok {
@@ -833,6 +971,9 @@ func (self *Gdax) Get24h(client interface{}, market string) (*model.Stats, error
 		Market: market,
 		High:   gdax.ParseFloat(gdaxStats.High),
 		Low:    gdax.ParseFloat(gdaxStats.Low),
+		Open:   gdax.ParseFloat(gdaxStats.Open),
+		Last:   gdax.ParseFloat(gdaxStats.Last),
+		Change: model.PercentChange(gdax.ParseFloat(gdaxStats.Open), gdax.ParseFloat(gdaxStats.Last)),
 		BtcVolume: func(stats1 *exchange.Stats) float64 {
 			products, err := self.getProducts(gdaxClient, true)
 			if err == nil {
@@ -853,6 +994,64 @@ func (self *Gdax) Get24h(client interface{}, market string) (*model.Stats, error
 	}, nil
 }
 
+func (self *Gdax) Get24hAll(client interface{}) (map[string]model.Stats, error) {
+	return get24hAll(self, client)
+}
+
+// GDAX supports these candle intervals (aka granularity)
+var gdaxIntervals = map[time.Duration]bool{
+	time.Minute:      true,
+	5 * time.Minute:  true,
+	15 * time.Minute: true,
+	time.Hour:        true,
+	6 * time.Hour:    true,
+	24 * time.Hour:   true,
+}
+
+func (self *Gdax) GetCandles(client interface{}, market string, interval time.Duration, start, end time.Time) (model.Candles, error) {
+	gdaxClient, ok := client.(*gdax.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	if !gdaxIntervals[interval] {
+		return nil, errors.Errorf("interval %s is not supported", model.FormatInterval(interval))
+	}
+
+	// GDAX returns (at most) 300 candles per request
+	if max := start.Add(300 * interval); end.After(max) {
+		end = max
+	}
+
+	rates, err := gdaxClient.GetHistoricRates(market, exchange.GetHistoricRatesParams{
+		Start:       start,
+		End:         end,
+		Granularity: int(interval.Seconds()),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	// GDAX returns the newest candle first
+	var out model.Candles
+	for i := len(rates) - 1; i >= 0; i-- {
+		rate := rates[i]
+		if rate.Time.Before(start) || !rate.Time.Before(end) {
+			continue
+		}
+		out = append(out, model.Candle{
+			Time:   rate.Time.UTC(),
+			Open:   rate.Open,
+			High:   rate.High,
+			Low:    rate.Low,
+			Close:  rate.Close,
+			Volume: rate.Volume,
+		})
+	}
+
+	return out, nil
+}
+
 func (self *Gdax) GetPricePrec(client interface{}, market string) (int, error) {
 	products, err := self.getProducts(client, true)
 	if err != nil {
@@ -906,6 +1105,10 @@ func (self *Gdax) GetMaxSize(client interface{}, base, quote string, hold, earn
 }
 
 func (self *Gdax) Cancel(client interface{}, market string, side model.OrderSide) error {
+	if observeCancel(self, market, side) {
+		return nil
+	}
+
 	gdaxClient, ok := client.(*gdax.Client)
 	if !ok {
 		return errors.New("invalid argument: client")
@@ -937,7 +1140,79 @@ func (self *Gdax) Cancel(client interface{}, market string, side model.OrderSide
 	return nil
 }
 
+func (self *Gdax) CancelOrder(client interface{}, market, id string) error {
+	if observeCancelOrder(self, market, id) {
+		return nil
+	}
+
+	gdaxClient, ok := client.(*gdax.Client)
+	if !ok {
+		return errors.New("invalid argument: client")
+	}
+
+	if err := gdaxClient.CancelOrder(id); err != nil {
+		return errors.Wrap(err, 1)
+	}
+
+	return nil
+}
+
+func (self *Gdax) GetOrder(client interface{}, market, id string) (*model.Order, error) {
+	gdaxClient, ok := client.(*gdax.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	order, err := gdaxClient.GetOrder(id)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	out := &model.Order{
+		ID:         id,
+		Side:       model.NewOrderSide(order.Side),
+		Status:     model.OPEN,
+		Market:     order.ProductID,
+		Size:       order.GetSize(),
+		Price:      order.GetPrice(),
+		CreatedAt:  order.CreatedAt.Time(),
+		FilledSize: gdax.ParseFloat(order.FilledSize),
+		Fee:        gdax.ParseFloat(order.FillFees),
+	}
+	if order.Status == "done" {
+		out.ClosedAt = model.TimePtr(order.DoneAt.Time())
+		if order.DoneReason == "filled" {
+			out.Status = model.FILLED
+		} else {
+			out.Status = model.CANCELLED
+		}
+	}
+
+	return out, nil
+}
+
+func (self *Gdax) GetFees(client interface{}, market string) (*model.Fees, error) {
+	gdaxClient, ok := client.(*gdax.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	fees, err := gdaxClient.GetFees()
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	return &model.Fees{
+		Maker: fees.MakerFeeRate,
+		Taker: fees.TakerFeeRate,
+	}, nil
+}
+
 func (self *Gdax) Buy(client interface{}, cancel bool, market string, calls model.Calls, deviation float64, kind model.OrderType) error {
+	if observeBuy(self, client, cancel, market, calls, kind) {
+		return nil
+	}
+
 	var err error
 
 	gdaxClient, ok := client.(*gdax.Client)
@@ -959,8 +1234,8 @@ func (self *Gdax) Buy(client interface{}, cancel bool, market string, calls mode
 				if order.ProductID == market {
 					if order.Side == model.OrderSideString[model.BUY] {
 						// do not cancel orders that we're about to re-place
-						index := calls.IndexByPrice(order.GetPrice())
-						if index > -1 && order.GetSize() == calls[index].Size {
+						index := calls.IndexByPriceSize(order.GetPrice(), order.GetSize())
+						if index > -1 {
 							calls[index].Skip = true
 						} else {
 							if err = gdaxClient.CancelOrder(order.ID); err != nil {
@@ -1028,6 +1303,71 @@ func newGdax() model.Exchange {
 	}
 }
 
+type gdaxMatch struct {
+	Type      string `json:"type"`
+	ProductID string `json:"product_id"`
+	Side      string `json:"side"` // the maker side
+	Size      string `json:"size"`
+	Price     string `json:"price"`
+	Time      string `json:"time"`
+}
+
+// WatchTrades subscribes to the public "matches" channel
+func (self *Gdax) WatchTrades(markets []string, sandbox bool, onTrade func(trade *model.Trade)) error {
+	URI := self.ExchangeInfo.WebSocket.URI
+	if sandbox {
+		URI = self.ExchangeInfo.WebSocket.Sandbox
+	}
+
+	conn, _, err := ws.DefaultDialer.Dial(URI, nil)
+	if err != nil {
+		return errors.Wrap(err, 1)
+	}
+	defer conn.Close()
+
+	if err = conn.WriteJSON(&gdaxSubscribePublic{
+		Type:       "subscribe",
+		ProductIDs: markets,
+		Channels:   []string{"matches"},
+	}); err != nil {
+		return errors.Wrap(err, 1)
+	}
+
+	for {
+		var data []byte
+		if _, data, err = conn.ReadMessage(); err != nil {
+			return errors.Wrap(err, 1)
+		}
+		var msg gdaxMatch
+		if err = json.Unmarshal(data, &msg); err != nil {
+			return errors.Errorf("%s. Message: %s", err.Error(), string(data))
+		}
+		if msg.Type != "match" && msg.Type != "last_match" {
+			continue
+		}
+		trade := model.Trade{
+			Market: msg.ProductID,
+			Side: func() model.OrderSide {
+				// the taker is on the other side of the maker
+				if msg.Side == "sell" {
+					return model.BUY
+				}
+				return model.SELL
+			}(),
+		}
+		if trade.Size, err = strconv.ParseFloat(msg.Size, 64); err != nil {
+			continue
+		}
+		if trade.Price, err = strconv.ParseFloat(msg.Price, 64); err != nil {
+			continue
+		}
+		if trade.Time, err = clock.Parse(msg.Time, time.RFC3339Nano); err != nil {
+			trade.Time = time.Now()
+		}
+		onTrade(&trade)
+	}
+}
+
 type gdaxSubscribePublic struct {
 	Type       string   `json:"type"`
 	ProductIDs []string `json:"product_ids"`
@@ -1040,7 +1380,7 @@ func (self *Gdax) newSubscribePublic(sandbox bool) (*gdaxSubscribePublic, error)
 		ProductIDs: []string{},
 		Channels:   []string{"user", "heartbeat"},
 	}
-	products, err := self.getProducts(gdax.New(sandbox), false)
+	products, err := self.getProducts(gdax.New(self.baseURL(sandbox)), false)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,81 @@
+package exchanges
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+type Health struct {
+	Exchange    string     `json:"exchange"`
+	Alive       bool       `json:"alive"`                   // false if we haven't completed an iteration for a while
+	Seconds     int64      `json:"seconds"`                 // seconds since the last successful iteration
+	LastError   string     `json:"last_error,omitempty"`    // the error of the last failed iteration
+	LastErrorAt *time.Time `json:"last_error_at,omitempty"` // the time of the last failed iteration
+	Failures    int        `json:"failures"`                // number of consecutive failed iterations
+	BreakerOpen bool       `json:"breaker_open"`            // true if we have failed so many times in a row that we need a human
+}
+
+const (
+	healthStale      = 10 * time.Minute // after this long without a successful iteration, we are no longer alive
+	healthBreakerMax = 5                // after this many failed iterations in a row, the circuit breaker opens
+)
+
+type heartbeat struct {
+	success     time.Time
+	lastError   error
+	lastErrorAt time.Time
+	failures    int
+}
+
+var (
+	heartbeatMutex sync.Mutex
+	heartbeats     = make(map[string]*heartbeat) // per exchange
+)
+
+// beat records the outcome of one iteration of the sell loop of an exchange.
+func beat(exchange string, err error) {
+	heartbeatMutex.Lock()
+	defer heartbeatMutex.Unlock()
+	hb, ok := heartbeats[exchange]
+	if !ok {
+		hb = &heartbeat{}
+		heartbeats[exchange] = hb
+	}
+	if err == nil {
+		hb.success = time.Now()
+		hb.failures = 0
+	} else {
+		hb.lastError = err
+		hb.lastErrorAt = time.Now()
+		hb.failures++
+	}
+}
+
+// GetHealth returns the liveness of the sell loop of every exchange we are listening to.
+func GetHealth() []Health {
+	heartbeatMutex.Lock()
+	defer heartbeatMutex.Unlock()
+	var out []Health
+	for exchange, hb := range heartbeats {
+		health := Health{
+			Exchange:    exchange,
+			Failures:    hb.failures,
+			BreakerOpen: hb.failures >= healthBreakerMax,
+		}
+		if !hb.success.IsZero() {
+			health.Seconds = int64(time.Since(hb.success).Seconds())
+			health.Alive = time.Since(hb.success) < healthStale
+		}
+		if hb.lastError != nil {
+			health.LastError = hb.lastError.Error()
+			lastErrorAt := hb.lastErrorAt
+			health.LastErrorAt = &lastErrorAt
+		}
+		out = append(out, health)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Exchange < out[j].Exchange
+	})
+	return out
+}
@@ -10,14 +10,15 @@ import (
 	"strings"
 	"time"
 
-	filemutex "github.com/alexflint/go-filemutex"
 	"github.com/svanas/nefertiti/aggregation"
 	"github.com/svanas/nefertiti/errors"
 	"github.com/svanas/nefertiti/flag"
 	exchange "github.com/svanas/nefertiti/hitbtc"
+	"github.com/svanas/nefertiti/i18n"
 	"github.com/svanas/nefertiti/model"
 	"github.com/svanas/nefertiti/multiplier"
 	"github.com/svanas/nefertiti/notify"
+	"github.com/svanas/nefertiti/override"
 	"github.com/svanas/nefertiti/precision"
 	"github.com/svanas/nefertiti/pricing"
 	"github.com/svanas/nefertiti/session"
@@ -25,7 +26,7 @@ import (
 )
 
 var (
-	hitbtcMutex *filemutex.FileMutex
+	hitbtcMutex *session.Mutex
 )
 
 const (
@@ -38,7 +39,7 @@ func init() {
 		var err error
 
 		if hitbtcMutex == nil {
-			if hitbtcMutex, err = filemutex.New(session.GetSessionFile(hitbtcSessionLock)); err != nil {
+			if hitbtcMutex, err = session.NewMutex(hitbtcSessionLock); err != nil {
 				return err
 			}
 		}
@@ -132,7 +133,7 @@ func (self *HitBTC) error(err error, level int64, service model.Notify) {
 	if service != nil {
 		if notify.CanSend(level, notify.ERROR) {
 			if err.Error() != "502 Bad Gateway" {
-				err := service.SendMessage(msg, "HitBTC - ERROR", model.ONCE_PER_MINUTE)
+				err := notify.SendError(service, err, msg, "HitBTC - ERROR")
 				if err != nil {
 					log.Printf("[ERROR] %v", err)
 				}
@@ -191,13 +192,26 @@ func (self *HitBTC) getTradeSide(trade *exchange.Trade) model.OrderSide {
 	return model.ORDER_SIDE_NONE
 }
 
+func (self *HitBTC) getBaseURL(sandbox bool) string {
+	if sandbox {
+		return self.ExchangeInfo.REST.Sandbox
+	}
+	return self.ExchangeInfo.REST.URI
+}
+
+func (self *HitBTC) newClient(apiKey, apiSecret string, sandbox bool) *exchange.HitBtc {
+	out := exchange.New(apiKey, apiSecret)
+	out.SetURL(self.getBaseURL(sandbox))
+	return out
+}
+
 func (self *HitBTC) GetInfo() *model.ExchangeInfo {
 	return self.ExchangeInfo
 }
 
 func (self *HitBTC) GetClient(permission model.Permission, sandbox bool) (interface{}, error) {
 	if permission != model.PRIVATE {
-		return exchange.New("", ""), nil
+		return self.newClient("", "", sandbox), nil
 	}
 
 	apiKey, apiSecret, err := promptForApiKeys("HitBTC")
@@ -205,13 +219,13 @@ func (self *HitBTC) GetClient(permission model.Permission, sandbox bool) (interf
 		return nil, err
 	}
 
-	return exchange.New(apiKey, apiSecret), nil
+	return self.newClient(apiKey, apiSecret, sandbox), nil
 }
 
 func (self *HitBTC) GetMarkets(cached, sandbox bool, ignore []string) ([]model.Market, error) {
 	var out []model.Market
 
-	client := exchange.New("", "")
+	client := self.newClient("", "", sandbox)
 
 	symbols, err := self.getSymbols(client, cached)
 	if err != nil {
@@ -229,8 +243,11 @@ func (self *HitBTC) GetMarkets(cached, sandbox bool, ignore []string) ([]model.M
 	return out, nil
 }
 
+// how HitBTC spells its markets
+var hitbtcSymbol = model.SymbolFormat{}
+
 func (self *HitBTC) FormatMarket(base, quote string) string {
-	return strings.ToUpper(base + quote)
+	return model.NewMarketSymbol(base, quote).Format(hitbtcSymbol)
 }
 
 // listens to the open orders, look for cancelled orders, send a notification.
@@ -264,7 +281,7 @@ func (self *HitBTC) listen(
 				side := self.getOrderSide(&order)
 				if side != model.ORDER_SIDE_NONE {
 					if service != nil && notify.CanSend(level, notify.CANCELLED) {
-						if err = service.SendMessage(order, fmt.Sprintf("HitBTC - Done %s (Reason: Cancelled)", model.FormatOrderSide(side)), model.ALWAYS); err != nil {
+						if err = service.SendMessage(order, fmt.Sprintf("HitBTC - %s", i18n.Sprintf("Done %s (Reason: Cancelled)", model.FormatOrderSide(side))), model.ALWAYS); err != nil {
 							log.Printf("[ERROR] %v", err)
 						}
 					}
@@ -287,7 +304,7 @@ func (self *HitBTC) listen(
 				side := self.getOrderSide(&order)
 				if side != model.ORDER_SIDE_NONE {
 					if notify.CanSend(level, notify.OPENED) || (level == notify.LEVEL_DEFAULT && side == model.SELL) {
-						if err = service.SendMessage(order, ("HitBTC - Open " + model.FormatOrderSide(side)), model.ALWAYS); err != nil {
+						if err = service.SendMessage(order, ("HitBTC - " + i18n.Sprintf("Open %s", model.FormatOrderSide(side))), model.ALWAYS); err != nil {
 							log.Printf("[ERROR] %v", err)
 						}
 					}
@@ -327,7 +344,7 @@ func (self *HitBTC) sell(
 
 	// send notification(s)
 	for _, trade := range new {
-		if old.indexByOrderId(trade.OrderId) == -1 {
+		if old.indexByOrderId(trade.OrderId) == -1 && processOnce(self, strconv.FormatUint(trade.Id, 10)) {
 			var data []byte
 			if data, err = json.Marshal(trade); err != nil {
 				return new, errors.Wrap(err, 1)
@@ -337,12 +354,12 @@ func (self *HitBTC) sell(
 
 			if notify.CanSend(level, notify.FILLED) {
 				if service != nil {
-					if err = service.SendMessage(trade, fmt.Sprintf("HitBTC - Done %s (Reason: Filled)", strings.Title(trade.Side)), model.ALWAYS); err != nil {
+					if err = service.SendMessage(trade, fmt.Sprintf("HitBTC - %s", i18n.Sprintf("Done %s (Reason: Filled)", strings.Title(trade.Side))), model.ALWAYS); err != nil {
 						log.Printf("[ERROR] %v", err)
 					}
 				}
 				if twitter != nil {
-					notify.Tweet(twitter, fmt.Sprintf("Done %s. %s priced at %f #HitBTC", strings.Title(trade.Side), model.TweetMarket(markets, trade.Symbol), trade.Price))
+					notify.Tweet(twitter, fmt.Sprintf("Done %s. %s priced at %s #HitBTC", strings.Title(trade.Side), model.TweetMarket(markets, trade.Symbol), model.FormatPrice(self, client, trade.Symbol, trade.Price)))
 				}
 			}
 		}
@@ -380,15 +397,15 @@ func (self *HitBTC) sell(
 				)
 				base, quote, err = model.ParseMarket(markets, new[i].Symbol)
 				if err == nil {
-					qty = self.GetMaxSize(client, base, quote, hold.HasMarket(new[i].Symbol), earn.HasMarket(new[i].Symbol), qty, mult)
+					qty = self.GetMaxSize(client, base, quote, hold.HasMarket(new[i].Symbol), earn.HasMarket(new[i].Symbol), qty, override.Mult(new[i].Symbol, mult))
 					if qty > 0 {
-						var prec int
-						if prec, err = self.GetPricePrec(client, new[i].Symbol); err == nil {
+						var tick float64
+						if tick, err = self.GetTickSize(client, new[i].Symbol); err == nil {
 							_, _, err = self.Order(client,
 								model.SELL,
 								new[i].Symbol,
 								qty,
-								pricing.Multiply(price, mult, prec),
+								pricing.MultiplyTick(price, override.Mult(new[i].Symbol, mult), tick),
 								model.LIMIT,
 								strconv.FormatFloat(price, 'f', -1, 64),
 							)
@@ -445,7 +462,7 @@ func (self *HitBTC) Sell(
 		}
 	}
 
-	client := exchange.New(apiKey, apiSecret)
+	client := self.newClient(apiKey, apiSecret, sandbox)
 
 	// get my filled orders
 	var filled []exchange.Trade
@@ -482,6 +499,7 @@ func (self *HitBTC) Sell(
 		if opened, err = self.listen(client, service, level, opened, filled); err != nil {
 			self.error(err, level, service)
 		}
+		beat(self.GetInfo().Name, err)
 	}
 }
 
@@ -494,6 +512,14 @@ func (self *HitBTC) Order(
 	kind model.OrderType,
 	metadata string,
 ) (oid []byte, raw []byte, err error) {
+	if raw, ok := observeOrder(self, client, side, market, size, price, kind); ok {
+		return nil, raw, nil
+	}
+
+	if err := CheckEquityShare(self, client, side, market, size, price); err != nil {
+		return nil, nil, err
+	}
+
 	hitbtc, ok := client.(*exchange.HitBtc)
 	if !ok {
 		return nil, nil, errors.New("invalid argument: client")
@@ -535,7 +561,15 @@ func (self *HitBTC) Order(
 	return []byte(order.ClientOrderId), out, nil
 }
 
+func (self *HitBTC) GetStops() model.Stops {
+	return model.Stops{Stop: true}
+}
+
 func (self *HitBTC) StopLoss(client interface{}, market string, size float64, price float64, kind model.OrderType, metadata string) ([]byte, error) {
+	if raw, ok := observeStopLoss(self, client, market, size, price); ok {
+		return raw, nil
+	}
+
 	var err error
 
 	hitbtc, ok := client.(*exchange.HitBtc)
@@ -545,6 +579,16 @@ func (self *HitBTC) StopLoss(client interface{}, market string, size float64, pr
 
 	var order exchange.Order
 	if kind == model.LIMIT {
+		var (
+			tick float64
+			exit *model.StopExit
+		)
+		if tick, err = model.GetTickSize(self, client, market); err != nil {
+			return nil, err
+		}
+		if exit, err = model.GetStopExit(); err != nil {
+			return nil, err
+		}
 		order, err = hitbtc.PlaceOrder(
 			self.getUniquePartnerId(),
 			market,
@@ -552,7 +596,7 @@ func (self *HitBTC) StopLoss(client interface{}, market string, size float64, pr
 			exchange.ORDER_TYPE_STOP_LIMIT,
 			exchange.GTC,
 			size,
-			price,
+			exit.Limit(price, tick),
 			price,
 		)
 	} else {
@@ -580,6 +624,10 @@ func (self *HitBTC) StopLoss(client interface{}, market string, size float64, pr
 }
 
 func (self *HitBTC) OCO(client interface{}, market string, size float64, price, stop float64, metadata string) ([]byte, error) {
+	if raw, ok := observeOCO(self, client, market, size, price, stop); ok {
+		return raw, nil
+	}
+
 	return nil, errors.New("Not implemented")
 }
 
@@ -599,10 +647,15 @@ func (self *HitBTC) GetClosed(client interface{}, market string) (model.Orders,
 	var out model.Orders
 	for _, trade := range trades {
 		out = append(out, model.Order{
-			Side:   self.getTradeSide(&trade),
-			Market: trade.Symbol,
-			Size:   trade.Quantity,
-			Price:  trade.Price,
+			Side:       self.getTradeSide(&trade),
+			Status:     model.FILLED,
+			Market:     trade.Symbol,
+			Size:       trade.Quantity,
+			Price:      trade.Price,
+			CreatedAt:  trade.Timestamp,
+			ClosedAt:   model.TimePtr(trade.Timestamp),
+			FilledSize: trade.Quantity,
+			Fee:        trade.Fee,
 		})
 	}
 
@@ -625,7 +678,9 @@ func (self *HitBTC) GetOpened(client interface{}, market string) (model.Orders,
 	var out model.Orders
 	for _, order := range orders {
 		out = append(out, model.Order{
+			Status:    model.OPEN,
 			Side:      self.getOrderSide(&order),
+			ID:        order.ClientOrderId,
 			Market:    order.Symbol,
 			Size:      order.Quantity,
 			Price:     order.ParsePrice(),
@@ -665,14 +720,14 @@ func (self *HitBTC) Aggregate(client, book interface{}, market string, agg float
 		return nil, errors.New("invalid argument: book")
 	}
 
-	prec, err := self.GetPricePrec(client, market)
+	tick, err := self.GetTickSize(client, market)
 	if err != nil {
 		return nil, err
 	}
 
 	var out model.Book
 	for _, e := range bids {
-		price := precision.Round(aggregation.Round(e.Price, agg), prec)
+		price := precision.RoundTick(aggregation.Round(e.Price, agg), tick)
 		entry := out.EntryByPrice(price)
 		if entry != nil {
 			entry.Size = entry.Size + e.Size
@@ -703,6 +758,25 @@ func (self *HitBTC) GetTicker(client interface{}, market string) (float64, error
 	return ticker.Last, nil
 }
 
+func (self *HitBTC) GetTickers(client interface{}) (map[string]float64, error) {
+	hitbtc, ok := client.(*exchange.HitBtc)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	tickers, err := hitbtc.GetTickers()
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	out := make(map[string]float64)
+	for _, ticker := range tickers {
+		out[ticker.Symbol] = ticker.Last
+	}
+
+	return out, nil
+}
+
 func (self *HitBTC) Get24h(client interface{}, market string) (*model.Stats, error) {
 	hitbtc, ok := client.(*exchange.HitBtc)
 	if !ok {
@@ -718,6 +792,9 @@ func (self *HitBTC) Get24h(client interface{}, market string) (*model.Stats, err
 		Market: market,
 		High:   ticker.High,
 		Low:    ticker.Low,
+		Open:   ticker.Open,
+		Last:   ticker.Last,
+		Change: model.PercentChange(ticker.Open, ticker.Last),
 		BtcVolume: func() float64 {
 			symbol, err := self.getSymbol(hitbtc, market)
 			if err == nil {
@@ -730,6 +807,38 @@ func (self *HitBTC) Get24h(client interface{}, market string) (*model.Stats, err
 	}, nil
 }
 
+func (self *HitBTC) Get24hAll(client interface{}) (map[string]model.Stats, error) {
+	hitbtc, ok := client.(*exchange.HitBtc)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	tickers, err := hitbtc.GetTickers()
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	out := make(map[string]model.Stats)
+	volume := make(map[string]float64) // in quote currency
+	for _, ticker := range tickers {
+		out[ticker.Symbol] = model.Stats{
+			Market: ticker.Symbol,
+			High:   ticker.High,
+			Low:    ticker.Low,
+			Open:   ticker.Open,
+			Last:   ticker.Last,
+			Change: model.PercentChange(ticker.Open, ticker.Last),
+		}
+		volume[ticker.Symbol] = ticker.VolumeQuote
+	}
+
+	if err = setBtcVolume(self, out, volume); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
 func (self *HitBTC) GetPricePrec(client interface{}, market string) (int, error) {
 	hitbtc, ok := client.(*exchange.HitBtc)
 	if !ok {
@@ -742,6 +851,18 @@ func (self *HitBTC) GetPricePrec(client interface{}, market string) (int, error)
 	return precision.Parse(strconv.FormatFloat(symbol.TickSize, 'f', -1, 64), 8), nil
 }
 
+func (self *HitBTC) GetTickSize(client interface{}, market string) (float64, error) {
+	hitbtc, ok := client.(*exchange.HitBtc)
+	if !ok {
+		return 0, errors.New("invalid argument: client")
+	}
+	symbol, err := self.getSymbol(hitbtc, market)
+	if err != nil {
+		return 0, err
+	}
+	return symbol.TickSize, nil
+}
+
 func (self *HitBTC) GetSizePrec(client interface{}, market string) (int, error) {
 	hitbtc, ok := client.(*exchange.HitBtc)
 	if !ok {
@@ -770,6 +891,10 @@ func (self *HitBTC) GetMaxSize(client interface{}, base, quote string, hold, ear
 }
 
 func (self *HitBTC) Cancel(client interface{}, market string, side model.OrderSide) error {
+	if observeCancel(self, market, side) {
+		return nil
+	}
+
 	var err error
 
 	hitbtc, ok := client.(*exchange.HitBtc)
@@ -793,7 +918,86 @@ func (self *HitBTC) Cancel(client interface{}, market string, side model.OrderSi
 	return nil
 }
 
+func (self *HitBTC) CancelOrder(client interface{}, market, id string) error {
+	if observeCancelOrder(self, market, id) {
+		return nil
+	}
+
+	hitbtc, ok := client.(*exchange.HitBtc)
+	if !ok {
+		return errors.New("invalid argument: client")
+	}
+
+	if _, err := hitbtc.CancelClientOrderId(id); err != nil {
+		return errors.Wrap(err, 1)
+	}
+
+	return nil
+}
+
+func (self *HitBTC) GetOrder(client interface{}, market, id string) (*model.Order, error) {
+	hitbtc, ok := client.(*exchange.HitBtc)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	// the order history has the closed orders, the open orders are somewhere else
+	var order exchange.Order
+	closed, err := hitbtc.GetOrder(id)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+	if len(closed) > 0 {
+		order = closed[0]
+	} else if order, err = hitbtc.GetActiveOrder(id); err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	out := &model.Order{
+		ID:         id,
+		Status:     model.OPEN,
+		Side:       self.getOrderSide(&order),
+		Market:     order.Symbol,
+		Size:       order.Quantity,
+		Price:      order.ParsePrice(),
+		CreatedAt:  order.Created,
+		FilledSize: order.CumQuantity,
+		Stop:       order.ParseStopPrice(),
+	}
+	switch order.Status {
+	case exchange.ORDER_STATUS_FILLED:
+		out.Status = model.FILLED
+		out.ClosedAt = model.TimePtr(order.Updated)
+	case exchange.ORDER_STATUS_CANCELED, exchange.ORDER_STATUS_EXPIRED:
+		out.Status = model.CANCELLED
+		out.ClosedAt = model.TimePtr(order.Updated)
+	}
+
+	return out, nil
+}
+
+func (self *HitBTC) GetFees(client interface{}, market string) (*model.Fees, error) {
+	hitbtc, ok := client.(*exchange.HitBtc)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	fee, err := hitbtc.GetTradingFee(market)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	return &model.Fees{
+		Maker: fee.ProvideLiquidityRate,
+		Taker: fee.TakeLiquidityRate,
+	}, nil
+}
+
 func (self *HitBTC) Buy(client interface{}, cancel bool, market string, calls model.Calls, deviation float64, kind model.OrderType) error {
+	if observeBuy(self, client, cancel, market, calls, kind) {
+		return nil
+	}
+
 	var err error
 
 	hitbtc, ok := client.(*exchange.HitBtc)
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"runtime"
+	"strconv"
 	"strings"
 
 	"github.com/svanas/nefertiti/aggregation"
@@ -65,7 +66,7 @@ func (self *Huobi) error(err error, level int64, service model.Notify) {
 
 	if service != nil {
 		if notify.CanSend(level, notify.ERROR) {
-			err := service.SendMessage(msg, "Huobi - ERROR", model.ONCE_PER_MINUTE)
+			err := notify.SendError(service, err, msg, "Huobi - ERROR")
 			if err != nil {
 				log.Printf("[ERROR] %v", err)
 			}
@@ -122,8 +123,11 @@ func (self *Huobi) GetMarkets(cached, sandbox bool, blacklist []string) ([]model
 	return out, nil
 }
 
+// how Huobi spells its markets
+var huobiSymbol = model.SymbolFormat{Lower: true}
+
 func (self *Huobi) FormatMarket(base, quote string) string {
-	return strings.ToLower(base + quote)
+	return model.NewMarketSymbol(base, quote).Format(huobiSymbol)
 }
 
 func (self *Huobi) Sell(
@@ -158,6 +162,14 @@ func (self *Huobi) Order(
 	kind model.OrderType,
 	metadata string,
 ) (oid []byte, raw []byte, err error) {
+	if raw, ok := observeOrder(self, client, side, market, size, price, kind); ok {
+		return nil, raw, nil
+	}
+
+	if err := CheckEquityShare(self, client, side, market, size, price); err != nil {
+		return nil, nil, err
+	}
+
 	huobiClient, ok := client.(*exchange.Client)
 	if !ok {
 		return nil, nil, errors.New("invalid argument: client")
@@ -214,12 +226,14 @@ func (self *Huobi) GetOpened(client interface{}, market string) (model.Orders, e
 
 	for _, order := range orders {
 		output = append(output, model.Order{
+			Status: model.OPEN,
 			Side: func() model.OrderSide {
 				if order.Sell() {
 					return model.SELL
 				}
 				return model.BUY
 			}(),
+			ID:        strconv.FormatInt(order.Id, 10),
 			Market:    market,
 			Size:      order.Amount,
 			Price:     order.Price,
@@ -294,6 +308,20 @@ func (self *Huobi) GetTicker(client interface{}, market string) (float64, error)
 	return ticker.Price, nil
 }
 
+func (self *Huobi) GetTickers(client interface{}) (map[string]float64, error) {
+	huobiClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	out, err := huobiClient.Prices()
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	return out, nil
+}
+
 func (self *Huobi) Get24h(client interface{}, market string) (*model.Stats, error) {
 	huobiClient, ok := client.(*exchange.Client)
 	if !ok {
@@ -312,6 +340,10 @@ func (self *Huobi) Get24h(client interface{}, market string) (*model.Stats, erro
 		Market: market,
 		High:   sum.High,
 		Low:    sum.Low,
+		Open:   sum.Open,
+		Last:   sum.Close,
+		Change: model.PercentChange(sum.Open, sum.Close),
+		Trades: sum.Count,
 		BtcVolume: func(sum *exchange.Summary) float64 {
 			symbols, err := self.getSymbols(huobiClient, true)
 			if err == nil {
@@ -332,6 +364,39 @@ func (self *Huobi) Get24h(client interface{}, market string) (*model.Stats, erro
 	}, nil
 }
 
+func (self *Huobi) Get24hAll(client interface{}) (map[string]model.Stats, error) {
+	huobiClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	ticks, err := huobiClient.Tickers()
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	out := make(map[string]model.Stats)
+	volume := make(map[string]float64) // in quote currency
+	for _, tick := range ticks {
+		out[tick.Symbol] = model.Stats{
+			Market: tick.Symbol,
+			High:   tick.High,
+			Low:    tick.Low,
+			Open:   tick.Open,
+			Last:   tick.Close,
+			Change: model.PercentChange(tick.Open, tick.Close),
+			Trades: tick.Count,
+		}
+		volume[tick.Symbol] = tick.Vol
+	}
+
+	if err = setBtcVolume(self, out, volume); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
 func (self *Huobi) GetPricePrec(client interface{}, market string) (int, error) {
 	return 8, errors.New("Not implemented")
 }
@@ -351,6 +416,10 @@ func (self *Huobi) GetMaxSize(client interface{}, base, quote string, hold, earn
 }
 
 func (self *Huobi) Cancel(client interface{}, market string, side model.OrderSide) error {
+	if observeCancel(self, market, side) {
+		return nil
+	}
+
 	huobiClient, ok := client.(*exchange.Client)
 	if !ok {
 		return errors.New("invalid argument: client")
@@ -372,7 +441,93 @@ func (self *Huobi) Cancel(client interface{}, market string, side model.OrderSid
 	return nil
 }
 
+func (self *Huobi) CancelOrder(client interface{}, market, id string) error {
+	if observeCancelOrder(self, market, id) {
+		return nil
+	}
+
+	huobiClient, ok := client.(*exchange.Client)
+	if !ok {
+		return errors.New("invalid argument: client")
+	}
+
+	orderID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return errors.Wrap(err, 1)
+	}
+
+	if err = huobiClient.CancelOrder(orderID); err != nil {
+		return errors.Wrap(err, 1)
+	}
+
+	return nil
+}
+
+func (self *Huobi) GetOrder(client interface{}, market, id string) (*model.Order, error) {
+	huobiClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	orderID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	order, err := huobiClient.GetOrder(orderID)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	out := &model.Order{
+		ID:     id,
+		Status: model.OPEN,
+		Side: func() model.OrderSide {
+			if order.Sell() {
+				return model.SELL
+			}
+			return model.BUY
+		}(),
+		Market:     market,
+		Size:       order.Amount,
+		Price:      order.Price,
+		CreatedAt:  order.GetCreatedAt(),
+		FilledSize: order.GetFilledAmount(),
+	}
+	switch order.State {
+	case exchange.OrderStateFilled:
+		out.Status = model.FILLED
+		out.ClosedAt = model.TimePtr(order.GetFinishedAt())
+	case exchange.OrderStatePartialCanceled, exchange.OrderStateCanceled:
+		out.Status = model.CANCELLED
+		out.ClosedAt = model.TimePtr(order.GetFinishedAt())
+	}
+
+	return out, nil
+}
+
+func (self *Huobi) GetFees(client interface{}, market string) (*model.Fees, error) {
+	huobiClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	maker, taker, err := huobiClient.GetTradingFee(market)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	return &model.Fees{
+		Maker: maker,
+		Taker: taker,
+	}, nil
+}
+
 func (self *Huobi) Buy(client interface{}, cancel bool, market string, calls model.Calls, deviation float64, kind model.OrderType) error {
+	if observeBuy(self, client, cancel, market, calls, kind) {
+		return nil
+	}
+
 	return errors.New("Not implemented")
 }
 
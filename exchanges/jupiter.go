@@ -0,0 +1,769 @@
+//lint:file-ignore ST1006 receiver name should be a reflection of its identity; don't use generic names such as "this" or "self"
+package exchanges
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/i18n"
+	exchange "github.com/svanas/nefertiti/jupiter"
+	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/multiplier"
+	"github.com/svanas/nefertiti/notify"
+	"github.com/svanas/nefertiti/override"
+	"github.com/svanas/nefertiti/precision"
+	"github.com/svanas/nefertiti/pricing"
+	"github.com/svanas/nefertiti/session"
+)
+
+var (
+	jupiterMutex *session.Mutex
+)
+
+const (
+	jupiterSessionFile    = "jupiter.time"
+	jupiterSessionLock    = "jupiter.lock"
+	jupiterSessionJournal = "jupiter.json"
+)
+
+// the tokens we quote in
+var jupiterQuotes = map[string]string{
+	"USDC": exchange.MINT_USDC,
+	"SOL":  exchange.MINT_SOL,
+}
+
+func init() {
+	exchange.BeforeRequest = func(method, path string) error {
+		var err error
+
+		if jupiterMutex == nil {
+			if jupiterMutex, err = session.NewMutex(jupiterSessionLock); err != nil {
+				return err
+			}
+		}
+
+		if err = jupiterMutex.Lock(); err != nil {
+			return err
+		}
+
+		var lastRequest *time.Time
+		if lastRequest, err = session.GetLastRequest(jupiterSessionFile); err != nil {
+			return err
+		}
+
+		if lastRequest != nil {
+			elapsed := time.Since(*lastRequest)
+			if elapsed.Seconds() < (float64(1) / exchange.RequestsPerSecond) {
+				sleep := time.Duration((float64(time.Second) / exchange.RequestsPerSecond) - float64(elapsed))
+				if flag.Debug() {
+					log.Printf("[DEBUG] sleeping %f seconds\n", sleep.Seconds())
+				}
+				time.Sleep(sleep)
+			}
+		}
+
+		if flag.Debug() {
+			log.Printf("[DEBUG] %s %s\n", method, path)
+		}
+
+		return nil
+	}
+	exchange.AfterRequest = func() {
+		defer func() {
+			jupiterMutex.Unlock()
+		}()
+		session.SetLastRequest(jupiterSessionFile, time.Now())
+	}
+}
+
+// a DEX has no order history, so we keep a journal of our swaps in the session dir.
+type JupiterFill struct {
+	Signature string          `json:"signature"`
+	Market    string          `json:"market"`
+	Side      model.OrderSide `json:"side"`
+	Size      float64         `json:"size"`
+	Price     float64         `json:"price"`
+	CreatedAt time.Time       `json:"created_at"`
+	ClosedBy  string          `json:"closed_by,omitempty"` // the signature of the swap that sold this buy
+}
+
+type JupiterJournal []JupiterFill
+
+func readJupiterJournal() (JupiterJournal, error) {
+	data, err := session.ReadFile(session.GetSessionFile(jupiterSessionJournal))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, 1)
+	}
+	var out JupiterJournal
+	if err = json.Unmarshal(data, &out); err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+	return out, nil
+}
+
+func (journal JupiterJournal) write() error {
+	data, err := json.Marshal(journal)
+	if err != nil {
+		return errors.Wrap(err, 1)
+	}
+	return session.WriteFile(session.GetSessionFile(jupiterSessionJournal), data)
+}
+
+type Jupiter struct {
+	*model.ExchangeInfo
+	tokens []exchange.Token
+}
+
+func (self *Jupiter) error(err error, level int64, service model.Notify) {
+	pc, file, line, _ := runtime.Caller(1)
+	prefix := errors.FormatCaller(pc, file, line)
+
+	msg := fmt.Sprintf("%s %v", prefix, err)
+	_, ok := err.(*errors.Error)
+	if ok && flag.Debug() {
+		log.Printf("[ERROR] %s", err.(*errors.Error).ErrorStack(prefix, ""))
+	} else {
+		log.Printf("[ERROR] %s", msg)
+	}
+
+	if service != nil {
+		if notify.CanSend(level, notify.ERROR) {
+			err := notify.SendError(service, err, msg, "Jupiter - ERROR")
+			if err != nil {
+				log.Printf("[ERROR] %v", err)
+			}
+		}
+	}
+}
+
+// --rpc=URI overrides the Solana JSON RPC
+func (self *Jupiter) getRPC() string {
+	arg := flag.Get("rpc")
+	if arg.Exists && arg.String() != "" {
+		return arg.String()
+	}
+	return exchange.RPC_BASE
+}
+
+// --keypair=path, defaults to the solana-keygen default
+func (self *Jupiter) getKeypair() (*exchange.Keypair, error) {
+	path := flag.Get("keypair").String()
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, errors.Wrap(err, 1)
+		}
+		path = filepath.Join(home, ".config", "solana", "id.json")
+	}
+	keypair, err := exchange.LoadKeypair(path)
+	if err != nil {
+		return nil, errors.Errorf("cannot load keypair %s: %v", path, err)
+	}
+	return keypair, nil
+}
+
+func (self *Jupiter) getTokens(client *exchange.Client, cached bool) ([]exchange.Token, error) {
+	if self.tokens == nil || !cached {
+		var err error
+		if self.tokens, err = client.Tokens(); err != nil {
+			return nil, errors.Wrap(err, 1)
+		}
+	}
+	return self.tokens, nil
+}
+
+func (self *Jupiter) getToken(client *exchange.Client, symbol string) (*exchange.Token, error) {
+	tokens, err := self.getTokens(client, true)
+	if err != nil {
+		return nil, err
+	}
+
+	mint, isQuote := jupiterQuotes[strings.ToUpper(symbol)]
+	for _, token := range tokens {
+		if (isQuote && token.Address == mint) || (!isQuote && strings.EqualFold(token.Symbol, symbol)) {
+			return &token, nil
+		}
+	}
+
+	return nil, errors.Errorf("token %v does not exist", symbol)
+}
+
+func (self *Jupiter) parseMarket(market string) (string, string, error) { // -> (base, quote, error)
+	subs := strings.Split(market, "-")
+	if len(subs) != 2 {
+		return "", "", errors.Errorf("cannot parse market %s", market)
+	}
+	return subs[0], subs[1], nil
+}
+
+func (self *Jupiter) getPair(client *exchange.Client, market string) (*exchange.Token, *exchange.Token, error) { // -> (base, quote, error)
+	base, quote, err := self.parseMarket(market)
+	if err != nil {
+		return nil, nil, err
+	}
+	baseToken, err := self.getToken(client, base)
+	if err != nil {
+		return nil, nil, err
+	}
+	quoteToken, err := self.getToken(client, quote)
+	if err != nil {
+		return nil, nil, err
+	}
+	return baseToken, quoteToken, nil
+}
+
+// swaps at market, bounded by --slippage=X. when a limit is included, we refuse to swap beyond the limit (plus slippage).
+func (self *Jupiter) swap(client *exchange.Client, side model.OrderSide, market string, size, limit float64) (*JupiterFill, error) {
+	base, quote, err := self.getPair(client, market)
+	if err != nil {
+		return nil, err
+	}
+
+	slippage, err := flag.Slippage()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		price  float64
+		result *exchange.Quote
+	)
+	if side == model.BUY {
+		price = limit
+		if price == 0 {
+			if price, err = client.Price(base.Address, quote.Address); err != nil {
+				return nil, errors.Wrap(err, 1)
+			}
+		}
+		if result, err = client.Quote(quote.Address, base.Address, quote.ToAmount(size*price), int(slippage*100)); err != nil {
+			return nil, errors.Wrap(err, 1)
+		}
+		received := base.FromAmount(result.OutAmount)
+		if received == 0 {
+			return nil, errors.Errorf("cannot buy %s. no liquidity", market)
+		}
+		price = quote.FromAmount(result.InAmount) / received
+		if limit > 0 && price > limit*(1+slippage/100) {
+			return nil, errors.Errorf("cannot buy %s. quoted price %s exceeds your limit %s", market, precision.FormatFloat(price, -1), precision.FormatFloat(limit, -1))
+		}
+		size = received
+	} else {
+		if result, err = client.Quote(base.Address, quote.Address, base.ToAmount(size), int(slippage*100)); err != nil {
+			return nil, errors.Wrap(err, 1)
+		}
+		price = quote.FromAmount(result.OutAmount) / size
+		if limit > 0 && price < limit*(1-slippage/100) {
+			return nil, errors.Errorf("cannot sell %s. quoted price %s is below your limit %s", market, precision.FormatFloat(price, -1), precision.FormatFloat(limit, -1))
+		}
+	}
+
+	if err = checkSwap(side, market, base.Symbol, quote.Symbol, price, slippage); err != nil {
+		return nil, err
+	}
+
+	signature, err := client.Swap(result)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	fill := JupiterFill{
+		Signature: signature,
+		Market:    market,
+		Side:      side,
+		Size:      size,
+		Price:     price,
+		CreatedAt: time.Now(),
+	}
+
+	journal, err := readJupiterJournal()
+	if err != nil {
+		return &fill, err
+	}
+	journal = append(journal, fill)
+
+	return &fill, journal.write()
+}
+
+func (self *Jupiter) GetInfo() *model.ExchangeInfo {
+	return self.ExchangeInfo
+}
+
+func (self *Jupiter) GetClient(permission model.Permission, sandbox bool) (interface{}, error) {
+	if sandbox {
+		return nil, errors.New("sandbox not supported")
+	}
+
+	if permission == model.PUBLIC {
+		return exchange.New(self.ExchangeInfo.REST.URI, self.getRPC(), nil), nil
+	}
+
+	keypair, err := self.getKeypair()
+	if err != nil {
+		return nil, err
+	}
+
+	return exchange.New(self.ExchangeInfo.REST.URI, self.getRPC(), keypair), nil
+}
+
+func (self *Jupiter) GetMarkets(cached, sandbox bool, blacklist []string) ([]model.Market, error) {
+	var out []model.Market
+
+	tokens, err := self.getTokens(exchange.New(self.ExchangeInfo.REST.URI, self.getRPC(), nil), cached)
+	if err != nil {
+		return nil, err
+	}
+
+	for quote, mint := range jupiterQuotes {
+		for _, token := range tokens {
+			if token.Address == mint || token.Symbol == "" || strings.Contains(token.Symbol, "-") {
+				continue
+			}
+			name := self.FormatMarket(token.Symbol, quote)
+			if func() bool {
+				for _, ignore := range blacklist {
+					if strings.EqualFold(name, ignore) {
+						return false
+					}
+				}
+				return true
+			}() {
+				out = append(out, model.Market{
+					Name:  name,
+					Base:  strings.ToUpper(token.Symbol),
+					Quote: quote,
+				})
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// how Jupiter spells its markets
+var jupiterSymbol = model.SymbolFormat{Separator: "-"}
+
+func (self *Jupiter) FormatMarket(base, quote string) string {
+	return model.NewMarketSymbol(base, quote).Format(jupiterSymbol)
+}
+
+// look for bought tokens that reached their target (or their stop), then swap them back.
+func (self *Jupiter) sell(
+	client *exchange.Client,
+	strategy model.Strategy,
+	mult multiplier.Mult,
+	stop multiplier.Mult,
+	hold model.Markets,
+	service model.Notify,
+	level int64,
+) error {
+	journal, err := readJupiterJournal()
+	if err != nil {
+		return err
+	}
+
+	for i, fill := range journal {
+		if fill.Side != model.BUY || fill.ClosedBy != "" || hold.HasMarket(fill.Market) {
+			continue
+		}
+
+		ticker, err := self.GetTicker(client, fill.Market)
+		if err != nil {
+			return err
+		}
+
+		tick, err := model.GetTickSize(self, client, fill.Market)
+		if err != nil {
+			return err
+		}
+
+		target := pricing.MultiplyTick(fill.Price, override.Mult(fill.Market, mult), tick)
+		stopped := strategy == model.STRATEGY_STOP_LOSS && ticker <= pricing.MultiplyTick(fill.Price, override.Stop(fill.Market, stop), tick)
+		if ticker < target && !stopped {
+			continue
+		}
+
+		if _, ok := observeOrder(self, client, model.SELL, fill.Market, fill.Size, 0, model.MARKET); ok {
+			continue
+		}
+
+		exit, err := self.swap(client, model.SELL, fill.Market, fill.Size, 0)
+		if err != nil {
+			data, _ := json.Marshal(fill)
+			if data == nil {
+				self.error(err, level, service)
+			} else {
+				self.error(errors.Append(err, "\t", string(data)), level, service)
+			}
+			continue
+		}
+
+		// re-read the journal, because swap() appended the exit
+		if journal, err = readJupiterJournal(); err != nil {
+			return err
+		}
+		journal[i].ClosedBy = exit.Signature
+		if err = journal.write(); err != nil {
+			return err
+		}
+
+		data, _ := json.Marshal(exit)
+		log.Println("[FILLED] " + string(data))
+
+		if stopped {
+			recordLoss(self, fill.Market, exit.Size*exit.Price, stop, service)
+		} else {
+			recordProfit(self, client, fill.Market, (exit.Size*exit.Price)-(fill.Size*fill.Price))
+		}
+
+		if service != nil && notify.CanSend(level, notify.FILLED) {
+			title := fmt.Sprintf("Jupiter - %s", i18n.Sprintf("Done Sell %s", multiplier.Format(mult)))
+			if stopped {
+				title = fmt.Sprintf("Jupiter - %s", i18n.Sprintf("Done Sell %s", multiplier.Format(stop)))
+			}
+			if err := service.SendMessage(exit, title, model.ALWAYS); err != nil {
+				log.Printf("[ERROR] %v", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (self *Jupiter) Sell(
+	strategy model.Strategy,
+	hold, earn model.Markets,
+	sandbox, tweet, debug bool,
+	success model.OnSuccess,
+) error {
+	if strategy == model.STRATEGY_STANDARD || strategy == model.STRATEGY_STOP_LOSS {
+		// we are OK
+	} else {
+		return errors.New("strategy not implemented")
+	}
+
+	keypair, err := self.getKeypair()
+	if err != nil {
+		return err
+	}
+
+	service, err := notify.New().Init(flag.Interactive(), true)
+	if err != nil {
+		return err
+	}
+
+	client := exchange.New(self.ExchangeInfo.REST.URI, self.getRPC(), keypair)
+
+	if _, err = self.getTokens(client, true); err != nil {
+		return err
+	}
+
+	if err = success(service); err != nil {
+		return err
+	}
+
+	for {
+		// read the dynamic settings
+		var (
+			level int64 = notify.LEVEL_DEFAULT
+			mult  multiplier.Mult
+			stop  multiplier.Mult
+		)
+		if level, err = notify.Level(); err != nil {
+			self.error(err, level, service)
+		} else if mult, err = multiplier.Get(multiplier.FIVE_PERCENT); err != nil {
+			self.error(err, level, service)
+		} else if stop, err = multiplier.Stop(); err != nil {
+			self.error(err, level, service)
+		} else
+		// look for bought tokens that reached their target (or their stop), then swap them back.
+		if err = self.sell(client, strategy, mult, stop, hold, service, level); err != nil {
+			self.error(err, level, service)
+		}
+		beat(self.GetInfo().Name, err)
+		time.Sleep(time.Minute)
+	}
+}
+
+func (self *Jupiter) Order(
+	client interface{},
+	side model.OrderSide,
+	market string,
+	size float64,
+	price float64,
+	kind model.OrderType,
+	metadata string,
+) (oid []byte, raw []byte, err error) {
+	if raw, ok := observeOrder(self, client, side, market, size, price, kind); ok {
+		return nil, raw, nil
+	}
+
+	if err := CheckEquityShare(self, client, side, market, size, price); err != nil {
+		return nil, nil, err
+	}
+
+	jupiterClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, nil, errors.New("invalid argument: client")
+	}
+
+	// there are no limit orders on a DEX. a limit is a bound on the price we are willing to swap at.
+	limit := price
+	if kind == model.MARKET {
+		limit = 0
+	}
+
+	fill, err := self.swap(jupiterClient, side, market, size, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if raw, err = json.Marshal(fill); err != nil {
+		return nil, nil, errors.Wrap(err, 1)
+	}
+
+	return []byte(fill.Signature), raw, nil
+}
+
+func (self *Jupiter) GetStops() model.Stops {
+	return model.Stops{Strategy: true}
+}
+
+func (self *Jupiter) StopLoss(client interface{}, market string, size float64, price float64, kind model.OrderType, metadata string) ([]byte, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (self *Jupiter) OCO(client interface{}, market string, size float64, price, stop float64, metadata string) ([]byte, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (self *Jupiter) GetClosed(client interface{}, market string) (model.Orders, error) {
+	journal, err := readJupiterJournal()
+	if err != nil {
+		return nil, err
+	}
+
+	var output model.Orders
+	for _, fill := range journal {
+		if fill.Market == market {
+			output = append(output, model.Order{
+				Side:       fill.Side,
+				Status:     model.FILLED,
+				Market:     market,
+				Size:       fill.Size,
+				Price:      fill.Price,
+				CreatedAt:  fill.CreatedAt,
+				ClosedAt:   model.TimePtr(fill.CreatedAt),
+				FilledSize: fill.Size,
+			})
+		}
+	}
+
+	return output, nil
+}
+
+// swaps are immediate, so there are no opened orders
+func (self *Jupiter) GetOpened(client interface{}, market string) (model.Orders, error) {
+	return nil, nil
+}
+
+// there is no order book on a DEX, so we return one (synthetic) entry at the current price.
+func (self *Jupiter) GetBook(client interface{}, market string, side model.BookSide) (interface{}, error) {
+	ticker, err := self.GetTicker(client, market)
+	if err != nil {
+		return nil, err
+	}
+	return model.Book{model.Buy{Market: market, Price: ticker, Size: 0}}, nil
+}
+
+func (self *Jupiter) Aggregate(client, book interface{}, market string, agg float64) (model.Book, error) {
+	out, ok := book.(model.Book)
+	if !ok {
+		return nil, errors.New("invalid argument: book")
+	}
+	return out, nil
+}
+
+func (self *Jupiter) GetTicker(client interface{}, market string) (float64, error) {
+	jupiterClient, ok := client.(*exchange.Client)
+	if !ok {
+		return 0, errors.New("invalid argument: client")
+	}
+
+	base, quote, err := self.getPair(jupiterClient, market)
+	if err != nil {
+		return 0, err
+	}
+
+	price, err := jupiterClient.Price(base.Address, quote.Address)
+	if err != nil {
+		return 0, errors.Wrap(err, 1)
+	}
+
+	return price, nil
+}
+
+func (self *Jupiter) GetTickers(client interface{}) (map[string]float64, error) {
+	return getTickers(self, client)
+}
+
+// jupiter does not publish a 24h high, low or volume, so we return the current price.
+func (self *Jupiter) Get24h(client interface{}, market string) (*model.Stats, error) {
+	ticker, err := self.GetTicker(client, market)
+	if err != nil {
+		return nil, err
+	}
+	return &model.Stats{
+		Market:    market,
+		High:      ticker,
+		Low:       ticker,
+		BtcVolume: 0,
+		Last:      ticker,
+	}, nil
+}
+
+func (self *Jupiter) Get24hAll(client interface{}) (map[string]model.Stats, error) {
+	return get24hAll(self, client)
+}
+
+// prices have no tick size, so we keep (at least) 4 significant digits.
+func (self *Jupiter) GetPricePrec(client interface{}, market string) (int, error) {
+	ticker, err := self.GetTicker(client, market)
+	if err != nil {
+		return 8, err
+	}
+	if ticker <= 0 {
+		return 8, nil
+	}
+	return int(math.Max(2, math.Ceil(-math.Log10(ticker))+4)), nil
+}
+
+func (self *Jupiter) GetSizePrec(client interface{}, market string) (int, error) {
+	jupiterClient, ok := client.(*exchange.Client)
+	if !ok {
+		return 0, errors.New("invalid argument: client")
+	}
+
+	base, _, err := self.getPair(jupiterClient, market)
+	if err != nil {
+		return 0, err
+	}
+
+	return base.Decimals, nil
+}
+
+func (self *Jupiter) GetMaxSize(client interface{}, base, quote string, hold, earn bool, def float64, mult multiplier.Mult) float64 {
+	return model.GetSizeMax(hold, earn, def, mult, func() int {
+		prec, err := self.GetSizePrec(client, self.FormatMarket(base, quote))
+		if err != nil {
+			return 0
+		}
+		return prec
+	})
+}
+
+// swaps are immediate, so there is nothing to cancel
+func (self *Jupiter) Cancel(client interface{}, market string, side model.OrderSide) error {
+	if observeCancel(self, market, side) {
+		return nil
+	}
+
+	return nil
+}
+
+func (self *Jupiter) CancelOrder(client interface{}, market, id string) error {
+	return errors.New("not implemented")
+}
+
+// a swap is filled or it is not, so we find it in our journal or we do not.
+func (self *Jupiter) GetOrder(client interface{}, market, id string) (*model.Order, error) {
+	journal, err := readJupiterJournal()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, fill := range journal {
+		if fill.Signature == id {
+			return &model.Order{
+				ID:         id,
+				Side:       fill.Side,
+				Status:     model.FILLED,
+				Market:     fill.Market,
+				Size:       fill.Size,
+				Price:      fill.Price,
+				CreatedAt:  fill.CreatedAt,
+				ClosedAt:   model.TimePtr(fill.CreatedAt),
+				FilledSize: fill.Size,
+			}, nil
+		}
+	}
+
+	return nil, errors.Errorf("order %s not found", id)
+}
+
+// GetFees returns zero, because the aggregator does not charge a fee. you pay for gas (per swap) only.
+func (self *Jupiter) GetFees(client interface{}, market string) (*model.Fees, error) {
+	return &model.Fees{}, nil
+}
+
+func (self *Jupiter) Buy(client interface{}, cancel bool, market string, calls model.Calls, deviation float64, kind model.OrderType) error {
+	if observeBuy(self, client, cancel, market, calls, kind) {
+		return nil
+	}
+
+	jupiterClient, ok := client.(*exchange.Client)
+	if !ok {
+		return errors.New("invalid argument: client")
+	}
+
+	prec, err := self.GetSizePrec(client, market)
+	if err != nil {
+		return err
+	}
+
+	for _, call := range calls {
+		if !call.Skip {
+			limit := call.Price
+			if kind == model.MARKET {
+				limit = 0
+			}
+			if _, err := self.swap(jupiterClient, model.BUY, market, precision.Floor(call.Size, prec), limit); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (self *Jupiter) IsLeveragedToken(name string) bool {
+	return false
+}
+
+func (self *Jupiter) HasAlgoOrder(client interface{}, market string) (bool, error) {
+	return false, nil
+}
+
+func newJupiter() model.Exchange {
+	return &Jupiter{
+		ExchangeInfo: &model.ExchangeInfo{
+			Code: "JUP",
+			Name: "Jupiter",
+			URL:  "https://jup.ag",
+			REST: model.Endpoint{
+				URI: exchange.API_BASE,
+			},
+		},
+	}
+}
@@ -0,0 +1,56 @@
+package exchanges
+
+import (
+	"log"
+	"sync"
+
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/model"
+)
+
+// the bot never withdraws. an API key that can withdraw is a key that can empty your account when it leaks, so we
+// check (once per exchange, where the API tells us) that yours cannot, and we warn you if it can.
+
+var (
+	keysMutex     sync.Mutex
+	keysVerified  = make(map[string]bool) // exchange code -> verified
+	keysVerifying = true
+)
+
+// SetVerifyKeys(false) stops GetClient from verifying your API key, for the commands that report on it themselves
+func SetVerifyKeys(value bool) {
+	keysMutex.Lock()
+	defer keysMutex.Unlock()
+	keysVerifying = value
+}
+
+// verifyKey warns you if your API key can withdraw, unless you have included --allow-withdraw
+func verifyKey(exchange model.Exchange, client interface{}) {
+	reader, ok := exchange.(model.KeyReader)
+	if !ok || flag.AllowWithdraw() {
+		return
+	}
+
+	keysMutex.Lock()
+	defer keysMutex.Unlock()
+
+	code := exchange.GetInfo().Code
+	if !keysVerifying || keysVerified[code] {
+		return
+	}
+	keysVerified[code] = true
+
+	permissions, err := reader.GetKeyPermissions(client)
+	if err != nil {
+		log.Printf("[WARN] Cannot verify the permissions of your %s API key: %v\n", exchange.GetInfo().Name, err)
+		return
+	}
+
+	if permissions.Withdraw {
+		log.Println("[WARN] ****************************************************************************")
+		log.Printf("[WARN] Your %s API key can WITHDRAW. The bot never withdraws, so if this key leaks,\n", exchange.GetInfo().Name)
+		log.Println("[WARN] somebody can empty your account for no good reason. Please create a new key")
+		log.Println("[WARN] with the withdraw permission disabled, or include --allow-withdraw if you must.")
+		log.Println("[WARN] ****************************************************************************")
+	}
+}
@@ -6,18 +6,20 @@ import (
 	"fmt"
 	"log"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
-	filemutex "github.com/alexflint/go-filemutex"
 	"github.com/svanas/nefertiti/aggregation"
 	"github.com/svanas/nefertiti/errors"
 	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/i18n"
 	exchange "github.com/svanas/nefertiti/kucoin"
 	"github.com/svanas/nefertiti/model"
 	"github.com/svanas/nefertiti/multiplier"
 	"github.com/svanas/nefertiti/notify"
+	"github.com/svanas/nefertiti/override"
 	"github.com/svanas/nefertiti/precision"
 	"github.com/svanas/nefertiti/pricing"
 	"github.com/svanas/nefertiti/session"
@@ -25,7 +27,7 @@ import (
 )
 
 var (
-	kucoinMutex *filemutex.FileMutex
+	kucoinMutex *session.Mutex
 )
 
 const (
@@ -38,7 +40,7 @@ func init() {
 		var err error
 
 		if kucoinMutex == nil {
-			if kucoinMutex, err = filemutex.New(session.GetSessionFile(kucoinSessionLock)); err != nil {
+			if kucoinMutex, err = session.NewMutex(session.Keyed(kucoinSessionLock)); err != nil {
 				return err
 			}
 		}
@@ -48,7 +50,7 @@ func init() {
 		}
 
 		var lastRequest *time.Time
-		if lastRequest, err = session.GetLastRequest(kucoinSessionFile); err != nil {
+		if lastRequest, err = session.GetLastRequest(session.Keyed(kucoinSessionFile)); err != nil {
 			return err
 		}
 
@@ -73,7 +75,10 @@ func init() {
 		defer func() {
 			kucoinMutex.Unlock()
 		}()
-		session.SetLastRequest(kucoinSessionFile, time.Now())
+		session.SetLastRequest(session.Keyed(kucoinSessionFile), time.Now())
+	}
+	exchange.OnRateLimit = func(path string) {
+		recordRateLimit(session.Keyed("kucoin"), path)
 	}
 }
 
@@ -116,7 +121,7 @@ func (self *Kucoin) error(err error, level int64, service model.Notify) {
 
 	if service != nil {
 		if notify.CanSend(level, notify.ERROR) {
-			err := service.SendMessage(msg, "Kucoin - ERROR", model.ONCE_PER_MINUTE)
+			err := notify.SendError(service, err, msg, "Kucoin - ERROR")
 			if err != nil {
 				log.Printf("[ERROR] %v", err)
 			}
@@ -147,6 +152,113 @@ func (self *Kucoin) getAvailableBalance(client *exchange.ApiService, curr string
 	return out, nil
 }
 
+func (self *Kucoin) GetBalances(client interface{}) (map[string]float64, error) {
+	service, ok := client.(*exchange.ApiService)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+	var (
+		err      error
+		resp     *exchange.ApiResponse
+		accounts exchange.AccountsModel
+	)
+	if resp, err = service.Accounts("", "trade"); err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+	if err = resp.ReadData(&accounts); err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+	out := make(map[string]float64)
+	for _, account := range accounts {
+		var available float64
+		if available, err = strconv.ParseFloat(account.Available, 64); err != nil {
+			return nil, errors.Wrap(err, 1)
+		}
+		out[account.Currency] += available
+	}
+	return out, nil
+}
+
+func (self *Kucoin) GetHoldings(client interface{}) (map[string]model.Holding, error) {
+	service, ok := client.(*exchange.ApiService)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+	var (
+		err      error
+		resp     *exchange.ApiResponse
+		accounts exchange.AccountsModel
+	)
+	if resp, err = service.Accounts("", "trade"); err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+	if err = resp.ReadData(&accounts); err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+	out := make(map[string]model.Holding)
+	for _, account := range accounts {
+		var holding model.Holding
+		if holding.Available, err = strconv.ParseFloat(account.Available, 64); err != nil {
+			return nil, errors.Wrap(err, 1)
+		}
+		if holding.Hold, err = strconv.ParseFloat(account.Holds, 64); err != nil {
+			return nil, errors.Wrap(err, 1)
+		}
+		entry := out[account.Currency]
+		entry.Available += holding.Available
+		entry.Hold += holding.Hold
+		out[account.Currency] = entry
+	}
+	return out, nil
+}
+
+// GetAccountEvents returns the withdrawals that have been created since a moment in time
+func (self *Kucoin) GetAccountEvents(client interface{}, since time.Time) ([]model.AccountEvent, error) {
+	service, ok := client.(*exchange.ApiService)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	const pageSize = 100
+
+	var (
+		err  error
+		curr int64 = 1
+		out  []model.AccountEvent
+	)
+	for {
+		var resp *exchange.ApiResponse
+		if resp, err = service.Withdrawals(map[string]string{
+			"startAt": strconv.FormatInt(since.Unix()*1000, 10),
+		}, &exchange.PaginationParam{CurrentPage: curr, PageSize: pageSize}); err != nil {
+			return nil, errors.Wrap(err, 1)
+		}
+		var (
+			page        *exchange.PaginationModel
+			withdrawals exchange.WithdrawalsModel
+		)
+		if page, err = resp.ReadPaginationData(&withdrawals); err != nil {
+			return nil, errors.Wrap(err, 1)
+		}
+		for _, withdrawal := range withdrawals {
+			out = append(out, model.AccountEvent{
+				Id:     withdrawal.Id,
+				Time:   withdrawal.ParseCreatedAt(),
+				Kind:   "withdrawal",
+				Asset:  withdrawal.Currency,
+				Amount: withdrawal.ParseAmount(),
+				Detail: withdrawal.Address,
+			})
+		}
+		if page.CurrentPage >= page.TotalPage {
+			break
+		}
+		curr++
+	}
+
+	return out, nil
+}
+
 func (self *Kucoin) getSymbol(client *exchange.ApiService, name string) (*exchange.SymbolModel, error) {
 	cached := true
 	for {
@@ -185,6 +297,22 @@ func (self *Kucoin) getSymbols(client *exchange.ApiService, cached bool) (exchan
 }
 
 // the minimum order quantity requried to place an order.
+// minSize returns the size, bumped to respect the baseMinSize
+func (self *Kucoin) minSize(client interface{}, market string, size, price float64) (float64, error) {
+	kucoin, ok := client.(*exchange.ApiService)
+	if !ok {
+		return size, errors.New("invalid argument: client")
+	}
+	min, err := self.getMinSize(kucoin, market)
+	if err != nil {
+		return size, err
+	}
+	if size < min {
+		return min, nil
+	}
+	return size, nil
+}
+
 func (self *Kucoin) getMinSize(client *exchange.ApiService, market string) (float64, error) {
 	symbol, err := self.getSymbol(client, market)
 	if err != nil {
@@ -307,14 +435,50 @@ func (self *Kucoin) GetClient(permission model.Permission, sandbox bool) (interf
 		return nil, err
 	}
 
-	return exchange.NewApiService(
+	client := exchange.NewApiService(
 		exchange.ApiBaseURIOption(self.baseURI(sandbox)),
 		exchange.ApiKeyOption(apiKey),
 		exchange.ApiSecretOption(apiSecret),
 		exchange.ApiPassPhraseOption(apiPassphrase),
 		exchange.ApiPartnerIdOption(kucoinPartnerID),
 		exchange.ApiPartnerSecretOption(kucoinPartnerSecret),
-	), nil
+	)
+	if !sandbox {
+		verifyKey(self, client)
+	}
+
+	return client, nil
+}
+
+func (self *Kucoin) GetKeyPermissions(client interface{}) (*model.KeyPermissions, error) {
+	service, ok := client.(*exchange.ApiService)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+	var (
+		err  error
+		resp *exchange.ApiResponse
+		key  exchange.ApiKeyModel
+	)
+	if resp, err = service.ApiKey(); err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+	if err = resp.ReadData(&key); err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+	// every KuCoin key has the General permission, which is read-only
+	out := &model.KeyPermissions{Read: true}
+	for _, permission := range strings.Split(key.Permission, ",") {
+		switch strings.ToLower(strings.TrimSpace(permission)) {
+		case "spot", "margin":
+			out.Trade = true
+		case "withdrawal":
+			out.Withdraw = true
+		case "futures":
+			out.Futures = true
+		}
+	}
+	return out, nil
 }
 
 func (self *Kucoin) GetMarkets(cached, sandbox bool, blacklist []string) ([]model.Market, error) {
@@ -349,8 +513,11 @@ func (self *Kucoin) GetMarkets(cached, sandbox bool, blacklist []string) ([]mode
 	return out, nil
 }
 
+// how Kucoin spells its markets
+var kucoinSymbol = model.SymbolFormat{Separator: "-"}
+
 func (self *Kucoin) FormatMarket(base, quote string) string {
-	return strings.ToUpper(fmt.Sprintf("%s-%s", base, quote))
+	return model.NewMarketSymbol(base, quote).Format(kucoinSymbol)
 }
 
 // listens to the filled orders, look for newly filled orders, automatically place new sell orders.
@@ -422,7 +589,7 @@ func (self *Kucoin) sell(
 
 	// look for newly filled orders
 	for _, order := range new {
-		if old.IndexOfOrderId(order.OrderId) == -1 {
+		if old.IndexOfOrderId(order.OrderId) == -1 && processOnce(self, order.OrderId) {
 			side := model.NewOrderSide(order.Side)
 
 			var data []byte
@@ -460,11 +627,16 @@ func (self *Kucoin) sell(
 				}
 			}
 
+			// has a take profit been filled? then add the profit to our journal
+			if side == model.SELL && order.Stop != "loss" {
+				recordTakeProfit(self, client, order.Symbol, order.ParseSize()*order.ParsePrice(), mult)
+			}
+
 			// send notification(s)
 			if side != model.ORDER_SIDE_NONE {
 				if notify.CanSend(level, notify.FILLED) {
 					if service != nil {
-						title := fmt.Sprintf("Kucoin - Done %s", model.FormatOrderSide(side))
+						title := fmt.Sprintf("Kucoin - %s", i18n.Sprintf("Done %s", model.FormatOrderSide(side)))
 						if side == model.SELL {
 							if strategy == model.STRATEGY_STOP_LOSS && order.Stop == "loss" {
 								title = fmt.Sprintf("%s %s", title, multiplier.Format(stop))
@@ -475,6 +647,7 @@ func (self *Kucoin) sell(
 						if err = service.SendMessage(order, title, model.ALWAYS); err != nil {
 							log.Printf("[ERROR] %v", err)
 						}
+						sendChart(self, client, service, strategy, order.Symbol, side, order.ParsePrice(), mult, stop, title)
 					}
 					if twitter != nil {
 						notify.Tweet(twitter, fmt.Sprintf("Done %s. %s priced at %s #Kucoin", model.FormatOrderSide(side), model.TweetMarket(markets, order.Symbol), order.Price))
@@ -486,6 +659,9 @@ func (self *Kucoin) sell(
 
 	// has a stop loss been filled? then place a buy order double the order size *** if --dca is included ***
 	if strategy == model.STRATEGY_STOP_LOSS {
+		for symbol, order := range stopped {
+			recordLoss(self, symbol, order.Size*avg(order), stop, service)
+		}
 		if flag.Dca() {
 			for symbol, stop := range stopped {
 				var opened exchange.OrdersModel
@@ -498,14 +674,20 @@ func (self *Kucoin) sell(
 					if opened.Find(&cb) > -1 {
 						log.Printf("[INFO] Not re-buying %s because you have at least one active (non-filled) stop-loss order.\n", symbol)
 					} else {
-						prec := 0
-						size := 2.2 * stop.Size
-						if prec, err = self.GetSizePrec(client, symbol); err == nil {
-							_, _, err = self.Order(client,
-								model.BUY, symbol,
-								precision.Round(size, prec),
-								0, model.MARKET, "",
-							)
+						var (
+							prec int
+							size float64
+						)
+						if size, err = dcaSize(self, client, symbol, stop.Size); err == nil && size > 0 {
+							if prec, err = self.GetSizePrec(client, symbol); err == nil {
+								if _, _, err = self.Order(client,
+									model.BUY, symbol,
+									precision.Round(size, prec),
+									0, model.MARKET, "",
+								); err == nil {
+									recordDca(self, client, symbol, size)
+								}
+							}
 						}
 						if err != nil {
 							self.error(err, level, service)
@@ -540,13 +722,13 @@ func (self *Kucoin) sell(
 		)
 		base, quote, err = model.ParseMarket(markets, symbol)
 		if err == nil {
-			amount = self.GetMaxSize(client, base, quote, hold.HasMarket(symbol), earn.HasMarket(symbol), amount, mult)
+			amount = self.GetMaxSize(client, base, quote, hold.HasMarket(symbol), earn.HasMarket(symbol), amount, override.Mult(symbol, mult))
 			if amount > 0 {
-				var pp int
-				if pp, err = self.GetPricePrec(client, symbol); err == nil {
+				var tick float64
+				if tick, err = self.GetTickSize(client, symbol); err == nil {
 					var ticker float64
 					if ticker, err = self.GetTicker(client, symbol); err == nil {
-						if ticker >= pricing.Multiply(bought, mult, pp) {
+						if ticker >= pricing.MultiplyTick(bought, override.Mult(symbol, mult), tick) {
 							_, _, err = self.Order(client,
 								model.SELL,
 								symbol,
@@ -556,19 +738,22 @@ func (self *Kucoin) sell(
 							)
 						} else {
 							if strategy == model.STRATEGY_STOP_LOSS {
-								_, err = self.StopLoss(client,
-									symbol,
-									amount,
-									pricing.Multiply(bought, stop, pp),
-									model.MARKET,
-									strconv.FormatFloat(bought, 'f', -1, 64),
-								)
+								var exit *model.StopExit
+								if exit, err = model.GetStopExit(); err == nil {
+									_, err = self.StopLoss(client,
+										symbol,
+										amount,
+										pricing.MultiplyTick(bought, override.Stop(symbol, stop), tick),
+										exit.Kind,
+										strconv.FormatFloat(bought, 'f', -1, 64),
+									)
+								}
 							} else {
 								_, _, err = self.Order(client,
 									model.SELL,
 									symbol,
 									amount,
-									pricing.Multiply(bought, mult, pp),
+									pricing.MultiplyTick(bought, override.Mult(symbol, mult), tick),
 									model.LIMIT,
 									strconv.FormatFloat(bought, 'f', -1, 64),
 								)
@@ -648,7 +833,7 @@ func (self *Kucoin) listen(
 				side := model.NewOrderSide(order.Side)
 				if side != model.ORDER_SIDE_NONE {
 					if notify.CanSend(level, notify.OPENED) || (level == notify.LEVEL_DEFAULT && side == model.SELL) {
-						if err = service.SendMessage(order, ("Kucoin - Open " + model.FormatOrderSide(side)), model.ALWAYS); err != nil {
+						if err = service.SendMessage(order, ("Kucoin - " + i18n.Sprintf("Open %s", model.FormatOrderSide(side))), model.ALWAYS); err != nil {
 							log.Printf("[ERROR] %v", err)
 						}
 					}
@@ -754,10 +939,10 @@ func (self *Kucoin) Sell(
 						}
 					}
 					if ticker > 0 {
-						var prec int
-						if prec, err = self.GetPricePrec(client, order.Symbol); err == nil {
-							bought := order.ParseStopPrice() / float64(stop)
-							if ticker >= pricing.Multiply(bought, mult, prec) {
+						var tick float64
+						if tick, err = self.GetTickSize(client, order.Symbol); err == nil {
+							bought := order.ParseStopPrice() / float64(override.Stop(order.Symbol, stop))
+							if ticker >= pricing.MultiplyTick(bought, override.Mult(order.Symbol, mult), tick) {
 								if _, err = client.CancelStopOrder(order.Id); err == nil {
 									_, _, err = self.Order(client,
 										model.SELL,
@@ -780,6 +965,9 @@ func (self *Kucoin) Sell(
 				}
 			}
 		}
+		// every so often, look for withdrawals that we didn't make
+		watchAccount(self, client, service)
+		beat(self.GetInfo().Name, err)
 	}
 }
 
@@ -792,6 +980,14 @@ func (self *Kucoin) Order(
 	kind model.OrderType,
 	metadata string,
 ) (oid []byte, raw []byte, err error) {
+	if raw, ok := observeOrder(self, client, side, market, size, price, kind); ok {
+		return nil, raw, nil
+	}
+
+	if err := CheckEquityShare(self, client, side, market, size, price); err != nil {
+		return nil, nil, err
+	}
+
 	var (
 		resp  *exchange.ApiResponse
 		order exchange.CreateOrderResultModel
@@ -811,6 +1007,17 @@ func (self *Kucoin) Order(
 	}
 	if kind == model.LIMIT {
 		params["price"] = strconv.FormatFloat(price, 'f', -1, 64)
+		// good-til-time: Kucoin cancels the buy order after --expire=X hours
+		if side == model.BUY {
+			var expire time.Duration
+			if expire, err = flag.Expire(); err != nil {
+				return nil, nil, err
+			}
+			if expire > 0 {
+				params["timeInForce"] = "GTT"
+				params["cancelAfter"] = strconv.FormatInt(int64(expire.Seconds()), 10)
+			}
+		}
 	}
 
 	if resp, err = kucoin.CreateOrder(params); err != nil {
@@ -826,7 +1033,15 @@ func (self *Kucoin) Order(
 	return []byte(order.OrderId), raw, nil
 }
 
+func (self *Kucoin) GetStops() model.Stops {
+	return model.Stops{Strategy: true, Stop: true}
+}
+
 func (self *Kucoin) StopLoss(client interface{}, market string, size float64, price float64, kind model.OrderType, metadata string) ([]byte, error) {
+	if raw, ok := observeStopLoss(self, client, market, size, price); ok {
+		return raw, nil
+	}
+
 	var (
 		err   error
 		out   []byte
@@ -849,7 +1064,17 @@ func (self *Kucoin) StopLoss(client interface{}, market string, size float64, pr
 		"stopPrice": strconv.FormatFloat(price, 'f', -1, 64),
 	}
 	if kind == model.LIMIT {
-		params["price"] = strconv.FormatFloat(price, 'f', -1, 64)
+		var (
+			tick float64
+			exit *model.StopExit
+		)
+		if tick, err = model.GetTickSize(self, client, market); err != nil {
+			return nil, err
+		}
+		if exit, err = model.GetStopExit(); err != nil {
+			return nil, err
+		}
+		params["price"] = strconv.FormatFloat(exit.Limit(price, tick), 'f', -1, 64)
 	}
 
 	if resp, err = kucoin.CreateStopOrder(params); err != nil {
@@ -866,6 +1091,10 @@ func (self *Kucoin) StopLoss(client interface{}, market string, size float64, pr
 }
 
 func (self *Kucoin) OCO(client interface{}, market string, size float64, price, stop float64, metadata string) ([]byte, error) {
+	if raw, ok := observeOCO(self, client, market, size, price, stop); ok {
+		return raw, nil
+	}
+
 	return nil, errors.New("Not implemented")
 }
 
@@ -894,12 +1123,18 @@ func (self *Kucoin) GetClosed(client interface{}, market string) (model.Orders,
 			return nil, errors.Wrap(err, 1)
 		}
 		for _, fill := range fills {
+			fee, _ := strconv.ParseFloat(fill.Fee, 64)
 			out = append(out, model.Order{
-				Side:      model.NewOrderSide(fill.Side),
-				Market:    fill.Symbol,
-				Size:      fill.ParseSize(),
-				Price:     fill.ParsePrice(),
-				CreatedAt: fill.ParseCreatedAt(),
+				Side:       model.NewOrderSide(fill.Side),
+				Status:     model.FILLED,
+				Market:     fill.Symbol,
+				Size:       fill.ParseSize(),
+				Price:      fill.ParsePrice(),
+				CreatedAt:  fill.ParseCreatedAt(),
+				ClosedAt:   model.TimePtr(fill.ParseCreatedAt()),
+				FilledSize: fill.ParseSize(),
+				Fee:        fee,
+				FeeAsset:   fill.FeeCurrency,
 			})
 		}
 		if page.CurrentPage >= page.TotalPage {
@@ -930,7 +1165,9 @@ func (self *Kucoin) GetOpened(client interface{}, market string) (model.Orders,
 
 	for _, order := range orders {
 		out = append(out, model.Order{
+			Status:    model.OPEN,
 			Side:      model.NewOrderSide(order.Side),
+			ID:        order.Id,
 			Market:    order.Symbol,
 			Size:      order.ParseSize(),
 			Price:     order.ParsePrice(),
@@ -976,14 +1213,14 @@ func (self *Kucoin) Aggregate(client, book interface{}, market string, agg float
 		return nil, errors.New("invalid argument: book")
 	}
 
-	prec, err := self.GetPricePrec(client, market)
+	tick, err := self.GetTickSize(client, market)
 	if err != nil {
 		return nil, err
 	}
 
 	var out model.Book
 	for _, e := range bids {
-		price := precision.Round(aggregation.Round(e.Price(), agg), prec)
+		price := precision.RoundTick(aggregation.Round(e.Price(), agg), tick)
 		entry := out.EntryByPrice(price)
 		if entry != nil {
 			entry.Size = entry.Size + e.Size()
@@ -1026,6 +1263,43 @@ func (self *Kucoin) GetTicker(client interface{}, market string) (float64, error
 	return out, nil
 }
 
+func (self *Kucoin) GetTickers(client interface{}) (map[string]float64, error) {
+	var (
+		err  error
+		resp *exchange.ApiResponse
+		data exchange.TickersResponseModel
+	)
+
+	kucoin, ok := client.(*exchange.ApiService)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	if resp, err = kucoin.Tickers(); err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+	if err = resp.ReadData(&data); err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	out := make(map[string]float64)
+	for _, ticker := range data.Tickers {
+		if price, err := strconv.ParseFloat(ticker.Last, 64); err == nil {
+			out[ticker.Symbol] = price
+		}
+	}
+
+	return out, nil
+}
+
+// kucoinOpen derives the open from the last price and the change rate (0.01 = 1%), because KuCoin does not tell us
+func kucoinOpen(last, rate float64) float64 {
+	if last == 0 || rate <= -1 {
+		return 0
+	}
+	return last / (1 + rate)
+}
+
 func (self *Kucoin) Get24h(client interface{}, market string) (*model.Stats, error) {
 	kucoin, ok := client.(*exchange.ApiService)
 	if !ok {
@@ -1055,10 +1329,16 @@ func (self *Kucoin) Get24h(client interface{}, market string) (*model.Stats, err
 		return nil, err
 	}
 
+	last, _ := strconv.ParseFloat(json.Last, 64)
+	rate, _ := strconv.ParseFloat(json.ChangeRate, 64) // 0.01 = 1%
+
 	return &model.Stats{
 		Market: market,
 		High:   high,
 		Low:    low,
+		Open:   kucoinOpen(last, rate),
+		Last:   last,
+		Change: rate * 100,
 		BtcVolume: func() float64 {
 			symbol, err := self.getSymbol(kucoin, market)
 			if err == nil {
@@ -1074,6 +1354,129 @@ func (self *Kucoin) Get24h(client interface{}, market string) (*model.Stats, err
 	}, nil
 }
 
+func (self *Kucoin) Get24hAll(client interface{}) (map[string]model.Stats, error) {
+	var (
+		err  error
+		resp *exchange.ApiResponse
+		data exchange.TickersResponseModel
+	)
+
+	kucoin, ok := client.(*exchange.ApiService)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	if resp, err = kucoin.Tickers(); err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+	if err = resp.ReadData(&data); err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	out := make(map[string]model.Stats)
+	volume := make(map[string]float64) // in quote currency
+	for _, ticker := range data.Tickers {
+		high, _ := strconv.ParseFloat(ticker.High, 64)
+		low, _ := strconv.ParseFloat(ticker.Low, 64)
+		last, _ := strconv.ParseFloat(ticker.Last, 64)
+		rate, _ := strconv.ParseFloat(ticker.ChangeRate, 64) // 0.01 = 1%
+		out[ticker.Symbol] = model.Stats{
+			Market: ticker.Symbol,
+			High:   high,
+			Low:    low,
+			Open:   kucoinOpen(last, rate),
+			Last:   last,
+			Change: rate * 100,
+		}
+		volume[ticker.Symbol], _ = strconv.ParseFloat(ticker.VolValue, 64)
+	}
+
+	if err = setBtcVolume(self, out, volume); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+var kucoinIntervals = map[time.Duration]string{
+	time.Minute:        "1min",
+	3 * time.Minute:    "3min",
+	5 * time.Minute:    "5min",
+	15 * time.Minute:   "15min",
+	30 * time.Minute:   "30min",
+	time.Hour:          "1hour",
+	2 * time.Hour:      "2hour",
+	4 * time.Hour:      "4hour",
+	6 * time.Hour:      "6hour",
+	8 * time.Hour:      "8hour",
+	12 * time.Hour:     "12hour",
+	24 * time.Hour:     "1day",
+	7 * 24 * time.Hour: "1week",
+}
+
+// Kucoin returns at most 1500 candles per request
+const kucoinMaxCandles = 1500
+
+func (self *Kucoin) GetCandles(client interface{}, market string, interval time.Duration, start, end time.Time) (model.Candles, error) {
+	var (
+		err  error
+		resp *exchange.ApiResponse
+		data exchange.KLinesModel
+	)
+
+	kucoin, ok := client.(*exchange.ApiService)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	kind, ok := kucoinIntervals[interval]
+	if !ok {
+		return nil, errors.Errorf("interval %s is not supported", model.FormatInterval(interval))
+	}
+
+	var out model.Candles
+	// the candles are newest first, so we page from start onwards ourselves. an empty page doesn't mean there are
+	// no candles after it, so we keep on going until we have some or we reach the end.
+	for from := start; len(out) == 0 && from.Before(end); from = from.Add(kucoinMaxCandles * interval) {
+		to := from.Add(kucoinMaxCandles * interval)
+		if to.After(end) {
+			to = end
+		}
+		if resp, err = kucoin.KLines(market, kind, from.Unix(), to.Unix()); err != nil {
+			return nil, errors.Wrap(err, 1)
+		}
+		data = nil
+		if err = resp.ReadData(&data); err != nil {
+			return nil, errors.Wrap(err, 1)
+		}
+		for _, line := range data {
+			if line == nil || len(*line) < 6 {
+				continue
+			}
+			sec, err := strconv.ParseInt((*line)[0], 10, 64)
+			if err != nil {
+				return nil, errors.Wrap(err, 1)
+			}
+			candle := model.Candle{Time: time.Unix(sec, 0).UTC()}
+			if candle.Time.Before(from) || !candle.Time.Before(to) {
+				continue
+			}
+			candle.Open, _ = strconv.ParseFloat((*line)[1], 64)
+			candle.Close, _ = strconv.ParseFloat((*line)[2], 64)
+			candle.High, _ = strconv.ParseFloat((*line)[3], 64)
+			candle.Low, _ = strconv.ParseFloat((*line)[4], 64)
+			candle.Volume, _ = strconv.ParseFloat((*line)[5], 64)
+			out = append(out, candle)
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Time.Before(out[j].Time)
+	})
+
+	return out, nil
+}
+
 func (self *Kucoin) GetPricePrec(client interface{}, market string) (int, error) {
 	kucoin, ok := client.(*exchange.ApiService)
 	if !ok {
@@ -1086,6 +1489,22 @@ func (self *Kucoin) GetPricePrec(client interface{}, market string) (int, error)
 	return precision.Parse(symbol.PriceIncrement, 8), nil
 }
 
+func (self *Kucoin) GetTickSize(client interface{}, market string) (float64, error) {
+	kucoin, ok := client.(*exchange.ApiService)
+	if !ok {
+		return 0, errors.New("invalid argument: client")
+	}
+	symbol, err := self.getSymbol(kucoin, market)
+	if err != nil {
+		return 0, err
+	}
+	out, err := strconv.ParseFloat(symbol.PriceIncrement, 64)
+	if err != nil {
+		return 0, errors.Wrap(err, 1)
+	}
+	return out, nil
+}
+
 func (self *Kucoin) GetSizePrec(client interface{}, market string) (int, error) {
 	kucoin, ok := client.(*exchange.ApiService)
 	if !ok {
@@ -1114,6 +1533,10 @@ func (self *Kucoin) GetMaxSize(client interface{}, base, quote string, hold, ear
 }
 
 func (self *Kucoin) Cancel(client interface{}, market string, side model.OrderSide) error {
+	if observeCancel(self, market, side) {
+		return nil
+	}
+
 	var (
 		err    error
 		orders exchange.OrdersModel
@@ -1141,7 +1564,102 @@ func (self *Kucoin) Cancel(client interface{}, market string, side model.OrderSi
 	return nil
 }
 
+func (self *Kucoin) CancelOrder(client interface{}, market, id string) error {
+	if observeCancelOrder(self, market, id) {
+		return nil
+	}
+
+	kucoin, ok := client.(*exchange.ApiService)
+	if !ok {
+		return errors.New("invalid argument: client")
+	}
+
+	if _, err := kucoin.CancelOrder(id); err != nil {
+		return errors.Wrap(err, 1)
+	}
+
+	return nil
+}
+
+func (self *Kucoin) GetOrder(client interface{}, market, id string) (*model.Order, error) {
+	kucoin, ok := client.(*exchange.ApiService)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	var (
+		err   error
+		resp  *exchange.ApiResponse
+		order exchange.OrderModel
+	)
+	if resp, err = kucoin.Order(id); err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+	if err = resp.ReadData(&order); err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	filled, _ := strconv.ParseFloat(order.DealSize, 64)
+	fee, _ := strconv.ParseFloat(order.Fee, 64)
+
+	out := &model.Order{
+		ID:         id,
+		Side:       model.NewOrderSide(order.Side),
+		Status:     model.OPEN,
+		Market:     order.Symbol,
+		Size:       order.ParseSize(),
+		Price:      order.ParsePrice(),
+		CreatedAt:  order.ParseCreatedAt(),
+		FilledSize: filled,
+		Fee:        fee,
+		FeeAsset:   order.FeeCurrency,
+	}
+	if !order.IsActive {
+		if order.CancelExist {
+			out.Status = model.CANCELLED
+		} else {
+			out.Status = model.FILLED
+		}
+	}
+
+	return out, nil
+}
+
+func (self *Kucoin) GetFees(client interface{}, market string) (*model.Fees, error) {
+	kucoin, ok := client.(*exchange.ApiService)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	var (
+		err  error
+		resp *exchange.ApiResponse
+		fees []exchange.TradeFeeModel
+	)
+	if resp, err = kucoin.TradeFees(market); err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+	if err = resp.ReadData(&fees); err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	for _, fee := range fees {
+		if fee.Symbol == market {
+			return &model.Fees{
+				Maker: fee.ParseMakerFeeRate(),
+				Taker: fee.ParseTakerFeeRate(),
+			}, nil
+		}
+	}
+
+	return nil, errors.Errorf("symbol %s does not exist", market)
+}
+
 func (self *Kucoin) Buy(client interface{}, cancel bool, market string, calls model.Calls, deviation float64, kind model.OrderType) error {
+	if observeBuy(self, client, cancel, market, calls, kind) {
+		return nil
+	}
+
 	var err error
 
 	kucoin, ok := client.(*exchange.ApiService)
@@ -1162,8 +1680,8 @@ func (self *Kucoin) Buy(client interface{}, cancel bool, market string, calls mo
 		}
 		for _, order := range orders {
 			// do not cancel orders that we're about to re-place
-			index := calls.IndexByPrice(order.ParsePrice())
-			if index > -1 && order.ParseSize() == calls[index].Size {
+			index := calls.IndexByPriceSize(order.ParsePrice(), order.ParseSize())
+			if index > -1 {
 				calls[index].Skip = true
 			} else {
 				if _, err = kucoin.CancelOrder(order.Id); err != nil {
@@ -1176,16 +1694,10 @@ func (self *Kucoin) Buy(client interface{}, cancel bool, market string, calls mo
 	// open the top X buy orders
 	for _, call := range calls {
 		if !call.Skip {
-			qty := call.Size
-
-			// respect the baseMinSize
-			var min float64
-			if min, err = self.getMinSize(kucoin, market); err != nil {
+			var qty float64
+			if qty, err = self.minSize(client, market, call.Size, call.Price); err != nil {
 				return err
 			}
-			if qty < min {
-				qty = min
-			}
 
 			limit := call.Price
 			if deviation != 1.0 {
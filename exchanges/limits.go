@@ -0,0 +1,261 @@
+package exchanges
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"sort"
+	"time"
+
+	coinbasepro "github.com/svanas/go-coinbasepro"
+	cryptodotcom "github.com/svanas/go-crypto-dot-com"
+	"github.com/svanas/nefertiti/binance"
+	"github.com/svanas/nefertiti/bitmex"
+	"github.com/svanas/nefertiti/bitstamp"
+	"github.com/svanas/nefertiti/bittrex"
+	"github.com/svanas/nefertiti/cexio"
+	"github.com/svanas/nefertiti/coinex"
+	"github.com/svanas/nefertiti/deribit"
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/hitbtc"
+	"github.com/svanas/nefertiti/huobi"
+	"github.com/svanas/nefertiti/jupiter"
+	"github.com/svanas/nefertiti/kucoin"
+	"github.com/svanas/nefertiti/luno"
+	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/oneinch"
+	"github.com/svanas/nefertiti/session"
+	"github.com/svanas/nefertiti/upbit"
+)
+
+type (
+	Endpoint struct {
+		Path              string     `json:"path"`
+		Intensity         int        `json:"intensity,omitempty"` // learned intensity, if the exchange has one
+		RequestsPerSecond float64    `json:"requests_per_second"`
+		LastRateLimited   *time.Time `json:"last_rate_limited,omitempty"`
+	}
+	RateLimit struct {
+		Path string    `json:"path,omitempty"`
+		Time time.Time `json:"time"`
+	}
+	Limits struct {
+		Exchange          string     `json:"exchange"`
+		RequestsPerSecond float64    `json:"requests_per_second"`   // effective rate for endpoints that are not listed below
+		Weighted          bool       `json:"weighted,omitempty"`    // if true, then requests_per_second is weight per second
+		UsedWeight        int        `json:"used_weight,omitempty"` // the weight we have used during the current minute
+		Cooldown          bool       `json:"cooldown"`              // if true, then our next request will cool down first
+		Endpoints         []Endpoint `json:"endpoints,omitempty"`
+		RateLimited       int        `json:"rate_limited"`                // number of rate limit errors during the last 24 hours
+		LastRateLimited   *time.Time `json:"last_rate_limited,omitempty"` // the last time we ran into a rate limit error
+	}
+)
+
+// we keep the rate limit errors for this long
+const rateLimitsWindow = 24 * time.Hour
+
+func rateLimitsFile(name string) string {
+	return session.GetSessionFile(name + ".ratelimits.json")
+}
+
+func readRateLimits(name string) ([]RateLimit, error) {
+	var out []RateLimit
+	data, err := session.ReadFile(rateLimitsFile(name))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, errors.Wrap(err, 1)
+		}
+		return nil, nil
+	}
+	if err = json.Unmarshal(data, &out); err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+	// prune the rate limit errors that fell out of our window
+	i := 0
+	for _, limit := range out {
+		if time.Since(limit.Time) < rateLimitsWindow {
+			out[i] = limit
+			i++
+		}
+	}
+	return out[:i], nil
+}
+
+// recordRateLimit adds a rate limit error to the session file with this name
+func recordRateLimit(name, path string) {
+	if err := func() error {
+		limits, err := readRateLimits(name)
+		if err != nil {
+			return err
+		}
+		limits = append(limits, RateLimit{
+			Path: path,
+			Time: time.Now(),
+		})
+		data, err := json.Marshal(limits)
+		if err != nil {
+			return errors.Wrap(err, 1)
+		}
+		return session.WriteFile(rateLimitsFile(name), data)
+	}(); err != nil {
+		log.Printf("[ERROR] %v", err)
+	}
+}
+
+func readBittrexSessionInfo() (*BittrexSessionInfo, error) {
+	var out BittrexSessionInfo
+	data, err := session.ReadFile(session.GetSessionFile(session.Keyed(bittrexSessionInfo)))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, errors.Wrap(err, 1)
+		}
+		out.Calls = bittrex.Calls
+	} else {
+		if err = json.Unmarshal(data, &out); err != nil {
+			return nil, errors.Wrap(err, 1)
+		}
+	}
+	return &out, nil
+}
+
+func readCryptoDotComSessionInfo() (*CryptoDotComSessionInfo, error) {
+	var out CryptoDotComSessionInfo
+	data, err := session.ReadFile(session.GetSessionFile(session.Keyed(cryptoDotComSessionInfo)))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, errors.Wrap(err, 1)
+		}
+	} else {
+		if err = json.Unmarshal(data, &out); err != nil {
+			return nil, errors.Wrap(err, 1)
+		}
+	}
+	return &out, nil
+}
+
+// GetLimits returns the current state of our throttle for this exchange
+func GetLimits(exchange model.Exchange) (*Limits, error) {
+	out := Limits{
+		Exchange: exchange.GetInfo().Name,
+	}
+
+	var name string // the name of our rate limit errors session file
+	switch exchange.(type) {
+	case *Binance:
+		client, err := exchange.GetClient(model.PUBLIC, flag.Sandbox())
+		if err != nil {
+			return nil, err
+		}
+		if out.RequestsPerSecond, err = binance.GetWeightPerSecond(client.(*binance.Client)); err != nil {
+			return nil, err
+		}
+		out.Weighted = true
+		out.UsedWeight, _ = binance.GetUsedWeight(client.(*binance.Client))
+		name = binanceSessionName(client.(*binance.Client))
+	case *BitMEX:
+		out.RequestsPerSecond = bitmex.RequestsPerSecond
+	case *Bitstamp:
+		out.RequestsPerSecond = bitstamp.RequestsPerSecond
+	case *Bittrex:
+		info, err := readBittrexSessionInfo()
+		if err != nil {
+			return nil, err
+		}
+		out.RequestsPerSecond = bittrex.RequestsPerSecond(bittrex.INTENSITY_LOW)
+		out.Cooldown = info.Cooldown
+		bittrex.Decay(info.Calls) // our next request would decay these anyway
+		for _, call := range info.Calls {
+			endpoint := Endpoint{
+				Path:              call.Path,
+				Intensity:         call.Intensity,
+				RequestsPerSecond: bittrex.RequestsPerSecond(call.Intensity),
+			}
+			if !call.LastRateLimit.IsZero() {
+				last := call.LastRateLimit
+				endpoint.LastRateLimited = &last
+			}
+			out.Endpoints = append(out.Endpoints, endpoint)
+		}
+		name = session.Keyed("bittrex") // metered per API key
+	case *CexIo:
+		out.RequestsPerSecond = cexio.RequestsPerSecond
+	case *CoinEx:
+		out.RequestsPerSecond = coinex.RequestsPerSecond
+	case *CryptoDotCom:
+		info, err := readCryptoDotComSessionInfo()
+		if err != nil {
+			return nil, err
+		}
+		out.RequestsPerSecond = cryptodotcom.RequestsPerSecond[cryptodotcom.RATE_LIMIT_NORMAL]
+		out.Cooldown = info.Cooldown
+		name = session.Keyed("crypto.com") // metered per API key
+	case *Deribit:
+		out.RequestsPerSecond = deribit.RPS_NON_MATCHING
+		out.Endpoints = []Endpoint{{
+			Path:              "/private/buy",
+			RequestsPerSecond: deribit.RPS_MATCHING,
+		}, {
+			Path:              "/private/sell",
+			RequestsPerSecond: deribit.RPS_MATCHING,
+		}}
+	case *Gdax:
+		out.RequestsPerSecond = coinbasepro.RequestsPerSecond
+	case *HitBTC:
+		out.RequestsPerSecond = hitbtc.RequestsPerSecond
+	case *Huobi:
+		out.RequestsPerSecond = huobi.RequestsPerSecond
+	case *Jupiter:
+		out.RequestsPerSecond = jupiter.RequestsPerSecond
+	case *Kucoin:
+		out.RequestsPerSecond = kucoin.RequestsPerSecond
+		name = session.Keyed("kucoin") // metered per API key
+	case *Luno:
+		out.RequestsPerSecond = luno.RequestsPerSecond
+	case *OneInch:
+		out.RequestsPerSecond = oneinch.RequestsPerSecond
+	case *Upbit:
+		out.RequestsPerSecond = upbit.RPS_QUOTATION
+		out.Endpoints = []Endpoint{{
+			Path:              "/orders",
+			RequestsPerSecond: upbit.RPS_ORDER,
+		}, {
+			Path:              "/order",
+			RequestsPerSecond: upbit.RPS_EXCHANGE,
+		}, {
+			Path:              "/orders/chance",
+			RequestsPerSecond: upbit.RPS_EXCHANGE,
+		}}
+	case *Woo:
+		out.RequestsPerSecond = 10
+		out.Endpoints = []Endpoint{{
+			Path:              "/v1/order",
+			RequestsPerSecond: 2,
+		}, {
+			Path:              "/v1/public/info",
+			RequestsPerSecond: 30,
+		}, {
+			Path:              "/md/cmc/summary",
+			RequestsPerSecond: 30,
+		}}
+	default:
+		return nil, errors.Errorf("%s does not support this command", out.Exchange)
+	}
+
+	if name != "" {
+		limits, err := readRateLimits(name)
+		if err != nil {
+			return nil, err
+		}
+		out.RateLimited = len(limits)
+		if len(limits) > 0 {
+			sort.Slice(limits, func(i, j int) bool {
+				return limits[i].Time.Before(limits[j].Time)
+			})
+			last := limits[len(limits)-1].Time
+			out.LastRateLimited = &last
+		}
+	}
+
+	return &out, nil
+}
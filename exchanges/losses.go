@@ -0,0 +1,223 @@
+package exchanges
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/i18n"
+	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/multiplier"
+	"github.com/svanas/nefertiti/notify"
+	"github.com/svanas/nefertiti/session"
+)
+
+type (
+	Loss struct {
+		Market string    `json:"market"`
+		Quote  string    `json:"quote"`
+		Amount float64   `json:"amount"` // in quote currency
+		Time   time.Time `json:"time"`
+	}
+	Losses struct {
+		Entries  []Loss               `json:"entries"`             // the last 24 hours, for the kill switch
+		History  []Loss               `json:"history,omitempty"`   // the last 1000 losses, for the outcomes
+		Since    *time.Time           `json:"since,omitempty"`     // when we started keeping the history
+		HaltedAt *time.Time           `json:"halted_at,omitempty"` // nil unless the kill switch has been tripped
+		Stops    map[string]time.Time `json:"stops,omitempty"`     // market -> last stop-loss fill
+		Total    map[string]float64   `json:"total,omitempty"`     // quote currency -> realized loss, forever
+	}
+)
+
+// we keep as many losses as we keep profits
+const lossesMax = profitsMax
+
+func lossesFile(exchange model.Exchange) string {
+	return session.GetSessionFile(strings.ToLower(exchange.GetInfo().Code) + ".losses.json")
+}
+
+func readLosses(exchange model.Exchange) (*Losses, error) {
+	var out Losses
+	data, err := session.ReadFile(lossesFile(exchange))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &out, nil
+		}
+		return nil, errors.Wrap(err, 1)
+	}
+	if err = json.Unmarshal(data, &out); err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+	return &out, nil
+}
+
+func (losses *Losses) write(exchange model.Exchange) error {
+	data, err := json.Marshal(losses)
+	if err != nil {
+		return errors.Wrap(err, 1)
+	}
+	return session.WriteFile(lossesFile(exchange), data)
+}
+
+// returns the realized losses per quote currency over the last 24 hours
+func (losses *Losses) last24h() map[string]float64 {
+	out := make(map[string]float64)
+	for _, entry := range losses.Entries {
+		if time.Since(entry.Time) < 24*time.Hour {
+			out[entry.Quote] += entry.Amount
+		}
+	}
+	return out
+}
+
+// recordLoss adds a filled stop-loss (worth notional in quote currency) to our rolling 24h ledger, and trips the
+// kill switch once our losses exceed --max-daily-loss. after that, we will stop buying (but we will keep managing
+// our exits) until you run the resume command.
+func recordLoss(exchange model.Exchange, market string, notional float64, stop multiplier.Mult, service model.Notify) {
+	if err := func() error {
+		if stop <= 0 || stop >= 1 || notional <= 0 {
+			return nil
+		}
+
+		markets, err := exchange.GetMarkets(true, flag.Sandbox(), nil)
+		if err != nil {
+			return err
+		}
+
+		quote, err := model.GetQuoteCurr(markets, market)
+		if err != nil {
+			return err
+		}
+
+		losses, err := readLosses(exchange)
+		if err != nil {
+			return err
+		}
+
+		// we sold at stop * bought, so we lost (1 - stop) * bought
+		loss := Loss{
+			Market: market,
+			Quote:  strings.ToUpper(quote),
+			Amount: notional * (1/float64(stop) - 1),
+			Time:   time.Now(),
+		}
+		losses.Entries = append(losses.Entries, loss)
+		losses.record(loss)
+
+		// remember when this market got stopped out, so that the buy command can cool down
+		if losses.Stops == nil {
+			losses.Stops = make(map[string]time.Time)
+		}
+		losses.Stops[market] = time.Now()
+
+		// you have asked for a stop-loss to be an emergency? then send a message you cannot miss
+		if service != nil && notify.IsEmergency(notify.EVENT_STOP_LOSS) {
+			msg := i18n.Sprintf("Your stop-loss on %s got filled. You lost %.8f %s.", market, losses.Entries[len(losses.Entries)-1].Amount, strings.ToUpper(quote))
+			if err := notify.Critical(service, notify.EVENT_STOP_LOSS, msg, (exchange.GetInfo().Name + " - Stop Loss")); err != nil {
+				log.Printf("[ERROR] %v", err)
+			}
+		}
+
+		// forget about the losses we no longer need
+		var entries []Loss
+		for _, entry := range losses.Entries {
+			if time.Since(entry.Time) < 24*time.Hour {
+				entries = append(entries, entry)
+			}
+		}
+		losses.Entries = entries
+
+		max, err := flag.MaxDailyLoss()
+		if err != nil {
+			return err
+		}
+
+		if max > 0 && losses.HaltedAt == nil {
+			total := losses.last24h()[strings.ToUpper(quote)]
+			if total > max {
+				now := time.Now()
+				losses.HaltedAt = &now
+				msg := i18n.Sprintf("Your realized losses over the last 24 hours (%.8f %s) exceed your max daily loss of %.8f %s. New buys are paused until you run the resume command.", total, strings.ToUpper(quote), max, strings.ToUpper(quote))
+				log.Printf("[WARN] %s\n", msg)
+				if service != nil {
+					if err := notify.Critical(service, notify.EVENT_KILL_SWITCH, msg, (exchange.GetInfo().Name + " - Kill Switch")); err != nil {
+						log.Printf("[ERROR] %v", err)
+					}
+				}
+			}
+		}
+
+		return losses.write(exchange)
+	}(); err != nil {
+		log.Printf("[ERROR] %v", err)
+	}
+}
+
+// record adds a loss to the history. the history is complete from Since onwards.
+func (losses *Losses) record(loss Loss) {
+	if losses.Since == nil {
+		since := loss.Time
+		losses.Since = &since
+	}
+	losses.Total = losses.total()
+	losses.Total[loss.Quote] += loss.Amount
+	losses.History = append(losses.History, loss)
+	if len(losses.History) > lossesMax {
+		losses.History = losses.History[len(losses.History)-lossesMax:]
+	}
+}
+
+// Halted returns true if the kill switch has been tripped
+func Halted(exchange model.Exchange) (bool, error) {
+	losses, err := readLosses(exchange)
+	if err != nil {
+		return false, err
+	}
+	return losses.HaltedAt != nil, nil
+}
+
+// Cooling returns true if the market got stopped out less than --stop-cooldown hours ago
+func Cooling(exchange model.Exchange, market string) (bool, error) {
+	cooldown, err := flag.StopCooldown()
+	if err != nil {
+		return false, err
+	}
+	if cooldown == 0 {
+		return false, nil
+	}
+	losses, err := readLosses(exchange)
+	if err != nil {
+		return false, err
+	}
+	stopped, ok := losses.Stops[market]
+	return ok && time.Since(stopped) < cooldown, nil
+}
+
+// Resume resets the kill switch, so that we will start buying again
+func Resume(exchange model.Exchange) error {
+	losses, err := readLosses(exchange)
+	if err != nil {
+		return err
+	}
+	// the history outlives the kill switch
+	losses.HaltedAt = nil
+	losses.Entries = nil
+	return losses.write(exchange)
+}
+
+// total returns the realized losses per quote currency. before we kept a total, we had the history only, so that is
+// where we start from.
+func (losses *Losses) total() map[string]float64 {
+	if losses.Total != nil {
+		return losses.Total
+	}
+	out := make(map[string]float64)
+	for _, entry := range losses.History {
+		out[entry.Quote] += entry.Amount
+	}
+	return out
+}
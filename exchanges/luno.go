@@ -0,0 +1,953 @@
+//lint:file-ignore ST1006 receiver name should be a reflection of its identity; don't use generic names such as "this" or "self"
+package exchanges
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/svanas/nefertiti/aggregation"
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/i18n"
+	exchange "github.com/svanas/nefertiti/luno"
+	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/multiplier"
+	"github.com/svanas/nefertiti/notify"
+	"github.com/svanas/nefertiti/override"
+	"github.com/svanas/nefertiti/precision"
+	"github.com/svanas/nefertiti/pricing"
+	"github.com/svanas/nefertiti/session"
+)
+
+var (
+	lunoMutex *session.Mutex
+)
+
+const (
+	lunoSessionFile = "luno.time"
+	lunoSessionLock = "luno.lock"
+)
+
+func init() {
+	exchange.BeforeRequest = func(method, path string) error {
+		var err error
+
+		if lunoMutex == nil {
+			if lunoMutex, err = session.NewMutex(lunoSessionLock); err != nil {
+				return err
+			}
+		}
+
+		if err = lunoMutex.Lock(); err != nil {
+			return err
+		}
+
+		var lastRequest *time.Time
+		if lastRequest, err = session.GetLastRequest(lunoSessionFile); err != nil {
+			return err
+		}
+
+		if lastRequest != nil {
+			elapsed := time.Since(*lastRequest)
+			if elapsed.Seconds() < (float64(1) / exchange.RequestsPerSecond) {
+				sleep := time.Duration((float64(time.Second) / exchange.RequestsPerSecond) - float64(elapsed))
+				if flag.Debug() {
+					log.Printf("[DEBUG] sleeping %f seconds\n", sleep.Seconds())
+				}
+				time.Sleep(sleep)
+			}
+		}
+
+		if flag.Debug() {
+			log.Printf("[DEBUG] %s %s\n", method, path)
+		}
+
+		return nil
+	}
+	exchange.AfterRequest = func() {
+		defer func() {
+			lunoMutex.Unlock()
+		}()
+		session.SetLastRequest(lunoSessionFile, time.Now())
+	}
+}
+
+type Luno struct {
+	*model.ExchangeInfo
+	markets []exchange.Market
+}
+
+func (self *Luno) error(err error, level int64, service model.Notify) {
+	pc, file, line, _ := runtime.Caller(1)
+	prefix := errors.FormatCaller(pc, file, line)
+
+	msg := fmt.Sprintf("%s %v", prefix, err)
+	_, ok := err.(*errors.Error)
+	if ok && flag.Debug() {
+		log.Printf("[ERROR] %s", err.(*errors.Error).ErrorStack(prefix, ""))
+	} else {
+		log.Printf("[ERROR] %s", msg)
+	}
+
+	if service != nil {
+		if notify.CanSend(level, notify.ERROR) {
+			err := notify.SendError(service, err, msg, "Luno - ERROR")
+			if err != nil {
+				log.Printf("[ERROR] %v", err)
+			}
+		}
+	}
+}
+
+func (self *Luno) indexByOrderID(orders []exchange.Order, id string) int {
+	for i, o := range orders {
+		if o.OrderID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (self *Luno) formatSide(order *exchange.Order) string {
+	if order.IsBuy() {
+		return model.FormatOrderSide(model.BUY)
+	}
+	return model.FormatOrderSide(model.SELL)
+}
+
+func (self *Luno) getBaseURL(sandbox bool) string {
+	return self.ExchangeInfo.REST.URI
+}
+
+func (self *Luno) getMarkets(client *exchange.Client, cached bool) ([]exchange.Market, error) {
+	if self.markets == nil || !cached {
+		markets, err := client.Markets()
+		if err != nil {
+			return nil, errors.Wrap(err, 1)
+		}
+		self.markets = nil
+		for _, market := range markets {
+			if market.Active() {
+				self.markets = append(self.markets, market)
+			}
+		}
+	}
+	return self.markets, nil
+}
+
+func (self *Luno) getMarket(client *exchange.Client, pair string, cached bool) (*exchange.Market, error) {
+	markets, err := self.getMarkets(client, cached)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, market := range markets {
+		if market.MarketID == pair {
+			return &market, nil
+		}
+	}
+
+	return nil, errors.Errorf("market %v does not exist", pair)
+}
+
+func (self *Luno) parseMarket(client *exchange.Client, pair string) (string, string, error) { // -> (base, quote, error)
+	market, err := self.getMarket(client, pair, true)
+	if err != nil {
+		return "", "", err
+	}
+	return exchange.ParseCurrency(market.BaseCurrency), exchange.ParseCurrency(market.CounterCurrency), nil
+}
+
+func (self *Luno) GetInfo() *model.ExchangeInfo {
+	return self.ExchangeInfo
+}
+
+func (self *Luno) GetClient(permission model.Permission, sandbox bool) (interface{}, error) {
+	if sandbox {
+		return nil, errors.New("sandbox not supported")
+	}
+
+	if permission == model.PUBLIC {
+		return exchange.New(self.getBaseURL(sandbox), "", ""), nil
+	}
+
+	apiKey, apiSecret, err := promptForApiKeys("Luno")
+	if err != nil {
+		return nil, err
+	}
+
+	return exchange.New(self.getBaseURL(sandbox), apiKey, apiSecret), nil
+}
+
+func (self *Luno) GetMarkets(cached, sandbox bool, blacklist []string) ([]model.Market, error) {
+	var out []model.Market
+
+	markets, err := self.getMarkets(exchange.New(self.getBaseURL(sandbox), "", ""), cached)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, market := range markets {
+		if func() bool {
+			for _, ignore := range blacklist {
+				if strings.EqualFold(market.MarketID, ignore) {
+					return false
+				}
+			}
+			return true
+		}() {
+			out = append(out, model.Market{
+				Name:  market.MarketID,
+				Base:  exchange.ParseCurrency(market.BaseCurrency),
+				Quote: exchange.ParseCurrency(market.CounterCurrency),
+			})
+		}
+	}
+
+	return out, nil
+}
+
+func (self *Luno) FormatMarket(base, quote string) string {
+	return exchange.FormatMarket(base, quote)
+}
+
+// listen to the opened orders, look for cancelled orders, send a notification.
+func (self *Luno) listen(
+	client *exchange.Client,
+	service model.Notify,
+	level int64,
+	old []exchange.Order,
+	filled []exchange.Order,
+) ([]exchange.Order, error) {
+	markets, err := self.getMarkets(client, true)
+	if err != nil {
+		return old, err
+	}
+
+	// get my opened orders
+	var new []exchange.Order
+	for _, market := range markets {
+		orders, err := client.Orders(market.MarketID, exchange.OrderStatePending)
+		if err != nil {
+			return old, errors.Wrap(err, 1)
+		}
+		new = append(new, orders...)
+	}
+
+	// look for cancelled orders
+	for _, order := range old {
+		if self.indexByOrderID(new, order.OrderID) == -1 {
+			// if this order has NOT been FILLED, then it has been cancelled.
+			if self.indexByOrderID(filled, order.OrderID) == -1 {
+				data, err := json.Marshal(order)
+				if err != nil {
+					return new, errors.Wrap(err, 1)
+				}
+
+				log.Println("[CANCELLED] " + string(data))
+
+				if service != nil {
+					if notify.CanSend(level, notify.CANCELLED) {
+						err := service.SendMessage(order, fmt.Sprintf("Luno - %s", i18n.Sprintf("Done %s (Reason: Cancelled)", self.formatSide(&order))), model.ALWAYS)
+						if err != nil {
+							log.Printf("[ERROR] %v", err)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	// look for newly opened orders
+	for _, order := range new {
+		if self.indexByOrderID(old, order.OrderID) == -1 {
+			data, err := json.Marshal(order)
+			if err != nil {
+				return new, errors.Wrap(err, 1)
+			}
+
+			log.Println("[OPEN] " + string(data))
+
+			if service != nil {
+				if notify.CanSend(level, notify.OPENED) || (level == notify.LEVEL_DEFAULT && !order.IsBuy()) {
+					err := service.SendMessage(order, fmt.Sprintf("Luno - %s", i18n.Sprintf("Open %s", self.formatSide(&order))), model.ALWAYS)
+					if err != nil {
+						log.Printf("[ERROR] %v", err)
+					}
+				}
+			}
+		}
+	}
+
+	return new, nil
+}
+
+// get my filled orders. luno reports cancelled orders as COMPLETE too, so we filter those out.
+func (self *Luno) getFilled(client *exchange.Client, markets []exchange.Market) ([]exchange.Order, error) {
+	var out []exchange.Order
+	for _, market := range markets {
+		orders, err := client.Orders(market.MarketID, exchange.OrderStateComplete)
+		if err != nil {
+			return nil, errors.Wrap(err, 1)
+		}
+		for _, order := range orders {
+			if order.Filled() {
+				out = append(out, order)
+			}
+		}
+	}
+	return out, nil
+}
+
+// listen to the filled orders, look for newly filled orders, automatically place new LIMIT SELL orders.
+func (self *Luno) sell(
+	client *exchange.Client,
+	mult multiplier.Mult,
+	hold, earn model.Markets,
+	service model.Notify,
+	level int64,
+	old []exchange.Order,
+) ([]exchange.Order, error) {
+	markets, err := self.getMarkets(client, true)
+	if err != nil {
+		return old, err
+	}
+
+	filled, err := self.getFilled(client, markets)
+	if err != nil {
+		return old, err
+	}
+
+	// make a list of newly filled orders
+	var new []exchange.Order
+	for _, order := range filled {
+		if self.indexByOrderID(old, order.OrderID) == -1 && processOnce(self, order.OrderID) {
+			new = append(new, order)
+		}
+	}
+
+	// send notification(s)
+	for _, order := range new {
+		data, err := json.Marshal(order)
+		if err != nil {
+			self.error(err, level, service)
+		} else {
+			log.Println("[FILLED] " + string(data))
+			if notify.CanSend(level, notify.FILLED) && service != nil {
+				err := service.SendMessage(order, fmt.Sprintf("Luno - %s", i18n.Sprintf("Done %s (Reason: Filled)", self.formatSide(&order))), model.ALWAYS)
+				if err != nil {
+					log.Printf("[ERROR] %v", err)
+				}
+			}
+		}
+	}
+
+	// has a buy order been filled? then place a sell order
+	for i := 0; i < len(new); i++ {
+		if new[i].IsBuy() {
+			qty := new[i].BaseMinusFee()
+
+			// add up amount(s), hereby preventing a problem with partial matches
+			n := i + 1
+			for n < len(new) {
+				if new[n].Pair == new[i].Pair && new[n].Type == new[i].Type && new[n].LimitPrice == new[i].LimitPrice {
+					qty = qty + new[n].BaseMinusFee()
+					new = append(new[:n], new[n+1:]...)
+				} else {
+					n++
+				}
+			}
+
+			// round to precision, because fees got deducted
+			prec, err := self.GetSizePrec(client, new[i].Pair)
+			if err != nil {
+				self.error(err, level, service)
+			} else {
+				qty = precision.Floor(qty, prec)
+			}
+
+			// get base currency and desired size, calculate price, place sell order
+			base, quote, err := self.parseMarket(client, new[i].Pair)
+			if err == nil {
+				qty = self.GetMaxSize(client, base, quote, hold.HasMarket(new[i].Pair), earn.HasMarket(new[i].Pair), qty, override.Mult(new[i].Pair, mult))
+				if qty > 0 {
+					var tick float64
+					tick, err = model.GetTickSize(self, client, new[i].Pair)
+					if err == nil {
+						_, err = client.LimitOrder(
+							new[i].Pair,
+							exchange.OrderTypeAsk,
+							qty,
+							pricing.MultiplyTick(new[i].ExecutedAt(), override.Mult(new[i].Pair, mult), tick),
+						)
+					}
+				}
+			}
+
+			if err != nil {
+				data, _ := json.Marshal(new[i])
+				if data == nil {
+					self.error(err, level, service)
+				} else {
+					self.error(errors.Append(err, "\t", string(data)), level, service)
+				}
+			}
+		}
+	}
+
+	return filled, nil
+}
+
+func (self *Luno) Sell(
+	strategy model.Strategy,
+	hold, earn model.Markets,
+	sandbox, tweet, debug bool,
+	success model.OnSuccess,
+) error {
+	if strategy != model.STRATEGY_STANDARD {
+		return errors.New("strategy not implemented")
+	}
+
+	apiKey, apiSecret, err := promptForApiKeys("Luno")
+	if err != nil {
+		return err
+	}
+
+	service, err := notify.New().Init(flag.Interactive(), true)
+	if err != nil {
+		return err
+	}
+
+	client := exchange.New(self.getBaseURL(sandbox), apiKey, apiSecret)
+
+	markets, err := self.getMarkets(client, true)
+	if err != nil {
+		return err
+	}
+
+	// get my filled orders
+	filled, err := self.getFilled(client, markets)
+	if err != nil {
+		return err
+	}
+
+	// get my opened orders
+	var opened []exchange.Order
+	for _, market := range markets {
+		orders, err := client.Orders(market.MarketID, exchange.OrderStatePending)
+		if err != nil {
+			return errors.Wrap(err, 1)
+		}
+		opened = append(opened, orders...)
+	}
+
+	if err = success(service); err != nil {
+		return err
+	}
+
+	for {
+		// read the dynamic settings
+		var (
+			level int64 = notify.LEVEL_DEFAULT
+			mult  multiplier.Mult
+		)
+		if level, err = notify.Level(); err != nil {
+			self.error(err, level, service)
+		} else if mult, err = multiplier.Get(multiplier.FIVE_PERCENT); err != nil {
+			self.error(err, level, service)
+		} else
+		// listen to the filled orders, look for newly filled orders, automatically place new LIMIT SELL orders.
+		if filled, err = self.sell(client, mult, hold, earn, service, level, filled); err != nil {
+			self.error(err, level, service)
+		} else
+		// listen to the opened orders, look for cancelled orders, send a notification.
+		if opened, err = self.listen(client, service, level, opened, filled); err != nil {
+			self.error(err, level, service)
+		}
+		beat(self.GetInfo().Name, err)
+	}
+}
+
+func (self *Luno) Order(
+	client interface{},
+	side model.OrderSide,
+	market string,
+	size float64,
+	price float64,
+	kind model.OrderType,
+	metadata string,
+) (oid []byte, raw []byte, err error) {
+	if raw, ok := observeOrder(self, client, side, market, size, price, kind); ok {
+		return nil, raw, nil
+	}
+
+	if err := CheckEquityShare(self, client, side, market, size, price); err != nil {
+		return nil, nil, err
+	}
+
+	lunoClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, nil, errors.New("invalid argument: client")
+	}
+
+	var orderID string
+	if kind == model.MARKET {
+		if side == model.BUY {
+			// market buy orders are placed for an amount in counter currency
+			var ticker float64
+			if ticker, err = self.GetTicker(client, market); err != nil {
+				return nil, nil, err
+			}
+			orderID, err = lunoClient.MarketOrder(market, exchange.OrderTypeBuy, size*ticker)
+		} else {
+			orderID, err = lunoClient.MarketOrder(market, exchange.OrderTypeSell, size)
+		}
+	} else {
+		if side == model.BUY {
+			orderID, err = lunoClient.LimitOrder(market, exchange.OrderTypeBid, size, price)
+		} else {
+			orderID, err = lunoClient.LimitOrder(market, exchange.OrderTypeAsk, size, price)
+		}
+	}
+	if err != nil {
+		return nil, nil, errors.Wrap(err, 1)
+	}
+
+	return []byte(orderID), nil, nil
+}
+
+func (self *Luno) StopLoss(client interface{}, market string, size float64, price float64, kind model.OrderType, metadata string) ([]byte, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (self *Luno) OCO(client interface{}, market string, size float64, price, stop float64, metadata string) ([]byte, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (self *Luno) GetClosed(client interface{}, market string) (model.Orders, error) {
+	lunoClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	orders, err := lunoClient.Orders(market, exchange.OrderStateComplete)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	var output model.Orders
+	for _, order := range orders {
+		if order.Filled() {
+			output = append(output, model.Order{
+				Side: func() model.OrderSide {
+					if order.IsBuy() {
+						return model.BUY
+					}
+					return model.SELL
+				}(),
+				Status:     model.FILLED,
+				Market:     market,
+				Size:       order.BaseMinusFee(),
+				Price:      order.ExecutedAt(),
+				CreatedAt:  order.CreatedAt(),
+				ClosedAt:   model.TimePtr(order.CompletedAt()),
+				FilledSize: order.Base,
+				Fee: func() float64 {
+					if order.IsBuy() {
+						return order.FeeBase
+					}
+					return order.FeeCounter
+				}(),
+			})
+		}
+	}
+
+	return output, nil
+}
+
+func (self *Luno) GetOpened(client interface{}, market string) (model.Orders, error) {
+	lunoClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	orders, err := lunoClient.Orders(market, exchange.OrderStatePending)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	var output model.Orders
+	for _, order := range orders {
+		output = append(output, model.Order{
+			Status: model.OPEN,
+			Side: func() model.OrderSide {
+				if order.IsBuy() {
+					return model.BUY
+				}
+				return model.SELL
+			}(),
+			ID:        order.OrderID,
+			Market:    market,
+			Size:      order.LimitVolume,
+			Price:     order.LimitPrice,
+			CreatedAt: order.CreatedAt(),
+		})
+	}
+
+	return output, nil
+}
+
+func (self *Luno) GetBook(client interface{}, market string, side model.BookSide) (interface{}, error) {
+	lunoClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	book, err := lunoClient.OrderBook(market)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	if side == model.BOOK_SIDE_ASKS {
+		return book.Asks, nil
+	}
+	return book.Bids, nil
+}
+
+func (self *Luno) Aggregate(client, book interface{}, market string, agg float64) (model.Book, error) {
+	bids, ok := book.([]exchange.BookEntry)
+	if !ok {
+		return nil, errors.New("invalid argument: book")
+	}
+
+	prec, err := self.GetPricePrec(client, market)
+	if err != nil {
+		return nil, err
+	}
+
+	var out model.Book
+	for _, e := range bids {
+		price := precision.Round(aggregation.Round(e.Price, agg), prec)
+		entry := out.EntryByPrice(price)
+		if entry != nil {
+			entry.Size = entry.Size + e.Volume
+		} else {
+			entry = &model.Buy{
+				Market: market,
+				Price:  price,
+				Size:   e.Volume,
+			}
+			out = append(out, *entry)
+		}
+	}
+
+	return out, nil
+}
+
+func (self *Luno) GetTicker(client interface{}, market string) (float64, error) {
+	lunoClient, ok := client.(*exchange.Client)
+	if !ok {
+		return 0, errors.New("invalid argument: client")
+	}
+
+	ticker, err := lunoClient.Ticker(market)
+	if err != nil {
+		return 0, errors.Wrap(err, 1)
+	}
+
+	return ticker.LastTrade, nil
+}
+
+func (self *Luno) GetTickers(client interface{}) (map[string]float64, error) {
+	lunoClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	tickers, err := lunoClient.Tickers()
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	out := make(map[string]float64)
+	for _, ticker := range tickers {
+		out[ticker.Pair] = ticker.LastTrade
+	}
+
+	return out, nil
+}
+
+// luno does not publish a 24h high and low, so we fall back on the best ask and the best bid.
+func (self *Luno) Get24h(client interface{}, market string) (*model.Stats, error) {
+	lunoClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	ticker, err := lunoClient.Ticker(market)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	return &model.Stats{
+		Market: market,
+		High:   ticker.Ask,
+		Low:    ticker.Bid,
+		Last:   ticker.LastTrade,
+		BtcVolume: func(ticker1 *exchange.Ticker) float64 {
+			base, quote, err := self.parseMarket(lunoClient, market)
+			if err == nil {
+				if strings.EqualFold(base, model.BTC) {
+					return ticker1.Rolling24HourVolume
+				}
+				if strings.EqualFold(quote, model.BTC) {
+					return ticker1.Rolling24HourVolume * ticker1.LastTrade
+				}
+				ticker2, err := lunoClient.Ticker(self.FormatMarket(model.BTC, quote))
+				if err == nil && ticker2.LastTrade > 0 {
+					return (ticker1.Rolling24HourVolume * ticker1.LastTrade) / ticker2.LastTrade
+				}
+			}
+			return 0
+		}(ticker),
+	}, nil
+}
+
+func (self *Luno) Get24hAll(client interface{}) (map[string]model.Stats, error) {
+	lunoClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	tickers, err := lunoClient.Tickers()
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	out := make(map[string]model.Stats)
+	volume := make(map[string]float64) // in quote currency
+	for _, ticker := range tickers {
+		out[ticker.Pair] = model.Stats{
+			Market: ticker.Pair,
+			High:   ticker.Ask,
+			Low:    ticker.Bid,
+			Last:   ticker.LastTrade,
+		}
+		volume[ticker.Pair] = ticker.Rolling24HourVolume * ticker.LastTrade
+	}
+
+	if err = setBtcVolume(self, out, volume); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (self *Luno) GetPricePrec(client interface{}, market string) (int, error) {
+	lunoClient, ok := client.(*exchange.Client)
+	if !ok {
+		return 0, errors.New("invalid argument: client")
+	}
+
+	info, err := self.getMarket(lunoClient, market, true)
+	if err != nil {
+		return 0, err
+	}
+
+	return info.PriceScale, nil
+}
+
+func (self *Luno) GetSizePrec(client interface{}, market string) (int, error) {
+	lunoClient, ok := client.(*exchange.Client)
+	if !ok {
+		return 0, errors.New("invalid argument: client")
+	}
+
+	info, err := self.getMarket(lunoClient, market, true)
+	if err != nil {
+		return 0, err
+	}
+
+	return info.VolumeScale, nil
+}
+
+func (self *Luno) GetMaxSize(client interface{}, base, quote string, hold, earn bool, def float64, mult multiplier.Mult) float64 {
+	return model.GetSizeMax(hold, earn, def, mult, func() int {
+		prec, err := self.GetSizePrec(client, self.FormatMarket(base, quote))
+		if err != nil {
+			return 0
+		}
+		return prec
+	})
+}
+
+func (self *Luno) Cancel(client interface{}, market string, side model.OrderSide) error {
+	if observeCancel(self, market, side) {
+		return nil
+	}
+
+	lunoClient, ok := client.(*exchange.Client)
+	if !ok {
+		return errors.New("invalid argument: client")
+	}
+
+	orders, err := lunoClient.Orders(market, exchange.OrderStatePending)
+	if err != nil {
+		return errors.Wrap(err, 1)
+	}
+
+	for _, order := range orders {
+		if (side == model.BUY) == order.IsBuy() {
+			if err := lunoClient.StopOrder(order.OrderID); err != nil {
+				return errors.Wrap(err, 1)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (self *Luno) CancelOrder(client interface{}, market, id string) error {
+	if observeCancelOrder(self, market, id) {
+		return nil
+	}
+
+	lunoClient, ok := client.(*exchange.Client)
+	if !ok {
+		return errors.New("invalid argument: client")
+	}
+
+	if err := lunoClient.StopOrder(id); err != nil {
+		return errors.Wrap(err, 1)
+	}
+
+	return nil
+}
+
+func (self *Luno) GetOrder(client interface{}, market, id string) (*model.Order, error) {
+	lunoClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	order, err := lunoClient.GetOrder(id)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	out := &model.Order{
+		ID:     id,
+		Status: model.OPEN,
+		Side: func() model.OrderSide {
+			if order.IsBuy() {
+				return model.BUY
+			}
+			return model.SELL
+		}(),
+		Market:     market,
+		Size:       order.LimitVolume,
+		Price:      order.ExecutedAt(),
+		CreatedAt:  order.CreatedAt(),
+		FilledSize: order.Base,
+	}
+	if order.State == exchange.OrderStateComplete {
+		if order.Filled() {
+			out.Status = model.FILLED
+		} else {
+			out.Status = model.CANCELLED
+		}
+		out.ClosedAt = model.TimePtr(order.CompletedAt())
+	}
+
+	return out, nil
+}
+
+func (self *Luno) GetFees(client interface{}, market string) (*model.Fees, error) {
+	return getFees(self)
+}
+
+func (self *Luno) Buy(client interface{}, cancel bool, market string, calls model.Calls, deviation float64, kind model.OrderType) error {
+	if observeBuy(self, client, cancel, market, calls, kind) {
+		return nil
+	}
+
+	lunoClient, ok := client.(*exchange.Client)
+	if !ok {
+		return errors.New("invalid argument: client")
+	}
+
+	// step #1: delete the buy order(s) that are open in your book
+	if cancel {
+		orders, err := lunoClient.Orders(market, exchange.OrderStatePending)
+		if err != nil {
+			return errors.Wrap(err, 1)
+		}
+		for _, order := range orders {
+			if order.IsBuy() {
+				// do not cancel orders that we're about to re-place
+				index := calls.IndexByPriceSize(order.LimitPrice, order.LimitVolume)
+				if index > -1 {
+					calls[index].Skip = true
+				} else {
+					if err := lunoClient.StopOrder(order.OrderID); err != nil {
+						return errors.Wrap(err, 1)
+					}
+				}
+			}
+		}
+	}
+
+	// step 2: open the top X buy orders
+	for _, call := range calls {
+		if !call.Skip {
+			var (
+				qty   float64 = call.Size
+				limit float64 = call.Price
+			)
+			if deviation != 1.0 {
+				kind, limit = call.Deviate(self, client, kind, deviation)
+			}
+			info, err := self.getMarket(lunoClient, market, true)
+			if err != nil {
+				return err
+			}
+			if info.MinVolume > 0 && qty < info.MinVolume {
+				qty = info.MinVolume
+			}
+			if _, _, err := self.Order(client, model.BUY, market, qty, limit, kind, ""); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (self *Luno) IsLeveragedToken(name string) bool {
+	return false
+}
+
+func (self *Luno) HasAlgoOrder(client interface{}, market string) (bool, error) {
+	return false, nil
+}
+
+func newLuno() model.Exchange {
+	return &Luno{
+		ExchangeInfo: &model.ExchangeInfo{
+			Code: "LUNO",
+			Name: "Luno",
+			URL:  "https://www.luno.com",
+			REST: model.Endpoint{
+				URI: exchange.API_BASE,
+			},
+			WebSocket: model.Endpoint{
+				URI: "wss://ws.luno.com/api/1/stream",
+			},
+			Country: "United Kingdom",
+		},
+	}
+}
@@ -1,6 +1,8 @@
 package exchanges
 
 import (
+	"strings"
+
 	"github.com/svanas/nefertiti/errors"
 	"github.com/svanas/nefertiti/flag"
 	"github.com/svanas/nefertiti/model"
@@ -20,17 +22,9 @@ func (exchanges *Exchanges) findByName(name string) model.Exchange {
 
 func New() *Exchanges {
 	var out Exchanges
-	out = append(out, newGdax())
-	out = append(out, newBittrex())
-	out = append(out, newBitstamp())
-	out = append(out, newCexIo())
-	out = append(out, newBinance())
-	out = append(out, newBinanceUS())
-	out = append(out, newHitBTC())
-	out = append(out, newKucoin())
-	out = append(out, newCryptoDotCom())
-	out = append(out, newWoo())
-	out = append(out, newHuobi())
+	for _, new := range registry {
+		out = append(out, new())
+	}
 	return &out
 }
 
@@ -43,13 +37,52 @@ func GetExchange() (model.Exchange, error) {
 	if out == nil {
 		return nil, errors.Errorf("exchange %v does not exist", arg)
 	}
+	if err := overrideEndpoints(out, flag.Sandbox()); err != nil {
+		return nil, err
+	}
 	return out, nil
 }
 
+// usesWebSocket returns true if the exchange streams from its websocket. the other exchanges publish a websocket
+// endpoint, but we only ever talk to their REST API.
+func usesWebSocket(exchange model.Exchange) bool {
+	switch exchange.(type) {
+	case *Gdax:
+		return true
+	}
+	return false
+}
+
+// overrideEndpoints replaces the hard-coded endpoints with --rest=URI and --websocket=URI, for example a regional mirror or a self-hosted proxy.
+// With --sandbox=Y, the URIs replace the sandbox endpoints, so we refuse them on the exchanges that do not have a sandbox.
+func overrideEndpoints(exchange model.Exchange, sandbox bool) error {
+	info := exchange.GetInfo()
+	if flag.Exists("websocket") && !usesWebSocket(exchange) {
+		return errors.Errorf("%s does not support --websocket", info.Name)
+	}
+	if sandbox && info.REST.Sandbox == "" && (flag.Exists("rest") || flag.Exists("websocket")) {
+		return errors.Errorf("%s does not have a sandbox; remove --sandbox to use --rest or --websocket", info.Name)
+	}
+	set := func(endpoint *model.Endpoint, name string) {
+		arg := flag.Get(name)
+		if arg.Exists && arg.String() != "" {
+			uri := strings.TrimSuffix(arg.String(), "/")
+			if sandbox {
+				endpoint.Sandbox = uri
+			} else {
+				endpoint.URI = uri
+			}
+		}
+	}
+	set(&info.REST, "rest")
+	set(&info.WebSocket, "websocket")
+	return nil
+}
+
 func promptForApiKeys(exchange string) (apiKey, apiSecret string, err error) {
 	apiKey = flag.Get("api-key").String()
 	if apiKey == "" {
-		if flag.Listen() {
+		if !flag.Interactive() {
 			return "", "", errors.New("missing argument: api-key")
 		}
 		var data []byte
@@ -62,7 +95,7 @@ func promptForApiKeys(exchange string) (apiKey, apiSecret string, err error) {
 
 	apiSecret = flag.Get("api-secret").String()
 	if apiSecret == "" {
-		if flag.Listen() {
+		if !flag.Interactive() {
 			return "", "", errors.New("missing argument: api-secret")
 		}
 		var data []byte
@@ -85,7 +118,7 @@ func promptForApiKeysEx(exchange string) (apiKey, apiSecret, apiPassphrase strin
 
 	apiPassphrase = flag.Get("api-passphrase").String()
 	if apiPassphrase == "" {
-		if flag.Listen() {
+		if !flag.Interactive() {
 			return "", "", "", errors.New("missing argument: api-passphrase")
 		}
 		var data []byte
@@ -0,0 +1,68 @@
+package exchanges
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/session"
+)
+
+// the names of the markets are cached on disk for a day, so that the shell completion doesn't wait for the exchange
+const marketNamesMaxAge = 24 * time.Hour
+
+func marketNamesFile(exchange model.Exchange, sandbox bool) string {
+	name := strings.ToLower(exchange.GetInfo().Code)
+	if sandbox {
+		name += ".sandbox"
+	}
+	return session.GetSessionFile(name + ".markets.json")
+}
+
+// GetMarketNames returns the (sorted) names of the markets on this exchange, from disk if we can
+func GetMarketNames(exchange model.Exchange, sandbox bool) ([]string, error) {
+	file := marketNamesFile(exchange, sandbox)
+
+	if info, err := os.Stat(file); err == nil && time.Since(info.ModTime()) < marketNamesMaxAge {
+		var out []string
+		if data, err := session.ReadFile(file); err == nil {
+			if json.Unmarshal(data, &out) == nil {
+				return out, nil
+			}
+		}
+	}
+
+	markets, err := exchange.GetMarkets(true, sandbox, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []string
+	for _, market := range markets {
+		out = append(out, market.Name)
+	}
+	sort.Strings(out)
+
+	data, err := json.Marshal(out)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+	if err = session.WriteFile(file, data); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// GetExchangeCodes returns the (lower case) codes of the exchanges that we support
+func GetExchangeCodes() []string {
+	var out []string
+	for _, exchange := range *New() {
+		out = append(out, strings.ToLower(exchange.GetInfo().Code))
+	}
+	return out
+}
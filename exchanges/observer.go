@@ -0,0 +1,314 @@
+package exchanges
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/notify"
+	"github.com/svanas/nefertiti/precision"
+	"github.com/svanas/nefertiti/session"
+)
+
+// in observer mode (--observe), you run the bot with read-only API keys. we do all the analysis and take all the
+// decisions, but we never send an order (or a cancellation) to the exchange. instead, we tell you what we would
+// have done, and we keep a journal of it. --dry-run is the same thing, minus the journal: we print the orders
+// that we would have sent, and then exit.
+
+type Observation struct {
+	Time   time.Time `json:"time"`
+	Action string    `json:"action"` // for example: limit, market, stop-loss, oco, cancel
+	Market string    `json:"market"`
+	Side   string    `json:"side,omitempty"`
+	ID     string    `json:"id,omitempty"`
+	Size   float64   `json:"size,omitempty"`
+	Price  float64   `json:"price,omitempty"`
+	Stop   float64   `json:"stop,omitempty"`
+	Run    string    `json:"run,omitempty"` // the run ID (and label) of the loop that observed this
+	// the simulation profile of the exchange (if any) decides when this order expires, or why it got refused
+	Expires *time.Time `json:"expires,omitempty"`
+	Reason  string     `json:"reason,omitempty"`
+}
+
+// we keep the last so many observations
+const observationsMax = 1000
+
+var (
+	observerMutex   sync.Mutex
+	observerService model.Notify
+	observerInit    bool
+)
+
+func observationsFile(exchange model.Exchange) string {
+	return session.GetSessionFile(strings.ToLower(exchange.GetInfo().Code) + ".observer.json")
+}
+
+func observe(exchange model.Exchange, observation Observation) {
+	observerMutex.Lock()
+	defer observerMutex.Unlock()
+
+	observation.Time = time.Now()
+	observation.Run = session.RunTag()
+
+	// in dry-run mode, we print the order and that's it
+	if flag.DryRun() {
+		if data, err := json.Marshal(observation); err == nil {
+			fmt.Println(string(data))
+		}
+		return
+	}
+
+	msg := fmt.Sprintf("Would place %s %s %s", observation.Action, observation.Side, observation.Market)
+	if observation.Action == "reject" {
+		msg = fmt.Sprintf("Would be refused: %s %s %v @ %v. %s", observation.Side, observation.Market, observation.Size, observation.Price, observation.Reason)
+	} else if observation.Action == "cancel" {
+		if observation.ID != "" {
+			msg = fmt.Sprintf("Would cancel order %s on %s", observation.ID, observation.Market)
+		} else {
+			msg = fmt.Sprintf("Would cancel %s orders on %s", observation.Side, observation.Market)
+		}
+	} else {
+		if observation.Size != 0 {
+			msg = fmt.Sprintf("%s %v", msg, observation.Size)
+		}
+		if observation.Price != 0 {
+			msg = fmt.Sprintf("%s @ %v", msg, observation.Price)
+		}
+		if observation.Stop != 0 {
+			msg = fmt.Sprintf("%s (stop %v)", msg, observation.Stop)
+		}
+	}
+	log.Printf("[OBSERVE] %s\n", msg)
+
+	// journal
+	if err := func() error {
+		var journal []Observation
+		data, err := session.ReadFile(observationsFile(exchange))
+		if err == nil {
+			if err = json.Unmarshal(data, &journal); err != nil {
+				return err
+			}
+		} else if !os.IsNotExist(err) {
+			return err
+		}
+		journal = append(expire(journal), observation)
+		if len(journal) > observationsMax {
+			journal = journal[len(journal)-observationsMax:]
+		}
+		if data, err = json.Marshal(journal); err != nil {
+			return err
+		}
+		return session.WriteFile(observationsFile(exchange), data)
+	}(); err != nil {
+		log.Printf("[ERROR] %v", err)
+	}
+
+	// notify
+	if !observerInit {
+		observerInit = true
+		var err error
+		if observerService, err = notify.New().Init(false, true); err != nil {
+			log.Printf("[ERROR] %v", err)
+		}
+	}
+	if observerService != nil {
+		if err := observerService.SendMessage(msg, (exchange.GetInfo().Name + " - Observe"), model.ALWAYS); err != nil {
+			log.Printf("[ERROR] %v", err)
+		}
+	}
+}
+
+// expire appends an observation for every order that the exchange would have cancelled by now, because it expired.
+// we find those whenever we write to the journal.
+func expire(journal []Observation) []Observation {
+	now := time.Now()
+	for i := range journal {
+		expires := journal[i].Expires
+		if expires != nil && expires.Before(now) {
+			log.Printf("[OBSERVE] Would have expired %s %s %s (placed %s)\n", journal[i].Action, journal[i].Side, journal[i].Market, journal[i].Time.Format(time.RFC3339))
+			journal[i].Expires = nil
+			journal = append(journal, Observation{
+				Time:   *expires,
+				Action: "expire",
+				Market: journal[i].Market,
+				Side:   journal[i].Side,
+				Size:   journal[i].Size,
+				Price:  journal[i].Price,
+				Stop:   journal[i].Stop,
+				Run:    journal[i].Run,
+			})
+		}
+	}
+	return journal
+}
+
+// simulate applies the simulation profile of the exchange (if any) to an order that we would have placed: the
+// exchange refuses the orders below its minimums, and cancels the orders that have been open for too long.
+func simulate(exchange model.Exchange, client interface{}, observation *Observation) {
+	simulator, ok := exchange.(model.Simulator)
+	if !ok {
+		return
+	}
+	sim, err := simulator.GetSimulation(client, observation.Market)
+	if err != nil {
+		log.Printf("[ERROR] %v", err)
+		return
+	}
+	if sim.MinSize > 0 && observation.Size < sim.MinSize {
+		observation.Reason = fmt.Sprintf("%v is below the minimum size of %v", observation.Size, sim.MinSize)
+	} else if sim.MinNotional > 0 && observation.Price > 0 && observation.Size*observation.Price < sim.MinNotional {
+		observation.Reason = fmt.Sprintf("%v is below the minimum notional of %v", observation.Size*observation.Price, sim.MinNotional)
+	}
+	if observation.Reason != "" {
+		observation.Action = "reject"
+		return
+	}
+	if sim.MaxOrderAge > 0 && observation.Action != model.OrderTypeString[model.MARKET] {
+		expires := time.Now().Add(sim.MaxOrderAge)
+		observation.Expires = &expires
+	}
+}
+
+// observing returns true if we should not send orders (or cancellations) to the exchange
+func observing() bool {
+	return flag.Observe() || flag.DryRun()
+}
+
+// adjust rounds size and price the way the exchange would
+func adjust(exchange model.Exchange, client interface{}, market string, size, price float64) (float64, float64) {
+	if prec, err := exchange.GetSizePrec(client, market); err == nil {
+		size = precision.Round(size, prec)
+	}
+	if price != 0 {
+		if prec, err := exchange.GetPricePrec(client, market); err == nil {
+			price = precision.Round(price, prec)
+		}
+	}
+	return size, price
+}
+
+// observeOrder returns true (and the order we would have placed) if we are in observer mode
+func observeOrder(exchange model.Exchange, client interface{}, side model.OrderSide, market string, size, price float64, kind model.OrderType) ([]byte, bool) {
+	if !observing() {
+		return nil, false
+	}
+	size, price = adjust(exchange, client, market, size, price)
+	observation := Observation{
+		Action: kind.String(),
+		Market: market,
+		Side:   side.String(),
+		Size:   size,
+		Price:  price,
+	}
+	simulate(exchange, client, &observation)
+	observe(exchange, observation)
+	raw, _ := json.Marshal(observation)
+	return raw, true
+}
+
+func observeStopLoss(exchange model.Exchange, client interface{}, market string, size, price float64) ([]byte, bool) {
+	if !observing() {
+		return nil, false
+	}
+	size, price = adjust(exchange, client, market, size, price)
+	observation := Observation{
+		Action: "stop-loss",
+		Market: market,
+		Side:   "sell",
+		Size:   size,
+		Stop:   price,
+	}
+	simulate(exchange, client, &observation)
+	observe(exchange, observation)
+	raw, _ := json.Marshal(observation)
+	return raw, true
+}
+
+func observeStopBuy(exchange model.Exchange, client interface{}, market string, size, trigger float64) ([]byte, bool) {
+	if !observing() {
+		return nil, false
+	}
+	size, trigger = adjust(exchange, client, market, size, trigger)
+	observation := Observation{
+		Action: "stop-buy",
+		Market: market,
+		Side:   "buy",
+		Size:   size,
+		Stop:   trigger,
+	}
+	simulate(exchange, client, &observation)
+	observe(exchange, observation)
+	raw, _ := json.Marshal(observation)
+	return raw, true
+}
+
+func observeOCO(exchange model.Exchange, client interface{}, market string, size, price, stop float64) ([]byte, bool) {
+	if !observing() {
+		return nil, false
+	}
+	size, price = adjust(exchange, client, market, size, price)
+	_, stop = adjust(exchange, client, market, size, stop)
+	observation := Observation{
+		Action: "oco",
+		Market: market,
+		Side:   "sell",
+		Size:   size,
+		Price:  price,
+		Stop:   stop,
+	}
+	simulate(exchange, client, &observation)
+	observe(exchange, observation)
+	raw, _ := json.Marshal(observation)
+	return raw, true
+}
+
+func observeCancel(exchange model.Exchange, market string, side model.OrderSide) bool {
+	if !observing() {
+		return false
+	}
+	observe(exchange, Observation{
+		Action: "cancel",
+		Market: market,
+		Side:   side.String(),
+	})
+	return true
+}
+
+func observeCancelOrder(exchange model.Exchange, market, id string) bool {
+	if !observing() {
+		return false
+	}
+	observe(exchange, Observation{
+		Action: "cancel",
+		Market: market,
+		ID:     id,
+	})
+	return true
+}
+
+func observeBuy(exchange model.Exchange, client interface{}, cancel bool, market string, calls model.Calls, kind model.OrderType) bool {
+	if !observing() {
+		return false
+	}
+	if cancel {
+		observeCancel(exchange, market, model.BUY)
+	}
+	for _, call := range calls {
+		if !call.Skip {
+			size := call.Size
+			if sizer, ok := exchange.(minSizer); ok {
+				if min, err := sizer.minSize(client, market, size, call.Price); err == nil {
+					size = min
+				}
+			}
+			observeOrder(exchange, client, model.BUY, market, size, call.Price, kind)
+		}
+	}
+	return true
+}
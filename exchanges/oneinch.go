@@ -0,0 +1,878 @@
+//lint:file-ignore ST1006 receiver name should be a reflection of its identity; don't use generic names such as "this" or "self"
+package exchanges
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"math/big"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/i18n"
+	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/multiplier"
+	"github.com/svanas/nefertiti/notify"
+	exchange "github.com/svanas/nefertiti/oneinch"
+	"github.com/svanas/nefertiti/override"
+	"github.com/svanas/nefertiti/passphrase"
+	"github.com/svanas/nefertiti/precision"
+	"github.com/svanas/nefertiti/pricing"
+	"github.com/svanas/nefertiti/session"
+)
+
+var (
+	oneinchMutex *session.Mutex
+)
+
+const (
+	oneinchSessionFile    = "oneinch.time"
+	oneinchSessionLock    = "oneinch.lock"
+	oneinchSessionJournal = "oneinch.json"
+)
+
+func init() {
+	exchange.BeforeRequest = func(method, path string) error {
+		var err error
+
+		if oneinchMutex == nil {
+			if oneinchMutex, err = session.NewMutex(oneinchSessionLock); err != nil {
+				return err
+			}
+		}
+
+		if err = oneinchMutex.Lock(); err != nil {
+			return err
+		}
+
+		var lastRequest *time.Time
+		if lastRequest, err = session.GetLastRequest(oneinchSessionFile); err != nil {
+			return err
+		}
+
+		if lastRequest != nil {
+			elapsed := time.Since(*lastRequest)
+			if elapsed.Seconds() < (float64(1) / exchange.RequestsPerSecond) {
+				sleep := time.Duration((float64(time.Second) / exchange.RequestsPerSecond) - float64(elapsed))
+				if flag.Debug() {
+					log.Printf("[DEBUG] sleeping %f seconds\n", sleep.Seconds())
+				}
+				time.Sleep(sleep)
+			}
+		}
+
+		if flag.Debug() {
+			log.Printf("[DEBUG] %s %s\n", method, path)
+		}
+
+		return nil
+	}
+	exchange.AfterRequest = func() {
+		defer func() {
+			oneinchMutex.Unlock()
+		}()
+		session.SetLastRequest(oneinchSessionFile, time.Now())
+	}
+}
+
+// a DEX has no order history, so we keep a journal of our swaps in the session dir.
+type OneInchFill struct {
+	Hash      string          `json:"hash"`
+	Market    string          `json:"market"`
+	Side      model.OrderSide `json:"side"`
+	Size      float64         `json:"size"`
+	Price     float64         `json:"price"`
+	CreatedAt time.Time       `json:"created_at"`
+	ClosedBy  string          `json:"closed_by,omitempty"` // the hash of the swap that sold this buy
+}
+
+type OneInchJournal []OneInchFill
+
+func readOneInchJournal() (OneInchJournal, error) {
+	data, err := session.ReadFile(session.GetSessionFile(oneinchSessionJournal))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, 1)
+	}
+	var out OneInchJournal
+	if err = json.Unmarshal(data, &out); err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+	return out, nil
+}
+
+func (journal OneInchJournal) write() error {
+	data, err := json.Marshal(journal)
+	if err != nil {
+		return errors.Wrap(err, 1)
+	}
+	return session.WriteFile(session.GetSessionFile(oneinchSessionJournal), data)
+}
+
+type OneInch struct {
+	*model.ExchangeInfo
+	tokens []exchange.Token
+}
+
+func (self *OneInch) error(err error, level int64, service model.Notify) {
+	pc, file, line, _ := runtime.Caller(1)
+	prefix := errors.FormatCaller(pc, file, line)
+
+	msg := fmt.Sprintf("%s %v", prefix, err)
+	_, ok := err.(*errors.Error)
+	if ok && flag.Debug() {
+		log.Printf("[ERROR] %s", err.(*errors.Error).ErrorStack(prefix, ""))
+	} else {
+		log.Printf("[ERROR] %s", msg)
+	}
+
+	if service != nil {
+		if notify.CanSend(level, notify.ERROR) {
+			err := notify.SendError(service, err, msg, "1inch - ERROR")
+			if err != nil {
+				log.Printf("[ERROR] %v", err)
+			}
+		}
+	}
+}
+
+// --chain=[ethereum|polygon|arbitrum] (defaults to ethereum)
+func (self *OneInch) getChain() (*exchange.Chain, error) {
+	arg := flag.Get("chain")
+	if arg.Exists && arg.String() != "" {
+		chain, err := exchange.GetChain(arg.String())
+		if err != nil {
+			return nil, errors.Wrap(err, 1)
+		}
+		return chain, nil
+	}
+	return &exchange.Chains[0], nil
+}
+
+// --max-gas-price=X and --max-priority-fee=X, in gwei
+func (self *OneInch) getGasLimits() (*exchange.GasLimits, error) {
+	toWei := func(gwei float64) *big.Int {
+		if gwei == 0 {
+			return nil
+		}
+		out, _ := new(big.Float).Mul(big.NewFloat(gwei), big.NewFloat(1e9)).Int(nil)
+		return out
+	}
+
+	maxGasPrice, err := flag.MaxGasPrice()
+	if err != nil {
+		return nil, err
+	}
+
+	maxPriorityFee, err := flag.MaxPriorityFee()
+	if err != nil {
+		return nil, err
+	}
+
+	return &exchange.GasLimits{
+		MaxGasPrice:    toWei(maxGasPrice),
+		MaxPriorityFee: toWei(maxPriorityFee),
+	}, nil
+}
+
+// --api-key=X is your 1inch developer portal key
+func (self *OneInch) getApiKey() (string, error) {
+	apiKey := flag.Get("api-key").String()
+	if apiKey == "" {
+		if !flag.Interactive() {
+			return "", errors.New("missing argument: api-key")
+		}
+		data, err := passphrase.Read("1inch API key")
+		if err != nil {
+			return "", errors.Wrap(err, 1)
+		}
+		apiKey = string(data)
+		flag.Set("api-key", apiKey)
+	}
+	return apiKey, nil
+}
+
+// --wallet=path is a file that holds your hex-encoded private key
+func (self *OneInch) getWallet() (*exchange.Wallet, error) {
+	path := flag.Get("wallet").String()
+	if path == "" {
+		return nil, errors.New("missing argument: wallet")
+	}
+	wallet, err := exchange.LoadWallet(path)
+	if err != nil {
+		return nil, errors.Errorf("cannot load wallet %s: %v", path, err)
+	}
+	return wallet, nil
+}
+
+// returns a new client. without a wallet, the client is read-only.
+func (self *OneInch) newClient(wallet *exchange.Wallet) (*exchange.Client, error) {
+	apiKey, err := self.getApiKey()
+	if err != nil {
+		return nil, err
+	}
+
+	chain, err := self.getChain()
+	if err != nil {
+		return nil, err
+	}
+
+	// --rpc=URI overrides the JSON RPC of the chain
+	client := exchange.New(self.ExchangeInfo.REST.URI, apiKey, chain, flag.Get("rpc").String(), wallet)
+
+	if wallet != nil {
+		limits, err := self.getGasLimits()
+		if err != nil {
+			return nil, err
+		}
+		client.Limits = *limits
+	}
+
+	return client, nil
+}
+
+// the tokens we quote in: USDC and the native token of the chain
+func (self *OneInch) getQuotes(client *exchange.Client) map[string]string {
+	return map[string]string{
+		"USDC":              client.Chain.USDC,
+		client.Chain.Native: exchange.NATIVE,
+	}
+}
+
+func (self *OneInch) getTokens(client *exchange.Client, cached bool) ([]exchange.Token, error) {
+	if self.tokens == nil || !cached {
+		var err error
+		if self.tokens, err = client.Tokens(); err != nil {
+			return nil, errors.Wrap(err, 1)
+		}
+	}
+	return self.tokens, nil
+}
+
+func (self *OneInch) getToken(client *exchange.Client, symbol string) (*exchange.Token, error) {
+	tokens, err := self.getTokens(client, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var out *exchange.Token
+	address, isQuote := self.getQuotes(client)[strings.ToUpper(symbol)]
+	for i, token := range tokens {
+		if isQuote && strings.EqualFold(token.Address, address) {
+			return &tokens[i], nil
+		}
+		if !isQuote && strings.EqualFold(token.Symbol, symbol) {
+			// more than one token goes by this symbol, we cannot tell which one you mean
+			if out != nil {
+				return nil, errors.Errorf("token %v is ambiguous", symbol)
+			}
+			out = &tokens[i]
+		}
+	}
+
+	if out == nil {
+		return nil, errors.Errorf("token %v does not exist", symbol)
+	}
+
+	return out, nil
+}
+
+func (self *OneInch) parseMarket(market string) (string, string, error) { // -> (base, quote, error)
+	subs := strings.Split(market, "-")
+	if len(subs) != 2 {
+		return "", "", errors.Errorf("cannot parse market %s", market)
+	}
+	return subs[0], subs[1], nil
+}
+
+func (self *OneInch) getPair(client *exchange.Client, market string) (*exchange.Token, *exchange.Token, error) { // -> (base, quote, error)
+	base, quote, err := self.parseMarket(market)
+	if err != nil {
+		return nil, nil, err
+	}
+	baseToken, err := self.getToken(client, base)
+	if err != nil {
+		return nil, nil, err
+	}
+	quoteToken, err := self.getToken(client, quote)
+	if err != nil {
+		return nil, nil, err
+	}
+	return baseToken, quoteToken, nil
+}
+
+// swaps at market, bounded by --slippage=X. when a limit is included, we refuse to swap beyond the limit (plus slippage).
+func (self *OneInch) swap(client *exchange.Client, side model.OrderSide, market string, size, limit float64) (*OneInchFill, error) {
+	base, quote, err := self.getPair(client, market)
+	if err != nil {
+		return nil, err
+	}
+
+	slippage, err := flag.Slippage()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		src, dst *exchange.Token
+		amount   *big.Int
+		price    float64
+	)
+	if side == model.BUY {
+		price = limit
+		if price == 0 {
+			if price, err = client.Price(base, quote); err != nil {
+				return nil, errors.Wrap(err, 1)
+			}
+		}
+		src, dst, amount = quote, base, quote.ToAmount(size*price)
+	} else {
+		src, dst, amount = base, quote, base.ToAmount(size)
+	}
+
+	// check the quote against our limit before we spend any gas
+	quoted, err := client.Quote(src.Address, dst.Address, amount)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+	if dst.FromAmount(quoted) == 0 {
+		return nil, errors.Errorf("cannot swap %s. no liquidity", market)
+	}
+	if side == model.BUY {
+		price = src.FromAmount(amount.String()) / dst.FromAmount(quoted)
+		if limit > 0 && price > limit*(1+slippage/100) {
+			return nil, errors.Errorf("cannot buy %s. quoted price %s exceeds your limit %s", market, precision.FormatFloat(price, -1), precision.FormatFloat(limit, -1))
+		}
+	} else {
+		price = dst.FromAmount(quoted) / size
+		if limit > 0 && price < limit*(1-slippage/100) {
+			return nil, errors.Errorf("cannot sell %s. quoted price %s is below your limit %s", market, precision.FormatFloat(price, -1), precision.FormatFloat(limit, -1))
+		}
+	}
+
+	if err = checkSwap(side, market, base.Symbol, quote.Symbol, price, slippage); err != nil {
+		return nil, err
+	}
+
+	hash, received, err := client.Swap(src.Address, dst.Address, amount, slippage)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	if side == model.BUY {
+		size = dst.FromAmount(received)
+		price = src.FromAmount(amount.String()) / size
+	} else {
+		price = dst.FromAmount(received) / size
+	}
+
+	fill := OneInchFill{
+		Hash:      hash,
+		Market:    market,
+		Side:      side,
+		Size:      size,
+		Price:     price,
+		CreatedAt: time.Now(),
+	}
+
+	journal, err := readOneInchJournal()
+	if err != nil {
+		return &fill, err
+	}
+	journal = append(journal, fill)
+
+	return &fill, journal.write()
+}
+
+func (self *OneInch) GetInfo() *model.ExchangeInfo {
+	return self.ExchangeInfo
+}
+
+func (self *OneInch) GetClient(permission model.Permission, sandbox bool) (interface{}, error) {
+	if sandbox {
+		return nil, errors.New("sandbox not supported")
+	}
+
+	if permission == model.PUBLIC {
+		return self.newClient(nil)
+	}
+
+	wallet, err := self.getWallet()
+	if err != nil {
+		return nil, err
+	}
+
+	return self.newClient(wallet)
+}
+
+func (self *OneInch) GetMarkets(cached, sandbox bool, blacklist []string) ([]model.Market, error) {
+	var out []model.Market
+
+	client, err := self.newClient(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := self.getTokens(client, cached)
+	if err != nil {
+		return nil, err
+	}
+
+	// skip the symbols that more than one token goes by
+	symbols := make(map[string]int)
+	for _, token := range tokens {
+		symbols[strings.ToUpper(token.Symbol)]++
+	}
+
+	for quote, address := range self.getQuotes(client) {
+		for _, token := range tokens {
+			if strings.EqualFold(token.Address, address) || token.Symbol == "" || strings.Contains(token.Symbol, "-") || symbols[strings.ToUpper(token.Symbol)] > 1 {
+				continue
+			}
+			name := self.FormatMarket(token.Symbol, quote)
+			if func() bool {
+				for _, ignore := range blacklist {
+					if strings.EqualFold(name, ignore) {
+						return false
+					}
+				}
+				return true
+			}() {
+				out = append(out, model.Market{
+					Name:  name,
+					Base:  strings.ToUpper(token.Symbol),
+					Quote: quote,
+				})
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// how OneInch spells its markets
+var oneinchSymbol = model.SymbolFormat{Separator: "-"}
+
+func (self *OneInch) FormatMarket(base, quote string) string {
+	return model.NewMarketSymbol(base, quote).Format(oneinchSymbol)
+}
+
+// look for bought tokens that reached their target (or their stop), then swap them back.
+func (self *OneInch) sell(
+	client *exchange.Client,
+	strategy model.Strategy,
+	mult multiplier.Mult,
+	stop multiplier.Mult,
+	hold model.Markets,
+	service model.Notify,
+	level int64,
+) error {
+	journal, err := readOneInchJournal()
+	if err != nil {
+		return err
+	}
+
+	for i, fill := range journal {
+		if fill.Side != model.BUY || fill.ClosedBy != "" || hold.HasMarket(fill.Market) {
+			continue
+		}
+
+		ticker, err := self.GetTicker(client, fill.Market)
+		if err != nil {
+			return err
+		}
+
+		tick, err := model.GetTickSize(self, client, fill.Market)
+		if err != nil {
+			return err
+		}
+
+		target := pricing.MultiplyTick(fill.Price, override.Mult(fill.Market, mult), tick)
+		stopped := strategy == model.STRATEGY_STOP_LOSS && ticker <= pricing.MultiplyTick(fill.Price, override.Stop(fill.Market, stop), tick)
+		if ticker < target && !stopped {
+			continue
+		}
+
+		if _, ok := observeOrder(self, client, model.SELL, fill.Market, fill.Size, 0, model.MARKET); ok {
+			continue
+		}
+
+		exit, err := self.swap(client, model.SELL, fill.Market, fill.Size, 0)
+		if err != nil {
+			data, _ := json.Marshal(fill)
+			if data == nil {
+				self.error(err, level, service)
+			} else {
+				self.error(errors.Append(err, "\t", string(data)), level, service)
+			}
+			continue
+		}
+
+		// re-read the journal, because swap() appended the exit
+		if journal, err = readOneInchJournal(); err != nil {
+			return err
+		}
+		journal[i].ClosedBy = exit.Hash
+		if err = journal.write(); err != nil {
+			return err
+		}
+
+		data, _ := json.Marshal(exit)
+		log.Println("[FILLED] " + string(data))
+
+		if stopped {
+			recordLoss(self, fill.Market, exit.Size*exit.Price, stop, service)
+		} else {
+			recordProfit(self, client, fill.Market, (exit.Size*exit.Price)-(fill.Size*fill.Price))
+		}
+
+		if service != nil && notify.CanSend(level, notify.FILLED) {
+			title := fmt.Sprintf("1inch - %s", i18n.Sprintf("Done Sell %s", multiplier.Format(mult)))
+			if stopped {
+				title = fmt.Sprintf("1inch - %s", i18n.Sprintf("Done Sell %s", multiplier.Format(stop)))
+			}
+			if err := service.SendMessage(exit, title, model.ALWAYS); err != nil {
+				log.Printf("[ERROR] %v", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (self *OneInch) Sell(
+	strategy model.Strategy,
+	hold, earn model.Markets,
+	sandbox, tweet, debug bool,
+	success model.OnSuccess,
+) error {
+	if strategy == model.STRATEGY_STANDARD || strategy == model.STRATEGY_STOP_LOSS {
+		// we are OK
+	} else {
+		return errors.New("strategy not implemented")
+	}
+
+	wallet, err := self.getWallet()
+	if err != nil {
+		return err
+	}
+
+	service, err := notify.New().Init(flag.Interactive(), true)
+	if err != nil {
+		return err
+	}
+
+	client, err := self.newClient(wallet)
+	if err != nil {
+		return err
+	}
+
+	if _, err = self.getTokens(client, true); err != nil {
+		return err
+	}
+
+	if err = success(service); err != nil {
+		return err
+	}
+
+	for {
+		// read the dynamic settings
+		var (
+			level int64 = notify.LEVEL_DEFAULT
+			mult  multiplier.Mult
+			stop  multiplier.Mult
+		)
+		if level, err = notify.Level(); err != nil {
+			self.error(err, level, service)
+		} else if mult, err = multiplier.Get(multiplier.FIVE_PERCENT); err != nil {
+			self.error(err, level, service)
+		} else if stop, err = multiplier.Stop(); err != nil {
+			self.error(err, level, service)
+		} else
+		// look for bought tokens that reached their target (or their stop), then swap them back.
+		if err = self.sell(client, strategy, mult, stop, hold, service, level); err != nil {
+			self.error(err, level, service)
+		}
+		beat(self.GetInfo().Name, err)
+		time.Sleep(time.Minute)
+	}
+}
+
+func (self *OneInch) Order(
+	client interface{},
+	side model.OrderSide,
+	market string,
+	size float64,
+	price float64,
+	kind model.OrderType,
+	metadata string,
+) (oid []byte, raw []byte, err error) {
+	if raw, ok := observeOrder(self, client, side, market, size, price, kind); ok {
+		return nil, raw, nil
+	}
+
+	if err := CheckEquityShare(self, client, side, market, size, price); err != nil {
+		return nil, nil, err
+	}
+
+	oneinchClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, nil, errors.New("invalid argument: client")
+	}
+
+	// there are no limit orders on a DEX. a limit is a bound on the price we are willing to swap at.
+	limit := price
+	if kind == model.MARKET {
+		limit = 0
+	}
+
+	fill, err := self.swap(oneinchClient, side, market, size, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if raw, err = json.Marshal(fill); err != nil {
+		return nil, nil, errors.Wrap(err, 1)
+	}
+
+	return []byte(fill.Hash), raw, nil
+}
+
+func (self *OneInch) GetStops() model.Stops {
+	return model.Stops{Strategy: true}
+}
+
+func (self *OneInch) StopLoss(client interface{}, market string, size float64, price float64, kind model.OrderType, metadata string) ([]byte, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (self *OneInch) OCO(client interface{}, market string, size float64, price, stop float64, metadata string) ([]byte, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (self *OneInch) GetClosed(client interface{}, market string) (model.Orders, error) {
+	journal, err := readOneInchJournal()
+	if err != nil {
+		return nil, err
+	}
+
+	var output model.Orders
+	for _, fill := range journal {
+		if fill.Market == market {
+			output = append(output, model.Order{
+				Side:       fill.Side,
+				Status:     model.FILLED,
+				Market:     market,
+				Size:       fill.Size,
+				Price:      fill.Price,
+				CreatedAt:  fill.CreatedAt,
+				ClosedAt:   model.TimePtr(fill.CreatedAt),
+				FilledSize: fill.Size,
+			})
+		}
+	}
+
+	return output, nil
+}
+
+// swaps are immediate, so there are no opened orders
+func (self *OneInch) GetOpened(client interface{}, market string) (model.Orders, error) {
+	return nil, nil
+}
+
+// there is no order book on a DEX, so we return one (synthetic) entry at the current price.
+func (self *OneInch) GetBook(client interface{}, market string, side model.BookSide) (interface{}, error) {
+	ticker, err := self.GetTicker(client, market)
+	if err != nil {
+		return nil, err
+	}
+	return model.Book{model.Buy{Market: market, Price: ticker, Size: 0}}, nil
+}
+
+func (self *OneInch) Aggregate(client, book interface{}, market string, agg float64) (model.Book, error) {
+	out, ok := book.(model.Book)
+	if !ok {
+		return nil, errors.New("invalid argument: book")
+	}
+	return out, nil
+}
+
+func (self *OneInch) GetTicker(client interface{}, market string) (float64, error) {
+	oneinchClient, ok := client.(*exchange.Client)
+	if !ok {
+		return 0, errors.New("invalid argument: client")
+	}
+
+	base, quote, err := self.getPair(oneinchClient, market)
+	if err != nil {
+		return 0, err
+	}
+
+	price, err := oneinchClient.Price(base, quote)
+	if err != nil {
+		return 0, errors.Wrap(err, 1)
+	}
+
+	return price, nil
+}
+
+func (self *OneInch) GetTickers(client interface{}) (map[string]float64, error) {
+	return getTickers(self, client)
+}
+
+// 1inch does not publish a 24h high, low or volume, so we return the current price.
+func (self *OneInch) Get24h(client interface{}, market string) (*model.Stats, error) {
+	ticker, err := self.GetTicker(client, market)
+	if err != nil {
+		return nil, err
+	}
+	return &model.Stats{
+		Market:    market,
+		High:      ticker,
+		Low:       ticker,
+		BtcVolume: 0,
+		Last:      ticker,
+	}, nil
+}
+
+func (self *OneInch) Get24hAll(client interface{}) (map[string]model.Stats, error) {
+	return get24hAll(self, client)
+}
+
+// prices have no tick size, so we keep (at least) 4 significant digits.
+func (self *OneInch) GetPricePrec(client interface{}, market string) (int, error) {
+	ticker, err := self.GetTicker(client, market)
+	if err != nil {
+		return 8, err
+	}
+	if ticker <= 0 {
+		return 8, nil
+	}
+	return int(math.Max(2, math.Ceil(-math.Log10(ticker))+4)), nil
+}
+
+func (self *OneInch) GetSizePrec(client interface{}, market string) (int, error) {
+	oneinchClient, ok := client.(*exchange.Client)
+	if !ok {
+		return 0, errors.New("invalid argument: client")
+	}
+
+	base, _, err := self.getPair(oneinchClient, market)
+	if err != nil {
+		return 0, err
+	}
+
+	return base.Decimals, nil
+}
+
+func (self *OneInch) GetMaxSize(client interface{}, base, quote string, hold, earn bool, def float64, mult multiplier.Mult) float64 {
+	return model.GetSizeMax(hold, earn, def, mult, func() int {
+		prec, err := self.GetSizePrec(client, self.FormatMarket(base, quote))
+		if err != nil {
+			return 0
+		}
+		return prec
+	})
+}
+
+// swaps are immediate, so there is nothing to cancel
+func (self *OneInch) Cancel(client interface{}, market string, side model.OrderSide) error {
+	if observeCancel(self, market, side) {
+		return nil
+	}
+
+	return nil
+}
+
+func (self *OneInch) CancelOrder(client interface{}, market, id string) error {
+	return errors.New("not implemented")
+}
+
+// a swap is filled or it is not, so we find it in our journal or we do not.
+func (self *OneInch) GetOrder(client interface{}, market, id string) (*model.Order, error) {
+	journal, err := readOneInchJournal()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, fill := range journal {
+		if fill.Hash == id {
+			return &model.Order{
+				ID:         id,
+				Side:       fill.Side,
+				Status:     model.FILLED,
+				Market:     fill.Market,
+				Size:       fill.Size,
+				Price:      fill.Price,
+				CreatedAt:  fill.CreatedAt,
+				ClosedAt:   model.TimePtr(fill.CreatedAt),
+				FilledSize: fill.Size,
+			}, nil
+		}
+	}
+
+	return nil, errors.Errorf("order %s not found", id)
+}
+
+// GetFees returns zero. 1inch doesn't charge a fee of its own, and the gas you pay is per swap (not per unit).
+func (self *OneInch) GetFees(client interface{}, market string) (*model.Fees, error) {
+	return &model.Fees{}, nil
+}
+
+func (self *OneInch) Buy(client interface{}, cancel bool, market string, calls model.Calls, deviation float64, kind model.OrderType) error {
+	if observeBuy(self, client, cancel, market, calls, kind) {
+		return nil
+	}
+
+	oneinchClient, ok := client.(*exchange.Client)
+	if !ok {
+		return errors.New("invalid argument: client")
+	}
+
+	prec, err := self.GetSizePrec(client, market)
+	if err != nil {
+		return err
+	}
+
+	for _, call := range calls {
+		if !call.Skip {
+			limit := call.Price
+			if kind == model.MARKET {
+				limit = 0
+			}
+			if _, err := self.swap(oneinchClient, model.BUY, market, precision.Floor(call.Size, prec), limit); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (self *OneInch) IsLeveragedToken(name string) bool {
+	return false
+}
+
+func (self *OneInch) HasAlgoOrder(client interface{}, market string) (bool, error) {
+	return false, nil
+}
+
+func newOneInch() model.Exchange {
+	return &OneInch{
+		ExchangeInfo: &model.ExchangeInfo{
+			Code: "1INCH",
+			Name: "1inch",
+			URL:  "https://1inch.io",
+			REST: model.Endpoint{
+				URI: exchange.API_BASE,
+			},
+		},
+	}
+}
@@ -0,0 +1,207 @@
+package exchanges
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/svanas/nefertiti/chainlink"
+	"github.com/svanas/nefertiti/coingecko"
+	"github.com/svanas/nefertiti/ecb"
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/precision"
+)
+
+// tickerOracle asks another exchange for its ticker
+type tickerOracle struct {
+	exchange model.Exchange
+}
+
+func (self *tickerOracle) Name() string {
+	return self.exchange.GetInfo().Name
+}
+
+func (self *tickerOracle) Price(base, quote string) (float64, error) {
+	client, err := self.exchange.GetClient(model.PUBLIC, false)
+	if err != nil {
+		return 0, err
+	}
+	return self.exchange.GetTicker(client, self.exchange.FormatMarket(base, quote))
+}
+
+type coingeckoOracle struct{}
+
+func (self *coingeckoOracle) Name() string {
+	return "CoinGecko"
+}
+
+func (self *coingeckoOracle) Price(base, quote string) (float64, error) {
+	out, err := coingecko.New(flag.Get("coingecko-key").String()).Price(base, ecb.Underlying(quote))
+	if err != nil {
+		return 0, errors.Wrap(err, 1)
+	}
+	return out, nil
+}
+
+// chainlinkOracle reads the on-chain price feeds. stablecoins are treated as USD, and a pair without a feed of its
+// own is derived from the USD feeds of its base and its quote.
+type chainlinkOracle struct{}
+
+func (self *chainlinkOracle) Name() string {
+	return "Chainlink"
+}
+
+func (self *chainlinkOracle) feed(base, quote string) (string, error) {
+	feeds, err := flag.ChainlinkFeeds()
+	if err != nil {
+		return "", err
+	}
+	pair := strings.ToUpper(base + "-" + quote)
+	if address, ok := feeds[pair]; ok {
+		return address, nil
+	}
+	if address, ok := chainlink.Feeds[pair]; ok {
+		return address, nil
+	}
+	return "", nil
+}
+
+func (self *chainlinkOracle) Price(base, quote string) (float64, error) {
+	base = strings.ToUpper(ecb.Underlying(base))
+	quote = strings.ToUpper(ecb.Underlying(quote))
+	if base == quote {
+		return 1, nil
+	}
+
+	client := chainlink.New(flag.Get("chainlink-rpc").String())
+
+	get := func(base, quote string) (float64, error) {
+		address, err := self.feed(base, quote)
+		if err != nil {
+			return 0, err
+		}
+		if address == "" {
+			return 0, nil
+		}
+		out, err := client.Price(address)
+		if err != nil {
+			return 0, errors.Wrap(err, 1)
+		}
+		return out, nil
+	}
+
+	// BASE-QUOTE
+	out, err := get(base, quote)
+	if err != nil || out > 0 {
+		return out, err
+	}
+	// QUOTE-BASE
+	if out, err = get(quote, base); err != nil || out > 0 {
+		if out > 0 {
+			out = 1 / out
+		}
+		return out, err
+	}
+	// BASE-USD / QUOTE-USD
+	if quote != "USD" {
+		var num, den float64
+		if num, err = self.Price(base, "USD"); err != nil {
+			return 0, err
+		}
+		if den, err = self.Price(quote, "USD"); err != nil {
+			return 0, err
+		}
+		if num > 0 && den > 0 {
+			return num / den, nil
+		}
+	}
+
+	return 0, errors.Errorf("Chainlink does not have a feed for %s-%s", base, quote)
+}
+
+// GetOracles returns the oracles in --oracle=X,Y,Z order
+func GetOracles() ([]model.Oracle, error) {
+	names, _ := flag.Oracle()
+	var out []model.Oracle
+	for _, name := range names {
+		switch strings.ToLower(name) {
+		case "coingecko":
+			out = append(out, &coingeckoOracle{})
+		case "chainlink":
+			out = append(out, &chainlinkOracle{})
+		default:
+			other := New().findByName(name)
+			if other == nil {
+				return nil, errors.Errorf("oracle %v does not exist", name)
+			}
+			out = append(out, &tickerOracle{exchange: other})
+		}
+	}
+	return out, nil
+}
+
+// fair prices are cached for a minute, so that a burst of signals does not exhaust the rate limit of our oracles
+var (
+	fairPriceCache = make(map[string]fairPrice)
+	fairPriceMutex sync.Mutex
+)
+
+type fairPrice struct {
+	price  float64
+	oracle string
+	time   time.Time
+}
+
+// GetFairPrice returns the price of base (expressed in quote), and the name of the oracle that it came from. we ask
+// the oracles in order of priority, and fall back on the next one when an oracle fails.
+func GetFairPrice(base, quote string) (float64, string, error) {
+	oracles, err := GetOracles()
+	if err != nil {
+		return 0, "", err
+	}
+
+	fairPriceMutex.Lock()
+	defer fairPriceMutex.Unlock()
+
+	key := strings.ToUpper(base + "-" + quote)
+	if cached, ok := fairPriceCache[key]; ok && time.Since(cached.time) < time.Minute {
+		return cached.price, cached.oracle, nil
+	}
+
+	var msgs []string
+	for _, oracle := range oracles {
+		out, err := oracle.Price(base, quote)
+		if err == nil && out <= 0 {
+			err = errors.Errorf("%s has no price for %s-%s", oracle.Name(), base, quote)
+		}
+		if err != nil {
+			msgs = append(msgs, oracle.Name()+": "+err.Error())
+			continue
+		}
+		fairPriceCache[key] = fairPrice{price: out, oracle: oracle.Name(), time: time.Now()}
+		return out, oracle.Name(), nil
+	}
+
+	return 0, "", errors.Errorf("no fair price for %s-%s. %s", base, quote, strings.Join(msgs, ". "))
+}
+
+// checkSwap refuses a DEX swap when the quoted price is more than --slippage=X percent worse than the fair price.
+// the check is disabled unless you include --oracle, because a DEX lists plenty of tokens that the oracles don't.
+func checkSwap(side model.OrderSide, market, base, quote string, price, slippage float64) error {
+	if _, ok := flag.Oracle(); !ok {
+		return nil
+	}
+	fair, oracle, err := GetFairPrice(base, quote)
+	if err != nil {
+		return err
+	}
+	if side == model.BUY && price > fair*(1+slippage/100) {
+		return errors.Errorf("cannot buy %s. quoted price %s exceeds the fair price %s (%s) plus slippage", market, precision.FormatFloat(price, -1), precision.FormatFloat(fair, -1), oracle)
+	}
+	if side == model.SELL && price < fair*(1-slippage/100) {
+		return errors.Errorf("cannot sell %s. quoted price %s is below the fair price %s (%s) minus slippage", market, precision.FormatFloat(price, -1), precision.FormatFloat(fair, -1), oracle)
+	}
+	return nil
+}
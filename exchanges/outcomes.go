@@ -0,0 +1,60 @@
+package exchanges
+
+import (
+	"time"
+
+	"github.com/svanas/nefertiti/model"
+)
+
+// Outcome is a realized profit (or loss, if Amount is negative) in quote currency
+type Outcome struct {
+	Market string    `json:"market"`
+	Quote  string    `json:"quote"`
+	Amount float64   `json:"amount"`
+	Time   time.Time `json:"time"`
+}
+
+// GetOutcomes returns the profits and the losses that we have journaled, plus the time since when we know about both.
+// before then, we would be reading your profits without your losses. we keep the last 1000 profits and the last 1000
+// losses, so once either journal is full, we start at its oldest entry.
+func GetOutcomes(exchange model.Exchange) ([]Outcome, time.Time, error) {
+	losses, err := readLosses(exchange)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	// we haven't been keeping the history of the losses? then we start now
+	if losses.Since == nil {
+		now := time.Now()
+		losses.Since = &now
+		if err = losses.write(exchange); err != nil {
+			return nil, time.Time{}, err
+		}
+	}
+	since := *losses.Since
+	if len(losses.History) >= lossesMax && losses.History[0].Time.After(since) {
+		since = losses.History[0].Time
+	}
+
+	profits, err := readProfits(exchange)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if len(profits.Entries) >= profitsMax && profits.Entries[0].Time.After(since) {
+		since = profits.Entries[0].Time
+	}
+
+	var out []Outcome
+	for _, entry := range profits.Entries {
+		if !entry.Time.Before(since) {
+			out = append(out, Outcome{Market: entry.Market, Quote: entry.Quote, Amount: entry.Amount, Time: entry.Time})
+		}
+	}
+	for _, entry := range losses.History {
+		if !entry.Time.Before(since) {
+			out = append(out, Outcome{Market: entry.Market, Quote: entry.Quote, Amount: -entry.Amount, Time: entry.Time})
+		}
+	}
+
+	return out, since, nil
+}
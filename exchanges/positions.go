@@ -0,0 +1,197 @@
+package exchanges
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/humanize"
+	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/multiplier"
+	"github.com/svanas/nefertiti/session"
+)
+
+// a position is what we are holding in a market, and what we paid for it on average. the import command backfills
+// the positions from your order history, so that we know the cost basis of assets you bought before the bot.
+
+type (
+	Position struct {
+		Size     float64   `json:"size"`
+		Cost     float64   `json:"cost"`                // average price paid, in quote currency
+		OpenedAt time.Time `json:"opened_at,omitempty"` // when we first bought into this position
+	}
+	Positions map[string]Position // market -> position
+	// PositionReport is a position, plus how old it is and how far the ticker is from our target
+	PositionReport struct {
+		Market      string    `json:"market"`
+		Size        float64   `json:"size"`
+		Cost        float64   `json:"cost"`
+		OpenedAt    time.Time `json:"opened_at,omitempty"`
+		Age         string    `json:"age,omitempty"`
+		Ticker      float64   `json:"ticker,omitempty"`
+		Target      float64   `json:"target"`
+		Distance    string    `json:"distance,omitempty"`    // what the ticker needs to move to reach the target
+		Stop        float64   `json:"stop,omitempty"`        // trigger price of the open stop-loss, if any
+		Unprotected bool      `json:"unprotected,omitempty"` // true if we failed to attach a stop-loss to your limit sell
+	}
+)
+
+func positionsFile(exchange model.Exchange) string {
+	return session.GetSessionFile(strings.ToLower(exchange.GetInfo().Code) + ".positions.json")
+}
+
+func ReadPositions(exchange model.Exchange) (Positions, error) {
+	out := make(Positions)
+	data, err := session.ReadFile(positionsFile(exchange))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return out, nil
+		}
+		return nil, errors.Wrap(err, 1)
+	}
+	if err = json.Unmarshal(data, &out); err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+	return out, nil
+}
+
+func (positions Positions) Write(exchange model.Exchange) error {
+	data, err := json.Marshal(positions)
+	if err != nil {
+		return errors.Wrap(err, 1)
+	}
+	return session.WriteFile(positionsFile(exchange), data)
+}
+
+// NewPositions replays your buys and sells (oldest first). a buy adds to the position at a weighted average cost,
+// a sell takes from the position at that same cost.
+func NewPositions(orders model.Orders) Positions {
+	sorted := make(model.Orders, len(orders))
+	copy(sorted, orders)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+	})
+
+	out := make(Positions)
+	for _, order := range sorted {
+		size := order.Size
+		if order.FilledSize > 0 {
+			size = order.FilledSize
+		}
+		pos := out[order.Market]
+		switch order.Side {
+		case model.BUY:
+			if pos.Size+size > 0 {
+				pos.Cost = ((pos.Size * pos.Cost) + (size * order.Price)) / (pos.Size + size)
+			}
+			if pos.Size == 0 {
+				pos.OpenedAt = order.CreatedAt
+			}
+			pos.Size += size
+		case model.SELL:
+			pos.Size -= size
+			if pos.Size <= 0 {
+				pos = Position{}
+			}
+		}
+		out[order.Market] = pos
+	}
+
+	for market, pos := range out {
+		if pos.Size == 0 {
+			delete(out, market)
+		}
+	}
+
+	return out
+}
+
+// CostBasis returns the average price you paid for your position in this market, or zero if we do not know.
+func CostBasis(exchange model.Exchange, market string) (float64, error) {
+	positions, err := ReadPositions(exchange)
+	if err != nil {
+		return 0, err
+	}
+	return positions[market].Cost, nil
+}
+
+// Report returns the positions (sorted by market) with their age and their distance to the target. the ticker is
+// optional: if we cannot get it (not even from the oracles), then we leave the distance out.
+func (positions Positions) Report(exchange model.Exchange, client interface{}, mult multiplier.Mult) []PositionReport {
+	unprotected, _ := GetUnprotected(exchange)
+	// the exchanges without an all-tickers endpoint return ErrNoTickers, and then we ask per position below
+	var tickers map[string]float64
+	if client != nil {
+		tickers, _ = exchange.GetTickers(client)
+	}
+	var out []PositionReport
+	for market, pos := range positions {
+		report := PositionReport{
+			Market:   market,
+			Size:     pos.Size,
+			Cost:     pos.Cost,
+			OpenedAt: pos.OpenedAt,
+			Target:   pos.Cost * float64(mult),
+		}
+		for _, entry := range unprotected {
+			if entry.Market == market {
+				report.Unprotected = true
+			}
+		}
+		if !pos.OpenedAt.IsZero() {
+			report.Age = humanize.Age(pos.OpenedAt)
+		}
+		if client != nil {
+			ticker, ok := tickers[market]
+			if !ok {
+				var err error
+				if ticker, err = exchange.GetTicker(client, market); err != nil {
+					ticker = 0
+				}
+			}
+			// if the exchange doesn't tell us, then we ask the oracles (if you included --oracle)
+			if ticker <= 0 {
+				if _, ok := flag.Oracle(); ok {
+					if markets, err := exchange.GetMarkets(true, false, nil); err == nil {
+						if base, quote, err := model.ParseMarket(markets, market); err == nil {
+							ticker, _, _ = GetFairPrice(base, quote)
+						}
+					}
+				}
+			}
+			if ticker > 0 {
+				report.Ticker = ticker
+				report.Distance = humanize.Distance(ticker, report.Target)
+			}
+			if conditionals, ok := exchange.(model.Conditionals); ok {
+				if stops, err := conditionals.GetOpenConditionals(client, market); err == nil {
+					for _, stop := range stops {
+						if stop.Side == model.SELL && stop.Stop > report.Stop {
+							report.Stop = stop.Stop
+						}
+					}
+				}
+			}
+		}
+		out = append(out, report)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Market < out[j].Market
+	})
+	return out
+}
+
+// Without returns a copy of the positions minus the market
+func (positions Positions) Without(market string) Positions {
+	out := make(Positions)
+	for key, value := range positions {
+		if key != market {
+			out[key] = value
+		}
+	}
+	return out
+}
@@ -0,0 +1,75 @@
+package exchanges
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/session"
+)
+
+// the fills we have handled, so that we don't handle them again after a restart (or when the exchange replays
+// its history to us). we forget about them after this long.
+const processedRetention = 90 * 24 * time.Hour
+
+var processedMutex sync.Mutex
+
+func processedFile(exchange model.Exchange) string {
+	return session.GetSessionFile(strings.ToLower(exchange.GetInfo().Code) + ".processed.json")
+}
+
+func readProcessed(exchange model.Exchange) (map[string]time.Time, error) {
+	out := make(map[string]time.Time)
+	data, err := session.ReadFile(processedFile(exchange))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return out, nil
+		}
+		return nil, errors.Wrap(err, 1)
+	}
+	if err = json.Unmarshal(data, &out); err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+	return out, nil
+}
+
+// processOnce returns true if we haven't processed this fill before, and remembers that we are processing it now.
+// returns false if we have, in which case the caller should leave the fill alone.
+func processOnce(exchange model.Exchange, id string) bool {
+	processedMutex.Lock()
+	defer processedMutex.Unlock()
+
+	processed, err := readProcessed(exchange)
+	if err != nil {
+		// better to process a fill twice than to leave a position without a sell order
+		log.Printf("[ERROR] %v", err)
+		return true
+	}
+
+	if _, ok := processed[id]; ok {
+		log.Printf("[WARN] Ignoring fill %s because it has been processed before\n", id)
+		return false
+	}
+
+	for key, at := range processed {
+		if time.Since(at) > processedRetention {
+			delete(processed, key)
+		}
+	}
+	processed[id] = time.Now()
+
+	var data []byte
+	if data, err = json.Marshal(processed); err == nil {
+		err = session.WriteFile(processedFile(exchange), data)
+	}
+	if err != nil {
+		log.Printf("[ERROR] %v", err)
+	}
+
+	return true
+}
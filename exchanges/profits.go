@@ -0,0 +1,340 @@
+package exchanges
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/multiplier"
+	"github.com/svanas/nefertiti/precision"
+	"github.com/svanas/nefertiti/session"
+)
+
+type (
+	Profit struct {
+		Market string    `json:"market"`
+		Quote  string    `json:"quote"`
+		Amount float64   `json:"amount"` // in quote currency
+		Time   time.Time `json:"time"`
+	}
+	Stake struct {
+		Quote  string  `json:"quote"`
+		Amount float64 `json:"amount"` // in quote currency
+	}
+	Profits struct {
+		Entries []Profit           `json:"entries"`
+		Total   map[string]float64 `json:"total"`             // quote currency -> realized profit
+		Skim    map[string]float64 `json:"skim"`              // quote currency -> profit that we have yet to skim
+		Skimmed map[string]float64 `json:"skimmed,omitempty"` // quote currency -> profit that we have skimmed
+		Staked  map[string]Stake   `json:"staked,omitempty"`  // market -> profit that --compound put into its buys
+	}
+)
+
+// we keep the last so many entries, but the totals are forever
+const profitsMax = 1000
+
+func profitsFile(exchange model.Exchange) string {
+	return session.GetSessionFile(strings.ToLower(exchange.GetInfo().Code) + ".profits.json")
+}
+
+func readProfits(exchange model.Exchange) (*Profits, error) {
+	var out Profits
+	data, err := session.ReadFile(profitsFile(exchange))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, errors.Wrap(err, 1)
+		}
+	} else {
+		if err = json.Unmarshal(data, &out); err != nil {
+			return nil, errors.Wrap(err, 1)
+		}
+	}
+	if out.Total == nil {
+		out.Total = make(map[string]float64)
+	}
+	if out.Skim == nil {
+		out.Skim = make(map[string]float64)
+	}
+	if out.Skimmed == nil {
+		out.Skimmed = make(map[string]float64)
+	}
+	if out.Staked == nil {
+		out.Staked = make(map[string]Stake)
+	}
+	return &out, nil
+}
+
+func (profits *Profits) write(exchange model.Exchange) error {
+	data, err := json.Marshal(profits)
+	if err != nil {
+		return errors.Wrap(err, 1)
+	}
+	return session.WriteFile(profitsFile(exchange), data)
+}
+
+// recordProfit adds a realized profit (in quote currency) to our journal
+func recordProfit(exchange model.Exchange, client interface{}, market string, amount float64) {
+	if err := func() error {
+		if amount == 0 {
+			return nil
+		}
+
+		markets, err := exchange.GetMarkets(true, flag.Sandbox(), nil)
+		if err != nil {
+			return err
+		}
+
+		quote, err := model.GetQuoteCurr(markets, market)
+		if err != nil {
+			return err
+		}
+		quote = strings.ToUpper(quote)
+
+		profits, err := readProfits(exchange)
+		if err != nil {
+			return err
+		}
+
+		profits.Entries = append(profits.Entries, Profit{
+			Market: market,
+			Quote:  quote,
+			Amount: amount,
+			Time:   time.Now(),
+		})
+		if len(profits.Entries) > profitsMax {
+			profits.Entries = profits.Entries[len(profits.Entries)-profitsMax:]
+		}
+		profits.Total[quote] += amount
+
+		// the position is closed, so whatever --compound put into it is available again
+		delete(profits.Staked, market)
+
+		// --skim=X takes X% of the profit off the table
+		rate, err := flag.Skim()
+		if err != nil {
+			return err
+		}
+		if rate > 0 && amount > 0 {
+			profits.Skim[quote] += amount * (rate / 100)
+			var done bool
+			if done, err = skim(exchange, client, markets, quote, profits.Skim[quote]); err != nil {
+				log.Printf("[ERROR] %v", err)
+			}
+			if done {
+				if err == nil {
+					profits.Skimmed[quote] += profits.Skim[quote]
+				}
+				profits.Skim[quote] = 0
+			}
+		}
+
+		return profits.write(exchange)
+	}(); err != nil {
+		log.Printf("[ERROR] %v", err)
+	}
+}
+
+// recordTakeProfit adds a filled take-profit (worth notional in quote currency) to our journal
+func recordTakeProfit(exchange model.Exchange, client interface{}, market string, notional float64, mult multiplier.Mult) {
+	if mult <= 1 || notional <= 0 {
+		return
+	}
+	// the position is closed, so the DCA rounds start over
+	resetDca(exchange, market)
+	// we sold at mult * bought, so we made (1 - 1/mult) * sold
+	profit := notional * (1 - 1/float64(mult))
+	// minus the maker fee we paid on the buy and on the sell
+	if fees, err := exchange.GetFees(client, market); err == nil {
+		profit -= fees.Maker * (notional/float64(mult) + notional)
+	}
+	recordProfit(exchange, client, market, profit)
+}
+
+// recordExitProfit adds the profit of a filled take-profit to our journal, now that we know what we paid for it
+func recordExitProfit(exchange model.Exchange, client interface{}, exit *Exit) {
+	// the position is closed, so the DCA rounds start over
+	resetDca(exchange, exit.Market)
+	profit := exit.Profit
+	// minus the maker fee we paid on the buy and on the sell
+	if fees, err := exchange.GetFees(client, exit.Market); err == nil {
+		profit -= fees.Maker * (exit.Bought*exit.Size + exit.Sold*exit.Size)
+	}
+	recordProfit(exchange, client, exit.Market, profit)
+}
+
+// skim converts an amount of quote currency into --skim-to (BTC or a stablecoin). returns false if the amount is
+// below the minimum order size, in which case we will try again after the next profit.
+func skim(exchange model.Exchange, client interface{}, markets []model.Market, quote string, amount float64) (bool, error) {
+	// a market order on a futures exchange opens a leveraged position
+	if _, ok := exchange.(model.Futures); ok {
+		return true, errors.Errorf("%s does not support --skim", exchange.GetInfo().Name)
+	}
+
+	asset := flag.SkimTo()
+	if strings.EqualFold(asset, quote) {
+		return true, nil // the profit is in the asset we want already
+	}
+
+	var (
+		err    error
+		side   model.OrderSide
+		market string
+		size   float64
+		price  float64
+	)
+	if m := model.FindMarket(markets, model.NewMarketSymbol(asset, quote)); m != nil {
+		market = m.Name
+		// buy the asset with our quote currency
+		side = model.BUY
+		if price, err = exchange.GetTicker(client, market); err != nil {
+			return false, err
+		}
+		size = amount / price
+	} else if m := model.FindMarket(markets, model.NewMarketSymbol(quote, asset)); m != nil {
+		market = m.Name
+		// sell our quote currency for the asset
+		side = model.SELL
+		if price, err = exchange.GetTicker(client, market); err != nil {
+			return false, err
+		}
+		size = amount
+	} else {
+		return true, errors.Errorf("cannot skim %s into %s. market not found", quote, asset)
+	}
+
+	prec, err := exchange.GetSizePrec(client, market)
+	if err != nil {
+		return false, err
+	}
+	size = precision.Floor(size, prec)
+
+	// do not bump the order to the minimum size, we would be skimming more than we made
+	if sizer, ok := exchange.(minSizer); ok {
+		var min float64
+		if min, err = sizer.minSize(client, market, size, price); err != nil {
+			return false, err
+		}
+		if min > size {
+			return false, nil
+		}
+	}
+	if size == 0 {
+		return false, nil
+	}
+
+	if _, _, err = exchange.Order(client, side, market, size, 0, model.MARKET, ""); err != nil {
+		return false, err
+	}
+
+	log.Printf("[INFO] Skimmed %f %s into %s\n", amount, quote, asset)
+
+	return true, nil
+}
+
+// journalsProfits returns true if the exchange adds its filled take-profits to our journal. --compound restakes what is
+// in that journal, so it doesn't do anything on the other exchanges.
+func journalsProfits(exchange model.Exchange) bool {
+	switch exchange.(type) {
+	case *Binance, *BitMEX, *Bittrex, *Deribit, *Jupiter, *Kucoin, *OneInch:
+		return true
+	}
+	return false
+}
+
+// ValidateCompound returns an error if you asked for --compound on an exchange that doesn't journal its profits
+func ValidateCompound(exchange model.Exchange) error {
+	rate, err := flag.Compound()
+	if err != nil || rate == 0 {
+		return err
+	}
+	if !journalsProfits(exchange) {
+		return errors.Errorf("%s does not support --compound", exchange.GetInfo().Name)
+	}
+	return nil
+}
+
+// ReleaseCompound makes the profit that --compound put into these markets available to the other markets again,
+// for example: when we have cancelled their buy orders.
+func ReleaseCompound(exchange model.Exchange, markets []string) error {
+	if rate, err := flag.Compound(); err != nil || rate == 0 {
+		return err
+	}
+	profits, err := readProfits(exchange)
+	if err != nil {
+		return err
+	}
+	for _, market := range markets {
+		delete(profits.Staked, market)
+	}
+	return profits.write(exchange)
+}
+
+// Compound returns the quote notional per order, grown by --compound=X percent of our realized profits (minus our
+// realized losses, minus what we have skimmed) on this quote currency. the extra notional is spread over the top X orders of the ladder. we keep track of the profit we
+// have put into every market, so that two markets do not restake the same profit.
+func Compound(exchange model.Exchange, market string, notional float64, top int64) (float64, error) {
+	rate, err := flag.Compound()
+	if err != nil {
+		return notional, err
+	}
+	if rate == 0 || notional == 0 {
+		return notional, nil
+	}
+
+	markets, err := exchange.GetMarkets(true, flag.Sandbox(), nil)
+	if err != nil {
+		return notional, err
+	}
+
+	quote, err := model.GetQuoteCurr(markets, market)
+	if err != nil {
+		return notional, err
+	}
+
+	profits, err := readProfits(exchange)
+	if err != nil {
+		return notional, err
+	}
+
+	losses, err := readLosses(exchange)
+	if err != nil {
+		return notional, err
+	}
+
+	quote = strings.ToUpper(quote)
+
+	// the profit we still have: minus our stop-losses, minus what --skim took (or will take) off the table
+	kept := profits.Total[quote] - losses.total()[quote] - profits.Skimmed[quote] - profits.Skim[quote]
+	if kept < 0 {
+		kept = 0
+	}
+
+	// what we can restake, minus what the other markets in this quote currency have restaked already
+	available := (rate / 100) * kept
+	for other, stake := range profits.Staked {
+		if other != market && stake.Quote == quote {
+			available -= stake.Amount
+		}
+	}
+	if available <= 0 {
+		available = 0
+	}
+
+	if profits.Staked[market].Amount != available {
+		profits.Staked[market] = Stake{Quote: quote, Amount: available}
+		if err = profits.write(exchange); err != nil {
+			return notional, err
+		}
+	}
+
+	if top < 1 {
+		top = 1
+	}
+
+	return notional + (available / float64(top)), nil
+}
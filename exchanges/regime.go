@@ -0,0 +1,110 @@
+package exchanges
+
+import (
+	"log"
+	"time"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/model"
+)
+
+// when BTC dumps, every alt dips at once, and a ladder on every market would put all of our capital into positions
+// that move together. the market regime tells us to spread less: once the benchmark (--regime-market, defaults to
+// BTC against your --quote) is down more than --regime-drop percent intraday, we buy --regime-max markets only.
+
+// regimeMarket returns the benchmark market
+func regimeMarket(exchange model.Exchange) (string, error) {
+	if arg := flag.Get("regime-market"); arg.Exists && arg.String() != "" {
+		return arg.String(), nil
+	}
+	quote := flag.Get("quote").String()
+	if quote == "" {
+		return "", errors.New("missing argument: regime-market")
+	}
+	return exchange.FormatMarket("BTC", quote), nil
+}
+
+// intraday returns the change (in %) of a market since the start of the day (UTC)
+func intraday(exchange model.Exchange, client interface{}, market string) (float64, error) {
+	stats, err := exchange.Get24h(client, market)
+	if err != nil {
+		return 0, err
+	}
+	if stats.Change != 0 {
+		return stats.Change, nil
+	}
+	if change := model.PercentChange(stats.Open, stats.Last); change != 0 {
+		return change, nil
+	}
+	// the exchange doesn't tell us? then we ask the candle store
+	now := time.Now().UTC()
+	candles, err := GetCandles(exchange, client, market, time.Hour, now.Truncate(24*time.Hour), now)
+	if err != nil {
+		return 0, err
+	}
+	if len(candles) == 0 {
+		return 0, nil
+	}
+	ticker, err := exchange.GetTicker(client, market)
+	if err != nil {
+		return 0, err
+	}
+	return model.PercentChange(candles[0].Open, ticker), nil
+}
+
+// RegimeMarkets splits the markets into the ones to buy and the ones to drop while the benchmark is down. the markets
+// that we have a position in (aka an open sell order) count towards max: we don't add to them, and they leave fewer
+// markets to buy.
+func RegimeMarkets(exchange model.Exchange, client interface{}, markets []string, max int) (keep, drop []string, err error) {
+	var positioned model.Markets
+	for _, market := range markets {
+		opened, err := exchange.GetOpened(client, market)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, order := range opened {
+			if order.Side == model.SELL {
+				positioned = append(positioned, market)
+				break
+			}
+		}
+	}
+	slots := max - len(positioned)
+	for _, market := range markets {
+		if slots > 0 && !positioned.HasMarket(market) {
+			keep = append(keep, market)
+			slots--
+		} else {
+			drop = append(drop, market)
+		}
+	}
+	return keep, drop, nil
+}
+
+// RegimeMax returns the max number of markets to buy, or -1 if there is no limit
+func RegimeMax(exchange model.Exchange, client interface{}) (int, error) {
+	drop, max, err := flag.Regime()
+	if err != nil {
+		return -1, err
+	}
+	if drop == 0 {
+		return -1, nil
+	}
+
+	market, err := regimeMarket(exchange)
+	if err != nil {
+		return -1, err
+	}
+
+	change, err := intraday(exchange, client, market)
+	if err != nil {
+		return -1, err
+	}
+	if change > -drop {
+		return -1, nil
+	}
+
+	log.Printf("[WARN] %s is down %.2f%% today. Buying %d market(s) only.\n", market, -change, max)
+	return int(max), nil
+}
@@ -0,0 +1,98 @@
+package exchanges
+
+import (
+	"sync"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/model"
+)
+
+// the registry is the list of supported exchanges. what they can do, we tell from the interfaces they implement.
+
+// Capabilities is what an exchange can do
+type Capabilities struct {
+	Code           string   `json:"code"`
+	Name           string   `json:"name"`
+	Country        string   `json:"country,omitempty"`
+	Strategies     []string `json:"strategies"`
+	Stop           bool     `json:"stop"`
+	OCO            bool     `json:"oco"`
+	TrailingStop   bool     `json:"trailing_stop"`
+	TickSize       bool     `json:"tick_size"` // the price increment isn't necessarily a power of ten
+	Futures        bool     `json:"futures"`
+	Sandbox        bool     `json:"sandbox"`
+	WebSocket      bool     `json:"websocket"`
+	Balances       bool     `json:"balances"`
+	Candles        bool     `json:"candles"`
+	KeyPermissions bool     `json:"key_permissions"`
+	AccountEvents  bool     `json:"account_events"`
+}
+
+var registry = []func() model.Exchange{
+	newGdax,
+	newBittrex,
+	newBitstamp,
+	newCexIo,
+	newBinance,
+	newBinanceUS,
+	newHitBTC,
+	newKucoin,
+	newCryptoDotCom,
+	newWoo,
+	newHuobi,
+	newUpbit,
+	newLuno,
+	newCoinEx,
+	newDeribit,
+	newBitMEX,
+	newJupiter,
+	newOneInch,
+}
+
+var (
+	registered     map[string]func() model.Exchange // indexed by code
+	registeredOnce sync.Once
+)
+
+// getRegistered returns the constructor of the exchange with this code, or nil if it is not registered
+func getRegistered(code string) func() model.Exchange {
+	registeredOnce.Do(func() {
+		registered = make(map[string]func() model.Exchange)
+		for _, new := range registry {
+			registered[new().GetInfo().Code] = new
+		}
+	})
+	return registered[code]
+}
+
+// GetCapabilities returns what an exchange can do, from the interfaces it implements
+func GetCapabilities(exchange model.Exchange) (*Capabilities, error) {
+	info := exchange.GetInfo()
+	if getRegistered(info.Code) == nil {
+		return nil, errors.Errorf("exchange %s is not registered", info.Name)
+	}
+	out := &Capabilities{
+		Code:       info.Code,
+		Name:       info.Name,
+		Country:    info.Country,
+		Strategies: []string{"standard"},
+		Sandbox:    info.REST.Sandbox != "",
+		WebSocket:  usesWebSocket(exchange),
+	}
+	if reader, ok := exchange.(model.StopReader); ok {
+		stops := reader.GetStops()
+		if stops.Strategy {
+			out.Strategies = append(out.Strategies, "stop-loss")
+		}
+		out.Stop = stops.Stop
+		out.OCO = stops.OCO
+	}
+	_, out.TrailingStop = exchange.(model.TrailingStop)
+	_, out.TickSize = exchange.(model.TickSize)
+	_, out.Futures = exchange.(model.Futures)
+	_, out.Balances = exchange.(model.BalanceReader)
+	_, out.Candles = exchange.(model.CandleReader)
+	_, out.KeyPermissions = exchange.(model.KeyReader)
+	_, out.AccountEvents = exchange.(model.AccountMonitor)
+	return out, nil
+}
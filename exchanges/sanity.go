@@ -0,0 +1,46 @@
+package exchanges
+
+import (
+	"math"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/model"
+)
+
+// CheckPrice refuses a limit price that deviates more than --sanity=X percent from an independent reference price,
+// protecting you from fat fingers and bad signals. the check is disabled unless you include --sanity.
+func CheckPrice(exchange model.Exchange, market string, side model.OrderSide, price float64, sandbox bool) error {
+	max, err := flag.Sanity()
+	if err != nil {
+		return err
+	}
+
+	// market orders have no price, and testnet prices are anything but realistic
+	if max == 0 || price == 0 || sandbox {
+		return nil
+	}
+
+	markets, err := exchange.GetMarkets(true, sandbox, nil)
+	if err != nil {
+		return err
+	}
+
+	base, quote, err := model.ParseMarket(markets, market)
+	if err != nil {
+		return err
+	}
+
+	ref, oracle, err := GetFairPrice(base, quote)
+	if err != nil {
+		return err
+	}
+
+	deviation := math.Abs(price-ref) / ref * 100
+	if deviation > max {
+		return errors.Errorf("Cannot %s %s. Price %v deviates %.2f%% from reference price %v (%s).",
+			model.FormatOrderSide(side), market, price, deviation, ref, oracle)
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package exchanges
+
+import (
+	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/precision"
+)
+
+// exchanges that bump an order to their minimum size (or notional value) implement this interface
+type minSizer interface {
+	minSize(client interface{}, market string, size, price float64) (float64, error)
+}
+
+// QuoteToSize converts an amount of quote currency into a size (in base currency) at the given price (or the ticker
+// if price is zero). The size gets rounded to the precision of the market, and bumped to the minimum size (or notional
+// value) if the exchange has one.
+func QuoteToSize(exchange model.Exchange, client interface{}, market string, quote, price float64) (float64, error) {
+	var err error
+	if price == 0 {
+		if price, err = exchange.GetTicker(client, market); err != nil {
+			return 0, err
+		}
+	}
+
+	prec, err := exchange.GetSizePrec(client, market)
+	if err != nil {
+		return 0, err
+	}
+
+	out := precision.Round((quote / price), prec)
+
+	if sizer, ok := exchange.(minSizer); ok {
+		if out, err = sizer.minSize(client, market, out, price); err != nil {
+			return 0, err
+		}
+	}
+
+	return out, nil
+}
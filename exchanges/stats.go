@@ -0,0 +1,43 @@
+package exchanges
+
+import (
+	"strings"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/model"
+)
+
+// ErrNoSummaries is Get24hAll for the exchanges that do not have a summaries endpoint. the caller is expected to
+// filter the markets first, and then ask Get24h for the markets that are left.
+var ErrNoSummaries = errors.New("this exchange does not have a summaries endpoint")
+
+func get24hAll(exchange model.Exchange, client interface{}) (map[string]model.Stats, error) {
+	return nil, ErrNoSummaries
+}
+
+// setBtcVolume converts the 24h volume (in quote currency) of every market into BTC, using the last price of the
+// BTC market of that quote currency.
+func setBtcVolume(exchange model.Exchange, stats map[string]model.Stats, volume map[string]float64) error {
+	markets, err := exchange.GetMarkets(true, flag.Sandbox(), nil)
+	if err != nil {
+		return err
+	}
+	for _, market := range markets {
+		entry, ok := stats[market.Name]
+		if !ok {
+			continue
+		}
+		if strings.EqualFold(market.Quote, model.BTC) {
+			entry.BtcVolume = volume[market.Name]
+		} else if m := model.FindMarket(markets, model.NewMarketSymbol(model.BTC, market.Quote)); m != nil {
+			if last := stats[m.Name].Last; last > 0 {
+				entry.BtcVolume = volume[market.Name] / last
+			}
+		} else if m := model.FindMarket(markets, model.NewMarketSymbol(market.Quote, model.BTC)); m != nil {
+			entry.BtcVolume = volume[market.Name] * stats[m.Name].Last
+		}
+		stats[market.Name] = entry
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+package exchanges
+
+import (
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/model"
+)
+
+// ErrNoTickers is GetTickers for the exchanges that do not have an all-tickers endpoint. asking for the ticker of
+// every market, one at a time, is hundreds of requests (and thousands of quotes on a DEX aggregator), so we leave it
+// up to the caller to ask for the tickers of the markets it needs.
+var ErrNoTickers = errors.New("this exchange does not have an all-tickers endpoint")
+
+func getTickers(exchange model.Exchange, client interface{}) (map[string]float64, error) {
+	return nil, ErrNoTickers
+}
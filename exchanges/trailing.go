@@ -0,0 +1,72 @@
+package exchanges
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/notify"
+	"github.com/svanas/nefertiti/precision"
+)
+
+// how often we look at the ticker when we trail a stop-loss ourselves
+const trailingInterval = 10 * time.Second
+
+// Trail sells size at market once the price drops percent below its highest price. we prefer the exchanges that
+// trail the stop server-side. for the other exchanges, we watch the ticker ourselves, and then this blocks until we
+// have sold.
+func Trail(exchange model.Exchange, client interface{}, market string, size, percent float64, service model.Notify) ([]byte, error) {
+	if percent <= 0 || percent >= 100 {
+		return nil, errors.Errorf("percent %v is invalid", percent)
+	}
+
+	if native, ok := exchange.(model.TrailingStop); ok {
+		return native.TrailingStop(client, market, size, percent, "")
+	}
+
+	high, err := exchange.GetTicker(client, market)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[INFO] %s does not support trailing stops. Trailing %s %.2f%% below %s ourselves...\n", exchange.GetInfo().Name, market, percent, precision.FormatFloat(high, -1))
+
+	for range time.Tick(trailingInterval) {
+		ticker, err := exchange.GetTicker(client, market)
+		if err != nil {
+			log.Printf("[ERROR] %v", err)
+			continue
+		}
+		if ticker > high {
+			high = ticker
+			continue
+		}
+		if ticker <= high*(1-percent/100) {
+			break
+		}
+	}
+
+	_, raw, err := exchange.Order(client, model.SELL, market, size, 0, model.MARKET, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if service != nil {
+		msg := fmt.Sprintf("Your trailing stop on %s got triggered %.2f%% below %s.", market, percent, model.FormatPrice(exchange, client, market, high))
+		if err := notify.Critical(service, notify.EVENT_STOP_LOSS, msg, (exchange.GetInfo().Name + " - Stop Loss")); err != nil {
+			log.Printf("[ERROR] %v", err)
+		}
+	}
+
+	if raw == nil {
+		raw, _ = json.Marshal(struct {
+			Market string  `json:"market"`
+			Size   float64 `json:"size"`
+			High   float64 `json:"high"`
+		}{market, size, high})
+	}
+
+	return raw, nil
+}
@@ -0,0 +1,105 @@
+package exchanges
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/session"
+)
+
+// Unprotected is a limit sell that we failed to attach a stop-loss to. we keep on telling you about it until the
+// limit sell is no longer open.
+type Unprotected struct {
+	Market  string    `json:"market"`
+	OrderId string    `json:"order_id"` // the limit sell
+	Size    float64   `json:"size"`
+	Price   float64   `json:"price"`
+	Stop    float64   `json:"stop"` // the trigger price of the missing stop-loss
+	Error   string    `json:"error"`
+	Time    time.Time `json:"time"`
+	Run     string    `json:"run,omitempty"` // the run ID (and label) of the loop that opened the limit sell
+}
+
+func unprotectedFile(exchange model.Exchange) string {
+	return session.GetSessionFile(strings.ToLower(exchange.GetInfo().Code) + ".unprotected.json")
+}
+
+func readUnprotected(exchange model.Exchange) (map[string]Unprotected, error) {
+	out := make(map[string]Unprotected)
+	data, err := session.ReadFile(unprotectedFile(exchange))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return out, nil
+		}
+		return nil, errors.Wrap(err, 1)
+	}
+	if err = json.Unmarshal(data, &out); err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+	return out, nil
+}
+
+func writeUnprotected(exchange model.Exchange, entries map[string]Unprotected) error {
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return errors.Wrap(err, 1)
+	}
+	return session.WriteFile(unprotectedFile(exchange), data)
+}
+
+// markUnprotected remembers a limit sell that doesn't have its stop-loss
+func markUnprotected(exchange model.Exchange, entry Unprotected) error {
+	entries, err := readUnprotected(exchange)
+	if err != nil {
+		return err
+	}
+	if entry.Run == "" {
+		entry.Run = session.RunTag()
+	}
+	entries[entry.OrderId] = entry
+	return writeUnprotected(exchange, entries)
+}
+
+// pruneUnprotected forgets about the limit sells that are no longer open, and returns the ones that still are.
+func pruneUnprotected(exchange model.Exchange, isOpen func(id string) bool) ([]Unprotected, error) {
+	entries, err := readUnprotected(exchange)
+	if err != nil {
+		return nil, err
+	}
+	var out []Unprotected
+	for id, entry := range entries {
+		if isOpen(id) {
+			out = append(out, entry)
+		} else {
+			delete(entries, id)
+		}
+	}
+	if err = writeUnprotected(exchange, entries); err != nil {
+		return nil, err
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Time.Before(out[j].Time)
+	})
+	return out, nil
+}
+
+// GetUnprotected returns the limit sells that we failed to attach a stop-loss to
+func GetUnprotected(exchange model.Exchange) ([]Unprotected, error) {
+	entries, err := readUnprotected(exchange)
+	if err != nil {
+		return nil, err
+	}
+	var out []Unprotected
+	for _, entry := range entries {
+		out = append(out, entry)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Time.Before(out[j].Time)
+	})
+	return out, nil
+}
@@ -0,0 +1,983 @@
+//lint:file-ignore ST1006 receiver name should be a reflection of its identity; don't use generic names such as "this" or "self"
+package exchanges
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/svanas/nefertiti/aggregation"
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/i18n"
+	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/multiplier"
+	"github.com/svanas/nefertiti/notify"
+	"github.com/svanas/nefertiti/override"
+	"github.com/svanas/nefertiti/precision"
+	"github.com/svanas/nefertiti/pricing"
+	"github.com/svanas/nefertiti/session"
+	exchange "github.com/svanas/nefertiti/upbit"
+)
+
+var (
+	upbitMutex *session.Mutex
+)
+
+const (
+	upbitSessionFile = "upbit.time"
+	upbitSessionLock = "upbit.lock"
+)
+
+// we support the KRW and the BTC markets only
+var upbitQuotes = []string{"KRW", model.BTC}
+
+// we look a bit further back than we strictly need to, for the clock skew between us and upbit
+const upbitHistoryOverlap = time.Hour
+
+func init() {
+	exchange.BeforeRequest = func(method, path string, rps float64) error {
+		var err error
+
+		if upbitMutex == nil {
+			if upbitMutex, err = session.NewMutex(upbitSessionLock); err != nil {
+				return err
+			}
+		}
+
+		if err = upbitMutex.Lock(); err != nil {
+			return err
+		}
+
+		var lastRequest *time.Time
+		if lastRequest, err = session.GetLastRequest(upbitSessionFile); err != nil {
+			return err
+		}
+
+		if lastRequest != nil {
+			elapsed := time.Since(*lastRequest)
+			if elapsed.Seconds() < (float64(1) / rps) {
+				sleep := time.Duration((float64(time.Second) / rps) - float64(elapsed))
+				if flag.Debug() {
+					log.Printf("[DEBUG] sleeping %f seconds\n", sleep.Seconds())
+				}
+				time.Sleep(sleep)
+			}
+		}
+
+		if flag.Debug() {
+			log.Printf("[DEBUG] %s %s\n", method, path)
+		}
+
+		return nil
+	}
+	exchange.AfterRequest = func() {
+		defer func() {
+			upbitMutex.Unlock()
+		}()
+		session.SetLastRequest(upbitSessionFile, time.Now())
+	}
+}
+
+type Upbit struct {
+	*model.ExchangeInfo
+	markets []exchange.Market
+}
+
+func (self *Upbit) error(err error, level int64, service model.Notify) {
+	pc, file, line, _ := runtime.Caller(1)
+	prefix := errors.FormatCaller(pc, file, line)
+
+	msg := fmt.Sprintf("%s %v", prefix, err)
+	_, ok := err.(*errors.Error)
+	if ok && flag.Debug() {
+		log.Printf("[ERROR] %s", err.(*errors.Error).ErrorStack(prefix, ""))
+	} else {
+		log.Printf("[ERROR] %s", msg)
+	}
+
+	if service != nil {
+		if notify.CanSend(level, notify.ERROR) {
+			err := notify.SendError(service, err, msg, "Upbit - ERROR")
+			if err != nil {
+				log.Printf("[ERROR] %v", err)
+			}
+		}
+	}
+}
+
+func (self *Upbit) indexByUUID(orders []exchange.Order, uuid string) int {
+	for i, o := range orders {
+		if o.UUID == uuid {
+			return i
+		}
+	}
+	return -1
+}
+
+// getOrders returns my orders (in the supported markets) in a state, created since a point in time
+func (self *Upbit) getOrders(client *exchange.Client, state exchange.OrderState, since time.Time) ([]exchange.Order, error) {
+	markets, err := self.getMarkets(client, true)
+	if err != nil {
+		return nil, err
+	}
+	orders, err := client.OrdersSince(state, since)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+	var out []exchange.Order
+	for _, order := range orders {
+		// the last page goes further back than we asked for
+		if order.GetCreatedAt().Before(since) {
+			continue
+		}
+		for _, market := range markets {
+			if market.Market == order.Market {
+				out = append(out, order)
+				break
+			}
+		}
+	}
+	return out, nil
+}
+
+// upbitSince returns the creation time of the oldest order that can have been filled since we last looked: the
+// orders that were open back then, and the orders that are newer than the newest filled order we know about.
+func upbitSince(opened, filled []exchange.Order) time.Time {
+	out := time.Now()
+	for _, order := range opened {
+		if createdAt := order.GetCreatedAt(); !createdAt.IsZero() && createdAt.Before(out) {
+			out = createdAt
+		}
+	}
+	var newest time.Time
+	for _, order := range filled {
+		if createdAt := order.GetCreatedAt(); createdAt.After(newest) {
+			newest = createdAt
+		}
+	}
+	if !newest.IsZero() && newest.Before(out) {
+		out = newest
+	}
+	return out.Add(-upbitHistoryOverlap)
+}
+
+func (self *Upbit) getBaseURL(sandbox bool) string {
+	return self.ExchangeInfo.REST.URI
+}
+
+func (self *Upbit) getMarkets(client *exchange.Client, cached bool) ([]exchange.Market, error) {
+	if self.markets == nil || !cached {
+		markets, err := client.Markets()
+		if err != nil {
+			return nil, errors.Wrap(err, 1)
+		}
+		self.markets = nil
+		for _, market := range markets {
+			_, quote, err := exchange.ParseMarket(market.Market)
+			if err != nil {
+				return nil, errors.Wrap(err, 1)
+			}
+			for _, supported := range upbitQuotes {
+				if strings.EqualFold(quote, supported) {
+					self.markets = append(self.markets, market)
+					break
+				}
+			}
+		}
+	}
+	return self.markets, nil
+}
+
+func (self *Upbit) GetInfo() *model.ExchangeInfo {
+	return self.ExchangeInfo
+}
+
+func (self *Upbit) GetClient(permission model.Permission, sandbox bool) (interface{}, error) {
+	if sandbox {
+		return nil, errors.New("sandbox not supported")
+	}
+
+	if permission == model.PUBLIC {
+		return exchange.New(self.getBaseURL(sandbox), "", ""), nil
+	}
+
+	accessKey, secretKey, err := promptForApiKeys("Upbit")
+	if err != nil {
+		return nil, err
+	}
+
+	return exchange.New(self.getBaseURL(sandbox), accessKey, secretKey), nil
+}
+
+func (self *Upbit) GetMarkets(cached, sandbox bool, blacklist []string) ([]model.Market, error) {
+	var out []model.Market
+
+	markets, err := self.getMarkets(exchange.New(self.getBaseURL(sandbox), "", ""), cached)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, market := range markets {
+		base, quote, err := exchange.ParseMarket(market.Market)
+		if err != nil {
+			return nil, errors.Wrap(err, 1)
+		}
+		if func() bool {
+			for _, ignore := range blacklist {
+				if strings.EqualFold(market.Market, ignore) {
+					return false
+				}
+			}
+			return true
+		}() {
+			out = append(out, model.Market{
+				Name:  market.Market,
+				Base:  base,
+				Quote: quote,
+			})
+		}
+	}
+
+	return out, nil
+}
+
+func (self *Upbit) FormatMarket(base, quote string) string {
+	return exchange.FormatMarket(base, quote)
+}
+
+// listen to the opened orders, look for cancelled orders, send a notification.
+func (self *Upbit) listen(
+	client *exchange.Client,
+	service model.Notify,
+	level int64,
+	old []exchange.Order,
+	filled []exchange.Order,
+) ([]exchange.Order, error) {
+	// get my opened orders
+	new, err := self.getOrders(client, exchange.OrderStateWait, time.Time{})
+	if err != nil {
+		return old, err
+	}
+
+	// look for cancelled orders
+	for _, order := range old {
+		if self.indexByUUID(new, order.UUID) == -1 {
+			// if this order has NOT been FILLED, then it has been cancelled.
+			if self.indexByUUID(filled, order.UUID) == -1 {
+				data, err := json.Marshal(order)
+				if err != nil {
+					return new, errors.Wrap(err, 1)
+				}
+
+				log.Println("[CANCELLED] " + string(data))
+
+				if service != nil {
+					if notify.CanSend(level, notify.CANCELLED) {
+						err := service.SendMessage(order, fmt.Sprintf("Upbit - %s", i18n.Sprintf("Done %s (Reason: Cancelled)", self.formatSide(order.Side))), model.ALWAYS)
+						if err != nil {
+							log.Printf("[ERROR] %v", err)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	// look for newly opened orders
+	for _, order := range new {
+		if self.indexByUUID(old, order.UUID) == -1 {
+			data, err := json.Marshal(order)
+			if err != nil {
+				return new, errors.Wrap(err, 1)
+			}
+
+			log.Println("[OPEN] " + string(data))
+
+			if service != nil {
+				if notify.CanSend(level, notify.OPENED) || (level == notify.LEVEL_DEFAULT && order.Side == exchange.OrderSideAsk) {
+					err := service.SendMessage(order, fmt.Sprintf("Upbit - %s", i18n.Sprintf("Open %s", self.formatSide(order.Side))), model.ALWAYS)
+					if err != nil {
+						log.Printf("[ERROR] %v", err)
+					}
+				}
+			}
+		}
+	}
+
+	return new, nil
+}
+
+func (self *Upbit) formatSide(side exchange.OrderSide) string {
+	if side == exchange.OrderSideAsk {
+		return model.FormatOrderSide(model.SELL)
+	}
+	return model.FormatOrderSide(model.BUY)
+}
+
+// listen to the filled orders, look for newly filled orders, automatically place new LIMIT SELL orders.
+func (self *Upbit) sell(
+	client *exchange.Client,
+	mult multiplier.Mult,
+	hold, earn model.Markets,
+	service model.Notify,
+	level int64,
+	old []exchange.Order,
+	opened []exchange.Order,
+) ([]exchange.Order, error) {
+	// get my filled orders, but only the ones that can have been filled since we last looked
+	since := upbitSince(opened, old)
+	filled, err := self.getOrders(client, exchange.OrderStateDone, since)
+	if err != nil {
+		return old, err
+	}
+
+	// keep the orders we have seen before (and that are still inside the window), so they do not look "new" to us
+	for _, order := range old {
+		if self.indexByUUID(filled, order.UUID) == -1 && !order.GetCreatedAt().Before(since) {
+			filled = append(filled, order)
+		}
+	}
+
+	// make a list of newly filled orders
+	var new []exchange.Order
+	for _, order := range filled {
+		if self.indexByUUID(old, order.UUID) == -1 && processOnce(self, order.UUID) {
+			new = append(new, order)
+		}
+	}
+
+	// send notification(s)
+	for _, order := range new {
+		data, err := json.Marshal(order)
+		if err != nil {
+			self.error(err, level, service)
+		} else {
+			log.Println("[FILLED] " + string(data))
+			if notify.CanSend(level, notify.FILLED) && service != nil {
+				err := service.SendMessage(order, fmt.Sprintf("Upbit - %s", i18n.Sprintf("Done %s (Reason: Filled)", self.formatSide(order.Side))), model.ALWAYS)
+				if err != nil {
+					log.Printf("[ERROR] %v", err)
+				}
+			}
+		}
+	}
+
+	// has a buy order been filled? then place a sell order
+	for i := 0; i < len(new); i++ {
+		if new[i].Side == exchange.OrderSideBid {
+			qty := new[i].GetExecutedVolume()
+
+			// add up amount(s), hereby preventing a problem with partial matches
+			n := i + 1
+			for n < len(new) {
+				if new[n].Market == new[i].Market && new[n].Side == new[i].Side && new[n].Price == new[i].Price {
+					qty = qty + new[n].GetExecutedVolume()
+					new = append(new[:n], new[n+1:]...)
+				} else {
+					n++
+				}
+			}
+
+			// get base currency and desired size, calculate price, place sell order
+			base, quote, err := exchange.ParseMarket(new[i].Market)
+			if err == nil {
+				qty = self.GetMaxSize(client, base, quote, hold.HasMarket(new[i].Market), earn.HasMarket(new[i].Market), qty, override.Mult(new[i].Market, mult))
+				if qty > 0 {
+					var tick float64
+					tick, err = model.GetTickSize(self, client, new[i].Market)
+					if err == nil {
+						_, err = client.Order(
+							new[i].Market,
+							exchange.OrderSideAsk,
+							exchange.OrderTypeLimit,
+							qty,
+							exchange.RoundPrice(quote, pricing.MultiplyTick(new[i].ExecutedAt(), override.Mult(new[i].Market, mult), tick)),
+						)
+					}
+				}
+			}
+
+			if err != nil {
+				data, _ := json.Marshal(new[i])
+				if data == nil {
+					self.error(err, level, service)
+				} else {
+					self.error(errors.Append(err, "\t", string(data)), level, service)
+				}
+			}
+		}
+	}
+
+	return filled, nil
+}
+
+func (self *Upbit) Sell(
+	strategy model.Strategy,
+	hold, earn model.Markets,
+	sandbox, tweet, debug bool,
+	success model.OnSuccess,
+) error {
+	if strategy != model.STRATEGY_STANDARD {
+		return errors.New("strategy not implemented")
+	}
+
+	accessKey, secretKey, err := promptForApiKeys("Upbit")
+	if err != nil {
+		return err
+	}
+
+	service, err := notify.New().Init(flag.Interactive(), true)
+	if err != nil {
+		return err
+	}
+
+	client := exchange.New(self.getBaseURL(sandbox), accessKey, secretKey)
+
+	// get my opened orders
+	opened, err := self.getOrders(client, exchange.OrderStateWait, time.Time{})
+	if err != nil {
+		return err
+	}
+
+	// get my filled orders, but only the ones that can still be news to us
+	filled, err := self.getOrders(client, exchange.OrderStateDone, upbitSince(opened, nil))
+	if err != nil {
+		return err
+	}
+
+	if err = success(service); err != nil {
+		return err
+	}
+
+	for {
+		// read the dynamic settings
+		var (
+			level int64 = notify.LEVEL_DEFAULT
+			mult  multiplier.Mult
+		)
+		if level, err = notify.Level(); err != nil {
+			self.error(err, level, service)
+		} else if mult, err = multiplier.Get(multiplier.FIVE_PERCENT); err != nil {
+			self.error(err, level, service)
+		} else
+		// listen to the filled orders, look for newly filled orders, automatically place new LIMIT SELL orders.
+		if filled, err = self.sell(client, mult, hold, earn, service, level, filled, opened); err != nil {
+			self.error(err, level, service)
+		} else
+		// listen to the opened orders, look for cancelled orders, send a notification.
+		if opened, err = self.listen(client, service, level, opened, filled); err != nil {
+			self.error(err, level, service)
+		}
+		beat(self.GetInfo().Name, err)
+	}
+}
+
+func (self *Upbit) Order(
+	client interface{},
+	side model.OrderSide,
+	market string,
+	size float64,
+	price float64,
+	kind model.OrderType,
+	metadata string,
+) (oid []byte, raw []byte, err error) {
+	if raw, ok := observeOrder(self, client, side, market, size, price, kind); ok {
+		return nil, raw, nil
+	}
+
+	if err := CheckEquityShare(self, client, side, market, size, price); err != nil {
+		return nil, nil, err
+	}
+
+	upbitClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, nil, errors.New("invalid argument: client")
+	}
+
+	_, quote, err := exchange.ParseMarket(market)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, 1)
+	}
+
+	var order *exchange.Order
+	if side == model.BUY {
+		if kind == model.MARKET {
+			// market buy orders are placed for a total (in quote currency) rather than a size
+			var ticker float64
+			if ticker, err = self.GetTicker(client, market); err != nil {
+				return nil, nil, err
+			}
+			order, err = upbitClient.Order(market, exchange.OrderSideBid, exchange.OrderTypePrice, 0, size*ticker)
+		} else {
+			order, err = upbitClient.Order(market, exchange.OrderSideBid, exchange.OrderTypeLimit, size, exchange.RoundPrice(quote, price))
+		}
+	} else {
+		if kind == model.MARKET {
+			order, err = upbitClient.Order(market, exchange.OrderSideAsk, exchange.OrderTypeMarket, size, 0)
+		} else {
+			order, err = upbitClient.Order(market, exchange.OrderSideAsk, exchange.OrderTypeLimit, size, exchange.RoundPrice(quote, price))
+		}
+	}
+	if err != nil {
+		return nil, nil, errors.Wrap(err, 1)
+	}
+
+	if raw, err = json.Marshal(order); err != nil {
+		return nil, nil, errors.Wrap(err, 1)
+	}
+
+	return []byte(order.UUID), raw, nil
+}
+
+func (self *Upbit) StopLoss(client interface{}, market string, size float64, price float64, kind model.OrderType, metadata string) ([]byte, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (self *Upbit) OCO(client interface{}, market string, size float64, price, stop float64, metadata string) ([]byte, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (self *Upbit) GetClosed(client interface{}, market string) (model.Orders, error) {
+	upbitClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	orders, err := upbitClient.Orders(market, exchange.OrderStateDone)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	var output model.Orders
+	for _, order := range orders {
+		output = append(output, model.Order{
+			Side: func() model.OrderSide {
+				if order.Side == exchange.OrderSideAsk {
+					return model.SELL
+				}
+				return model.BUY
+			}(),
+			Status:     model.FILLED,
+			Market:     market,
+			Size:       order.GetExecutedVolume(),
+			Price:      order.ExecutedAt(),
+			CreatedAt:  order.GetCreatedAt(),
+			FilledSize: order.GetExecutedVolume(),
+			Fee:        order.GetPaidFee(),
+		})
+	}
+
+	return output, nil
+}
+
+func (self *Upbit) GetOpened(client interface{}, market string) (model.Orders, error) {
+	upbitClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	orders, err := upbitClient.Orders(market, exchange.OrderStateWait)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	var output model.Orders
+	for _, order := range orders {
+		output = append(output, model.Order{
+			Status: model.OPEN,
+			Side: func() model.OrderSide {
+				if order.Side == exchange.OrderSideAsk {
+					return model.SELL
+				}
+				return model.BUY
+			}(),
+			ID:        order.UUID,
+			Market:    market,
+			Size:      order.GetVolume(),
+			Price:     order.GetPrice(),
+			CreatedAt: order.GetCreatedAt(),
+		})
+	}
+
+	return output, nil
+}
+
+func (self *Upbit) GetBook(client interface{}, market string, side model.BookSide) (interface{}, error) {
+	upbitClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	book, err := upbitClient.OrderBook(market)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	if side == model.BOOK_SIDE_ASKS {
+		return book.Asks(), nil
+	}
+	return book.Bids(), nil
+}
+
+func (self *Upbit) Aggregate(client, book interface{}, market string, agg float64) (model.Book, error) {
+	bids, ok := book.([]exchange.BookEntry)
+	if !ok {
+		return nil, errors.New("invalid argument: book")
+	}
+
+	_, quote, err := exchange.ParseMarket(market)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	var out model.Book
+	for _, e := range bids {
+		price := exchange.RoundPrice(quote, aggregation.Round(e.Price, agg))
+		entry := out.EntryByPrice(price)
+		if entry != nil {
+			entry.Size = entry.Size + e.Size
+		} else {
+			entry = &model.Buy{
+				Market: market,
+				Price:  price,
+				Size:   e.Size,
+			}
+			out = append(out, *entry)
+		}
+	}
+
+	return out, nil
+}
+
+func (self *Upbit) GetTicker(client interface{}, market string) (float64, error) {
+	upbitClient, ok := client.(*exchange.Client)
+	if !ok {
+		return 0, errors.New("invalid argument: client")
+	}
+
+	ticker, err := upbitClient.Ticker(market)
+	if err != nil {
+		return 0, errors.Wrap(err, 1)
+	}
+
+	return ticker.TradePrice, nil
+}
+
+func (self *Upbit) GetTickers(client interface{}) (map[string]float64, error) {
+	upbitClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	markets, err := self.GetMarkets(true, flag.Sandbox(), nil)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, market := range markets {
+		names = append(names, market.Name)
+	}
+
+	tickers, err := upbitClient.Tickers(names)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	out := make(map[string]float64)
+	for _, ticker := range tickers {
+		out[ticker.Market] = ticker.TradePrice
+	}
+
+	return out, nil
+}
+
+func (self *Upbit) Get24h(client interface{}, market string) (*model.Stats, error) {
+	upbitClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	ticker, err := upbitClient.Ticker(market)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	return &model.Stats{
+		Market: market,
+		High:   ticker.HighPrice,
+		Low:    ticker.LowPrice,
+		Open:   ticker.OpeningPrice,
+		Last:   ticker.TradePrice,
+		Change: ticker.SignedChangeRate * 100,
+		BtcVolume: func(ticker1 *exchange.Ticker) float64 {
+			_, quote, err := exchange.ParseMarket(market)
+			if err == nil {
+				if strings.EqualFold(quote, model.BTC) {
+					return ticker1.AccTradePrice24h
+				}
+				ticker2, err := upbitClient.Ticker(self.FormatMarket(model.BTC, quote))
+				if err == nil {
+					return ticker1.AccTradePrice24h / ticker2.TradePrice
+				}
+			}
+			return 0
+		}(ticker),
+	}, nil
+}
+
+func (self *Upbit) Get24hAll(client interface{}) (map[string]model.Stats, error) {
+	upbitClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	markets, err := self.GetMarkets(true, flag.Sandbox(), nil)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, market := range markets {
+		names = append(names, market.Name)
+	}
+
+	tickers, err := upbitClient.Tickers(names)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	out := make(map[string]model.Stats)
+	volume := make(map[string]float64) // in quote currency
+	for _, ticker := range tickers {
+		out[ticker.Market] = model.Stats{
+			Market: ticker.Market,
+			High:   ticker.HighPrice,
+			Low:    ticker.LowPrice,
+			Open:   ticker.OpeningPrice,
+			Last:   ticker.TradePrice,
+			Change: ticker.SignedChangeRate * 100,
+		}
+		volume[ticker.Market] = ticker.AccTradePrice24h
+	}
+
+	if err = setBtcVolume(self, out, volume); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// the KRW markets have a tick size that depends on the price level, so we look at the ticker price.
+func (self *Upbit) GetPricePrec(client interface{}, market string) (int, error) {
+	_, quote, err := exchange.ParseMarket(market)
+	if err != nil {
+		return 8, errors.Wrap(err, 1)
+	}
+
+	if !strings.EqualFold(quote, "KRW") {
+		return 8, nil
+	}
+
+	ticker, err := self.GetTicker(client, market)
+	if err != nil {
+		return 0, err
+	}
+
+	return precision.Parse(strconv.FormatFloat(exchange.TickSize(quote, ticker), 'f', -1, 64), 0), nil
+}
+
+func (self *Upbit) GetSizePrec(client interface{}, market string) (int, error) {
+	return 8, nil
+}
+
+func (self *Upbit) GetMaxSize(client interface{}, base, quote string, hold, earn bool, def float64, mult multiplier.Mult) float64 {
+	return model.GetSizeMax(hold, earn, def, mult, func() int {
+		prec, err := self.GetSizePrec(client, self.FormatMarket(base, quote))
+		if err != nil {
+			return 0
+		}
+		return prec
+	})
+}
+
+func (self *Upbit) Cancel(client interface{}, market string, side model.OrderSide) error {
+	if observeCancel(self, market, side) {
+		return nil
+	}
+
+	upbitClient, ok := client.(*exchange.Client)
+	if !ok {
+		return errors.New("invalid argument: client")
+	}
+
+	orders, err := upbitClient.Orders(market, exchange.OrderStateWait)
+	if err != nil {
+		return errors.Wrap(err, 1)
+	}
+
+	for _, order := range orders {
+		if ((side == model.BUY) && (order.Side == exchange.OrderSideBid)) || ((side == model.SELL) && (order.Side == exchange.OrderSideAsk)) {
+			if err := upbitClient.CancelOrder(order.UUID); err != nil {
+				return errors.Wrap(err, 1)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (self *Upbit) CancelOrder(client interface{}, market, id string) error {
+	if observeCancelOrder(self, market, id) {
+		return nil
+	}
+
+	upbitClient, ok := client.(*exchange.Client)
+	if !ok {
+		return errors.New("invalid argument: client")
+	}
+
+	if err := upbitClient.CancelOrder(id); err != nil {
+		return errors.Wrap(err, 1)
+	}
+
+	return nil
+}
+
+func (self *Upbit) GetOrder(client interface{}, market, id string) (*model.Order, error) {
+	upbitClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	order, err := upbitClient.GetOrder(id)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	out := &model.Order{
+		ID:     id,
+		Status: model.OPEN,
+		Side: func() model.OrderSide {
+			if order.Side == exchange.OrderSideAsk {
+				return model.SELL
+			}
+			return model.BUY
+		}(),
+		Market:     market,
+		Size:       order.GetVolume(),
+		Price:      order.ExecutedAt(),
+		CreatedAt:  order.GetCreatedAt(),
+		FilledSize: order.GetExecutedVolume(),
+		Fee:        order.GetPaidFee(),
+	}
+	switch order.State {
+	case exchange.OrderStateDone:
+		out.Status = model.FILLED
+	case exchange.OrderStateCancel:
+		out.Status = model.CANCELLED
+	}
+
+	return out, nil
+}
+
+func (self *Upbit) GetFees(client interface{}, market string) (*model.Fees, error) {
+	return getFees(self)
+}
+
+func (self *Upbit) Buy(client interface{}, cancel bool, market string, calls model.Calls, deviation float64, kind model.OrderType) error {
+	if observeBuy(self, client, cancel, market, calls, kind) {
+		return nil
+	}
+
+	upbitClient, ok := client.(*exchange.Client)
+	if !ok {
+		return errors.New("invalid argument: client")
+	}
+
+	// step #1: delete the buy order(s) that are open in your book
+	if cancel {
+		orders, err := upbitClient.Orders(market, exchange.OrderStateWait)
+		if err != nil {
+			return errors.Wrap(err, 1)
+		}
+		for _, order := range orders {
+			if order.Side == exchange.OrderSideBid {
+				// do not cancel orders that we're about to re-place
+				index := calls.IndexByPriceSize(order.GetPrice(), order.GetVolume())
+				if index > -1 {
+					calls[index].Skip = true
+				} else {
+					if err := upbitClient.CancelOrder(order.UUID); err != nil {
+						return errors.Wrap(err, 1)
+					}
+				}
+			}
+		}
+	}
+
+	// step 2: open the top X buy orders
+	for _, call := range calls {
+		if !call.Skip {
+			var (
+				qty   float64 = call.Size
+				limit float64 = call.Price
+			)
+			if deviation != 1.0 {
+				kind, limit = call.Deviate(self, client, kind, deviation)
+			}
+			// order value should be greater or equal to the minimum total
+			chance, err := upbitClient.Chance(market)
+			if err != nil {
+				return errors.Wrap(err, 1)
+			}
+			if chance.MinTotal() > 0 {
+				if limit == 0 {
+					if limit, err = self.GetTicker(client, market); err != nil {
+						return err
+					}
+				}
+				if (qty * limit) < chance.MinTotal() {
+					prec, err := self.GetSizePrec(client, market)
+					if err != nil {
+						return err
+					}
+					qty = precision.Ceil((chance.MinTotal() / limit), prec)
+				}
+			}
+			if _, _, err := self.Order(client, model.BUY, market, qty, limit, kind, ""); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (self *Upbit) IsLeveragedToken(name string) bool {
+	return false
+}
+
+func (self *Upbit) HasAlgoOrder(client interface{}, market string) (bool, error) {
+	return false, nil
+}
+
+func newUpbit() model.Exchange {
+	return &Upbit{
+		ExchangeInfo: &model.ExchangeInfo{
+			Code: "UPBT",
+			Name: "Upbit",
+			URL:  "https://upbit.com",
+			REST: model.Endpoint{
+				URI: exchange.API_BASE,
+			},
+			WebSocket: model.Endpoint{
+				URI: "wss://api.upbit.com/websocket/v1",
+			},
+			Country: "South Korea",
+		},
+	}
+}
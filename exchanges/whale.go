@@ -0,0 +1,102 @@
+package exchanges
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/precision"
+	"github.com/svanas/nefertiti/session"
+)
+
+// a whale is a single print on the public trade feed that is worth more than --notional (in quote currency). we
+// notify you about every whale, and we stop buying a market for --cooldown minutes after a whale sold into it.
+
+type Whales map[string]time.Time // market -> no buys until
+
+var whalesMutex sync.Mutex
+
+func whalesFile(exchange model.Exchange) string {
+	return session.GetSessionFile(strings.ToLower(exchange.GetInfo().Code) + ".whales.json")
+}
+
+func readWhales(exchange model.Exchange) (Whales, error) {
+	out := make(Whales)
+	data, err := session.ReadFile(whalesFile(exchange))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return out, nil
+		}
+		return nil, errors.Wrap(err, 1)
+	}
+	if err = json.Unmarshal(data, &out); err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+	return out, nil
+}
+
+func (whales Whales) write(exchange model.Exchange) error {
+	data, err := json.Marshal(whales)
+	if err != nil {
+		return errors.Wrap(err, 1)
+	}
+	return session.WriteFile(whalesFile(exchange), data)
+}
+
+// WhaleGated returns true if a whale sold into this market less than --cooldown minutes ago
+func WhaleGated(exchange model.Exchange, market string) (bool, error) {
+	whalesMutex.Lock()
+	defer whalesMutex.Unlock()
+
+	whales, err := readWhales(exchange)
+	if err != nil {
+		return false, err
+	}
+
+	until, ok := whales[market]
+	return ok && time.Now().Before(until), nil
+}
+
+// WatchWhales listens to the public trade feed of the exchange. blocks until an error occurs.
+func WatchWhales(exchange model.Exchange, markets []string, notional float64, cooldown time.Duration, service model.Notify, sandbox bool) error {
+	feed, ok := exchange.(model.TradeFeed)
+	if !ok {
+		return errors.Errorf("%s does not provide a public trade feed", exchange.GetInfo().Name)
+	}
+
+	return feed.WatchTrades(markets, sandbox, func(trade *model.Trade) {
+		if trade.Notional() < notional {
+			return
+		}
+
+		msg := fmt.Sprintf("%s %s %s @ %s (%.2f)", trade.Market, trade.Side.String(), precision.FormatFloat(trade.Size, -1), precision.FormatFloat(trade.Price, -1), trade.Notional())
+		log.Printf("[WHALE] %s\n", msg)
+
+		if trade.Side == model.SELL && cooldown > 0 {
+			if err := func() error {
+				whalesMutex.Lock()
+				defer whalesMutex.Unlock()
+				whales, err := readWhales(exchange)
+				if err != nil {
+					return err
+				}
+				whales[trade.Market] = time.Now().Add(cooldown)
+				return whales.write(exchange)
+			}(); err != nil {
+				log.Printf("[ERROR] %v\n", err)
+			}
+		}
+
+		if service != nil {
+			if err := service.SendMessage(msg, (exchange.GetInfo().Name + " - Whale"), model.ALWAYS); err != nil {
+				log.Printf("[ERROR] %v\n", err)
+			}
+		}
+	})
+}
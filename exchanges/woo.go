@@ -10,13 +10,14 @@ import (
 	"strings"
 	"time"
 
-	filemutex "github.com/alexflint/go-filemutex"
 	"github.com/svanas/nefertiti/aggregation"
 	"github.com/svanas/nefertiti/errors"
 	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/i18n"
 	"github.com/svanas/nefertiti/model"
 	"github.com/svanas/nefertiti/multiplier"
 	"github.com/svanas/nefertiti/notify"
+	"github.com/svanas/nefertiti/override"
 	"github.com/svanas/nefertiti/precision"
 	"github.com/svanas/nefertiti/pricing"
 	"github.com/svanas/nefertiti/session"
@@ -24,7 +25,7 @@ import (
 )
 
 var (
-	wooMutex *filemutex.FileMutex
+	wooMutex *session.Mutex
 )
 
 const (
@@ -37,7 +38,7 @@ func init() {
 		var err error
 
 		if wooMutex == nil {
-			if wooMutex, err = filemutex.New(session.GetSessionFile(wooSessionLock)); err != nil {
+			if wooMutex, err = session.NewMutex(wooSessionLock); err != nil {
 				return err
 			}
 		}
@@ -95,7 +96,7 @@ func (self *Woo) error(err error, level int64, service model.Notify) {
 
 	if service != nil {
 		if notify.CanSend(level, notify.ERROR) {
-			err := service.SendMessage(msg, "Woo - ERROR", model.ONCE_PER_MINUTE)
+			err := notify.SendError(service, err, msg, "Woo - ERROR")
 			if err != nil {
 				log.Printf("[ERROR] %v", err)
 			}
@@ -238,7 +239,7 @@ func (self *Woo) listen(
 
 				if service != nil {
 					if notify.CanSend(level, notify.CANCELLED) {
-						err := service.SendMessage(order, fmt.Sprintf("Woo - Done %v (Reason: Cancelled)", order.Side), model.ALWAYS)
+						err := service.SendMessage(order, fmt.Sprintf("Woo - %s", i18n.Sprintf("Done %s (Reason: Cancelled)", order.Side)), model.ALWAYS)
 						if err != nil {
 							log.Printf("[ERROR] %v", err)
 						}
@@ -260,7 +261,7 @@ func (self *Woo) listen(
 
 			if service != nil {
 				if notify.CanSend(level, notify.OPENED) || (level == notify.LEVEL_DEFAULT && order.Side == exchange.OrderSideSell) {
-					err := service.SendMessage(order, fmt.Sprintf("Woo - Open %v", order.Side), model.ALWAYS)
+					err := service.SendMessage(order, fmt.Sprintf("Woo - %s", i18n.Sprintf("Open %s", order.Side)), model.ALWAYS)
 					if err != nil {
 						log.Printf("[ERROR] %v", err)
 					}
@@ -299,7 +300,7 @@ func (self *Woo) sell(
 	// make a list of newly filled orders
 	var new []exchange.Order
 	for _, order := range filled {
-		if self.indexByOrderID(old, order.OrderID) == -1 {
+		if self.indexByOrderID(old, order.OrderID) == -1 && processOnce(self, strconv.FormatInt(order.OrderID, 10)) {
 			new = append(new, order)
 		}
 	}
@@ -312,7 +313,7 @@ func (self *Woo) sell(
 		} else {
 			log.Println("[FILLED] " + string(data))
 			if notify.CanSend(level, notify.FILLED) && service != nil {
-				err := service.SendMessage(order, fmt.Sprintf("Woo - Done %s (Reason: Filled)", order.Side), model.ALWAYS)
+				err := service.SendMessage(order, fmt.Sprintf("Woo - %s", i18n.Sprintf("Done %s (Reason: Filled)", order.Side)), model.ALWAYS)
 				if err != nil {
 					log.Printf("[ERROR] %v", err)
 				}
@@ -349,19 +350,22 @@ func (self *Woo) sell(
 			// get base currency and desired size, calculate price, place sell order
 			base, quote, err := self.parseMarket(new[i].Symbol)
 			if err == nil {
-				qty = self.GetMaxSize(client, base, quote, hold.HasMarket(new[i].Symbol), earn.HasMarket(new[i].Symbol), qty, mult)
+				qty = self.GetMaxSize(client, base, quote, hold.HasMarket(new[i].Symbol), earn.HasMarket(new[i].Symbol), qty, override.Mult(new[i].Symbol, mult))
 				if qty > 0 {
-					var prec int
-					prec, err = self.GetPricePrec(client, new[i].Symbol)
+					var tick float64
+					tick, err = model.GetTickSize(self, client, new[i].Symbol)
 					if err == nil {
-						_, err = client.Order(
-							new[i].Symbol,
-							exchange.OrderSideSell,
-							exchange.OrderTypeLimit,
-							qty,
-							pricing.Multiply(new[i].ExecutedAt(), mult, prec),
-							"NEF2021xxxxxxx",
-						)
+						limit := pricing.MultiplyTick(new[i].ExecutedAt(), override.Mult(new[i].Symbol, mult), tick)
+						if _, ok := observeOrder(self, client, model.SELL, new[i].Symbol, qty, limit, model.LIMIT); !ok {
+							_, err = client.Order(
+								new[i].Symbol,
+								exchange.OrderSideSell,
+								exchange.OrderTypeLimit,
+								qty,
+								limit,
+								"NEF2021xxxxxxx",
+							)
+						}
 					}
 				}
 			}
@@ -453,6 +457,7 @@ func (self *Woo) Sell(
 		if opened, err = self.listen(client, service, level, opened, filled); err != nil {
 			self.error(err, level, service)
 		}
+		beat(self.GetInfo().Name, err)
 	}
 }
 
@@ -465,6 +470,14 @@ func (self *Woo) Order(
 	kind model.OrderType,
 	metadata string,
 ) (oid []byte, raw []byte, err error) {
+	if raw, ok := observeOrder(self, client, side, market, size, price, kind); ok {
+		return nil, raw, nil
+	}
+
+	if err := CheckEquityShare(self, client, side, market, size, price); err != nil {
+		return nil, nil, err
+	}
+
 	wooClient, ok := client.(*exchange.Client)
 	if !ok {
 		return nil, nil, errors.New("invalid argument: client")
@@ -526,10 +539,14 @@ func (self *Woo) GetClosed(client interface{}, market string) (model.Orders, err
 				}
 				return model.BUY
 			}(),
-			Market:    market,
-			Size:      order.QuantityMinusFee(),
-			Price:     order.ExecutedAt(),
-			CreatedAt: order.CreatedAt(),
+			Status:     model.FILLED,
+			Market:     market,
+			Size:       order.QuantityMinusFee(),
+			Price:      order.ExecutedAt(),
+			CreatedAt:  order.CreatedAt(),
+			ClosedAt:   model.TimePtr(order.UpdatedAt()),
+			FilledSize: order.Executed,
+			Fee:        order.TotalFee,
 		})
 	}
 
@@ -554,12 +571,14 @@ func (self *Woo) GetOpened(client interface{}, market string) (model.Orders, err
 
 	for _, order := range orders {
 		output = append(output, model.Order{
+			Status: model.OPEN,
 			Side: func() model.OrderSide {
 				if order.Side == exchange.OrderSideSell {
 					return model.SELL
 				}
 				return model.BUY
 			}(),
+			ID:        strconv.FormatInt(order.OrderID, 10),
 			Market:    market,
 			Size:      order.Quantity,
 			Price:     order.Price,
@@ -634,6 +653,28 @@ func (self *Woo) GetTicker(client interface{}, market string) (float64, error) {
 	return ticker.LastPrice, nil
 }
 
+func (self *Woo) GetTickers(client interface{}) (map[string]float64, error) {
+	wooClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	sum, err := wooClient.Summary()
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	out := make(map[string]float64)
+	for _, ticker := range sum {
+		// the summary is in BASE_QUOTE, but the markets are in SPOT_BASE_QUOTE
+		if symbols := strings.Split(ticker.Symbol, "_"); len(symbols) == 2 {
+			out[self.FormatMarket(symbols[0], symbols[1])] = ticker.LastPrice
+		}
+	}
+
+	return out, nil
+}
+
 func (self *Woo) Get24h(client interface{}, market string) (*model.Stats, error) {
 	wooClient, ok := client.(*exchange.Client)
 	if !ok {
@@ -649,6 +690,8 @@ func (self *Woo) Get24h(client interface{}, market string) (*model.Stats, error)
 		Market: market,
 		High:   ticker.HighestPrice24h,
 		Low:    ticker.LowestPrice24h,
+		Last:   ticker.LastPrice,
+		Change: ticker.PriceChangePercent24h,
 		BtcVolume: func(ticker1 *exchange.Ticker) float64 {
 			_, quote, err := self.parseMarket(market)
 			if err == nil {
@@ -665,6 +708,43 @@ func (self *Woo) Get24h(client interface{}, market string) (*model.Stats, error)
 	}, nil
 }
 
+func (self *Woo) Get24hAll(client interface{}) (map[string]model.Stats, error) {
+	wooClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	sum, err := wooClient.Summary()
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	out := make(map[string]model.Stats)
+	volume := make(map[string]float64) // in quote currency
+	for _, ticker := range sum {
+		// the summary is in BASE_QUOTE, but the markets are in SPOT_BASE_QUOTE
+		symbols := strings.Split(ticker.Symbol, "_")
+		if len(symbols) != 2 {
+			continue
+		}
+		market := self.FormatMarket(symbols[0], symbols[1])
+		out[market] = model.Stats{
+			Market: market,
+			High:   ticker.HighestPrice24h,
+			Low:    ticker.LowestPrice24h,
+			Last:   ticker.LastPrice,
+			Change: ticker.PriceChangePercent24h,
+		}
+		volume[market] = ticker.QuoteVolume
+	}
+
+	if err = setBtcVolume(self, out, volume); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
 func (self *Woo) GetPricePrec(client interface{}, market string) (int, error) {
 	wooClient, ok := client.(*exchange.Client)
 	if !ok {
@@ -709,6 +789,10 @@ func (self *Woo) GetMaxSize(client interface{}, base, quote string, hold, earn b
 }
 
 func (self *Woo) Cancel(client interface{}, market string, side model.OrderSide) error {
+	if observeCancel(self, market, side) {
+		return nil
+	}
+
 	wooClient, ok := client.(*exchange.Client)
 	if !ok {
 		return errors.New("invalid argument: client")
@@ -730,7 +814,113 @@ func (self *Woo) Cancel(client interface{}, market string, side model.OrderSide)
 	return nil
 }
 
+func (self *Woo) CancelOrder(client interface{}, market, id string) error {
+	if observeCancelOrder(self, market, id) {
+		return nil
+	}
+
+	wooClient, ok := client.(*exchange.Client)
+	if !ok {
+		return errors.New("invalid argument: client")
+	}
+
+	orderID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return errors.Wrap(err, 1)
+	}
+
+	if err = wooClient.CancelOrder(market, orderID); err != nil {
+		return errors.Wrap(err, 1)
+	}
+
+	return nil
+}
+
+func (self *Woo) GetOrder(client interface{}, market, id string) (*model.Order, error) {
+	wooClient, ok := client.(*exchange.Client)
+	if !ok {
+		return nil, errors.New("invalid argument: client")
+	}
+
+	orderID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	order, err := wooClient.GetOrder(orderID)
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+
+	out := &model.Order{
+		ID:     id,
+		Status: model.OPEN,
+		Side: func() model.OrderSide {
+			if order.Side == exchange.OrderSideSell {
+				return model.SELL
+			}
+			return model.BUY
+		}(),
+		Market:     market,
+		Size:       order.Quantity,
+		Price:      order.ExecutedAt(),
+		CreatedAt:  order.CreatedAt(),
+		FilledSize: order.Executed,
+		Fee:        order.TotalFee,
+	}
+	switch order.Status {
+	case exchange.OrderStatusFilled, exchange.OrderStatusCompleted:
+		out.Status = model.FILLED
+		out.ClosedAt = model.TimePtr(order.UpdatedAt())
+	case exchange.OrderStatusCancelled, exchange.OrderStatusRejected:
+		out.Status = model.CANCELLED
+		out.ClosedAt = model.TimePtr(order.UpdatedAt())
+	}
+
+	return out, nil
+}
+
+func (self *Woo) GetFees(client interface{}, market string) (*model.Fees, error) {
+	return getFees(self)
+}
+
+// minSize returns the size, bumped to respect the min notional value and the min base size
+func (self *Woo) minSize(client interface{}, market string, size, price float64) (float64, error) {
+	wooClient, ok := client.(*exchange.Client)
+	if !ok {
+		return size, errors.New("invalid argument: client")
+	}
+	// --- BEGIN --- svanas 2021-07-25 --- order value should be greater or equal to X ----
+	symbol, err := self.getSymbol(wooClient, market, true)
+	if err != nil {
+		return size, err
+	}
+	if symbol.MinNotional > 0 {
+		if price == 0 {
+			if price, err = self.GetTicker(client, market); err != nil {
+				return size, err
+			}
+		}
+		if (size * price) < symbol.MinNotional {
+			prec, err := self.GetSizePrec(client, market)
+			if err != nil {
+				return size, err
+			}
+			size = precision.Ceil((symbol.MinNotional / price), prec)
+		}
+	}
+	if symbol.BaseMin > 0 && size < symbol.BaseMin {
+		size = symbol.BaseMin
+	}
+	// ---- END ---- svanas 2021-07-25 ----------------------------------------------------
+	return size, nil
+}
+
 func (self *Woo) Buy(client interface{}, cancel bool, market string, calls model.Calls, deviation float64, kind model.OrderType) error {
+	if observeBuy(self, client, cancel, market, calls, kind) {
+		return nil
+	}
+
 	wooClient, ok := client.(*exchange.Client)
 	if !ok {
 		return errors.New("invalid argument: client")
@@ -745,8 +935,8 @@ func (self *Woo) Buy(client interface{}, cancel bool, market string, calls model
 		for _, order := range orders {
 			if order.Side == exchange.OrderSideBuy {
 				// do not cancel orders that we're about to re-place
-				index := calls.IndexByPrice(order.Price)
-				if index > -1 && order.Quantity == calls[index].Size {
+				index := calls.IndexByPriceSize(order.Price, order.Quantity)
+				if index > -1 {
 					calls[index].Skip = true
 				} else {
 					if err := wooClient.CancelOrder(market, order.OrderID); err != nil {
@@ -767,29 +957,10 @@ func (self *Woo) Buy(client interface{}, cancel bool, market string, calls model
 			if deviation != 1.0 {
 				kind, limit = call.Deviate(self, client, kind, deviation)
 			}
-			// --- BEGIN --- svanas 2021-07-25 --- order value should be greater or equal to X ----
-			symbol, err := self.getSymbol(wooClient, market, true)
+			qty, err := self.minSize(client, market, qty, limit)
 			if err != nil {
 				return err
 			}
-			if symbol.MinNotional > 0 {
-				if limit == 0 {
-					if limit, err = self.GetTicker(client, market); err != nil {
-						return err
-					}
-				}
-				if (qty * limit) < symbol.MinNotional {
-					prec, err := self.GetSizePrec(client, market)
-					if err != nil {
-						return err
-					}
-					qty = precision.Ceil((symbol.MinNotional / limit), prec)
-				}
-			}
-			if symbol.BaseMin > 0 && qty < symbol.BaseMin {
-				qty = symbol.BaseMin
-			}
-			// ---- END ---- svanas 2021-07-25 ----------------------------------------------------
 			if _, err := wooClient.Order(market, exchange.OrderSideBuy, func() exchange.OrderType {
 				if kind == model.MARKET {
 					return exchange.OrderTypeMarket
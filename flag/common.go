@@ -2,6 +2,8 @@ package flag
 
 import (
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/svanas/nefertiti/errors"
 )
@@ -11,6 +13,103 @@ func Dca() bool {
 	return Exists("dca")
 }
 
+// --dca-factor=X (defaults to 2.2) is what we buy back after a stop-loss, as a multiple of what we sold
+func DcaFactor() (float64, error) {
+	var (
+		err error
+		out float64 = 2.2
+	)
+	arg := Get("dca-factor")
+	if arg.Exists && arg.String() != "" {
+		if out, err = arg.Float64(); err != nil {
+			return out, errors.Errorf("dca-factor %v is invalid", arg)
+		}
+		if out <= 0 {
+			return out, errors.Errorf("dca-factor %v is invalid", arg)
+		}
+	}
+	return out, nil
+}
+
+// --dca-rounds=X (defaults to 0, aka no limit) is the max number of DCA buys per market
+func DcaRounds() (int64, error) {
+	var (
+		err error
+		out int64
+	)
+	arg := Get("dca-rounds")
+	if arg.Exists && arg.String() != "" {
+		if out, err = arg.Int64(); err != nil {
+			return out, errors.Errorf("dca-rounds %v is invalid", arg)
+		}
+		if out < 0 {
+			return out, errors.Errorf("dca-rounds %v is invalid", arg)
+		}
+	}
+	return out, nil
+}
+
+// --dca-max=X (defaults to 0, aka no limit) is the max we spend on DCA buys per market, in quote currency
+func DcaMax() (float64, error) {
+	var (
+		err error
+		out float64
+	)
+	arg := Get("dca-max")
+	if arg.Exists && arg.String() != "" {
+		if out, err = arg.Float64(); err != nil {
+			return out, errors.Errorf("dca-max %v is invalid", arg)
+		}
+		if out < 0 {
+			return out, errors.Errorf("dca-max %v is invalid", arg)
+		}
+	}
+	return out, nil
+}
+
+// --user=NAME isolates the credentials, session state, journals and notifications of one user from another
+func User() (string, error) {
+	arg := Get("user")
+	if !arg.Exists || arg.String() == "" {
+		return "", nil
+	}
+	name := strings.ToLower(arg.String())
+	for _, r := range name {
+		if !((r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' || r == '_') {
+			return "", errors.Errorf("user %v is invalid", arg)
+		}
+	}
+	return name, nil
+}
+
+// --json-errors prints the final error of a command as a JSON object on stderr, so that your wrapper script can parse it
+func JsonErrors() bool {
+	return Exists("json-errors")
+}
+
+// --chart attaches a candlestick chart to the notifications of filled orders, where the exchange and the notification
+// service support it
+func Chart() bool {
+	return Exists("chart")
+}
+
+// --watch-account polls your account for withdrawals (where the exchange supports it) and alerts you about every one
+// of them, because the bot never withdraws
+func WatchAccount() bool {
+	return Exists("watch-account")
+}
+
+// --keep-buys leaves the buy orders on the book while the buy side is paused
+func KeepBuys() bool {
+	return Exists("keep-buys")
+}
+
+// --allow-withdraw acknowledges that you have given the bot an API key that can withdraw, so that we stop warning you
+// about it
+func AllowWithdraw() bool {
+	return Exists("allow-withdraw")
+}
+
 // --debug
 func Debug() bool {
 	return Exists("debug")
@@ -110,6 +209,572 @@ func Listen() bool {
 	return Exists("listen")
 }
 
+// we prompt for a missing argument, unless the bot is listening to a port or the command told us not to
+var interactive = true
+
+// SetInteractive(false) turns every prompt into a "missing argument" error
+func SetInteractive(value bool) {
+	interactive = value
+}
+
 func Interactive() bool {
-	return !Listen()
+	return interactive && !Listen()
+}
+
+// when included, then multiple processes coordinate their rate limits through the session dir (at the cost of disk I/O)
+func SharedSession() bool {
+	return Exists("shared-session")
+}
+
+// --leverage=X (defaults to 1, aka no leverage)
+func Leverage() (float64, error) {
+	var (
+		err      error
+		leverage float64 = 1
+	)
+	arg := Get("leverage")
+	if arg.Exists {
+		if leverage, err = arg.Float64(); err != nil {
+			return leverage, errors.Errorf("leverage %v is invalid", arg)
+		}
+		if leverage <= 0 {
+			return leverage, errors.Errorf("leverage %v is invalid", arg)
+		}
+	}
+	return leverage, nil
+}
+
+// --isolated=[Y|N], returns (isolated, included). when not included, then we leave the margin mode as-is.
+func Isolated() (bool, bool) {
+	arg := Get("isolated")
+	if arg.Exists {
+		str := arg.String()
+		return len(str) == 0 || (str[0] == 'Y' || str[0] == 'y'), true
+	}
+	return false, false
+}
+
+// --slippage=[0..50] in percent (defaults to 0.5)
+func Slippage() (float64, error) {
+	var (
+		err      error
+		slippage float64 = 0.5
+	)
+	arg := Get("slippage")
+	if arg.Exists {
+		if slippage, err = arg.Float64(); err != nil {
+			return slippage, errors.Errorf("slippage %v is invalid", arg)
+		}
+		if slippage <= 0 || slippage > 50 {
+			return slippage, errors.Errorf("slippage %v is invalid", arg)
+		}
+	}
+	return slippage, nil
+}
+
+// --max-gas-price=X in gwei (defaults to no limit)
+func MaxGasPrice() (float64, error) {
+	return gwei("max-gas-price")
+}
+
+// --max-priority-fee=X in gwei (defaults to no limit)
+func MaxPriorityFee() (float64, error) {
+	return gwei("max-priority-fee")
+}
+
+func gwei(name string) (float64, error) {
+	var (
+		err error
+		out float64
+	)
+	arg := Get(name)
+	if arg.Exists {
+		if out, err = arg.Float64(); err != nil {
+			return out, errors.Errorf("%s %v is invalid", name, arg)
+		}
+		if out <= 0 {
+			return out, errors.Errorf("%s %v is invalid", name, arg)
+		}
+	}
+	return out, nil
+}
+
+// --currency=X is the (fiat) currency we report in (optional, defaults to the quote currency of every market)
+func Currency() string {
+	arg := Get("currency")
+	if arg.Exists && arg.String() != "" {
+		return strings.ToUpper(arg.String())
+	}
+	return ""
+}
+
+// --sanity=[0..100] is the max deviation (in percent) from the reference price, defaults to 0 (disabled)
+func Sanity() (float64, error) {
+	var (
+		err    error
+		sanity float64
+	)
+	arg := Get("sanity")
+	if arg.Exists && arg.String() != "" {
+		if sanity, err = arg.Float64(); err != nil {
+			return sanity, errors.Errorf("sanity %v is invalid", arg)
+		}
+		if sanity <= 0 || sanity > 100 {
+			return sanity, errors.Errorf("sanity %v is invalid", arg)
+		}
+	}
+	return sanity, nil
+}
+
+// --sanity-ref=[coingecko|exchange] is where we get our reference price from (defaults to coingecko)
+func SanityRef() string {
+	arg := Get("sanity-ref")
+	if arg.Exists && arg.String() != "" {
+		return arg.String()
+	}
+	return "coingecko"
+}
+
+// --oracle=X,Y,Z is where we get the fair price of an asset from, in order of priority. every X is coingecko,
+// chainlink or the name of an exchange. defaults to --sanity-ref. the bool is true if you included --oracle.
+func Oracle() ([]string, bool) {
+	arg := Get("oracle")
+	if arg.Exists && arg.String() != "" {
+		return arg.Split(), true
+	}
+	return []string{SanityRef()}, false
+}
+
+// --chainlink-feed=BASE-QUOTE:ADDRESS,... adds to (or overrides) the built-in Chainlink feeds
+func ChainlinkFeeds() (map[string]string, error) {
+	out := make(map[string]string)
+	arg := Get("chainlink-feed")
+	if !arg.Exists {
+		return out, nil
+	}
+	for _, part := range arg.Split() {
+		i := strings.LastIndex(part, ":")
+		if i == -1 || !strings.HasPrefix(part[i+1:], "0x") {
+			return nil, errors.Errorf("chainlink-feed %v is invalid", arg)
+		}
+		out[strings.ToUpper(part[:i])] = part[i+1:]
+	}
+	return out, nil
+}
+
+// --max-exposure=X and --max-market-exposure=X, in quote currency (defaults to 0, aka no limit)
+func MaxExposure() (float64, float64, error) { // -> (total, per market, error)
+	get := func(name string) (float64, error) {
+		var (
+			err error
+			out float64
+		)
+		arg := Get(name)
+		if arg.Exists && arg.String() != "" {
+			if out, err = arg.Float64(); err != nil {
+				return out, errors.Errorf("%s %v is invalid", name, arg)
+			}
+			if out < 0 {
+				return out, errors.Errorf("%s %v is invalid", name, arg)
+			}
+		}
+		return out, nil
+	}
+	total, err := get("max-exposure")
+	if err != nil {
+		return 0, 0, err
+	}
+	market, err := get("max-market-exposure")
+	if err != nil {
+		return 0, 0, err
+	}
+	return total, market, nil
+}
+
+// --max-equity-share=X in percent (defaults to 0, aka no limit) is the max share of your equity in a single market
+func MaxEquityShare() (float64, error) {
+	var (
+		err error
+		out float64
+	)
+	arg := Get("max-equity-share")
+	if arg.Exists && arg.String() != "" {
+		if out, err = arg.Float64(); err != nil {
+			return out, errors.Errorf("max-equity-share %v is invalid", arg)
+		}
+		if out < 0 || out > 100 {
+			return out, errors.Errorf("max-equity-share %v is not in the 0..100 range", arg)
+		}
+	}
+	return out, nil
+}
+
+// --max-daily-loss=X in quote currency (defaults to 0, aka no limit)
+func MaxDailyLoss() (float64, error) {
+	var (
+		err error
+		out float64
+	)
+	arg := Get("max-daily-loss")
+	if arg.Exists && arg.String() != "" {
+		if out, err = arg.Float64(); err != nil {
+			return out, errors.Errorf("max-daily-loss %v is invalid", arg)
+		}
+		if out < 0 {
+			return out, errors.Errorf("max-daily-loss %v is invalid", arg)
+		}
+	}
+	return out, nil
+}
+
+// --approve-above=X in quote currency (defaults to 0, aka never) and --approve-timeout=N in minutes (defaults to 15)
+func ApproveAbove() (float64, time.Duration, error) {
+	var (
+		err     error
+		above   float64
+		timeout int64 = 15
+	)
+	arg := Get("approve-above")
+	if arg.Exists && arg.String() != "" {
+		if above, err = arg.Float64(); err != nil {
+			return above, 0, errors.Errorf("approve-above %v is invalid", arg)
+		}
+		if above < 0 {
+			return above, 0, errors.Errorf("approve-above %v is invalid", arg)
+		}
+	}
+	arg = Get("approve-timeout")
+	if arg.Exists && arg.String() != "" {
+		if timeout, err = arg.Int64(); err != nil {
+			return above, 0, errors.Errorf("approve-timeout %v is invalid", arg)
+		}
+		if timeout <= 0 {
+			return above, 0, errors.Errorf("approve-timeout %v is invalid", arg)
+		}
+	}
+	return above, time.Duration(timeout) * time.Minute, nil
+}
+
+// --observe (aka read-only mode)
+func Observe() bool {
+	return Exists("observe")
+}
+
+// --dry-run (print the orders that would be sent, then exit)
+func DryRun() bool {
+	return Exists("dry-run")
+}
+
+// --older-than=X where X is a duration (for example: 90m or 48h) or a number of hours
+func OlderThan() (time.Duration, error) {
+	arg := Get("older-than")
+	if !arg.Exists || arg.String() == "" {
+		return 0, nil
+	}
+	out, err := time.ParseDuration(arg.String())
+	if err != nil {
+		var hours float64
+		if hours, err = arg.Float64(); err != nil {
+			return 0, errors.Errorf("older-than %v is invalid", arg)
+		}
+		out = time.Duration(hours * float64(time.Hour))
+	}
+	if out < 0 {
+		return 0, errors.Errorf("older-than %v is invalid", arg)
+	}
+	return out, nil
+}
+
+// --expire=X in hours (defaults to 0, aka never) is how long a buy order lives before we cancel it
+func Expire() (time.Duration, error) {
+	var (
+		err error
+		out float64
+	)
+	arg := Get("expire")
+	if arg.Exists && arg.String() != "" {
+		if out, err = arg.Float64(); err != nil {
+			return 0, errors.Errorf("expire %v is invalid", arg)
+		}
+		if out < 0 {
+			return 0, errors.Errorf("expire %v is invalid", arg)
+		}
+	}
+	return time.Duration(out * float64(time.Hour)), nil
+}
+
+// --price-above=X and --price-below=Y (both default to 0, aka no bound)
+func PriceRange() (float64, float64, error) {
+	get := func(name string) (float64, error) {
+		var out float64
+		arg := Get(name)
+		if arg.Exists && arg.String() != "" {
+			var err error
+			if out, err = arg.Float64(); err != nil || out < 0 {
+				return 0, errors.Errorf("%s %v is invalid", name, arg)
+			}
+		}
+		return out, nil
+	}
+	above, err := get("price-above")
+	if err != nil {
+		return 0, 0, err
+	}
+	below, err := get("price-below")
+	if err != nil {
+		return 0, 0, err
+	}
+	if above > 0 && below > 0 && above >= below {
+		return 0, 0, errors.Errorf("price-above %v must be lower than price-below %v", above, below)
+	}
+	return above, below, nil
+}
+
+// --range=[0..100] the bot only buys when the price is in the lower X% of its 24h range (defaults to 0, aka always)
+func Range() (float64, error) {
+	var (
+		err error
+		out float64
+	)
+	arg := Get("range")
+	if arg.Exists && arg.String() != "" {
+		if out, err = arg.Float64(); err != nil {
+			return out, errors.Errorf("range %v is invalid", arg)
+		}
+		if out < 0 || out > 100 {
+			return out, errors.Errorf("range %v is invalid", arg)
+		}
+	}
+	return out, nil
+}
+
+// --regime-drop=X limits the number of markets we buy once the benchmark is down more than X% intraday, and
+// --regime-max=N is that number (defaults to 0 and 1, aka disabled)
+func Regime() (float64, int64, error) {
+	var (
+		err  error
+		drop float64
+		max  int64 = 1
+	)
+	arg := Get("regime-drop")
+	if arg.Exists && arg.String() != "" {
+		if drop, err = arg.Float64(); err != nil {
+			return drop, max, errors.Errorf("regime-drop %v is invalid", arg)
+		}
+		if drop < 0 || drop >= 100 {
+			return drop, max, errors.Errorf("regime-drop %v is invalid", arg)
+		}
+	}
+	arg = Get("regime-max")
+	if arg.Exists && arg.String() != "" {
+		if max, err = arg.Int64(); err != nil {
+			return drop, max, errors.Errorf("regime-max %v is invalid", arg)
+		}
+		if max < 0 {
+			return drop, max, errors.Errorf("regime-max %v is invalid", arg)
+		}
+	}
+	// the benchmark defaults to BTC against your --quote, and that is no market when your quote is BTC
+	if drop > 0 && strings.EqualFold(Get("quote").String(), "BTC") {
+		if arg = Get("regime-market"); !arg.Exists || arg.String() == "" {
+			return drop, max, errors.New("regime-market is required when your quote currency is BTC")
+		}
+	}
+	return drop, max, nil
+}
+
+// --breakout=X the bot places a stop-buy X% above the high of the last N periods (defaults to 0, aka disabled)
+func Breakout() (float64, error) {
+	var (
+		err error
+		out float64
+	)
+	arg := Get("breakout")
+	if arg.Exists && arg.String() != "" {
+		if out, err = arg.Float64(); err != nil {
+			return out, errors.Errorf("breakout %v is invalid", arg)
+		}
+		if out < 0 {
+			return out, errors.Errorf("breakout %v is invalid", arg)
+		}
+	}
+	return out, nil
+}
+
+// --stop-cooldown=X in hours (defaults to 0, aka disabled)
+func StopCooldown() (time.Duration, error) {
+	var (
+		err error
+		out float64
+	)
+	arg := Get("stop-cooldown")
+	if arg.Exists && arg.String() != "" {
+		if out, err = arg.Float64(); err != nil {
+			return 0, errors.Errorf("stop-cooldown %v is invalid", arg)
+		}
+		if out < 0 {
+			return 0, errors.Errorf("stop-cooldown %v is invalid", arg)
+		}
+	}
+	return time.Duration(out * float64(time.Hour)), nil
+}
+
+// --reopen-after=X in days (defaults to 21). Bittrex removes orders that are older than 28 days, so we cancel and
+// re-open them before that happens. 0 disables this.
+func ReopenAfter() (time.Duration, error) {
+	var (
+		err error
+		out int64 = 21
+	)
+	arg := Get("reopen-after")
+	if arg.Exists && arg.String() != "" {
+		if out, err = arg.Int64(); err != nil {
+			return 0, errors.Errorf("reopen-after %v is invalid", arg)
+		}
+		if out < 0 || out >= 28 {
+			return 0, errors.Errorf("reopen-after %v is not in the 0..27 range", arg)
+		}
+	}
+	return time.Duration(out) * 24 * time.Hour, nil
+}
+
+// --reopen-reprice (re-open a stale sell order at the current --mult target instead of its old price)
+func ReopenReprice() bool {
+	return Exists("reopen-reprice")
+}
+
+// --ticks=X (defaults to 0, aka always move the buy orders)
+func Ticks() (int64, error) {
+	var (
+		err error
+		out int64
+	)
+	arg := Get("ticks")
+	if arg.Exists && arg.String() != "" {
+		if out, err = arg.Int64(); err != nil {
+			return out, errors.Errorf("ticks %v is invalid", arg)
+		}
+		if out < 0 {
+			return out, errors.Errorf("ticks %v is invalid", arg)
+		}
+	}
+	return out, nil
+}
+
+// --size=X or --size=MARKET:X,MARKET:Y,default:Z returns the size for the market (or the default size)
+func Size(market string) (float64, error) {
+	arg := Get("size")
+	if !arg.Exists {
+		return 0, nil
+	}
+	var (
+		out float64
+		def float64
+	)
+	for _, part := range arg.Split() {
+		key := "default"
+		val := part
+		if i := strings.LastIndex(part, ":"); i > -1 {
+			key = part[:i]
+			val = part[i+1:]
+		}
+		size, err := strconv.ParseFloat(val, 64)
+		if err != nil || size < 0 {
+			return 0, errors.Errorf("size %v is invalid", arg)
+		}
+		if strings.EqualFold(key, "default") {
+			def = size
+		} else if market != "" && strings.EqualFold(key, market) {
+			out = size
+		}
+	}
+	if out == 0 {
+		out = def
+	}
+	return out, nil
+}
+
+// --compound=[0..100] percentage of realized profits to restake (defaults to 0, aka disabled)
+func Compound() (float64, error) {
+	var (
+		err error
+		out float64
+	)
+	arg := Get("compound")
+	if arg.Exists && arg.String() != "" {
+		if out, err = arg.Float64(); err != nil {
+			return out, errors.Errorf("compound %v is invalid", arg)
+		}
+		if out < 0 || out > 100 {
+			return out, errors.Errorf("compound %v is invalid", arg)
+		}
+	}
+	return out, nil
+}
+
+// --skim=[0..100] percentage of every realized profit to convert into --skim-to (defaults to 0, aka disabled)
+func Skim() (float64, error) {
+	var (
+		err error
+		out float64
+	)
+	arg := Get("skim")
+	if arg.Exists && arg.String() != "" {
+		if out, err = arg.Float64(); err != nil {
+			return out, errors.Errorf("skim %v is invalid", arg)
+		}
+		if out < 0 || out > 100 {
+			return out, errors.Errorf("skim %v is invalid", arg)
+		}
+	}
+	return out, nil
+}
+
+// --skim-to=[BTC|USDT|...] (defaults to BTC)
+func SkimTo() string {
+	arg := Get("skim-to")
+	if arg.Exists && arg.String() != "" {
+		return strings.ToUpper(arg.String())
+	}
+	return "BTC"
+}
+
+// --cost=ASSET:X,ASSET:Y is the price you paid (in quote currency) per asset
+func Cost(asset string) (float64, error) {
+	arg := Get("cost")
+	if !arg.Exists {
+		return 0, nil
+	}
+	for _, part := range arg.Split() {
+		i := strings.LastIndex(part, ":")
+		if i == -1 {
+			return 0, errors.Errorf("cost %v is invalid", arg)
+		}
+		cost, err := strconv.ParseFloat(part[i+1:], 64)
+		if err != nil || cost < 0 {
+			return 0, errors.Errorf("cost %v is invalid", arg)
+		}
+		if strings.EqualFold(part[:i], asset) {
+			return cost, nil
+		}
+	}
+	return 0, nil
+}
+
+// --breakout-periods=N is the number of hourly candles that make up the resistance (defaults to 24)
+func BreakoutPeriods() (int64, error) {
+	var (
+		err error
+		out int64 = 24
+	)
+	arg := Get("breakout-periods")
+	if arg.Exists && arg.String() != "" {
+		if out, err = arg.Int64(); err != nil {
+			return out, errors.Errorf("breakout-periods %v is invalid", arg)
+		}
+		if out <= 0 {
+			return out, errors.Errorf("breakout-periods %v is invalid", arg)
+		}
+	}
+	return out, nil
 }
@@ -113,3 +113,27 @@ func Exists(name string) bool {
 	}
 	return false
 }
+
+// Load reads the flags from a config file, one name=value (or just name) per line. lines that start with # are
+// comments. the flags on the command line win.
+func Load(name string) error {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimLeft(line, "-")
+		key, value := line, ""
+		if i := strings.Index(line, "="); i > -1 {
+			key, value = strings.TrimSpace(line[:i]), strings.TrimSpace(line[i+1:])
+		}
+		if key != "" && !Get(key).Exists {
+			Set(key, value)
+		}
+	}
+	return nil
+}
@@ -5,6 +5,8 @@ import (
 	exchange "github.com/svanas/go-coinbasepro"
 	"net/http"
 	"time"
+
+	"github.com/svanas/nefertiti/ratelimit"
 )
 
 const (
@@ -46,22 +48,17 @@ func (self *Client) GetOrder(id string) (*Order, error) {
 	return wrapped, nil
 }
 
-func New(sandbox bool) *Client {
+func New(baseURL string) *Client {
 	client := exchange.NewClient()
 
 	client.HTTPClient = &http.Client{
-		Timeout: 30 * time.Second,
+		Timeout:   30 * time.Second,
+		Transport: ratelimit.NewTransport("Coinbase Pro", nil),
 	}
 
-	if sandbox {
-		client.UpdateConfig(&exchange.ClientConfig{
-			BaseURL: BASE_URL_SANDBOX,
-		})
-	} else {
-		client.UpdateConfig(&exchange.ClientConfig{
-			BaseURL: BASE_URL,
-		})
-	}
+	client.UpdateConfig(&exchange.ClientConfig{
+		BaseURL: baseURL,
+	})
 
 	return &Client{Client: client}
 }
@@ -0,0 +1,20 @@
+//lint:file-ignore ST1006 receiver name should be a reflection of its identity; don't use generic names such as "this" or "self"
+package gdax
+
+type Fees struct {
+	MakerFeeRate float64 `json:"maker_fee_rate,string"`
+	TakerFeeRate float64 `json:"taker_fee_rate,string"`
+	UsdVolume    float64 `json:"usd_volume,string"`
+}
+
+// GetFees returns your (account-specific) fee tier
+func (self *Client) GetFees() (*Fees, error) {
+	var (
+		err error
+		out Fees
+	)
+	if _, err = self.Client.Request("GET", "/fees", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -8,6 +8,7 @@ require (
 	github.com/alexflint/go-filemutex v1.1.0
 	github.com/armon/go-radix v1.0.0 // indirect
 	github.com/blang/semver v3.5.1+incompatible
+	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.0.1
 	github.com/dghubble/go-twitter v0.0.0-20211002212826-ad02880e616b
 	github.com/dghubble/oauth1 v0.7.0
 	github.com/fatih/color v1.13.0 // indirect
@@ -31,7 +32,7 @@ require (
 	github.com/svanas/go-crypto-dot-com v0.0.0-20210821090330-15dc76c25616
 	github.com/svanas/go-mining-hamster v0.0.0-20190102110438-73bc620cc6e9
 	github.com/yanzay/tbot v1.0.0
-	golang.org/x/crypto v0.0.0-20210921155107-089bfa567519 // indirect
+	golang.org/x/crypto v0.0.0-20210921155107-089bfa567519
 	golang.org/x/sys v0.0.0-20211020064051-0ec99a608a1b // indirect
 	golang.org/x/term v0.0.0-20210927222741-03fcf44c2211
 )
@@ -4,14 +4,17 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"net/http"
 	"net/url"
 	"strings"
 	"time"
+
+	"github.com/svanas/nefertiti/ratelimit"
 )
 
 type client struct {
+	URL        string
 	apiKey     string
 	apiSecret  string
 	httpClient *http.Client
@@ -44,12 +47,12 @@ func NewClient(apiKey, apiSecret string) (c *client) {
 
 // NewClientWithCustomHttpConfig returns a new HitBtc HTTP client using the predefined http client
 func NewClientWithCustomHttpConfig(apiKey, apiSecret string, httpClient *http.Client) (c *client) {
-	return &client{apiKey, apiSecret, httpClient}
+	return &client{API_BASE, apiKey, apiSecret, httpClient}
 }
 
 // NewClient returns a new HitBtc HTTP client with custom timeout
 func NewClientWithCustomTimeout(apiKey, apiSecret string, timeout time.Duration) (c *client) {
-	return NewClientWithCustomHttpConfig(apiKey, apiSecret, &http.Client{Timeout: timeout})
+	return NewClientWithCustomHttpConfig(apiKey, apiSecret, &http.Client{Timeout: timeout, Transport: ratelimit.NewTransport("HitBTC", nil)})
 }
 
 // do prepare and process HTTP request to HitBtc API
@@ -66,7 +69,7 @@ func (c *client) do(method string, resource string, payload map[string]string, a
 	if strings.HasPrefix(resource, "http") {
 		rawurl = resource
 	} else {
-		rawurl = fmt.Sprintf("%s/%s", API_BASE, resource)
+		rawurl = fmt.Sprintf("%s/%s", c.URL, resource)
 	}
 	var formData string
 	if method == "GET" {
@@ -114,7 +117,7 @@ func (c *client) do(method string, resource string, payload map[string]string, a
 	}
 
 	defer resp.Body.Close()
-	response, err = ioutil.ReadAll(resp.Body)
+	response, err = io.ReadAll(resp.Body)
 	if err != nil {
 		return response, err
 	}
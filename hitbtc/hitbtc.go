@@ -12,7 +12,8 @@ import (
 )
 
 const (
-	API_BASE = "https://api.hitbtc.com/api/2" // HitBtc API endpoint
+	API_BASE    = "https://api.hitbtc.com/api/2" // HitBtc API endpoint
+	TIME_FORMAT = "2006-01-02T15:04:05.999Z"
 )
 
 // New returns an instantiated HitBTC struct
@@ -61,6 +62,11 @@ type HitBtc struct {
 	client *client
 }
 
+// SetURL points the client at another endpoint, for example a regional mirror or a proxy
+func (b *HitBtc) SetURL(uri string) {
+	b.client.URL = uri
+}
+
 // GetSymbols is used to get the open and available trading markets at HitBtc along with other meta data.
 func (b *HitBtc) GetSymbols() (symbols []Symbol, err error) {
 	r, err := b.client.do("GET", "public/symbol", nil, false)
@@ -95,6 +101,40 @@ func (b *HitBtc) GetTicker(market string) (ticker Ticker, err error) {
 	return
 }
 
+// GetTickers returns the tickers of every symbol
+func (b *HitBtc) GetTickers() (tickers []Ticker, err error) {
+	r, err := b.client.do("GET", "public/ticker", nil, false)
+	if err != nil {
+		return
+	}
+	var response interface{}
+	if err = json.Unmarshal(r, &response); err != nil {
+		return
+	}
+	if err = handleErr(response); err != nil {
+		return
+	}
+	err = json.Unmarshal(r, &tickers)
+	return
+}
+
+// GetTradingFee returns your (account-specific) commission rates for a market
+func (b *HitBtc) GetTradingFee(market string) (fee TradingFee, err error) {
+	r, err := b.client.do("GET", "trading/fee/"+strings.ToUpper(market), nil, true)
+	if err != nil {
+		return
+	}
+	var response interface{}
+	if err = json.Unmarshal(r, &response); err != nil {
+		return
+	}
+	if err = handleErr(response); err != nil {
+		return
+	}
+	err = json.Unmarshal(r, &fee)
+	return
+}
+
 // GetTrades used to retrieve your trade history.
 // market string literal for the market (ie. BTC/LTC). If set to "all", will return for all market
 func (b *HitBtc) GetTrades(currencyPair string) (trades []Trade, err error) {
@@ -171,6 +211,23 @@ func (b *HitBtc) GetOrder(orderId string) (orders []Order, err error) {
 	return
 }
 
+// GetActiveOrder returns an open order. once the order has been closed, look for it with GetOrder.
+func (b *HitBtc) GetActiveOrder(clientOrderId string) (order Order, err error) {
+	r, err := b.client.do("GET", "order/"+clientOrderId, nil, true)
+	if err != nil {
+		return
+	}
+	var response interface{}
+	if err = json.Unmarshal(r, &response); err != nil {
+		return
+	}
+	if err = handleErr(response); err != nil {
+		return
+	}
+	err = json.Unmarshal(r, &order)
+	return
+}
+
 func (b *HitBtc) GetOrderHistory() (orders []Order, err error) {
 	r, err := b.client.do("GET", "history/order", nil, true)
 	if err != nil {
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"strconv"
 	"time"
+
+	"github.com/svanas/nefertiti/clock"
 )
 
 const (
@@ -13,6 +15,15 @@ const (
 	ORDER_TYPE_STOP_MARKET = "stopMarket"
 )
 
+const (
+	ORDER_STATUS_NEW              = "new"
+	ORDER_STATUS_SUSPENDED        = "suspended"
+	ORDER_STATUS_PARTIALLY_FILLED = "partiallyFilled"
+	ORDER_STATUS_FILLED           = "filled"
+	ORDER_STATUS_CANCELED         = "canceled"
+	ORDER_STATUS_EXPIRED          = "expired"
+)
+
 const (
 	GTC = "GTC"
 	IOC = "IOC"
@@ -68,19 +79,19 @@ func (t *Order) UnmarshalJSON(data []byte) error {
 		return err
 	}
 	if aux.Created != "" {
-		t.Created, err = time.Parse("2006-01-02T15:04:05.999Z", aux.Created)
+		t.Created, err = clock.Parse(aux.Created, TIME_FORMAT)
 		if err != nil {
 			return err
 		}
 	}
 	if aux.Updated != "" {
-		t.Updated, err = time.Parse("2006-01-02T15:04:05.999Z", aux.Updated)
+		t.Updated, err = clock.Parse(aux.Updated, TIME_FORMAT)
 		if err != nil {
 			return err
 		}
 	}
 	if aux.Expire != "" {
-		t.Expire, err = time.Parse("2006-01-02T15:04:05.999Z", aux.Expire)
+		t.Expire, err = clock.Parse(aux.Expire, TIME_FORMAT)
 		if err != nil {
 			return err
 		}
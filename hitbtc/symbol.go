@@ -10,3 +10,8 @@ type Symbol struct {
 	ProvideLiquidityRate float64 `json:"provideLiquidityRate,string"`
 	FeeCurrency          string  `json:"feeCurrency"`
 }
+
+type TradingFee struct {
+	TakeLiquidityRate    float64 `json:"takeLiquidityRate,string"`
+	ProvideLiquidityRate float64 `json:"provideLiquidityRate,string"`
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"strconv"
 	"time"
+
+	"github.com/svanas/nefertiti/clock"
 )
 
 type Ticker struct {
@@ -65,7 +67,7 @@ func (t *Ticker) UnmarshalJSON(data []byte) error {
 		return err
 	}
 	//---- END ---- svanas --- 2018-04-04 -------------------------------------
-	if t.Timestamp, err = time.Parse("2006-01-02T15:04:05.999Z", aux.Timestamp); err != nil {
+	if t.Timestamp, err = clock.Parse(aux.Timestamp, TIME_FORMAT); err != nil {
 		return err
 	}
 	return nil
@@ -3,6 +3,8 @@ package hitbtc
 import (
 	"encoding/json"
 	"time"
+
+	"github.com/svanas/nefertiti/clock"
 )
 
 type Trade struct {
@@ -29,7 +31,7 @@ func (t *Trade) UnmarshalJSON(data []byte) error {
 	if err = json.Unmarshal(data, &aux); err != nil {
 		return err
 	}
-	t.Timestamp, err = time.Parse("2006-01-02T15:04:05.999Z", aux.Timestamp)
+	t.Timestamp, err = clock.Parse(aux.Timestamp, TIME_FORMAT)
 	if err != nil {
 		return err
 	}
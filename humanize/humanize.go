@@ -0,0 +1,61 @@
+// Package humanize formats durations, ages and distances the way we put them in our messages.
+package humanize
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type unit struct {
+	name string
+	size time.Duration
+}
+
+var units = []unit{
+	{"day", 24 * time.Hour},
+	{"hour", time.Hour},
+	{"minute", time.Minute},
+	{"second", time.Second},
+}
+
+// Duration returns the two largest units of a duration, for example: 21 days 3 hours
+func Duration(d time.Duration) string {
+	if d < 0 {
+		d = -d
+	}
+	var out []string
+	for _, unit := range units {
+		if len(out) == 2 {
+			break
+		}
+		n := d / unit.size
+		if n > 0 {
+			if n == 1 {
+				out = append(out, fmt.Sprintf("1 %s", unit.name))
+			} else {
+				out = append(out, fmt.Sprintf("%d %ss", n, unit.name))
+			}
+			d -= n * unit.size
+		} else if len(out) > 0 {
+			break // 2 days 0 hours 5 minutes is not what we want
+		}
+	}
+	if len(out) == 0 {
+		return "0 seconds"
+	}
+	return strings.Join(out, " ")
+}
+
+// Age returns how long ago a moment was, for example: 21 days 3 hours ago
+func Age(t time.Time) string {
+	return Duration(time.Since(t)) + " ago"
+}
+
+// Distance returns how far the price needs to move (in percent) to reach the target, for example: +4.20%
+func Distance(price, target float64) string {
+	if price == 0 {
+		return "n/a"
+	}
+	return fmt.Sprintf("%+.2f%%", ((target - price) / price * 100))
+}
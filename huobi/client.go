@@ -3,11 +3,14 @@ package huobi
 import (
 	"bytes"
 	"encoding/json"
-	"errors"
-	"io/ioutil"
+	"io"
 	"net/http"
 	"net/url"
 	"time"
+
+	"github.com/svanas/nefertiti/clock"
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/ratelimit"
 )
 
 var (
@@ -15,6 +18,7 @@ var (
 	RequestsPerSecond float64                         = 10
 	BeforeRequest     func(method, path string) error = nil
 	AfterRequest      func()                          = nil
+	ServerTime        *clock.Offset                   = clock.New("Huobi")
 )
 
 func init() {
@@ -43,7 +47,8 @@ func New(URL, apiKey, apiSecret string) *Client {
 		apiKey,
 		apiSecret,
 		&http.Client{
-			Timeout: 30 * time.Second,
+			Timeout:   30 * time.Second,
+			Transport: ratelimit.NewTransport("Huobi", nil),
 		},
 	}
 }
@@ -55,7 +60,7 @@ func (client *Client) do(req *http.Request) ([]byte, error) {
 	}
 	defer resp.Body.Close()
 
-	body, err := ioutil.ReadAll(resp.Body)
+	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, err
 	}
@@ -65,13 +70,18 @@ func (client *Client) do(req *http.Request) ([]byte, error) {
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
-		return nil, errors.New(resp.Status)
+		return nil, errors.NewStatusError(resp.StatusCode, resp.Status)
 	}
 
 	return body, nil
 }
 
 func (client *Client) get(path string, query url.Values, auth bool) ([]byte, error) {
+	// sync with the server time before we sign this request
+	if auth {
+		ServerTime.Sync(client.getServerTime)
+	}
+
 	// respect the rate limit
 	err := BeforeRequest(http.MethodGet, path)
 	if err != nil {
@@ -96,7 +106,7 @@ func (client *Client) get(path string, query url.Values, auth bool) ([]byte, err
 		query.Add("AccessKeyId", client.apiKey)
 		query.Add("SignatureMethod", "HmacSHA256")
 		query.Add("SignatureVersion", "2")
-		query.Add("Timestamp", time.Now().UTC().Format("2006-01-02T15:04:05"))
+		query.Add("Timestamp", ServerTime.Now().UTC().Format("2006-01-02T15:04:05"))
 		query.Add("Signature", sign(client.apiSecret, http.MethodGet, endpoint.Host, path, query))
 	}
 
@@ -116,6 +126,9 @@ func (client *Client) get(path string, query url.Values, auth bool) ([]byte, err
 }
 
 func (client *Client) post(path string, params interface{}) ([]byte, error) {
+	// sync with the server time before we sign this request
+	ServerTime.Sync(client.getServerTime)
+
 	// respect the rate limit
 	err := BeforeRequest(http.MethodPost, path)
 	if err != nil {
@@ -137,7 +150,7 @@ func (client *Client) post(path string, params interface{}) ([]byte, error) {
 	query.Add("AccessKeyId", client.apiKey)
 	query.Add("SignatureMethod", "HmacSHA256")
 	query.Add("SignatureVersion", "2")
-	query.Add("Timestamp", time.Now().UTC().Format("2006-01-02T15:04:05"))
+	query.Add("Timestamp", ServerTime.Now().UTC().Format("2006-01-02T15:04:05"))
 	query.Add("Signature", sign(client.apiSecret, http.MethodPost, endpoint.Host, path, query))
 	endpoint.RawQuery = query.Encode()
 
@@ -162,3 +175,17 @@ func (client *Client) post(path string, params interface{}) ([]byte, error) {
 	// do the request
 	return client.do(req)
 }
+
+func (client *Client) getServerTime() (time.Time, error) {
+	body, err := client.get("/v1/common/timestamp", nil, false)
+	if err != nil {
+		return time.Time{}, err
+	}
+	var resp struct {
+		Data int64 `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return time.Time{}, err
+	}
+	return clock.FromMilli(resp.Data), nil
+}
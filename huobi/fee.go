@@ -0,0 +1,51 @@
+package huobi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+type TradingFee struct {
+	Symbol          string `json:"symbol"`
+	ActualMakerRate string `json:"actualMakerRate"`
+	ActualTakerRate string `json:"actualTakerRate"`
+}
+
+// GetTradingFee returns your (account-specific) commission rates for a market, after the point-card deductions
+func (client *Client) GetTradingFee(symbol string) (maker, taker float64, err error) {
+	type Response struct {
+		Data []TradingFee `json:"data"`
+	}
+
+	var (
+		body []byte
+		resp Response
+	)
+
+	params := url.Values{}
+	params.Add("symbols", symbol)
+
+	if body, err = client.get("/v2/reference/transact-fee-rate", params, true); err != nil {
+		return 0, 0, err
+	}
+
+	if err = json.Unmarshal(body, &resp); err != nil {
+		return 0, 0, err
+	}
+
+	for _, fee := range resp.Data {
+		if fee.Symbol == symbol {
+			if maker, err = strconv.ParseFloat(fee.ActualMakerRate, 64); err != nil {
+				return 0, 0, err
+			}
+			if taker, err = strconv.ParseFloat(fee.ActualTakerRate, 64); err != nil {
+				return 0, 0, err
+			}
+			return maker, taker, nil
+		}
+	}
+
+	return 0, 0, fmt.Errorf("symbol %s does not exist", symbol)
+}
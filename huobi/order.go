@@ -53,6 +53,23 @@ type Order struct {
 	Id               int64      `json:"id"`                        // the unique identity for the order
 	State            OrderState `json:"state"`                     // order status (see above)
 	OrderType        OrderType  `json:"type"`                      // order type (see above)
+	FieldAmount      float64    `json:"field-amount,string"`       // the amount which has been filled, if you got one order
+	FinishedAt       int64      `json:"finished-at"`               // the timestamp in milliseconds when the order was filled or cancelled
+}
+
+// GetFilledAmount returns the amount which has been filled. the order endpoints disagree on what to call it.
+func (order *Order) GetFilledAmount() float64 {
+	if order.FilledAmount > 0 {
+		return order.FilledAmount
+	}
+	return order.FieldAmount
+}
+
+func (order *Order) GetFinishedAt() time.Time {
+	if order.FinishedAt == 0 {
+		return time.Time{}
+	}
+	return time.Unix((order.FinishedAt / 1000), 0)
 }
 
 func (order *Order) Buy() bool {
@@ -156,6 +173,28 @@ func (client *Client) OpenOrders(symbol string) ([]Order, error) {
 	return resp.Data, nil
 }
 
+func (client *Client) GetOrder(orderId int64) (*Order, error) {
+	type Response struct {
+		Data Order `json:"data"`
+	}
+
+	var (
+		err  error
+		body []byte
+		resp Response
+	)
+
+	if body, err = client.get(fmt.Sprintf("/v1/order/orders/%d", orderId), url.Values{}, true); err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.Data, nil
+}
+
 func (client *Client) CancelOrder(orderId int64) error {
 	_, err := client.post(fmt.Sprintf("/v1/order/orders/%d/submitcancel", orderId), nil)
 	return err
@@ -5,13 +5,18 @@ import (
 )
 
 type Response struct {
-	Status string `json:"status"`
-	ErrMsg string `json:"err-msg"`
+	Status  string `json:"status"`
+	ErrMsg  string `json:"err-msg"`
+	Code    int    `json:"code"`    // the v2 endpoints return a code (and a message) instead of a status
+	Message string `json:"message"` // v2
 }
 
 func IsError(body []byte) (bool, string) {
 	var resp Response
 	if json.Unmarshal(body, &resp) == nil {
+		if resp.Status == "" && resp.Code != 0 {
+			return resp.Code != 200, resp.Message
+		}
 		return resp.Status != "ok", resp.ErrMsg
 	}
 	return false, ""
@@ -12,6 +12,7 @@ type Summary struct {
 	Close  float64 `json:"close"`  // the closing price of last 24 hours
 	Volume float64 `json:"vol"`    // the trading volume in base currency of last 24 hours
 	Amount float64 `json:"amount"` // the aggregated trading volume in USDT of last 24 hours
+	Count  int64   `json:"count"`  // the number of completed trades of last 24 hours
 }
 
 func (client *Client) Summary(symbol string) (*Summary, error) {
@@ -37,3 +37,52 @@ func (client *Client) Ticker(symbol string) (*Ticker, error) {
 
 	return &resp.Tick.Data[0], nil
 }
+
+type Tick struct {
+	Symbol string  `json:"symbol"`
+	Open   float64 `json:"open"`   // the opening price of last 24 hours
+	High   float64 `json:"high"`   // the highest price of last 24 hours
+	Low    float64 `json:"low"`    // the lowest price of last 24 hours
+	Close  float64 `json:"close"`  // the last price
+	Amount float64 `json:"amount"` // the trading volume in base currency of last 24 hours
+	Vol    float64 `json:"vol"`    // the trading value in quote currency of last 24 hours
+	Count  int64   `json:"count"`  // the number of completed trades of last 24 hours
+}
+
+// Tickers returns the 24h summary of every symbol
+func (client *Client) Tickers() ([]Tick, error) {
+	type Response struct {
+		Data []Tick `json:"data"`
+	}
+
+	var (
+		err  error
+		body []byte
+		resp Response
+	)
+
+	if body, err = client.get("/market/tickers", nil, false); err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Data, nil
+}
+
+// Prices returns the last price of every symbol, indexed by symbol
+func (client *Client) Prices() (map[string]float64, error) {
+	ticks, err := client.Tickers()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]float64)
+	for _, tick := range ticks {
+		out[tick.Symbol] = tick.Close
+	}
+
+	return out, nil
+}
@@ -0,0 +1,157 @@
+// Package i18n translates the notifications we send you.
+package i18n
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/svanas/nefertiti/flag"
+)
+
+const DEFAULT_LANG = "en"
+
+// --lang=[en|nl|de|fr|es] (optional, defaults to en)
+func Lang() string {
+	arg := flag.Get("lang")
+	if arg.Exists && arg.String() != "" {
+		lang := strings.ToLower(arg.String())
+		// en_US.UTF-8 -> en
+		if i := strings.IndexAny(lang, "_-."); i > -1 {
+			lang = lang[:i]
+		}
+		if _, ok := catalog[lang]; ok {
+			return lang
+		}
+	}
+	return DEFAULT_LANG
+}
+
+// T returns the translation of a message in the --lang=X language, or the message itself if we do not have one.
+func T(msg string) string {
+	if translations, ok := catalog[Lang()]; ok {
+		if out, ok := translations[msg]; ok {
+			return out
+		}
+	}
+	return msg
+}
+
+// Sprintf translates the format, then formats according to it. The string arguments that we have a translation for
+// (for example: Buy and Sell) are translated too.
+func Sprintf(format string, a ...interface{}) string {
+	lang := Lang()
+	if lang == DEFAULT_LANG {
+		return fmt.Sprintf(format, a...)
+	}
+	args := make([]interface{}, len(a))
+	for i, arg := range a {
+		args[i] = arg
+		if arg != nil && reflect.TypeOf(arg).Kind() == reflect.String {
+			if word, ok := words[lang][strings.ToLower(fmt.Sprint(arg))]; ok {
+				args[i] = word
+			}
+		}
+	}
+	return fmt.Sprintf(T(format), args...)
+}
+
+// words are the order sides (and the like) that we translate when they are an argument to Sprintf
+var words = map[string]map[string]string{
+	"nl": {
+		"buy":        "Koop",
+		"sell":       "Verkoop",
+		"withdrawal": "opname",
+	},
+	"de": {
+		"buy":        "Kauf",
+		"sell":       "Verkauf",
+		"withdrawal": "Auszahlung",
+	},
+	"fr": {
+		"buy":        "Achat",
+		"sell":       "Vente",
+		"withdrawal": "Retrait",
+	},
+	"es": {
+		"buy":        "Compra",
+		"sell":       "Venta",
+		"withdrawal": "Retiro",
+	},
+}
+
+var catalog = map[string]map[string]string{
+	"en": {},
+	"nl": {
+		"Open %s":                         "%s geopend",
+		"Done %s":                         "%s uitgevoerd",
+		"Done %s (Reason: Cancelled)":     "%s gesloten (reden: geannuleerd)",
+		"Done %s (Reason: Filled)":        "%s gesloten (reden: gevuld)",
+		"Done %s (Reason: Filled %s qty)": "%s gesloten (reden: %s gevuld)",
+		"Done Sell %s":                    "Verkoop uitgevoerd %s",
+		"New Sell":                        "Nieuwe verkoop",
+		"New Buy":                         "Nieuwe koop",
+		"Listening to %s...":              "Luistert naar %s...",
+		"Your realized losses over the last 24 hours (%.8f %s) exceed your max daily loss of %.8f %s. New buys are paused until you run the resume command.": "Je gerealiseerde verliezen van de afgelopen 24 uur (%.8f %s) overschrijden je maximale dagverlies van %.8f %s. Nieuwe aankopen zijn gepauzeerd totdat je het resume-commando uitvoert.",
+		"Your stop-loss on %s got filled. You lost %.8f %s.":                         "Je stop-loss op %s is uitgevoerd. Je verloor %.8f %s.",
+		"Recovered from a crash: %v":                                                 "Hersteld na een crash: %v",
+		"Crashed %d times during the last hour. Last crash: %v":                      "%d keer gecrasht in het afgelopen uur. Laatste crash: %v",
+		"While you were away (%d)":                                                   "Terwijl je weg was (%d)",
+		"Unexpected %s on your account: %v %s to %s at %s. The bot did not do this.": "Onverwachte %s op je account: %v %s naar %s om %s. De bot heeft dit niet gedaan.",
+		"Settings changed (%d)":                                                      "Instellingen gewijzigd (%d)",
+	},
+	"de": {
+		"Open %s":                         "%s eröffnet",
+		"Done %s":                         "%s ausgeführt",
+		"Done %s (Reason: Cancelled)":     "%s beendet (Grund: storniert)",
+		"Done %s (Reason: Filled)":        "%s beendet (Grund: ausgeführt)",
+		"Done %s (Reason: Filled %s qty)": "%s beendet (Grund: %s ausgeführt)",
+		"Done Sell %s":                    "Verkauf ausgeführt %s",
+		"New Sell":                        "Neuer Verkauf",
+		"New Buy":                         "Neuer Kauf",
+		"Listening to %s...":              "Verbunden mit %s...",
+		"Your realized losses over the last 24 hours (%.8f %s) exceed your max daily loss of %.8f %s. New buys are paused until you run the resume command.": "Deine realisierten Verluste der letzten 24 Stunden (%.8f %s) übersteigen deinen maximalen Tagesverlust von %.8f %s. Neue Käufe sind pausiert, bis du den Befehl resume ausführst.",
+		"Your stop-loss on %s got filled. You lost %.8f %s.":                         "Dein Stop-Loss auf %s wurde ausgeführt. Du hast %.8f %s verloren.",
+		"Recovered from a crash: %v":                                                 "Nach einem Absturz wiederhergestellt: %v",
+		"Crashed %d times during the last hour. Last crash: %v":                      "%d Abstürze in der letzten Stunde. Letzter Absturz: %v",
+		"While you were away (%d)":                                                   "Während du weg warst (%d)",
+		"Unexpected %s on your account: %v %s to %s at %s. The bot did not do this.": "Unerwartete %s auf deinem Konto: %v %s an %s um %s. Der Bot hat das nicht getan.",
+		"Settings changed (%d)":                                                      "Einstellungen geändert (%d)",
+	},
+	"fr": {
+		"Open %s":                         "Ouverture : %s",
+		"Done %s":                         "Exécution : %s",
+		"Done %s (Reason: Cancelled)":     "Clôture : %s (raison : annulé)",
+		"Done %s (Reason: Filled)":        "Clôture : %s (raison : exécuté)",
+		"Done %s (Reason: Filled %s qty)": "Clôture : %s (raison : %s exécuté)",
+		"Done Sell %s":                    "Exécution : Vente %s",
+		"New Sell":                        "Nouvelle vente",
+		"New Buy":                         "Nouvel achat",
+		"Listening to %s...":              "À l'écoute de %s...",
+		"Your realized losses over the last 24 hours (%.8f %s) exceed your max daily loss of %.8f %s. New buys are paused until you run the resume command.": "Vos pertes réalisées sur les dernières 24 heures (%.8f %s) dépassent votre perte journalière maximale de %.8f %s. Les nouveaux achats sont suspendus jusqu'à ce que vous lanciez la commande resume.",
+		"Your stop-loss on %s got filled. You lost %.8f %s.":                         "Votre stop-loss sur %s a été exécuté. Vous avez perdu %.8f %s.",
+		"Recovered from a crash: %v":                                                 "Rétabli après un plantage : %v",
+		"Crashed %d times during the last hour. Last crash: %v":                      "%d plantages au cours de la dernière heure. Dernier plantage : %v",
+		"While you were away (%d)":                                                   "Pendant votre absence (%d)",
+		"Unexpected %s on your account: %v %s to %s at %s. The bot did not do this.": "%s inattendu sur votre compte : %v %s vers %s à %s. Le bot n'a pas fait cela.",
+		"Settings changed (%d)":                                                      "Paramètres modifiés (%d)",
+	},
+	"es": {
+		"Open %s":                         "Apertura: %s",
+		"Done %s":                         "Ejecución: %s",
+		"Done %s (Reason: Cancelled)":     "Cierre: %s (motivo: cancelada)",
+		"Done %s (Reason: Filled)":        "Cierre: %s (motivo: ejecutada)",
+		"Done %s (Reason: Filled %s qty)": "Cierre: %s (motivo: %s ejecutada)",
+		"Done Sell %s":                    "Ejecución: Venta %s",
+		"New Sell":                        "Nueva venta",
+		"New Buy":                         "Nueva compra",
+		"Listening to %s...":              "Escuchando %s...",
+		"Your realized losses over the last 24 hours (%.8f %s) exceed your max daily loss of %.8f %s. New buys are paused until you run the resume command.": "Tus pérdidas realizadas en las últimas 24 horas (%.8f %s) superan tu pérdida diaria máxima de %.8f %s. Las nuevas compras están en pausa hasta que ejecutes el comando resume.",
+		"Your stop-loss on %s got filled. You lost %.8f %s.":                         "Tu stop-loss en %s se ha ejecutado. Has perdido %.8f %s.",
+		"Recovered from a crash: %v":                                                 "Recuperado tras un fallo: %v",
+		"Crashed %d times during the last hour. Last crash: %v":                      "%d fallos durante la última hora. Último fallo: %v",
+		"While you were away (%d)":                                                   "Mientras no estabas (%d)",
+		"Unexpected %s on your account: %v %s to %s at %s. The bot did not do this.": "%s inesperado en tu cuenta: %v %s a %s el %s. El bot no ha hecho esto.",
+		"Settings changed (%d)":                                                      "Ajustes modificados (%d)",
+	},
+}
@@ -0,0 +1,42 @@
+package jupiter
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"testing"
+)
+
+func TestBase58(t *testing.T) {
+	decoded, err := Base58Decode(MINT_SOL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded) != 32 {
+		t.Errorf("TestBase58 failed, got: %v bytes, want: 32 bytes.", len(decoded))
+	}
+	if encoded := Base58Encode(decoded); encoded != MINT_SOL {
+		t.Errorf("TestBase58 failed, got: %v, want: %v.", encoded, MINT_SOL)
+	}
+}
+
+func TestSignTransaction(t *testing.T) {
+	seed := bytes.Repeat([]byte{1}, ed25519.SeedSize)
+	keypair := &Keypair{key: ed25519.NewKeyFromSeed(seed)}
+
+	// one (empty) signature followed by the message
+	message := []byte("message")
+	tx := append(append([]byte{1}, make([]byte, 64)...), message...)
+
+	signed, err := signTransaction(keypair, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	public := keypair.key.Public().(ed25519.PublicKey)
+	if !ed25519.Verify(public, message, signed[1:65]) {
+		t.Errorf("TestSignTransaction failed, signature does not verify.")
+	}
+	if !bytes.Equal(signed[65:], message) {
+		t.Errorf("TestSignTransaction failed, message got modified.")
+	}
+}
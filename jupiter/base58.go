@@ -0,0 +1,65 @@
+package jupiter
+
+import (
+	"errors"
+	"math/big"
+)
+
+const alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// encodes bytes (for example a public key or a signature) the way Solana does
+func Base58Encode(input []byte) string {
+	var (
+		zero = big.NewInt(0)
+		base = big.NewInt(58)
+		num  = new(big.Int).SetBytes(input)
+		mod  = new(big.Int)
+		out  []byte
+	)
+	for num.Cmp(zero) > 0 {
+		num.DivMod(num, base, mod)
+		out = append(out, alphabet[mod.Int64()])
+	}
+	// leading zero bytes are encoded as 1
+	for _, b := range input {
+		if b != 0 {
+			break
+		}
+		out = append(out, alphabet[0])
+	}
+	// reverse
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
+
+func Base58Decode(input string) ([]byte, error) {
+	var (
+		base = big.NewInt(58)
+		num  = new(big.Int)
+	)
+	for _, r := range input {
+		i := -1
+		for n, c := range alphabet {
+			if c == r {
+				i = n
+				break
+			}
+		}
+		if i < 0 {
+			return nil, errors.New("invalid base58 string")
+		}
+		num.Mul(num, base)
+		num.Add(num, big.NewInt(int64(i)))
+	}
+	out := num.Bytes()
+	// leading 1s are decoded as zero bytes
+	for _, r := range input {
+		if r != rune(alphabet[0]) {
+			break
+		}
+		out = append([]byte{0}, out...)
+	}
+	return out, nil
+}
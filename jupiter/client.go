@@ -0,0 +1,132 @@
+package jupiter
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/ratelimit"
+)
+
+const (
+	API_BASE   = "https://quote-api.jup.ag/v6"
+	PRICE_BASE = "https://price.jup.ag/v6"
+	TOKEN_LIST = "https://token.jup.ag/strict"
+	RPC_BASE   = "https://api.mainnet-beta.solana.com"
+)
+
+var (
+	lastRequest       time.Time
+	RequestsPerSecond float64                         = 5
+	BeforeRequest     func(method, path string) error = nil
+	AfterRequest      func()                          = nil
+)
+
+func init() {
+	BeforeRequest = func(method, path string) error {
+		elapsed := time.Since(lastRequest)
+		if elapsed.Seconds() < (float64(1) / RequestsPerSecond) {
+			time.Sleep(time.Duration((float64(time.Second) / RequestsPerSecond) - float64(elapsed)))
+		}
+		return nil
+	}
+	AfterRequest = func() {
+		lastRequest = time.Now()
+	}
+}
+
+type Client struct {
+	URL        string // the Jupiter API
+	RPC        string // the Solana JSON RPC
+	keypair    *Keypair
+	httpClient *http.Client
+}
+
+// keypair is optional. without it, you get a read-only client (quotes and prices only).
+func New(URL, RPC string, keypair *Keypair) *Client {
+	return &Client{
+		URL,
+		RPC,
+		keypair,
+		&http.Client{
+			Timeout:   30 * time.Second,
+			Transport: ratelimit.NewTransport("Jupiter", nil),
+		},
+	}
+}
+
+func (client *Client) PublicKey() (string, error) {
+	if client.keypair == nil {
+		return "", errors.New("keypair is missing")
+	}
+	return client.keypair.PublicKey(), nil
+}
+
+func (client *Client) do(req *http.Request) ([]byte, error) {
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+			return nil, errors.NewStatusError(resp.StatusCode, resp.Status)
+		}
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		if err, msg := IsError(body); err {
+			return body, errors.NewStatusError(resp.StatusCode, msg)
+		}
+		return body, errors.NewStatusError(resp.StatusCode, resp.Status)
+	}
+
+	return body, nil
+}
+
+func (client *Client) call(method, URL string, query url.Values, payload interface{}) ([]byte, error) {
+	// respect the rate limit
+	err := BeforeRequest(method, URL)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		AfterRequest()
+	}()
+
+	// set the endpoint for this request
+	endpoint, err := url.Parse(URL)
+	if err != nil {
+		return nil, err
+	}
+	if query != nil {
+		endpoint.RawQuery = query.Encode()
+	}
+
+	// create the request
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, endpoint.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Add("Content-Type", "application/json")
+	}
+
+	// do the request
+	return client.do(req)
+}
@@ -0,0 +1,31 @@
+package jupiter
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type Error struct {
+	Message string `json:"error"`
+}
+
+func IsError(response []byte) (bool, string) {
+	var err Error
+	if json.Unmarshal(response, &err) == nil {
+		return err.Message != "", err.Message
+	}
+	return false, ""
+}
+
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (err *RPCError) Error() string {
+	return fmt.Sprintf("%d %s", err.Code, err.Message)
+}
+
+func (err *Error) Error() string {
+	return err.Message
+}
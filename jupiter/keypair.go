@@ -0,0 +1,46 @@
+package jupiter
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"os"
+)
+
+type Keypair struct {
+	key ed25519.PrivateKey
+}
+
+// reads a keypair in the format of solana-keygen, aka a JSON array of 64 bytes
+func LoadKeypair(path string) (*Keypair, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var key []byte
+	if err = json.Unmarshal(data, &key); err != nil {
+		// encoding/json expects a base64 string for []byte, so we fall back on []int
+		var ints []int
+		if err = json.Unmarshal(data, &ints); err != nil {
+			return nil, err
+		}
+		for _, i := range ints {
+			key = append(key, byte(i))
+		}
+	}
+
+	if len(key) != ed25519.PrivateKeySize {
+		return nil, errors.New("invalid keypair")
+	}
+
+	return &Keypair{key: ed25519.PrivateKey(key)}, nil
+}
+
+func (keypair *Keypair) PublicKey() string {
+	return Base58Encode(keypair.key.Public().(ed25519.PublicKey))
+}
+
+func (keypair *Keypair) Sign(message []byte) []byte {
+	return ed25519.Sign(keypair.key, message)
+}
@@ -0,0 +1,98 @@
+package jupiter
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+type Quote struct {
+	InputMint            string `json:"inputMint"`
+	InAmount             string `json:"inAmount"`
+	OutputMint           string `json:"outputMint"`
+	OutAmount            string `json:"outAmount"`
+	OtherAmountThreshold string `json:"otherAmountThreshold"`
+	SlippageBps          int    `json:"slippageBps"`
+	PriceImpactPct       string `json:"priceImpactPct"`
+	raw                  json.RawMessage
+}
+
+// the swap endpoint wants the quote exactly as we got it
+func (quote *Quote) UnmarshalJSON(data []byte) error {
+	type alias Quote
+	var aux alias
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*quote = Quote(aux)
+	quote.raw = append(json.RawMessage{}, data...)
+	return nil
+}
+
+func (quote *Quote) MarshalJSON() ([]byte, error) {
+	if quote.raw != nil {
+		return quote.raw, nil
+	}
+	type alias Quote
+	return json.Marshal((*alias)(quote))
+}
+
+// returns a quote for swapping an amount (in the smallest unit) of the input token, bounded by a maximum slippage in basis points.
+func (client *Client) Quote(inputMint, outputMint string, amount uint64, slippageBps int) (*Quote, error) {
+	query := url.Values{}
+	query.Add("inputMint", inputMint)
+	query.Add("outputMint", outputMint)
+	query.Add("amount", strconv.FormatUint(amount, 10))
+	query.Add("slippageBps", strconv.Itoa(slippageBps))
+
+	var (
+		err  error
+		body []byte
+		out  Quote
+	)
+	if body, err = client.call(http.MethodGet, (client.URL + "/quote"), query, nil); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(body, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+type Price struct {
+	ID            string  `json:"id"`
+	MintSymbol    string  `json:"mintSymbol"`
+	VsToken       string  `json:"vsToken"`
+	VsTokenSymbol string  `json:"vsTokenSymbol"`
+	Price         float64 `json:"price"`
+}
+
+// returns the price of a token, expressed in another token
+func (client *Client) Price(mint, vsToken string) (float64, error) {
+	query := url.Values{}
+	query.Add("ids", mint)
+	query.Add("vsToken", vsToken)
+
+	var (
+		err  error
+		body []byte
+		out  struct {
+			Data map[string]Price `json:"data"`
+		}
+	)
+	if body, err = client.call(http.MethodGet, (PRICE_BASE + "/price"), query, nil); err != nil {
+		return 0, err
+	}
+	if err = json.Unmarshal(body, &out); err != nil {
+		return 0, err
+	}
+
+	price, ok := out.Data[mint]
+	if !ok {
+		return 0, &Error{Message: "no price for " + mint}
+	}
+
+	return price.Price, nil
+}
@@ -0,0 +1,91 @@
+package jupiter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *RPCError       `json:"error"`
+}
+
+func (client *Client) rpc(method string, params ...interface{}) (json.RawMessage, error) {
+	body, err := client.call(http.MethodPost, client.RPC, nil, map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  method,
+		"params":  params,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var out rpcResponse
+	if err = json.Unmarshal(body, &out); err != nil {
+		return nil, err
+	}
+	if out.Error != nil {
+		return nil, out.Error
+	}
+
+	return out.Result, nil
+}
+
+// sends a signed transaction, returns the signature
+func (client *Client) SendTransaction(encoded string) (string, error) {
+	result, err := client.rpc("sendTransaction", encoded, map[string]interface{}{
+		"encoding":      "base64",
+		"skipPreflight": false,
+		"maxRetries":    3,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var signature string
+	if err = json.Unmarshal(result, &signature); err != nil {
+		return "", err
+	}
+
+	return signature, nil
+}
+
+type signatureStatus struct {
+	ConfirmationStatus string      `json:"confirmationStatus"`
+	Err                interface{} `json:"err"`
+}
+
+// waits for a transaction to get confirmed
+func (client *Client) ConfirmTransaction(signature string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		result, err := client.rpc("getSignatureStatuses", []string{signature})
+		if err != nil {
+			return err
+		}
+
+		var out struct {
+			Value []*signatureStatus `json:"value"`
+		}
+		if err = json.Unmarshal(result, &out); err != nil {
+			return err
+		}
+
+		if len(out.Value) > 0 && out.Value[0] != nil {
+			status := out.Value[0]
+			if status.Err != nil {
+				return fmt.Errorf("transaction %s failed: %v", signature, status.Err)
+			}
+			if status.ConfirmationStatus == "confirmed" || status.ConfirmationStatus == "finalized" {
+				return nil
+			}
+		}
+
+		time.Sleep(2 * time.Second)
+	}
+	return errors.New("timeout waiting for transaction " + signature)
+}
@@ -0,0 +1,54 @@
+package jupiter
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// swaps according to a quote, signs the transaction with our keypair, sends it, waits for a confirmation, then returns the signature
+func (client *Client) Swap(quote *Quote) (string, error) {
+	publicKey, err := client.PublicKey()
+	if err != nil {
+		return "", err
+	}
+
+	var (
+		body []byte
+		out  struct {
+			SwapTransaction string `json:"swapTransaction"`
+		}
+	)
+	if body, err = client.call(http.MethodPost, (client.URL + "/swap"), nil, map[string]interface{}{
+		"quoteResponse":             quote,
+		"userPublicKey":             publicKey,
+		"wrapAndUnwrapSol":          true,
+		"dynamicComputeUnitLimit":   true,
+		"prioritizationFeeLamports": "auto",
+	}); err != nil {
+		return "", err
+	}
+	if err = json.Unmarshal(body, &out); err != nil {
+		return "", err
+	}
+
+	tx, err := base64.StdEncoding.DecodeString(out.SwapTransaction)
+	if err != nil {
+		return "", err
+	}
+	if tx, err = signTransaction(client.keypair, tx); err != nil {
+		return "", err
+	}
+
+	signature, err := client.SendTransaction(base64.StdEncoding.EncodeToString(tx))
+	if err != nil {
+		return "", err
+	}
+
+	if err = client.ConfirmTransaction(signature, time.Minute); err != nil {
+		return signature, err
+	}
+
+	return signature, nil
+}
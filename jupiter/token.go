@@ -0,0 +1,49 @@
+package jupiter
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+)
+
+const (
+	MINT_SOL  = "So11111111111111111111111111111111111111112"
+	MINT_USDC = "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"
+)
+
+type Token struct {
+	Address  string `json:"address"`
+	Symbol   string `json:"symbol"`
+	Name     string `json:"name"`
+	Decimals int    `json:"decimals"`
+}
+
+// converts a size into the smallest unit of the token, for example lamports
+func (token *Token) ToAmount(size float64) uint64 {
+	return uint64(math.Floor(size * math.Pow10(token.Decimals)))
+}
+
+func (token *Token) FromAmount(amount string) float64 {
+	value, err := strconv.ParseFloat(amount, 64)
+	if err != nil {
+		return 0
+	}
+	return value / math.Pow10(token.Decimals)
+}
+
+// returns the strict (aka verified) token list
+func (client *Client) Tokens() ([]Token, error) {
+	var (
+		err  error
+		body []byte
+		out  []Token
+	)
+	if body, err = client.call(http.MethodGet, TOKEN_LIST, nil, nil); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(body, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,43 @@
+package jupiter
+
+import (
+	"errors"
+)
+
+// reads a compact-u16, returns (value, number of bytes read)
+func readCompactU16(data []byte) (int, int, error) {
+	value := 0
+	for i := 0; i < 3; i++ {
+		if i >= len(data) {
+			return 0, 0, errors.New("invalid transaction")
+		}
+		b := int(data[i])
+		value |= (b & 0x7f) << (i * 7)
+		if b&0x80 == 0 {
+			return value, i + 1, nil
+		}
+	}
+	return 0, 0, errors.New("invalid transaction")
+}
+
+// signs a serialized (versioned) transaction. the fee payer is always the first signer.
+func signTransaction(keypair *Keypair, tx []byte) ([]byte, error) {
+	count, n, err := readCompactU16(tx)
+	if err != nil {
+		return nil, err
+	}
+	if count < 1 {
+		return nil, errors.New("transaction has no signers")
+	}
+
+	offset := n + (count * 64)
+	if offset >= len(tx) {
+		return nil, errors.New("invalid transaction")
+	}
+
+	out := make([]byte, len(tx))
+	copy(out, tx)
+	copy(out[n:n+64], keypair.Sign(tx[offset:]))
+
+	return out, nil
+}
@@ -28,5 +28,5 @@ func (as *ApiService) Accounts(currency, typo string) (*ApiResponse, error) {
 		p["type"] = typo
 	}
 	req := NewRequest(http.MethodGet, "/api/v1/accounts", p)
-	return as.call(req, requestsPerSecond)
+	return as.call(req, RequestsPerSecond)
 }
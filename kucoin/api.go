@@ -11,13 +11,14 @@ import (
 )
 
 const (
-	requestsPerSecond = 30 // 1800 reqs per minute
+	RequestsPerSecond = 30 // 1800 reqs per minute
 )
 
 var (
 	lastRequest   time.Time
 	BeforeRequest func(client *ApiService, request *Request, rps float64) error = nil
 	AfterRequest  func()                                                        = nil
+	OnRateLimit   func(path string)                                             = nil
 )
 
 func init() {
@@ -110,6 +111,11 @@ func NewApiService(opts ...ApiServiceOption) *ApiService {
 
 // Call calls the API by passing *Request and returns *ApiResponse.
 func (as *ApiService) call(request *Request, rps float64) (*ApiResponse, error) {
+	// sync with the server time before we sign this request
+	if as.signer != nil {
+		ServerTime.Sync(as.getServerTime)
+	}
+
 	// --- BEGIN --- svanas 2019-02-13 --- satisfy the rate limiter -------
 	if err := BeforeRequest(as, request, rps); err != nil {
 		return nil, err
@@ -144,6 +150,9 @@ func (as *ApiService) call(request *Request, rps float64) (*ApiResponse, error)
 		rsp, err = as.requester.Request(request, request.Timeout)
 		// --- BEGIN --- svanas 2021-07-31 --- rate limit is exceeded? cool down for 10 seconds ------------------
 		if rsp != nil && rsp.StatusCode == http.StatusTooManyRequests {
+			if OnRateLimit != nil {
+				OnRateLimit(request.Path)
+			}
 			time.Sleep(10 * time.Second)
 		} else
 		// ---- END ---- svanas 2021-07-31 -----------------------------------------------------------------------
@@ -0,0 +1,37 @@
+package kucoin
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// A TradeFeeModel represents your (account-specific) fee rates for a symbol.
+type TradeFeeModel struct {
+	Symbol       string `json:"symbol"`
+	TakerFeeRate string `json:"takerFeeRate"`
+	MakerFeeRate string `json:"makerFeeRate"`
+}
+
+// ParseTakerFeeRate returns the taker fee rate as float64
+func (fee *TradeFeeModel) ParseTakerFeeRate() float64 {
+	out, err := strconv.ParseFloat(fee.TakerFeeRate, 64)
+	if err == nil {
+		return out
+	}
+	return 0
+}
+
+// ParseMakerFeeRate returns the maker fee rate as float64
+func (fee *TradeFeeModel) ParseMakerFeeRate() float64 {
+	out, err := strconv.ParseFloat(fee.MakerFeeRate, 64)
+	if err == nil {
+		return out
+	}
+	return 0
+}
+
+// TradeFees returns your fee rates for a comma-separated list of symbols.
+func (as *ApiService) TradeFees(symbols string) (*ApiResponse, error) {
+	req := NewRequest(http.MethodGet, "/api/v1/trade-fees", map[string]string{"symbols": symbols})
+	return as.call(req, RequestsPerSecond)
+}
@@ -5,12 +5,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"log"
 	"net/http"
 	"net/url"
 	"strings"
 	"time"
+
+	"github.com/svanas/nefertiti/ratelimit"
 )
 
 // A Request represents a HTTP request.
@@ -124,7 +126,8 @@ type BasicRequester struct {
 // Request makes a http request.
 func (br *BasicRequester) Request(request *Request, timeout time.Duration) (*Response, error) {
 	cli := &http.Client{
-		Timeout: timeout,
+		Timeout:   timeout,
+		Transport: ratelimit.NewTransport("KuCoin", nil),
 	}
 
 	req, err := request.HttpRequest()
@@ -161,7 +164,7 @@ func (r *Response) ReadBody() ([]byte, error) {
 
 	r.body = make([]byte, 0)
 	defer r.Body.Close()
-	b, err := ioutil.ReadAll(r.Body)
+	b, err := io.ReadAll(r.Body)
 	if err != nil {
 		return nil, err
 	}
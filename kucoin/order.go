@@ -148,17 +148,17 @@ func (as *ApiService) StopOrders(params map[string]string, pagination *Paginatio
 // Order returns a single order by order id.
 func (as *ApiService) Order(orderId string) (*ApiResponse, error) {
 	req := NewRequest(http.MethodGet, "/api/v1/orders/"+orderId, nil)
-	return as.call(req, requestsPerSecond)
+	return as.call(req, RequestsPerSecond)
 }
 
 // StopOrder returns a single order by stop-order id.
 func (as *ApiService) StopOrder(orderId string) (*ApiResponse, error) {
 	req := NewRequest(http.MethodGet, "/api/v1/stop-order/"+orderId, nil)
-	return as.call(req, requestsPerSecond)
+	return as.call(req, RequestsPerSecond)
 }
 
 // RecentOrders returns the recent orders of the latest transactions within 24 hours.
 func (as *ApiService) RecentOrders() (*ApiResponse, error) {
 	req := NewRequest(http.MethodGet, "/api/v1/limit/orders", nil)
-	return as.call(req, requestsPerSecond)
+	return as.call(req, RequestsPerSecond)
 }
@@ -34,7 +34,7 @@ func (as *ApiService) Symbols(market string) (*ApiResponse, error) {
 		p["market"] = market
 	}
 	req := NewRequest(http.MethodGet, "/api/v1/symbols", p)
-	return as.call(req, requestsPerSecond)
+	return as.call(req, RequestsPerSecond)
 }
 
 // A TickerLevel1Model represents ticker include only the inside (i.e. best) bid and ask data, last price and last trade size.
@@ -52,7 +52,7 @@ type TickerLevel1Model struct {
 // TickerLevel1 returns the ticker include only the inside (i.e. best) bid and ask data, last price and last trade size.
 func (as *ApiService) TickerLevel1(symbol string) (*ApiResponse, error) {
 	req := NewRequest(http.MethodGet, "/api/v1/market/orderbook/level1", map[string]string{"symbol": symbol})
-	return as.call(req, requestsPerSecond)
+	return as.call(req, RequestsPerSecond)
 }
 
 // A TickerModel represents a market ticker for all trading pairs in the market (including 24h volume).
@@ -87,7 +87,7 @@ type TickersResponseModel struct {
 // Tickers returns all tickers as TickersResponseModel for all trading pairs in the market (including 24h volume).
 func (as *ApiService) Tickers() (*ApiResponse, error) {
 	req := NewRequest(http.MethodGet, "/api/v1/market/allTickers", nil)
-	return as.call(req, requestsPerSecond)
+	return as.call(req, RequestsPerSecond)
 }
 
 // A Stats24hrModel represents 24 hr stats for the symbol.
@@ -103,18 +103,37 @@ type Stats24hrModel struct {
 	Low         string `json:"low"`
 	Vol         string `json:"vol"`
 	VolValue    string `json:"volValue"`
+	Last        string `json:"last"`
 }
 
 // Stats24hr returns 24 hr stats for the symbol. volume is in base currency units. open, high, low are in quote currency units.
 func (as *ApiService) Stats24hr(symbol string) (*ApiResponse, error) {
 	req := NewRequest(http.MethodGet, "/api/v1/market/stats", map[string]string{"symbol": symbol})
-	return as.call(req, requestsPerSecond)
+	return as.call(req, RequestsPerSecond)
+}
+
+// A KLineModel represents the k lines for a symbol: time (in seconds), open, close, high, low, volume and turnover.
+type KLineModel []string
+
+// A KLinesModel is the set of *KLineModel, newest first.
+type KLinesModel []*KLineModel
+
+// KLines returns the k lines for a symbol. type is 1min, 3min, 5min, 15min, 30min, 1hour, 2hour, 4hour, 6hour, 8hour,
+// 12hour, 1day or 1week. startAt and endAt are in seconds. returns at most 1500 k lines.
+func (as *ApiService) KLines(symbol, typo string, startAt, endAt int64) (*ApiResponse, error) {
+	req := NewRequest(http.MethodGet, "/api/v1/market/candles", map[string]string{
+		"symbol":  symbol,
+		"type":    typo,
+		"startAt": strconv.FormatInt(startAt, 10),
+		"endAt":   strconv.FormatInt(endAt, 10),
+	})
+	return as.call(req, RequestsPerSecond)
 }
 
 // Markets returns the transaction currencies for the entire trading market.
 func (as *ApiService) Markets() (*ApiResponse, error) {
 	req := NewRequest(http.MethodGet, "/api/v1/markets", nil)
-	return as.call(req, requestsPerSecond)
+	return as.call(req, RequestsPerSecond)
 }
 
 // BookEntry = bid or ask info with price and size
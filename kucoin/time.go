@@ -2,10 +2,27 @@ package kucoin
 
 import (
 	"net/http"
+	"time"
+
+	"github.com/svanas/nefertiti/clock"
 )
 
+var ServerTime = clock.New("KuCoin")
+
 // ServerTime returns the API server time.
 func (as *ApiService) ServerTime() (*ApiResponse, error) {
 	req := NewRequest(http.MethodGet, "/api/v1/timestamp", nil)
-	return as.call(req, requestsPerSecond)
+	return as.call(req, RequestsPerSecond)
+}
+
+func (as *ApiService) getServerTime() (time.Time, error) {
+	resp, err := as.ServerTime()
+	if err != nil {
+		return time.Time{}, err
+	}
+	var msec int64
+	if err := resp.ReadData(&msec); err != nil {
+		return time.Time{}, err
+	}
+	return clock.FromMilli(msec), nil
 }
@@ -0,0 +1,23 @@
+package kucoin
+
+import (
+	"net/http"
+)
+
+// An ApiKeyModel represents the API key that signs our requests.
+type ApiKeyModel struct {
+	Remark      string `json:"remark"`
+	ApiKey      string `json:"apiKey"`
+	ApiVersion  int    `json:"apiVersion"`
+	Permission  string `json:"permission"` // comma-separated, for example: General,Spot,Futures,Withdrawal
+	IpWhitelist string `json:"ipWhitelist"`
+	CreatedAt   int64  `json:"createdAt"`
+	Uid         int64  `json:"uid"`
+	IsMaster    bool   `json:"isMaster"`
+}
+
+// ApiKey returns the info of the API key that signs this request.
+func (as *ApiService) ApiKey() (*ApiResponse, error) {
+	req := NewRequest(http.MethodGet, "/api/v1/user/api-key", nil)
+	return as.call(req, RequestsPerSecond)
+}
@@ -0,0 +1,47 @@
+package kucoin
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// A WithdrawalModel represents a withdrawal.
+type WithdrawalModel struct {
+	Id         string `json:"id"`
+	Address    string `json:"address"`
+	Memo       string `json:"memo"`
+	Currency   string `json:"currency"`
+	Amount     string `json:"amount"`
+	Fee        string `json:"fee"`
+	WalletTxId string `json:"walletTxId"`
+	IsInner    bool   `json:"isInner"`
+	Status     string `json:"status"`
+	Remark     string `json:"remark"`
+	CreatedAt  int64  `json:"createdAt"`
+	UpdatedAt  int64  `json:"updatedAt"`
+}
+
+// ParseAmount returns the amount as float64
+func (withdrawal *WithdrawalModel) ParseAmount() float64 {
+	out, err := strconv.ParseFloat(withdrawal.Amount, 64)
+	if err == nil {
+		return out
+	}
+	return 0
+}
+
+// ParseCreatedAt returns the creation time as time.Time
+func (withdrawal *WithdrawalModel) ParseCreatedAt() time.Time {
+	return time.Unix(withdrawal.CreatedAt/1000, 0)
+}
+
+// A WithdrawalsModel is the set of *WithdrawalModel.
+type WithdrawalsModel []*WithdrawalModel
+
+// Withdrawals returns a list of withdrawals.
+func (as *ApiService) Withdrawals(params map[string]string, pagination *PaginationParam) (*ApiResponse, error) {
+	pagination.ReadParam(params)
+	req := NewRequest(http.MethodGet, "/api/v1/withdrawals", params)
+	return as.call(req, RequestsPerSecond)
+}
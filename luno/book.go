@@ -0,0 +1,37 @@
+package luno
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+type BookEntry struct {
+	Price  float64 `json:"price,string"`
+	Volume float64 `json:"volume,string"`
+}
+
+type OrderBook struct {
+	Timestamp int64       `json:"timestamp"`
+	Bids      []BookEntry `json:"bids"`
+	Asks      []BookEntry `json:"asks"`
+}
+
+func (client *Client) OrderBook(pair string) (*OrderBook, error) {
+	params := url.Values{}
+	params.Add("pair", pair)
+
+	var (
+		err  error
+		body []byte
+		out  OrderBook
+	)
+	if body, err = client.call(http.MethodGet, "/api/1/orderbook_top", params, false); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(body, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
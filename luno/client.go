@@ -0,0 +1,123 @@
+package luno
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/ratelimit"
+)
+
+const (
+	API_BASE = "https://api.luno.com"
+)
+
+var (
+	lastRequest       time.Time
+	RequestsPerSecond float64                         = 5 // 300 calls per minute
+	BeforeRequest     func(method, path string) error = nil
+	AfterRequest      func()                          = nil
+)
+
+func init() {
+	BeforeRequest = func(method, path string) error {
+		elapsed := time.Since(lastRequest)
+		if elapsed.Seconds() < (float64(1) / RequestsPerSecond) {
+			time.Sleep(time.Duration((float64(time.Second) / RequestsPerSecond) - float64(elapsed)))
+		}
+		return nil
+	}
+	AfterRequest = func() {
+		lastRequest = time.Now()
+	}
+}
+
+type Client struct {
+	URL        string
+	apiKey     string
+	apiSecret  string
+	httpClient *http.Client
+}
+
+func New(URL, apiKey, apiSecret string) *Client {
+	return &Client{
+		URL,
+		apiKey,
+		apiSecret,
+		&http.Client{
+			Timeout:   30 * time.Second,
+			Transport: ratelimit.NewTransport("Luno", nil),
+		},
+	}
+}
+
+func (client *Client) do(req *http.Request) ([]byte, error) {
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+			return nil, errors.NewStatusError(resp.StatusCode, resp.Status)
+		}
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		if err, msg := IsError(body); err {
+			return body, errors.NewStatusError(resp.StatusCode, msg)
+		}
+		return body, errors.NewStatusError(resp.StatusCode, resp.Status)
+	}
+
+	return body, nil
+}
+
+func (client *Client) call(method, path string, params url.Values, auth bool) ([]byte, error) {
+	// respect the rate limit
+	err := BeforeRequest(method, path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		AfterRequest()
+	}()
+
+	// set the endpoint for this request
+	endpoint, err := url.Parse(client.URL)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path += path
+
+	// create the request
+	var body io.Reader
+	if params != nil {
+		if method == http.MethodPost {
+			body = strings.NewReader(params.Encode())
+		} else {
+			endpoint.RawQuery = params.Encode()
+		}
+	}
+	req, err := http.NewRequest(method, endpoint.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	// luno uses HTTP basic authentication
+	if auth {
+		req.SetBasicAuth(client.apiKey, client.apiSecret)
+	}
+
+	// do the request
+	return client.do(req)
+}
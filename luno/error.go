@@ -0,0 +1,22 @@
+package luno
+
+import (
+	"encoding/json"
+)
+
+type Error struct {
+	Code    string `json:"error_code"`
+	Message string `json:"error"`
+}
+
+func (err *Error) Failure() bool {
+	return err.Message != ""
+}
+
+func IsError(response []byte) (bool, string) {
+	var err Error
+	if json.Unmarshal(response, &err) == nil {
+		return err.Failure(), err.Message
+	}
+	return false, ""
+}
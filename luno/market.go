@@ -0,0 +1,63 @@
+package luno
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+type Market struct {
+	MarketID        string  `json:"market_id"`
+	TradingStatus   string  `json:"trading_status"`
+	BaseCurrency    string  `json:"base_currency"`
+	CounterCurrency string  `json:"counter_currency"`
+	MinVolume       float64 `json:"min_volume,string"`
+	MaxVolume       float64 `json:"max_volume,string"`
+	VolumeScale     int     `json:"volume_scale"`
+	MinPrice        float64 `json:"min_price,string"`
+	MaxPrice        float64 `json:"max_price,string"`
+	PriceScale      int     `json:"price_scale"` // number of decimals, often 0 for the fiat markets
+	FeeScale        int     `json:"fee_scale"`
+}
+
+func (market *Market) Active() bool {
+	return market.TradingStatus == "ACTIVE"
+}
+
+type Markets struct {
+	Markets []Market `json:"markets"`
+}
+
+func (client *Client) Markets() ([]Market, error) {
+	var (
+		err  error
+		body []byte
+		out  Markets
+	)
+	if body, err = client.call(http.MethodGet, "/api/exchange/1/markets", nil, false); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(body, &out); err != nil {
+		return nil, err
+	}
+	return out.Markets, nil
+}
+
+// luno calls bitcoin XBT
+func FormatCurrency(currency string) string {
+	if strings.EqualFold(currency, "BTC") {
+		return "XBT"
+	}
+	return strings.ToUpper(currency)
+}
+
+func ParseCurrency(currency string) string {
+	if strings.EqualFold(currency, "XBT") {
+		return "BTC"
+	}
+	return strings.ToUpper(currency)
+}
+
+func FormatMarket(base, quote string) string {
+	return FormatCurrency(base) + FormatCurrency(quote)
+}
@@ -0,0 +1,166 @@
+package luno
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type (
+	OrderType  string
+	OrderState string
+)
+
+const (
+	OrderTypeBid  OrderType = "BID"  // limit buy
+	OrderTypeAsk  OrderType = "ASK"  // limit sell
+	OrderTypeBuy  OrderType = "BUY"  // market buy
+	OrderTypeSell OrderType = "SELL" // market sell
+)
+
+const (
+	OrderStatePending  OrderState = "PENDING"
+	OrderStateComplete OrderState = "COMPLETE"
+)
+
+type Order struct {
+	OrderID             string     `json:"order_id"`
+	Pair                string     `json:"pair"`
+	Type                OrderType  `json:"type"`
+	State               OrderState `json:"state"`
+	LimitPrice          float64    `json:"limit_price,string"`
+	LimitVolume         float64    `json:"limit_volume,string"`
+	Base                float64    `json:"base,string"`
+	Counter             float64    `json:"counter,string"`
+	FeeBase             float64    `json:"fee_base,string"`
+	FeeCounter          float64    `json:"fee_counter,string"`
+	CreationTimestamp   int64      `json:"creation_timestamp"`
+	CompletedTimestamp  int64      `json:"completed_timestamp"`
+	ExpirationTimestamp int64      `json:"expiration_timestamp"`
+}
+
+func (order *Order) IsBuy() bool {
+	return order.Type == OrderTypeBid || order.Type == OrderTypeBuy
+}
+
+// a completed order has been filled if (part of) the volume got traded, otherwise it has been cancelled.
+func (order *Order) Filled() bool {
+	return order.State == OrderStateComplete && order.Base > 0
+}
+
+func (order *Order) CreatedAt() time.Time {
+	return time.Unix(0, order.CreationTimestamp*int64(time.Millisecond))
+}
+
+func (order *Order) CompletedAt() time.Time {
+	return time.Unix(0, order.CompletedTimestamp*int64(time.Millisecond))
+}
+
+func (order *Order) ExecutedAt() float64 {
+	if order.Base > 0 && order.Counter > 0 {
+		return order.Counter / order.Base
+	}
+	return order.LimitPrice
+}
+
+// luno deducts the fee from the currency we receive, so a buy order yields base minus fee_base.
+func (order *Order) BaseMinusFee() float64 {
+	if order.IsBuy() {
+		return order.Base - order.FeeBase
+	}
+	return order.Base
+}
+
+type newOrder struct {
+	OrderID string `json:"order_id"`
+}
+
+func (client *Client) LimitOrder(pair string, orderType OrderType, volume, price float64) (string, error) {
+	params := url.Values{}
+	params.Add("pair", pair)
+	params.Add("type", string(orderType))
+	params.Add("volume", strconv.FormatFloat(volume, 'f', -1, 64))
+	params.Add("price", strconv.FormatFloat(price, 'f', -1, 64))
+	params.Add("post_only", "false")
+
+	return client.postOrder("/api/1/postorder", params)
+}
+
+// market buy orders are placed for an amount in counter currency, market sell orders for an amount in base currency.
+func (client *Client) MarketOrder(pair string, orderType OrderType, volume float64) (string, error) {
+	params := url.Values{}
+	params.Add("pair", pair)
+	params.Add("type", string(orderType))
+	if orderType == OrderTypeBuy {
+		params.Add("counter_volume", strconv.FormatFloat(volume, 'f', -1, 64))
+	} else {
+		params.Add("base_volume", strconv.FormatFloat(volume, 'f', -1, 64))
+	}
+
+	return client.postOrder("/api/1/marketorder", params)
+}
+
+func (client *Client) postOrder(path string, params url.Values) (string, error) {
+	var (
+		err  error
+		body []byte
+		out  newOrder
+	)
+	if body, err = client.call(http.MethodPost, path, params, true); err != nil {
+		return "", err
+	}
+	if err = json.Unmarshal(body, &out); err != nil {
+		return "", err
+	}
+	return out.OrderID, nil
+}
+
+func (client *Client) StopOrder(orderID string) error {
+	params := url.Values{}
+	params.Add("order_id", orderID)
+
+	_, err := client.call(http.MethodPost, "/api/1/stoporder", params, true)
+
+	return err
+}
+
+func (client *Client) GetOrder(orderID string) (*Order, error) {
+	var (
+		err  error
+		body []byte
+		out  Order
+	)
+	if body, err = client.call(http.MethodGet, "/api/1/orders/"+orderID, nil, true); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+type Orders struct {
+	Orders []Order `json:"orders"`
+}
+
+func (client *Client) Orders(pair string, state OrderState) ([]Order, error) {
+	params := url.Values{}
+	params.Add("pair", pair)
+	params.Add("state", string(state))
+
+	var (
+		err  error
+		body []byte
+		out  Orders
+	)
+	if body, err = client.call(http.MethodGet, "/api/1/listorders", params, true); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(body, &out); err != nil {
+		return nil, err
+	}
+
+	return out.Orders, nil
+}
@@ -0,0 +1,53 @@
+package luno
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+type Ticker struct {
+	Pair                string  `json:"pair"`
+	Timestamp           int64   `json:"timestamp"`
+	Bid                 float64 `json:"bid,string"`
+	Ask                 float64 `json:"ask,string"`
+	LastTrade           float64 `json:"last_trade,string"`
+	Rolling24HourVolume float64 `json:"rolling_24_hour_volume,string"`
+	Status              string  `json:"status"`
+}
+
+func (client *Client) Ticker(pair string) (*Ticker, error) {
+	params := url.Values{}
+	params.Add("pair", pair)
+
+	var (
+		err  error
+		body []byte
+		out  Ticker
+	)
+	if body, err = client.call(http.MethodGet, "/api/1/ticker", params, false); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(body, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+func (client *Client) Tickers() ([]Ticker, error) {
+	var (
+		err  error
+		body []byte
+		out  struct {
+			Tickers []Ticker `json:"tickers"`
+		}
+	)
+	if body, err = client.call(http.MethodGet, "/api/1/tickers", nil, false); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(body, &out); err != nil {
+		return nil, err
+	}
+	return out.Tickers, nil
+}
@@ -6,13 +6,20 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/gorilla/mux"
 	"github.com/mitchellh/cli"
 	"github.com/svanas/nefertiti/command"
+	"github.com/svanas/nefertiti/control"
 	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/exchanges"
 	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/ratelimit"
+	"github.com/svanas/nefertiti/session"
+	"github.com/svanas/nefertiti/vault"
 )
 
 var (
@@ -47,12 +54,54 @@ func main() {
 		CallBack:   &cb,
 	}
 
+	// the validate command never prompts for anything, not even for the password of your vault
+	if len(os.Args) > 1 && os.Args[1] == command.VALIDATE_COMMAND {
+		flag.SetInteractive(false)
+	}
+
+	// --config=FILE reads the flags that aren't on the command line from a file
+	if arg := flag.Get("config"); arg.Exists && arg.String() != "" {
+		if err := flag.Load(arg.String()); err != nil {
+			log.Printf("[ERROR] %v", err)
+			os.Exit(errors.EXIT_VALIDATION)
+		}
+	}
+
+	// --user=NAME (from the command line or the config file) must be valid before we touch the session or the vault
+	if _, err := flag.User(); err != nil {
+		log.Printf("[ERROR] %v", err)
+		os.Exit(errors.EXIT_VALIDATION)
+	}
+	if _, err := control.Tokens(); err != nil {
+		log.Printf("[ERROR] %v", err)
+		os.Exit(errors.EXIT_VALIDATION)
+	}
+
+	// --vault turns the secrets of your exchange (and of your notifications) into flags
+	if flag.Exists("vault") {
+		var code string
+		if exchange, err := exchanges.GetExchange(); err == nil {
+			code = exchange.GetInfo().Code
+		}
+		if err := vault.Unlock(code); err != nil {
+			log.Printf("[ERROR] %v", err)
+			os.Exit(errors.EXIT_AUTH)
+		}
+	}
+
+	// every log line starts with the run ID (and label), so that you can tell apart the loops that run simultaneously
+	log.SetPrefix("[" + session.RunTag() + "] ")
+	log.SetFlags(log.Flags() | log.Lmsgprefix)
+
 	console = cli.NewCLI(APP_NAME, APP_VERSION)
 	console.Args = os.Args[1:]
 	console.Commands = map[string]cli.CommandFactory{
 		"exchanges": func() (cli.Command, error) {
 			return &command.ExchangesCommand{CommandMeta: &cm}, nil
 		},
+		"exchanges list": func() (cli.Command, error) {
+			return &command.ExchangesListCommand{CommandMeta: &cm}, nil
+		},
 		"markets": func() (cli.Command, error) {
 			return &command.MarketsCommand{CommandMeta: &cm}, nil
 		},
@@ -74,6 +123,24 @@ func main() {
 		"update": func() (cli.Command, error) {
 			return &command.UpdateCommand{CommandMeta: &cm}, nil
 		},
+		"audit": func() (cli.Command, error) {
+			return &command.AuditCommand{CommandMeta: &cm}, nil
+		},
+		"download": func() (cli.Command, error) {
+			return &command.DownloadCommand{CommandMeta: &cm}, nil
+		},
+		"optimize": func() (cli.Command, error) {
+			return &command.OptimizeCommand{CommandMeta: &cm}, nil
+		},
+		"risk": func() (cli.Command, error) {
+			return &command.RiskCommand{CommandMeta: &cm}, nil
+		},
+		"benchmark": func() (cli.Command, error) {
+			return &command.BenchmarkCommand{CommandMeta: &cm}, nil
+		},
+		"pause": func() (cli.Command, error) {
+			return &command.PauseCommand{CommandMeta: &cm}, nil
+		},
 		"agg": func() (cli.Command, error) {
 			return &command.AggCommand{CommandMeta: &cm}, nil
 		},
@@ -89,16 +156,74 @@ func main() {
 		"notify": func() (cli.Command, error) {
 			return &command.NotifyCommand{CommandMeta: &cm}, nil
 		},
+		"notify test": func() (cli.Command, error) {
+			return &command.NotifyTestCommand{CommandMeta: &cm}, nil
+		},
 		"stoploss": func() (cli.Command, error) {
 			return &command.StopLossCommand{CommandMeta: &cm}, nil
 		},
+		"trailing": func() (cli.Command, error) {
+			return &command.TrailingCommand{CommandMeta: &cm}, nil
+		},
 		"listen": func() (cli.Command, error) {
 			return &command.ListenCommand{CommandMeta: &cm}, nil
 		},
 		"exit": func() (cli.Command, error) {
 			return &command.ExitCommand{CommandMeta: &cm}, nil
 		},
+		"resume": func() (cli.Command, error) {
+			return &command.ResumeCommand{CommandMeta: &cm}, nil
+		},
+		"approve": func() (cli.Command, error) {
+			return &command.ApproveCommand{CommandMeta: &cm}, nil
+		},
+		"orders": func() (cli.Command, error) {
+			return &command.OrdersCommand{CommandMeta: &cm}, nil
+		},
+		"movers": func() (cli.Command, error) {
+			return &command.MoversCommand{CommandMeta: &cm}, nil
+		},
+		"whales": func() (cli.Command, error) {
+			return &command.WhalesCommand{CommandMeta: &cm}, nil
+		},
+		"import": func() (cli.Command, error) {
+			return &command.ImportCommand{CommandMeta: &cm}, nil
+		},
+		"limits": func() (cli.Command, error) {
+			return &command.LimitsCommand{CommandMeta: &cm}, nil
+		},
+		command.VALIDATE_COMMAND: func() (cli.Command, error) {
+			return &command.ValidateCommand{CommandMeta: &cm}, nil
+		},
+		"profile": func() (cli.Command, error) {
+			return &command.ProfileCommand{CommandMeta: &cm}, nil
+		},
+		"profile export": func() (cli.Command, error) {
+			return &command.ProfileExportCommand{CommandMeta: &cm}, nil
+		},
+		"profile import": func() (cli.Command, error) {
+			return &command.ProfileImportCommand{CommandMeta: &cm}, nil
+		},
+		"keys": func() (cli.Command, error) {
+			return &command.KeysCommand{CommandMeta: &cm}, nil
+		},
+		"keys check": func() (cli.Command, error) {
+			return &command.KeysCheckCommand{CommandMeta: &cm}, nil
+		},
+		"init": func() (cli.Command, error) {
+			return &command.InitCommand{CommandMeta: &cm}, nil
+		},
+		"completion": func() (cli.Command, error) {
+			return &command.CompletionCommand{CommandMeta: &cm}, nil
+		},
+		command.SCAFFOLD_COMMAND: func() (cli.Command, error) {
+			return &command.ScaffoldCommand{CommandMeta: &cm}, nil
+		},
+		command.COMPLETE_COMMAND: func() (cli.Command, error) {
+			return &command.CompleteCommand{CommandMeta: &cm, Commands: console.Commands}, nil
+		},
 	}
+	console.HiddenCommands = []string{command.COMPLETE_COMMAND, command.SCAFFOLD_COMMAND}
 
 	if flag.Listen() {
 		go func() {
@@ -108,9 +233,16 @@ func main() {
 			)
 
 			router = mux.NewRouter()
-			router.HandleFunc("/ping", ping).Host("127.0.0.1").Methods(http.MethodGet)
-			router.HandleFunc("/post", post).Host("127.0.0.1").Methods(http.MethodPost)
-			router.HandleFunc("/", delete).Host("127.0.0.1").Methods(http.MethodDelete)
+			router.HandleFunc("/ping", control.Require(control.ROLE_VIEWER, ping)).Host("127.0.0.1").Methods(http.MethodGet)
+			router.HandleFunc("/post", control.Require(control.ROLE_OPERATOR, post)).Host("127.0.0.1").Methods(http.MethodPost)
+			router.HandleFunc("/", control.Require(control.ROLE_OPERATOR, delete)).Host("127.0.0.1").Methods(http.MethodDelete)
+			router.HandleFunc("/approve", control.Require(control.ROLE_OPERATOR, approve)).Host("127.0.0.1").Methods(http.MethodPost)
+			router.HandleFunc("/reject", control.Require(control.ROLE_OPERATOR, reject)).Host("127.0.0.1").Methods(http.MethodPost)
+			router.HandleFunc("/pause", control.Require(control.ROLE_OPERATOR, pause)).Host("127.0.0.1").Methods(http.MethodPost)
+			router.HandleFunc("/resume", control.Require(control.ROLE_OPERATOR, resume)).Host("127.0.0.1").Methods(http.MethodPost)
+			router.HandleFunc("/fill", control.Require(control.ROLE_OPERATOR, fill)).Host("127.0.0.1").Methods(http.MethodPost)
+			router.HandleFunc("/metrics", control.Require(control.ROLE_VIEWER, metrics)).Host("127.0.0.1").Methods(http.MethodGet)
+			router.HandleFunc("/healthz", control.Require(control.ROLE_VIEWER, healthz)).Methods(http.MethodGet)
 
 			flg := flag.Get("port")
 			if flg.Exists {
@@ -142,6 +274,8 @@ func main() {
 
 	code, _ := console.Run()
 
+	session.Flush()
+
 	if err != nil {
 		prefix := errors.FormatCaller(cnt, file, line)
 		_, ok := err.(*errors.Error)
@@ -150,11 +284,28 @@ func main() {
 		} else {
 			log.Printf("[ERROR] %s", fmt.Sprintf("%s %v", prefix, err))
 		}
-		if code != 0 {
-			os.Exit(code)
-		} else {
-			os.Exit(1)
+		if code == 0 {
+			code = errors.ExitCode(err)
+			if code == errors.EXIT_OK {
+				code = errors.EXIT_ERROR
+			}
 		}
+		if flag.JsonErrors() {
+			json.NewEncoder(os.Stderr).Encode(struct {
+				Command string `json:"command"`
+				Code    int    `json:"code"`
+				Kind    string `json:"kind"`
+				Error   string `json:"error"`
+				Run     string `json:"run"`
+			}{
+				Command: console.Subcommand(),
+				Code:    code,
+				Kind:    errors.Kind(code),
+				Error:   err.Error(),
+				Run:     session.RunTag(),
+			})
+		}
+		os.Exit(code)
 	}
 
 	os.Exit(code)
@@ -166,7 +317,10 @@ func getPong() *command.Pong {
 	out := command.Pong{
 		Port:    port,
 		Command: console.Subcommand(),
+		Run:     session.RunId(),
+		Label:   session.RunLabel(),
 	}
+	out.Paused, _ = session.Paused()
 	for _, arg := range os.Args {
 		if strings.HasPrefix(arg, "-") {
 			for {
@@ -176,7 +330,8 @@ func getPong() *command.Pong {
 				}
 			}
 			if strings.HasPrefix(arg, "hub=") || strings.HasPrefix(arg, "port=") ||
-				strings.HasPrefix(arg, "api-key") || strings.HasPrefix(arg, "api-secret") || strings.HasPrefix(arg, "api-passphrase") {
+				strings.HasPrefix(arg, "api-key") || strings.HasPrefix(arg, "api-secret") || strings.HasPrefix(arg, "api-passphrase") ||
+				strings.HasPrefix(arg, "control-token") {
 				// nothing
 			} else {
 				out.Args = append(out.Args, arg)
@@ -196,11 +351,13 @@ func post(resp http.ResponseWriter, req *http.Request) {
 	req.ParseForm()
 	for key, value := range req.Form {
 		if key != "" && key != "port" && key != "command" {
+			from := flag.Get(key).String()
 			if value == nil {
 				flag.Set(key, "")
 			} else {
 				flag.Set(key, value[0])
 			}
+			session.Audit("api", key, from, flag.Get(key).String())
 		}
 	}
 	json.NewEncoder(resp).Encode(getPong())
@@ -210,5 +367,133 @@ func post(resp http.ResponseWriter, req *http.Request) {
 
 func delete(resp http.ResponseWriter, req *http.Request) {
 	resp.Write([]byte(""))
+	session.Flush()
 	defer os.Exit(0)
 }
+
+// GET 127.0.0.1:[port]/metrics
+
+func metrics(resp http.ResponseWriter, req *http.Request) {
+	paused, _ := session.Paused()
+	json.NewEncoder(resp).Encode(struct {
+		Run        string             `json:"run"`
+		Label      string             `json:"label,omitempty"`
+		Paused     *session.Pause     `json:"paused,omitempty"`
+		RateLimits []ratelimit.Header `json:"rate_limits"`
+	}{
+		Run:        session.RunId(),
+		Label:      session.RunLabel(),
+		Paused:     paused,
+		RateLimits: ratelimit.Get(),
+	})
+}
+
+// GET [host]:[port]/healthz
+//
+// responds with 503 Service Unavailable if one of our exchanges isn't alive (or its circuit breaker is open), so that
+// a liveness probe or uptime monitor can restart us. ?format=prometheus returns the same in the Prometheus text format.
+
+func healthz(resp http.ResponseWriter, req *http.Request) {
+	health := exchanges.GetHealth()
+
+	status := http.StatusOK
+	for _, exchange := range health {
+		if !exchange.Alive || exchange.BreakerOpen {
+			status = http.StatusServiceUnavailable
+		}
+	}
+
+	if req.FormValue("format") == "prometheus" {
+		resp.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		resp.WriteHeader(status)
+		for _, exchange := range health {
+			fmt.Fprintf(resp, "nefertiti_alive{exchange=%q,run=%q} %d\n", exchange.Exchange, session.RunTag(), boolToInt(exchange.Alive))
+			fmt.Fprintf(resp, "nefertiti_seconds_since_success{exchange=%q,run=%q} %d\n", exchange.Exchange, session.RunTag(), exchange.Seconds)
+			fmt.Fprintf(resp, "nefertiti_consecutive_failures{exchange=%q,run=%q} %d\n", exchange.Exchange, session.RunTag(), exchange.Failures)
+			fmt.Fprintf(resp, "nefertiti_breaker_open{exchange=%q,run=%q} %d\n", exchange.Exchange, session.RunTag(), boolToInt(exchange.BreakerOpen))
+		}
+		return
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(status)
+	json.NewEncoder(resp).Encode(health)
+}
+
+func boolToInt(value bool) int {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+// POST 127.0.0.1:[port]/approve?id=X
+
+func approve(resp http.ResponseWriter, req *http.Request) {
+	decide(resp, req, true)
+}
+
+// POST 127.0.0.1:[port]/reject?id=X
+
+func reject(resp http.ResponseWriter, req *http.Request) {
+	decide(resp, req, false)
+}
+
+// POST 127.0.0.1:[port]/pause?reason=X and POST 127.0.0.1:[port]/resume
+//
+// flips the pause switch of every loop that shares our session dir
+
+func pause(resp http.ResponseWriter, req *http.Request) {
+	setPaused(resp, req, true)
+}
+
+func resume(resp http.ResponseWriter, req *http.Request) {
+	setPaused(resp, req, false)
+}
+
+func setPaused(resp http.ResponseWriter, req *http.Request, paused bool) {
+	if err := session.SetPaused(paused, req.FormValue("reason"), "api"); err != nil {
+		http.Error(resp, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(resp).Encode(getPong())
+}
+
+// POST 127.0.0.1:[port]/fill?market=X&side=[buy|sell]&size=X&price=X&id=X
+//
+// tells the bot about a fill that happened outside of the bot, so that we start managing the position. the ID is
+// optional. if you include it, then we will handle the same fill only once.
+
+func fill(resp http.ResponseWriter, req *http.Request) {
+	size, err := strconv.ParseFloat(req.FormValue("size"), 64)
+	if err != nil {
+		http.Error(resp, fmt.Sprintf("size %s is invalid", req.FormValue("size")), http.StatusBadRequest)
+		return
+	}
+	price, err := strconv.ParseFloat(req.FormValue("price"), 64)
+	if err != nil {
+		http.Error(resp, fmt.Sprintf("price %s is invalid", req.FormValue("price")), http.StatusBadRequest)
+		return
+	}
+	exit, err := exchanges.Manage(exchanges.ExternalFill{
+		Id:     req.FormValue("id"),
+		Market: req.FormValue("market"),
+		Side:   model.NewOrderSide(strings.ToLower(req.FormValue("side"))),
+		Size:   size,
+		Price:  price,
+	})
+	if err != nil {
+		http.Error(resp, err.Error(), http.StatusBadRequest)
+		return
+	}
+	json.NewEncoder(resp).Encode(exit)
+}
+
+func decide(resp http.ResponseWriter, req *http.Request, approved bool) {
+	approval, err := exchanges.Approve(req.FormValue("id"), approved)
+	if err != nil {
+		http.Error(resp, err.Error(), http.StatusBadRequest)
+		return
+	}
+	json.NewEncoder(resp).Encode(approval)
+}
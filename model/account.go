@@ -0,0 +1,32 @@
+package model
+
+import "time"
+
+// AccountEvent is something that happened on your account, for example: a withdrawal
+type AccountEvent struct {
+	Id     string    `json:"id"`
+	Time   time.Time `json:"time"`
+	Kind   string    `json:"kind"` // for example: withdrawal
+	Asset  string    `json:"asset,omitempty"`
+	Amount float64   `json:"amount,omitempty"`
+	Detail string    `json:"detail,omitempty"` // for example: the address the funds went to
+}
+
+// AccountMonitor is implemented by the exchanges that can tell you what happened on your account since a moment in
+// time. the bot never withdraws, so every event it returns is something that somebody else did with your keys.
+type AccountMonitor interface {
+	GetAccountEvents(client interface{}, since time.Time) ([]AccountEvent, error)
+}
+
+// KeyPermissions is what your API key is allowed to do
+type KeyPermissions struct {
+	Read     bool `json:"read"`
+	Trade    bool `json:"trade"`
+	Withdraw bool `json:"withdraw"`
+	Futures  bool `json:"futures"`
+}
+
+// KeyReader is implemented by the exchanges that can tell you the permissions of your API key
+type KeyReader interface {
+	GetKeyPermissions(client interface{}) (*KeyPermissions, error)
+}
@@ -0,0 +1,106 @@
+package model
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/svanas/nefertiti/session"
+)
+
+// not every exchange calls an asset by the same name. assetAliases maps the exchange-specific names to the canonical
+// name, per exchange code. "*" applies to every exchange. you can add to (or override) this table with an assets.json
+// file in your session dir, in the same format, for example: {"kucn": {"XBT": "BTC"}}
+var assetAliases = map[string]map[string]string{
+	"bmex": {
+		"XBT": BTC,
+	},
+	"bina": {
+		"BCC":    "BCH",
+		"BCHABC": "BCH",
+		"BCHSV":  "BSV",
+	},
+	"hitb": {
+		"BCHABC": "BCH",
+		"BCHSV":  "BSV",
+	},
+}
+
+var assetAliasesOnce sync.Once
+
+func loadAssetAliases() {
+	data, err := session.ReadFile(session.GetSessionFile("assets.json"))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("[ERROR] %v\n", err)
+		}
+		return
+	}
+	var overrides map[string]map[string]string
+	if err = json.Unmarshal(data, &overrides); err != nil {
+		log.Printf("[ERROR] assets.json: %v\n", err)
+		return
+	}
+	for code, aliases := range overrides {
+		code = strings.ToLower(code)
+		if _, ok := assetAliases[code]; !ok {
+			assetAliases[code] = make(map[string]string)
+		}
+		for alias, asset := range aliases {
+			assetAliases[code][strings.ToUpper(alias)] = strings.ToUpper(asset)
+		}
+	}
+}
+
+// CanonicalAsset returns the canonical name of an asset on an exchange, for example: XBT becomes BTC
+func CanonicalAsset(code, asset string) string {
+	assetAliasesOnce.Do(loadAssetAliases)
+	asset = strings.ToUpper(asset)
+	if aliases, ok := assetAliases[strings.ToLower(code)]; ok {
+		if out, ok := aliases[asset]; ok {
+			return out
+		}
+	}
+	if out, ok := assetAliases["*"][asset]; ok {
+		return out
+	}
+	return asset
+}
+
+// Canonical returns the currency pair in canonical asset names, so that you can compare pairs across exchanges
+func (symbol MarketSymbol) Canonical(code string) MarketSymbol {
+	return MarketSymbol{
+		Base:  CanonicalAsset(code, symbol.Base),
+		Quote: CanonicalAsset(code, symbol.Quote),
+	}
+}
+
+// FindCanonicalMarket returns the market on an exchange that trades this (canonical) currency pair, or nil
+func FindCanonicalMarket(exchange Exchange, markets []Market, symbol MarketSymbol) *Market {
+	code := exchange.GetInfo().Code
+	for i := range markets {
+		if markets[i].Symbol().Canonical(code).Equals(symbol) {
+			return &markets[i]
+		}
+	}
+	return nil
+}
+
+// ResolveMarket returns the name of a market on an exchange. you can spell it the way the exchange does (for example:
+// XBTUSD on BitMEX) or as a canonical currency pair (for example: BTC/USD or BTC-USD).
+func ResolveMarket(exchange Exchange, markets []Market, name string) string {
+	if HasMarket(markets, name) {
+		return name
+	}
+	code := exchange.GetInfo().Code
+	for _, separator := range []string{"/", "-"} {
+		if symbol, err := ParseSymbol(name, SymbolFormat{Separator: separator}); err == nil {
+			if market := FindCanonicalMarket(exchange, markets, symbol.Canonical(code)); market != nil {
+				return market.Name
+			}
+		}
+	}
+	return name
+}
@@ -0,0 +1,39 @@
+package model
+
+import (
+	"strings"
+)
+
+// BalanceReader is implemented by the exchanges that can tell you what is in your wallet. GetBalances returns the
+// available (eg. not on hold) amount per asset.
+type BalanceReader interface {
+	GetBalances(client interface{}) (map[string]float64, error)
+}
+
+// Holding is what you own of an asset
+type Holding struct {
+	Available float64 // not on hold
+	Hold      float64 // on hold in your open orders
+}
+
+func (holding Holding) Total() float64 {
+	return holding.Available + holding.Hold
+}
+
+// HoldingReader is implemented by the exchanges that can tell you what is on hold in your open orders, next to what
+// is available. GetHoldings returns your holding per asset.
+type HoldingReader interface {
+	GetHoldings(client interface{}) (map[string]Holding, error)
+}
+
+// GetHolding returns what you own of an asset, regardless of how the exchange spells it
+func GetHolding(holdings map[string]Holding, asset string) Holding {
+	var out Holding
+	for key, holding := range holdings {
+		if strings.EqualFold(key, asset) {
+			out.Available += holding.Available
+			out.Hold += holding.Hold
+		}
+	}
+	return out
+}
@@ -2,12 +2,12 @@ package model
 
 import (
 	"encoding/json"
-	"io/ioutil"
-	"os"
+	"math"
 	"strconv"
 
 	"github.com/svanas/nefertiti/errors"
 	"github.com/svanas/nefertiti/precision"
+	"github.com/svanas/nefertiti/session"
 )
 
 type (
@@ -36,12 +36,7 @@ func Call2File(call *Call, name string) error {
 	if raw, err = json.Marshal(call); err != nil {
 		return errors.Wrap(err, 1)
 	}
-	if _, err = os.Stat(name); err == nil {
-		if err = os.Truncate(name, 0); err != nil {
-			return errors.Wrap(err, 1)
-		}
-	}
-	if err = ioutil.WriteFile(name, raw, 0600); err != nil {
+	if err = session.WriteFile(name, raw); err != nil {
 		return errors.Wrap(err, 1)
 	}
 	return nil
@@ -53,7 +48,7 @@ func File2Call(name string) (*Call, error) {
 		raw []byte
 		out Call
 	)
-	if raw, err = ioutil.ReadFile(name); err != nil {
+	if raw, err = session.ReadFile(name); err != nil {
 		return nil, errors.Wrap(err, 1)
 	}
 	if err = json.Unmarshal(raw, &out); err != nil {
@@ -139,6 +134,17 @@ func (c Calls) IndexByMarket(market string) int {
 	return -1
 }
 
+// Markets returns the (distinct) markets of the calls
+func (c Calls) Markets() []string {
+	var out []string
+	for i, e := range c {
+		if c.IndexByMarket(e.Market) == i {
+			out = append(out, e.Market)
+		}
+	}
+	return out
+}
+
 func (c Calls) IndexByPrice(price float64) int {
 	for i, e := range c {
 		if e.Price == price {
@@ -148,6 +154,16 @@ func (c Calls) IndexByPrice(price float64) int {
 	return -1
 }
 
+// IndexByPriceSize returns the call that an open order already is, so that the exchange can leave that order alone
+func (c Calls) IndexByPriceSize(price, size float64) int {
+	for i, e := range c {
+		if e.Price == price && e.Size == size {
+			return i
+		}
+	}
+	return -1
+}
+
 func (c Calls) IndexByMarketPrice(market string, price float64) int {
 	for i, e := range c {
 		if e.Market == market && e.Price == price {
@@ -157,6 +173,31 @@ func (c Calls) IndexByMarketPrice(market string, price float64) int {
 	return -1
 }
 
+// Snap moves the calls onto the open buy orders that are no more than X ticks away, so that the exchange leaves
+// those orders alone (instead of cancelling and replacing them every time the support levels wiggle). most exchanges
+// leave an order alone only if both its price and its size match, so the call takes the size of the order, too.
+func (c Calls) Snap(opened Orders, ticks int64, tick float64) {
+	if ticks <= 0 {
+		return
+	}
+	used := make(map[int]bool)
+	for i := range c {
+		for n, order := range opened {
+			if used[n] || order.Side != BUY || order.Market != c[i].Market {
+				continue
+			}
+			if math.Abs(order.Price-c[i].Price) <= (float64(ticks) * tick) {
+				c[i].Price = order.Price
+				if order.Size > 0 {
+					c[i].Size = order.Size
+				}
+				used[n] = true
+				break
+			}
+		}
+	}
+}
+
 type (
 	Book []Buy
 )
@@ -0,0 +1,31 @@
+package model
+
+import (
+	"testing"
+)
+
+func TestCallsSnap(t *testing.T) {
+	opened := Orders{
+		{Side: BUY, Market: "BTC-USDT", Price: 100.02, Size: 0.5},
+		{Side: SELL, Market: "BTC-USDT", Price: 99.99, Size: 0.5},
+		{Side: BUY, Market: "ETH-USDT", Price: 100.01, Size: 2},
+	}
+	calls := Calls{
+		{Buy: &Buy{Market: "BTC-USDT", Price: 100, Size: 0.49}}, // 2 ticks away
+		{Buy: &Buy{Market: "BTC-USDT", Price: 90, Size: 0.55}},  // too far away
+	}
+
+	calls.Snap(opened, 2, 0.01)
+
+	if calls[0].Price != 100.02 || calls[0].Size != 0.5 {
+		t.Errorf("Snap failed, got: %v @ %v, want: 0.5 @ 100.02", calls[0].Size, calls[0].Price)
+	}
+	if calls[1].Price != 90 || calls[1].Size != 0.55 {
+		t.Errorf("Snap failed, got: %v @ %v, want: 0.55 @ 90", calls[1].Size, calls[1].Price)
+	}
+
+	// the exchange leaves the open order alone, because the call is that order now
+	if index := calls.IndexByPriceSize(opened[0].Price, opened[0].Size); index != 0 {
+		t.Errorf("IndexByPriceSize failed, got: %d, want: 0", index)
+	}
+}
@@ -0,0 +1,87 @@
+package model
+
+import (
+	"time"
+)
+
+// CancelFilter narrows down the open orders that CancelOrders will cancel. The zero value matches every order.
+type CancelFilter struct {
+	Side       OrderSide     // ORDER_SIDE_NONE matches both sides
+	OlderThan  time.Duration // zero matches every order, regardless of its age
+	PriceAbove float64       // zero means no lower bound
+	PriceBelow float64       // zero means no upper bound
+}
+
+func (filter *CancelFilter) Matches(order *Order) bool {
+	if filter.Side != ORDER_SIDE_NONE && order.Side != filter.Side {
+		return false
+	}
+	if filter.OlderThan > 0 {
+		// some exchanges do not tell us when the order was opened. in that case, we leave the order alone.
+		if order.CreatedAt.IsZero() || time.Since(order.CreatedAt) < filter.OlderThan {
+			return false
+		}
+	}
+	price := order.Price
+	if price == 0 {
+		price = order.Stop // stop-market orders do not have a price, but they do have a trigger
+	}
+	if filter.PriceAbove > 0 && price <= filter.PriceAbove {
+		return false
+	}
+	if filter.PriceBelow > 0 && price >= filter.PriceBelow {
+		return false
+	}
+	return true
+}
+
+// CancelOrders cancels the open orders on a market that match the filter. Returns the (to be) cancelled orders.
+func CancelOrders(exchange Exchange, client interface{}, market string, filter *CancelFilter, dryRun bool) (Orders, error) {
+	opened, err := GetOpenedEx(exchange, client, market)
+	if err != nil {
+		return nil, err
+	}
+
+	var out Orders
+	for _, order := range opened {
+		if !filter.Matches(&order) {
+			continue
+		}
+		if !dryRun {
+			if order.Conditional {
+				err = exchange.(Conditionals).CancelConditional(client, market, order.ID)
+			} else {
+				err = exchange.CancelOrder(client, market, order.ID)
+			}
+			if err != nil {
+				return out, err
+			}
+		}
+		out = append(out, order)
+	}
+
+	return out, nil
+}
+
+// Conditionals is implemented by the exchanges that keep their stops away from the order book, where GetOpened cannot
+// see them.
+type Conditionals interface {
+	GetOpenConditionals(client interface{}, market string) (Orders, error)
+	CancelConditional(client interface{}, market, id string) error
+}
+
+// GetOpenedEx returns the open orders on a market, including the conditional orders that have not been triggered yet.
+func GetOpenedEx(exchange Exchange, client interface{}, market string) (Orders, error) {
+	out, err := exchange.GetOpened(client, market)
+	if err != nil {
+		return nil, err
+	}
+	if conditionals, ok := exchange.(Conditionals); ok {
+		var stops Orders
+		if stops, err = conditionals.GetOpenConditionals(client, market); err != nil {
+			return nil, err
+		}
+		out = append(out, stops...)
+	}
+	return out, nil
+}
@@ -0,0 +1,63 @@
+package model
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/svanas/nefertiti/errors"
+)
+
+type (
+	Candle struct {
+		Time   time.Time `json:"time"` // the open time
+		Open   float64   `json:"open"`
+		High   float64   `json:"high"`
+		Low    float64   `json:"low"`
+		Close  float64   `json:"close"`
+		Volume float64   `json:"volume"` // in base currency
+	}
+	Candles []Candle
+)
+
+// CandleReader is implemented by the exchanges that can give you historic OHLCV. GetCandles returns the candles
+// that opened in [start, end), oldest first. it may return fewer candles than you asked for (for example, because
+// the exchange pages its results), but never candles outside of the range.
+type CandleReader interface {
+	GetCandles(client interface{}, market string, interval time.Duration, start, end time.Time) (Candles, error)
+}
+
+// ParseInterval parses a candle interval like 1m, 15m, 1h, 4h or 1d
+func ParseInterval(value string) (time.Duration, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if len(value) > 1 {
+		n, err := strconv.Atoi(value[:len(value)-1])
+		if err == nil && n > 0 {
+			switch value[len(value)-1] {
+			case 'm':
+				return time.Duration(n) * time.Minute, nil
+			case 'h':
+				return time.Duration(n) * time.Hour, nil
+			case 'd':
+				return time.Duration(n) * 24 * time.Hour, nil
+			case 'w':
+				return time.Duration(n) * 7 * 24 * time.Hour, nil
+			}
+		}
+	}
+	return 0, errors.Errorf("interval %s is invalid", value)
+}
+
+// FormatInterval is the opposite of ParseInterval
+func FormatInterval(interval time.Duration) string {
+	switch {
+	case interval%(7*24*time.Hour) == 0:
+		return strconv.FormatInt(int64(interval/(7*24*time.Hour)), 10) + "w"
+	case interval%(24*time.Hour) == 0:
+		return strconv.FormatInt(int64(interval/(24*time.Hour)), 10) + "d"
+	case interval%time.Hour == 0:
+		return strconv.FormatInt(int64(interval/time.Hour), 10) + "h"
+	default:
+		return strconv.FormatInt(int64(interval/time.Minute), 10) + "m"
+	}
+}
@@ -37,6 +37,37 @@ func (info *ExchangeInfo) Equals(name string) bool {
 	return strings.EqualFold(info.Code, name) || strings.EqualFold(info.Name, name)
 }
 
+// TrailingStop is implemented by the exchanges that trail a stop-loss server-side. percent is how far (in percent)
+// the price may drop below its high before we sell at market.
+type TrailingStop interface {
+	TrailingStop(client interface{}, market string, size, percent float64, metadata string) ([]byte, error)
+}
+
+// StopBuy is implemented by the exchanges that can buy once the price rises to a trigger price. the order rests on
+// the exchange (out of the order book) until then.
+type StopBuy interface {
+	StopBuy(client interface{}, market string, size, trigger float64, metadata string) (oid []byte, raw []byte, err error)
+}
+
+// TickSize is implemented by the exchanges with a price increment that isn't necessarily a power of ten, for example:
+// 0.05 or 0.25. GetTickSize returns that increment.
+type TickSize interface {
+	GetTickSize(client interface{}, market string) (float64, error)
+}
+
+// Stops is what an exchange can do with its StopLoss and OCO methods
+type Stops struct {
+	Strategy bool // the sell command supports --strategy=stop-loss
+	Stop     bool // StopLoss opens a stop order
+	OCO      bool // OCO opens a one-cancels-the-other order
+}
+
+// StopReader is implemented by the exchanges that support stop orders (or the stop-loss strategy). every Exchange
+// implements StopLoss and OCO, so the interface alone doesn't tell us if they do anything.
+type StopReader interface {
+	GetStops() Stops
+}
+
 type Exchange interface {
 	GetInfo() *ExchangeInfo
 	GetClient(permission Permission, sandbox bool) (interface{}, error)
@@ -51,11 +82,16 @@ type Exchange interface {
 	GetBook(client interface{}, market string, side BookSide) (interface{}, error)
 	Aggregate(client, book interface{}, market string, agg float64) (Book, error)
 	GetTicker(client interface{}, market string) (float64, error)
+	GetTickers(client interface{}) (map[string]float64, error) // market -> last price, in one call (or exchanges.ErrNoTickers)
 	Get24h(client interface{}, market string) (*Stats, error)
+	Get24hAll(client interface{}) (map[string]Stats, error) // market -> stats, in one call (or exchanges.ErrNoSummaries)
 	GetPricePrec(client interface{}, market string) (int, error)
 	GetSizePrec(client interface{}, market string) (int, error)
 	GetMaxSize(client interface{}, base, quote string, hold, earn bool, def float64, mult multiplier.Mult) float64
 	Cancel(client interface{}, market string, side OrderSide) error
+	CancelOrder(client interface{}, market, id string) error
+	GetOrder(client interface{}, market, id string) (*Order, error)
+	GetFees(client interface{}, market string) (*Fees, error)
 	Buy(client interface{}, cancel bool, market string, calls Calls, deviation float64, kind OrderType) error
 	IsLeveragedToken(name string) bool
 	HasAlgoOrder(client interface{}, market string) (bool, error)
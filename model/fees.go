@@ -0,0 +1,7 @@
+package model
+
+// Fees are the maker and taker rates of a market, for example: 0.001 is 0.1%
+type Fees struct {
+	Maker float64 `json:"maker"`
+	Taker float64 `json:"taker"`
+}
@@ -0,0 +1,18 @@
+package model
+
+type Position struct {
+	Market           string
+	Size             float64 // in base currency, negative when short
+	EntryPrice       float64
+	Leverage         float64
+	Isolated         bool
+	LiquidationPrice float64
+}
+
+// Futures is implemented by the exchanges that trade (perpetual) swaps rather than spot markets.
+type Futures interface {
+	Exchange
+	GetPosition(client interface{}, market string) (*Position, error)
+	ClosePosition(client interface{}, market string) error
+	SetIsolated(client interface{}, market string, isolated bool) error
+}
@@ -81,6 +81,37 @@ func TweetMarket(markets []Market, market string) string {
 	return market
 }
 
+// FormatPrice formats a price with the tick size of the market, falling back on as many decimals as necessary.
+func FormatPrice(exchange Exchange, client interface{}, market string, price float64) string {
+	prec, err := exchange.GetPricePrec(client, market)
+	if err != nil {
+		prec = -1
+	}
+	return precision.FormatFloat(price, prec)
+}
+
+// GetTickSize returns the price increment of a market. if the exchange doesn't implement TickSize, then that is one
+// unit of the price precision.
+func GetTickSize(exchange Exchange, client interface{}, market string) (float64, error) {
+	if tick, ok := exchange.(TickSize); ok {
+		return tick.GetTickSize(client, market)
+	}
+	prec, err := exchange.GetPricePrec(client, market)
+	if err != nil {
+		return 0, err
+	}
+	return precision.Tick(prec), nil
+}
+
+// FormatSize formats a size with the lot size of the market, falling back on as many decimals as necessary.
+func FormatSize(exchange Exchange, client interface{}, market string, size float64) string {
+	prec, err := exchange.GetSizePrec(client, market)
+	if err != nil {
+		prec = -1
+	}
+	return precision.FormatFloat(size, prec)
+}
+
 func IndexByMarket(markets []Market, market string) int {
 	//lint:ignore S1031 unnecessary nil check around range
 	if markets != nil {
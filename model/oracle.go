@@ -0,0 +1,7 @@
+package model
+
+// Oracle knows the fair price of an asset, independent of the exchange that you are trading on
+type Oracle interface {
+	Name() string
+	Price(base, quote string) (float64, error) // the price of base, expressed in quote
+}
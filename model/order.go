@@ -70,13 +70,43 @@ func NewOrderType(data string) OrderType {
 	return ORDER_TYPE_NONE
 }
 
+type OrderStatus int
+
+const (
+	ORDER_STATUS_NONE OrderStatus = iota
+	OPEN
+	FILLED
+	CANCELLED
+	CLOSED // filled or cancelled, but the exchange does not tell us which
+)
+
+var OrderStatusString = map[OrderStatus]string{
+	ORDER_STATUS_NONE: "",
+	OPEN:              "open",
+	FILLED:            "filled",
+	CANCELLED:         "cancelled",
+	CLOSED:            "closed",
+}
+
+func (os *OrderStatus) String() string {
+	return OrderStatusString[*os]
+}
+
 type (
 	Order struct {
-		Side      OrderSide `json:"-"`
-		Market    string    `json:"market"`
-		Size      float64   `json:"size"`
-		Price     float64   `json:"price"`
-		CreatedAt time.Time `json:"createdAt"`
+		ID          string      `json:"id,omitempty"`
+		Side        OrderSide   `json:"-"`
+		Status      OrderStatus `json:"-"`
+		Market      string      `json:"market"`
+		Size        float64     `json:"size"`
+		Price       float64     `json:"price"`
+		CreatedAt   time.Time   `json:"createdAt"`
+		ClosedAt    *time.Time  `json:"closedAt,omitempty"`   // nil if the order is open, or the exchange does not tell us
+		FilledSize  float64     `json:"filledSize,omitempty"` // in base currency
+		Fee         float64     `json:"fee,omitempty"`        // in FeeAsset
+		FeeAsset    string      `json:"feeAsset,omitempty"`
+		Stop        float64     `json:"stop,omitempty"`        // trigger price, if this is a conditional order
+		Conditional bool        `json:"conditional,omitempty"` // true if this is a stop that is not in the order book (yet)
 	}
 	Orders []Order
 )
@@ -84,11 +114,13 @@ type (
 func (order *Order) MarshalJSON() ([]byte, error) {
 	type Alias Order
 	return json.Marshal(&struct {
-		Side string `json:"side"`
+		Side   string `json:"side"`
+		Status string `json:"status,omitempty"`
 		*Alias
 	}{
-		Side:  order.Side.String(),
-		Alias: (*Alias)(order),
+		Side:   order.Side.String(),
+		Status: order.Status.String(),
+		Alias:  (*Alias)(order),
 	})
 }
 
@@ -109,12 +141,24 @@ func (orders Orders) OrderByPrice(side OrderSide, market string, price float64)
 	return nil
 }
 
+// TimePtr returns a pointer to the time, or nil if the time is zero
+func TimePtr(at time.Time) *time.Time {
+	if at.IsZero() {
+		return nil
+	}
+	return &at
+}
+
 func (orders Orders) Youngest(side OrderSide, def time.Time) time.Time {
 	youngest := time.Time{} // January 1, year 1, 00:00:00.000000000 UTC
 	for _, order := range orders {
 		if order.Side == side {
-			if youngest.IsZero() || youngest.Before(order.CreatedAt) {
-				youngest = order.CreatedAt
+			at := order.CreatedAt
+			if order.ClosedAt != nil {
+				at = *order.ClosedAt
+			}
+			if youngest.IsZero() || youngest.Before(at) {
+				youngest = at
 			}
 		}
 	}
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// Simulation is the profile of an exchange that a paper trade (--observe) must obey, so that a strategy that you test
+// offline behaves the same live: the orders that the exchange would have refused, and the orders that the exchange
+// would have cancelled on its own.
+type Simulation struct {
+	MaxOrderAge time.Duration `json:"max_order_age,omitempty"` // the exchange cancels the orders that are older than this (0 = never)
+	MinSize     float64       `json:"min_size,omitempty"`      // in base currency (0 = no minimum)
+	MinNotional float64       `json:"min_notional,omitempty"`  // size * price, in quote currency (0 = no minimum)
+}
+
+// Simulator is implemented by the exchanges that have rules of their own
+type Simulator interface {
+	GetSimulation(client interface{}, market string) (*Simulation, error)
+}
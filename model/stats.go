@@ -7,6 +7,18 @@ type Stats struct {
 	High      float64
 	Low       float64
 	BtcVolume float64
+	Open      float64 // zero if the exchange does not tell us
+	Last      float64 // zero if the exchange does not tell us
+	Change    float64 // in %, zero if the exchange does not tell us
+	Trades    int64   // number of trades over the last 24 hours, zero if the exchange does not tell us
+}
+
+// PercentChange returns the change from open to last (in %), or zero if we don't know the open
+func PercentChange(open, last float64) float64 {
+	if open == 0 || last == 0 {
+		return 0
+	}
+	return ((last - open) / open) * 100
 }
 
 func (s *Stats) Avg(exchange Exchange, sandbox bool) (float64, error) {
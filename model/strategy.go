@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/precision"
 )
 
 type Strategy int
@@ -42,3 +43,53 @@ func GetStrategy() (Strategy, error) {
 
 	return STRATEGY_STANDARD, nil
 }
+
+// StopExit is how we exit once a stop-loss gets triggered: at market (the default), or with a limit order that is
+// --stop-limit=X percent below the trigger price. a stop-limit won't sell at any price on an illiquid book.
+type StopExit struct {
+	Kind   OrderType
+	Offset float64 // in percent, only if Kind is LIMIT
+}
+
+func GetStopExit() (*StopExit, error) {
+	out := StopExit{Kind: MARKET}
+	arg := flag.Get("stop-limit")
+	if arg.Exists && arg.String() != "" {
+		offset, err := arg.Float64()
+		if err != nil || offset <= 0 || offset >= 100 {
+			return nil, fmt.Errorf("stop-limit %v is invalid", arg)
+		}
+		out.Kind = LIMIT
+		out.Offset = offset
+	}
+	return &out, nil
+}
+
+// Limit returns the limit price of a stop that triggers at this price. without an offset, this is (about) one
+// percent below the trigger price.
+func (exit *StopExit) Limit(trigger, tick float64) float64 {
+	if exit.Offset > 0 {
+		limit := precision.FloorTick(trigger*(1-exit.Offset/100), tick)
+		if limit > 0 && limit < trigger {
+			return limit
+		}
+	}
+	limit := trigger
+	for {
+		limit = limit * 0.99
+		if precision.RoundTick(limit, tick) < trigger {
+			break
+		}
+	}
+	return precision.RoundTick(limit, tick)
+}
+
+// StopBuyLimit returns the limit price of a stop-buy that triggers at this price: (about) one percent above the
+// trigger price, so that we get filled when the price breaks out.
+func StopBuyLimit(trigger, tick float64) float64 {
+	limit := precision.CeilTick(trigger*1.01, tick)
+	if limit <= trigger {
+		limit = trigger + tick
+	}
+	return limit
+}
@@ -0,0 +1,96 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/svanas/nefertiti/errors"
+)
+
+// MarketSymbol is a currency pair, independent of how an exchange spells it. two exchanges might call the same pair
+// BTC-USDT and btcusdt, but their MarketSymbol is the same.
+type MarketSymbol struct {
+	Base  string `json:"base"`
+	Quote string `json:"quote"`
+}
+
+// SymbolFormat is how an exchange spells a currency pair.
+type SymbolFormat struct {
+	Prefix    string // for example: SPOT_
+	Separator string // between the base and the quote, for example: -
+	Inverted  bool   // true if the quote comes first, for example: BTC-ETH
+	Lower     bool   // true if the exchange spells its pairs in lower case
+}
+
+func NewMarketSymbol(base, quote string) MarketSymbol {
+	return MarketSymbol{
+		Base:  strings.ToUpper(base),
+		Quote: strings.ToUpper(quote),
+	}
+}
+
+func (symbol MarketSymbol) String() string {
+	return symbol.Base + "/" + symbol.Quote
+}
+
+func (symbol MarketSymbol) Equals(other MarketSymbol) bool {
+	return strings.EqualFold(symbol.Base, other.Base) && strings.EqualFold(symbol.Quote, other.Quote)
+}
+
+// Format returns the name of the market on an exchange
+func (symbol MarketSymbol) Format(format SymbolFormat) string {
+	first, second := symbol.Base, symbol.Quote
+	if format.Inverted {
+		first, second = second, first
+	}
+	out := format.Prefix + first + format.Separator + second
+	if format.Lower {
+		return strings.ToLower(out)
+	}
+	return strings.ToUpper(out)
+}
+
+// ParseSymbol parses the name of a market on an exchange. this works for the exchanges that have a separator only,
+// please use GetMarketSymbol otherwise.
+func ParseSymbol(name string, format SymbolFormat) (MarketSymbol, error) {
+	if format.Separator == "" {
+		return MarketSymbol{}, errors.Errorf("cannot parse market %s", name)
+	}
+	if format.Prefix != "" {
+		if !strings.HasPrefix(strings.ToUpper(name), strings.ToUpper(format.Prefix)) {
+			return MarketSymbol{}, errors.Errorf("cannot parse market %s", name)
+		}
+		name = name[len(format.Prefix):]
+	}
+	subs := strings.Split(name, format.Separator)
+	if len(subs) != 2 || subs[0] == "" || subs[1] == "" {
+		return MarketSymbol{}, errors.Errorf("cannot parse market %s", name)
+	}
+	if format.Inverted {
+		return NewMarketSymbol(subs[1], subs[0]), nil
+	}
+	return NewMarketSymbol(subs[0], subs[1]), nil
+}
+
+func (market *Market) Symbol() MarketSymbol {
+	return NewMarketSymbol(market.Base, market.Quote)
+}
+
+// GetMarketSymbol returns the currency pair of a market
+func GetMarketSymbol(markets []Market, market string) (MarketSymbol, error) {
+	for _, m := range markets {
+		if m.Name == market {
+			return m.Symbol(), nil
+		}
+	}
+	return MarketSymbol{}, errors.Errorf("market %s does not exist", market)
+}
+
+// FindMarket returns the market that trades this currency pair, or nil if there is no such market
+func FindMarket(markets []Market, symbol MarketSymbol) *Market {
+	for i := range markets {
+		if markets[i].Symbol().Equals(symbol) {
+			return &markets[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package model
+
+import (
+	"time"
+)
+
+// Trade is a print on the public trade feed of an exchange. Side is the taker side.
+type Trade struct {
+	Market string    `json:"market"`
+	Side   OrderSide `json:"-"`
+	Size   float64   `json:"size"`
+	Price  float64   `json:"price"`
+	Time   time.Time `json:"time"`
+}
+
+func (trade *Trade) Notional() float64 {
+	return trade.Size * trade.Price
+}
+
+// TradeFeed is implemented by the exchanges that stream their public trades. WatchTrades blocks until an error occurs.
+type TradeFeed interface {
+	WatchTrades(markets []string, sandbox bool, onTrade func(trade *Trade)) error
+}
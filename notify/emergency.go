@@ -0,0 +1,55 @@
+package notify
+
+import (
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/model"
+)
+
+type Event string
+
+const (
+	EVENT_NONE        Event = ""            // nothing out of the ordinary, for example: an order got filled
+	EVENT_ERROR       Event = "error"       // something went wrong
+	EVENT_STOP_LOSS   Event = "stop-loss"   // a stop-loss got filled
+	EVENT_KILL_SWITCH Event = "kill-switch" // the kill switch got tripped
+	EVENT_AUTH        Event = "auth"        // the exchange rejected our API key
+	EVENT_ACCOUNT     Event = "account"     // somebody else did something with our API key, for example a withdrawal
+)
+
+// Emergency is implemented by the services that can send a message that you cannot miss (for example: a message that
+// keeps on alerting you until you have acknowledged it).
+type Emergency interface {
+	SendEmergency(message interface{}, title string) error
+}
+
+// --pushover-emergency=[stop-loss,kill-switch,auth,account] (optional, defaults to kill-switch,auth,account)
+func IsEmergency(event Event) bool {
+	arg := flag.Get("pushover-emergency")
+	if !arg.Exists {
+		return event == EVENT_KILL_SWITCH || event == EVENT_AUTH || event == EVENT_ACCOUNT
+	}
+	return arg.Contains(string(event))
+}
+
+// Critical sends a message about a critical event. If you have asked for this event to be an emergency, and the
+// service supports it, then the message is sent with emergency priority.
+func Critical(service model.Notify, event Event, message interface{}, title string) error {
+	if IsEmergency(event) {
+		if emergency, ok := service.(Emergency); ok {
+			return emergency.SendEmergency(message, title)
+		}
+	}
+	return SendEvent(service, event, message, title, model.ALWAYS)
+}
+
+// SendError sends an error. if the exchange rejected our API key, and you have asked for that to be an emergency, then
+// the error is sent with emergency priority.
+func SendError(service model.Notify, err error, message interface{}, title string) error {
+	if errors.ExitCode(err) == errors.EXIT_AUTH && IsEmergency(EVENT_AUTH) {
+		if emergency, ok := service.(Emergency); ok {
+			return emergency.SendEmergency(message, title)
+		}
+	}
+	return SendEvent(service, EVENT_ERROR, message, title, model.ONCE_PER_MINUTE)
+}
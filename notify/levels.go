@@ -24,6 +24,14 @@ const (
 	CANCELLED
 )
 
+var NotificationString = map[Notification]string{
+	ERROR:     "ERROR",
+	INFO:      "INFO",
+	FILLED:    "FILLED",
+	OPENED:    "OPENED",
+	CANCELLED: "CANCELLED",
+}
+
 // --level=[0..3]
 func Level() (int64, error) {
 	var (
@@ -2,8 +2,22 @@ package notify
 
 import (
 	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/session"
 )
 
+// tag prefixes the title with the run ID (and label), so that you can tell apart the loops that run simultaneously
+func tag(title string) string {
+	if title == "" {
+		return "[" + session.RunTag() + "]"
+	}
+	return "[" + session.RunTag() + "] " + title
+}
+
+// Image is implemented by the services that can send an image, for example: a chart of a filled order
+type Image interface {
+	SendImage(image []byte, caption, title string) error
+}
+
 type Services []model.Notify
 
 func (services *Services) Init(interactive, verify bool) (model.Notify, error) {
@@ -4,17 +4,20 @@ package notify
 import (
 	"encoding/json"
 	"time"
+	"unicode/utf8"
 
 	"github.com/gregdel/pushover"
 	"github.com/svanas/nefertiti/errors"
 	"github.com/svanas/nefertiti/flag"
 	"github.com/svanas/nefertiti/model"
 	"github.com/svanas/nefertiti/passphrase"
+	"github.com/svanas/nefertiti/precision"
 )
 
 type Pushover struct {
 	appKey  string
 	userKey string
+	quiet   *quietHours
 }
 
 func (self *Pushover) promptForKeys(interactive bool) (ok bool, err error) {
@@ -52,6 +55,13 @@ func (self *Pushover) promptForKeys(interactive bool) (ok bool, err error) {
 
 func (self *Pushover) PromptForKeys(interactive, verify bool) (ok bool, err error) {
 	ok, err = self.promptForKeys(interactive)
+	if ok {
+		if self.quiet, err = newQuietHours("pushover", func(body, title string) error {
+			return self.send(body, title, pushover.PriorityNormal)
+		}); err != nil {
+			ok = false
+		}
+	}
 	if ok && verify {
 		// verify the pushover user key
 		if err = pushoverVerifyRecipient(self.appKey, self.userKey); err != nil {
@@ -80,6 +90,11 @@ func init() {
 }
 
 func (self *Pushover) SendMessage(message interface{}, title string, frequency model.Frequency) error {
+	return self.SendEvent(EVENT_NONE, message, title, frequency)
+}
+
+// SendEvent sends a message about an event. during our quiet hours, only the urgent events go through.
+func (self *Pushover) SendEvent(event Event, message interface{}, title string, frequency model.Frequency) error {
 	if self.appKey == "" {
 		return errors.New("missing argument: Pushover application key")
 	}
@@ -99,7 +114,7 @@ func (self *Pushover) SendMessage(message interface{}, title string, frequency m
 			if err != nil {
 				return "", err
 			} else {
-				return string(data), nil
+				return precision.ExpandJSON(string(data)), nil
 			}
 		}
 	}(message); err != nil {
@@ -117,20 +132,77 @@ func (self *Pushover) SendMessage(message interface{}, title string, frequency m
 		delete(messageHistory, body)
 	}
 
-	app := pushover.New(self.appKey)
-	rec := pushover.NewRecipient(self.userKey)
-	msg := pushover.NewMessageWithTitle(body, title)
+	priority := pushover.PriorityNormal
 
-	_, err = app.SendMessage(msg, rec)
-	if err != nil {
+	// are we in our quiet hours? then hold back (or lower) everything that isn't urgent
+	if self.quiet != nil && !urgent(event) && self.quiet.active(time.Now()) {
+		if self.quiet.mode == QUIET_LOWER {
+			priority = pushover.PriorityLow
+		} else {
+			if err = self.quiet.hold(title, body); err != nil {
+				return err
+			}
+			messageHistory[body] = time.Now()
+			return nil
+		}
+	}
+
+	if err = self.send(body, title, priority); err != nil {
 		return err
-	} else {
-		messageHistory[body] = time.Now()
 	}
+	messageHistory[body] = time.Now()
 
 	return nil
 }
 
+// SendEmergency sends a message with emergency priority. Pushover will keep on alerting you until you acknowledge it.
+func (self *Pushover) SendEmergency(message interface{}, title string) error {
+	if self.appKey == "" {
+		return errors.New("missing argument: Pushover application key")
+	}
+	if self.userKey == "" {
+		return errors.New("missing argument: Pushover recipient")
+	}
+
+	body, ok := message.(string)
+	if !ok {
+		data, err := json.MarshalIndent(message, "", "  ")
+		if err != nil {
+			return err
+		}
+		body = precision.ExpandJSON(string(data))
+	}
+
+	return self.send(body, title, pushover.PriorityEmergency)
+}
+
+const (
+	pushoverRetry  = time.Minute // with emergency priority, Pushover retries every minute...
+	pushoverExpire = time.Hour   // ...for an hour, or until you have acknowledged the message
+)
+
+// Pushover rejects messages that are longer than this many characters
+const pushoverMaxLen = 1024
+
+func (self *Pushover) send(body, title string, priority int) error {
+	if utf8.RuneCountInString(body) > pushoverMaxLen {
+		body = string([]rune(body)[:pushoverMaxLen-3]) + "..."
+	}
+
+	app := pushover.New(self.appKey)
+	rec := pushover.NewRecipient(self.userKey)
+	msg := pushover.NewMessageWithTitle(body, tag(title))
+
+	msg.Priority = priority
+	if priority == pushover.PriorityEmergency {
+		msg.Retry = pushoverRetry
+		msg.Expire = pushoverExpire
+	}
+
+	_, err := app.SendMessage(msg, rec)
+	return err
+}
+
 func NewPushover() model.Notify {
 	return &Pushover{}
 }
@@ -0,0 +1,224 @@
+package notify
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/i18n"
+	"github.com/svanas/nefertiti/model"
+	"github.com/svanas/nefertiti/session"
+)
+
+const (
+	QUIET_DIGEST = "digest" // hold back the notifications, then send them in one message when the quiet hours are over
+	QUIET_LOWER  = "lower"  // send the notifications right away, but without sound (if the service supports it)
+)
+
+// Quiet is implemented by the services that can have quiet hours. unlike SendMessage, SendEvent tells the service
+// what happened, so that a message about an error (or a critical event) never waits for the quiet hours to be over.
+type Quiet interface {
+	SendEvent(event Event, message interface{}, title string, frequency model.Frequency) error
+}
+
+// SendEvent sends a message about an event. if the service has quiet hours, the message goes through regardless.
+func SendEvent(service model.Notify, event Event, message interface{}, title string, frequency model.Frequency) error {
+	if quiet, ok := service.(Quiet); ok {
+		return quiet.SendEvent(event, message, title, frequency)
+	}
+	return service.SendMessage(message, title, frequency)
+}
+
+// urgent returns true if a message about this event should never wait for our quiet hours to be over
+func urgent(event Event) bool {
+	return event != EVENT_NONE
+}
+
+// quietHours is a daily window during which a service holds back the notifications that aren't urgent. the digest of
+// what we held back lives in the session dir, so that it survives a restart (and so that the processes that share a
+// service send one digest between them).
+type quietHours struct {
+	service string
+	from    int // minutes since midnight, local time
+	to      int // minutes since midnight, local time
+	mode    string
+	send    func(body, title string) error
+	mutex   sync.Mutex
+	timer   *time.Timer
+}
+
+func parseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// --[service]-quiet-hours=22:00-07:00 (optional)
+// --[service]-quiet-mode=[digest|lower] (optional, defaults to digest)
+func newQuietHours(service string, send func(body, title string) error) (*quietHours, error) {
+	arg := flag.Get(service + "-quiet-hours")
+	if !arg.Exists || arg.String() == "" {
+		return nil, nil
+	}
+	window := strings.Split(arg.String(), "-")
+	if len(window) != 2 {
+		return nil, errors.Errorf("%s-quiet-hours %v is invalid", service, arg)
+	}
+	var err error
+	out := &quietHours{
+		service: service,
+		mode:    QUIET_DIGEST,
+		send:    send,
+	}
+	if out.from, err = parseClock(window[0]); err != nil {
+		return nil, errors.Errorf("%s-quiet-hours %v is invalid", service, arg)
+	}
+	if out.to, err = parseClock(window[1]); err != nil {
+		return nil, errors.Errorf("%s-quiet-hours %v is invalid", service, arg)
+	}
+	if mode := flag.Get(service + "-quiet-mode"); mode.Exists && mode.String() != "" {
+		out.mode = strings.ToLower(mode.String())
+		if out.mode != QUIET_DIGEST && out.mode != QUIET_LOWER {
+			return nil, errors.Errorf("%s-quiet-mode %v is invalid", service, mode)
+		}
+	}
+	// did we hold back anything before we (re)started? then send it when our quiet hours are over
+	if out.mode == QUIET_DIGEST {
+		digest, err := out.read()
+		if err != nil {
+			return nil, err
+		}
+		if len(digest) > 0 {
+			out.schedule()
+		}
+	}
+	return out, nil
+}
+
+// active returns true if this time falls within our quiet hours
+func (q *quietHours) active(now time.Time) bool {
+	minutes := now.Hour()*60 + now.Minute()
+	if q.from <= q.to {
+		return minutes >= q.from && minutes < q.to
+	}
+	// the window wraps around midnight, for example: 22:00-07:00
+	return minutes >= q.from || minutes < q.to
+}
+
+// end returns the end of the quiet hours that this time falls within
+func (q *quietHours) end(now time.Time) time.Time {
+	out := time.Date(now.Year(), now.Month(), now.Day(), q.to/60, q.to%60, 0, 0, now.Location())
+	if !out.After(now) {
+		out = out.AddDate(0, 0, 1)
+	}
+	return out
+}
+
+func (q *quietHours) file() string {
+	return session.GetSessionFile(q.service + ".digest.json")
+}
+
+// lock locks the digest. the processes that share a service share the digest, so we lock a file in the session dir.
+func (q *quietHours) lock() (*session.Mutex, error) {
+	mutex, err := session.NewFileMutex(q.service + ".digest.lock")
+	if err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+	if err = mutex.Lock(); err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+	return mutex, nil
+}
+
+func (q *quietHours) read() ([]string, error) {
+	var out []string
+	data, err := session.ReadFile(q.file())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return out, nil
+		}
+		return nil, errors.Wrap(err, 1)
+	}
+	if err = json.Unmarshal(data, &out); err != nil {
+		return nil, errors.Wrap(err, 1)
+	}
+	return out, nil
+}
+
+func (q *quietHours) write(digest []string) error {
+	data, err := json.Marshal(digest)
+	if err != nil {
+		return errors.Wrap(err, 1)
+	}
+	return session.WriteFile(q.file(), data)
+}
+
+// hold adds a notification to our digest. when our quiet hours are over, we send the digest.
+func (q *quietHours) hold(title, body string) error {
+	mutex, err := q.lock()
+	if err != nil {
+		return err
+	}
+	defer mutex.Unlock()
+
+	digest, err := q.read()
+	if err != nil {
+		return err
+	}
+	line := time.Now().Format("15:04") + " "
+	if title != "" {
+		line = line + title + ": "
+	}
+	if err = q.write(append(digest, line+body)); err != nil {
+		return err
+	}
+
+	q.schedule()
+	return nil
+}
+
+// schedule sends the digest when our quiet hours are over (or right away if they are over already)
+func (q *quietHours) schedule() {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	if q.timer != nil {
+		return
+	}
+	var wait time.Duration
+	if now := time.Now(); q.active(now) {
+		wait = time.Until(q.end(now))
+	}
+	q.timer = time.AfterFunc(wait, func() {
+		q.mutex.Lock()
+		q.timer = nil
+		q.mutex.Unlock()
+		if err := q.flush(); err != nil {
+			log.Printf("[ERROR] %v", err)
+		}
+	})
+}
+
+// flush sends the digest, and empties it. if another process got here first, then the digest is empty already.
+func (q *quietHours) flush() error {
+	mutex, err := q.lock()
+	if err != nil {
+		return err
+	}
+	defer mutex.Unlock()
+
+	digest, err := q.read()
+	if err != nil || len(digest) == 0 {
+		return err
+	}
+	if err = q.send(strings.Join(digest, "\n\n"), i18n.Sprintf("While you were away (%d)", len(digest))); err != nil {
+		return err
+	}
+	return q.write(nil)
+}
@@ -2,19 +2,26 @@
 package notify
 
 import (
+	"bytes"
 	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/url"
 	"strconv"
+	"time"
 
 	"github.com/svanas/nefertiti/errors"
 	"github.com/svanas/nefertiti/flag"
 	"github.com/svanas/nefertiti/model"
 	"github.com/svanas/nefertiti/passphrase"
+	"github.com/svanas/nefertiti/precision"
 	"github.com/yanzay/tbot"
 )
 
 type Telegram struct {
 	appKey string
 	chatId int64
+	quiet  *quietHours
 }
 
 func (self *Telegram) PromptForKeys(interactive, verify bool) (ok bool, err error) {
@@ -54,10 +61,21 @@ func (self *Telegram) PromptForKeys(interactive, verify bool) (ok bool, err erro
 		}
 	}
 
+	if self.appKey != "" && self.chatId != 0 {
+		if self.quiet, err = newQuietHours("telegram", self.send); err != nil {
+			return false, err
+		}
+	}
+
 	return self.appKey != "" && self.chatId != 0, nil
 }
 
 func (self *Telegram) SendMessage(message interface{}, title string, frequency model.Frequency) error {
+	return self.SendEvent(EVENT_NONE, message, title, frequency)
+}
+
+// SendEvent sends a message about an event. during our quiet hours, only the urgent events go through.
+func (self *Telegram) SendEvent(event Event, message interface{}, title string, frequency model.Frequency) error {
 	if self.appKey == "" {
 		return errors.New("missing argument: Telegram application key")
 	}
@@ -77,23 +95,71 @@ func (self *Telegram) SendMessage(message interface{}, title string, frequency m
 			if err != nil {
 				return "", err
 			} else {
-				return string(data), nil
+				return precision.ExpandJSON(string(data)), nil
 			}
 		}
 	}(message); err != nil {
 		return err
 	}
 
+	// are we in our quiet hours? then hold back everything that isn't urgent. Telegram cannot lower a notification.
+	if self.quiet != nil && !urgent(event) && self.quiet.active(time.Now()) {
+		return self.quiet.hold(title, body)
+	}
+
+	return self.send(body, title)
+}
+
+func (self *Telegram) send(body, title string) error {
 	bot, err := tbot.NewServer(self.appKey)
 	if err != nil {
 		return err
 	}
 
-	if title == "" {
-		return bot.Send(self.chatId, body)
-	} else {
-		return bot.Send(self.chatId, (title + ": " + body))
+	return bot.Send(self.chatId, (tag(title) + ": " + body))
+}
+
+// we never want to hang on a chart that Telegram isn't receiving
+var telegramClient = &http.Client{Timeout: 30 * time.Second}
+
+// SendImage sends a PNG with a caption. during our quiet hours, we don't send images at all.
+func (self *Telegram) SendImage(image []byte, caption, title string) error {
+	if self.appKey == "" || self.chatId == 0 {
+		return nil
 	}
+	if self.quiet != nil && self.quiet.active(time.Now()) {
+		return nil
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	writer.WriteField("chat_id", strconv.FormatInt(self.chatId, 10))
+	writer.WriteField("caption", (tag(title) + ": " + caption))
+	part, err := writer.CreateFormFile("photo", "chart.png")
+	if err != nil {
+		return errors.Wrap(err, 1)
+	}
+	if _, err = part.Write(image); err != nil {
+		return errors.Wrap(err, 1)
+	}
+	if err = writer.Close(); err != nil {
+		return errors.Wrap(err, 1)
+	}
+
+	resp, err := telegramClient.Post(("https://api.telegram.org/bot" + self.appKey + "/sendPhoto"), writer.FormDataContentType(), &body)
+	if err != nil {
+		// the URL has our bot token in it, so we leave the URL out of the error
+		if urlErr, ok := err.(*url.Error); ok {
+			err = urlErr.Err
+		}
+		return errors.Errorf("Telegram sendPhoto failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("Telegram sendPhoto failed: %s", resp.Status)
+	}
+
+	return nil
 }
 
 func NewTelegram() model.Notify {
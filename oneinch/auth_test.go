@@ -0,0 +1,48 @@
+package oneinch
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+)
+
+// the example from EIP-155
+func TestSignature(t *testing.T) {
+	wallet, err := NewWallet("0x4646464646464646464646464646464646464646464646464646464646464646")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if wallet.Address() != "0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F" {
+		t.Errorf("TestSignature failed, got: %v, want: %v.", wallet.Address(), "0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F")
+	}
+
+	to, _ := hex.DecodeString("3535353535353535353535353535353535353535")
+	value, _ := new(big.Int).SetString("1000000000000000000", 10)
+	data := rlpEncode(rlpList{uint64(9), big.NewInt(20000000000), uint64(21000), to, value, []byte{}, uint64(1), uint64(0), uint64(0)})
+
+	expected := "ec098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a764000080018080"
+	if hex.EncodeToString(data) != expected {
+		t.Errorf("TestSignature failed, got: %x, want: %v.", data, expected)
+	}
+
+	hash := keccak256(data)
+	expected = "daf5a779ae972f972197303d7b574746c7ef83eadac0f2791ad23db92e4c8e53"
+	if hex.EncodeToString(hash) != expected {
+		t.Errorf("TestSignature failed, got: %x, want: %v.", hash, expected)
+	}
+
+	r, s, v, err := sign(wallet.key, hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.String() != "18515461264373351373200002665853028612451056578545711640558177340181847433846" {
+		t.Errorf("TestSignature failed, got: r = %v.", r)
+	}
+	if s.String() != "46948507304638947509940763649030358759909902576025900602547168820602576006531" {
+		t.Errorf("TestSignature failed, got: s = %v.", s)
+	}
+	if v != 0 {
+		t.Errorf("TestSignature failed, got: v = %v, want: 0.", v)
+	}
+}
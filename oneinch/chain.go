@@ -0,0 +1,50 @@
+package oneinch
+
+import (
+	"errors"
+	"strings"
+)
+
+// 1inch refers to the native token (for example ETH) with this pseudo address
+const NATIVE = "0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE"
+
+type Chain struct {
+	Id     int64
+	Name   string
+	Native string // symbol of the native token
+	USDC   string // address of (native) USDC on this chain
+	RPC    string // default JSON RPC
+}
+
+var Chains = []Chain{
+	{
+		Id:     1,
+		Name:   "ethereum",
+		Native: "ETH",
+		USDC:   "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48",
+		RPC:    "https://eth.llamarpc.com",
+	},
+	{
+		Id:     137,
+		Name:   "polygon",
+		Native: "MATIC",
+		USDC:   "0x3c499c542cEF5E3811e1192ce70d8cC03d5c3359",
+		RPC:    "https://polygon-rpc.com",
+	},
+	{
+		Id:     42161,
+		Name:   "arbitrum",
+		Native: "ETH",
+		USDC:   "0xaf88d065e77c8cC2239327C5EDb3A432268e5831",
+		RPC:    "https://arb1.arbitrum.io/rpc",
+	},
+}
+
+func GetChain(name string) (*Chain, error) {
+	for _, chain := range Chains {
+		if strings.EqualFold(chain.Name, name) {
+			return &chain, nil
+		}
+	}
+	return nil, errors.New("chain " + name + " does not exist")
+}
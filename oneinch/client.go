@@ -0,0 +1,156 @@
+package oneinch
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/ratelimit"
+)
+
+const (
+	API_BASE = "https://api.1inch.dev/swap/v5.2"
+)
+
+var (
+	lastRequest       time.Time
+	RequestsPerSecond float64                         = 1
+	BeforeRequest     func(method, path string) error = nil
+	AfterRequest      func()                          = nil
+)
+
+func init() {
+	BeforeRequest = func(method, path string) error {
+		elapsed := time.Since(lastRequest)
+		if elapsed.Seconds() < (float64(1) / RequestsPerSecond) {
+			time.Sleep(time.Duration((float64(time.Second) / RequestsPerSecond) - float64(elapsed)))
+		}
+		return nil
+	}
+	AfterRequest = func() {
+		lastRequest = time.Now()
+	}
+}
+
+// upper bounds on what we are willing to pay for gas. nil means: no limit.
+type GasLimits struct {
+	MaxGasPrice    *big.Int // in wei, the base fee plus the priority fee
+	MaxPriorityFee *big.Int // in wei, aka the tip
+}
+
+type Client struct {
+	URL        string // the 1inch API
+	RPC        string // the JSON RPC of the chain
+	Chain      *Chain
+	Limits     GasLimits
+	key        string
+	wallet     *Wallet
+	nonce      *uint64 // the next nonce we expect to use, nil until we have sent our first transaction
+	mutex      sync.Mutex
+	httpClient *http.Client
+}
+
+// wallet is optional. without it, you get a read-only client (quotes and prices only).
+func New(URL, apiKey string, chain *Chain, RPC string, wallet *Wallet) *Client {
+	if RPC == "" {
+		RPC = chain.RPC
+	}
+	return &Client{
+		URL:    URL,
+		RPC:    RPC,
+		Chain:  chain,
+		key:    apiKey,
+		wallet: wallet,
+		httpClient: &http.Client{
+			Timeout:   30 * time.Second,
+			Transport: ratelimit.NewTransport("1inch", nil),
+		},
+	}
+}
+
+func (client *Client) Address() (string, error) {
+	if client.wallet == nil {
+		return "", errors.New("wallet is missing")
+	}
+	return client.wallet.Address(), nil
+}
+
+func (client *Client) endpoint(path string) string {
+	return client.URL + "/" + strconv.FormatInt(client.Chain.Id, 10) + path
+}
+
+func (client *Client) do(req *http.Request) ([]byte, error) {
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+			return nil, errors.NewStatusError(resp.StatusCode, resp.Status)
+		}
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		if err, msg := IsError(body); err {
+			return body, errors.NewStatusError(resp.StatusCode, msg)
+		}
+		return body, errors.NewStatusError(resp.StatusCode, resp.Status)
+	}
+
+	return body, nil
+}
+
+func (client *Client) call(method, URL string, query url.Values, payload interface{}) ([]byte, error) {
+	// respect the rate limit
+	err := BeforeRequest(method, URL)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		AfterRequest()
+	}()
+
+	// set the endpoint for this request
+	endpoint, err := url.Parse(URL)
+	if err != nil {
+		return nil, err
+	}
+	if query != nil {
+		endpoint.RawQuery = query.Encode()
+	}
+
+	// create the request
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, endpoint.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Add("Content-Type", "application/json")
+	}
+	// the API key is for the 1inch API only, never send it to the RPC
+	if client.key != "" && URL != client.RPC {
+		req.Header.Add("Authorization", "Bearer "+client.key)
+	}
+
+	// do the request
+	return client.do(req)
+}
@@ -0,0 +1,39 @@
+package oneinch
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type Error struct {
+	StatusCode  int    `json:"statusCode"`
+	Message     string `json:"error"`
+	Description string `json:"description"`
+}
+
+func IsError(response []byte) (bool, string) {
+	var err Error
+	if json.Unmarshal(response, &err) == nil {
+		if err.Description != "" {
+			return true, err.Description
+		}
+		return err.Message != "", err.Message
+	}
+	return false, ""
+}
+
+func (err *Error) Error() string {
+	if err.Description != "" {
+		return err.Description
+	}
+	return err.Message
+}
+
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (err *RPCError) Error() string {
+	return fmt.Sprintf("%d %s", err.Code, err.Message)
+}
@@ -0,0 +1,39 @@
+package oneinch
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/url"
+)
+
+// returns the amount of dst we get for amount of src
+func (client *Client) Quote(src, dst string, amount *big.Int) (string, error) {
+	var (
+		err  error
+		body []byte
+		out  struct {
+			ToAmount string `json:"toAmount"`
+		}
+	)
+	if body, err = client.call(http.MethodGet, client.endpoint("/quote"), url.Values{
+		"src":    {src},
+		"dst":    {dst},
+		"amount": {amount.String()},
+	}, nil); err != nil {
+		return "", err
+	}
+	if err = json.Unmarshal(body, &out); err != nil {
+		return "", err
+	}
+	return out.ToAmount, nil
+}
+
+// returns the price of one base token, expressed in the quote token
+func (client *Client) Price(base, quote *Token) (float64, error) {
+	amount, err := client.Quote(base.Address, quote.Address, base.ToAmount(1))
+	if err != nil {
+		return 0, err
+	}
+	return quote.FromAmount(amount), nil
+}
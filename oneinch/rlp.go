@@ -0,0 +1,42 @@
+package oneinch
+
+import (
+	"math/big"
+)
+
+// a minimal recursive-length-prefix encoder, supporting the types we need in a transaction
+type rlpList []interface{}
+
+func rlpEncode(item interface{}) []byte {
+	switch v := item.(type) {
+	case []byte:
+		if len(v) == 1 && v[0] < 0x80 {
+			return v
+		}
+		return append(rlpHeader(0x80, len(v)), v...)
+	case string:
+		return rlpEncode([]byte(v))
+	case uint64:
+		return rlpEncode(new(big.Int).SetUint64(v))
+	case *big.Int:
+		if v == nil {
+			return rlpEncode([]byte{})
+		}
+		return rlpEncode(v.Bytes()) // big-endian, without leading zeroes
+	case rlpList:
+		var payload []byte
+		for _, elem := range v {
+			payload = append(payload, rlpEncode(elem)...)
+		}
+		return append(rlpHeader(0xc0, len(payload)), payload...)
+	}
+	panic("rlp: unsupported type")
+}
+
+func rlpHeader(offset byte, size int) []byte {
+	if size < 56 {
+		return []byte{offset + byte(size)}
+	}
+	length := new(big.Int).SetInt64(int64(size)).Bytes()
+	return append([]byte{offset + 55 + byte(len(length))}, length...)
+}
@@ -0,0 +1,266 @@
+package oneinch
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *RPCError       `json:"error"`
+}
+
+func (client *Client) rpc(method string, params ...interface{}) (json.RawMessage, error) {
+	if params == nil {
+		params = []interface{}{}
+	}
+
+	body, err := client.call(http.MethodPost, client.RPC, nil, map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  method,
+		"params":  params,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var out rpcResponse
+	if err = json.Unmarshal(body, &out); err != nil {
+		return nil, err
+	}
+	if out.Error != nil {
+		return nil, out.Error
+	}
+
+	return out.Result, nil
+}
+
+// parses a hex quantity, for example 0x1bc16d674ec80000
+func parseQuantity(data json.RawMessage) (*big.Int, error) {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return nil, err
+	}
+	out, ok := new(big.Int).SetString(strings.TrimPrefix(str, "0x"), 16)
+	if !ok {
+		return nil, fmt.Errorf("cannot parse quantity %s", str)
+	}
+	return out, nil
+}
+
+func formatQuantity(value *big.Int) string {
+	if value == nil || value.Sign() == 0 {
+		return "0x0"
+	}
+	return "0x" + value.Text(16)
+}
+
+func (client *Client) GetTransactionCount(address string) (uint64, error) {
+	result, err := client.rpc("eth_getTransactionCount", address, "pending")
+	if err != nil {
+		return 0, err
+	}
+	count, err := parseQuantity(result)
+	if err != nil {
+		return 0, err
+	}
+	return count.Uint64(), nil
+}
+
+func (client *Client) BaseFee() (*big.Int, error) {
+	result, err := client.rpc("eth_getBlockByNumber", "latest", false)
+	if err != nil {
+		return nil, err
+	}
+	var block struct {
+		BaseFeePerGas json.RawMessage `json:"baseFeePerGas"`
+	}
+	if err = json.Unmarshal(result, &block); err != nil {
+		return nil, err
+	}
+	if block.BaseFeePerGas == nil {
+		return nil, errors.New("chain does not support EIP-1559")
+	}
+	return parseQuantity(block.BaseFeePerGas)
+}
+
+func (client *Client) MaxPriorityFee() (*big.Int, error) {
+	result, err := client.rpc("eth_maxPriorityFeePerGas")
+	if err != nil {
+		return nil, err
+	}
+	return parseQuantity(result)
+}
+
+func (client *Client) EstimateGas(from, to string, value *big.Int, data []byte) (uint64, error) {
+	result, err := client.rpc("eth_estimateGas", map[string]string{
+		"from":  from,
+		"to":    to,
+		"value": formatQuantity(value),
+		"data":  "0x" + hex.EncodeToString(data),
+	})
+	if err != nil {
+		return 0, err
+	}
+	gas, err := parseQuantity(result)
+	if err != nil {
+		return 0, err
+	}
+	return gas.Uint64(), nil
+}
+
+// sends a signed transaction, returns the transaction hash
+func (client *Client) SendRawTransaction(raw []byte) (string, error) {
+	result, err := client.rpc("eth_sendRawTransaction", "0x"+hex.EncodeToString(raw))
+	if err != nil {
+		return "", err
+	}
+	var hash string
+	if err = json.Unmarshal(result, &hash); err != nil {
+		return "", err
+	}
+	return hash, nil
+}
+
+// waits for a transaction to get mined
+func (client *Client) WaitForReceipt(hash string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		result, err := client.rpc("eth_getTransactionReceipt", hash)
+		if err != nil {
+			return err
+		}
+
+		var receipt *struct {
+			Status string `json:"status"`
+		}
+		if err = json.Unmarshal(result, &receipt); err != nil {
+			return err
+		}
+
+		if receipt != nil {
+			if receipt.Status != "0x1" {
+				return fmt.Errorf("transaction %s reverted", hash)
+			}
+			return nil
+		}
+
+		time.Sleep(5 * time.Second)
+	}
+	return errors.New("timeout waiting for transaction " + hash)
+}
+
+func gwei(wei *big.Int) string {
+	return new(big.Float).Quo(new(big.Float).SetInt(wei), big.NewFloat(1e9)).Text('f', 2)
+}
+
+// returns (max priority fee, max fee per gas) within our gas limits
+func (client *Client) fees() (*big.Int, *big.Int, error) {
+	baseFee, err := client.BaseFee()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tip, err := client.MaxPriorityFee()
+	if err != nil {
+		return nil, nil, err
+	}
+	if client.Limits.MaxPriorityFee != nil && tip.Cmp(client.Limits.MaxPriorityFee) > 0 {
+		tip = new(big.Int).Set(client.Limits.MaxPriorityFee)
+	}
+
+	// allow for the base fee to double while our transaction is pending
+	feeCap := new(big.Int).Add(new(big.Int).Lsh(baseFee, 1), tip)
+
+	if client.Limits.MaxGasPrice != nil {
+		price := new(big.Int).Add(baseFee, tip)
+		if price.Cmp(client.Limits.MaxGasPrice) > 0 {
+			return nil, nil, fmt.Errorf("gas price %s gwei exceeds your maximum of %s gwei", gwei(price), gwei(client.Limits.MaxGasPrice))
+		}
+		if feeCap.Cmp(client.Limits.MaxGasPrice) > 0 {
+			feeCap = new(big.Int).Set(client.Limits.MaxGasPrice)
+		}
+	}
+
+	return tip, feeCap, nil
+}
+
+// returns the next nonce. we keep track of the nonces we used ourselves, because the pending count of
+// a load-balanced RPC can lag behind the transactions we have just sent.
+func (client *Client) nextNonce(address string) (uint64, error) {
+	nonce, err := client.GetTransactionCount(address)
+	if err != nil {
+		return 0, err
+	}
+	if client.nonce != nil && *client.nonce > nonce {
+		nonce = *client.nonce
+	}
+	return nonce, nil
+}
+
+// signs and sends a transaction, waits for it to get mined, then returns the transaction hash
+func (client *Client) sendTransaction(to string, value *big.Int, data []byte, gas uint64) (string, error) {
+	address, err := client.Address()
+	if err != nil {
+		return "", err
+	}
+
+	// one transaction at a time, so we never hand out the same nonce twice
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	if gas == 0 {
+		if gas, err = client.EstimateGas(address, to, value, data); err != nil {
+			return "", err
+		}
+	}
+
+	tip, feeCap, err := client.fees()
+	if err != nil {
+		return "", err
+	}
+
+	var hash string
+	for attempt := 0; ; attempt++ {
+		nonce, err := client.nextNonce(address)
+		if err != nil {
+			return "", err
+		}
+
+		raw, err := signTransaction(client.wallet, &Transaction{
+			ChainId:              client.Chain.Id,
+			Nonce:                nonce,
+			MaxPriorityFeePerGas: tip,
+			MaxFeePerGas:         feeCap,
+			Gas:                  gas + gas/4, // plus a 25% safety margin
+			To:                   to,
+			Value:                value,
+			Data:                 data,
+		})
+		if err != nil {
+			return "", err
+		}
+
+		if hash, err = client.SendRawTransaction(raw); err != nil {
+			// our local nonce is out of sync (for example, because we sent a transaction from another wallet app), start over
+			if attempt == 0 && strings.Contains(strings.ToLower(err.Error()), "nonce too low") {
+				client.nonce = nil
+				continue
+			}
+			return "", err
+		}
+
+		next := nonce + 1
+		client.nonce = &next
+		break
+	}
+
+	return hash, client.WaitForReceipt(hash, 5*time.Minute)
+}
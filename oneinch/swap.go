@@ -0,0 +1,127 @@
+package oneinch
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+type SwapTx struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Data  string `json:"data"`
+	Value string `json:"value"`
+	Gas   uint64 `json:"gas"`
+}
+
+func (tx *SwapTx) decode() (*big.Int, []byte, error) { // -> (value, data, error)
+	value, ok := new(big.Int).SetString(tx.Value, 10)
+	if !ok {
+		return nil, nil, errors.New("cannot parse value " + tx.Value)
+	}
+	data, err := hex.DecodeString(strings.TrimPrefix(tx.Data, "0x"))
+	if err != nil {
+		return nil, nil, err
+	}
+	return value, data, nil
+}
+
+// makes sure the 1inch router is allowed to spend amount of our token. native tokens need no approval.
+func (client *Client) approve(token string, amount *big.Int) error {
+	if strings.EqualFold(token, NATIVE) {
+		return nil
+	}
+
+	address, err := client.Address()
+	if err != nil {
+		return err
+	}
+
+	var (
+		body      []byte
+		allowance struct {
+			Allowance string `json:"allowance"`
+		}
+	)
+	if body, err = client.call(http.MethodGet, client.endpoint("/approve/allowance"), url.Values{
+		"tokenAddress":  {token},
+		"walletAddress": {address},
+	}, nil); err != nil {
+		return err
+	}
+	if err = json.Unmarshal(body, &allowance); err != nil {
+		return err
+	}
+	if current, ok := new(big.Int).SetString(allowance.Allowance, 10); ok && current.Cmp(amount) >= 0 {
+		return nil
+	}
+
+	var tx SwapTx
+	if body, err = client.call(http.MethodGet, client.endpoint("/approve/transaction"), url.Values{
+		"tokenAddress": {token},
+		"amount":       {amount.String()},
+	}, nil); err != nil {
+		return err
+	}
+	if err = json.Unmarshal(body, &tx); err != nil {
+		return err
+	}
+
+	value, data, err := tx.decode()
+	if err != nil {
+		return err
+	}
+
+	_, err = client.sendTransaction(tx.To, value, data, 0)
+	return err
+}
+
+// swaps amount of src into dst (approving the router if need be), waits for the transaction to get mined,
+// then returns (transaction hash, amount of dst we received)
+func (client *Client) Swap(src, dst string, amount *big.Int, slippage float64) (string, string, error) {
+	address, err := client.Address()
+	if err != nil {
+		return "", "", err
+	}
+
+	if err = client.approve(src, amount); err != nil {
+		return "", "", err
+	}
+
+	var (
+		body []byte
+		out  struct {
+			ToAmount string `json:"toAmount"`
+			Tx       SwapTx `json:"tx"`
+		}
+	)
+	if body, err = client.call(http.MethodGet, client.endpoint("/swap"), url.Values{
+		"src":      {src},
+		"dst":      {dst},
+		"amount":   {amount.String()},
+		"from":     {address},
+		"slippage": {strconv.FormatFloat(slippage, 'f', -1, 64)},
+	}, nil); err != nil {
+		return "", "", err
+	}
+	if err = json.Unmarshal(body, &out); err != nil {
+		return "", "", err
+	}
+
+	value, data, err := out.Tx.decode()
+	if err != nil {
+		return "", "", err
+	}
+
+	hash, err := client.sendTransaction(out.Tx.To, value, data, out.Tx.Gas)
+	if err != nil {
+		return hash, "", err
+	}
+
+	return hash, out.ToAmount, nil
+}
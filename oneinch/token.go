@@ -0,0 +1,55 @@
+package oneinch
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+)
+
+type Token struct {
+	Address  string `json:"address"`
+	Symbol   string `json:"symbol"`
+	Name     string `json:"name"`
+	Decimals int    `json:"decimals"`
+}
+
+func (token *Token) unit() *big.Float {
+	return new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(token.Decimals)), nil))
+}
+
+// converts a size into the smallest unit of the token, for example wei
+func (token *Token) ToAmount(size float64) *big.Int {
+	out, _ := new(big.Float).Mul(big.NewFloat(size), token.unit()).Int(nil)
+	return out
+}
+
+func (token *Token) FromAmount(amount string) float64 {
+	value, ok := new(big.Float).SetString(amount)
+	if !ok {
+		return 0
+	}
+	out, _ := new(big.Float).Quo(value, token.unit()).Float64()
+	return out
+}
+
+// returns the tokens that 1inch supports on this chain
+func (client *Client) Tokens() ([]Token, error) {
+	var (
+		err  error
+		body []byte
+		out  struct {
+			Tokens map[string]Token `json:"tokens"`
+		}
+	)
+	if body, err = client.call(http.MethodGet, client.endpoint("/tokens"), nil, nil); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(body, &out); err != nil {
+		return nil, err
+	}
+	var result []Token
+	for _, token := range out.Tokens {
+		result = append(result, token)
+	}
+	return result, nil
+}
@@ -0,0 +1,57 @@
+package oneinch
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
+)
+
+// an EIP-1559 (aka type 2) transaction
+type Transaction struct {
+	ChainId              int64
+	Nonce                uint64
+	MaxPriorityFeePerGas *big.Int
+	MaxFeePerGas         *big.Int
+	Gas                  uint64
+	To                   string
+	Value                *big.Int
+	Data                 []byte
+}
+
+func (tx *Transaction) fields() (rlpList, error) {
+	to, err := hex.DecodeString(strings.TrimPrefix(tx.To, "0x"))
+	if err != nil || len(to) != 20 {
+		return nil, errors.New("invalid address: " + tx.To)
+	}
+	return rlpList{
+		big.NewInt(tx.ChainId),
+		tx.Nonce,
+		tx.MaxPriorityFeePerGas,
+		tx.MaxFeePerGas,
+		tx.Gas,
+		to,
+		tx.Value,
+		tx.Data,
+		rlpList{}, // access list
+	}, nil
+}
+
+// signs the transaction with our wallet, returns the raw transaction
+func signTransaction(wallet *Wallet, tx *Transaction) ([]byte, error) {
+	if wallet == nil {
+		return nil, errors.New("wallet is missing")
+	}
+
+	fields, err := tx.fields()
+	if err != nil {
+		return nil, err
+	}
+
+	r, s, v, err := sign(wallet.key, keccak256([]byte{0x02}, rlpEncode(fields)))
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte{0x02}, rlpEncode(append(fields, uint64(v), r, s))...), nil
+}
@@ -0,0 +1,86 @@
+package oneinch
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"golang.org/x/crypto/sha3"
+)
+
+type Wallet struct {
+	key     *secp256k1.PrivateKey
+	address string
+}
+
+func keccak256(data ...[]byte) []byte {
+	hash := sha3.NewLegacyKeccak256()
+	for _, d := range data {
+		hash.Write(d)
+	}
+	return hash.Sum(nil)
+}
+
+// reads a file that holds a hex-encoded private key (with or without the 0x prefix)
+func LoadWallet(path string) (*Wallet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewWallet(string(data))
+}
+
+func NewWallet(privateKey string) (*Wallet, error) {
+	data, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(privateKey), "0x"))
+	if err != nil || len(data) != 32 {
+		return nil, errors.New("invalid private key")
+	}
+
+	var scalar secp256k1.ModNScalar
+	if overflow := scalar.SetByteSlice(data); overflow || scalar.IsZero() {
+		return nil, errors.New("invalid private key")
+	}
+
+	key := secp256k1.NewPrivateKey(&scalar)
+	pub := key.PubKey().SerializeUncompressed()[1:] // minus the 0x04 prefix
+
+	return &Wallet{
+		key:     key,
+		address: checksumAddress(keccak256(pub)[12:]),
+	}, nil
+}
+
+// returns the EIP-55 mixed-case address
+func (wallet *Wallet) Address() string {
+	return wallet.address
+}
+
+func checksumAddress(address []byte) string {
+	lower := hex.EncodeToString(address)
+	hash := hex.EncodeToString(keccak256([]byte(lower)))
+	out := []byte(lower)
+	for i, c := range out {
+		if c >= 'a' && hash[i] >= '8' {
+			out[i] = c - 32
+		}
+	}
+	return "0x" + string(out)
+}
+
+// signs a 32-byte hash, returns (r, s, recovery id). the nonce is deterministic (RFC 6979), and s is normalized to the
+// lower half of the curve order (EIP-2).
+func sign(key *secp256k1.PrivateKey, hash []byte) (*big.Int, *big.Int, byte, error) {
+	if len(hash) != 32 {
+		return nil, nil, 0, errors.New("hash is required to be exactly 32 bytes")
+	}
+	// the compact signature is [27 + recovery id] [r] [s]
+	sig := ecdsa.SignCompact(key, hash, false)
+	if len(sig) != 65 {
+		return nil, nil, 0, errors.New("invalid signature")
+	}
+	return new(big.Int).SetBytes(sig[1:33]), new(big.Int).SetBytes(sig[33:]), sig[0] - 27, nil
+}
@@ -0,0 +1,156 @@
+package override
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/multiplier"
+	"github.com/svanas/nefertiti/session"
+)
+
+// --overrides=FILE is a JSON file with the mult, stop and dip per market, for example the file that the optimize
+// command writes. the markets that aren't in the file get the --mult, --stop and --dip of the command line. we
+// re-read the file whenever it changes, so that you can edit it while the bot is running.
+
+type (
+	Params struct {
+		Mult float64 `json:"mult,omitempty"`
+		Stop float64 `json:"stop,omitempty"`
+		Dip  float64 `json:"dip,omitempty"`
+	}
+	Overrides map[string]Params // market -> params
+)
+
+var (
+	mutex    sync.Mutex
+	cache    Overrides
+	cachedAt time.Time // the modification time of the file that we've read
+)
+
+// --overrides=FILE (optional)
+func File() string {
+	return flag.Get("overrides").String()
+}
+
+func Read(name string) (Overrides, error) {
+	out := make(Overrides)
+	data, err := os.ReadFile(name)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return out, nil
+		}
+		return nil, errors.Wrap(err, 1)
+	}
+	if err = json.Unmarshal(data, &out); err != nil {
+		return nil, errors.Errorf("%s is invalid: %v", name, err)
+	}
+	for market, params := range out {
+		if params.Mult != 0 && (params.Mult <= 1 || params.Mult >= 2) {
+			return nil, errors.Errorf("%s is invalid. mult %v of %s is not in the 1..2 range", name, params.Mult, market)
+		}
+		if params.Stop != 0 && (params.Stop <= 0 || params.Stop >= 1) {
+			return nil, errors.Errorf("%s is invalid. stop %v of %s is not in the 0..1 range", name, params.Stop, market)
+		}
+		if params.Dip < 0 || params.Dip >= 100 {
+			return nil, errors.Errorf("%s is invalid. dip %v of %s is not in the 0..99 range", name, params.Dip, market)
+		}
+	}
+	return out, nil
+}
+
+func (overrides Overrides) Write(name string) error {
+	data, err := json.MarshalIndent(overrides, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, 1)
+	}
+	if err = os.WriteFile(name+".tmp", data, 0600); err != nil {
+		return errors.Wrap(err, 1)
+	}
+	return os.Rename(name+".tmp", name)
+}
+
+func format(value float64) string {
+	if value == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// audit adds the differences between the old and the new file to the audit trail
+func audit(old, new Overrides) {
+	markets := make(map[string]bool)
+	for market := range old {
+		markets[market] = true
+	}
+	for market := range new {
+		markets[market] = true
+	}
+	for market := range markets {
+		session.Audit("file", "mult["+market+"]", format(old[market].Mult), format(new[market].Mult))
+		session.Audit("file", "stop["+market+"]", format(old[market].Stop), format(new[market].Stop))
+		session.Audit("file", "dip["+market+"]", format(old[market].Dip), format(new[market].Dip))
+	}
+}
+
+// Get returns the overrides of a market, or nil if there are none
+func Get(market string) *Params {
+	name := File()
+	if name == "" {
+		return nil
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if info, err := os.Stat(name); err == nil && !info.ModTime().Equal(cachedAt) {
+		overrides, err := Read(name)
+		if err != nil {
+			// we keep on using the last file that was valid
+			log.Printf("[ERROR] %v", err)
+		} else {
+			if cache != nil {
+				audit(cache, overrides)
+			}
+			cache = overrides
+		}
+		cachedAt = info.ModTime()
+	}
+
+	for key, params := range cache {
+		if strings.EqualFold(key, market) {
+			return &params
+		}
+	}
+	return nil
+}
+
+// Mult returns the mult of a market, or def if the market doesn't have one
+func Mult(market string, def multiplier.Mult) multiplier.Mult {
+	if params := Get(market); params != nil && params.Mult != 0 {
+		return multiplier.Mult(params.Mult)
+	}
+	return def
+}
+
+// Stop returns the stop of a market, or def if the market doesn't have one
+func Stop(market string, def multiplier.Mult) multiplier.Mult {
+	if params := Get(market); params != nil && params.Stop != 0 {
+		return multiplier.Mult(params.Stop)
+	}
+	return def
+}
+
+// Dip returns the dip of a market, or def if the market doesn't have one
+func Dip(market string, def float64) float64 {
+	if params := Get(market); params != nil && params.Dip != 0 {
+		return params.Dip
+	}
+	return def
+}
@@ -3,6 +3,7 @@ package precision
 import (
 	"fmt"
 	"math"
+	"regexp"
 	"strconv"
 	"strings"
 )
@@ -10,12 +11,10 @@ import (
 func Parse(value string, def int) int {
 	i := strings.Index(value, ".")
 	if i > -1 {
-		n := i + 1
-		for n < len(value) {
-			if string(value[n]) != "0" {
-				return n - i
-			}
-			n++
+		// the last non-zero digit, so that a tick size of 0.25 has 2 decimals (and not 1)
+		n := len(strings.TrimRight(value, "0")) - 1
+		if n > i {
+			return n - i
 		}
 		return 0
 	}
@@ -39,6 +38,12 @@ func Format(prec int) string {
 	return out
 }
 
+// Tick returns the tick size that goes with a number of decimals, for example: 0.01 for 2
+func Tick(prec int) float64 {
+	out, _ := strconv.ParseFloat(Format(prec), 64)
+	return out
+}
+
 func Round(value float64, prec int) float64 {
 	out, _ := strconv.ParseFloat(fmt.Sprintf("%.[2]*[1]f", value, prec), 64)
 	return out
@@ -60,3 +65,56 @@ func Ceil(value float64, prec int) float64 {
 	pow := math.Pow(10, float64(prec))
 	return math.Ceil((value * pow)) / pow
 }
+
+// RoundTick rounds a value to the nearest multiple of a tick size. unlike Round, the tick size doesn't need to be a
+// power of ten, for example: 0.05 or 0.25. a tick size of zero leaves the value as-is.
+func RoundTick(value, tick float64) float64 {
+	if tick <= 0 {
+		return value
+	}
+	return Round(math.Round(value/tick)*tick, decimals(tick))
+}
+
+// FloorTick rounds a value down to a multiple of a tick size
+func FloorTick(value, tick float64) float64 {
+	if tick <= 0 {
+		return value
+	}
+	return Round(math.Floor((value/tick)+1e-9)*tick, decimals(tick))
+}
+
+// CeilTick rounds a value up to a multiple of a tick size
+func CeilTick(value, tick float64) float64 {
+	if tick <= 0 {
+		return value
+	}
+	return Round(math.Ceil((value/tick)-1e-9)*tick, decimals(tick))
+}
+
+// the number of decimals in a tick size, so that 0.1 * 3 is 0.3 (and not 0.30000000000000004)
+func decimals(tick float64) int {
+	return Parse(strconv.FormatFloat(tick, 'f', -1, 64), 0)
+}
+
+// FormatFloat formats a price (or size) with prec decimals, never in scientific notation. if prec is negative, then
+// we use the smallest number of decimals necessary to represent the value.
+func FormatFloat(value float64, prec int) string {
+	if prec < 0 {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	return strconv.FormatFloat(value, 'f', prec, 64)
+}
+
+var scientific = regexp.MustCompile(`(:\s*)(-?[0-9]+(?:\.[0-9]+)?[eE][-+]?[0-9]+)(\s*[,}\]]|\s*$)`)
+
+// ExpandJSON rewrites the numbers in scientific notation (for example: 1e-07) in a JSON document to fixed-point notation.
+func ExpandJSON(data string) string {
+	return scientific.ReplaceAllStringFunc(data, func(match string) string {
+		parts := scientific.FindStringSubmatch(match)
+		value, err := strconv.ParseFloat(parts[2], 64)
+		if err != nil {
+			return match
+		}
+		return parts[1] + FormatFloat(value, -1) + parts[3]
+	})
+}
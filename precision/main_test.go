@@ -0,0 +1,55 @@
+package precision
+
+import (
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		value string
+		def   int
+		want  int
+	}{
+		{"0.01", 8, 2},
+		{"0.01000000", 8, 2},
+		{"0.25", 8, 2},
+		{"0.5", 8, 1},
+		{"1", 8, 0},
+		{"1.00000000", 8, 0},
+		{"10", 8, 8},
+	}
+	for _, test := range tests {
+		if got := Parse(test.value, test.def); got != test.want {
+			t.Errorf("Parse(%q, %d) failed, got: %v, want: %v.", test.value, test.def, got, test.want)
+		}
+	}
+}
+
+func TestTick(t *testing.T) {
+	tests := []struct {
+		name  string
+		fn    func(value, tick float64) float64
+		value float64
+		tick  float64
+		want  float64
+	}{
+		{"RoundTick", RoundTick, 1.23, 0.05, 1.25},
+		{"RoundTick", RoundTick, 1.22, 0.05, 1.2},
+		{"RoundTick", RoundTick, 0.3, 0.1, 0.3},
+		{"RoundTick", RoundTick, 101.3, 0.25, 101.25},
+		{"RoundTick", RoundTick, 1.23, 0, 1.23},
+		{"FloorTick", FloorTick, 1.24, 0.05, 1.2},
+		{"FloorTick", FloorTick, 1.25, 0.05, 1.25},
+		{"FloorTick", FloorTick, 0.3, 0.1, 0.3},
+		{"FloorTick", FloorTick, 101.49, 0.25, 101.25},
+		{"CeilTick", CeilTick, 1.21, 0.05, 1.25},
+		{"CeilTick", CeilTick, 1.25, 0.05, 1.25},
+		{"CeilTick", CeilTick, 0.3, 0.1, 0.3},
+		{"CeilTick", CeilTick, 101.01, 0.25, 101.25},
+	}
+	for _, test := range tests {
+		if got := test.fn(test.value, test.tick); got != test.want {
+			t.Errorf("%s(%v, %v) failed, got: %v, want: %v.", test.name, test.value, test.tick, got, test.want)
+		}
+	}
+}
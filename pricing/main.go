@@ -1,13 +1,14 @@
 package pricing
 
 import (
-	"fmt"
-	"strconv"
-
 	"github.com/svanas/nefertiti/multiplier"
+	"github.com/svanas/nefertiti/precision"
 )
 
-func Multiply(price float64, mult multiplier.Mult, prec int) float64 {
+// MultiplyTick multiplies a price, and then rounds the outcome to a multiple of the tick size. the tick size doesn't
+// need to be a power of ten, for example: 0.05 or 0.25. if the rounding undoes the multiplication, then we keep
+// multiplying until the outcome is (at least) one tick away from the price we started with.
+func MultiplyTick(price float64, mult multiplier.Mult, tick float64) float64 {
 	var (
 		multiplied float64
 		rounded    float64
@@ -15,7 +16,7 @@ func Multiply(price float64, mult multiplier.Mult, prec int) float64 {
 	multiplied = price * float64(mult)
 	if multiplied != 0 {
 		for {
-			rounded, _ = strconv.ParseFloat(fmt.Sprintf("%.[2]*[1]f", multiplied, prec), 64)
+			rounded = precision.RoundTick(multiplied, tick)
 			if (mult > 1 && rounded > price) || (mult < 1 && rounded < price) || (mult == 1) {
 				break
 			} else {
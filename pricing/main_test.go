@@ -0,0 +1,30 @@
+package pricing
+
+import (
+	"testing"
+
+	"github.com/svanas/nefertiti/multiplier"
+)
+
+func TestMultiplyTick(t *testing.T) {
+	tests := []struct {
+		price float64
+		mult  multiplier.Mult
+		tick  float64
+		want  float64
+	}{
+		{100, 1.05, 0.01, 105},
+		{100, 1.05, 0.25, 105},
+		{100, 0.95, 0.25, 95},
+		{1.23, 1.001, 0.05, 1.25}, // rounds up to the next tick
+		{1.23, 0.999, 0.05, 1.2},  // rounds down to the previous tick
+		{0.1, 1.001, 0.1, 0.2},    // rounding would undo the multiplication, so we move one tick up
+		{0.1, 0.999, 0.01, 0.09},  // rounding would undo the multiplication, so we move one tick down
+		{100, 1, 0.25, 100},
+	}
+	for _, test := range tests {
+		if got := MultiplyTick(test.price, test.mult, test.tick); got != test.want {
+			t.Errorf("MultiplyTick(%v, %v, %v) failed, got: %v, want: %v.", test.price, test.mult, test.tick, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+// Package ratelimit keeps track of the rate limit headers that the exchanges send back to us.
+package ratelimit
+
+import (
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/svanas/nefertiti/flag"
+)
+
+type Header struct {
+	Exchange  string    `json:"exchange"`
+	Limit     string    `json:"limit,omitempty"`     // the number of requests (or weight) we are allowed to make
+	Remaining string    `json:"remaining,omitempty"` // the number of requests (or weight) we have left
+	Used      string    `json:"used,omitempty"`      // the number of requests (or weight) we have used
+	Reset     string    `json:"reset,omitempty"`     // when (or in how many seconds) our budget resets
+	Time      time.Time `json:"time"`                // when we received these headers
+}
+
+// the response headers we look for, in order of preference. every exchange has its own idea about what to call them.
+var (
+	limitHeaders     = []string{"X-RateLimit-Limit", "X-Ratelimit-Limit", "Gw-Ratelimit-Limit", "RateLimit-Limit"}
+	remainingHeaders = []string{"X-RateLimit-Remaining", "X-Ratelimit-Remaining", "Gw-Ratelimit-Remaining", "RateLimit-Remaining", "Remaining-Req"}
+	usedHeaders      = []string{"X-MBX-USED-WEIGHT-1M", "X-MBX-USED-WEIGHT", "X-RateLimit-Used"}
+	resetHeaders     = []string{"X-RateLimit-Reset", "X-Ratelimit-Reset", "Gw-Ratelimit-Reset", "RateLimit-Reset", "Retry-After"}
+)
+
+var (
+	mutex   sync.Mutex
+	headers = make(map[string]Header) // per exchange
+)
+
+func first(header http.Header, names []string) string {
+	for _, name := range names {
+		if value := header.Get(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// Capture remembers the rate limit headers of a response we received from an exchange.
+func Capture(exchange string, header http.Header) {
+	out := Header{
+		Exchange:  exchange,
+		Limit:     first(header, limitHeaders),
+		Remaining: first(header, remainingHeaders),
+		Used:      first(header, usedHeaders),
+		Reset:     first(header, resetHeaders),
+		Time:      time.Now(),
+	}
+	if out.Limit == "" && out.Remaining == "" && out.Used == "" && out.Reset == "" {
+		return
+	}
+
+	mutex.Lock()
+	headers[exchange] = out
+	mutex.Unlock()
+
+	if flag.Debug() {
+		var fields []string
+		if out.Limit != "" {
+			fields = append(fields, "limit="+out.Limit)
+		}
+		if out.Remaining != "" {
+			fields = append(fields, "remaining="+out.Remaining)
+		}
+		if out.Used != "" {
+			fields = append(fields, "used="+out.Used)
+		}
+		if out.Reset != "" {
+			fields = append(fields, "reset="+out.Reset)
+		}
+		log.Printf("[DEBUG] %s rate limit: %s", exchange, strings.Join(fields, ", "))
+	}
+}
+
+// Get returns the rate limit headers we have last received, per exchange.
+func Get() []Header {
+	mutex.Lock()
+	defer mutex.Unlock()
+	var out []Header
+	for _, header := range headers {
+		out = append(out, header)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Exchange < out[j].Exchange
+	})
+	return out
+}
+
+// Transport captures the rate limit headers of every response before it hands the response to the caller.
+type Transport struct {
+	exchange string
+	inner    http.RoundTripper
+}
+
+func NewTransport(exchange string, inner http.RoundTripper) *Transport {
+	if inner == nil {
+		inner = http.DefaultTransport
+	}
+	return &Transport{exchange: exchange, inner: inner}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.inner.RoundTrip(req)
+	if err == nil {
+		Capture(t.exchange, resp.Header)
+	}
+	return resp, err
+}
@@ -0,0 +1,135 @@
+package session
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	filemutex "github.com/alexflint/go-filemutex"
+)
+
+// the audit trail is the journal of every settings change at runtime: a new multiplier, a market added to the hold
+// list, a loop that got paused. every change has a timestamp and a source, so that afterwards you can explain why
+// the bot did what it did.
+
+type Change struct {
+	Time   time.Time `json:"time"`
+	Source string    `json:"source"` // for example: api, file, signal
+	Name   string    `json:"name"`   // the flag that changed
+	From   string    `json:"from,omitempty"`
+	To     string    `json:"to,omitempty"`
+	Run    string    `json:"run,omitempty"` // the run ID (and label) of the loop that changed
+}
+
+// we keep the last so many changes
+const auditMax = 1000
+
+const auditFile = "audit.json"
+
+var auditMutex sync.Mutex
+
+// the values of the flags with these words in their name never go into the journal. better one flag too many than
+// one too few, so we redact every key (api-key, coingecko-key, twitter-consumer-key, etc) and not just the ones we know.
+var auditSecrets = []string{"secret", "key", "token", "password", "passphrase", "wallet"}
+
+func auditRedact(name, value string) string {
+	if value == "" {
+		return value
+	}
+	name = strings.ToLower(name)
+	for _, secret := range auditSecrets {
+		if strings.Contains(name, secret) {
+			return "********"
+		}
+	}
+	return value
+}
+
+func readChanges() ([]Change, error) {
+	var out []Change
+	data, err := ReadFile(GetSessionFile(auditFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return out, nil
+		}
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Audit adds a settings change to the journal. we never return an error, because a change that has been made
+// shouldn't fail on its journal.
+func Audit(source, name, from, to string) {
+	if from == to {
+		return
+	}
+
+	change := Change{
+		Time:   time.Now(),
+		Source: source,
+		Name:   name,
+		From:   auditRedact(name, from),
+		To:     auditRedact(name, to),
+		Run:    RunTag(),
+	}
+	log.Printf("[INFO] %s changed %s from %q to %q\n", change.Source, change.Name, change.From, change.To)
+
+	auditMutex.Lock()
+	defer auditMutex.Unlock()
+
+	if err := func() error {
+		// the loops that share this session dir share this journal, too
+		lock, err := filemutex.New(GetSessionFile(auditFile + ".lock"))
+		if err != nil {
+			return err
+		}
+		if err = lock.Lock(); err != nil {
+			return err
+		}
+		defer lock.Unlock()
+
+		journal, err := readChanges()
+		if err != nil {
+			return err
+		}
+		journal = append(journal, change)
+		if len(journal) > auditMax {
+			journal = journal[len(journal)-auditMax:]
+		}
+		data, err := json.Marshal(journal)
+		if err != nil {
+			return err
+		}
+		return WriteFile(GetSessionFile(auditFile), data)
+	}(); err != nil {
+		log.Printf("[ERROR] %v", err)
+	}
+}
+
+// Changes returns the settings changes since a point in time, oldest first
+func Changes(since time.Time) ([]Change, error) {
+	auditMutex.Lock()
+	defer auditMutex.Unlock()
+
+	journal, err := readChanges()
+	if err != nil {
+		return nil, err
+	}
+
+	var out []Change
+	for _, change := range journal {
+		if change.Time.After(since) {
+			// the journal might be older than the list of secrets, so we redact them (again) on the way out
+			change.From = auditRedact(change.Name, change.From)
+			change.To = auditRedact(change.Name, change.To)
+			out = append(out, change)
+		}
+	}
+	return out, nil
+}
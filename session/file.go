@@ -0,0 +1,135 @@
+package session
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/svanas/nefertiti/errors"
+)
+
+// every file we write ends with a checksum, so we can tell a complete file from a file that got truncated mid-write.
+const checksumPrefix = "\n#sha256:"
+
+var ErrCorrupt = errors.New("session file is corrupt")
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// WriteFile writes data to a temp file, then renames it into place. The previous version is kept as a backup.
+func WriteFile(name string, data []byte) error {
+	// a unique temp file in the same directory, so that two writers never share a temp file and the rename stays on one filesystem
+	file, err := os.CreateTemp(filepath.Dir(name), filepath.Base(name)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmp := file.Name()
+
+	if _, err = file.Write(append(append([]byte{}, data...), []byte(checksumPrefix+checksum(data))...)); err == nil {
+		err = file.Sync()
+	}
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+
+	// the live file stays in place until the rename below replaces it, so there is never a moment without one
+	if err = backup(name); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+
+	return os.Rename(tmp, name)
+}
+
+// backup hard-links (or, where links are not supported, copies) the live file to name.bak. A corrupt file does not replace a good backup.
+func backup(name string) error {
+	if _, err := readFile(name); err != nil {
+		return nil
+	}
+
+	bak := name + ".bak"
+	if err := os.Remove(bak); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.Link(name, bak); err == nil {
+		return nil
+	}
+
+	src, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.CreateTemp(filepath.Dir(name), filepath.Base(bak)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(dst, src); err == nil {
+		err = dst.Sync()
+	}
+	if cerr := dst.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(dst.Name())
+		return err
+	}
+
+	return os.Rename(dst.Name(), bak)
+}
+
+// ReadFile reads a file that was written by WriteFile. A corrupt (or missing) file is restored from its backup.
+func ReadFile(name string) ([]byte, error) {
+	data, err := readFile(name)
+	if err == nil {
+		return data, nil
+	}
+
+	backup, berr := readFile(name + ".bak")
+	if berr == nil {
+		if errors.Is(err, ErrCorrupt) {
+			log.Printf("[WARN] %s is corrupt. Restoring from backup.", name)
+		}
+		return backup, WriteFile(name, backup)
+	}
+
+	if errors.Is(err, ErrCorrupt) {
+		log.Printf("[WARN] %s is corrupt. Removing.", name)
+		os.Remove(name)
+	}
+
+	return nil, err
+}
+
+func readFile(name string) ([]byte, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	i := bytes.LastIndex(data, []byte(checksumPrefix))
+	if i == -1 {
+		// a file from a previous version, before we started to write checksums
+		if len(data) == 0 {
+			return nil, ErrCorrupt
+		}
+		return data, nil
+	}
+
+	if checksum(data[:i]) != string(data[i+len(checksumPrefix):]) {
+		return nil, ErrCorrupt
+	}
+
+	return data[:i], nil
+}
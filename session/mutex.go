@@ -0,0 +1,52 @@
+package session
+
+import (
+	"sync"
+
+	filemutex "github.com/alexflint/go-filemutex"
+	"github.com/svanas/nefertiti/flag"
+)
+
+// Mutex serializes the requests we send to an exchange. By default, this is an in-process lock. With --shared-session,
+// we lock a file in the session dir, so that multiple processes sharing the same API key do not exceed the rate limit.
+type Mutex struct {
+	file  *filemutex.FileMutex
+	inner sync.Mutex
+}
+
+func NewMutex(name string) (*Mutex, error) {
+	if !flag.SharedSession() {
+		return &Mutex{}, nil
+	}
+	file, err := filemutex.New(GetSessionFile(name))
+	if err != nil {
+		return nil, err
+	}
+	return &Mutex{file: file}, nil
+}
+
+func (m *Mutex) Lock() error {
+	if m.file != nil {
+		return m.file.Lock()
+	}
+	m.inner.Lock()
+	return nil
+}
+
+func (m *Mutex) Unlock() error {
+	if m.file != nil {
+		return m.file.Unlock()
+	}
+	m.inner.Unlock()
+	return nil
+}
+
+// NewFileMutex always locks a file in the session dir, because the data it protects is shared with other processes
+// (for example, the approve command) regardless of --shared-session.
+func NewFileMutex(name string) (*Mutex, error) {
+	file, err := filemutex.New(GetSessionFile(name))
+	if err != nil {
+		return nil, err
+	}
+	return &Mutex{file: file}, nil
+}
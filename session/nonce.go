@@ -0,0 +1,85 @@
+package session
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"sync"
+
+	filemutex "github.com/alexflint/go-filemutex"
+)
+
+var (
+	nonceMutex   sync.Mutex
+	nonceMutexes = make(map[string]*sync.Mutex)
+)
+
+// NonceLock serializes the signed requests for an API key (across goroutines *and* processes) and hands out strictly increasing nonces.
+type NonceLock struct {
+	name  string
+	inner *sync.Mutex
+	file  *filemutex.FileMutex
+}
+
+// we never write an API key to disk. we key the nonce file by a hash of the API key instead.
+func nonceName(exchange, apiKey string) string {
+	return exchange + "." + Fingerprint(apiKey)
+}
+
+// Fingerprint returns a hash of an API key that is safe to use in a file name.
+func Fingerprint(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:8])
+}
+
+// LockNonce locks the nonce for this API key. Call Unlock after the request has been sent.
+func LockNonce(exchange, apiKey string) (*NonceLock, error) {
+	name := nonceName(exchange, apiKey)
+
+	nonceMutex.Lock()
+	inner, ok := nonceMutexes[name]
+	if !ok {
+		inner = &sync.Mutex{}
+		nonceMutexes[name] = inner
+	}
+	nonceMutex.Unlock()
+
+	inner.Lock()
+
+	file, err := filemutex.New(GetSessionFile(name + ".lock"))
+	if err == nil {
+		err = file.Lock()
+	}
+	if err != nil {
+		inner.Unlock()
+		return nil, err
+	}
+
+	return &NonceLock{name: name, inner: inner, file: file}, nil
+}
+
+// Next returns a nonce that is at least min, and higher than any nonce we have handed out before.
+func (l *NonceLock) Next(min int64) (int64, error) {
+	var last int64
+	if data, err := ReadFile(GetSessionFile(l.name + ".nonce")); err == nil {
+		last, _ = strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	}
+
+	out := min
+	if out <= last {
+		out = last + 1
+	}
+
+	if err := WriteFile(GetSessionFile(l.name+".nonce"), []byte(strconv.FormatInt(out, 10))); err != nil {
+		return 0, err
+	}
+
+	return out, nil
+}
+
+func (l *NonceLock) Unlock() {
+	l.file.Unlock()
+	l.file.Close()
+	l.inner.Unlock()
+}
@@ -0,0 +1,55 @@
+package session
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// the pause switch stops the buy side of every loop that shares this session dir, while the exits continue to be
+// managed. it is a plain file, so that `touch pause` in the session dir works as well as the pause command does.
+
+const pauseFile = "pause"
+
+type Pause struct {
+	Since  time.Time `json:"since"`
+	Reason string    `json:"reason,omitempty"`
+}
+
+// Paused returns the pause switch, or nil if we're not paused
+func Paused() (*Pause, error) {
+	name := GetSessionFile(pauseFile)
+	info, err := os.Stat(name)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return &Pause{Since: info.ModTime(), Reason: strings.TrimSpace(string(data))}, nil
+}
+
+// SetPaused flips the pause switch. source is where the change came from, for example: cli or api.
+func SetPaused(paused bool, reason, source string) error {
+	old, err := Paused()
+	if err != nil {
+		return err
+	}
+	name := GetSessionFile(pauseFile)
+	if paused {
+		if err = os.WriteFile(name, []byte(reason), 0600); err != nil {
+			return err
+		}
+	} else if old != nil {
+		if err = os.Remove(name); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	Audit(source, "pause", strconv.FormatBool(old != nil), strconv.FormatBool(paused))
+	return nil
+}
@@ -0,0 +1,33 @@
+package session
+
+import (
+	"strings"
+
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/uuid"
+)
+
+// every process gets a run ID, so that you can tell apart the loops that run simultaneously. --label=X adds a name
+// of your own to it.
+var runId = uuid.New().Short()[:8]
+
+func RunId() string {
+	return runId
+}
+
+// --label=X (optional)
+func RunLabel() string {
+	return strings.TrimSpace(flag.Get("label").String())
+}
+
+// RunTag returns the user and the label plus the run ID, for example: alice/btc-dip#k3v9x2ab
+func RunTag() string {
+	out := runId
+	if label := RunLabel(); label != "" {
+		out = label + "#" + out
+	}
+	if user, err := flag.User(); err == nil && user != "" {
+		out = user + "/" + out
+	}
+	return out
+}
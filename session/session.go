@@ -1,10 +1,13 @@
 package session
 
 import (
-	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 	"time"
+
+	"github.com/svanas/nefertiti/flag"
 )
 
 const (
@@ -14,6 +17,10 @@ const (
 func GetSessionDir() string {
 	tmp := os.TempDir()
 	dir := filepath.Join(tmp, "com.cryptotrader.session")
+	// every --user gets a session of their own
+	if user, err := flag.User(); err == nil && user != "" {
+		dir = filepath.Join(dir, "users", user)
+	}
 	os.MkdirAll(dir, os.ModePerm)
 	return dir
 }
@@ -26,8 +33,112 @@ func GetTempFileName(name, ext string) string {
 	return filepath.Join(GetSessionDir(), (name + ext))
 }
 
+// Keyed returns this session file name, keyed by a fingerprint of the API key we are using (if any). Use this for the
+// exchanges that meter requests per API key, so two key profiles on the same exchange do not share the same throttle.
+// Exchanges that meter per IP address (for example, Binance) share one throttle between every key on this machine.
+func Keyed(name string) string {
+	apiKey := flag.Get("api-key").String()
+	if apiKey == "" {
+		return name
+	}
+	ext := filepath.Ext(name)
+	return strings.TrimSuffix(name, ext) + "." + Fingerprint(apiKey) + ext
+}
+
+// we write the cached last-request timestamps back to disk at most once per this interval
+const FlushInterval = time.Minute
+
+type lastRequest struct {
+	value   time.Time
+	flushed time.Time
+	dirty   bool
+}
+
+var (
+	cache      = make(map[string]*lastRequest)
+	cacheMutex sync.Mutex
+)
+
 func GetLastRequest(exchange string) (*time.Time, error) {
-	data, err := ioutil.ReadFile(GetSessionFile(exchange))
+	if flag.SharedSession() {
+		return readTime(exchange)
+	}
+
+	cacheMutex.Lock()
+	defer cacheMutex.Unlock()
+
+	entry, ok := cache[exchange]
+	if !ok {
+		value, err := readTime(exchange)
+		if err != nil || value == nil {
+			return nil, err
+		}
+		entry = &lastRequest{value: *value, flushed: time.Now()}
+		cache[exchange] = entry
+	}
+
+	out := entry.value
+	return &out, nil
+}
+
+func SetLastRequest(exchange string, value time.Time) error {
+	if flag.SharedSession() {
+		return writeTime(exchange, value)
+	}
+
+	cacheMutex.Lock()
+	defer cacheMutex.Unlock()
+
+	entry, ok := cache[exchange]
+	if !ok {
+		entry = &lastRequest{}
+		cache[exchange] = entry
+	}
+	entry.value = value
+	entry.dirty = true
+
+	if time.Since(entry.flushed) < FlushInterval {
+		return nil
+	}
+	return entry.flush(exchange)
+}
+
+// Flush writes the cached last-request timestamps to disk.
+func Flush() error {
+	cacheMutex.Lock()
+	defer cacheMutex.Unlock()
+
+	for exchange, entry := range cache {
+		if entry.dirty {
+			if err := entry.flush(exchange); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (entry *lastRequest) flush(exchange string) error {
+	if err := writeTime(exchange, entry.value); err != nil {
+		return err
+	}
+	entry.flushed = time.Now()
+	entry.dirty = false
+	return nil
+}
+
+// GetCursor returns the timestamp we have last seen in an incremental fetch, or nil if we have never seen one.
+func GetCursor(name string) (*time.Time, error) {
+	return readTime(name)
+}
+
+func SetCursor(name string, value time.Time) error {
+	return writeTime(name, value)
+}
+
+func readTime(name string) (*time.Time, error) {
+	data, err := ReadFile(GetSessionFile(name))
 	if err == nil {
 		for len(data) > 0 {
 			out, err := time.Parse(RFC3339Milli, string(data))
@@ -45,16 +156,6 @@ func GetLastRequest(exchange string) (*time.Time, error) {
 	return nil, nil
 }
 
-func SetLastRequest(exchange string, value time.Time) error {
-	var (
-		err error
-		str string
-	)
-	str = GetSessionFile(exchange)
-	if _, err = os.Stat(str); err == nil {
-		if err = os.Truncate(str, 0); err != nil {
-			return err
-		}
-	}
-	return ioutil.WriteFile(str, []byte(value.Format(RFC3339Milli)), 0600)
+func writeTime(name string, value time.Time) error {
+	return WriteFile(GetSessionFile(name), []byte(value.Format(RFC3339Milli)))
 }
@@ -3,7 +3,7 @@ package signals
 
 import (
 	"encoding/json"
-	"io/ioutil"
+	"io"
 	"log"
 	"net/http"
 	"strings"
@@ -208,7 +208,7 @@ func (self *CryptoBaseScanner) get(
 
 	// read the body of the http message into a byte array
 	var body []byte
-	if body, err = ioutil.ReadAll(resp.Body); err != nil {
+	if body, err = io.ReadAll(resp.Body); err != nil {
 		return err
 	}
 	defer resp.Body.Close()
@@ -265,7 +265,7 @@ func (self *CryptoBaseScanner) get(
 
 func (self *CryptoBaseScanner) Init() error {
 	if self.apiKey == "" {
-		if flag.Listen() {
+		if !flag.Interactive() {
 			return errors.New("missing argument: crypto-base-scanner-key")
 		}
 		data, err := passphrase.Read("cryptobasescanner.com API key")
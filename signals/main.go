@@ -24,6 +24,7 @@ func New() *Signals {
 	out = append(out, NewListings())
 	out = append(out, NewMiningHamster())
 	out = append(out, NewQualitySignals())
+	out = append(out, NewSpike())
 	out = append(out, NewVolume())
 	return &out
 }
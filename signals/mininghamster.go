@@ -63,7 +63,7 @@ func (self *MiningHamster) get(exchange model.Exchange, validity time.Duration)
 
 func (self *MiningHamster) Init() error {
 	if self.apiKey == "" {
-		if flag.Listen() {
+		if !flag.Interactive() {
 			return errors.New("missing argument: mining-hamster-key")
 		}
 		data, err := passphrase.Read("MiningHamster API key")
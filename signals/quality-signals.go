@@ -4,7 +4,7 @@ package signals
 import (
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"log"
 	"net/http"
 	"strconv"
@@ -134,7 +134,7 @@ func (self *CryptoQualitySignals) get(exchange model.Exchange, validity time.Dur
 
 	// read the body of the http message into a byte array
 	var body []byte
-	if body, err = ioutil.ReadAll(resp.Body); err != nil {
+	if body, err = io.ReadAll(resp.Body); err != nil {
 		return err
 	}
 	defer resp.Body.Close()
@@ -197,7 +197,7 @@ func (self *CryptoQualitySignals) Init() error {
 		API_KEY_FREE = "FREE"
 	)
 	if self.apiKey == "" {
-		if flag.Listen() {
+		if !flag.Interactive() {
 			return errors.New("missing argument: quality-signals-key")
 		}
 		data, err := passphrase.Read("cryptoqualitysignals.com API key")
@@ -0,0 +1,217 @@
+//lint:file-ignore ST1006 receiver name should be a reflection of its identity; don't use generic names such as "this" or "self"
+package signals
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/model"
+)
+
+// Spike is a built-in volume-spike detector. Every iteration, we sample the 24h volume of every market. Once the
+// volume exceeds its rolling average by --factor (defaults to 3), while the price went up by less than --max-up
+// percent (defaults to 5), we emit a buy call.
+
+type sample struct {
+	Volume float64
+	Price  float64
+}
+
+type Spike struct {
+	client  interface{}
+	samples map[string][]sample // market -> samples, oldest first
+	cache   []Listing
+}
+
+func (self *Spike) Init() error {
+	return nil
+}
+
+func (self *Spike) GetName() string {
+	return "spike"
+}
+
+func (self *Spike) GetValidity() (time.Duration, error) {
+	return 1 * time.Hour, nil
+}
+
+func (self *Spike) GetRateLimit() time.Duration {
+	return 15 * time.Minute
+}
+
+func (self *Spike) GetOrderType() model.OrderType {
+	return model.MARKET
+}
+
+func (self *Spike) getClient(exchange model.Exchange, sandbox bool) (interface{}, error) {
+	if self.client == nil {
+		var err error
+		self.client, err = exchange.GetClient(model.PUBLIC, sandbox)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return self.client, nil
+}
+
+func (self *Spike) getArgs() (factor, maxUp float64, window int, err error) {
+	factor = 3
+	maxUp = 5
+	window = 24
+	if flg := flag.Get("factor"); flg.Exists {
+		if factor, err = flg.Float64(); err != nil || factor <= 1 {
+			return 0, 0, 0, fmt.Errorf("factor %v is invalid", flg)
+		}
+	}
+	if flg := flag.Get("max-up"); flg.Exists {
+		if maxUp, err = flg.Float64(); err != nil {
+			return 0, 0, 0, fmt.Errorf("max-up %v is invalid", flg)
+		}
+	}
+	if flg := flag.Get("window"); flg.Exists {
+		var i int64
+		if i, err = flg.Int64(); err != nil || i < 2 {
+			return 0, 0, 0, fmt.Errorf("window %v is invalid", flg)
+		}
+		window = int(i)
+	}
+	return factor, maxUp, window, nil
+}
+
+func (self *Spike) GetMarkets(
+	exchange model.Exchange,
+	quote model.Assets,
+	btcVolumeMin float64,
+	valid time.Duration,
+	sandbox, debug bool,
+	ignore []string,
+) (model.Markets, error) {
+	factor, maxUp, window, err := self.getArgs()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := self.getClient(exchange, sandbox)
+	if err != nil {
+		return nil, err
+	}
+
+	markets, err := exchange.GetMarkets(true, sandbox, ignore)
+	if err != nil {
+		return nil, err
+	}
+
+	if self.samples == nil {
+		self.samples = make(map[string][]sample)
+	}
+
+	for _, market := range markets {
+		if !quote.HasAsset(market.Quote) || exchange.IsLeveragedToken(market.Base) {
+			continue
+		}
+
+		var stats *model.Stats
+		if stats, err = exchange.Get24h(client, market.Name); err != nil {
+			log.Printf("[ERROR] %v\n", err)
+			continue
+		}
+		if btcVolumeMin > 0 && stats.BtcVolume > 0 && stats.BtcVolume < btcVolumeMin {
+			continue
+		}
+
+		var ticker float64
+		if ticker, err = exchange.GetTicker(client, market.Name); err != nil {
+			log.Printf("[ERROR] %v\n", err)
+			continue
+		}
+
+		samples := self.samples[market.Name]
+
+		// we need a full window before we can tell what normal volume looks like
+		if len(samples) >= window {
+			var avg float64
+			for _, s := range samples {
+				avg += s.Volume
+			}
+			avg = avg / float64(len(samples))
+
+			up := 0.0
+			if samples[0].Price > 0 {
+				up = ((ticker - samples[0].Price) / samples[0].Price) * 100
+			}
+
+			if debug {
+				log.Printf("[DEBUG] %s volume %f (avg %f), price %+.2f%%", market.Name, stats.BtcVolume, avg, up)
+			}
+
+			if avg > 0 && stats.BtcVolume > (avg*factor) && up < maxUp {
+				if self.indexByMarket(market.Name) == -1 {
+					self.cache = append(self.cache, Listing{
+						Market:  market.Name,
+						Price:   ticker,
+						Created: time.Now(),
+					})
+				}
+			}
+		}
+
+		samples = append(samples, sample{
+			Volume: stats.BtcVolume,
+			Price:  ticker,
+		})
+		if len(samples) > window {
+			samples = samples[len(samples)-window:]
+		}
+		self.samples[market.Name] = samples
+	}
+
+	// remove signals that are older than 1 hour
+	if valid > 0 {
+		i := 0
+		for i < len(self.cache) {
+			if time.Since(self.cache[i].Created) > valid {
+				self.cache = append(self.cache[:i], self.cache[i+1:]...)
+			} else {
+				i++
+			}
+		}
+	}
+
+	var out model.Markets
+	for _, signal := range self.cache {
+		out = append(out, signal.Market)
+	}
+
+	return out, nil
+}
+
+func (self *Spike) indexByMarket(market string) int {
+	for i, signal := range self.cache {
+		if signal.Market == market {
+			return i
+		}
+	}
+	return -1
+}
+
+func (self *Spike) GetCalls(exchange model.Exchange, market string, sandbox, debug bool) (model.Calls, error) {
+	var out model.Calls
+	for _, signal := range self.cache {
+		if strings.EqualFold(signal.Market, market) {
+			out = append(out, model.Call{
+				Buy: &model.Buy{
+					Market: market,
+					Price:  signal.Price,
+				},
+			})
+		}
+	}
+	return out, nil
+}
+
+func NewSpike() model.Channel {
+	return &Spike{}
+}
@@ -0,0 +1,50 @@
+package upbit
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+)
+
+type claims struct {
+	AccessKey    string `json:"access_key"`
+	Nonce        string `json:"nonce"`
+	QueryHash    string `json:"query_hash,omitempty"`
+	QueryHashAlg string `json:"query_hash_alg,omitempty"`
+}
+
+// sign a request, returns a HS256 JSON Web Token
+func token(accessKey, secretKey, nonce string, params url.Values) (string, error) {
+	payload := claims{
+		AccessKey: accessKey,
+		Nonce:     nonce,
+	}
+
+	// the query hash is the SHA512 of the unescaped query string
+	if len(params) > 0 {
+		query, err := url.QueryUnescape(params.Encode())
+		if err != nil {
+			return "", err
+		}
+		hash := sha512.Sum512([]byte(query))
+		payload.QueryHash = hex.EncodeToString(hash[:])
+		payload.QueryHashAlg = "SHA512"
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	encoding := base64.RawURLEncoding
+	unsigned := encoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." + encoding.EncodeToString(data)
+
+	mac := hmac.New(sha256.New, []byte(secretKey))
+	mac.Write([]byte(unsigned))
+
+	return unsigned + "." + encoding.EncodeToString(mac.Sum(nil)), nil
+}
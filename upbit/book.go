@@ -0,0 +1,66 @@
+package upbit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type BookEntry struct {
+	Price float64
+	Size  float64
+}
+
+type OrderBookUnit struct {
+	AskPrice float64 `json:"ask_price"`
+	BidPrice float64 `json:"bid_price"`
+	AskSize  float64 `json:"ask_size"`
+	BidSize  float64 `json:"bid_size"`
+}
+
+type OrderBook struct {
+	Market string          `json:"market"`
+	Units  []OrderBookUnit `json:"orderbook_units"`
+}
+
+func (book *OrderBook) Asks() []BookEntry {
+	var out []BookEntry
+	for _, unit := range book.Units {
+		out = append(out, BookEntry{Price: unit.AskPrice, Size: unit.AskSize})
+	}
+	return out
+}
+
+func (book *OrderBook) Bids() []BookEntry {
+	var out []BookEntry
+	for _, unit := range book.Units {
+		out = append(out, BookEntry{Price: unit.BidPrice, Size: unit.BidSize})
+	}
+	return out
+}
+
+func (client *Client) OrderBook(market string) (*OrderBook, error) {
+	params := url.Values{}
+	params.Add("markets", market)
+
+	var (
+		err  error
+		body []byte
+		out  []OrderBook
+	)
+	if body, err = client.call(http.MethodGet, "/orderbook", params, false, RPS_QUOTATION); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(body, &out); err != nil {
+		return nil, err
+	}
+
+	for _, book := range out {
+		if book.Market == market {
+			return &book, nil
+		}
+	}
+
+	return nil, fmt.Errorf("market %s does not exist", market)
+}
@@ -0,0 +1,135 @@
+package upbit
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/ratelimit"
+	"github.com/svanas/nefertiti/uuid"
+)
+
+const (
+	API_BASE = "https://api.upbit.com"
+	API_PATH = "/v1"
+)
+
+// the quotation api allows for 10 requests per second, the exchange api allows for 8 orders per second and 30 other requests per second.
+const (
+	RPS_QUOTATION float64 = 10
+	RPS_ORDER     float64 = 8
+	RPS_EXCHANGE  float64 = 30
+)
+
+var (
+	lastRequest   time.Time
+	BeforeRequest func(method, path string, rps float64) error = nil
+	AfterRequest  func()                                       = nil
+)
+
+func init() {
+	BeforeRequest = func(method, path string, rps float64) error {
+		elapsed := time.Since(lastRequest)
+		if elapsed.Seconds() < (float64(1) / rps) {
+			time.Sleep(time.Duration((float64(time.Second) / rps) - float64(elapsed)))
+		}
+		return nil
+	}
+	AfterRequest = func() {
+		lastRequest = time.Now()
+	}
+}
+
+type Client struct {
+	URL        string
+	accessKey  string
+	secretKey  string
+	httpClient *http.Client
+}
+
+func New(URL, accessKey, secretKey string) *Client {
+	return &Client{
+		URL,
+		accessKey,
+		secretKey,
+		&http.Client{
+			Timeout:   30 * time.Second,
+			Transport: ratelimit.NewTransport("Upbit", nil),
+		},
+	}
+}
+
+func (client *Client) do(req *http.Request) ([]byte, error) {
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+			return nil, errors.NewStatusError(resp.StatusCode, resp.Status)
+		}
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		if err, msg := IsError(body); err {
+			return body, errors.NewStatusError(resp.StatusCode, msg)
+		}
+		return body, errors.NewStatusError(resp.StatusCode, resp.Status)
+	}
+
+	return body, nil
+}
+
+func (client *Client) call(method, path string, params url.Values, auth bool, rps float64) ([]byte, error) {
+	// respect the rate limit
+	err := BeforeRequest(method, path, rps)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		AfterRequest()
+	}()
+
+	// set the endpoint for this request
+	endpoint, err := url.Parse(client.URL)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path += (API_PATH + path)
+
+	// create the request. upbit accepts query parameters on every method, including POST.
+	var body io.Reader
+	if params != nil {
+		if method == http.MethodPost {
+			body = strings.NewReader(params.Encode())
+		} else {
+			endpoint.RawQuery = params.Encode()
+		}
+	}
+	req, err := http.NewRequest(method, endpoint.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	// add autentication header
+	if auth {
+		token, err := token(client.accessKey, client.secretKey, strings.ToLower(uuid.New().Long()), params)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Add("Authorization", "Bearer "+token)
+	}
+
+	// do the request
+	return client.do(req)
+}
@@ -0,0 +1,79 @@
+package upbit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/svanas/nefertiti/precision"
+)
+
+type Market struct {
+	Market      string `json:"market"`
+	KoreanName  string `json:"korean_name"`
+	EnglishName string `json:"english_name"`
+}
+
+func (client *Client) Markets() ([]Market, error) {
+	var (
+		err  error
+		body []byte
+		out  []Market
+	)
+	if body, err = client.call(http.MethodGet, "/market/all", nil, false, RPS_QUOTATION); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(body, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// upbit markets are formatted as QUOTE-BASE, for example: KRW-BTC
+func FormatMarket(base, quote string) string {
+	return strings.ToUpper(quote) + "-" + strings.ToUpper(base)
+}
+
+func ParseMarket(market string) (string, string, error) { // -> (base, quote, error)
+	subs := strings.Split(market, "-")
+	if len(subs) == 2 {
+		return subs[1], subs[0], nil
+	}
+	return "", "", fmt.Errorf("cannot parse market %s", market)
+}
+
+// the KRW markets have a tick size that depends on the price level. the BTC markets have a fixed tick size of 1 satoshi.
+func TickSize(quote string, price float64) float64 {
+	if !strings.EqualFold(quote, "KRW") {
+		return 0.00000001
+	}
+	switch {
+	case price >= 2000000:
+		return 1000
+	case price >= 1000000:
+		return 500
+	case price >= 500000:
+		return 100
+	case price >= 100000:
+		return 50
+	case price >= 10000:
+		return 10
+	case price >= 1000:
+		return 5
+	case price >= 100:
+		return 1
+	case price >= 10:
+		return 0.1
+	case price >= 1:
+		return 0.01
+	case price >= 0.1:
+		return 0.001
+	}
+	return 0.0001
+}
+
+// round the price down to the nearest tick
+func RoundPrice(quote string, price float64) float64 {
+	return precision.FloorTick(price, TickSize(quote, price))
+}
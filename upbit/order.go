@@ -0,0 +1,248 @@
+package upbit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/svanas/nefertiti/clock"
+)
+
+type (
+	OrderSide  string
+	OrderType  string
+	OrderState string
+)
+
+const (
+	OrderSideBid OrderSide = "bid" // buy
+	OrderSideAsk OrderSide = "ask" // sell
+)
+
+const (
+	OrderTypeLimit  OrderType = "limit"
+	OrderTypePrice  OrderType = "price"  // market buy
+	OrderTypeMarket OrderType = "market" // market sell
+)
+
+const (
+	OrderStateWait   OrderState = "wait"
+	OrderStateWatch  OrderState = "watch"
+	OrderStateDone   OrderState = "done"
+	OrderStateCancel OrderState = "cancel"
+)
+
+type Order struct {
+	UUID            string     `json:"uuid"`
+	Side            OrderSide  `json:"side"`
+	OrdType         OrderType  `json:"ord_type"`
+	Price           string     `json:"price"`
+	State           OrderState `json:"state"`
+	Market          string     `json:"market"`
+	CreatedAt       string     `json:"created_at"`
+	Volume          string     `json:"volume"`
+	RemainingVolume string     `json:"remaining_volume"`
+	ExecutedVolume  string     `json:"executed_volume"`
+	PaidFee         string     `json:"paid_fee"`
+	TradesCount     int64      `json:"trades_count"`
+}
+
+func parseFloat(value string) float64 {
+	out, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0
+	}
+	return out
+}
+
+func (order *Order) GetCreatedAt() time.Time {
+	out, err := clock.Parse(order.CreatedAt, time.RFC3339)
+	if err != nil {
+		return time.Time{}
+	}
+	return out
+}
+
+func (order *Order) GetPrice() float64 {
+	return parseFloat(order.Price)
+}
+
+func (order *Order) GetVolume() float64 {
+	return parseFloat(order.Volume)
+}
+
+// upbit deducts the fee from the quote currency, so this is in quote currency
+func (order *Order) GetPaidFee() float64 {
+	return parseFloat(order.PaidFee)
+}
+
+// upbit deducts the fee from the quote currency, so the executed volume is what we got.
+func (order *Order) GetExecutedVolume() float64 {
+	return parseFloat(order.ExecutedVolume)
+}
+
+func (order *Order) ExecutedAt() float64 {
+	// market buy orders have the total (rather than the unit) price
+	if order.OrdType == OrderTypePrice {
+		executed := order.GetExecutedVolume()
+		if executed > 0 {
+			return order.GetPrice() / executed
+		}
+	}
+	return order.GetPrice()
+}
+
+func (client *Client) Order(market string, side OrderSide, orderType OrderType, volume, price float64) (*Order, error) {
+	params := url.Values{}
+	params.Add("market", market)
+	params.Add("side", string(side))
+	params.Add("ord_type", string(orderType))
+	if orderType != OrderTypePrice {
+		params.Add("volume", strconv.FormatFloat(volume, 'f', -1, 64))
+	}
+	if orderType != OrderTypeMarket {
+		params.Add("price", strconv.FormatFloat(price, 'f', -1, 64))
+	}
+
+	var (
+		err  error
+		body []byte
+		out  Order
+	)
+	if body, err = client.call(http.MethodPost, "/orders", params, true, RPS_ORDER); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(body, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+func (client *Client) CancelOrder(uuid string) error {
+	params := url.Values{}
+	params.Add("uuid", uuid)
+
+	_, err := client.call(http.MethodDelete, "/order", params, true, RPS_EXCHANGE)
+
+	return err
+}
+
+func (client *Client) GetOrder(uuid string) (*Order, error) {
+	params := url.Values{}
+	params.Add("uuid", uuid)
+
+	var (
+		err  error
+		body []byte
+		out  Order
+	)
+	if body, err = client.call(http.MethodGet, "/order", params, true, RPS_EXCHANGE); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(body, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// Orders returns the orders of a market in a state, newest first
+func (client *Client) Orders(market string, state OrderState) ([]Order, error) {
+	return client.orders(market, state, time.Time{})
+}
+
+// OrdersSince returns the orders of every market in a state, newest first. we stop paging once the orders have been
+// created before since. a zero since returns every order.
+func (client *Client) OrdersSince(state OrderState, since time.Time) ([]Order, error) {
+	return client.orders("", state, since)
+}
+
+func (client *Client) orders(market string, state OrderState, since time.Time) ([]Order, error) {
+	const limit = 100
+
+	var (
+		page   int64 = 1
+		result []Order
+	)
+
+	for {
+		params := url.Values{}
+		if market != "" {
+			params.Add("market", market)
+		}
+		params.Add("state", string(state))
+		params.Add("page", strconv.FormatInt(page, 10))
+		params.Add("limit", strconv.Itoa(limit))
+		params.Add("order_by", "desc")
+
+		var (
+			err  error
+			body []byte
+			out  []Order
+		)
+		if body, err = client.call(http.MethodGet, "/orders", params, true, RPS_EXCHANGE); err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(body, &out); err != nil {
+			return nil, err
+		}
+
+		result = append(result, out...)
+
+		if len(out) < limit {
+			break
+		}
+
+		if !since.IsZero() && out[len(out)-1].GetCreatedAt().Before(since) {
+			break
+		}
+
+		page++
+	}
+
+	return result, nil
+}
+
+type Chance struct {
+	BidFee string `json:"bid_fee"`
+	AskFee string `json:"ask_fee"`
+	Market struct {
+		ID  string `json:"id"`
+		Bid struct {
+			Currency string `json:"currency"`
+			MinTotal string `json:"min_total"`
+		} `json:"bid"`
+		Ask struct {
+			Currency string `json:"currency"`
+			MinTotal string `json:"min_total"`
+		} `json:"ask"`
+		MaxTotal string `json:"max_total"`
+	} `json:"market"`
+}
+
+// returns the minimum order value (in quote currency) for a market
+func (chance *Chance) MinTotal() float64 {
+	return parseFloat(chance.Market.Bid.MinTotal)
+}
+
+func (client *Client) Chance(market string) (*Chance, error) {
+	params := url.Values{}
+	params.Add("market", market)
+
+	var (
+		err  error
+		body []byte
+		out  Chance
+	)
+	if body, err = client.call(http.MethodGet, "/orders/chance", params, true, RPS_EXCHANGE); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(body, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
@@ -0,0 +1,75 @@
+package upbit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type Ticker struct {
+	Market            string  `json:"market"`
+	TradePrice        float64 `json:"trade_price"`
+	OpeningPrice      float64 `json:"opening_price"`
+	SignedChangeRate  float64 `json:"signed_change_rate"` // 0.01 = 1%
+	HighPrice         float64 `json:"high_price"`
+	LowPrice          float64 `json:"low_price"`
+	AccTradePrice24h  float64 `json:"acc_trade_price_24h"`
+	AccTradeVolume24h float64 `json:"acc_trade_volume_24h"`
+	Timestamp         int64   `json:"timestamp"`
+}
+
+func (client *Client) Ticker(market string) (*Ticker, error) {
+	params := url.Values{}
+	params.Add("markets", market)
+
+	var (
+		err  error
+		body []byte
+		out  []Ticker
+	)
+	if body, err = client.call(http.MethodGet, "/ticker", params, false, RPS_QUOTATION); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(body, &out); err != nil {
+		return nil, err
+	}
+
+	for _, ticker := range out {
+		if ticker.Market == market {
+			return &ticker, nil
+		}
+	}
+
+	return nil, fmt.Errorf("market %s does not exist", market)
+}
+
+// Tickers returns the tickers of the markets, asking for (at most) 100 markets per request
+func (client *Client) Tickers(markets []string) ([]Ticker, error) {
+	var out []Ticker
+	for len(markets) > 0 {
+		n := len(markets)
+		if n > 100 {
+			n = 100
+		}
+
+		params := url.Values{}
+		params.Add("markets", strings.Join(markets[:n], ","))
+		markets = markets[n:]
+
+		var (
+			err     error
+			body    []byte
+			tickers []Ticker
+		)
+		if body, err = client.call(http.MethodGet, "/ticker", params, false, RPS_QUOTATION); err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(body, &tickers); err != nil {
+			return nil, err
+		}
+		out = append(out, tickers...)
+	}
+	return out, nil
+}
@@ -0,0 +1,75 @@
+package vault
+
+import (
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/svanas/nefertiti/flag"
+	"github.com/svanas/nefertiti/passphrase"
+)
+
+// Password returns the password of the vault, from the environment or from your keyboard
+func Password(confirm bool) ([]byte, error) {
+	if env := os.Getenv(ENV_PASSWORD); env != "" {
+		return []byte(env), nil
+	}
+	if !flag.Interactive() {
+		return nil, errors.New("missing argument: vault password (or " + ENV_PASSWORD + ")")
+	}
+	out, err := passphrase.Read("vault password")
+	if err != nil {
+		return nil, err
+	}
+	if len(out) == 0 {
+		return nil, errors.New("missing argument: vault password")
+	}
+	if confirm {
+		again, err := passphrase.Read("vault password (again)")
+		if err != nil {
+			return nil, err
+		}
+		if string(again) != string(out) {
+			return nil, errors.New("vault passwords do not match")
+		}
+	}
+	return out, nil
+}
+
+// Section returns the section of the vault that holds the secrets of an exchange
+func Section(code string) string {
+	return strings.ToLower(code)
+}
+
+// Unlock decrypts the vault, and turns the secrets of an exchange (and of your notifications) into flags. the flags
+// on the command line win.
+func Unlock(code string) error {
+	if !Exists() {
+		return errors.New("vault does not exist. please run the init command")
+	}
+
+	password, err := Password(false)
+	if err != nil {
+		return err
+	}
+
+	vault, err := Open(password)
+	if err != nil {
+		return err
+	}
+
+	sections := []string{SECTION_NOTIFY}
+	if code != "" {
+		sections = append(sections, Section(code))
+	}
+
+	for _, section := range sections {
+		for name, value := range vault[section] {
+			if !flag.Get(name).Exists {
+				flag.Set(name, value)
+			}
+		}
+	}
+
+	return nil
+}
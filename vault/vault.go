@@ -0,0 +1,158 @@
+package vault
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/svanas/nefertiti/flag"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Vault holds your secrets (API keys, notification keys) per section, where a section is the code of an exchange or
+// "notify". the secrets are named after the flags that they are the value of, for example: api-key
+type Vault map[string]map[string]string
+
+const (
+	SECTION_NOTIFY = "notify"
+	// if this environment variable is set, we don't prompt for the password of the vault
+	ENV_PASSWORD = "NEFERTITI_VAULT_PASSWORD"
+)
+
+// the vault on disk: the secrets, encrypted with a key that is derived from your password
+type sealed struct {
+	Salt  []byte `json:"salt"`
+	Nonce []byte `json:"nonce"`
+	Box   []byte `json:"box"`
+}
+
+// Dir returns the directory where we keep your configuration. unlike the session directory, this one survives a
+// reboot. every --user gets a directory (and a vault) of their own.
+func Dir() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	dir = filepath.Join(dir, "nefertiti")
+	if user, err := flag.User(); err == nil && user != "" {
+		dir = filepath.Join(dir, "users", user)
+	}
+	os.MkdirAll(dir, 0700)
+	return dir
+}
+
+func File() string {
+	return filepath.Join(Dir(), "vault.json")
+}
+
+func Exists() bool {
+	_, err := os.Stat(File())
+	return err == nil
+}
+
+func deriveKey(password, salt []byte) (*[32]byte, error) {
+	data, err := scrypt.Key(password, salt, 1<<15, 8, 1, 32)
+	if err != nil {
+		return nil, err
+	}
+	var out [32]byte
+	copy(out[:], data)
+	return &out, nil
+}
+
+// Open decrypts the vault. returns an empty vault if there is none yet.
+func Open(password []byte) (Vault, error) {
+	out := make(Vault)
+
+	data, err := os.ReadFile(File())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return out, nil
+		}
+		return nil, err
+	}
+
+	var box sealed
+	if err = json.Unmarshal(data, &box); err != nil {
+		return nil, err
+	}
+	if len(box.Nonce) != 24 {
+		return nil, errors.New("vault is corrupt")
+	}
+
+	key, err := deriveKey(password, box.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	var nonce [24]byte
+	copy(nonce[:], box.Nonce)
+	plain, ok := secretbox.Open(nil, box.Box, &nonce, key)
+	if !ok {
+		return nil, errors.New("vault password is incorrect")
+	}
+
+	if err = json.Unmarshal(plain, &out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// Save encrypts the vault with a fresh salt and nonce, and writes it to disk
+func (vault Vault) Save(password []byte) error {
+	plain, err := json.Marshal(vault)
+	if err != nil {
+		return err
+	}
+
+	box := sealed{
+		Salt:  make([]byte, 32),
+		Nonce: make([]byte, 24),
+	}
+	if _, err = io.ReadFull(rand.Reader, box.Salt); err != nil {
+		return err
+	}
+	if _, err = io.ReadFull(rand.Reader, box.Nonce); err != nil {
+		return err
+	}
+
+	key, err := deriveKey(password, box.Salt)
+	if err != nil {
+		return err
+	}
+
+	var nonce [24]byte
+	copy(nonce[:], box.Nonce)
+	box.Box = secretbox.Seal(nil, plain, &nonce, key)
+
+	data, err := json.Marshal(box)
+	if err != nil {
+		return err
+	}
+
+	tmp := File() + ".tmp"
+	if err = os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, File())
+}
+
+// Get returns a secret, or an empty string if we don't have it
+func (vault Vault) Get(section, name string) string {
+	if secrets, ok := vault[section]; ok {
+		return secrets[name]
+	}
+	return ""
+}
+
+func (vault Vault) Set(section, name, value string) {
+	if _, ok := vault[section]; !ok {
+		vault[section] = make(map[string]string)
+	}
+	vault[section][name] = value
+}
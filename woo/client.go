@@ -1,14 +1,15 @@
 package woo
 
 import (
-	"errors"
 	"io"
-	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/svanas/nefertiti/errors"
+	"github.com/svanas/nefertiti/ratelimit"
 )
 
 var (
@@ -43,7 +44,8 @@ func New(URL, apiKey, apiSecret string) *Client {
 		apiKey,
 		apiSecret,
 		&http.Client{
-			Timeout: 30 * time.Second,
+			Timeout:   30 * time.Second,
+			Transport: ratelimit.NewTransport("Woo", nil),
 		},
 	}
 }
@@ -55,19 +57,19 @@ func (client *Client) do(req *http.Request) ([]byte, error) {
 	}
 	defer resp.Body.Close()
 
-	body, err := ioutil.ReadAll(resp.Body)
+	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		if resp.StatusCode < 200 || resp.StatusCode >= 400 {
-			return nil, errors.New(resp.Status)
+			return nil, errors.NewStatusError(resp.StatusCode, resp.Status)
 		}
 		return nil, err
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
 		if err, msg := IsError(body); err {
-			return body, errors.New(msg)
+			return body, errors.NewStatusError(resp.StatusCode, msg)
 		}
-		return body, errors.New(resp.Status)
+		return body, errors.NewStatusError(resp.StatusCode, resp.Status)
 	}
 
 	return body, nil
@@ -132,6 +132,22 @@ func (order *Order) QuantityMinusFee() float64 {
 	return executed
 }
 
+func (client *Client) GetOrder(orderID int64) (*Order, error) {
+	var (
+		err  error
+		body []byte
+		out  Order
+	)
+	if body, err = client.get("/v1/order/"+strconv.FormatInt(orderID, 10), nil, true, 10); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(body, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
 type Orders struct {
 	Meta struct {
 		RecordsPerPage int64 `json:"records_per_page"`